@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+)
+
+func runArb(ctx context.Context, c clients, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: %s arb <list|resolve>", prog)
+	}
+	switch args[0] {
+	case "list":
+		return runArbList(ctx, c, args[1:])
+	case "resolve":
+		return runArbResolve(ctx, c, args[1:])
+	default:
+		return fmt.Errorf("unknown arb command %q", args[0])
+	}
+}
+
+func runArbList(ctx context.Context, c clients, args []string) error {
+	flags := flag.NewFlagSet("arb list", flag.ContinueOnError)
+	bot := flags.String("bot", "", "bot ID filter")
+	unsettled := flags.Bool("unsettled", false, "only open and hedge-needed trades")
+	limit := flags.Int("limit", 50, "maximum trades (at most 500)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *limit < 1 || *limit > 500 {
+		return fmt.Errorf("limit must be between 1 and 500")
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	resp, err := c.arbs.ListArbTrades(ctx, connect.NewRequest(&controlv1.ListArbTradesRequest{
+		BotId: *bot, UnsettledOnly: *unsettled, Limit: int32(*limit), //nolint:gosec // capped at 500
+	}))
+	if err != nil {
+		return err
+	}
+	for _, t := range resp.Msg.GetTrades() {
+		printArbTrade(os.Stdout, t)
+	}
+	return nil
+}
+
+// runArbResolve takes the note from the arguments after the trade ID, so
+// it needs no quoting.
+func runArbResolve(ctx context.Context, c clients, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: %s arb resolve <trade-id> [note...]", prog)
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	resp, err := c.arbs.ResolveArbHedge(ctx, connect.NewRequest(&controlv1.ResolveArbHedgeRequest{
+		TradeId: args[0], Note: strings.Join(args[1:], " "),
+	}))
+	if err != nil {
+		return err
+	}
+	printArbTrade(os.Stdout, resp.Msg.GetTrade())
+	return nil
+}
+
+// printArbTrade writes the trade's line and, while a hedge is needed, the
+// order that would even it out.
+func printArbTrade(w io.Writer, t *controlv1.ArbTrade) {
+	line := fmt.Sprintf("%s  %s  %s/%s %s  buy %s @%s  sell %s @%s  edge %s bps  %s", t.GetTradeId(), t.GetBotId(),
+		t.GetBase(), t.GetQuote(), t.GetQty(), t.GetBuyVenue(), t.GetBuyPrice(), t.GetSellVenue(), t.GetSellPrice(),
+		t.GetEdgeBps(), enumText(t.GetStatus().String(), "ARB_TRADE_STATUS_"))
+	if t.GetReason() != "" {
+		line += "  (" + t.GetReason() + ")"
+	}
+	fmt.Fprintln(w, line)
+	if t.GetStatus() == controlv1.ArbTradeStatus_ARB_TRADE_STATUS_HEDGE_NEEDED {
+		fmt.Fprintf(w, "  hedge: %s %s %s\n", enumText(t.GetHedgeSide().String(), "SIDE_"), t.GetHedgeQty(), t.GetBase())
+	}
+}
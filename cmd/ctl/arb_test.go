@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+)
+
+type fakeArbClient struct {
+	list    *controlv1.ListArbTradesRequest
+	resolve *controlv1.ResolveArbHedgeRequest
+}
+
+func (f *fakeArbClient) ListArbTrades(_ context.Context, req *connect.Request[controlv1.ListArbTradesRequest]) (*connect.Response[controlv1.ListArbTradesResponse], error) {
+	f.list = req.Msg
+	return connect.NewResponse(&controlv1.ListArbTradesResponse{}), nil
+}
+
+func (f *fakeArbClient) ResolveArbHedge(_ context.Context, req *connect.Request[controlv1.ResolveArbHedgeRequest]) (*connect.Response[controlv1.ResolveArbHedgeResponse], error) {
+	f.resolve = req.Msg
+	return connect.NewResponse(&controlv1.ResolveArbHedgeResponse{Trade: &controlv1.ArbTrade{TradeId: req.Msg.GetTradeId()}}), nil
+}
+
+func TestArbCommands(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+		verify  func(*testing.T, *fakeArbClient)
+	}{
+		{
+			name: "list sends its filters",
+			args: []string{"list", "--bot", "btc-arb", "--unsettled", "--limit", "5"},
+			verify: func(t *testing.T, fake *fakeArbClient) {
+				if fake.list.GetBotId() != "btc-arb" || !fake.list.GetUnsettledOnly() || fake.list.GetLimit() != 5 {
+					t.Fatalf("list request = %+v", fake.list)
+				}
+			},
+		},
+		{
+			name:    "list rejects a limit past 500",
+			args:    []string{"list", "--limit", "501"},
+			wantErr: true,
+			verify: func(t *testing.T, fake *fakeArbClient) {
+				if fake.list != nil {
+					t.Fatalf("list request = %+v, want none", fake.list)
+				}
+			},
+		},
+		{
+			name: "resolve joins the note",
+			args: []string{"resolve", "01J00000000000000000000001", "sold", "0.5", "on", "bybit"},
+			verify: func(t *testing.T, fake *fakeArbClient) {
+				if fake.resolve.GetTradeId() != "01J00000000000000000000001" || fake.resolve.GetNote() != "sold 0.5 on bybit" {
+					t.Fatalf("resolve request = %+v", fake.resolve)
+				}
+			},
+		},
+		{
+			name:    "resolve needs a trade ID",
+			args:    []string{"resolve"},
+			wantErr: true,
+			verify:  func(*testing.T, *fakeArbClient) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := &fakeArbClient{}
+			err := runArb(t.Context(), clients{arbs: fake}, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr = %v", err, tt.wantErr)
+			}
+			tt.verify(t, fake)
+		})
+	}
+}
+
+func TestPrintArbTrade(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	printArbTrade(&out, &controlv1.ArbTrade{
+		TradeId: "T", BotId: "btc-arb", Base: "BTC", Quote: "USDT", Qty: "0.5", BuyPrice: "100", BuyVenue: "bybit",
+		SellPrice: "101", SellVenue: "binance", EdgeBps: "79.8", Status: controlv1.ArbTradeStatus_ARB_TRADE_STATUS_HEDGE_NEEDED,
+		HedgeSide: controlv1.Side_SIDE_SELL, HedgeQty: "0.5", Reason: "bought 0.5 on bybit, sold 0 on binance",
+	})
+	want := "T  btc-arb  BTC/USDT 0.5  buy bybit @100  sell binance @101  edge 79.8 bps  hedge_needed  (bought 0.5 on bybit, sold 0 on binance)\n" +
+		"  hedge: sell 0.5 BTC\n"
+	if out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
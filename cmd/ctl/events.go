@@ -14,17 +14,27 @@ import (
 func runEvents(ctx context.Context, c clients, args []string) error {
 	flags := flag.NewFlagSet("events", flag.ExitOnError)
 	prefix := flags.String("prefix", "", "subject prefix filter (empty = all)")
+	resumeAfter := flags.Int64("resume-after", -1, "replay outbox events after this cursor first (-1 = live only)")
 	_ = flags.Parse(args)
 
-	stream, err := c.events.StreamEvents(ctx, connect.NewRequest(&controlv1.StreamEventsRequest{
-		SubjectPrefix: *prefix,
-	}))
+	req := &controlv1.StreamEventsRequest{SubjectPrefix: *prefix}
+	if *resumeAfter >= 0 {
+		req.ResumeAfter = resumeAfter
+	}
+	stream, err := c.events.StreamEvents(ctx, connect.NewRequest(req))
 	if err != nil {
 		return err
 	}
 	defer func() { _ = stream.Close() }()
 	for stream.Receive() {
-		line, err := protojson.Marshal(stream.Msg().GetEvent())
+		// Events print bare; a gap prints as {"gap": ...} so it cannot be
+		// mistaken for one.
+		var line []byte
+		if stream.Msg().GetGap() != nil {
+			line, err = protojson.Marshal(stream.Msg())
+		} else {
+			line, err = protojson.Marshal(stream.Msg().GetEvent())
+		}
 		if err != nil {
 			return err
 		}
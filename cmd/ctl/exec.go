@@ -0,0 +1,319 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/id"
+)
+
+func runExec(ctx context.Context, c clients, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: %s exec <twap|iceberg|vwap|pause|resume|cancel|get|list>", prog)
+	}
+	switch args[0] {
+	case "twap":
+		return runExecTWAP(ctx, c, args[1:])
+	case "iceberg":
+		return runExecIceberg(ctx, c, args[1:])
+	case "vwap":
+		return runExecVWAP(ctx, c, args[1:])
+	case "pause", "resume", "cancel", "get":
+		return runExecParent(ctx, c, args[0], args[1:])
+	case "list":
+		return runExecList(ctx, c, args[1:])
+	default:
+		return fmt.Errorf("unknown exec command %q", args[0])
+	}
+}
+
+// runExecTWAP picks the parent ID itself, so a placement that failed in
+// transit can be resumed by repeating it with -id.
+func runExecTWAP(ctx context.Context, c clients, args []string) error {
+	flags := flag.NewFlagSet("exec twap", flag.ContinueOnError)
+	venue := flags.String("venue", "", "venue")
+	base := flags.String("base", "", "base currency")
+	quote := flags.String("quote", "", "quote currency")
+	side := flags.String("side", "", "buy or sell")
+	qty := flags.String("qty", "", "total quantity")
+	duration := flags.Duration("duration", 0, "time to spread the quantity over, e.g. 30m")
+	slices := flags.Int("slices", 0, "number of slices (at most 1000)")
+	limit := flags.String("limit", "", "price cap for every slice; empty places market slices")
+	jitter := flags.Float64("jitter", 0, "randomizes each slice's size and wait by up to this fraction (0 to 0.5)")
+	parentID := flags.String("id", "", "parent ID, to resume a placement")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *slices < 1 || *slices > 1000 {
+		return fmt.Errorf("slices must be between 1 and 1000")
+	}
+	request := &controlv1.PlaceTWAPRequest{
+		Venue: *venue, Base: strings.ToUpper(*base), Quote: strings.ToUpper(*quote), Side: parseSide(*side), Qty: *qty,
+		Duration: durationpb.New(*duration), Slices: int32(*slices), LimitPrice: *limit, Jitter: *jitter, //nolint:gosec // capped at 1000
+		ParentId: *parentID,
+	}
+	if request.ParentId == "" {
+		request.ParentId = id.New()
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	resp, err := c.executions.PlaceTWAP(ctx, connect.NewRequest(request))
+	if err != nil {
+		if connect.CodeOf(err) == connect.CodeUnavailable || connect.CodeOf(err) == connect.CodeDeadlineExceeded {
+			return fmt.Errorf("%w; resume with -id %s", err, request.ParentId)
+		}
+		return err
+	}
+	printParent(os.Stdout, resp.Msg.GetParent())
+	return nil
+}
+
+// runExecIceberg picks the parent ID itself, as runExecTWAP does.
+func runExecIceberg(ctx context.Context, c clients, args []string) error {
+	flags := flag.NewFlagSet("exec iceberg", flag.ContinueOnError)
+	venue := flags.String("venue", "", "venue")
+	base := flags.String("base", "", "base currency")
+	quote := flags.String("quote", "", "quote currency")
+	side := flags.String("side", "", "buy or sell")
+	qty := flags.String("qty", "", "total quantity")
+	limit := flags.String("limit", "", "price cap for every child")
+	display := flags.String("display", "", "quantity shown at the venue at a time")
+	priceJitter := flags.String("price-jitter", "", "prices each refill up to this far inside the limit")
+	parentID := flags.String("id", "", "parent ID, to resume a placement")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *limit == "" || *display == "" {
+		return fmt.Errorf("an iceberg needs -limit and -display")
+	}
+	request := &controlv1.PlaceIcebergRequest{
+		Venue: *venue, Base: strings.ToUpper(*base), Quote: strings.ToUpper(*quote), Side: parseSide(*side), Qty: *qty,
+		LimitPrice: *limit, DisplayQty: *display, PriceJitter: *priceJitter, ParentId: *parentID,
+	}
+	if request.ParentId == "" {
+		request.ParentId = id.New()
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	resp, err := c.executions.PlaceIceberg(ctx, connect.NewRequest(request))
+	if err != nil {
+		if connect.CodeOf(err) == connect.CodeUnavailable || connect.CodeOf(err) == connect.CodeDeadlineExceeded {
+			return fmt.Errorf("%w; resume with -id %s", err, request.ParentId)
+		}
+		return err
+	}
+	printParent(os.Stdout, resp.Msg.GetParent())
+	return nil
+}
+
+// runExecVWAP picks the parent ID itself, as runExecTWAP does. Without
+// -profile the daemon reads the profile from its recorded trades.
+func runExecVWAP(ctx context.Context, c clients, args []string) error {
+	flags := flag.NewFlagSet("exec vwap", flag.ContinueOnError)
+	venue := flags.String("venue", "", "venue")
+	base := flags.String("base", "", "base currency")
+	quote := flags.String("quote", "", "quote currency")
+	side := flags.String("side", "", "buy or sell")
+	qty := flags.String("qty", "", "total quantity")
+	duration := flags.Duration("duration", 0, "time to spread the quantity over, e.g. 4h")
+	slices := flags.Int("slices", 0, "number of slices (at most 1000)")
+	limit := flags.String("limit", "", "price cap for every slice; empty places market slices")
+	profilePath := flags.String("profile", "", "file of HH:MM,volume lines, evenly spaced from 00:00 UTC")
+	parentID := flags.String("id", "", "parent ID, to resume a placement")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *slices < 1 || *slices > 1000 {
+		return fmt.Errorf("slices must be between 1 and 1000")
+	}
+	request := &controlv1.PlaceVWAPRequest{
+		Venue: *venue, Base: strings.ToUpper(*base), Quote: strings.ToUpper(*quote), Side: parseSide(*side), Qty: *qty,
+		Duration: durationpb.New(*duration), Slices: int32(*slices), LimitPrice: *limit, ParentId: *parentID, //nolint:gosec // capped at 1000
+	}
+	if *profilePath != "" {
+		f, err := os.Open(*profilePath)
+		if err != nil {
+			return err
+		}
+		request.Profile, err = readProfile(f)
+		f.Close() //nolint:errcheck,gosec // read-only
+		if err != nil {
+			return fmt.Errorf("%s: %w", *profilePath, err)
+		}
+	}
+	if request.ParentId == "" {
+		request.ParentId = id.New()
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	resp, err := c.executions.PlaceVWAP(ctx, connect.NewRequest(request))
+	if err != nil {
+		if connect.CodeOf(err) == connect.CodeUnavailable || connect.CodeOf(err) == connect.CodeDeadlineExceeded {
+			return fmt.Errorf("%w; resume with -id %s", err, request.ParentId)
+		}
+		return err
+	}
+	printParent(os.Stdout, resp.Msg.GetParent())
+	return nil
+}
+
+// readProfile reads a volume profile as HH:MM,volume lines, the first at
+// 00:00 and the rest evenly spaced through the UTC day. Blank lines and
+// lines starting with # are skipped. The volumes are left for the daemon
+// to validate.
+func readProfile(r io.Reader) ([]string, error) {
+	type bucket struct {
+		minute int
+		volume string
+	}
+	var buckets []bucket
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		at, volume, ok := strings.Cut(line, ",")
+		clock, err := time.Parse("15:04", strings.TrimSpace(at))
+		if !ok || err != nil {
+			return nil, fmt.Errorf("line %d: want HH:MM,volume", n)
+		}
+		buckets = append(buckets, bucket{minute: clock.Hour()*60 + clock.Minute(), volume: strings.TrimSpace(volume)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(buckets) == 0 || 1440%len(buckets) != 0 {
+		return nil, fmt.Errorf("%d buckets do not split the day into whole minutes", len(buckets))
+	}
+	width := 1440 / len(buckets)
+	profile := make([]string, 0, len(buckets))
+	for i, b := range buckets {
+		if b.minute != i*width {
+			return nil, fmt.Errorf("bucket %d starts at %02d:%02d, want %02d:%02d", i+1, b.minute/60, b.minute%60,
+				i*width/60, i*width%60)
+		}
+		profile = append(profile, b.volume)
+	}
+	return profile, nil
+}
+
+// runExecParent runs the commands that take only a parent ID.
+func runExecParent(ctx context.Context, c clients, command string, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: %s exec %s <parent-id>", prog, command)
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	var parent *controlv1.ParentOrder
+	switch command {
+	case "pause":
+		resp, err := c.executions.PauseParentOrder(ctx, connect.NewRequest(&controlv1.PauseParentOrderRequest{ParentId: args[0]}))
+		if err != nil {
+			return err
+		}
+		parent = resp.Msg.GetParent()
+	case "resume":
+		resp, err := c.executions.ResumeParentOrder(ctx, connect.NewRequest(&controlv1.ResumeParentOrderRequest{ParentId: args[0]}))
+		if err != nil {
+			return err
+		}
+		parent = resp.Msg.GetParent()
+	case "cancel":
+		resp, err := c.executions.CancelParentOrder(ctx, connect.NewRequest(&controlv1.CancelParentOrderRequest{ParentId: args[0]}))
+		if err != nil {
+			return err
+		}
+		parent = resp.Msg.GetParent()
+	default:
+		resp, err := c.executions.GetParentOrder(ctx, connect.NewRequest(&controlv1.GetParentOrderRequest{ParentId: args[0]}))
+		if err != nil {
+			return err
+		}
+		parent = resp.Msg.GetParent()
+	}
+	printParent(os.Stdout, parent)
+	return nil
+}
+
+func runExecList(ctx context.Context, c clients, args []string) error {
+	flags := flag.NewFlagSet("exec list", flag.ContinueOnError)
+	venue := flags.String("venue", "", "venue filter")
+	live := flags.Bool("live", false, "only running and paused parents")
+	limit := flags.Int("limit", 50, "maximum parents (at most 500)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *limit < 1 || *limit > 500 {
+		return fmt.Errorf("limit must be between 1 and 500")
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	resp, err := c.executions.ListParentOrders(ctx, connect.NewRequest(&controlv1.ListParentOrdersRequest{
+		Venue: *venue, LiveOnly: *live, Limit: int32(*limit), //nolint:gosec // capped at 500
+	}))
+	if err != nil {
+		return err
+	}
+	for _, p := range resp.Msg.GetParents() {
+		fmt.Println(parentLine(p))
+	}
+	return nil
+}
+
+// printParent writes the parent's line, its progress, then one line per
+// child.
+func printParent(w io.Writer, p *controlv1.ParentOrder) {
+	fmt.Fprintln(w, parentLine(p))
+	progress := fmt.Sprintf("  filled %s of %s", p.GetFilledQty(), p.GetQty())
+	if p.GetFilledQty() != "0" {
+		progress += " @ " + p.GetAvgFillPrice()
+	}
+	if p.GetAlgo() == controlv1.ExecutionAlgo_EXECUTION_ALGO_ICEBERG {
+		progress += fmt.Sprintf("  working %s  refills %d", p.GetWorkingQty(), p.GetSlicesSent())
+	} else {
+		progress += fmt.Sprintf("  working %s  slices %d/%d every %s", p.GetWorkingQty(), p.GetSlicesSent(), p.GetSlices(),
+			p.GetInterval().AsDuration())
+	}
+	if p.GetNextSliceAt() != nil {
+		progress += "  next " + p.GetNextSliceAt().AsTime().Local().Format(time.TimeOnly)
+	}
+	if p.GetBenchmarkPrice() != "" {
+		progress += fmt.Sprintf("  vwap %s  slippage %s bps", p.GetBenchmarkPrice(), p.GetSlippageBps())
+	}
+	fmt.Fprintln(w, progress)
+	for _, child := range p.GetChildren() {
+		fmt.Fprintf(w, "  %s  %s %s  %s filled %s\n", child.GetClientOrderId(), enumText(child.GetType().String(), "ORDER_TYPE_"),
+			child.GetQty(), orderStatusText(child.GetStatus()), child.GetFilledQty())
+	}
+}
+
+func parentLine(p *controlv1.ParentOrder) string {
+	terms := p.GetQty()
+	if p.GetLimitPrice() != "" {
+		terms += " limit " + p.GetLimitPrice()
+	}
+	if p.GetDisplayQty() != "" {
+		terms += " display " + p.GetDisplayQty()
+	}
+	if p.GetPriceJitter() != "" {
+		terms += " price-jitter " + p.GetPriceJitter()
+	}
+	line := fmt.Sprintf("%s  %s  %s  %s/%s  %s %s  %s", p.GetParentId(), enumText(p.GetAlgo().String(), "EXECUTION_ALGO_"),
+		p.GetVenue(), p.GetBase(), p.GetQuote(), enumText(p.GetSide().String(), "SIDE_"), terms,
+		enumText(p.GetStatus().String(), "PARENT_ORDER_STATUS_"))
+	if p.GetReason() != "" {
+		line += "  (" + p.GetReason() + ")"
+	}
+	return line
+}
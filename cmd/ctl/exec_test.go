@@ -0,0 +1,231 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+)
+
+type fakeExecutionClient struct {
+	twap    *controlv1.PlaceTWAPRequest
+	iceberg *controlv1.PlaceIcebergRequest
+	vwap    *controlv1.PlaceVWAPRequest
+	paused  string
+	list    *controlv1.ListParentOrdersRequest
+}
+
+func (f *fakeExecutionClient) PlaceTWAP(_ context.Context, req *connect.Request[controlv1.PlaceTWAPRequest]) (*connect.Response[controlv1.PlaceTWAPResponse], error) {
+	f.twap = req.Msg
+	return connect.NewResponse(&controlv1.PlaceTWAPResponse{Parent: &controlv1.ParentOrder{ParentId: req.Msg.GetParentId()}}), nil
+}
+
+func (f *fakeExecutionClient) PlaceIceberg(_ context.Context, req *connect.Request[controlv1.PlaceIcebergRequest]) (*connect.Response[controlv1.PlaceIcebergResponse], error) {
+	f.iceberg = req.Msg
+	return connect.NewResponse(&controlv1.PlaceIcebergResponse{Parent: &controlv1.ParentOrder{ParentId: req.Msg.GetParentId()}}), nil
+}
+
+func (f *fakeExecutionClient) PlaceVWAP(_ context.Context, req *connect.Request[controlv1.PlaceVWAPRequest]) (*connect.Response[controlv1.PlaceVWAPResponse], error) {
+	f.vwap = req.Msg
+	return connect.NewResponse(&controlv1.PlaceVWAPResponse{Parent: &controlv1.ParentOrder{ParentId: req.Msg.GetParentId()}}), nil
+}
+
+func (f *fakeExecutionClient) PauseParentOrder(_ context.Context, req *connect.Request[controlv1.PauseParentOrderRequest]) (*connect.Response[controlv1.PauseParentOrderResponse], error) {
+	f.paused = req.Msg.GetParentId()
+	return connect.NewResponse(&controlv1.PauseParentOrderResponse{}), nil
+}
+
+func (*fakeExecutionClient) ResumeParentOrder(context.Context, *connect.Request[controlv1.ResumeParentOrderRequest]) (*connect.Response[controlv1.ResumeParentOrderResponse], error) {
+	return connect.NewResponse(&controlv1.ResumeParentOrderResponse{}), nil
+}
+
+func (*fakeExecutionClient) CancelParentOrder(context.Context, *connect.Request[controlv1.CancelParentOrderRequest]) (*connect.Response[controlv1.CancelParentOrderResponse], error) {
+	return connect.NewResponse(&controlv1.CancelParentOrderResponse{}), nil
+}
+
+func (*fakeExecutionClient) GetParentOrder(context.Context, *connect.Request[controlv1.GetParentOrderRequest]) (*connect.Response[controlv1.GetParentOrderResponse], error) {
+	return connect.NewResponse(&controlv1.GetParentOrderResponse{}), nil
+}
+
+func (f *fakeExecutionClient) ListParentOrders(_ context.Context, req *connect.Request[controlv1.ListParentOrdersRequest]) (*connect.Response[controlv1.ListParentOrdersResponse], error) {
+	f.list = req.Msg
+	return connect.NewResponse(&controlv1.ListParentOrdersResponse{}), nil
+}
+
+func TestExecFlags(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+		verify  func(*testing.T, *fakeExecutionClient)
+	}{
+		{
+			name: "twap with a generated parent ID",
+			args: []string{"twap", "--venue", "bybit", "--base", "btc", "--quote", "usdt", "--side", "sell", "--qty", "2",
+				"--duration", "30m", "--slices", "6", "--limit", "100", "--jitter", "0.2"},
+			verify: func(t *testing.T, fake *fakeExecutionClient) {
+				p := fake.twap
+				if p.GetBase() != "BTC" || p.GetSide() != controlv1.Side_SIDE_SELL || p.GetDuration().AsDuration() != 30*time.Minute ||
+					p.GetSlices() != 6 || p.GetLimitPrice() != "100" || p.GetJitter() != 0.2 || len(p.GetParentId()) != 26 {
+					t.Fatalf("twap request = %+v", p)
+				}
+			},
+		},
+		{
+			name:    "twap rejects a missing slice count before calling the API",
+			args:    []string{"twap", "--qty", "1", "--duration", "1m"},
+			wantErr: true,
+			verify: func(t *testing.T, fake *fakeExecutionClient) {
+				if fake.twap != nil {
+					t.Fatalf("twap request = %+v, want none", fake.twap)
+				}
+			},
+		},
+		{
+			name: "iceberg with a price jitter",
+			args: []string{"iceberg", "--venue", "bybit", "--base", "btc", "--quote", "usdt", "--side", "buy", "--qty", "5",
+				"--limit", "100", "--display", "0.5", "--price-jitter", "0.2"},
+			verify: func(t *testing.T, fake *fakeExecutionClient) {
+				p := fake.iceberg
+				if p.GetLimitPrice() != "100" || p.GetDisplayQty() != "0.5" || p.GetPriceJitter() != "0.2" || len(p.GetParentId()) != 26 {
+					t.Fatalf("iceberg request = %+v", p)
+				}
+			},
+		},
+		{
+			name:    "iceberg needs a display size",
+			args:    []string{"iceberg", "--qty", "5", "--limit", "100"},
+			wantErr: true,
+			verify: func(t *testing.T, fake *fakeExecutionClient) {
+				if fake.iceberg != nil {
+					t.Fatalf("iceberg request = %+v, want none", fake.iceberg)
+				}
+			},
+		},
+		{
+			name: "vwap without a profile leaves it to the daemon",
+			args: []string{"vwap", "--venue", "bybit", "--base", "btc", "--quote", "usdt", "--side", "buy", "--qty", "3",
+				"--duration", "4h", "--slices", "8"},
+			verify: func(t *testing.T, fake *fakeExecutionClient) {
+				p := fake.vwap
+				if p.GetDuration().AsDuration() != 4*time.Hour || p.GetSlices() != 8 || len(p.GetProfile()) != 0 || len(p.GetParentId()) != 26 {
+					t.Fatalf("vwap request = %+v", p)
+				}
+			},
+		},
+		{
+			name:    "vwap with an unreadable profile file",
+			args:    []string{"vwap", "--qty", "3", "--duration", "4h", "--slices", "8", "--profile", "/nonexistent/profile.csv"},
+			wantErr: true,
+			verify: func(t *testing.T, fake *fakeExecutionClient) {
+				if fake.vwap != nil {
+					t.Fatalf("vwap request = %+v, want none", fake.vwap)
+				}
+			},
+		},
+		{
+			name: "pause sends the parent ID",
+			args: []string{"pause", "01J00000000000000000000001"},
+			verify: func(t *testing.T, fake *fakeExecutionClient) {
+				if fake.paused != "01J00000000000000000000001" {
+					t.Fatalf("paused = %q", fake.paused)
+				}
+			},
+		},
+		{
+			name:    "resume needs a parent ID",
+			args:    []string{"resume"},
+			wantErr: true,
+			verify:  func(*testing.T, *fakeExecutionClient) {},
+		},
+		{
+			name: "list sends its filters",
+			args: []string{"list", "--venue", "bybit", "--live", "--limit", "5"},
+			verify: func(t *testing.T, fake *fakeExecutionClient) {
+				if fake.list.GetVenue() != "bybit" || !fake.list.GetLiveOnly() || fake.list.GetLimit() != 5 {
+					t.Fatalf("list request = %+v", fake.list)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := &fakeExecutionClient{}
+			err := runExec(t.Context(), clients{executions: fake}, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr = %v", err, tt.wantErr)
+			}
+			tt.verify(t, fake)
+		})
+	}
+}
+
+func TestReadProfile(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr string
+	}{
+		{name: "hourly with comments", input: "# hour,volume\n" + hourly(), want: 24},
+		{name: "a bucket count not dividing the day", input: "00:00,1\n04:00,1\n08:00,1\n12:00,1\n16:00,1\n20:00,1\n22:00,1\n", wantErr: "do not split"},
+		{name: "out of step", input: strings.Replace(hourly(), "05:00", "05:30", 1), wantErr: "bucket 6 starts at 05:30, want 05:00"},
+		{name: "not a time", input: "midnight,1\n", wantErr: "line 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			profile, err := readProfile(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || len(profile) != tt.want || profile[1] != "2" {
+				t.Fatalf("profile = %v, %v", profile, err)
+			}
+		})
+	}
+}
+
+// hourly is a profile of the day's 24 hours, hour i holding i+1.
+func hourly() string {
+	var b strings.Builder
+	for i := range 24 {
+		fmt.Fprintf(&b, "%02d:00,%d\n", i, i+1)
+	}
+	return b.String()
+}
+
+func TestPrintParent(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	printParent(&out, &controlv1.ParentOrder{
+		ParentId: "P", Algo: controlv1.ExecutionAlgo_EXECUTION_ALGO_TWAP, Status: controlv1.ParentOrderStatus_PARENT_ORDER_STATUS_PAUSED,
+		Venue: "bybit", Base: "BTC", Quote: "USDT", Side: controlv1.Side_SIDE_BUY, Qty: "4", LimitPrice: "100",
+		Slices: 4, SlicesSent: 2, Interval: durationpb.New(15 * time.Minute), Reason: "paused by request",
+		FilledQty: "1.5", AvgFillPrice: "99.5", WorkingQty: "0.5",
+		Children: []*controlv1.Order{
+			{ClientOrderId: "C1", Type: controlv1.OrderType_ORDER_TYPE_LIMIT, Qty: "1", Status: controlv1.OrderStatus_ORDER_STATUS_FILLED, FilledQty: "1"},
+			{ClientOrderId: "C2", Type: controlv1.OrderType_ORDER_TYPE_LIMIT, Qty: "1", Status: controlv1.OrderStatus_ORDER_STATUS_PARTIALLY_FILLED, FilledQty: "0.5"},
+		},
+	})
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 || lines[0] != "P  twap  bybit  BTC/USDT  buy 4 limit 100  paused  (paused by request)" {
+		t.Fatalf("output = %q", out.String())
+	}
+	if lines[1] != "  filled 1.5 of 4 @ 99.5  working 0.5  slices 2/4 every 15m0s" || !strings.Contains(lines[3], "C2  limit 1  partially_filled filled 0.5") {
+		t.Fatalf("progress and children = %q", lines[1:])
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"connectrpc.com/connect"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+)
+
+func runGrid(ctx context.Context, c clients, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: %s grid <list|get|pause|resume|stop>", prog)
+	}
+	switch args[0] {
+	case "list":
+		return runGridList(ctx, c, args[1:])
+	case "get", "pause", "resume", "stop":
+		return runGridBot(ctx, c, args[0], args[1:])
+	default:
+		return fmt.Errorf("unknown grid command %q", args[0])
+	}
+}
+
+func runGridList(ctx context.Context, c clients, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: %s grid list", prog)
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	resp, err := c.grids.ListGridBots(ctx, connect.NewRequest(&controlv1.ListGridBotsRequest{}))
+	if err != nil {
+		return err
+	}
+	for _, b := range resp.Msg.GetBots() {
+		printGridBot(os.Stdout, b)
+	}
+	return nil
+}
+
+// runGridBot runs the commands that take only a bot ID.
+func runGridBot(ctx context.Context, c clients, command string, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: %s grid %s <bot-id>", prog, command)
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	var bot *controlv1.GridBot
+	switch command {
+	case "pause":
+		resp, err := c.grids.PauseGridBot(ctx, connect.NewRequest(&controlv1.PauseGridBotRequest{BotId: args[0]}))
+		if err != nil {
+			return err
+		}
+		bot = resp.Msg.GetBot()
+	case "resume":
+		resp, err := c.grids.ResumeGridBot(ctx, connect.NewRequest(&controlv1.ResumeGridBotRequest{BotId: args[0]}))
+		if err != nil {
+			return err
+		}
+		bot = resp.Msg.GetBot()
+	case "stop":
+		resp, err := c.grids.StopGridBot(ctx, connect.NewRequest(&controlv1.StopGridBotRequest{BotId: args[0]}))
+		if err != nil {
+			return err
+		}
+		bot = resp.Msg.GetBot()
+	default:
+		resp, err := c.grids.GetGridBot(ctx, connect.NewRequest(&controlv1.GetGridBotRequest{BotId: args[0]}))
+		if err != nil {
+			return err
+		}
+		bot = resp.Msg.GetBot()
+	}
+	printGridBot(os.Stdout, bot)
+	return nil
+}
+
+func printGridBot(w io.Writer, b *controlv1.GridBot) {
+	fmt.Fprintf(w, "%s  %s  %d open\n", b.GetBotId(), enumText(b.GetState().String(), "GRID_BOT_STATE_"), b.GetOpenOrders())
+}
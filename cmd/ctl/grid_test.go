@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+)
+
+type fakeGridClient struct {
+	calls []string
+}
+
+func (f *fakeGridClient) ListGridBots(context.Context, *connect.Request[controlv1.ListGridBotsRequest]) (*connect.Response[controlv1.ListGridBotsResponse], error) {
+	f.calls = append(f.calls, "list")
+	return connect.NewResponse(&controlv1.ListGridBotsResponse{}), nil
+}
+
+func (f *fakeGridClient) GetGridBot(_ context.Context, req *connect.Request[controlv1.GetGridBotRequest]) (*connect.Response[controlv1.GetGridBotResponse], error) {
+	f.calls = append(f.calls, "get "+req.Msg.GetBotId())
+	return connect.NewResponse(&controlv1.GetGridBotResponse{Bot: &controlv1.GridBot{BotId: req.Msg.GetBotId()}}), nil
+}
+
+func (f *fakeGridClient) PauseGridBot(_ context.Context, req *connect.Request[controlv1.PauseGridBotRequest]) (*connect.Response[controlv1.PauseGridBotResponse], error) {
+	f.calls = append(f.calls, "pause "+req.Msg.GetBotId())
+	return connect.NewResponse(&controlv1.PauseGridBotResponse{Bot: &controlv1.GridBot{BotId: req.Msg.GetBotId()}}), nil
+}
+
+func (f *fakeGridClient) ResumeGridBot(_ context.Context, req *connect.Request[controlv1.ResumeGridBotRequest]) (*connect.Response[controlv1.ResumeGridBotResponse], error) {
+	f.calls = append(f.calls, "resume "+req.Msg.GetBotId())
+	return connect.NewResponse(&controlv1.ResumeGridBotResponse{Bot: &controlv1.GridBot{BotId: req.Msg.GetBotId()}}), nil
+}
+
+func (f *fakeGridClient) StopGridBot(_ context.Context, req *connect.Request[controlv1.StopGridBotRequest]) (*connect.Response[controlv1.StopGridBotResponse], error) {
+	f.calls = append(f.calls, "stop "+req.Msg.GetBotId())
+	return connect.NewResponse(&controlv1.StopGridBotResponse{Bot: &controlv1.GridBot{BotId: req.Msg.GetBotId()}}), nil
+}
+
+func TestGridCommands(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		wantCall string
+	}{
+		{name: "list", args: []string{"list"}, wantCall: "list"},
+		{name: "get", args: []string{"get", "grid-1"}, wantCall: "get grid-1"},
+		{name: "pause", args: []string{"pause", "grid-1"}, wantCall: "pause grid-1"},
+		{name: "resume", args: []string{"resume", "grid-1"}, wantCall: "resume grid-1"},
+		{name: "stop", args: []string{"stop", "grid-1"}, wantCall: "stop grid-1"},
+		{name: "stop needs a bot ID", args: []string{"stop"}, wantErr: true},
+		{name: "unknown command", args: []string{"restart", "grid-1"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := &fakeGridClient{}
+			err := runGrid(t.Context(), clients{grids: fake}, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr = %v", err, tt.wantErr)
+			}
+			var got string
+			if len(fake.calls) > 0 {
+				got = fake.calls[0]
+			}
+			if got != tt.wantCall || len(fake.calls) > 1 {
+				t.Fatalf("calls = %q, want %q", fake.calls, tt.wantCall)
+			}
+		})
+	}
+}
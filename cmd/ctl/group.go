@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/id"
+)
+
+func runGroup(ctx context.Context, c clients, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: %s group <place|cancel|get|list>", prog)
+	}
+	switch args[0] {
+	case "place":
+		return runGroupPlace(ctx, c, args[1:])
+	case "cancel":
+		return runGroupCancel(ctx, c, args[1:])
+	case "get":
+		return runGroupGet(ctx, c, args[1:])
+	case "list":
+		return runGroupList(ctx, c, args[1:])
+	default:
+		return fmt.Errorf("unknown group command %q", args[0])
+	}
+}
+
+// runGroupPlace picks the group ID itself, so a placement that failed in
+// transit can be resumed by repeating it with -id.
+func runGroupPlace(ctx context.Context, c clients, args []string) error {
+	flags := flag.NewFlagSet("group place", flag.ContinueOnError)
+	kind := flags.String("kind", "", "oco or bracket")
+	venue := flags.String("venue", "", "venue")
+	base := flags.String("base", "", "base currency")
+	quote := flags.String("quote", "", "quote currency")
+	side := flags.String("side", "", "buy or sell: the entry's side for a bracket, the exits' for an oco")
+	qty := flags.String("qty", "", "quantity")
+	entry := flags.String("entry", "", "bracket entry type: limit or market")
+	entryPrice := flags.String("entry-price", "", "bracket limit entry price")
+	takeProfit := flags.String("tp", "", "take-profit limit price")
+	stopTrigger := flags.String("sl-trigger", "", "stop-loss trigger price")
+	stopPrice := flags.String("sl-price", "", "stop-loss limit price; empty places a stop-market")
+	groupID := flags.String("id", "", "group ID, to resume a placement")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	request := &controlv1.PlaceOrderGroupRequest{
+		Kind: parseGroupKind(*kind), Venue: *venue, Base: strings.ToUpper(*base), Quote: strings.ToUpper(*quote),
+		Side: parseSide(*side), Qty: *qty, EntryPrice: *entryPrice,
+		TakeProfitPrice: *takeProfit, StopLossTriggerPrice: *stopTrigger, StopLossPrice: *stopPrice, GroupId: *groupID,
+	}
+	if request.Kind == controlv1.OrderGroupKind_ORDER_GROUP_KIND_UNSPECIFIED {
+		return fmt.Errorf("invalid group kind %q: want oco or bracket", *kind)
+	}
+	if *entry != "" {
+		request.EntryType = parseOrderType(*entry)
+	}
+	if request.GroupId == "" {
+		request.GroupId = id.New()
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	resp, err := c.groups.PlaceOrderGroup(ctx, connect.NewRequest(request))
+	if err != nil {
+		if connect.CodeOf(err) == connect.CodeUnavailable || connect.CodeOf(err) == connect.CodeDeadlineExceeded {
+			return fmt.Errorf("%w; resume with -id %s", err, request.GroupId)
+		}
+		return err
+	}
+	printGroup(os.Stdout, resp.Msg.GetGroup())
+	if resp.Msg.GetSubmitUnsettled() {
+		fmt.Println("WARNING: venue submission is unsettled; reconciliation will determine the final state")
+	}
+	return nil
+}
+
+func runGroupCancel(ctx context.Context, c clients, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: %s group cancel <group-id>", prog)
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	resp, err := c.groups.CancelOrderGroup(ctx, connect.NewRequest(&controlv1.CancelOrderGroupRequest{GroupId: args[0]}))
+	if err != nil {
+		return err
+	}
+	printGroup(os.Stdout, resp.Msg.GetGroup())
+	return nil
+}
+
+func runGroupGet(ctx context.Context, c clients, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: %s group get <group-id>", prog)
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	resp, err := c.groups.GetOrderGroup(ctx, connect.NewRequest(&controlv1.GetOrderGroupRequest{GroupId: args[0]}))
+	if err != nil {
+		return err
+	}
+	printGroup(os.Stdout, resp.Msg.GetGroup())
+	return nil
+}
+
+func runGroupList(ctx context.Context, c clients, args []string) error {
+	flags := flag.NewFlagSet("group list", flag.ContinueOnError)
+	venue := flags.String("venue", "", "venue filter")
+	open := flags.Bool("open", false, "only pending and active groups")
+	limit := flags.Int("limit", 50, "maximum groups (at most 500)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *limit < 1 || *limit > 500 {
+		return fmt.Errorf("limit must be between 1 and 500")
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	resp, err := c.groups.ListOrderGroups(ctx, connect.NewRequest(&controlv1.ListOrderGroupsRequest{
+		Venue: *venue, OpenOnly: *open, Limit: int32(*limit), //nolint:gosec // capped at 500
+	}))
+	if err != nil {
+		return err
+	}
+	for _, g := range resp.Msg.GetGroups() {
+		fmt.Println(groupLine(g))
+	}
+	return nil
+}
+
+// printGroup writes the group's line, then one line per leg with its
+// order's status once the leg is placed.
+func printGroup(w io.Writer, g *controlv1.OrderGroup) {
+	fmt.Fprintln(w, groupLine(g))
+	for _, leg := range g.GetLegs() {
+		terms := leg.GetQty()
+		if leg.GetType() != controlv1.OrderType_ORDER_TYPE_MARKET && leg.GetType() != controlv1.OrderType_ORDER_TYPE_STOP_MARKET {
+			terms += " @ " + leg.GetPrice()
+		}
+		if leg.GetTriggerPrice() != "" {
+			terms += "  trigger " + leg.GetTriggerPrice()
+		}
+		status := "not placed"
+		if leg.GetStatus() != controlv1.OrderStatus_ORDER_STATUS_UNSPECIFIED {
+			status = orderStatusText(leg.GetStatus()) + " filled " + leg.GetFilledQty()
+		}
+		fmt.Fprintf(w, "  %-11s  %s  %s %s %s  %s\n", enumText(leg.GetRole().String(), "ORDER_GROUP_ROLE_"),
+			leg.GetClientOrderId(), enumText(leg.GetSide().String(), "SIDE_"), enumText(leg.GetType().String(), "ORDER_TYPE_"),
+			terms, status)
+	}
+}
+
+func groupLine(g *controlv1.OrderGroup) string {
+	line := fmt.Sprintf("%s  %s  %s  %s/%s  %s", g.GetGroupId(), enumText(g.GetKind().String(), "ORDER_GROUP_KIND_"),
+		g.GetVenue(), g.GetBase(), g.GetQuote(), enumText(g.GetStatus().String(), "ORDER_GROUP_STATUS_"))
+	if g.GetReason() != "" {
+		line += "  (" + g.GetReason() + ")"
+	}
+	return line
+}
+
+func parseGroupKind(value string) controlv1.OrderGroupKind {
+	return controlv1.OrderGroupKind(controlv1.OrderGroupKind_value["ORDER_GROUP_KIND_"+strings.ToUpper(value)])
+}
+
+func enumText(name, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(name, prefix))
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+)
+
+type fakeGroupClient struct {
+	place *controlv1.PlaceOrderGroupRequest
+	list  *controlv1.ListOrderGroupsRequest
+}
+
+func (f *fakeGroupClient) PlaceOrderGroup(_ context.Context, req *connect.Request[controlv1.PlaceOrderGroupRequest]) (*connect.Response[controlv1.PlaceOrderGroupResponse], error) {
+	f.place = req.Msg
+	return connect.NewResponse(&controlv1.PlaceOrderGroupResponse{Group: &controlv1.OrderGroup{GroupId: req.Msg.GetGroupId()}}), nil
+}
+
+func (*fakeGroupClient) CancelOrderGroup(context.Context, *connect.Request[controlv1.CancelOrderGroupRequest]) (*connect.Response[controlv1.CancelOrderGroupResponse], error) {
+	return connect.NewResponse(&controlv1.CancelOrderGroupResponse{}), nil
+}
+
+func (*fakeGroupClient) GetOrderGroup(context.Context, *connect.Request[controlv1.GetOrderGroupRequest]) (*connect.Response[controlv1.GetOrderGroupResponse], error) {
+	return connect.NewResponse(&controlv1.GetOrderGroupResponse{}), nil
+}
+
+func (f *fakeGroupClient) ListOrderGroups(_ context.Context, req *connect.Request[controlv1.ListOrderGroupsRequest]) (*connect.Response[controlv1.ListOrderGroupsResponse], error) {
+	f.list = req.Msg
+	return connect.NewResponse(&controlv1.ListOrderGroupsResponse{}), nil
+}
+
+func TestGroupFlags(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		run     func(context.Context, clients, []string) error
+		args    []string
+		wantErr bool
+		verify  func(*testing.T, *fakeGroupClient)
+	}{
+		{
+			name: "place a bracket with a generated group ID",
+			run:  runGroupPlace,
+			args: []string{"--kind", "bracket", "--venue", "bybit", "--base", "btc", "--quote", "usdt", "--side", "buy", "--qty", "1",
+				"--entry", "limit", "--entry-price", "100", "--tp", "110", "--sl-trigger", "95"},
+			verify: func(t *testing.T, fake *fakeGroupClient) {
+				p := fake.place
+				if p.GetKind() != controlv1.OrderGroupKind_ORDER_GROUP_KIND_BRACKET || p.GetBase() != "BTC" ||
+					p.GetEntryType() != controlv1.OrderType_ORDER_TYPE_LIMIT || p.GetStopLossTriggerPrice() != "95" || len(p.GetGroupId()) != 26 {
+					t.Fatalf("place request = %+v", p)
+				}
+			},
+		},
+		{
+			name: "place an oco under the given group ID",
+			run:  runGroupPlace,
+			args: []string{"--kind", "OCO", "--side", "sell", "--qty", "1", "--tp", "110", "--sl-trigger", "95", "--sl-price", "94",
+				"--id", "01J00000000000000000000001"},
+			verify: func(t *testing.T, fake *fakeGroupClient) {
+				p := fake.place
+				if p.GetKind() != controlv1.OrderGroupKind_ORDER_GROUP_KIND_OCO || p.GetEntryType() != controlv1.OrderType_ORDER_TYPE_UNSPECIFIED ||
+					p.GetStopLossPrice() != "94" || p.GetGroupId() != "01J00000000000000000000001" {
+					t.Fatalf("place request = %+v", p)
+				}
+			},
+		},
+		{
+			name:    "place rejects an unknown kind before calling the API",
+			run:     runGroupPlace,
+			args:    []string{"--kind", "trailing"},
+			wantErr: true,
+			verify: func(t *testing.T, fake *fakeGroupClient) {
+				if fake.place != nil {
+					t.Fatalf("place request = %+v, want none", fake.place)
+				}
+			},
+		},
+		{
+			name: "list sends its filters",
+			run:  runGroupList,
+			args: []string{"--venue", "bybit", "--open", "--limit", "5"},
+			verify: func(t *testing.T, fake *fakeGroupClient) {
+				if fake.list.GetVenue() != "bybit" || !fake.list.GetOpenOnly() || fake.list.GetLimit() != 5 {
+					t.Fatalf("list request = %+v", fake.list)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := &fakeGroupClient{}
+			err := tt.run(t.Context(), clients{groups: fake}, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr = %v", err, tt.wantErr)
+			}
+			tt.verify(t, fake)
+		})
+	}
+}
+
+func TestPrintGroup(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	printGroup(&out, &controlv1.OrderGroup{
+		GroupId: "G", Kind: controlv1.OrderGroupKind_ORDER_GROUP_KIND_BRACKET, Status: controlv1.OrderGroupStatus_ORDER_GROUP_STATUS_PENDING,
+		Venue: "bybit", Base: "BTC", Quote: "USDT",
+		Legs: []*controlv1.OrderGroupLeg{
+			{Role: controlv1.OrderGroupRole_ORDER_GROUP_ROLE_ENTRY, ClientOrderId: "E", Side: controlv1.Side_SIDE_BUY,
+				Type: controlv1.OrderType_ORDER_TYPE_LIMIT, Price: "100", Qty: "1", Status: controlv1.OrderStatus_ORDER_STATUS_OPEN, FilledQty: "0"},
+			{Role: controlv1.OrderGroupRole_ORDER_GROUP_ROLE_STOP_LOSS, ClientOrderId: "SL", Side: controlv1.Side_SIDE_SELL,
+				Type: controlv1.OrderType_ORDER_TYPE_STOP_MARKET, Price: "0", Qty: "1", TriggerPrice: "95"},
+		},
+	})
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 || lines[0] != "G  bracket  bybit  BTC/USDT  pending" {
+		t.Fatalf("output = %q", out.String())
+	}
+	if !strings.Contains(lines[1], "buy limit 1 @ 100  open filled 0") || !strings.Contains(lines[2], "sell stop_market 1  trigger 95  not placed") {
+		t.Fatalf("legs = %q", lines[1:])
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+)
+
+func runInstruments(ctx context.Context, c clients, args []string) error {
+	flags := flag.NewFlagSet("instruments", flag.ContinueOnError)
+	venue := flags.String("venue", "", "venue filter")
+	typ := flags.String("type", "", "instrument type filter, e.g. spot")
+	base := flags.String("base", "", "base currency filter")
+	quote := flags.String("quote", "", "quote currency filter")
+	asJSON := flags.Bool("json", false, "print JSON instead of a table")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	var instruments []*controlv1.Instrument
+	var msg proto.Message
+	switch flags.NArg() {
+	case 0:
+		resp, err := c.instruments.ListInstruments(ctx, connect.NewRequest(&controlv1.ListInstrumentsRequest{
+			Venue: *venue, Type: *typ, Base: strings.ToUpper(*base), Quote: strings.ToUpper(*quote),
+		}))
+		if err != nil {
+			return err
+		}
+		instruments, msg = resp.Msg.GetInstruments(), resp.Msg
+	case 2:
+		pairBase, pairQuote, ok := strings.Cut(flags.Arg(1), "/")
+		if !ok {
+			return fmt.Errorf("pair %q: want BASE/QUOTE", flags.Arg(1))
+		}
+		resp, err := c.instruments.GetInstrument(ctx, connect.NewRequest(&controlv1.GetInstrumentRequest{
+			Venue: flags.Arg(0), Type: *typ, Base: strings.ToUpper(pairBase), Quote: strings.ToUpper(pairQuote),
+		}))
+		if err != nil {
+			return err
+		}
+		instruments, msg = []*controlv1.Instrument{resp.Msg.GetInstrument()}, resp.Msg.GetInstrument()
+	default:
+		return fmt.Errorf("usage: %s instruments [-venue v] [-type t] [-base b] [-quote q] [-json] [venue BASE/QUOTE]", prog)
+	}
+
+	if *asJSON {
+		out, err := protojson.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+	return printInstruments(os.Stdout, instruments)
+}
+
+func printInstruments(w io.Writer, instruments []*controlv1.Instrument) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VENUE\tTYPE\tPAIR\tSYMBOL\tSTATUS\tTICK\tSTEP\tMIN QTY\tMIN NOTIONAL")
+	for _, i := range instruments {
+		fmt.Fprintf(tw, "%s\t%s\t%s/%s\t%s\t%s\t%s\t%s\t%s\t%s\n", i.GetVenue(), i.GetType(), i.GetBase(), i.GetQuote(),
+			i.GetVenueSymbol(), instrumentStatusText(i.GetStatus()),
+			i.GetPriceIncrement(), i.GetQtyIncrement(), i.GetMinQty(), i.GetMinNotional())
+	}
+	return tw.Flush()
+}
+
+func instrumentStatusText(status controlv1.InstrumentStatus) string {
+	return strings.ToLower(strings.TrimPrefix(status.String(), "INSTRUMENT_STATUS_"))
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+)
+
+type fakeInstrumentClient struct {
+	list *controlv1.ListInstrumentsRequest
+	get  *controlv1.GetInstrumentRequest
+}
+
+func (f *fakeInstrumentClient) ListInstruments(_ context.Context, req *connect.Request[controlv1.ListInstrumentsRequest]) (*connect.Response[controlv1.ListInstrumentsResponse], error) {
+	f.list = req.Msg
+	return connect.NewResponse(&controlv1.ListInstrumentsResponse{}), nil
+}
+
+func (f *fakeInstrumentClient) GetInstrument(_ context.Context, req *connect.Request[controlv1.GetInstrumentRequest]) (*connect.Response[controlv1.GetInstrumentResponse], error) {
+	f.get = req.Msg
+	return connect.NewResponse(&controlv1.GetInstrumentResponse{Instrument: &controlv1.Instrument{}}), nil
+}
+
+func TestInstrumentsArgs(t *testing.T) {
+	t.Parallel()
+	fake := &fakeInstrumentClient{}
+	c := clients{instruments: fake}
+	if err := runInstruments(t.Context(), c, []string{"-venue", "bybit", "-quote", "usdt", "-json"}); err != nil {
+		t.Fatal(err)
+	}
+	if fake.list.GetVenue() != "bybit" || fake.list.GetQuote() != "USDT" || fake.get != nil {
+		t.Fatalf("list request = %+v", fake.list)
+	}
+	if err := runInstruments(t.Context(), c, []string{"-json", "bybit", "btc/usdt"}); err != nil {
+		t.Fatal(err)
+	}
+	if fake.get.GetBase() != "BTC" || fake.get.GetQuote() != "USDT" {
+		t.Fatalf("get request = %+v", fake.get)
+	}
+	if err := runInstruments(t.Context(), c, []string{"bybit", "BTCUSDT"}); err == nil {
+		t.Fatal("a pair without '/' was accepted")
+	}
+}
+
+func TestPrintInstruments(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	err := printInstruments(&out, []*controlv1.Instrument{{
+		Venue: "bybit", Type: "spot", Base: "BTC", Quote: "USDT", VenueSymbol: "BTCUSDT",
+		Status:         controlv1.InstrumentStatus_INSTRUMENT_STATUS_HALTED,
+		PriceIncrement: "0.1", QtyIncrement: "0.000001", MinQty: "0.000048", MinNotional: "5",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "VENUE") ||
+		strings.Join(strings.Fields(lines[1]), " ") != "bybit spot BTC/USDT BTCUSDT halted 0.1 0.000001 0.000048 5" {
+		t.Fatalf("table =\n%s", out.String())
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+)
+
+func runKill(ctx context.Context, c clients, args []string) error {
+	flags := flag.NewFlagSet("kill", flag.ContinueOnError)
+	reason := flags.String("reason", "", "why trading is halted, kept with the switch")
+	release := flags.Bool("release", false, "release the switch instead of engaging it")
+	status := flags.Bool("status", false, "list the engaged switches")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 || (*release && *status) {
+		return fmt.Errorf("usage: %s kill [-reason r] [venue] | -release [venue] | -status", prog)
+	}
+	venue := flags.Arg(0)
+	switch {
+	case *status:
+		return runKillStatus(ctx, c)
+	case *release:
+		ctx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+		if _, err := c.kill.ReleaseKillSwitch(ctx, connect.NewRequest(&controlv1.ReleaseKillSwitchRequest{Venue: venue})); err != nil {
+			return err
+		}
+		fmt.Printf("released  %s\n", killScope(venue))
+		return nil
+	}
+
+	// The daemon bounds the kill by its settle timeout; this only stops a
+	// hung connection from holding the terminal forever.
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+	stream, err := c.kill.Kill(ctx, connect.NewRequest(&controlv1.KillRequest{Venue: venue, Reason: *reason}))
+	if err != nil {
+		return err
+	}
+	defer stream.Close() //nolint:errcheck // closing a finished stream
+	unsettled := 0
+	for stream.Receive() {
+		p := stream.Msg()
+		if p.GetKind() == controlv1.KillProgressKind_KILL_PROGRESS_KIND_UNSETTLED {
+			unsettled++
+		}
+		fmt.Println(killProgressLine(p))
+	}
+	if err := stream.Err(); err != nil {
+		return err
+	}
+	if unsettled > 0 {
+		return fmt.Errorf("%d orders still active; the switch stays engaged and reconciliation keeps converging them", unsettled)
+	}
+	return nil
+}
+
+func runKillStatus(ctx context.Context, c clients) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	resp, err := c.kill.ListKillSwitches(ctx, connect.NewRequest(&controlv1.ListKillSwitchesRequest{}))
+	if err != nil {
+		return err
+	}
+	if len(resp.Msg.GetKillSwitches()) == 0 {
+		fmt.Println("no kill switch engaged")
+	}
+	for _, ks := range resp.Msg.GetKillSwitches() {
+		fmt.Printf("engaged  %s  since %s  %s\n", killScope(ks.GetVenue()),
+			ks.GetEngagedAt().AsTime().Local().Format(time.RFC3339), ks.GetReason())
+	}
+	return nil
+}
+
+func killProgressLine(p *controlv1.KillProgress) string {
+	kind := strings.ToLower(strings.TrimPrefix(p.GetKind().String(), "KILL_PROGRESS_KIND_"))
+	if p.GetKind() == controlv1.KillProgressKind_KILL_PROGRESS_KIND_ENGAGED {
+		return fmt.Sprintf("%s  %s  %d active orders", kind, killScope(p.GetKillSwitch().GetVenue()), p.GetActiveOrders())
+	}
+	line := fmt.Sprintf("%s  %s  %s  %s", kind, p.GetClientOrderId(), p.GetVenue(), orderStatusText(p.GetStatus()))
+	if p.GetError() != "" {
+		line += "  " + p.GetError()
+	}
+	return line
+}
+
+func killScope(venue string) string {
+	if venue == "" {
+		return "all venues"
+	}
+	return venue
+}
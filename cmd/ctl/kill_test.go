@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+)
+
+func TestKillProgressLine(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		progress *controlv1.KillProgress
+		want     string
+	}{
+		{
+			&controlv1.KillProgress{Kind: controlv1.KillProgressKind_KILL_PROGRESS_KIND_ENGAGED, KillSwitch: &controlv1.KillSwitch{}, ActiveOrders: 2},
+			"engaged  all venues  2 active orders",
+		},
+		{
+			&controlv1.KillProgress{
+				Kind: controlv1.KillProgressKind_KILL_PROGRESS_KIND_CANCEL_FAILED, ClientOrderId: "01J00000000000000000000001",
+				Venue: "bybit", Status: controlv1.OrderStatus_ORDER_STATUS_OPEN, Error: "unavailable: venue unavailable",
+			},
+			"cancel_failed  01J00000000000000000000001  bybit  open  unavailable: venue unavailable",
+		},
+	}
+	for _, tt := range tests {
+		if got := killProgressLine(tt.progress); got != tt.want {
+			t.Errorf("line = %q, want %q", got, tt.want)
+		}
+	}
+}
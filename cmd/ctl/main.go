@@ -16,29 +16,70 @@ import (
 	"github.com/romanornr/delta-works/internal/config"
 )
 
-// addrEnv derives from config.EnvPrefix so a project rename touches one
-// constant (AGENTS.md).
+// addrEnv and tokenEnv derive from config.EnvPrefix so a project rename
+// touches one constant (AGENTS.md).
 var (
-	addrEnv = config.EnvPrefix + "API__ADDR"
-	prog    = filepath.Base(os.Args[0])
-	usage   = `usage: ` + prog + ` [-addr address] [-config path] <command>
+	addrEnv  = config.EnvPrefix + "API__ADDR"
+	tokenEnv = config.EnvPrefix + "API__TOKEN"
+	prog     = filepath.Base(os.Args[0])
+	usage    = `usage: ` + prog + ` [-addr address] [-config path] <command>
 
 commands:
   snapshot <venue> <account>   print the last snapshot checkpoint
-  events [-prefix p]           stream bus events as JSON lines
+  events [-prefix p] [-resume-after cursor]
+                               stream bus events as JSON lines, first
+                               replaying outbox events after cursor
   watch                        live balances view (q to quit)
-  order place|cancel|list      place, cancel, or list orders
+  order place|cancel|replace|list
+                               place, cancel, replace, or list orders
+  group place|cancel|get|list  place, cancel, show, or list oco and
+                               bracket order groups
+  exec twap|iceberg|vwap|pause|resume|cancel|get|list
+                               work, steer, show, or list parent orders
+                               worked by an execution algorithm
+  grid list|get|pause|resume|stop
+                               show or steer the configured grid bots
+  arb list|resolve             list arbitrage trades, or mark one whose
+                               legs filled unequally hedged
+  sinks list [-sink s]         list events sinks could not deliver
+  sinks retry [-sink s] [id...]
+                               retry dead letters once, by ID or all
+                               matching the filter
+  token create [-scopes s] [-file] <name>
+                               create a bearer token and print its
+                               secret once; -file mints one for
+                               api.tokens_file without the daemon
+  token list [-revoked]        list bearer tokens
+  token revoke <id>            revoke a stored bearer token
+  kill [-reason r] [venue]     halt new orders and cancel active ones
+                               (every venue when none is given)
+  kill -release [venue]        release a kill switch
+  kill -status                 list the engaged kill switches
+  instruments [-venue v] [-type t] [-base b] [-quote q] [-json]
+                               list the instrument catalog
+  instruments [-json] <venue> <BASE/QUOTE>
+                               show one instrument and its rules
 
 The address is resolved from -addr, then ` + addrEnv + `, then api.addr in
 the config file, in the same forms the daemon accepts:
-unix:///path/to.sock or host:port.
+unix:///path/to.sock or host:port. A daemon with api.auth set wants a
+bearer token, read from ` + tokenEnv + `, then api.token or
+api.token_file in the config file.
 `
 )
 
 type clients struct {
-	snapshots controlv1connect.SnapshotServiceClient
-	events    controlv1connect.EventServiceClient
-	orders    controlv1connect.OrderServiceClient
+	snapshots   controlv1connect.SnapshotServiceClient
+	events      controlv1connect.EventServiceClient
+	orders      controlv1connect.OrderServiceClient
+	kill        controlv1connect.KillSwitchServiceClient
+	instruments controlv1connect.InstrumentServiceClient
+	groups      controlv1connect.OrderGroupServiceClient
+	executions  controlv1connect.ExecutionServiceClient
+	grids       controlv1connect.GridServiceClient
+	arbs        controlv1connect.ArbServiceClient
+	sinks       controlv1connect.SinkServiceClient
+	tokens      controlv1connect.TokenServiceClient
 }
 
 func main() {
@@ -59,6 +100,11 @@ func run(args []string) error {
 		flags.Usage()
 		return fmt.Errorf("missing command")
 	}
+	ctx := context.Background()
+	cmd, rest := flags.Arg(0), flags.Args()[1:]
+	if cmd == "token" && isFileTokenCreate(rest) {
+		return runToken(ctx, clients{}, rest)
+	}
 	if *addr == "" {
 		*addr = os.Getenv(addrEnv)
 	}
@@ -73,15 +119,30 @@ func run(args []string) error {
 		return fmt.Errorf("no address: pass -addr, set %s, or set api.addr in %s", addrEnv, *configPath)
 	}
 
-	httpClient, baseURL := api.NewHTTPClient(*addr)
+	token := os.Getenv(tokenEnv)
+	if token == "" {
+		fromFile, err := config.APIToken(*configPath)
+		if err != nil {
+			return err
+		}
+		token = fromFile
+	}
+
+	httpClient, baseURL := api.NewHTTPClient(*addr, token)
 	c := clients{
-		snapshots: controlv1connect.NewSnapshotServiceClient(httpClient, baseURL),
-		events:    controlv1connect.NewEventServiceClient(httpClient, baseURL),
-		orders:    controlv1connect.NewOrderServiceClient(httpClient, baseURL),
+		snapshots:   controlv1connect.NewSnapshotServiceClient(httpClient, baseURL),
+		events:      controlv1connect.NewEventServiceClient(httpClient, baseURL),
+		orders:      controlv1connect.NewOrderServiceClient(httpClient, baseURL),
+		kill:        controlv1connect.NewKillSwitchServiceClient(httpClient, baseURL),
+		instruments: controlv1connect.NewInstrumentServiceClient(httpClient, baseURL),
+		groups:      controlv1connect.NewOrderGroupServiceClient(httpClient, baseURL),
+		executions:  controlv1connect.NewExecutionServiceClient(httpClient, baseURL),
+		grids:       controlv1connect.NewGridServiceClient(httpClient, baseURL),
+		arbs:        controlv1connect.NewArbServiceClient(httpClient, baseURL),
+		sinks:       controlv1connect.NewSinkServiceClient(httpClient, baseURL),
+		tokens:      controlv1connect.NewTokenServiceClient(httpClient, baseURL),
 	}
 
-	ctx := context.Background()
-	cmd, rest := flags.Arg(0), flags.Args()[1:]
 	switch cmd {
 	case "snapshot":
 		return runSnapshot(ctx, c, rest)
@@ -91,6 +152,22 @@ func run(args []string) error {
 		return runWatch(ctx, c)
 	case "order":
 		return runOrder(ctx, c, rest)
+	case "group":
+		return runGroup(ctx, c, rest)
+	case "exec":
+		return runExec(ctx, c, rest)
+	case "grid":
+		return runGrid(ctx, c, rest)
+	case "arb":
+		return runArb(ctx, c, rest)
+	case "sinks":
+		return runSinks(ctx, c, rest)
+	case "token":
+		return runToken(ctx, c, rest)
+	case "kill":
+		return runKill(ctx, c, rest)
+	case "instruments":
+		return runInstruments(ctx, c, rest)
 	default:
 		flags.Usage()
 		return fmt.Errorf("unknown command %q", cmd)
@@ -8,19 +8,23 @@ import (
 	"time"
 
 	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/id"
 )
 
 func runOrder(ctx context.Context, c clients, args []string) error {
 	if len(args) == 0 {
-		return fmt.Errorf("usage: %s order <place|cancel|list>", prog)
+		return fmt.Errorf("usage: %s order <place|cancel|replace|list>", prog)
 	}
 	switch args[0] {
 	case "place":
 		return runOrderPlace(ctx, c, args[1:])
 	case "cancel":
 		return runOrderCancel(ctx, c, args[1:])
+	case "replace":
+		return runOrderReplace(ctx, c, args[1:])
 	case "list":
 		return runOrderList(ctx, c, args[1:])
 	default:
@@ -34,19 +38,35 @@ func runOrderPlace(ctx context.Context, c clients, args []string) error {
 	base := flags.String("base", "", "base currency")
 	quote := flags.String("quote", "", "quote currency")
 	side := flags.String("side", "", "buy or sell")
-	kind := flags.String("type", "", "limit or market")
+	kind := flags.String("type", "", "limit, market, stop_market or stop_limit")
 	qty := flags.String("qty", "", "quantity")
 	price := flags.String("price", "", "limit price")
+	trigger := flags.String("trigger", "", "stop trigger price: the last trade that fires the stop")
 	clientID := flags.String("client-order-id", "", "idempotency key")
+	tif := flags.String("tif", "", "time in force: gtc, ioc, fok or gtd (default gtc)")
+	expires := flags.String("expires", "", "gtd expiry: an RFC 3339 time or a duration from now")
+	postOnly := flags.Bool("post-only", false, "reject the order rather than take liquidity")
 	if err := flags.Parse(args); err != nil {
 		return err
 	}
+	request := &controlv1.PlaceOrderRequest{
+		Venue: *venue, Base: strings.ToUpper(*base), Quote: strings.ToUpper(*quote),
+		Side: parseSide(*side), Type: parseOrderType(*kind), Qty: *qty, Price: *price, TriggerPrice: *trigger, ClientOrderId: *clientID,
+		TimeInForce: parseTimeInForce(*tif), PostOnly: *postOnly,
+	}
+	if *tif != "" && request.TimeInForce == controlv1.TimeInForce_TIME_IN_FORCE_UNSPECIFIED {
+		return fmt.Errorf("invalid time in force %q", *tif)
+	}
+	if *expires != "" {
+		at, err := parseExpiry(*expires, time.Now())
+		if err != nil {
+			return err
+		}
+		request.ExpiresAt = timestamppb.New(at)
+	}
 	ctx, cancel := context.WithTimeout(ctx, time.Minute)
 	defer cancel()
-	resp, err := c.orders.PlaceOrder(ctx, connect.NewRequest(&controlv1.PlaceOrderRequest{
-		Venue: *venue, Base: strings.ToUpper(*base), Quote: strings.ToUpper(*quote),
-		Side: parseSide(*side), Type: parseOrderType(*kind), Qty: *qty, Price: *price, ClientOrderId: *clientID,
-	}))
+	resp, err := c.orders.PlaceOrder(ctx, connect.NewRequest(request))
 	if err != nil {
 		return err
 	}
@@ -71,6 +91,42 @@ func runOrderCancel(ctx context.Context, c clients, args []string) error {
 	return nil
 }
 
+// runOrderReplace picks the replacement's client order ID itself, so a
+// replace that did not settle can be resumed by repeating it with -new-id.
+func runOrderReplace(ctx context.Context, c clients, args []string) error {
+	flags := flag.NewFlagSet("order replace", flag.ContinueOnError)
+	price := flags.String("price", "", "new limit price")
+	qty := flags.String("qty", "", "new total quantity, fills included")
+	newID := flags.String("new-id", "", "replacement client order ID, to resume a replace")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: %s order replace -price p -qty q [-new-id id] <client-order-id>", prog)
+	}
+	if *newID == "" {
+		*newID = id.New()
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	resp, err := c.orders.ReplaceOrder(ctx, connect.NewRequest(&controlv1.ReplaceOrderRequest{
+		ClientOrderId: flags.Arg(0), Price: *price, Qty: *qty, NewClientOrderId: *newID,
+	}))
+	if err != nil {
+		if connect.CodeOf(err) == connect.CodeAborted {
+			return fmt.Errorf("%w; resume with -new-id %s", err, *newID)
+		}
+		return err
+	}
+	mode := strings.ToLower(strings.TrimPrefix(resp.Msg.GetMode().String(), "REPLACE_MODE_"))
+	fmt.Printf("%s -> %s  %s  %s\n", resp.Msg.GetReplacedClientOrderId(), resp.Msg.GetClientOrderId(),
+		mode, orderStatusText(resp.Msg.GetStatus()))
+	if resp.Msg.GetSubmitUnsettled() {
+		fmt.Println("WARNING: venue submission is unsettled; reconciliation will determine the final state")
+	}
+	return nil
+}
+
 type statusFlags []string
 
 func (s *statusFlags) String() string { return strings.Join(*s, ",") }
@@ -84,6 +140,7 @@ func runOrderList(ctx context.Context, c clients, args []string) error {
 	venue := flags.String("venue", "", "venue filter")
 	bot := flags.String("bot", "", "bot filter")
 	limit := flags.Int("limit", 50, "maximum orders")
+	parents := flags.Bool("parents", false, "also list execution parents, their children's fills added up")
 	var statuses statusFlags
 	flags.Var(&statuses, "status", "status filter (repeatable)")
 	if err := flags.Parse(args); err != nil {
@@ -107,13 +164,21 @@ func runOrderList(ctx context.Context, c clients, args []string) error {
 		pageLimit := min(remaining, 500)
 		resp, err := c.orders.ListOrders(ctx, connect.NewRequest(&controlv1.ListOrdersRequest{
 			Venue: *venue, Statuses: wireStatuses, BotId: *bot, Limit: int32(pageLimit), PageToken: pageToken, //nolint:gosec // capped at 500
+			IncludeParents: *parents,
 		}))
 		if err != nil {
 			return err
 		}
 		for _, order := range resp.Msg.GetOrders() {
-			fmt.Printf("%s  %s  %s/%s  %s %s @ %s  %s\n", order.GetClientOrderId(), order.GetVenue(),
-				order.GetBase(), order.GetQuote(), strings.ToLower(strings.TrimPrefix(order.GetSide().String(), "SIDE_")), order.GetQty(), order.GetPrice(), orderStatusText(order.GetStatus()))
+			var stop string
+			if order.GetTriggerPrice() != "" {
+				stop = "  trigger " + order.GetTriggerPrice()
+			}
+			if order.GetRollup() {
+				stop += "  parent, filled " + order.GetFilledQty()
+			}
+			fmt.Printf("%s  %s  %s/%s  %s %s @ %s%s  %s\n", order.GetClientOrderId(), order.GetVenue(),
+				order.GetBase(), order.GetQuote(), strings.ToLower(strings.TrimPrefix(order.GetSide().String(), "SIDE_")), order.GetQty(), order.GetPrice(), stop, orderStatusText(order.GetStatus()))
 			remaining--
 		}
 		pageToken = resp.Msg.GetNextPageToken()
@@ -135,13 +200,27 @@ func parseSide(value string) controlv1.Side {
 }
 
 func parseOrderType(value string) controlv1.OrderType {
-	if strings.EqualFold(value, "limit") {
-		return controlv1.OrderType_ORDER_TYPE_LIMIT
+	normalized := "ORDER_TYPE_" + strings.ToUpper(strings.ReplaceAll(value, "-", "_"))
+	return controlv1.OrderType(controlv1.OrderType_value[normalized])
+}
+
+func parseTimeInForce(value string) controlv1.TimeInForce {
+	if value == "" {
+		return controlv1.TimeInForce_TIME_IN_FORCE_UNSPECIFIED
 	}
-	if strings.EqualFold(value, "market") {
-		return controlv1.OrderType_ORDER_TYPE_MARKET
+	return controlv1.TimeInForce(controlv1.TimeInForce_value["TIME_IN_FORCE_"+strings.ToUpper(value)])
+}
+
+// parseExpiry reads an absolute RFC 3339 time or a duration after now.
+func parseExpiry(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(d), nil
+	}
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid expiry %q: want an RFC 3339 time or a duration", value)
 	}
-	return controlv1.OrderType_ORDER_TYPE_UNSPECIFIED
+	return at, nil
 }
 
 func parseOrderStatus(value string) controlv1.OrderStatus {
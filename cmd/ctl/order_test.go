@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"testing"
+	"time"
 
 	"connectrpc.com/connect"
 
@@ -10,8 +11,9 @@ import (
 )
 
 type fakeOrderClient struct {
-	place *controlv1.PlaceOrderRequest
-	list  []*controlv1.ListOrdersRequest
+	place   *controlv1.PlaceOrderRequest
+	replace *controlv1.ReplaceOrderRequest
+	list    []*controlv1.ListOrdersRequest
 }
 
 func (f *fakeOrderClient) PlaceOrder(_ context.Context, req *connect.Request[controlv1.PlaceOrderRequest]) (*connect.Response[controlv1.PlaceOrderResponse], error) {
@@ -23,6 +25,14 @@ func (*fakeOrderClient) CancelOrder(context.Context, *connect.Request[controlv1.
 	return connect.NewResponse(&controlv1.CancelOrderResponse{Status: controlv1.OrderStatus_ORDER_STATUS_OPEN}), nil
 }
 
+func (f *fakeOrderClient) ReplaceOrder(_ context.Context, req *connect.Request[controlv1.ReplaceOrderRequest]) (*connect.Response[controlv1.ReplaceOrderResponse], error) {
+	f.replace = req.Msg
+	return connect.NewResponse(&controlv1.ReplaceOrderResponse{
+		ReplacedClientOrderId: req.Msg.GetClientOrderId(), ClientOrderId: req.Msg.GetNewClientOrderId(),
+		Mode: controlv1.ReplaceMode_REPLACE_MODE_CANCEL_REPLACE, Status: controlv1.OrderStatus_ORDER_STATUS_PENDING,
+	}), nil
+}
+
 func (f *fakeOrderClient) ListOrders(_ context.Context, req *connect.Request[controlv1.ListOrdersRequest]) (*connect.Response[controlv1.ListOrdersResponse], error) {
 	f.list = append(f.list, req.Msg)
 	if len(f.list) == 1 {
@@ -50,6 +60,61 @@ func TestOrderFlags(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "place sends gtd post-only with a relative expiry",
+			run:  runOrderPlace,
+			args: []string{"--venue", "bybit", "--base", "btc", "--quote", "usdt", "--side", "sell", "--type", "limit", "--qty", "1", "--price", "60000",
+				"--tif", "GTD", "--expires", "1h", "--post-only"},
+			verify: func(t *testing.T, fake *fakeOrderClient) {
+				if fake.place.GetTimeInForce() != controlv1.TimeInForce_TIME_IN_FORCE_GTD || !fake.place.GetPostOnly() ||
+					!fake.place.GetExpiresAt().AsTime().After(time.Now()) {
+					t.Fatalf("place request = %+v", fake.place)
+				}
+			},
+		},
+		{
+			name: "place sends a stop-limit with its trigger",
+			run:  runOrderPlace,
+			args: []string{"--venue", "bybit", "--base", "btc", "--quote", "usdt", "--side", "sell", "--type", "stop-limit", "--qty", "1",
+				"--price", "48900", "--trigger", "49000"},
+			verify: func(t *testing.T, fake *fakeOrderClient) {
+				if fake.place.GetType() != controlv1.OrderType_ORDER_TYPE_STOP_LIMIT || fake.place.GetTriggerPrice() != "49000" {
+					t.Fatalf("place request = %+v", fake.place)
+				}
+			},
+		},
+		{
+			name:    "place rejects an unknown time in force before calling the API",
+			run:     runOrderPlace,
+			args:    []string{"--tif", "day"},
+			wantErr: true,
+			verify: func(t *testing.T, fake *fakeOrderClient) {
+				if fake.place != nil {
+					t.Fatalf("place request = %+v, want none", fake.place)
+				}
+			},
+		},
+		{
+			name: "replace picks a new client order ID to resume with",
+			run:  runOrderReplace,
+			args: []string{"--price", "49000", "--qty", "2", "01J00000000000000000000001"},
+			verify: func(t *testing.T, fake *fakeOrderClient) {
+				if fake.replace.GetClientOrderId() != "01J00000000000000000000001" || fake.replace.GetPrice() != "49000" ||
+					len(fake.replace.GetNewClientOrderId()) != 26 {
+					t.Fatalf("replace request = %+v", fake.replace)
+				}
+			},
+		},
+		{
+			name: "replace resumes under the given ID",
+			run:  runOrderReplace,
+			args: []string{"--price", "49000", "--qty", "2", "--new-id", "01J00000000000000000000002", "01J00000000000000000000001"},
+			verify: func(t *testing.T, fake *fakeOrderClient) {
+				if fake.replace.GetNewClientOrderId() != "01J00000000000000000000002" {
+					t.Fatalf("replace request = %+v", fake.replace)
+				}
+			},
+		},
 		{
 			name: "list follows page tokens and repeats statuses",
 			run:  runOrderList,
@@ -60,6 +125,16 @@ func TestOrderFlags(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "list asks for parents",
+			run:  runOrderList,
+			args: []string{"--parents", "--limit", "1"},
+			verify: func(t *testing.T, fake *fakeOrderClient) {
+				if len(fake.list) != 1 || !fake.list[0].GetIncludeParents() {
+					t.Fatalf("list requests = %+v", fake.list)
+				}
+			},
+		},
 		{
 			name:    "list rejects an unknown status before calling the API",
 			run:     runOrderList,
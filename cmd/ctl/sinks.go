@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+)
+
+func runSinks(ctx context.Context, c clients, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: %s sinks <list|retry>", prog)
+	}
+	switch args[0] {
+	case "list":
+		return runSinksList(ctx, c, args[1:])
+	case "retry":
+		return runSinksRetry(ctx, c, args[1:])
+	default:
+		return fmt.Errorf("unknown sinks command %q", args[0])
+	}
+}
+
+func runSinksList(ctx context.Context, c clients, args []string) error {
+	flags := flag.NewFlagSet("sinks list", flag.ContinueOnError)
+	sink := flags.String("sink", "", "sink name filter")
+	limit := flags.Int("limit", 100, "maximum dead letters (at most 500)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *limit < 1 || *limit > 500 {
+		return fmt.Errorf("limit must be between 1 and 500")
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	resp, err := c.sinks.ListDeadLetters(ctx, connect.NewRequest(&controlv1.ListDeadLettersRequest{
+		Sink: *sink, Limit: int32(*limit), //nolint:gosec // capped at 500
+	}))
+	if err != nil {
+		return err
+	}
+	for _, letter := range resp.Msg.GetLetters() {
+		printDeadLetter(os.Stdout, letter)
+	}
+	return nil
+}
+
+// runSinksRetry retries the dead letters named by ID, or every letter
+// matching the filters when none is named, and fails when any of them
+// failed again so scripts notice.
+func runSinksRetry(ctx context.Context, c clients, args []string) error {
+	flags := flag.NewFlagSet("sinks retry", flag.ContinueOnError)
+	sink := flags.String("sink", "", "sink name filter")
+	limit := flags.Int("limit", 100, "maximum dead letters (at most 500)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *limit < 1 || *limit > 500 {
+		return fmt.Errorf("limit must be between 1 and 500")
+	}
+	ids := make([]int64, 0, flags.NArg())
+	for _, arg := range flags.Args() {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil || id <= 0 {
+			return fmt.Errorf("dead letter ID %q: must be a positive integer", arg)
+		}
+		ids = append(ids, id)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+	resp, err := c.sinks.RetryDeadLetters(ctx, connect.NewRequest(&controlv1.RetryDeadLettersRequest{
+		Sink: *sink, Ids: ids, Limit: int32(*limit), //nolint:gosec // capped at 500
+	}))
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, r := range resp.Msg.GetResults() {
+		if r.GetDelivered() {
+			fmt.Printf("%d  %s  delivered\n", r.GetLetter().GetId(), r.GetLetter().GetSink())
+			continue
+		}
+		failed++
+		printDeadLetter(os.Stdout, r.GetLetter())
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d dead letters failed again", failed, len(resp.Msg.GetResults()))
+	}
+	return nil
+}
+
+func printDeadLetter(w io.Writer, letter *controlv1.DeadLetter) {
+	fmt.Fprintf(w, "%d  %s  cursor %d  %s  %d attempts  failed %s: %s\n", letter.GetId(), letter.GetSink(),
+		letter.GetCursor(), letter.GetSubject(), letter.GetAttempts(),
+		letter.GetFailedAt().AsTime().Local().Format(time.RFC3339), letter.GetLastError())
+}
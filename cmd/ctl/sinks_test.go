@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+)
+
+type fakeSinkClient struct {
+	list  *controlv1.ListDeadLettersRequest
+	retry *controlv1.RetryDeadLettersRequest
+	fail  bool
+}
+
+func (f *fakeSinkClient) ListDeadLetters(_ context.Context, req *connect.Request[controlv1.ListDeadLettersRequest]) (*connect.Response[controlv1.ListDeadLettersResponse], error) {
+	f.list = req.Msg
+	return connect.NewResponse(&controlv1.ListDeadLettersResponse{}), nil
+}
+
+func (f *fakeSinkClient) RetryDeadLetters(_ context.Context, req *connect.Request[controlv1.RetryDeadLettersRequest]) (*connect.Response[controlv1.RetryDeadLettersResponse], error) {
+	f.retry = req.Msg
+	return connect.NewResponse(&controlv1.RetryDeadLettersResponse{Results: []*controlv1.DeadLetterRetry{
+		{Letter: &controlv1.DeadLetter{Id: 1, Sink: "hooks"}, Delivered: !f.fail},
+	}}), nil
+}
+
+func TestSinksCommands(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		fail    bool
+		wantErr bool
+		verify  func(*testing.T, *fakeSinkClient)
+	}{
+		{
+			name: "list sends its filters",
+			args: []string{"list", "--sink", "hooks", "--limit", "5"},
+			verify: func(t *testing.T, fake *fakeSinkClient) {
+				if fake.list.GetSink() != "hooks" || fake.list.GetLimit() != 5 {
+					t.Fatalf("list request = %+v", fake.list)
+				}
+			},
+		},
+		{
+			name: "retry sends the IDs",
+			args: []string{"retry", "--sink", "hooks", "7", "9"},
+			verify: func(t *testing.T, fake *fakeSinkClient) {
+				if fake.retry.GetSink() != "hooks" || len(fake.retry.GetIds()) != 2 || fake.retry.GetIds()[1] != 9 {
+					t.Fatalf("retry request = %+v", fake.retry)
+				}
+			},
+		},
+		{
+			name:    "retry rejects an ID that is not a number",
+			args:    []string{"retry", "seven"},
+			wantErr: true,
+			verify: func(t *testing.T, fake *fakeSinkClient) {
+				if fake.retry != nil {
+					t.Fatalf("retry request = %+v, want none", fake.retry)
+				}
+			},
+		},
+		{
+			name:    "retry fails when a letter fails again",
+			args:    []string{"retry"},
+			fail:    true,
+			wantErr: true,
+			verify:  func(*testing.T, *fakeSinkClient) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := &fakeSinkClient{fail: tt.fail}
+			err := runSinks(t.Context(), clients{sinks: fake}, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr = %v", err, tt.wantErr)
+			}
+			tt.verify(t, fake)
+		})
+	}
+}
+
+func TestPrintDeadLetter(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	printDeadLetter(&out, &controlv1.DeadLetter{
+		Id: 3, Sink: "hooks", Cursor: 42, Subject: "order.filled", Attempts: 5,
+		LastError: "webhook: 503 Service Unavailable", FailedAt: timestamppb.New(time.Now()),
+	})
+	if got := out.String(); !strings.HasPrefix(got, "3  hooks  cursor 42  order.filled  5 attempts  failed ") ||
+		!strings.HasSuffix(got, ": webhook: 503 Service Unavailable\n") {
+		t.Fatalf("output = %q", got)
+	}
+}
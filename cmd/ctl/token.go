@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/domain/auth"
+)
+
+func runToken(ctx context.Context, c clients, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: %s token <create|list|revoke>", prog)
+	}
+	switch args[0] {
+	case "create":
+		return runTokenCreate(ctx, c, args[1:])
+	case "list":
+		return runTokenList(ctx, c, args[1:])
+	case "revoke":
+		return runTokenRevoke(ctx, c, args[1:])
+	default:
+		return fmt.Errorf("unknown token command %q", args[0])
+	}
+}
+
+// tokenCreate is a parsed "token create".
+type tokenCreate struct {
+	name   string
+	scopes []auth.Scope
+	// file mints the token for api.tokens_file instead of asking the
+	// daemon to store it.
+	file bool
+}
+
+func parseTokenCreate(args []string) (tokenCreate, error) {
+	flags := flag.NewFlagSet("token create", flag.ContinueOnError)
+	scopes := flags.String("scopes", "read", "comma-separated scopes: read, trade, admin")
+	file := flags.Bool("file", false, "print a tokens-file line instead of storing the token in the daemon")
+	if err := flags.Parse(args); err != nil {
+		return tokenCreate{}, err
+	}
+	if flags.NArg() != 1 {
+		return tokenCreate{}, fmt.Errorf("usage: %s token create [-scopes read,trade,admin] [-file] <name>", prog)
+	}
+	create := tokenCreate{name: flags.Arg(0), file: *file}
+	if err := auth.ValidateName(create.name); err != nil {
+		return tokenCreate{}, err
+	}
+	var err error
+	if create.scopes, err = auth.ParseScopes(*scopes); err != nil {
+		return tokenCreate{}, err
+	}
+	return create, nil
+}
+
+// isFileTokenCreate reports whether args are "create -file ...", which
+// needs no daemon: it is how the first admin token reaches one that
+// refuses every client until it has a token.
+func isFileTokenCreate(args []string) bool {
+	if len(args) == 0 || args[0] != "create" {
+		return false
+	}
+	create, err := parseTokenCreate(args[1:])
+	return err == nil && create.file
+}
+
+func runTokenCreate(ctx context.Context, c clients, args []string) error {
+	create, err := parseTokenCreate(args)
+	if err != nil {
+		return err
+	}
+	if create.file {
+		printFileToken(os.Stdout, create, auth.NewSecret())
+		return nil
+	}
+	scopes := make([]controlv1.TokenScope, 0, len(create.scopes))
+	for _, scope := range create.scopes {
+		scopes = append(scopes, parseTokenScope(scope))
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	resp, err := c.tokens.CreateToken(ctx, connect.NewRequest(&controlv1.CreateTokenRequest{Name: create.name, Scopes: scopes}))
+	if err != nil {
+		return err
+	}
+	fmt.Println(tokenLine(resp.Msg.GetToken()))
+	fmt.Println("secret: " + resp.Msg.GetSecret())
+	return nil
+}
+
+// printFileToken prints a fresh secret and the line that admits it from
+// api.tokens_file. Only the hash goes in the file.
+func printFileToken(w io.Writer, create tokenCreate, secret string) {
+	fmt.Fprintln(w, "secret: "+secret)
+	fmt.Fprintln(w, "tokens_file: "+auth.FileLine(create.name, create.scopes, auth.HashSecret(secret)))
+}
+
+func runTokenList(ctx context.Context, c clients, args []string) error {
+	flags := flag.NewFlagSet("token list", flag.ContinueOnError)
+	revoked := flags.Bool("revoked", false, "include revoked tokens")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	resp, err := c.tokens.ListTokens(ctx, connect.NewRequest(&controlv1.ListTokensRequest{IncludeRevoked: *revoked}))
+	if err != nil {
+		return err
+	}
+	for _, token := range resp.Msg.GetTokens() {
+		fmt.Println(tokenLine(token))
+	}
+	return nil
+}
+
+func runTokenRevoke(ctx context.Context, c clients, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: %s token revoke <token-id>", prog)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	if _, err := c.tokens.RevokeToken(ctx, connect.NewRequest(&controlv1.RevokeTokenRequest{TokenId: args[0]})); err != nil {
+		return err
+	}
+	fmt.Println("revoked " + args[0])
+	return nil
+}
+
+// tokenLine formats a token; file tokens have no ID and show "-".
+func tokenLine(t *controlv1.Token) string {
+	id := t.GetTokenId()
+	if id == "" {
+		id = "-"
+	}
+	scopes := make([]string, 0, len(t.GetScopes()))
+	for _, scope := range t.GetScopes() {
+		scopes = append(scopes, enumText(scope.String(), "TOKEN_SCOPE_"))
+	}
+	line := fmt.Sprintf("%s  %s  %s  %s", id, t.GetName(), strings.Join(scopes, ","), enumText(t.GetSource().String(), "TOKEN_SOURCE_"))
+	if t.GetCreatedAt() != nil {
+		line += "  created " + t.GetCreatedAt().AsTime().Local().Format(time.RFC3339)
+	}
+	if t.GetRevokedAt() != nil {
+		line += "  revoked " + t.GetRevokedAt().AsTime().Local().Format(time.RFC3339)
+	}
+	return line
+}
+
+func parseTokenScope(scope auth.Scope) controlv1.TokenScope {
+	return controlv1.TokenScope(controlv1.TokenScope_value["TOKEN_SCOPE_"+strings.ToUpper(string(scope))])
+}
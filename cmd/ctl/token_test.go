@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/domain/auth"
+)
+
+type fakeTokenClient struct {
+	create *controlv1.CreateTokenRequest
+	list   *controlv1.ListTokensRequest
+	revoke *controlv1.RevokeTokenRequest
+}
+
+func (f *fakeTokenClient) CreateToken(_ context.Context, req *connect.Request[controlv1.CreateTokenRequest]) (*connect.Response[controlv1.CreateTokenResponse], error) {
+	f.create = req.Msg
+	return connect.NewResponse(&controlv1.CreateTokenResponse{Token: &controlv1.Token{TokenId: "01J", Name: req.Msg.GetName()}, Secret: "s"}), nil
+}
+
+func (f *fakeTokenClient) ListTokens(_ context.Context, req *connect.Request[controlv1.ListTokensRequest]) (*connect.Response[controlv1.ListTokensResponse], error) {
+	f.list = req.Msg
+	return connect.NewResponse(&controlv1.ListTokensResponse{}), nil
+}
+
+func (f *fakeTokenClient) RevokeToken(_ context.Context, req *connect.Request[controlv1.RevokeTokenRequest]) (*connect.Response[controlv1.RevokeTokenResponse], error) {
+	f.revoke = req.Msg
+	return connect.NewResponse(&controlv1.RevokeTokenResponse{}), nil
+}
+
+func TestTokenCommands(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+		verify  func(*testing.T, *fakeTokenClient)
+	}{
+		{
+			name: "create defaults to read",
+			args: []string{"create", "dashboard"},
+			verify: func(t *testing.T, fake *fakeTokenClient) {
+				if fake.create.GetName() != "dashboard" || len(fake.create.GetScopes()) != 1 ||
+					fake.create.GetScopes()[0] != controlv1.TokenScope_TOKEN_SCOPE_READ {
+					t.Fatalf("create request = %+v", fake.create)
+				}
+			},
+		},
+		{
+			name: "create sends the scopes",
+			args: []string{"create", "-scopes", "read,trade", "bot"},
+			verify: func(t *testing.T, fake *fakeTokenClient) {
+				if scopes := fake.create.GetScopes(); len(scopes) != 2 || scopes[1] != controlv1.TokenScope_TOKEN_SCOPE_TRADE {
+					t.Fatalf("create scopes = %v", scopes)
+				}
+			},
+		},
+		{
+			name:    "create rejects an unknown scope",
+			args:    []string{"create", "-scopes", "root", "bot"},
+			wantErr: true,
+			verify: func(t *testing.T, fake *fakeTokenClient) {
+				if fake.create != nil {
+					t.Fatalf("create request = %+v, want none", fake.create)
+				}
+			},
+		},
+		{
+			name: "create -file stays offline",
+			args: []string{"create", "-file", "-scopes", "admin", "ops"},
+			verify: func(t *testing.T, fake *fakeTokenClient) {
+				if fake.create != nil {
+					t.Fatalf("create request = %+v, want none", fake.create)
+				}
+			},
+		},
+		{
+			name: "list asks for revoked tokens",
+			args: []string{"list", "-revoked"},
+			verify: func(t *testing.T, fake *fakeTokenClient) {
+				if !fake.list.GetIncludeRevoked() {
+					t.Fatalf("list request = %+v", fake.list)
+				}
+			},
+		},
+		{
+			name: "revoke sends the ID",
+			args: []string{"revoke", "01J9Z8Q4X2V7K3M5N6P8R0S1T2"},
+			verify: func(t *testing.T, fake *fakeTokenClient) {
+				if fake.revoke.GetTokenId() != "01J9Z8Q4X2V7K3M5N6P8R0S1T2" {
+					t.Fatalf("revoke request = %+v", fake.revoke)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := &fakeTokenClient{}
+			err := runToken(t.Context(), clients{tokens: fake}, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr = %v", err, tt.wantErr)
+			}
+			tt.verify(t, fake)
+		})
+	}
+}
+
+func TestIsFileTokenCreate(t *testing.T) {
+	t.Parallel()
+	if !isFileTokenCreate([]string{"create", "-scopes", "admin", "-file", "ops"}) {
+		t.Fatal("create -file is not offline")
+	}
+	if isFileTokenCreate([]string{"create", "ops"}) || isFileTokenCreate([]string{"list"}) {
+		t.Fatal("a daemon command is offline")
+	}
+}
+
+// TestPrintFileToken checks the printed line admits the printed secret.
+func TestPrintFileToken(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	printFileToken(&out, tokenCreate{name: "ops", scopes: []auth.Scope{auth.ScopeAdmin}}, "the-secret")
+	secret, line, ok := strings.Cut(out.String(), "\n")
+	if !ok || secret != "secret: the-secret" {
+		t.Fatalf("output = %q", out.String())
+	}
+	tokens, err := auth.ParseFile([]byte(strings.TrimPrefix(line, "tokens_file: ")))
+	if err != nil || len(tokens) != 1 || tokens[0].Name != "ops" || tokens[0].Hash != auth.HashSecret("the-secret") ||
+		!tokens[0].Allows(auth.ScopeAdmin) {
+		t.Fatalf("ParseFile(%q) = %+v, %v", line, tokens, err)
+	}
+}
+
+func TestTokenLine(t *testing.T) {
+	t.Parallel()
+	file := tokenLine(&controlv1.Token{Name: "ops", Scopes: []controlv1.TokenScope{controlv1.TokenScope_TOKEN_SCOPE_ADMIN}, Source: controlv1.TokenSource_TOKEN_SOURCE_FILE})
+	if file != "-  ops  admin  file" {
+		t.Fatalf("file token line = %q", file)
+	}
+	stored := tokenLine(&controlv1.Token{
+		TokenId: "01J", Name: "bot", Scopes: []controlv1.TokenScope{controlv1.TokenScope_TOKEN_SCOPE_READ, controlv1.TokenScope_TOKEN_SCOPE_TRADE},
+		Source: controlv1.TokenSource_TOKEN_SOURCE_STORE, CreatedAt: timestamppb.New(time.Now()), RevokedAt: timestamppb.New(time.Now()),
+	})
+	if !strings.HasPrefix(stored, "01J  bot  read,trade  store  created ") || !strings.Contains(stored, "  revoked ") {
+		t.Fatalf("stored token line = %q", stored)
+	}
+}
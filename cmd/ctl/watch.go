@@ -30,7 +30,9 @@ func runWatch(ctx context.Context, c clients) error {
 		}
 		defer func() { _ = stream.Close() }()
 		for stream.Receive() {
-			p.Send(eventMsg{stream.Msg().GetEvent()})
+			if event := stream.Msg().GetEvent(); event != nil {
+				p.Send(eventMsg{event})
+			}
 		}
 		if err := stream.Err(); err != nil && ctx.Err() == nil {
 			p.Send(streamErrMsg{err})
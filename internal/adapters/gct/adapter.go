@@ -27,7 +27,10 @@ type Exchange struct {
 	exch gctexchange.IBotExchange
 }
 
-var _ ports.Exchange = (*Exchange)(nil)
+var (
+	_ ports.Exchange    = (*Exchange)(nil)
+	_ ports.TradeReader = (*Exchange)(nil)
+)
 
 // New instantiates and configures a GCT exchange for the named venue.
 // Credentials are optional; without them only public endpoints work.
@@ -86,6 +89,20 @@ func (e *Exchange) Ticker(ctx context.Context, inst instrument.Instrument) (mark
 	return toTicker(inst, price), nil
 }
 
+// RecentTrades implements ports.TradeReader with the venue's recent public
+// trades, as many as its REST endpoint returns.
+func (e *Exchange) RecentTrades(ctx context.Context, inst instrument.Instrument) ([]marketdata.Trade, error) {
+	pair, item, err := toGCTPairAsset(e.exch, inst)
+	if err != nil {
+		return nil, err
+	}
+	trades, err := e.exch.GetRecentTrades(ctx, pair, item)
+	if err != nil {
+		return nil, fmt.Errorf("gct: recent trades %s %s: %w", e.id, inst.Pair(), err)
+	}
+	return toTrades(inst, trades), nil
+}
+
 // Instruments implements ports.MarketDataReader. It returns the venue's
 // enabled instruments for the given type.
 func (e *Exchange) Instruments(_ context.Context, typ instrument.Type) ([]instrument.Instrument, error) {
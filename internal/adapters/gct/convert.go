@@ -8,7 +8,9 @@ package gct
 // strings, never through float64.
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 	"strings"
 	"unicode"
 
@@ -17,6 +19,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchange/accounts"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 
 	"github.com/romanornr/delta-works/internal/domain/account"
 	"github.com/romanornr/delta-works/internal/domain/instrument"
@@ -90,6 +93,23 @@ func toTicker(inst instrument.Instrument, p *ticker.Price) marketdata.Ticker {
 	}
 }
 
+// toTrades converts venue trades oldest first, whatever order the venue
+// listed them in.
+func toTrades(inst instrument.Instrument, data []trade.Data) []marketdata.Trade {
+	out := make([]marketdata.Trade, 0, len(data))
+	for i := range data {
+		out = append(out, marketdata.Trade{
+			Instrument: inst,
+			ID:         data[i].TID,
+			Price:      decimal.NewFromFloat(data[i].Price),
+			Qty:        decimal.NewFromFloat(data[i].Amount),
+			At:         data[i].Timestamp,
+		})
+	}
+	slices.SortStableFunc(out, func(a, b marketdata.Trade) int { return cmp.Compare(a.At.UnixNano(), b.At.UnixNano()) })
+	return out
+}
+
 func toInstruments(venue instrument.VenueID, typ instrument.Type, pairs currency.Pairs) []instrument.Instrument {
 	out := make([]instrument.Instrument, 0, len(pairs))
 	for _, p := range pairs {
@@ -17,6 +17,7 @@ import (
 	"github.com/romanornr/delta-works/internal/domain/instrument"
 	"github.com/romanornr/delta-works/internal/domain/money"
 	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/ports"
 )
 
 func toGCTSubmit(name string, m symbolMatcher, req order.Request) (*gctorder.Submit, error) {
@@ -28,10 +29,19 @@ func toGCTSubmit(name string, m symbolMatcher, req order.Request) (*gctorder.Sub
 	if err != nil {
 		return nil, err
 	}
+	if req.Type.Stop() {
+		// The local trigger engine holds stops for this adapter; one
+		// reaching it is refused before anything is sent.
+		return nil, &ports.UnsupportedError{Venue: req.Instrument.Venue, Flag: string(req.Type)}
+	}
 	typ, err := toGCTType(req.Type)
 	if err != nil {
 		return nil, err
 	}
+	tif, err := toGCTTimeInForce(req)
+	if err != nil {
+		return nil, err
+	}
 	return &gctorder.Submit{
 		Exchange:      name,
 		Pair:          pair,
@@ -41,9 +51,54 @@ func toGCTSubmit(name string, m symbolMatcher, req order.Request) (*gctorder.Sub
 		Price:         req.Price.InexactFloat64(),
 		Amount:        req.Qty.InexactFloat64(),
 		ClientOrderID: string(req.ClientOrderID),
+		TimeInForce:   tif,
 	}, nil
 }
 
+// postOnlyVenues lists the venues whose GCT wrapper turns the PostOnly bit
+// into the venue's maker-only flag. Other wrappers ignore the bit and
+// would send a plain limit order that can take liquidity. Each entry names
+// the SubmitOrder in exchanges/<venue>/<venue>_wrapper.go that maps the
+// bit, as of the GCT version in postOnlyCheckedAgainst; a GCT bump fails
+// TestPostOnlyVenuesPinned until the list is re-checked.
+var postOnlyVenues = map[instrument.VenueID]bool{
+	"binance": true, // binance_wrapper.go SubmitOrder: spot type LIMIT_MAKER
+	"bybit":   true, // bybit_wrapper.go SubmitOrder: timeInForce PostOnly
+	"gateio":  true, // gateio_wrapper.go SubmitOrder: time_in_force poc
+	"kraken":  true, // kraken_wrapper.go SubmitOrder: spot oflags post
+	"kucoin":  true, // kucoin_wrapper.go SubmitOrder: postOnly
+	"okx":     true, // okx_wrapper.go SubmitOrder: ordType post_only
+}
+
+// postOnlyCheckedAgainst is the GCT module version postOnlyVenues was
+// read against: the fork go.mod replaces GCT with.
+const postOnlyCheckedAgainst = "v0.0.0-20260703020034-fa94ed8d0137"
+
+// toGCTTimeInForce maps the execution flags onto GCT's time-in-force bit
+// set. GTC stays unset, the venue default, as it was before the flags
+// existed. GCT's submit has no expiry, so GTD is refused rather than sent
+// as an order that would never expire, and post-only is refused on venues
+// whose wrapper does not honor it.
+func toGCTTimeInForce(req order.Request) (gctorder.TimeInForce, error) {
+	var tif gctorder.TimeInForce
+	switch req.TimeInForce {
+	case "", order.GTC:
+	case order.IOC:
+		tif = gctorder.ImmediateOrCancel
+	case order.FOK:
+		tif = gctorder.FillOrKill
+	default:
+		return tif, &ports.UnsupportedError{Venue: req.Instrument.Venue, Flag: "time in force " + string(req.TimeInForce)}
+	}
+	if req.PostOnly {
+		if !postOnlyVenues[req.Instrument.Venue] {
+			return tif, &ports.UnsupportedError{Venue: req.Instrument.Venue, Flag: "post-only"}
+		}
+		tif |= gctorder.PostOnly
+	}
+	return tif, nil
+}
+
 func toGCTCancel(name string, m symbolMatcher, ref order.Ref) (*gctorder.Cancel, error) {
 	pair, item, err := toGCTPairAsset(m, ref.Instrument)
 	if err != nil {
@@ -1,6 +1,8 @@
 package gct
 
 import (
+	"errors"
+	"runtime/debug"
 	"testing"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 
 	"github.com/romanornr/delta-works/internal/domain/instrument"
 	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/ports"
 )
 
 func orderPairInst(t *testing.T) (currency.Pair, instrument.Instrument) {
@@ -50,6 +53,35 @@ func TestToGCTSubmit(t *testing.T) {
 		t.Fatalf("price/amount = %v %v", got.Price, got.Amount)
 	}
 
+	if got.TimeInForce != 0 {
+		t.Fatalf("gtc TimeInForce = %v, want unset", got.TimeInForce)
+	}
+
+	req.TimeInForce, req.PostOnly = order.GTC, true
+	if got, err := toGCTSubmit("bybit", &fakeMatcher{pair: pair}, req); err != nil || got.TimeInForce != gctorder.PostOnly {
+		t.Fatalf("post-only TimeInForce = %v, %v", got.TimeInForce, err)
+	}
+	var unsupported *ports.UnsupportedError
+	elsewhere := req
+	elsewhere.Instrument.Venue = "bitstamp"
+	if _, err := toGCTSubmit("bitstamp", &fakeMatcher{pair: pair}, elsewhere); !errors.As(err, &unsupported) {
+		t.Fatalf("post-only on a venue that ignores it: err = %v, want *ports.UnsupportedError", err)
+	}
+	req.TimeInForce, req.PostOnly = order.IOC, false
+	if got, err := toGCTSubmit("bybit", &fakeMatcher{pair: pair}, req); err != nil || got.TimeInForce != gctorder.ImmediateOrCancel {
+		t.Fatalf("ioc TimeInForce = %v, %v", got.TimeInForce, err)
+	}
+	req.TimeInForce, req.ExpiresAt = order.GTD, time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)
+	if _, err := toGCTSubmit("bybit", &fakeMatcher{pair: pair}, req); !errors.As(err, &unsupported) {
+		t.Fatalf("gtd err = %v, want *ports.UnsupportedError", err)
+	}
+	req.TimeInForce, req.ExpiresAt = order.GTC, time.Time{}
+	req.Type, req.TriggerPrice = order.StopLimit, decimal.RequireFromString("49000")
+	if _, err := toGCTSubmit("bybit", &fakeMatcher{pair: pair}, req); !errors.As(err, &unsupported) {
+		t.Fatalf("stop-limit err = %v, want *ports.UnsupportedError", err)
+	}
+	req.Type, req.TriggerPrice = order.Limit, decimal.Zero
+
 	req.Side = "short"
 	if _, err := toGCTSubmit("bybit", &fakeMatcher{pair: pair}, req); err == nil {
 		t.Fatal("unsupported side: want error")
@@ -183,6 +215,31 @@ func TestToSnapshot(t *testing.T) {
 	}
 }
 
+// TestPostOnlyVenuesPinned fails when the GCT module moves off the version
+// postOnlyVenues was read against, so a bump re-checks each wrapper's
+// SubmitOrder before a venue that dropped the flag can place post-only.
+func TestPostOnlyVenuesPinned(t *testing.T) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		t.Skip("test binary carries no module information")
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != "github.com/thrasher-corp/gocryptotrader" {
+			continue
+		}
+		version := dep.Version
+		if dep.Replace != nil {
+			version = dep.Replace.Version
+		}
+		if version != postOnlyCheckedAgainst {
+			t.Fatalf("GCT is %s, postOnlyVenues was checked against %s: re-read each wrapper's SubmitOrder, then update both",
+				version, postOnlyCheckedAgainst)
+		}
+		return
+	}
+	t.Fatal("GCT is not a dependency of this test binary")
+}
+
 func TestToOrderEvents(t *testing.T) {
 	single := detail(t, nil)
 	batch := []gctorder.Detail{*detail(t, nil), *detail(t, nil)}
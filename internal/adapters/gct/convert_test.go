@@ -9,6 +9,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchange/accounts"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 
 	"github.com/romanornr/delta-works/internal/domain/account"
 	"github.com/romanornr/delta-works/internal/domain/instrument"
@@ -128,6 +129,20 @@ func TestToTicker(t *testing.T) {
 	}
 }
 
+func TestToTradesOldestFirst(t *testing.T) {
+	at := time.Date(2026, 7, 2, 12, 0, 0, 0, time.UTC)
+	got := toTrades(btcusdt(), []trade.Data{
+		{TID: "2", Price: 50001, Amount: 0.5, Timestamp: at.Add(time.Second)},
+		{TID: "1", Price: 50000.5, Amount: 0.25, Timestamp: at},
+	})
+	if len(got) != 2 || got[0].ID != "1" || got[1].ID != "2" {
+		t.Fatalf("trades = %+v, want oldest first", got)
+	}
+	if !got[0].Price.Equal(decimal.NewFromFloat(50000.5)) || !got[0].Qty.Equal(decimal.NewFromFloat(0.25)) || !got[0].At.Equal(at) {
+		t.Errorf("first trade = %+v", got[0])
+	}
+}
+
 func TestToBalancesSumsAcrossSubAccounts(t *testing.T) {
 	subs := accounts.SubAccounts{
 		{
@@ -13,7 +13,14 @@ import (
 	"github.com/romanornr/delta-works/internal/ports"
 )
 
-var _ ports.OrderPlacer = (*Exchange)(nil)
+var (
+	_ ports.OrderPlacer    = (*Exchange)(nil)
+	_ ports.PostOnlyPlacer = (*Exchange)(nil)
+)
+
+// HonorsPostOnly implements ports.PostOnlyPlacer for the venues listed in
+// postOnlyVenues.
+func (e *Exchange) HonorsPostOnly() bool { return postOnlyVenues[e.id] }
 
 // PlaceOrder implements ports.OrderPlacer. The ClientOrderID rides to the
 // venue as the idempotency key; the caller has already persisted the
@@ -0,0 +1,142 @@
+package paper
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+)
+
+// quote is one top-of-book observation before it is stamped with an
+// instrument and a time.
+type quote struct {
+	Bid, Ask, Last decimal.Decimal
+}
+
+// feed produces the next quote for a market each simulation step.
+type feed interface {
+	next(m *market) quote
+}
+
+// randomWalk moves each market's last price by a normally distributed
+// relative step, rounded to the price increment, with a one-increment
+// spread on either side. A fixed seed replays the same path.
+type randomWalk struct {
+	rng        *rand.Rand
+	volatility float64
+}
+
+func newRandomWalk(seed uint64, volatility float64) *randomWalk {
+	return &randomWalk{rng: rand.New(rand.NewPCG(seed, seed)), volatility: volatility}
+}
+
+func (w *randomWalk) next(m *market) quote {
+	last := m.ticker.Last
+	step := decimal.NewFromFloat(w.rng.NormFloat64() * w.volatility)
+	moved := roundDown(last.Add(last.Mul(step)), m.inst.Rules.PriceIncrement)
+	if moved.IsPositive() {
+		last = moved
+	}
+	spread := m.inst.Rules.PriceIncrement
+	if !spread.IsPositive() {
+		spread = last.Mul(decimal.New(1, -4))
+	}
+	return quote{Bid: last.Sub(spread), Ask: last.Add(spread), Last: last}
+}
+
+// recording replays a captured ticker file. Each step advances every pair
+// by one row and wraps at the end, so a short capture drives an
+// arbitrarily long session.
+type recording struct {
+	rows map[string][]quote
+	pos  map[string]int
+}
+
+// loadRecording reads CSV rows of pair,bid,ask,last. An optional header
+// row starting with "pair" is skipped. Every configured pair must appear.
+func loadRecording(path string, pairs []string) (*recording, error) {
+	f, err := os.Open(path) //nolint:gosec // the operator chooses the feed path
+	if err != nil {
+		return nil, fmt.Errorf("open feed: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	return parseRecording(f, pairs)
+}
+
+func parseRecording(r io.Reader, pairs []string) (*recording, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 4
+	reader.Comment = '#'
+	rec := &recording{rows: map[string][]quote{}, pos: map[string]int{}}
+	for line := 1; ; line++ {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read feed: %w", err)
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(fields[0]), "pair") {
+			continue
+		}
+		pair, q, err := parseRow(fields)
+		if err != nil {
+			return nil, fmt.Errorf("feed line %d: %w", line, err)
+		}
+		rec.rows[pair] = append(rec.rows[pair], q)
+	}
+	for _, pair := range pairs {
+		if len(rec.rows[pair]) == 0 {
+			return nil, fmt.Errorf("feed has no rows for %s", pair)
+		}
+	}
+	return rec, nil
+}
+
+func parseRow(fields []string) (string, quote, error) {
+	base, quoteCurrency, err := instrument.ParsePair(fields[0])
+	if err != nil {
+		return "", quote{}, err
+	}
+	var values [3]decimal.Decimal
+	for i, field := range fields[1:] {
+		values[i], err = decimal.NewFromString(strings.TrimSpace(field))
+		if err != nil || !values[i].IsPositive() {
+			return "", quote{}, fmt.Errorf("price %q: must be a positive decimal", field)
+		}
+	}
+	if values[0].GreaterThan(values[1]) {
+		return "", quote{}, fmt.Errorf("bid %s above ask %s", values[0], values[1])
+	}
+	return string(base) + "/" + string(quoteCurrency), quote{Bid: values[0], Ask: values[1], Last: values[2]}, nil
+}
+
+func (r *recording) next(m *market) quote {
+	pair := m.inst.Pair()
+	rows := r.rows[pair]
+	q := rows[r.pos[pair]%len(rows)]
+	r.pos[pair]++
+	return q
+}
+
+// roundDown truncates v to a multiple of increment. A zero increment
+// leaves v unchanged.
+func roundDown(v, increment decimal.Decimal) decimal.Decimal {
+	if !increment.IsPositive() {
+		return v
+	}
+	return v.Div(increment).Floor().Mul(increment)
+}
+
+// onIncrement reports whether v is a whole multiple of increment. Every
+// value is on a zero increment.
+func onIncrement(v, increment decimal.Decimal) bool {
+	return !increment.IsPositive() || v.Mod(increment).IsZero()
+}
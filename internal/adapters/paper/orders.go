@@ -0,0 +1,384 @@
+package paper
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// streamBuffer sizes each subscriber's channel. Events are never allowed
+// to block the simulation: a full subscriber misses them and
+// reconciliation recovers the state, as it would after a socket drop.
+const streamBuffer = 256
+
+type paperOrder struct {
+	req      order.Request
+	ref      order.Ref
+	status   order.Status
+	filled   decimal.Decimal
+	notional decimal.Decimal // sum of fill qty × price, for the average
+	fills    int
+	// reserved is what the order still locks in reserveIn: quote for a
+	// buy at reservePrice (fee included), base for a sell.
+	reserved     decimal.Decimal
+	reserveIn    money.Currency
+	reservePrice decimal.Decimal
+	placedAt     time.Time
+	updatedAt    time.Time
+}
+
+func (o *paperOrder) snapshot() order.Snapshot {
+	snap := order.Snapshot{
+		Ref: o.ref, Status: o.status,
+		Price: o.req.Price, Qty: o.req.Qty, FilledQty: o.filled,
+		UpdatedAt: o.updatedAt,
+	}
+	if o.filled.IsPositive() {
+		snap.AvgFillPrice = o.notional.Div(o.filled)
+	}
+	return snap
+}
+
+func (o *paperOrder) ack() order.Ack {
+	return order.Ack{Ref: o.ref, Status: o.status, AcceptedAt: o.placedAt}
+}
+
+// PlaceOrder implements ports.OrderPlacer. The client order ID is echoed
+// and is the idempotency key: a repeated submit returns the existing
+// order. Orders that break the instrument rules or the free balance are
+// rejected the way a venue would, with the reason on the stream. Matching
+// happens only at feed steps, so the ack always precedes the first fill.
+func (e *Exchange) PlaceOrder(_ context.Context, req order.Request) (order.Ack, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := e.clk.Now()
+	e.sync(now)
+	if existing, ok := e.orders[req.ClientOrderID]; ok {
+		return existing.ack(), nil
+	}
+
+	e.seq++
+	o := &paperOrder{
+		req: req,
+		ref: order.Ref{
+			Instrument:    req.Instrument,
+			ClientOrderID: req.ClientOrderID,
+			VenueOrderID:  fmt.Sprintf("%s-%06d", e.id, e.seq),
+		},
+		status:   order.StatusOpen,
+		placedAt: now, updatedAt: now,
+	}
+	e.orders[req.ClientOrderID] = o
+	e.byVenueID[o.ref.VenueOrderID] = o
+	if reason := e.admit(o); reason != "" {
+		e.finish(o, order.StatusRejected, reason, now)
+		return o.ack(), nil
+	}
+	e.open = append(e.open, o)
+	return o.ack(), nil
+}
+
+// admit checks the order against its market's rules and the free balance
+// and reserves funds for it. It returns the rejection reason, or "".
+// HonorsPostOnly implements ports.PostOnlyPlacer: a post-only order that
+// would take is rejected at admission.
+func (*Exchange) HonorsPostOnly() bool { return true }
+
+func (e *Exchange) admit(o *paperOrder) string {
+	req := o.req
+	m, ok := e.markets[req.Instrument.Pair()]
+	if !ok {
+		return "unknown instrument " + req.Instrument.Pair()
+	}
+	o.ref.Instrument = m.inst
+	if reason := checkRequest(req, m); reason != "" {
+		return reason
+	}
+	if _, takes := marketable(req, m); takes && req.PostOnly {
+		return fmt.Sprintf("post-only price %s would take liquidity", req.Price)
+	}
+	switch req.Side {
+	case order.Buy:
+		o.reserveIn = m.inst.Quote
+		o.reservePrice = req.Price
+		if req.Type == order.Market {
+			o.reservePrice = m.ticker.Ask
+		}
+		o.reservePrice = o.reservePrice.Mul(decimal.NewFromInt(1).Add(e.feeRate))
+		o.reserved = req.Qty.Mul(o.reservePrice)
+	case order.Sell:
+		o.reserveIn = m.inst.Base
+		o.reservePrice = decimal.NewFromInt(1)
+		o.reserved = req.Qty
+	}
+	if free := e.balances[o.reserveIn].Sub(e.locked()[o.reserveIn]); free.LessThan(o.reserved) {
+		return fmt.Sprintf("insufficient %s balance: need %s, free %s", o.reserveIn, o.reserved, free)
+	}
+	return ""
+}
+
+// checkRequest enforces instrument.Rules the way a venue does: by
+// rejecting, never by silently rounding.
+func checkRequest(req order.Request, m *market) string {
+	rules := m.inst.Rules
+	switch {
+	case req.Side != order.Buy && req.Side != order.Sell:
+		return fmt.Sprintf("unsupported side %q", req.Side)
+	case req.Type != order.Limit && req.Type != order.Market:
+		return fmt.Sprintf("unsupported order type %q", req.Type)
+	case !slices.Contains([]order.TimeInForce{"", order.GTC, order.IOC, order.FOK, order.GTD}, req.TimeInForce):
+		return fmt.Sprintf("unsupported time in force %q", req.TimeInForce)
+	case req.TimeInForce == order.GTD && req.ExpiresAt.IsZero():
+		return "gtd order needs an expiry"
+	case req.PostOnly && req.Type != order.Limit:
+		return "post-only needs a limit order"
+	case !req.Qty.IsPositive():
+		return "qty must be positive"
+	case req.Type == order.Limit && !req.Price.IsPositive():
+		return "limit price must be positive"
+	case !onIncrement(req.Qty, rules.QtyIncrement):
+		return fmt.Sprintf("qty %s is not a multiple of %s", req.Qty, rules.QtyIncrement)
+	case req.Qty.LessThan(rules.MinQty):
+		return fmt.Sprintf("qty %s below minimum %s", req.Qty, rules.MinQty)
+	case req.Type == order.Limit && !onIncrement(req.Price, rules.PriceIncrement):
+		return fmt.Sprintf("price %s is not a multiple of %s", req.Price, rules.PriceIncrement)
+	}
+	price := req.Price
+	if req.Type == order.Market {
+		price = m.ticker.Last
+	}
+	if notional := req.Qty.Mul(price); notional.LessThan(rules.MinNotional) {
+		return fmt.Sprintf("notional %s below minimum %s", notional, rules.MinNotional)
+	}
+	return ""
+}
+
+// locked sums what resting orders reserve per currency. Callers hold mu.
+func (e *Exchange) locked() map[money.Currency]decimal.Decimal {
+	out := map[money.Currency]decimal.Decimal{}
+	for _, o := range e.open {
+		out[o.reserveIn] = out[o.reserveIn].Add(o.reserved)
+	}
+	return out
+}
+
+// marketable reports whether req would trade against m's current quote,
+// and at what price: buys take the ask, sells hit the bid.
+func marketable(req order.Request, m *market) (decimal.Decimal, bool) {
+	price := m.ticker.Ask
+	takes := req.Type == order.Market || !req.Price.LessThan(price)
+	if req.Side == order.Sell {
+		price = m.ticker.Bid
+		takes = req.Type == order.Market || !req.Price.GreaterThan(price)
+	}
+	return price, takes && price.IsPositive()
+}
+
+// match fills o against its market's current quote when marketable. Each
+// step fills at most fillRatio of the order quantity, rounded down to the
+// quantity increment, which is how partial fills reach the stream; a FOK
+// order fills whole, since the simulated book has no depth to run out of.
+func (e *Exchange) match(o *paperOrder, at time.Time) {
+	m := e.markets[o.ref.Instrument.Pair()]
+	price, ok := marketable(o.req, m)
+	if !ok {
+		return
+	}
+	remaining := o.req.Qty.Sub(o.filled)
+	qty := roundDown(o.req.Qty.Mul(e.fillRatio), m.inst.Rules.QtyIncrement)
+	if !qty.IsPositive() || qty.GreaterThan(remaining) || o.req.TimeInForce == order.FOK {
+		qty = remaining
+	}
+	gross := qty.Mul(price)
+	fee := gross.Mul(e.feeRate)
+	base, quoteCurrency := m.inst.Base, m.inst.Quote
+	if o.req.Side == order.Buy {
+		e.balances[quoteCurrency] = e.balances[quoteCurrency].Sub(gross).Sub(fee)
+		e.balances[base] = e.balances[base].Add(qty)
+	} else {
+		e.balances[base] = e.balances[base].Sub(qty)
+		e.balances[quoteCurrency] = e.balances[quoteCurrency].Add(gross).Sub(fee)
+	}
+	o.reserved = decimal.Max(decimal.Zero, o.reserved.Sub(qty.Mul(o.reservePrice)))
+	o.filled = o.filled.Add(qty)
+	o.notional = o.notional.Add(gross)
+	o.fills++
+	o.status = order.StatusPartiallyFilled
+	if o.filled.Equal(o.req.Qty) {
+		o.status = order.StatusFilled
+		o.reserved = decimal.Zero
+	}
+	o.updatedAt = at
+	e.emit(order.Event{
+		Ref: o.ref, Status: o.status, FilledQty: o.filled,
+		FillPrice:   price,
+		VenueFillID: fmt.Sprintf("%s-%d", o.ref.VenueOrderID, o.fills),
+		Fee:         fee, FeeCurrency: quoteCurrency,
+		At: at,
+	})
+}
+
+// finish moves o to a terminal status and releases its reservation.
+func (e *Exchange) finish(o *paperOrder, status order.Status, reason string, at time.Time) {
+	o.status = status
+	o.reserved = decimal.Zero
+	o.updatedAt = at
+	e.emit(order.Event{Ref: o.ref, Status: status, FilledQty: o.filled, Reason: reason, At: at})
+}
+
+// CancelOrder implements ports.OrderPlacer. Canceling a terminal order is
+// a no-op, as the intent is already satisfied; the canceled state arrives
+// on the stream.
+func (e *Exchange) CancelOrder(_ context.Context, ref order.Ref) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := e.clk.Now()
+	e.sync(now)
+	o, err := e.find(ref)
+	if err != nil {
+		return err
+	}
+	if o.status.Terminal() {
+		return nil
+	}
+	e.finish(o, order.StatusCanceled, "canceled by request", now)
+	for i, candidate := range e.open {
+		if candidate == o {
+			e.open = append(e.open[:i], e.open[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// AmendOrder implements ports.OrderAmender. The order keeps its IDs, its
+// fills and its place in the book; the new terms face the rules and the
+// free balance like a new order would, and a refused amend leaves the
+// order as it was. Only resting limit orders can be amended.
+func (e *Exchange) AmendOrder(_ context.Context, ref order.Ref, req order.Request) (order.Ack, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := e.clk.Now()
+	e.sync(now)
+	o, err := e.find(ref)
+	if err != nil {
+		return order.Ack{}, err
+	}
+	refuse := func(format string, args ...any) (order.Ack, error) {
+		return order.Ack{}, fmt.Errorf("paper: amend %s order %s: %s", e.id, ref.ClientOrderID, fmt.Sprintf(format, args...))
+	}
+	switch {
+	case o.status.Terminal():
+		return refuse("order is %s", o.status)
+	case o.req.Type != order.Limit:
+		return refuse("only limit orders can be amended")
+	case !req.Qty.GreaterThan(o.filled):
+		return refuse("qty %s does not exceed the filled %s", req.Qty, o.filled)
+	}
+	amended := o.req
+	amended.Price, amended.Qty = req.Price, req.Qty
+	m := e.markets[o.ref.Instrument.Pair()]
+	if reason := checkRequest(amended, m); reason != "" {
+		return refuse("%s", reason)
+	}
+	if _, takes := marketable(amended, m); takes && amended.PostOnly {
+		return refuse("post-only price %s would take liquidity", amended.Price)
+	}
+	reservePrice := o.reservePrice
+	if amended.Side == order.Buy {
+		reservePrice = amended.Price.Mul(decimal.NewFromInt(1).Add(e.feeRate))
+	}
+	reserved := amended.Qty.Sub(o.filled).Mul(reservePrice)
+	if free := e.balances[o.reserveIn].Sub(e.locked()[o.reserveIn]).Add(o.reserved); free.LessThan(reserved) {
+		return refuse("insufficient %s balance: need %s, free %s", o.reserveIn, reserved, free)
+	}
+	o.req = amended
+	o.reservePrice, o.reserved = reservePrice, reserved
+	o.updatedAt = now
+	return o.ack(), nil
+}
+
+// find looks an order up by venue ID, then by client ID. Callers hold mu.
+func (e *Exchange) find(ref order.Ref) (*paperOrder, error) {
+	if o, ok := e.byVenueID[ref.VenueOrderID]; ok {
+		return o, nil
+	}
+	if o, ok := e.orders[ref.ClientOrderID]; ok {
+		return o, nil
+	}
+	return nil, fmt.Errorf("%w: paper %s order %s", ports.ErrNotFound, e.id, ref.ClientOrderID)
+}
+
+// OpenOrders implements ports.OrderPlacer.
+func (e *Exchange) OpenOrders(context.Context) ([]order.Snapshot, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.sync(e.clk.Now())
+	out := make([]order.Snapshot, 0, len(e.open))
+	for _, o := range e.open {
+		out = append(out, o.snapshot())
+	}
+	return out, nil
+}
+
+// GetOrder implements ports.OrderPlacer.
+func (e *Exchange) GetOrder(_ context.Context, ref order.Ref) (order.Snapshot, error) {
+	if ref.VenueOrderID == "" {
+		return order.Snapshot{}, fmt.Errorf("paper: get order %s client %s: %w", e.id, ref.ClientOrderID, ports.ErrNoVenueOrderID)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.sync(e.clk.Now())
+	o, ok := e.byVenueID[ref.VenueOrderID]
+	if !ok {
+		return order.Snapshot{}, fmt.Errorf("%w: paper %s order %s", ports.ErrNotFound, e.id, ref.VenueOrderID)
+	}
+	return o.snapshot(), nil
+}
+
+// StreamOrderEvents implements ports.PrivateStreamer. The subscription
+// also drives the clock: each interval it steps the simulation, so fills
+// and expiries are reported without anyone polling.
+func (e *Exchange) StreamOrderEvents(ctx context.Context) (<-chan order.Event, error) {
+	out := make(chan order.Event, streamBuffer)
+	e.mu.Lock()
+	e.subscribers[out] = struct{}{}
+	e.mu.Unlock()
+	ticker := e.clk.NewTicker(e.interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				e.mu.Lock()
+				delete(e.subscribers, out)
+				close(out)
+				e.mu.Unlock()
+				return
+			case <-ticker.Chan():
+				e.mu.Lock()
+				e.sync(e.clk.Now())
+				e.mu.Unlock()
+			}
+		}
+	}()
+	return out, nil
+}
+
+// emit fans ev out without blocking. Callers hold mu, which also keeps a
+// subscriber from closing mid-send.
+func (e *Exchange) emit(ev order.Event) {
+	for ch := range e.subscribers {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
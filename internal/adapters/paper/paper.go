@@ -0,0 +1,300 @@
+// Package paper simulates a venue so the daemon (order service,
+// reconciliation, ledger, outbox) can run end to end without money at
+// risk. Prices come from a seeded random walk or a recorded ticker file;
+// resting orders match against each new quote, honor the configured
+// instrument rules, time in force and post-only, and report partial fills,
+// cancels and expiries on the private stream exactly like a real adapter
+// would.
+package paper
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/config"
+	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+const (
+	defaultInterval = time.Second
+	// maxCatchUpSteps bounds the steps replayed after a long idle gap.
+	// Older steps are skipped: nothing observed them, and replaying hours
+	// of one-second steps under the lock would stall every caller.
+	maxCatchUpSteps = 1000
+)
+
+// Exchange is a simulated venue implementing ports.Exchange,
+// ports.OrderPlacer, ports.OrderAmender and ports.PrivateStreamer. Time advances in fixed
+// steps on the injected clock; every call first catches the simulation
+// up to now, so results depend only on the clock and the feed.
+type Exchange struct {
+	id        instrument.VenueID
+	clk       clockwork.Clock
+	interval  time.Duration
+	fillRatio decimal.Decimal
+	orderTTL  time.Duration
+	feeRate   decimal.Decimal
+
+	mu          sync.Mutex
+	feed        feed
+	markets     map[string]*market // by canonical pair
+	pairs       []string           // stepping order, for deterministic replays
+	steppedAt   time.Time
+	balances    map[money.Currency]decimal.Decimal
+	orders      map[order.ClientOrderID]*paperOrder
+	byVenueID   map[string]*paperOrder
+	open        []*paperOrder // resting orders in placement order
+	seq         int
+	subscribers map[chan order.Event]struct{}
+}
+
+var (
+	_ ports.Exchange        = (*Exchange)(nil)
+	_ ports.OrderPlacer     = (*Exchange)(nil)
+	_ ports.OrderAmender    = (*Exchange)(nil)
+	_ ports.PostOnlyPlacer  = (*Exchange)(nil)
+	_ ports.PrivateStreamer = (*Exchange)(nil)
+)
+
+type market struct {
+	inst   instrument.Instrument
+	ticker marketdata.Ticker
+}
+
+// New builds the simulated venue from its config section. Values that
+// cannot be parsed fail here, at startup, with the config key that holds
+// them.
+func New(venue string, cfg config.Paper, clk clockwork.Clock) (*Exchange, error) {
+	id := instrument.NewVenueID(venue)
+	prefix := "venues." + venue + ".paper"
+	e := &Exchange{
+		id:          id,
+		clk:         clk,
+		interval:    cfg.Interval,
+		fillRatio:   decimal.NewFromFloat(cfg.FillRatio),
+		orderTTL:    cfg.OrderTTL,
+		markets:     map[string]*market{},
+		balances:    map[money.Currency]decimal.Decimal{},
+		orders:      map[order.ClientOrderID]*paperOrder{},
+		byVenueID:   map[string]*paperOrder{},
+		subscribers: map[chan order.Event]struct{}{},
+		steppedAt:   clk.Now(),
+	}
+	if e.interval == 0 {
+		e.interval = defaultInterval
+	}
+	if cfg.FillRatio == 0 {
+		e.fillRatio = decimal.NewFromInt(1)
+	}
+	var err error
+	if e.feeRate, err = parseDecimal(cfg.FeeRate); err != nil {
+		return nil, fmt.Errorf("%s.fee_rate: %w", prefix, err)
+	}
+	for code, amount := range cfg.Balances {
+		value, err := parseDecimal(amount)
+		if err != nil {
+			return nil, fmt.Errorf("%s.balances.%s: %w", prefix, code, err)
+		}
+		e.balances[money.NewCurrency(code)] = value
+	}
+	for i, spec := range cfg.Instruments {
+		m, err := newMarket(id, spec)
+		if err != nil {
+			return nil, fmt.Errorf("%s.instruments[%d]: %w", prefix, i, err)
+		}
+		pair := m.inst.Pair()
+		if _, dup := e.markets[pair]; dup {
+			return nil, fmt.Errorf("%s.instruments[%d]: duplicate pair %s", prefix, i, pair)
+		}
+		e.markets[pair] = m
+		e.pairs = append(e.pairs, pair)
+	}
+	slices.Sort(e.pairs)
+	if cfg.Feed == "" {
+		e.feed = newRandomWalk(cfg.Seed, cfg.Volatility)
+	} else if e.feed, err = loadRecording(cfg.Feed, e.pairs); err != nil {
+		return nil, fmt.Errorf("%s.feed: %w", prefix, err)
+	}
+	// The first quote exists before the first step so a fresh venue
+	// already has a book to price against.
+	now := clk.Now()
+	for _, pair := range e.pairs {
+		e.quote(e.markets[pair], now)
+	}
+	return e, nil
+}
+
+func newMarket(venue instrument.VenueID, spec config.PaperInstrument) (*market, error) {
+	base, quoteCurrency, err := instrument.ParsePair(spec.Pair)
+	if err != nil {
+		return nil, err
+	}
+	var rules instrument.Rules
+	fields := []struct {
+		name string
+		raw  string
+		dst  *decimal.Decimal
+	}{
+		{"price_increment", spec.PriceIncrement, &rules.PriceIncrement},
+		{"qty_increment", spec.QtyIncrement, &rules.QtyIncrement},
+		{"min_qty", spec.MinQty, &rules.MinQty},
+		{"min_notional", spec.MinNotional, &rules.MinNotional},
+	}
+	for _, field := range fields {
+		if *field.dst, err = parseDecimal(field.raw); err != nil {
+			return nil, fmt.Errorf("%s: %w", field.name, err)
+		}
+	}
+	// A recorded feed supplies its own prices; the configured one only
+	// seeds the random walk.
+	last, err := parseDecimal(spec.Price)
+	if err != nil {
+		return nil, fmt.Errorf("price: %w", err)
+	}
+	inst := instrument.Instrument{
+		Venue: venue, Type: instrument.TypeSpot,
+		Base: base, Quote: quoteCurrency,
+		VenueSymbol: string(base) + string(quoteCurrency),
+		Rules:       rules,
+	}
+	return &market{inst: inst, ticker: marketdata.Ticker{Instrument: inst, Last: last}}, nil
+}
+
+// parseDecimal reads a non-negative decimal. Empty means zero: every
+// decimal in the section is optional.
+func parseDecimal(raw string) (decimal.Decimal, error) {
+	if raw == "" {
+		return decimal.Zero, nil
+	}
+	v, err := decimal.NewFromString(raw)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("%q: not a decimal", raw)
+	}
+	if v.IsNegative() {
+		return decimal.Zero, fmt.Errorf("%q: must not be negative", raw)
+	}
+	return v, nil
+}
+
+// ID implements ports.Exchange.
+func (e *Exchange) ID() instrument.VenueID { return e.id }
+
+// Ticker implements ports.MarketDataReader.
+func (e *Exchange) Ticker(_ context.Context, inst instrument.Instrument) (marketdata.Ticker, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.sync(e.clk.Now())
+	m, ok := e.markets[inst.Pair()]
+	if !ok {
+		return marketdata.Ticker{}, fmt.Errorf("%w: paper %s has no market %s", ports.ErrNotFound, e.id, inst.Pair())
+	}
+	ticker := m.ticker
+	ticker.Instrument = inst
+	return ticker, nil
+}
+
+// Instruments implements ports.MarketDataReader. Unlike most adapters the
+// simulator knows its rules, so they are always populated.
+func (e *Exchange) Instruments(_ context.Context, typ instrument.Type) ([]instrument.Instrument, error) {
+	if typ != instrument.TypeSpot {
+		return nil, nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	out := make([]instrument.Instrument, 0, len(e.pairs))
+	for _, pair := range e.pairs {
+		out = append(out, e.markets[pair].inst)
+	}
+	return out, nil
+}
+
+// Balances implements ports.AccountReader. Locked is what resting orders
+// reserve; fills move Total.
+func (e *Exchange) Balances(_ context.Context, acct account.Type) ([]account.Balance, error) {
+	if acct != account.TypeSpot {
+		return nil, fmt.Errorf("%w: paper %s simulates spot only, got %s", ports.ErrUnsupportedAccount, e.id, acct)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.sync(e.clk.Now())
+	locked := e.locked()
+	currencies := make([]money.Currency, 0, len(e.balances))
+	for currency := range e.balances {
+		currencies = append(currencies, currency)
+	}
+	for currency := range locked {
+		if _, ok := e.balances[currency]; !ok {
+			currencies = append(currencies, currency)
+		}
+	}
+	slices.Sort(currencies)
+	out := make([]account.Balance, 0, len(currencies))
+	for _, currency := range currencies {
+		total := e.balances[currency]
+		out = append(out, account.Balance{
+			Currency: currency,
+			Total:    total,
+			Free:     total.Sub(locked[currency]),
+			Locked:   locked[currency],
+		})
+	}
+	return out, nil
+}
+
+// sync advances the simulation in whole steps up to now. Callers hold mu.
+func (e *Exchange) sync(now time.Time) {
+	if behind := int64(now.Sub(e.steppedAt) / e.interval); behind > maxCatchUpSteps {
+		e.steppedAt = e.steppedAt.Add(time.Duration(behind-maxCatchUpSteps) * e.interval)
+	}
+	for next := e.steppedAt.Add(e.interval); !next.After(now); next = e.steppedAt.Add(e.interval) {
+		e.steppedAt = next
+		e.step(next)
+	}
+}
+
+// step draws one quote per market, then matches and expires resting
+// orders against it in placement order. Matching starts at the first step
+// after placement, so that step is "arrival" for IOC and FOK orders.
+func (e *Exchange) step(at time.Time) {
+	for _, pair := range e.pairs {
+		e.quote(e.markets[pair], at)
+	}
+	resting := e.open[:0]
+	for _, o := range e.open {
+		e.match(o, at)
+		switch tif := o.req.TimeInForce; {
+		case o.status.Terminal():
+		case tif == order.IOC || tif == order.FOK:
+			e.finish(o, order.StatusExpired, string(tif)+" order not filled on arrival", at)
+		case tif == order.GTD && !at.Before(o.req.ExpiresAt):
+			e.finish(o, order.StatusExpired, "gtd expiry reached", at)
+		case e.orderTTL > 0 && !at.Before(o.placedAt.Add(e.orderTTL)):
+			e.finish(o, order.StatusExpired, "order ttl elapsed", at)
+		}
+		if !o.status.Terminal() {
+			resting = append(resting, o)
+		}
+	}
+	clear(e.open[len(resting):])
+	e.open = resting
+}
+
+func (e *Exchange) quote(m *market, at time.Time) {
+	q := e.feed.next(m)
+	m.ticker = marketdata.Ticker{
+		Instrument: m.inst,
+		Bid:        q.Bid, Ask: q.Ask, Last: q.Last,
+		At: at,
+	}
+}
@@ -0,0 +1,383 @@
+package paper
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/config"
+	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/ports"
+	"github.com/romanornr/delta-works/internal/ports/portstest"
+)
+
+var start = time.Date(2026, 7, 20, 12, 0, 0, 0, time.UTC)
+
+// newTestExchange builds a flat market (zero volatility): BTC/USDT last
+// 50000, bid 49999.9, ask 50000.1.
+func newTestExchange(t *testing.T, mutate func(*config.Paper)) (*Exchange, *clockwork.FakeClock) {
+	t.Helper()
+	cfg := config.Paper{
+		Interval: time.Second,
+		FeeRate:  "0.001",
+		Balances: map[string]string{"usdt": "100000", "BTC": "1"},
+		Instruments: []config.PaperInstrument{{
+			Pair: "BTC/USDT", Price: "50000",
+			PriceIncrement: "0.1", QtyIncrement: "0.001", MinQty: "0.001", MinNotional: "10",
+		}},
+	}
+	if mutate != nil {
+		mutate(&cfg)
+	}
+	clk := clockwork.NewFakeClockAt(start)
+	ex, err := New("paper", cfg, clk)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return ex, clk
+}
+
+func btc(t *testing.T, ex *Exchange) instrument.Instrument {
+	t.Helper()
+	insts, err := ex.Instruments(t.Context(), instrument.TypeSpot)
+	if err != nil || len(insts) != 1 {
+		t.Fatalf("Instruments = %+v, %v", insts, err)
+	}
+	return insts[0]
+}
+
+func subscribe(t *testing.T, ex *Exchange) <-chan order.Event {
+	t.Helper()
+	events, err := ex.StreamOrderEvents(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+func nextEvent(t *testing.T, events <-chan order.Event) order.Event {
+	t.Helper()
+	select {
+	case ev := <-events:
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an order event")
+		return order.Event{}
+	}
+}
+
+// step advances the fake clock one interval and syncs synchronously, so
+// the step's events are on the stream when it returns.
+func step(t *testing.T, ex *Exchange, clk *clockwork.FakeClock) {
+	t.Helper()
+	clk.Advance(time.Second)
+	if _, err := ex.OpenOrders(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOrderPlacerContract(t *testing.T) {
+	t.Parallel()
+	ex, _ := newTestExchange(t, nil)
+	inst := btc(t, ex)
+	portstest.RunOrderPlacerContract(t, ex, portstest.Fixture{
+		Instrument:  inst,
+		MinQty:      inst.Rules.MinQty,
+		MinNotional: inst.Rules.MinNotional,
+		NonMarketablePrice: func(ctx context.Context) (decimal.Decimal, error) {
+			ticker, err := ex.Ticker(ctx, inst)
+			return roundDown(ticker.Bid.Div(decimal.NewFromInt(2)), inst.Rules.PriceIncrement), err
+		},
+		EchoesClientOrderID: true,
+		Deadline:            5 * time.Second,
+		Cleanup:             portstest.CleanupPlacedOrders,
+	})
+}
+
+func TestPartialFillsSettleBalances(t *testing.T) {
+	t.Parallel()
+	ex, clk := newTestExchange(t, func(c *config.Paper) { c.FillRatio = 0.5 })
+	events := subscribe(t, ex)
+	req := order.Request{
+		ClientOrderID: "buy-1", BotID: "grid-1", Instrument: btc(t, ex),
+		Side: order.Buy, Type: order.Limit,
+		Price: decimal.RequireFromString("50000.5"), Qty: decimal.RequireFromString("0.5"),
+	}
+	ack, err := ex.PlaceOrder(t.Context(), req)
+	if err != nil || ack.Status != order.StatusOpen || ack.Ref.ClientOrderID != "buy-1" || ack.Ref.VenueOrderID == "" {
+		t.Fatalf("ack = %+v, err = %v", ack, err)
+	}
+
+	step(t, ex, clk)
+	first := nextEvent(t, events)
+	if first.Status != order.StatusPartiallyFilled || !first.FilledQty.Equal(decimal.RequireFromString("0.25")) ||
+		!first.FillPrice.Equal(decimal.RequireFromString("50000.1")) || first.FeeCurrency != "USDT" ||
+		!first.Fee.Equal(decimal.RequireFromString("12.500025")) || first.VenueFillID == "" {
+		t.Fatalf("first fill = %+v", first)
+	}
+	step(t, ex, clk)
+	second := nextEvent(t, events)
+	if second.Status != order.StatusFilled || !second.FilledQty.Equal(req.Qty) || second.VenueFillID == first.VenueFillID {
+		t.Fatalf("second fill = %+v", second)
+	}
+
+	snapshot, err := ex.GetOrder(t.Context(), ack.Ref)
+	if err != nil || snapshot.Status != order.StatusFilled || !snapshot.AvgFillPrice.Equal(decimal.RequireFromString("50000.1")) {
+		t.Fatalf("GetOrder = %+v, %v", snapshot, err)
+	}
+	balances, err := ex.Balances(t.Context(), account.TypeSpot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"BTC": "1.5", "USDT": "74974.94995"}
+	for _, b := range balances {
+		if !b.Total.Equal(decimal.RequireFromString(want[string(b.Currency)])) || !b.Locked.IsZero() {
+			t.Errorf("%s balance = %+v, want total %s unlocked", b.Currency, b, want[string(b.Currency)])
+		}
+	}
+}
+
+func TestRestingOrderLocksFunds(t *testing.T) {
+	t.Parallel()
+	ex, _ := newTestExchange(t, nil)
+	if _, err := ex.PlaceOrder(t.Context(), order.Request{
+		ClientOrderID: "sell-1", Instrument: btc(t, ex), Side: order.Sell, Type: order.Limit,
+		Price: decimal.RequireFromString("60000"), Qty: decimal.RequireFromString("0.4"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	balances, err := ex.Balances(t.Context(), account.TypeSpot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balances[0].Currency != "BTC" || !balances[0].Locked.Equal(decimal.RequireFromString("0.4")) ||
+		!balances[0].Free.Equal(decimal.RequireFromString("0.6")) {
+		t.Fatalf("BTC balance = %+v", balances[0])
+	}
+	if _, err := ex.Balances(t.Context(), account.TypeMargin); err == nil {
+		t.Fatal("margin balances succeeded on a spot-only simulator")
+	}
+}
+
+func TestAmendKeepsFillsAndRelocksFunds(t *testing.T) {
+	t.Parallel()
+	ex, clk := newTestExchange(t, func(c *config.Paper) { c.FillRatio = 0.5 })
+	events := subscribe(t, ex)
+	ack, err := ex.PlaceOrder(t.Context(), order.Request{
+		ClientOrderID: "buy-1", Instrument: btc(t, ex), Side: order.Buy, Type: order.Limit,
+		Price: decimal.RequireFromString("50000.5"), Qty: decimal.RequireFromString("0.5"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	step(t, ex, clk)
+	if ev := nextEvent(t, events); !ev.FilledQty.Equal(decimal.RequireFromString("0.25")) {
+		t.Fatalf("fill = %+v", ev)
+	}
+
+	amend := func(price, qty string) (order.Ack, error) {
+		return ex.AmendOrder(t.Context(), ack.Ref, order.Request{
+			ClientOrderID: "buy-1", Price: decimal.RequireFromString(price), Qty: decimal.RequireFromString(qty),
+		})
+	}
+	for _, refused := range []struct{ price, qty, reason string }{
+		{"40000", "0.2", "does not exceed the filled"},
+		{"40000.05", "0.4", "not a multiple"},
+		{"40000", "3", "insufficient USDT"},
+	} {
+		if _, err := amend(refused.price, refused.qty); err == nil || !strings.Contains(err.Error(), refused.reason) {
+			t.Fatalf("amend %s @ %s = %v, want %q", refused.qty, refused.price, err, refused.reason)
+		}
+	}
+	amended, err := amend("40000", "0.4")
+	if err != nil || amended.Ref != ack.Ref || amended.Status != order.StatusPartiallyFilled {
+		t.Fatalf("amend = %+v, %v", amended, err)
+	}
+
+	step(t, ex, clk)
+	snapshot, err := ex.GetOrder(t.Context(), ack.Ref)
+	if err != nil || !snapshot.Price.Equal(decimal.RequireFromString("40000")) ||
+		!snapshot.Qty.Equal(decimal.RequireFromString("0.4")) || !snapshot.FilledQty.Equal(decimal.RequireFromString("0.25")) {
+		t.Fatalf("GetOrder after amend = %+v, %v", snapshot, err)
+	}
+	balances, err := ex.Balances(t.Context(), account.TypeSpot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The unfilled 0.15 at the new price, fee included.
+	if usdt := balances[1]; usdt.Currency != "USDT" || !usdt.Locked.Equal(decimal.RequireFromString("6006")) {
+		t.Fatalf("USDT balance = %+v", usdt)
+	}
+
+	if err := ex.CancelOrder(t.Context(), ack.Ref); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := amend("40000", "0.5"); err == nil || !strings.Contains(err.Error(), "canceled") {
+		t.Fatalf("amend canceled order = %v", err)
+	}
+}
+
+func TestPlaceRejectsRuleViolations(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name, side, typ, price, qty, reason string
+	}{
+		{"qty off increment", "buy", "limit", "50000", "0.0015", "not a multiple"},
+		{"qty below minimum", "buy", "limit", "50000", "0", "must be positive"},
+		{"price off increment", "buy", "limit", "50000.05", "0.01", "not a multiple"},
+		{"notional below minimum", "buy", "limit", "1000", "0.001", "notional"},
+		{"insufficient quote", "buy", "limit", "50000", "3", "insufficient USDT"},
+		{"insufficient base", "sell", "limit", "50000", "2", "insufficient BTC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ex, _ := newTestExchange(t, nil)
+			events := subscribe(t, ex)
+			ack, err := ex.PlaceOrder(t.Context(), order.Request{
+				ClientOrderID: order.ClientOrderID(tt.name), Instrument: btc(t, ex),
+				Side: order.Side(tt.side), Type: order.Type(tt.typ),
+				Price: decimal.RequireFromString(tt.price), Qty: decimal.RequireFromString(tt.qty),
+			})
+			if err != nil || ack.Status != order.StatusRejected {
+				t.Fatalf("ack = %+v, err = %v", ack, err)
+			}
+			if ev := nextEvent(t, events); ev.Status != order.StatusRejected || !strings.Contains(ev.Reason, tt.reason) {
+				t.Fatalf("event = %+v, want reason containing %q", ev, tt.reason)
+			}
+		})
+	}
+}
+
+func TestCancelAndExpiry(t *testing.T) {
+	t.Parallel()
+	ex, clk := newTestExchange(t, func(c *config.Paper) { c.OrderTTL = 2 * time.Second })
+	events := subscribe(t, ex)
+	inst := btc(t, ex)
+	place := func(id order.ClientOrderID) order.Ack {
+		ack, err := ex.PlaceOrder(t.Context(), order.Request{
+			ClientOrderID: id, Instrument: inst, Side: order.Buy, Type: order.Limit,
+			Price: decimal.RequireFromString("40000"), Qty: decimal.RequireFromString("0.01"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ack
+	}
+	canceled, expiring := place("cancel-me"), place("expire-me")
+
+	if err := ex.CancelOrder(t.Context(), canceled.Ref); err != nil {
+		t.Fatal(err)
+	}
+	if ev := nextEvent(t, events); ev.Ref.ClientOrderID != "cancel-me" || ev.Status != order.StatusCanceled {
+		t.Fatalf("cancel event = %+v", ev)
+	}
+	if err := ex.CancelOrder(t.Context(), canceled.Ref); err != nil {
+		t.Fatalf("second cancel = %v, want no-op", err)
+	}
+	step(t, ex, clk)
+	step(t, ex, clk)
+	if ev := nextEvent(t, events); ev.Ref.ClientOrderID != expiring.Ref.ClientOrderID || ev.Status != order.StatusExpired {
+		t.Fatalf("expiry event = %+v", ev)
+	}
+	if open, err := ex.OpenOrders(t.Context()); err != nil || len(open) != 0 {
+		t.Fatalf("OpenOrders = %+v, %v", open, err)
+	}
+	if err := ex.CancelOrder(t.Context(), order.Ref{ClientOrderID: "missing"}); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("cancel unknown = %v, want ErrNotFound", err)
+	}
+}
+
+func TestTimeInForceAndPostOnly(t *testing.T) {
+	t.Parallel()
+	ex, clk := newTestExchange(t, func(c *config.Paper) { c.FillRatio = 0.5 })
+	events := subscribe(t, ex)
+	inst := btc(t, ex)
+	place := func(id order.ClientOrderID, price string, tif order.TimeInForce, postOnly bool) order.Ack {
+		t.Helper()
+		req := order.Request{
+			ClientOrderID: id, Instrument: inst, Side: order.Buy, Type: order.Limit,
+			Price: decimal.RequireFromString(price), Qty: decimal.RequireFromString("0.2"),
+			TimeInForce: tif, PostOnly: postOnly,
+		}
+		if tif == order.GTD {
+			req.ExpiresAt = start.Add(2 * time.Second)
+		}
+		ack, err := ex.PlaceOrder(t.Context(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ack
+	}
+
+	// The ask is 50000.1: a post-only bid there would take.
+	if ack := place("post-cross", "50000.1", order.GTC, true); ack.Status != order.StatusRejected {
+		t.Fatalf("crossing post-only ack = %+v, want rejected", ack)
+	}
+	if ev := nextEvent(t, events); !strings.Contains(ev.Reason, "would take liquidity") {
+		t.Fatalf("post-only rejection = %+v", ev)
+	}
+	place("post-rest", "49000", order.GTC, true)
+	place("ioc", "50000.1", order.IOC, false)
+	place("fok", "50000.1", order.FOK, false)
+	place("ioc-away", "49000", order.IOC, false)
+	place("gtd", "49000", order.GTD, false)
+
+	step(t, ex, clk)
+	got := map[order.ClientOrderID][]order.Event{}
+	for range 4 {
+		ev := nextEvent(t, events)
+		got[ev.Ref.ClientOrderID] = append(got[ev.Ref.ClientOrderID], ev)
+	}
+	half, whole := decimal.RequireFromString("0.1"), decimal.RequireFromString("0.2")
+	if evs := got["ioc"]; len(evs) != 2 || !evs[0].FilledQty.Equal(half) || evs[1].Status != order.StatusExpired {
+		t.Fatalf("ioc events = %+v, want a partial fill then the rest expired", evs)
+	}
+	if evs := got["fok"]; len(evs) != 1 || evs[0].Status != order.StatusFilled || !evs[0].FilledQty.Equal(whole) {
+		t.Fatalf("fok events = %+v, want one whole fill", evs)
+	}
+	if evs := got["ioc-away"]; len(evs) != 1 || evs[0].Status != order.StatusExpired || !evs[0].FilledQty.IsZero() {
+		t.Fatalf("ioc-away events = %+v, want expired unfilled", evs)
+	}
+	step(t, ex, clk)
+	if ev := nextEvent(t, events); ev.Ref.ClientOrderID != "gtd" || ev.Status != order.StatusExpired {
+		t.Fatalf("gtd event = %+v, want expired at its expiry", ev)
+	}
+	open, err := ex.OpenOrders(t.Context())
+	if err != nil || len(open) != 1 || open[0].Ref.ClientOrderID != "post-rest" {
+		t.Fatalf("OpenOrders = %+v, %v; want only the resting post-only order", open, err)
+	}
+}
+
+func TestRecordedFeedReplaysAndWraps(t *testing.T) {
+	t.Parallel()
+	rec, err := parseRecording(strings.NewReader(`pair,bid,ask,last
+# captured 2026-07-20
+BTC/USDT,49990,50010,50000
+btc/usdt,50990,51010,51000
+`), []string{"BTC/USDT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &market{inst: instrument.Instrument{Base: "BTC", Quote: "USDT"}}
+	var lasts []string
+	for range 3 {
+		lasts = append(lasts, rec.next(m).Last.String())
+	}
+	if strings.Join(lasts, ",") != "50000,51000,50000" {
+		t.Fatalf("replayed lasts = %v", lasts)
+	}
+	if _, err := parseRecording(strings.NewReader("BTC/USDT,2,1,1\n"), []string{"BTC/USDT"}); err == nil {
+		t.Fatal("crossed book accepted")
+	}
+	if _, err := parseRecording(strings.NewReader("BTC/USDT,1,2,1\n"), []string{"ETH/USDT"}); err == nil {
+		t.Fatal("feed without a configured pair accepted")
+	}
+}
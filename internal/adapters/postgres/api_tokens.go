@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/romanornr/delta-works/internal/adapters/postgres/sqlcgen"
+	"github.com/romanornr/delta-works/internal/domain/auth"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// TokenStore persists control-plane tokens by hash.
+type TokenStore struct {
+	q *sqlcgen.Queries
+}
+
+var _ ports.TokenStore = (*TokenStore)(nil)
+
+// NewTokenStore builds a store over the pool.
+func NewTokenStore(pool *pgxpool.Pool) *TokenStore {
+	return &TokenStore{q: sqlcgen.New(pool)}
+}
+
+// InsertToken stores the token, or returns false when a valid token holds
+// its name.
+func (s *TokenStore) InsertToken(ctx context.Context, t auth.Token) (bool, error) {
+	scopes := make([]string, len(t.Scopes))
+	for i, scope := range t.Scopes {
+		scopes[i] = string(scope)
+	}
+	n, err := s.q.InsertAPIToken(ctx, sqlcgen.InsertAPITokenParams{
+		TokenID: t.ID, Name: t.Name, Scopes: scopes, Hash: t.Hash[:], CreatedAt: t.CreatedAt.UTC(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("postgres: insert api token %s: %w", t.Name, err)
+	}
+	return n > 0, nil
+}
+
+// TokenByHash returns the valid token with the hash, or ports.ErrNotFound.
+func (s *TokenStore) TokenByHash(ctx context.Context, hash auth.Hash) (auth.Token, error) {
+	row, err := s.q.GetValidAPITokenByHash(ctx, hash[:])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return auth.Token{}, ports.ErrNotFound
+	}
+	if err != nil {
+		return auth.Token{}, fmt.Errorf("postgres: get api token: %w", err)
+	}
+	return toToken(row), nil
+}
+
+// ListTokens returns the tokens, oldest first.
+func (s *TokenStore) ListTokens(ctx context.Context, includeRevoked bool) ([]auth.Token, error) {
+	rows, err := s.q.ListAPITokens(ctx, includeRevoked)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list api tokens: %w", err)
+	}
+	tokens := make([]auth.Token, 0, len(rows))
+	for _, row := range rows {
+		tokens = append(tokens, toToken(row))
+	}
+	return tokens, nil
+}
+
+// RevokeToken revokes the valid token with the id, or returns
+// ports.ErrNotFound.
+func (s *TokenStore) RevokeToken(ctx context.Context, id string, at time.Time) error {
+	n, err := s.q.RevokeAPIToken(ctx, sqlcgen.RevokeAPITokenParams{TokenID: id, RevokedAt: nullTimestamp(at)})
+	if err != nil {
+		return fmt.Errorf("postgres: revoke api token %s: %w", id, err)
+	}
+	if n == 0 {
+		return ports.ErrNotFound
+	}
+	return nil
+}
+
+func toToken(row sqlcgen.ApiToken) auth.Token {
+	t := auth.Token{
+		ID: row.TokenID, Name: row.Name, Source: auth.SourceStore, CreatedAt: row.CreatedAt,
+		Scopes: make([]auth.Scope, len(row.Scopes)),
+	}
+	for i, scope := range row.Scopes {
+		t.Scopes[i] = auth.Scope(scope)
+	}
+	copy(t.Hash[:], row.Hash)
+	if row.RevokedAt.Valid {
+		t.RevokedAt = row.RevokedAt.Time
+	}
+	return t
+}
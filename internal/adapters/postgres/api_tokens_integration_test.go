@@ -0,0 +1,68 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/romanornr/delta-works/internal/domain/auth"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+func TestTokenStore(t *testing.T) {
+	ctx := context.Background()
+	pool, err := Connect(ctx, startPostgres(t))
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer pool.Close()
+	store := NewTokenStore(pool)
+
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+	dashboard := auth.Token{
+		ID: "01J00000000000000000000001", Name: "dashboard", Scopes: []auth.Scope{auth.ScopeRead},
+		Hash: auth.HashSecret("dashboard-secret"), CreatedAt: createdAt,
+	}
+	if ok, err := store.InsertToken(ctx, dashboard); err != nil || !ok {
+		t.Fatalf("InsertToken = %v, err=%v; want stored", ok, err)
+	}
+	clash := dashboard
+	clash.ID, clash.Hash = "01J00000000000000000000002", auth.HashSecret("other-secret")
+	if ok, err := store.InsertToken(ctx, clash); err != nil || ok {
+		t.Fatalf("InsertToken with a taken name = %v, err=%v; want refused", ok, err)
+	}
+
+	got, err := store.TokenByHash(ctx, auth.HashSecret("dashboard-secret"))
+	if err != nil || got.ID != dashboard.ID || got.Hash != dashboard.Hash || !got.Allows(auth.ScopeRead) ||
+		got.Source != auth.SourceStore || !got.CreatedAt.Equal(createdAt) || got.Revoked() {
+		t.Fatalf("TokenByHash = %+v, err=%v", got, err)
+	}
+	if _, err := store.TokenByHash(ctx, auth.HashSecret("other-secret")); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("TokenByHash of an unknown secret: err=%v, want ErrNotFound", err)
+	}
+
+	if err := store.RevokeToken(ctx, dashboard.ID, createdAt.Add(time.Minute)); err != nil {
+		t.Fatalf("RevokeToken: %v", err)
+	}
+	if err := store.RevokeToken(ctx, dashboard.ID, createdAt.Add(time.Hour)); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("RevokeToken twice: err=%v, want ErrNotFound", err)
+	}
+	if _, err := store.TokenByHash(ctx, dashboard.Hash); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("TokenByHash after revoking: err=%v, want ErrNotFound", err)
+	}
+	// Revoking frees the name.
+	if ok, err := store.InsertToken(ctx, clash); err != nil || !ok {
+		t.Fatalf("InsertToken after revoking = %v, err=%v; want stored", ok, err)
+	}
+
+	if valid, err := store.ListTokens(ctx, false); err != nil || len(valid) != 1 || valid[0].ID != clash.ID {
+		t.Fatalf("ListTokens = %+v, err=%v; want the replacement only", valid, err)
+	}
+	all, err := store.ListTokens(ctx, true)
+	if err != nil || len(all) != 2 || all[0].ID != dashboard.ID || !all[0].RevokedAt.Equal(createdAt.Add(time.Minute)) {
+		t.Fatalf("ListTokens(include revoked) = %+v, err=%v", all, err)
+	}
+}
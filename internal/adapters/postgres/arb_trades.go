@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/romanornr/delta-works/internal/adapters/postgres/sqlcgen"
+	"github.com/romanornr/delta-works/internal/domain/arb"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+var _ ports.ArbTradeStore = (*OrderStore)(nil)
+
+// CreateArbTrade inserts the trade before its legs are placed.
+func (s *OrderStore) CreateArbTrade(ctx context.Context, t arb.Trade) error {
+	err := s.q.InsertArbTrade(ctx, sqlcgen.InsertArbTradeParams{
+		TradeID:     string(t.ID),
+		BotID:       t.BotID,
+		Base:        string(t.Buy.Base),
+		Quote:       string(t.Buy.Quote),
+		BuyVenue:    string(t.Buy.Venue),
+		SellVenue:   string(t.Sell.Venue),
+		BuyOrderID:  string(t.BuyOrderID),
+		SellOrderID: string(t.SellOrderID),
+		Qty:         t.Qty,
+		BuyPrice:    t.BuyPrice,
+		SellPrice:   t.SellPrice,
+		EdgeBps:     t.EdgeBps,
+		Status:      string(t.Status),
+		At:          t.CreatedAt.UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("postgres: insert arb trade: %w", err)
+	}
+	return nil
+}
+
+// GetArbTrade returns the trade, or ports.ErrNotFound.
+func (s *OrderStore) GetArbTrade(ctx context.Context, id arb.TradeID) (arb.Trade, error) {
+	row, err := s.q.GetArbTrade(ctx, string(id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return arb.Trade{}, ports.ErrNotFound
+	}
+	if err != nil {
+		return arb.Trade{}, fmt.Errorf("postgres: get arb trade: %w", err)
+	}
+	return arbTradeRecord(row), nil
+}
+
+// ListArbTrades returns at most limit trades, newest first.
+func (s *OrderStore) ListArbTrades(ctx context.Context, botID string, unsettledOnly bool, limit int32) ([]arb.Trade, error) {
+	rows, err := s.q.ListArbTrades(ctx, sqlcgen.ListArbTradesParams{
+		BotID: nullString(botID), UnsettledOnly: unsettledOnly, RowLimit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list arb trades: %w", err)
+	}
+	return arbTradeRecords(rows), nil
+}
+
+// ListUnsettledArbTrades returns the bot's open and hedge-needed trades,
+// oldest first.
+func (s *OrderStore) ListUnsettledArbTrades(ctx context.Context, botID string) ([]arb.Trade, error) {
+	rows, err := s.q.ListUnsettledArbTrades(ctx, botID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list unsettled arb trades: %w", err)
+	}
+	return arbTradeRecords(rows), nil
+}
+
+// UpdateArbTrade stores the trade's status, hedge and reason.
+func (s *OrderStore) UpdateArbTrade(ctx context.Context, t arb.Trade) error {
+	n, err := s.q.UpdateArbTrade(ctx, sqlcgen.UpdateArbTradeParams{
+		TradeID:   string(t.ID),
+		Status:    string(t.Status),
+		Reason:    t.Reason,
+		HedgeSide: nullString(string(t.HedgeSide)),
+		HedgeQty:  nullNumeric(t.HedgeQty),
+		At:        t.UpdatedAt.UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("postgres: update arb trade: %w", err)
+	}
+	if n == 0 {
+		return ports.ErrNotFound
+	}
+	return nil
+}
+
+func arbTradeRecords(rows []sqlcgen.ArbTrade) []arb.Trade {
+	trades := make([]arb.Trade, 0, len(rows))
+	for _, row := range rows {
+		trades = append(trades, arbTradeRecord(row))
+	}
+	return trades
+}
+
+func arbTradeRecord(row sqlcgen.ArbTrade) arb.Trade {
+	leg := func(venue string) instrument.Instrument {
+		return instrument.Instrument{
+			Venue: instrument.VenueID(venue), Type: instrument.TypeSpot,
+			Base: money.Currency(row.Base), Quote: money.Currency(row.Quote),
+		}
+	}
+	return arb.Trade{
+		ID:          arb.TradeID(row.TradeID),
+		BotID:       row.BotID,
+		Buy:         leg(row.BuyVenue),
+		Sell:        leg(row.SellVenue),
+		BuyOrderID:  order.ClientOrderID(row.BuyOrderID),
+		SellOrderID: order.ClientOrderID(row.SellOrderID),
+		Qty:         row.Qty,
+		BuyPrice:    row.BuyPrice,
+		SellPrice:   row.SellPrice,
+		EdgeBps:     row.EdgeBps,
+		Status:      arb.Status(row.Status),
+		HedgeSide:   order.Side(fromNullString(row.HedgeSide)),
+		HedgeQty:    fromNumeric(row.HedgeQty),
+		Reason:      row.Reason,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}
+}
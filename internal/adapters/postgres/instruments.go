@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/romanornr/delta-works/internal/adapters/postgres/sqlcgen"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/events"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// InstrumentStore persists the instrument catalog and its history.
+type InstrumentStore struct {
+	pool *pgxpool.Pool
+	q    *sqlcgen.Queries
+}
+
+var _ ports.InstrumentStore = (*InstrumentStore)(nil)
+
+// NewInstrumentStore builds a store over the pool.
+func NewInstrumentStore(pool *pgxpool.Pool) *InstrumentStore {
+	return &InstrumentStore{pool: pool, q: sqlcgen.New(pool)}
+}
+
+// ListInstruments returns the catalog entries for the venue, or for every
+// venue when it is empty, ordered by key.
+func (s *InstrumentStore) ListInstruments(ctx context.Context, venue instrument.VenueID) ([]instrument.Instrument, error) {
+	rows, err := s.q.ListInstruments(ctx, nullString(string(venue)))
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list instruments: %w", err)
+	}
+	out := make([]instrument.Instrument, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, toInstrument(row))
+	}
+	return out, nil
+}
+
+// ApplyInstrumentChanges upserts each changed instrument, appends its
+// history row and queues its instrument.changed event in one transaction.
+func (s *InstrumentStore) ApplyInstrumentChanges(ctx context.Context, changes []instrument.Change, at time.Time) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	at = at.UTC()
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("postgres: begin instrument changes: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	q := s.q.WithTx(tx)
+	for _, c := range changes {
+		inst := c.Instrument
+		if err := q.UpsertInstrument(ctx, sqlcgen.UpsertInstrumentParams{
+			Venue:          string(inst.Venue),
+			Type:           string(inst.Type),
+			Base:           string(inst.Base),
+			Quote:          string(inst.Quote),
+			VenueSymbol:    inst.VenueSymbol,
+			Status:         string(inst.Status),
+			PriceIncrement: inst.Rules.PriceIncrement,
+			QtyIncrement:   inst.Rules.QtyIncrement,
+			MinQty:         inst.Rules.MinQty,
+			MinNotional:    inst.Rules.MinNotional,
+			At:             at,
+		}); err != nil {
+			return fmt.Errorf("postgres: upsert instrument %s: %w", inst.Key(), err)
+		}
+		if err := q.InsertInstrumentHistory(ctx, sqlcgen.InsertInstrumentHistoryParams{
+			Venue:          string(inst.Venue),
+			Type:           string(inst.Type),
+			Base:           string(inst.Base),
+			Quote:          string(inst.Quote),
+			Change:         string(c.Kind),
+			VenueSymbol:    inst.VenueSymbol,
+			Status:         string(inst.Status),
+			PriceIncrement: inst.Rules.PriceIncrement,
+			QtyIncrement:   inst.Rules.QtyIncrement,
+			MinQty:         inst.Rules.MinQty,
+			MinNotional:    inst.Rules.MinNotional,
+			RecordedAt:     at,
+		}); err != nil {
+			return fmt.Errorf("postgres: insert instrument history %s: %w", inst.Key(), err)
+		}
+		if err := insertOutboxJSON(ctx, q, events.SubjectInstrumentChanged, events.InstrumentChangedPayload{
+			Venue:          inst.Venue,
+			Type:           inst.Type,
+			Base:           inst.Base,
+			Quote:          inst.Quote,
+			VenueSymbol:    inst.VenueSymbol,
+			Status:         inst.Status,
+			Change:         c.Kind,
+			PriceIncrement: inst.Rules.PriceIncrement,
+			QtyIncrement:   inst.Rules.QtyIncrement,
+			MinQty:         inst.Rules.MinQty,
+			MinNotional:    inst.Rules.MinNotional,
+			At:             at,
+		}); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("postgres: commit instrument changes: %w", err)
+	}
+	return nil
+}
+
+func toInstrument(row sqlcgen.Instrument) instrument.Instrument {
+	return instrument.Instrument{
+		Venue:       instrument.VenueID(row.Venue),
+		Type:        instrument.Type(row.Type),
+		Base:        money.Currency(row.Base),
+		Quote:       money.Currency(row.Quote),
+		VenueSymbol: row.VenueSymbol,
+		Status:      instrument.Status(row.Status),
+		Rules: instrument.Rules{
+			PriceIncrement: row.PriceIncrement,
+			QtyIncrement:   row.QtyIncrement,
+			MinQty:         row.MinQty,
+			MinNotional:    row.MinNotional,
+		},
+	}
+}
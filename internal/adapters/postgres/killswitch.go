@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/romanornr/delta-works/internal/adapters/postgres/sqlcgen"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// KillSwitchStore persists engaged kill switches.
+type KillSwitchStore struct {
+	q *sqlcgen.Queries
+}
+
+var _ ports.KillSwitchStore = (*KillSwitchStore)(nil)
+
+// NewKillSwitchStore builds a store over the pool.
+func NewKillSwitchStore(pool *pgxpool.Pool) *KillSwitchStore {
+	return &KillSwitchStore{q: sqlcgen.New(pool)}
+}
+
+// EngageKillSwitch stores the halt, or returns the one already engaged
+// for the venue.
+func (s *KillSwitchStore) EngageKillSwitch(ctx context.Context, h order.Halt) (order.Halt, error) {
+	row, err := s.q.EngageKillSwitch(ctx, sqlcgen.EngageKillSwitchParams{
+		Venue: string(h.Venue), Reason: h.Reason, EngagedAt: h.EngagedAt.UTC(),
+	})
+	if err != nil {
+		return order.Halt{}, fmt.Errorf("postgres: engage kill switch: %w", err)
+	}
+	return toHalt(row), nil
+}
+
+// ReleaseKillSwitch removes the venue's halt, or returns ErrNotFound.
+func (s *KillSwitchStore) ReleaseKillSwitch(ctx context.Context, venue instrument.VenueID) error {
+	n, err := s.q.ReleaseKillSwitch(ctx, string(venue))
+	if err != nil {
+		return fmt.Errorf("postgres: release kill switch: %w", err)
+	}
+	if n == 0 {
+		return ports.ErrNotFound
+	}
+	return nil
+}
+
+// ListKillSwitches returns every engaged halt, ordered by venue.
+func (s *KillSwitchStore) ListKillSwitches(ctx context.Context) ([]order.Halt, error) {
+	rows, err := s.q.ListKillSwitches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list kill switches: %w", err)
+	}
+	halts := make([]order.Halt, 0, len(rows))
+	for _, row := range rows {
+		halts = append(halts, toHalt(row))
+	}
+	return halts, nil
+}
+
+func toHalt(row sqlcgen.KillSwitch) order.Halt {
+	return order.Halt{Venue: instrument.VenueID(row.Venue), Reason: row.Reason, EngagedAt: row.EngagedAt}
+}
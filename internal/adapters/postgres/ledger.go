@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 
 	"github.com/romanornr/delta-works/internal/adapters/postgres/sqlcgen"
@@ -63,28 +64,85 @@ func (s *OrderStore) postLedgerFill(
 		return ledger.Outcome{}, fmt.Errorf("postgres: lock inventory: %w", err)
 	}
 
+	fee := ledger.SplitFee(ev.Fee, ev.FeeCurrency, money.Currency(row.Base), money.Currency(row.Quote))
+	if fee.Other.IsPositive() {
+		if err := consumeFeeLots(ctx, q, row, ev, fee.Other, fillID); err != nil {
+			return ledger.Outcome{}, err
+		}
+	}
+
 	switch order.Side(row.Side) {
 	case order.Buy:
-		return s.openLot(ctx, q, row, ev, fillQty, fillID)
+		return s.openLot(ctx, q, row, ev, fillQty, fee, fillID)
 	case order.Sell:
-		return s.closeLots(ctx, q, row, ev, fillQty, fillID)
+		return s.closeLots(ctx, q, row, ev, fillQty, fee, fillID)
 	default:
 		return ledger.Outcome{}, fmt.Errorf("postgres: post ledger fill: unsupported side %q", row.Side)
 	}
 }
 
+// consumeFeeLots records a third-currency fee against the inventory that
+// paid it and takes the fee out of the bot's lots held in that currency on
+// the same venue, oldest first. The lots are row-locked rather than
+// inventory-locked: their quote is only known once they are read. A fee no
+// lot covers stays a charge only. A fee without a currency has no lots to
+// consume.
+func consumeFeeLots(
+	ctx context.Context,
+	q *sqlcgen.Queries,
+	row sqlcgen.Order,
+	ev order.Event,
+	amount decimal.Decimal,
+	fillID int64,
+) error {
+	if err := q.InsertFeeCharge(ctx, sqlcgen.InsertFeeChargeParams{
+		FillID: fillID, BotID: row.BotID, Venue: row.Venue, Base: row.Base, Quote: row.Quote,
+		Currency: string(ev.FeeCurrency), Amount: amount, OccurredAt: ev.At.UTC(),
+	}); err != nil {
+		return fmt.Errorf("postgres: insert fee charge: %w", err)
+	}
+	if ev.FeeCurrency == "" {
+		return nil
+	}
+	rows, err := q.ListFeeLotsForUpdate(ctx, sqlcgen.ListFeeLotsForUpdateParams{
+		BotID: row.BotID, Venue: row.Venue, Base: string(ev.FeeCurrency),
+	})
+	if err != nil {
+		return fmt.Errorf("postgres: list fee lots: %w", err)
+	}
+	open := make([]ledger.Lot, 0, len(rows))
+	for _, lot := range rows {
+		open = append(open, toDomainLot(lot, decimal.Zero))
+	}
+	// A fee closure has no sell side, so it carries no price.
+	for _, closure := range ledger.ConsumeFee(open, amount).Closures {
+		if err := recordClosure(ctx, q, closure, decimal.Zero, ev.At.UTC(), fillID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openLot opens the lot a buy fill created. A base fee shrinks the lot and
+// a quote fee raises its cost; a fee that would eat the whole fill is
+// refused, so the fill is never dropped from the ledger unseen.
 func (*OrderStore) openLot(
 	ctx context.Context,
 	q *sqlcgen.Queries,
 	row sqlcgen.Order,
 	ev order.Event,
 	fillQty decimal.Decimal,
+	fee ledger.FeeSplit,
 	fillID int64,
 ) (ledger.Outcome, error) {
+	lotQty, cost, err := ledger.BuyBasis(fillQty, ev.FillPrice, fee)
+	if err != nil {
+		return ledger.Outcome{}, fmt.Errorf("postgres: open lot for fill %d: fee %s of %s: %w", fillID, fee.Base, fillQty, err)
+	}
 	lotID := id.New()
 	if err := q.InsertLot(ctx, sqlcgen.InsertLotParams{
 		ID: lotID, BotID: row.BotID, Venue: row.Venue, Base: row.Base, Quote: row.Quote,
-		Qty: fillQty, CostPrice: ev.FillPrice, OpenedByFillID: fillID, OpenedAt: ev.At.UTC(),
+		Qty: lotQty, CostPrice: ev.FillPrice, Cost: cost, OpenedByFillID: fillID, OpenedAt: ev.At.UTC(),
 	}); err != nil {
 		return ledger.Outcome{}, fmt.Errorf("postgres: insert lot: %w", err)
 	}
@@ -97,6 +155,7 @@ func (s *OrderStore) closeLots(
 	row sqlcgen.Order,
 	ev order.Event,
 	fillQty decimal.Decimal,
+	fee ledger.FeeSplit,
 	fillID int64,
 ) (ledger.Outcome, error) {
 	rows, err := q.ListOpenLotsForUpdate(ctx, sqlcgen.ListOpenLotsForUpdateParams{
@@ -106,43 +165,50 @@ func (s *OrderStore) closeLots(
 		return ledger.Outcome{}, fmt.Errorf("postgres: list open lots: %w", err)
 	}
 	open := make([]ledger.Lot, 0, len(rows))
-	for _, lot := range rows {
-		open = append(open, ledger.Lot{
-			ID: lot.ID, BotID: lot.BotID, Venue: instrument.VenueID(lot.Venue),
-			Base: money.Currency(lot.Base), Quote: money.Currency(lot.Quote),
-			Qty: lot.Qty, RemainingQty: lot.RemainingQty, CostPrice: lot.CostPrice, OpenedAt: lot.OpenedAt,
-		})
+	for _, r := range rows {
+		open = append(open, toDomainLot(r.Lot, r.OrderPrice))
 	}
-	allocation := s.selector.Select(open, fillQty)
-	for _, closure := range allocation.Closures {
+	posting := ledger.PostSell(open, s.selectors.For(row.BotID), ledger.Sell{Qty: fillQty, Price: row.Price}, fee)
+	for _, closure := range posting.Closures {
 		if err := recordClosure(ctx, q, closure, ev.FillPrice, ev.At.UTC(), fillID); err != nil {
 			return ledger.Outcome{}, err
 		}
 	}
-	if !allocation.Unmatched.IsPositive() {
+	if !posting.Unmatched.IsPositive() {
 		return ledger.Outcome{}, nil
 	}
-	if err := recordUnmatched(ctx, q, row, ev, allocation.Unmatched, fillID); err != nil {
+	if err := recordUnmatched(ctx, q, row, ev, posting.Unmatched, posting.UnmatchedFee, fillID); err != nil {
 		return ledger.Outcome{}, err
 	}
-	return ledger.Outcome{UnmatchedQty: allocation.Unmatched}, nil
+	return ledger.Outcome{UnmatchedQty: posting.Unmatched}, nil
+}
+
+func toDomainLot(lot sqlcgen.Lot, orderPrice decimal.Decimal) ledger.Lot {
+	return ledger.Lot{
+		ID: lot.ID, BotID: lot.BotID, Venue: instrument.VenueID(lot.Venue),
+		Base: money.Currency(lot.Base), Quote: money.Currency(lot.Quote),
+		Qty: lot.Qty, RemainingQty: lot.RemainingQty, CostPrice: lot.CostPrice,
+		Cost: lot.Cost, RemainingCost: lot.RemainingCost,
+		OrderPrice: orderPrice, OpenedAt: lot.OpenedAt,
+	}
 }
 
 func recordClosure(
 	ctx context.Context,
 	q *sqlcgen.Queries,
-	closure ledger.Closure,
+	closure ledger.PostedClosure,
 	price decimal.Decimal,
 	closedAt time.Time,
 	fillID int64,
 ) error {
 	if err := q.InsertLotClosure(ctx, sqlcgen.InsertLotClosureParams{
-		LotID: closure.LotID, SellFillID: fillID, Qty: closure.Qty, Price: price, ClosedAt: closedAt,
+		LotID: closure.LotID, SellFillID: fillID, Kind: string(closure.Kind), Qty: closure.Qty,
+		Price: price, Cost: closure.Cost, Fee: closure.Fee, ClosedAt: closedAt,
 	}); err != nil {
 		return fmt.Errorf("postgres: insert lot closure: %w", err)
 	}
 	if err := q.DecrementLot(ctx, sqlcgen.DecrementLotParams{
-		ID: closure.LotID, Qty: closure.Qty, ClosedAt: closedAt,
+		ID: closure.LotID, Qty: closure.Qty, Cost: closure.Cost, ClosedAt: closedAt,
 	}); err != nil {
 		return fmt.Errorf("postgres: decrement lot: %w", err)
 	}
@@ -154,12 +220,12 @@ func recordUnmatched(
 	q *sqlcgen.Queries,
 	row sqlcgen.Order,
 	ev order.Event,
-	qty decimal.Decimal,
+	qty, fee decimal.Decimal,
 	fillID int64,
 ) error {
 	if err := q.InsertUnmatchedSell(ctx, sqlcgen.InsertUnmatchedSellParams{
 		SellFillID: fillID, BotID: row.BotID, Venue: row.Venue, Base: row.Base,
-		Quote: row.Quote, Qty: qty, OccurredAt: ev.At.UTC(),
+		Quote: row.Quote, Qty: qty, Fee: fee, OccurredAt: ev.At.UTC(),
 	}); err != nil {
 		return fmt.Errorf("postgres: insert unmatched sell: %w", err)
 	}
@@ -170,3 +236,100 @@ func recordUnmatched(
 		UnmatchedQty: qty.String(),
 	})
 }
+
+// ListOpenLots returns every open lot, grouped by inventory and in FIFO
+// order within it.
+func (s *OrderStore) ListOpenLots(ctx context.Context) ([]ledger.Lot, error) {
+	rows, err := s.q.ListOpenLots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list open lots: %w", err)
+	}
+	out := make([]ledger.Lot, 0, len(rows))
+	for _, lot := range rows {
+		out = append(out, toDomainLot(lot, decimal.Zero))
+	}
+	return out, nil
+}
+
+// ListClosedLots returns one page of the closures in the query window
+// joined with the lots they closed, in closing order.
+func (s *OrderStore) ListClosedLots(ctx context.Context, query ledger.ClosureQuery) ([]ledger.ClosedLot, error) {
+	var cursorClosedAt pgtype.Timestamptz
+	if query.CursorClosedAt != nil {
+		cursorClosedAt = pgtype.Timestamptz{Time: query.CursorClosedAt.UTC(), Valid: true}
+	}
+	rows, err := s.q.ListClosedLots(ctx, sqlcgen.ListClosedLotsParams{
+		ClosedFrom: query.From.UTC(), ClosedTo: query.To.UTC(),
+		BotID: query.BotID, Venue: query.Venue, Base: query.Base, Quote: query.Quote,
+		CursorClosedAt: cursorClosedAt, CursorID: query.CursorID,
+		RowLimit: int64(query.Limit) + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list closed lots: %w", err)
+	}
+	out := make([]ledger.ClosedLot, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, ledger.ClosedLot{
+			ClosureID: row.ID, LotID: row.LotID, BotID: row.BotID,
+			Venue: instrument.VenueID(row.Venue), Base: money.Currency(row.Base), Quote: money.Currency(row.Quote),
+			Kind: ledger.ClosureKind(row.Kind), Qty: row.Qty, CostPrice: row.CostPrice, SellPrice: row.SellPrice,
+			Cost: row.Cost, Fee: row.Fee, BuyFillID: row.OpenedByFillID, SellFillID: row.SellFillID, ClosedAt: row.ClosedAt,
+		})
+	}
+	return out, nil
+}
+
+// HeldQty sums the remaining quantity of one inventory's open lots in SQL,
+// so a position check reads one number rather than every open lot.
+func (s *OrderStore) HeldQty(ctx context.Context, botID string, inst instrument.Instrument) (decimal.Decimal, error) {
+	qty, err := s.q.SumOpenLotQty(ctx, sqlcgen.SumOpenLotQtyParams{
+		BotID: botID, Venue: string(inst.Venue), Base: string(inst.Base), Quote: string(inst.Quote),
+	})
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("postgres: sum open lot qty: %w", err)
+	}
+	return qty, nil
+}
+
+// SumClosedLots totals the closures in the whole query window per
+// inventory in SQL, so the totals never depend on how the closures are
+// paged.
+func (s *OrderStore) SumClosedLots(ctx context.Context, query ledger.ClosureQuery) ([]ledger.ClosureTotal, error) {
+	rows, err := s.q.SumClosedLots(ctx, sqlcgen.SumClosedLotsParams{
+		ClosedFrom: query.From.UTC(), ClosedTo: query.To.UTC(),
+		BotID: query.BotID, Venue: query.Venue, Base: query.Base, Quote: query.Quote,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postgres: sum closed lots: %w", err)
+	}
+	out := make([]ledger.ClosureTotal, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, ledger.ClosureTotal{
+			BotID: row.BotID, Venue: instrument.VenueID(row.Venue),
+			Base: money.Currency(row.Base), Quote: money.Currency(row.Quote),
+			Qty: row.Qty, FeeQty: row.FeeQty, Cost: row.Cost, Proceeds: row.Proceeds, UnpricedQty: row.UnpricedQty,
+		})
+	}
+	return out, nil
+}
+
+// SumFeeCharges totals the third-currency fees paid in the query window
+// per inventory and currency.
+func (s *OrderStore) SumFeeCharges(ctx context.Context, query ledger.ClosureQuery) ([]ledger.FeeTotal, error) {
+	rows, err := s.q.SumFeeCharges(ctx, sqlcgen.SumFeeChargesParams{
+		OccurredFrom: query.From.UTC(), OccurredTo: query.To.UTC(),
+		BotID: query.BotID, Venue: query.Venue, Base: query.Base, Quote: query.Quote,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postgres: sum fee charges: %w", err)
+	}
+	out := make([]ledger.FeeTotal, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, ledger.FeeTotal{
+			BotID: row.BotID, Venue: instrument.VenueID(row.Venue),
+			Base: money.Currency(row.Base), Quote: money.Currency(row.Quote),
+			Currency: money.Currency(row.Currency), Amount: row.Amount,
+		})
+	}
+	return out, nil
+}
@@ -5,6 +5,7 @@ package postgres
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -13,6 +14,9 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/shopspring/decimal"
 
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/ledger"
+	"github.com/romanornr/delta-works/internal/domain/money"
 	"github.com/romanornr/delta-works/internal/domain/order"
 	"github.com/romanornr/delta-works/internal/events"
 	"github.com/romanornr/delta-works/internal/id"
@@ -72,7 +76,7 @@ func TestOrderStoreLedgerPosting(t *testing.T) {
 		t.Fatalf("Connect: %v", err)
 	}
 	defer pool.Close()
-	store := NewOrderStore(pool)
+	store := NewOrderStore(pool, ledger.Selectors{})
 
 	t.Run("buy opens lot and venue fill replay is idempotent", func(t *testing.T) {
 		req := newLedgerOrder(ctx, t, store, "buy-replay", order.Buy, "1")
@@ -338,7 +342,7 @@ func TestOrderStoreLedgerConcurrency(t *testing.T) {
 		t.Fatalf("Connect: %v", err)
 	}
 	defer pool.Close()
-	store := NewOrderStore(pool)
+	store := NewOrderStore(pool, ledger.Selectors{})
 
 	for i := range 20 {
 		t.Run("buy-sell-"+id.New(), func(t *testing.T) {
@@ -406,6 +410,209 @@ WHERE f.client_order_id=$1`, string(buy.ClientOrderID)).Scan(&closed); err != ni
 	assertLedgerCrossTableInvariant(ctx, t, pool)
 }
 
+func TestOrderStoreGridPairing(t *testing.T) {
+	ctx := context.Background()
+	pool, err := Connect(ctx, startPostgres(t))
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer pool.Close()
+	levels := []decimal.Decimal{decimal.NewFromInt(100), decimal.NewFromInt(102), decimal.NewFromInt(104)}
+	store := NewOrderStore(pool, ledger.Selectors{ByBot: map[string]ledger.LotSelector{
+		"grid": ledger.GridPairing{Levels: levels, Fallback: ledger.Unmatched{}},
+	}})
+
+	at := time.Date(2026, 7, 12, 14, 0, 0, 0, time.UTC)
+	place := func(side order.Side, price string) order.Request {
+		req := order.Request{
+			ClientOrderID: order.ClientOrderID(id.New()), BotID: "grid",
+			Instrument: testInstrument(), Side: side, Type: order.Limit,
+			Price: decimal.RequireFromString(price), Qty: decimal.NewFromInt(1),
+		}
+		if _, err := store.CreatePending(ctx, req); err != nil {
+			t.Fatalf("CreatePending: %v", err)
+		}
+		return req
+	}
+	// The older lot sits two levels down; FIFO would close it first.
+	low, mid := place(order.Buy, "100"), place(order.Buy, "102")
+	// The mid buy fills below its limit: pairing follows the level, not the fill.
+	applyLedgerEvent(ctx, t, store, order.SourceStream, ledgerEvent(low, order.StatusFilled, "1", "100", "grid-low", at))
+	applyLedgerEvent(ctx, t, store, order.SourceStream, ledgerEvent(mid, order.StatusFilled, "1", "101.5", "grid-mid", at.Add(time.Minute)))
+
+	sell := place(order.Sell, "104")
+	sellAt := at.Add(2 * time.Minute)
+	applyLedgerEvent(ctx, t, store, order.SourceStream, ledgerEvent(sell, order.StatusFilled, "1", "104.2", "grid-sell", sellAt))
+	assertLotState(ctx, t, pool, mid.ClientOrderID, "0", "closed", sellAt)
+	assertLotState(ctx, t, pool, low.ClientOrderID, "1", "open", time.Time{})
+
+	bot := "grid"
+	closed, err := store.ListClosedLots(ctx, ledger.ClosureQuery{BotID: &bot, From: at, To: sellAt.Add(time.Second), Limit: 10})
+	if err != nil {
+		t.Fatalf("ListClosedLots: %v", err)
+	}
+	if len(closed) != 1 || !closed[0].CostPrice.Equal(decimal.RequireFromString("101.5")) ||
+		!closed[0].SellPrice.Equal(decimal.RequireFromString("104.2")) || closed[0].BuyFillID == 0 || closed[0].SellFillID == 0 {
+		t.Fatalf("closed lots = %+v", closed)
+	}
+
+	offGrid := place(order.Sell, "103")
+	result := applyLedgerEvent(ctx, t, store, order.SourceStream, ledgerEvent(offGrid, order.StatusFilled, "1", "103", "grid-off", sellAt.Add(time.Minute)))
+	if !result.UnmatchedQty.Equal(decimal.NewFromInt(1)) {
+		t.Fatalf("off-grid sell unmatched = %s, want 1", result.UnmatchedQty)
+	}
+	assertLotState(ctx, t, pool, low.ClientOrderID, "1", "open", time.Time{})
+	assertLedgerCrossTableInvariant(ctx, t, pool)
+}
+
+func TestOrderStoreLedgerFees(t *testing.T) {
+	ctx := context.Background()
+	pool, err := Connect(ctx, startPostgres(t))
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer pool.Close()
+	store := NewOrderStore(pool, ledger.Selectors{})
+	at := time.Date(2026, 7, 12, 16, 0, 0, 0, time.UTC)
+	fill := func(side order.Side, qty, price, fee, currency string, offset time.Duration) order.Request {
+		req := newLedgerOrder(ctx, t, store, "fees", side, qty)
+		ev := ledgerEvent(req, order.StatusFilled, qty, price, "fee-"+string(req.ClientOrderID), at.Add(offset))
+		ev.Fee, ev.FeeCurrency = decimal.RequireFromString(fee), money.Currency(currency)
+		applyLedgerEvent(ctx, t, store, order.SourceStream, ev)
+		return req
+	}
+
+	quoteBuy := fill(order.Buy, "1", "100", "0.1", "USDT", 0)
+	baseBuy := fill(order.Buy, "1", "110", "0.001", "BTC", time.Minute)
+	var lotQty, lotCost decimal.Decimal
+	if err := pool.QueryRow(ctx, `
+SELECT l.qty, l.cost FROM lots l JOIN fills f ON f.id=l.opened_by_fill_id
+WHERE f.client_order_id=$1`, string(baseBuy.ClientOrderID)).Scan(&lotQty, &lotCost); err != nil {
+		t.Fatalf("query base-fee lot: %v", err)
+	}
+	if !lotQty.Equal(decimal.RequireFromString("0.999")) || !lotCost.Equal(decimal.NewFromInt(110)) {
+		t.Fatalf("base-fee lot = %s for %s, want 0.999 for 110", lotQty, lotCost)
+	}
+
+	// The base fee rides after the sale on the same lot.
+	fill(order.Sell, "1.2", "120", "0.001", "BTC", 2*time.Minute)
+	assertLotState(ctx, t, pool, quoteBuy.ClientOrderID, "0", "closed", at.Add(2*time.Minute))
+	assertLotState(ctx, t, pool, baseBuy.ClientOrderID, "0.798", "open", time.Time{})
+	fill(order.Sell, "0.5", "120", "0.12", "USDT", 3*time.Minute)
+	fill(order.Sell, "0.1", "120", "0.002", "BNB", 4*time.Minute)
+
+	open, err := store.ListOpenLots(ctx)
+	if err != nil {
+		t.Fatalf("ListOpenLots: %v", err)
+	}
+	var held []ledger.Lot
+	for _, lot := range open {
+		if lot.BotID == "fees" {
+			held = append(held, lot)
+		}
+	}
+	if len(held) != 1 || !held[0].RemainingQty.Equal(decimal.RequireFromString("0.198")) ||
+		!held[0].RemainingCost.Add(decimal.NewFromInt(110).Mul(decimal.RequireFromString("0.801")).DivRound(decimal.RequireFromString("0.999"), 18)).Round(8).Equal(decimal.NewFromInt(110)) {
+		t.Fatalf("open lots = %+v", held)
+	}
+
+	bot := "fees"
+	query := ledger.ClosureQuery{BotID: &bot, From: at, To: at.Add(time.Hour), Limit: 10}
+	closed, err := store.ListClosedLots(ctx, query)
+	if err != nil {
+		t.Fatalf("ListClosedLots: %v", err)
+	}
+	// Keyset pages return Limit+1 rows and resume strictly after the cursor.
+	paged := ledger.ClosureQuery{BotID: &bot, From: at, To: at.Add(time.Hour), Limit: 3}
+	first, err := store.ListClosedLots(ctx, paged)
+	if err != nil || len(first) != 4 {
+		t.Fatalf("first page = %d closures, %v; want 4", len(first), err)
+	}
+	paged.CursorClosedAt, paged.CursorID = &first[2].ClosedAt, &first[2].ClosureID
+	rest, err := store.ListClosedLots(ctx, paged)
+	if err != nil || len(rest) != 2 || rest[0].ClosureID != closed[3].ClosureID {
+		t.Fatalf("second page = %+v, %v", rest, err)
+	}
+	fees, err := store.SumFeeCharges(ctx, query)
+	if err != nil {
+		t.Fatalf("SumFeeCharges: %v", err)
+	}
+	if len(fees) != 1 || fees[0].Currency != "BNB" || !fees[0].Amount.Equal(decimal.RequireFromString("0.002")) {
+		t.Fatalf("fee totals = %+v", fees)
+	}
+	totals, err := store.SumClosedLots(ctx, paged)
+	if err != nil {
+		t.Fatalf("SumClosedLots: %v", err)
+	}
+	// The SQL totals cover the window whatever the page, as SumClosures would.
+	if want := ledger.SumClosures(closed); len(totals) != 1 || !totals[0].Qty.Equal(want[0].Qty) ||
+		!totals[0].FeeQty.Equal(want[0].FeeQty) || !totals[0].Cost.Equal(want[0].Cost) ||
+		!totals[0].Proceeds.Equal(want[0].Proceeds) || !totals[0].UnpricedQty.Equal(want[0].UnpricedQty) {
+		t.Fatalf("totals = %+v, want %+v", totals, want)
+	}
+	pnl := ledger.Realize(totals, fees, closed)
+	if len(pnl) != 1 || len(pnl[0].Closures) != 5 {
+		t.Fatalf("pnl = %+v", pnl)
+	}
+	// Cost: 100.1 + 110 × 0.801/0.999 for 1.8 sold and 0.001 paid as fee.
+	// Proceeds: 1.8 × 120 − 0.12.
+	got := pnl[0]
+	if !got.Qty.Equal(decimal.RequireFromString("1.8")) || !got.FeeQty.Equal(decimal.RequireFromString("0.001")) ||
+		!got.Proceeds.Equal(decimal.RequireFromString("215.88")) ||
+		!got.Cost.Round(8).Equal(decimal.RequireFromString("188.2981982")) ||
+		!got.OtherFees["BNB"].Equal(decimal.RequireFromString("0.002")) {
+		t.Fatalf("pnl = %+v", got)
+	}
+	assertLedgerCrossTableInvariant(ctx, t, pool)
+}
+
+func TestOrderStoreLedgerFeeCurrencyLots(t *testing.T) {
+	ctx := context.Background()
+	pool, err := Connect(ctx, startPostgres(t))
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer pool.Close()
+	store := NewOrderStore(pool, ledger.Selectors{})
+	at := time.Date(2026, 7, 12, 17, 0, 0, 0, time.UTC)
+
+	bnb := order.Request{
+		ClientOrderID: order.ClientOrderID(id.New()), BotID: "bnb",
+		Instrument: instrument.Instrument{Venue: "bybit", Type: instrument.TypeSpot, Base: "BNB", Quote: "USDT", VenueSymbol: "BNBUSDT"},
+		Side:       order.Buy, Type: order.Limit, Price: decimal.NewFromInt(600), Qty: decimal.NewFromInt(1),
+	}
+	if _, err := store.CreatePending(ctx, bnb); err != nil {
+		t.Fatalf("CreatePending: %v", err)
+	}
+	applyLedgerEvent(ctx, t, store, order.SourceStream, ledgerEvent(bnb, order.StatusFilled, "1", "600", "bnb-buy", at))
+
+	// A BNB fee on a BTC buy consumes the bot's BNB lot at its cost.
+	btc := newLedgerOrder(ctx, t, store, "bnb", order.Buy, "1")
+	ev := ledgerEvent(btc, order.StatusFilled, "1", "100", "btc-buy", at.Add(time.Minute))
+	ev.Fee, ev.FeeCurrency = decimal.RequireFromString("0.01"), "BNB"
+	applyLedgerEvent(ctx, t, store, order.SourceStream, ev)
+	assertLotState(ctx, t, pool, bnb.ClientOrderID, "0.99", "open", time.Time{})
+
+	bot := "bnb"
+	closed, err := store.ListClosedLots(ctx, ledger.ClosureQuery{BotID: &bot, From: at, To: at.Add(time.Hour), Limit: 10})
+	if err != nil {
+		t.Fatalf("ListClosedLots: %v", err)
+	}
+	if len(closed) != 1 || closed[0].Base != "BNB" || closed[0].Kind != ledger.ClosureFee ||
+		!closed[0].Qty.Equal(decimal.RequireFromString("0.01")) || !closed[0].Cost.Equal(decimal.NewFromInt(6)) {
+		t.Fatalf("closed lots = %+v", closed)
+	}
+
+	// A base fee that would leave no lot is refused instead of dropped.
+	whole := newLedgerOrder(ctx, t, store, "bnb", order.Buy, "0.001")
+	ev = ledgerEvent(whole, order.StatusFilled, "0.001", "100", "btc-whole", at.Add(2*time.Minute))
+	ev.Fee, ev.FeeCurrency = decimal.RequireFromString("0.001"), "BTC"
+	if _, err := store.ApplyEvent(ctx, order.SourceStream, ev); !errors.Is(err, ledger.ErrFeeExceedsFill) {
+		t.Fatalf("whole-fill base fee: err = %v, want ErrFeeExceedsFill", err)
+	}
+	assertLedgerCrossTableInvariant(ctx, t, pool)
+}
+
 func applyConcurrently(
 	ctx context.Context,
 	t *testing.T,
@@ -517,7 +724,9 @@ SELECT COUNT(*) FROM lot_closures c
 JOIN fills f ON f.id=c.sell_fill_id
 JOIN orders o ON o.client_order_id=f.client_order_id
 JOIN lots l ON l.id=c.lot_id
-WHERE o.side <> 'sell' OR l.bot_id <> o.bot_id OR l.venue <> o.venue OR l.base <> o.base OR l.quote <> o.quote`); got != 0 {
+WHERE l.bot_id <> o.bot_id OR l.venue <> o.venue OR NOT (
+    (o.side = 'sell' AND l.base = o.base AND l.quote = o.quote) OR
+    (c.kind = 'fee' AND l.base = f.fee_currency AND l.base NOT IN (o.base, o.quote)))`); got != 0 {
 		t.Fatalf("closures violating sell invariant = %d", got)
 	}
 	if got := countRows(ctx, t, pool, `
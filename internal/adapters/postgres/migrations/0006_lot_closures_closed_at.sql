@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX lot_closures_closed_at_idx ON lot_closures (closed_at, id);
+
+-- +goose Down
+DROP INDEX lot_closures_closed_at_idx;
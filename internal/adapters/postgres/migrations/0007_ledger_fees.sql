@@ -0,0 +1,55 @@
+-- +goose Up
+ALTER TABLE lots
+    ADD COLUMN cost           numeric CHECK (cost >= 0),
+    ADD COLUMN remaining_cost numeric CHECK (remaining_cost >= 0);
+
+UPDATE lots SET cost = qty * cost_price, remaining_cost = remaining_qty * cost_price;
+
+ALTER TABLE lots
+    ALTER COLUMN cost SET NOT NULL,
+    ALTER COLUMN remaining_cost SET NOT NULL;
+
+ALTER TABLE lot_closures
+    ADD COLUMN kind text NOT NULL DEFAULT 'sale' CHECK (kind IN ('sale', 'fee')),
+    ADD COLUMN cost numeric CHECK (cost >= 0),
+    ADD COLUMN fee  numeric NOT NULL DEFAULT 0 CHECK (fee >= 0),
+    ADD CHECK (kind = 'sale' OR fee = 0);
+
+UPDATE lot_closures SET cost = lot_closures.qty * lots.cost_price
+FROM lots WHERE lots.id = lot_closures.lot_id;
+
+ALTER TABLE lot_closures
+    ALTER COLUMN cost SET NOT NULL,
+    DROP CONSTRAINT lot_closures_lot_id_sell_fill_id_key,
+    ADD UNIQUE (lot_id, sell_fill_id, kind);
+
+ALTER TABLE unmatched_sells
+    ADD COLUMN fee numeric NOT NULL DEFAULT 0 CHECK (fee >= 0);
+
+-- fee_charges records fees paid in a currency that is neither side of the
+-- pair. They never touch lots: converting them needs a price the ledger
+-- does not have.
+CREATE TABLE fee_charges (
+    fill_id     bigint PRIMARY KEY REFERENCES fills (id),
+    bot_id      text NOT NULL,
+    venue       text NOT NULL,
+    base        text NOT NULL,
+    quote       text NOT NULL,
+    currency    text NOT NULL,
+    amount      numeric NOT NULL CHECK (amount > 0),
+    occurred_at timestamptz NOT NULL
+);
+
+CREATE INDEX fee_charges_occurred_at_idx ON fee_charges (occurred_at, fill_id);
+
+-- +goose Down
+DROP TABLE fee_charges;
+ALTER TABLE unmatched_sells DROP COLUMN fee;
+DELETE FROM lot_closures WHERE kind = 'fee';
+ALTER TABLE lot_closures
+    DROP CONSTRAINT lot_closures_lot_id_sell_fill_id_kind_key,
+    ADD UNIQUE (lot_id, sell_fill_id),
+    DROP COLUMN fee,
+    DROP COLUMN cost,
+    DROP COLUMN kind;
+ALTER TABLE lots DROP COLUMN remaining_cost, DROP COLUMN cost;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- One row per engaged kill switch; the empty venue halts every venue.
+CREATE TABLE kill_switches (
+    venue      text        PRIMARY KEY,
+    reason     text        NOT NULL DEFAULT '',
+    engaged_at timestamptz NOT NULL
+);
+
+-- +goose Down
+DROP TABLE kill_switches;
@@ -0,0 +1,41 @@
+-- +goose Up
+-- The local instrument catalog, synced from each venue. Delisted rows are
+-- kept so a relisting is recognised and old orders still resolve.
+CREATE TABLE instruments (
+    venue           text        NOT NULL,
+    type            text        NOT NULL,
+    base            text        NOT NULL,
+    quote           text        NOT NULL,
+    venue_symbol    text        NOT NULL,
+    status          text        NOT NULL CHECK (status IN ('trading', 'halted', 'delisted')),
+    price_increment numeric     NOT NULL DEFAULT 0,
+    qty_increment   numeric     NOT NULL DEFAULT 0,
+    min_qty         numeric     NOT NULL DEFAULT 0,
+    min_notional    numeric     NOT NULL DEFAULT 0,
+    listed_at       timestamptz NOT NULL,
+    updated_at      timestamptz NOT NULL,
+    PRIMARY KEY (venue, type, base, quote)
+);
+
+-- Append-only; each row is the instrument after one change.
+CREATE TABLE instrument_history (
+    id              bigint GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    venue           text        NOT NULL,
+    type            text        NOT NULL,
+    base            text        NOT NULL,
+    quote           text        NOT NULL,
+    change          text        NOT NULL CHECK (change IN ('listed', 'updated', 'delisted')),
+    venue_symbol    text        NOT NULL,
+    status          text        NOT NULL,
+    price_increment numeric     NOT NULL,
+    qty_increment   numeric     NOT NULL,
+    min_qty         numeric     NOT NULL,
+    min_notional    numeric     NOT NULL,
+    recorded_at     timestamptz NOT NULL
+);
+
+CREATE INDEX instrument_history_key_idx ON instrument_history (venue, type, base, quote, id);
+
+-- +goose Down
+DROP TABLE instrument_history;
+DROP TABLE instruments;
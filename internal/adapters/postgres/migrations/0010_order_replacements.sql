@@ -0,0 +1,21 @@
+-- +goose Up
+-- One row per replace. An amend keeps the order, so both IDs are equal;
+-- a cancel-replace links the canceled order to the one placed after it.
+-- price and qty are the terms after the replacement.
+CREATE TABLE order_replacements (
+    id                  bigint GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    old_client_order_id text NOT NULL REFERENCES orders (client_order_id),
+    new_client_order_id text NOT NULL REFERENCES orders (client_order_id),
+    mode                text NOT NULL CHECK (mode IN ('amend', 'cancel_replace')),
+    price               numeric NOT NULL,
+    qty                 numeric NOT NULL,
+    requested_at        timestamptz NOT NULL,
+    CHECK ((mode = 'amend') = (old_client_order_id = new_client_order_id))
+);
+
+-- An order is placed as the replacement of at most one other.
+CREATE UNIQUE INDEX order_replacements_new_key ON order_replacements (new_client_order_id) WHERE mode = 'cancel_replace';
+CREATE INDEX order_replacements_old_idx ON order_replacements (old_client_order_id);
+
+-- +goose Down
+DROP TABLE order_replacements;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- Orders stored before these columns existed were GTC and not post-only,
+-- which the defaults record.
+ALTER TABLE orders
+    ADD COLUMN time_in_force text NOT NULL DEFAULT 'gtc' CHECK (time_in_force IN ('gtc', 'ioc', 'fok', 'gtd')),
+    ADD COLUMN expires_at    timestamptz,
+    ADD COLUMN post_only     boolean NOT NULL DEFAULT false,
+    ADD CONSTRAINT orders_expires_at_check CHECK ((time_in_force = 'gtd') = (expires_at IS NOT NULL)),
+    ADD CONSTRAINT orders_post_only_check CHECK (NOT post_only OR (type = 'limit' AND time_in_force IN ('gtc', 'gtd')));
+
+-- +goose Down
+ALTER TABLE orders
+    DROP CONSTRAINT orders_post_only_check,
+    DROP CONSTRAINT orders_expires_at_check,
+    DROP COLUMN post_only,
+    DROP COLUMN expires_at,
+    DROP COLUMN time_in_force;
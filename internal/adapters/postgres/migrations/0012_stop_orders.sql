@@ -0,0 +1,28 @@
+-- +goose Up
+-- A stop held by the local trigger engine has no venue order until it
+-- fires; child_client_order_id is the order it then places, fixed up front
+-- so firing twice places it once.
+ALTER TABLE orders
+    DROP CONSTRAINT orders_type_check,
+    DROP CONSTRAINT orders_status_check,
+    ADD CONSTRAINT orders_type_check CHECK (type IN ('limit', 'market', 'stop_market', 'stop_limit')),
+    ADD CONSTRAINT orders_status_check CHECK (status IN ('pending', 'untriggered', 'open', 'partially_filled', 'filled', 'canceled', 'rejected', 'expired', 'triggered')),
+    ADD COLUMN trigger_price         numeric,
+    ADD COLUMN child_client_order_id text UNIQUE,
+    ADD CONSTRAINT orders_trigger_price_check CHECK ((type IN ('stop_market', 'stop_limit')) = (trigger_price IS NOT NULL)),
+    ADD CONSTRAINT orders_child_check CHECK (child_client_order_id IS NULL OR trigger_price IS NOT NULL);
+
+CREATE INDEX orders_untriggered_idx ON orders (venue, created_at) WHERE status = 'untriggered';
+
+-- +goose Down
+DROP INDEX orders_untriggered_idx;
+
+ALTER TABLE orders
+    DROP CONSTRAINT orders_child_check,
+    DROP CONSTRAINT orders_trigger_price_check,
+    DROP COLUMN child_client_order_id,
+    DROP COLUMN trigger_price,
+    DROP CONSTRAINT orders_status_check,
+    DROP CONSTRAINT orders_type_check,
+    ADD CONSTRAINT orders_type_check CHECK (type IN ('limit', 'market')),
+    ADD CONSTRAINT orders_status_check CHECK (status IN ('pending', 'open', 'partially_filled', 'filled', 'canceled', 'rejected', 'expired'));
@@ -0,0 +1,38 @@
+-- +goose Up
+-- An order group links orders that act on each other: an OCO pair, or a
+-- bracket's entry and the OCO pair of exits placed once it fills.
+CREATE TABLE order_groups (
+    group_id   text        PRIMARY KEY,
+    kind       text        NOT NULL CHECK (kind IN ('oco', 'bracket')),
+    status     text        NOT NULL CHECK (status IN ('pending', 'active', 'completed', 'canceled')),
+    venue      text        NOT NULL,
+    base       text        NOT NULL,
+    quote      text        NOT NULL,
+    bot_id     text        NOT NULL,
+    reason     text        NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    CHECK (status <> 'pending' OR kind = 'bracket')
+);
+
+CREATE INDEX order_groups_created_idx ON order_groups (created_at DESC, group_id DESC);
+CREATE INDEX order_groups_open_idx ON order_groups (created_at) WHERE status IN ('pending', 'active');
+
+-- Legs keep their terms because a bracket's exits are placed only once the
+-- entry fills: client_order_id names no order until its leg is placed.
+CREATE TABLE order_group_legs (
+    group_id        text    NOT NULL REFERENCES order_groups (group_id),
+    role            text    NOT NULL CHECK (role IN ('entry', 'take_profit', 'stop_loss')),
+    client_order_id text    NOT NULL UNIQUE,
+    side            text    NOT NULL CHECK (side IN ('buy', 'sell')),
+    type            text    NOT NULL CHECK (type IN ('limit', 'market', 'stop_market', 'stop_limit')),
+    price           numeric NOT NULL,
+    qty             numeric NOT NULL,
+    trigger_price   numeric,
+    PRIMARY KEY (group_id, role),
+    CHECK ((type IN ('stop_market', 'stop_limit')) = (trigger_price IS NOT NULL))
+);
+
+-- +goose Down
+DROP TABLE order_group_legs;
+DROP TABLE order_groups;
@@ -0,0 +1,38 @@
+-- +goose Up
+-- A parent order is worked by an execution algorithm through child orders.
+-- Its progress lives in its children; the row keeps the schedule.
+-- next_child_id is fixed before the slice is placed, so a slice cut short
+-- by a restart is placed again under the same ID rather than twice.
+CREATE TABLE parent_orders (
+    parent_id         text             PRIMARY KEY,
+    algo              text             NOT NULL CHECK (algo IN ('twap')),
+    status            text             NOT NULL CHECK (status IN ('running', 'paused', 'completed', 'canceled')),
+    venue             text             NOT NULL,
+    base              text             NOT NULL,
+    quote             text             NOT NULL,
+    bot_id            text             NOT NULL,
+    side              text             NOT NULL CHECK (side IN ('buy', 'sell')),
+    qty               numeric          NOT NULL CHECK (qty > 0),
+    -- NULL places market children.
+    limit_price       numeric          CHECK (limit_price > 0),
+    slices            integer          NOT NULL CHECK (slices > 0),
+    slice_interval_ms bigint           NOT NULL CHECK (slice_interval_ms > 0),
+    jitter            double precision NOT NULL CHECK (jitter >= 0 AND jitter <= 0.5),
+    slices_sent       integer          NOT NULL DEFAULT 0,
+    next_child_id     text             NOT NULL,
+    next_at           timestamptz      NOT NULL,
+    reason            text             NOT NULL DEFAULT '',
+    created_at        timestamptz      NOT NULL,
+    updated_at        timestamptz      NOT NULL
+);
+
+CREATE INDEX parent_orders_created_idx ON parent_orders (created_at DESC, parent_id DESC);
+CREATE INDEX parent_orders_live_idx ON parent_orders (created_at) WHERE status IN ('running', 'paused');
+
+ALTER TABLE orders ADD COLUMN parent_id text REFERENCES parent_orders (parent_id);
+CREATE INDEX orders_parent_idx ON orders (parent_id, created_at) WHERE parent_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX orders_parent_idx;
+ALTER TABLE orders DROP COLUMN parent_id;
+DROP TABLE parent_orders;
@@ -0,0 +1,26 @@
+-- +goose Up
+-- An iceberg keeps one limit child of display_qty working and refills it
+-- as it fills, each refill priced up to price_jitter inside limit_price.
+-- It has no schedule, so slices and slice_interval_ms are TWAP's alone.
+ALTER TABLE parent_orders
+    DROP CONSTRAINT parent_orders_algo_check,
+    DROP CONSTRAINT parent_orders_slices_check,
+    DROP CONSTRAINT parent_orders_slice_interval_ms_check,
+    ADD COLUMN display_qty  numeric CHECK (display_qty > 0),
+    ADD COLUMN price_jitter numeric NOT NULL DEFAULT 0 CHECK (price_jitter >= 0),
+    ADD CONSTRAINT parent_orders_algo_check CHECK (algo IN ('twap', 'iceberg')),
+    ADD CONSTRAINT parent_orders_twap_check CHECK (algo <> 'twap' OR (slices > 0 AND slice_interval_ms > 0)),
+    ADD CONSTRAINT parent_orders_iceberg_check CHECK (
+        algo <> 'iceberg' OR (limit_price IS NOT NULL AND display_qty IS NOT NULL AND price_jitter < limit_price)
+    );
+
+-- +goose Down
+ALTER TABLE parent_orders
+    DROP CONSTRAINT parent_orders_iceberg_check,
+    DROP CONSTRAINT parent_orders_twap_check,
+    DROP CONSTRAINT parent_orders_algo_check,
+    DROP COLUMN price_jitter,
+    DROP COLUMN display_qty,
+    ADD CONSTRAINT parent_orders_algo_check CHECK (algo IN ('twap')),
+    ADD CONSTRAINT parent_orders_slices_check CHECK (slices > 0),
+    ADD CONSTRAINT parent_orders_slice_interval_ms_check CHECK (slice_interval_ms > 0);
@@ -0,0 +1,22 @@
+-- +goose Up
+-- A VWAP runs TWAP's schedule but sizes slice i by curve[i], the share of
+-- the day's volume its window historically saw. benchmark_price is the
+-- market's VWAP over the parent's life, recorded when it ends.
+ALTER TABLE parent_orders
+    DROP CONSTRAINT parent_orders_algo_check,
+    DROP CONSTRAINT parent_orders_twap_check,
+    ADD COLUMN curve           numeric[],
+    ADD COLUMN benchmark_price numeric CHECK (benchmark_price > 0),
+    ADD CONSTRAINT parent_orders_algo_check CHECK (algo IN ('twap', 'iceberg', 'vwap')),
+    ADD CONSTRAINT parent_orders_twap_check CHECK (algo NOT IN ('twap', 'vwap') OR (slices > 0 AND slice_interval_ms > 0)),
+    ADD CONSTRAINT parent_orders_vwap_check CHECK (algo <> 'vwap' OR array_length(curve, 1) = slices);
+
+-- +goose Down
+ALTER TABLE parent_orders
+    DROP CONSTRAINT parent_orders_vwap_check,
+    DROP CONSTRAINT parent_orders_twap_check,
+    DROP CONSTRAINT parent_orders_algo_check,
+    DROP COLUMN benchmark_price,
+    DROP COLUMN curve,
+    ADD CONSTRAINT parent_orders_algo_check CHECK (algo IN ('twap', 'iceberg')),
+    ADD CONSTRAINT parent_orders_twap_check CHECK (algo <> 'twap' OR (slices > 0 AND slice_interval_ms > 0));
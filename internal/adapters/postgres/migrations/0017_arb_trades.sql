@@ -0,0 +1,35 @@
+-- +goose Up
+-- An arbitrage trade is one pair of IOC legs: a buy on one venue and a sell
+-- of the same quantity on another. The legs' client order IDs are fixed
+-- before either is placed, so they are not foreign keys: a leg the order
+-- service refused is never stored. A trade whose legs filled unequally
+-- needs a hedge of hedge_qty on hedge_side until an operator resolves it.
+CREATE TABLE arb_trades (
+    trade_id      text        PRIMARY KEY,
+    bot_id        text        NOT NULL,
+    base          text        NOT NULL,
+    quote         text        NOT NULL,
+    buy_venue     text        NOT NULL,
+    sell_venue    text        NOT NULL CHECK (sell_venue <> buy_venue),
+    buy_order_id  text        NOT NULL UNIQUE,
+    sell_order_id text        NOT NULL UNIQUE,
+    qty           numeric     NOT NULL CHECK (qty > 0),
+    buy_price     numeric     NOT NULL CHECK (buy_price > 0),
+    sell_price    numeric     NOT NULL CHECK (sell_price > 0),
+    edge_bps      numeric     NOT NULL,
+    status        text        NOT NULL CHECK (status IN ('open', 'balanced', 'hedge_needed', 'hedged')),
+    hedge_side    text        CHECK (hedge_side IN ('buy', 'sell')),
+    hedge_qty     numeric     CHECK (hedge_qty > 0),
+    reason        text        NOT NULL DEFAULT '',
+    created_at    timestamptz NOT NULL,
+    updated_at    timestamptz NOT NULL,
+    CONSTRAINT arb_trades_hedge_check CHECK (
+        (status IN ('hedge_needed', 'hedged')) = (hedge_side IS NOT NULL AND hedge_qty IS NOT NULL)
+    )
+);
+
+CREATE INDEX arb_trades_created_idx ON arb_trades (created_at DESC, trade_id DESC);
+CREATE INDEX arb_trades_unsettled_idx ON arb_trades (bot_id, created_at) WHERE status IN ('open', 'hedge_needed');
+
+-- +goose Down
+DROP TABLE arb_trades;
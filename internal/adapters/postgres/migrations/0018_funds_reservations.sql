@@ -0,0 +1,44 @@
+-- +goose Up
+-- The balances of each account's latest successful snapshot, replaced in
+-- the transaction that records its checkpoint. They are what placement
+-- reserves funds against; the series store keeps the history.
+CREATE TABLE balances (
+    venue        text        NOT NULL,
+    account_type text        NOT NULL,
+    currency     text        NOT NULL,
+    total        numeric     NOT NULL,
+    free         numeric     NOT NULL,
+    locked       numeric     NOT NULL,
+    taken_at     timestamptz NOT NULL,
+    PRIMARY KEY (venue, account_type, currency)
+);
+
+-- A reservation is what one order holds of one currency: quote for a buy,
+-- base for a sell, lot_qty of it drawn from the bot's open lots. It is
+-- stored with the order and follows its fills until the order ends and
+-- hands back what it did not spend as freed.
+CREATE TABLE reservations (
+    client_order_id text        PRIMARY KEY REFERENCES orders (client_order_id),
+    bot_id          text        NOT NULL,
+    venue           text        NOT NULL,
+    account_type    text        NOT NULL,
+    base            text        NOT NULL,
+    quote           text        NOT NULL,
+    side            text        NOT NULL CHECK (side IN ('buy', 'sell')),
+    currency        text        NOT NULL,
+    amount          numeric     NOT NULL CHECK (amount >= 0),
+    lot_qty         numeric     NOT NULL CHECK (lot_qty >= 0 AND lot_qty <= amount),
+    remaining       numeric     NOT NULL CHECK (remaining >= 0),
+    lot_remaining   numeric     NOT NULL CHECK (lot_remaining >= 0 AND lot_remaining <= remaining),
+    freed           numeric     NOT NULL DEFAULT 0 CHECK (freed >= 0),
+    created_at      timestamptz NOT NULL,
+    released_at     timestamptz,
+    CONSTRAINT reservations_released_check CHECK (released_at IS NULL OR remaining = 0)
+);
+
+CREATE INDEX reservations_currency_idx ON reservations (venue, account_type, currency, created_at);
+CREATE INDEX reservations_held_lots_idx ON reservations (venue, currency, bot_id) WHERE released_at IS NULL AND side = 'sell';
+
+-- +goose Down
+DROP TABLE reservations;
+DROP TABLE balances;
@@ -0,0 +1,40 @@
+-- +goose Up
+-- publish_seq orders rows by when the relay published them. IDs are taken
+-- when a transaction inserts and become visible when it commits, so a
+-- lower ID can appear after a higher one; the relay is one goroutine that
+-- commits a batch before claiming the next, so a sink reading after a
+-- publish_seq never misses a row that shows up later.
+CREATE SEQUENCE outbox_publish_seq;
+ALTER TABLE outbox ADD COLUMN publish_seq bigint;
+CREATE UNIQUE INDEX outbox_publish_seq_idx ON outbox (publish_seq) WHERE publish_seq IS NOT NULL;
+
+-- Each configured sink's delivery cursor: the publish_seq of the last row
+-- it delivered or dead-lettered. Outbox cleanup keeps every row after the
+-- lowest cursor.
+CREATE TABLE sink_cursors (
+    sink       text        PRIMARY KEY,
+    after      bigint      NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+-- An event a sink gave up on, with the body it sent, so a retry resends
+-- exactly that even after the outbox row is cleaned up.
+CREATE TABLE sink_dead_letters (
+    id         bigint      GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    sink       text        NOT NULL,
+    outbox_id  bigint      NOT NULL,
+    subject    text        NOT NULL,
+    at         timestamptz NOT NULL,
+    body       jsonb       NOT NULL,
+    attempts   integer     NOT NULL CHECK (attempts > 0),
+    last_error text        NOT NULL,
+    failed_at  timestamptz NOT NULL,
+    UNIQUE (sink, outbox_id)
+);
+
+-- +goose Down
+DROP TABLE sink_dead_letters;
+DROP TABLE sink_cursors;
+DROP INDEX outbox_publish_seq_idx;
+ALTER TABLE outbox DROP COLUMN publish_seq;
+DROP SEQUENCE outbox_publish_seq;
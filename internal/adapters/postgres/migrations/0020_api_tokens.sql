@@ -0,0 +1,15 @@
+-- +goose Up
+-- Control-plane bearer tokens (ADR-0012). Only the SHA-256 of the secret
+-- is kept; a revoked token stays for the audit trail and frees its name.
+CREATE TABLE api_tokens (
+    token_id   text        PRIMARY KEY,
+    name       text        NOT NULL,
+    scopes     text[]      NOT NULL CHECK (cardinality(scopes) > 0 AND scopes <@ ARRAY['read', 'trade', 'admin']),
+    hash       bytea       NOT NULL UNIQUE CHECK (length(hash) = 32),
+    created_at timestamptz NOT NULL,
+    revoked_at timestamptz
+);
+CREATE UNIQUE INDEX api_tokens_valid_name_idx ON api_tokens (name) WHERE revoked_at IS NULL;
+
+-- +goose Down
+DROP TABLE api_tokens;
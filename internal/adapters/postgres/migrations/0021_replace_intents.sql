@@ -0,0 +1,14 @@
+-- +goose Up
+-- A cancel-replace records which order will replace the old one before it
+-- cancels the old one, so only that replacement can be placed once the old
+-- order is terminal. One per old order: the first intent wins.
+CREATE TABLE replace_intents (
+    old_client_order_id text PRIMARY KEY REFERENCES orders (client_order_id),
+    new_client_order_id text NOT NULL,
+    price               numeric NOT NULL,
+    qty                 numeric NOT NULL,
+    requested_at        timestamptz NOT NULL
+);
+
+-- +goose Down
+DROP TABLE replace_intents;
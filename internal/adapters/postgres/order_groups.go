@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/romanornr/delta-works/internal/adapters/postgres/sqlcgen"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+var _ ports.OrderGroupStore = (*OrderStore)(nil)
+
+// CreateGroup inserts the group and its legs in one transaction.
+// Re-inserting the same GroupID is a no-op, so a retried placement keeps
+// the legs it was first given.
+func (s *OrderStore) CreateGroup(ctx context.Context, g order.Group) (bool, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("postgres: begin create group: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	q := s.q.WithTx(tx)
+	n, err := q.InsertOrderGroup(ctx, sqlcgen.InsertOrderGroupParams{
+		GroupID: string(g.ID),
+		Kind:    string(g.Kind),
+		Status:  string(g.Status),
+		Venue:   string(g.Instrument.Venue),
+		Base:    string(g.Instrument.Base),
+		Quote:   string(g.Instrument.Quote),
+		BotID:   g.BotID,
+		At:      g.CreatedAt.UTC(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("postgres: insert order group: %w", err)
+	}
+	if n == 0 {
+		return false, nil
+	}
+	for _, leg := range g.Legs {
+		if err := q.InsertOrderGroupLeg(ctx, sqlcgen.InsertOrderGroupLegParams{
+			GroupID:       string(g.ID),
+			Role:          string(leg.Role),
+			ClientOrderID: string(leg.ClientOrderID),
+			Side:          string(leg.Side),
+			Type:          string(leg.Type),
+			Price:         leg.Price,
+			Qty:           leg.Qty,
+			TriggerPrice:  nullNumeric(leg.TriggerPrice),
+		}); err != nil {
+			return false, fmt.Errorf("postgres: insert order group leg %s: %w", leg.Role, err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("postgres: commit create group: %w", err)
+	}
+	return true, nil
+}
+
+// GetGroup returns the group with its legs, or ports.ErrNotFound.
+func (s *OrderStore) GetGroup(ctx context.Context, id order.GroupID) (order.Group, error) {
+	row, err := s.q.GetOrderGroup(ctx, string(id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return order.Group{}, ports.ErrNotFound
+	}
+	if err != nil {
+		return order.Group{}, fmt.Errorf("postgres: get order group: %w", err)
+	}
+	groups, err := s.withLegs(ctx, []sqlcgen.OrderGroup{row})
+	if err != nil {
+		return order.Group{}, err
+	}
+	return groups[0], nil
+}
+
+// GroupOf returns the group the order is a leg of, or ports.ErrNotFound.
+func (s *OrderStore) GroupOf(ctx context.Context, id order.ClientOrderID) (order.Group, error) {
+	row, err := s.q.GetOrderGroupOfLeg(ctx, string(id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return order.Group{}, ports.ErrNotFound
+	}
+	if err != nil {
+		return order.Group{}, fmt.Errorf("postgres: get group of order: %w", err)
+	}
+	groups, err := s.withLegs(ctx, []sqlcgen.OrderGroup{row})
+	if err != nil {
+		return order.Group{}, err
+	}
+	return groups[0], nil
+}
+
+// ListGroups returns at most limit groups, newest first.
+func (s *OrderStore) ListGroups(ctx context.Context, venue instrument.VenueID, openOnly bool, limit int32) ([]order.Group, error) {
+	rows, err := s.q.ListOrderGroups(ctx, sqlcgen.ListOrderGroupsParams{
+		Venue: nullString(string(venue)), OpenOnly: openOnly, RowLimit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list order groups: %w", err)
+	}
+	return s.withLegs(ctx, rows)
+}
+
+// ListOpenGroups returns every pending or active group, oldest first.
+func (s *OrderStore) ListOpenGroups(ctx context.Context) ([]order.Group, error) {
+	rows, err := s.q.ListOpenOrderGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list open order groups: %w", err)
+	}
+	return s.withLegs(ctx, rows)
+}
+
+// SetGroupStatus stores the group's status and reason.
+func (s *OrderStore) SetGroupStatus(ctx context.Context, id order.GroupID, status order.GroupStatus, reason string, at time.Time) error {
+	n, err := s.q.SetOrderGroupStatus(ctx, sqlcgen.SetOrderGroupStatusParams{
+		GroupID: string(id), Status: string(status), Reason: reason, At: at.UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("postgres: set order group status: %w", err)
+	}
+	if n == 0 {
+		return ports.ErrNotFound
+	}
+	return nil
+}
+
+// withLegs loads the legs of rows in one query and returns the groups in
+// the order of rows.
+func (s *OrderStore) withLegs(ctx context.Context, rows []sqlcgen.OrderGroup) ([]order.Group, error) {
+	if len(rows) == 0 {
+		return []order.Group{}, nil
+	}
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.GroupID)
+	}
+	legRows, err := s.q.ListOrderGroupLegs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list order group legs: %w", err)
+	}
+	legs := make(map[string][]order.Leg, len(rows))
+	for _, leg := range legRows {
+		legs[leg.GroupID] = append(legs[leg.GroupID], order.Leg{
+			Role:          order.Role(leg.Role),
+			ClientOrderID: order.ClientOrderID(leg.ClientOrderID),
+			Side:          order.Side(leg.Side),
+			Type:          order.Type(leg.Type),
+			Price:         leg.Price,
+			Qty:           leg.Qty,
+			TriggerPrice:  fromNumeric(leg.TriggerPrice),
+		})
+	}
+	groups := make([]order.Group, 0, len(rows))
+	for _, row := range rows {
+		groups = append(groups, order.Group{
+			ID:     order.GroupID(row.GroupID),
+			Kind:   order.GroupKind(row.Kind),
+			Status: order.GroupStatus(row.Status),
+			BotID:  row.BotID,
+			Instrument: instrument.Instrument{
+				Venue: instrument.VenueID(row.Venue), Type: instrument.TypeSpot,
+				Base: money.Currency(row.Base), Quote: money.Currency(row.Quote),
+			},
+			Legs:      legs[row.GroupID],
+			Reason:    row.Reason,
+			CreatedAt: row.CreatedAt,
+			UpdatedAt: row.UpdatedAt,
+		})
+	}
+	return groups, nil
+}
@@ -1,11 +1,13 @@
 package postgres
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -26,9 +28,9 @@ import (
 // single write path for venue events: transition row, fill row and outbox
 // rows commit atomically (docs/specs/manual-trading.md, ADR-0008).
 type OrderStore struct {
-	pool     *pgxpool.Pool
-	q        *sqlcgen.Queries
-	selector ledger.LotSelector
+	pool      *pgxpool.Pool
+	q         *sqlcgen.Queries
+	selectors ledger.Selectors
 }
 
 var (
@@ -36,18 +38,70 @@ var (
 	_ ports.OrderEventStore     = (*OrderStore)(nil)
 	_ ports.OrderReconcileStore = (*OrderStore)(nil)
 	_ ports.OrderQueryStore     = (*OrderStore)(nil)
+	_ ports.StopStore           = (*OrderStore)(nil)
+	_ ports.LedgerQueryStore    = (*OrderStore)(nil)
+	_ ports.OpenLotStore        = (*OrderStore)(nil)
+	_ ports.HeldQtyReader       = (*OrderStore)(nil)
+	_ ports.ActiveOrderCounter  = (*OrderStore)(nil)
+	_ ports.GridOrderStore      = (*OrderStore)(nil)
 )
 
-// NewOrderStore returns an OrderStore backed by pool.
-func NewOrderStore(pool *pgxpool.Pool) *OrderStore {
-	return &OrderStore{pool: pool, q: sqlcgen.New(pool), selector: ledger.FIFO{}}
+// NewOrderStore returns an OrderStore backed by pool. Sell fills close lots
+// with the selector chosen for the order's bot; the zero Selectors closes
+// FIFO for every bot.
+func NewOrderStore(pool *pgxpool.Pool, selectors ledger.Selectors) *OrderStore {
+	return &OrderStore{pool: pool, q: sqlcgen.New(pool), selectors: selectors}
 }
 
 // CreatePending inserts the pending row before the venue submit.
 // Re-inserting the same ClientOrderID is a no-op, so submit retries with
-// the same ULID are safe.
+// the same ULID are safe. A request that replaces another order stores
+// the cancel-replace link in the same transaction.
 func (s *OrderStore) CreatePending(ctx context.Context, req order.Request) (bool, error) {
-	n, err := s.q.InsertPendingOrder(ctx, sqlcgen.InsertPendingOrderParams{
+	if req.Replaces == "" || req.Replaces == req.ClientOrderID {
+		return insertPending(ctx, s.q, req)
+	}
+	return s.createPending(ctx, req, nil)
+}
+
+// createPending inserts the pending row, its cancel-replace link and
+// whatever hold stores alongside it in one transaction. hold runs only
+// for a newly inserted order and refuses it by returning an error.
+func (s *OrderStore) createPending(ctx context.Context, req order.Request, hold func(*sqlcgen.Queries) error) (bool, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("postgres: begin create pending: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	q := s.q.WithTx(tx)
+	inserted, err := insertPending(ctx, q, req)
+	if err != nil || !inserted {
+		return false, err
+	}
+	if req.Replaces != "" && req.Replaces != req.ClientOrderID {
+		if err := q.InsertOrderReplacement(ctx, sqlcgen.InsertOrderReplacementParams{
+			OldClientOrderID: string(req.Replaces),
+			NewClientOrderID: string(req.ClientOrderID),
+			Mode:             string(order.ReplaceCancelPlace),
+			Price:            req.Price,
+			Qty:              req.Qty,
+		}); err != nil {
+			return false, fmt.Errorf("postgres: insert order replacement: %w", err)
+		}
+	}
+	if hold != nil {
+		if err := hold(q); err != nil {
+			return false, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("postgres: commit create pending: %w", err)
+	}
+	return true, nil
+}
+
+func insertPending(ctx context.Context, q *sqlcgen.Queries, req order.Request) (bool, error) {
+	n, err := q.InsertPendingOrder(ctx, sqlcgen.InsertPendingOrderParams{
 		ClientOrderID: string(req.ClientOrderID),
 		Venue:         string(req.Instrument.Venue),
 		Base:          string(req.Instrument.Base),
@@ -58,6 +112,11 @@ func (s *OrderStore) CreatePending(ctx context.Context, req order.Request) (bool
 		Price:         req.Price,
 		Qty:           req.Qty,
 		BotID:         req.BotID,
+		TimeInForce:   string(timeInForce(req.TimeInForce)),
+		ExpiresAt:     nullTimestamp(req.ExpiresAt),
+		PostOnly:      req.PostOnly,
+		TriggerPrice:  nullNumeric(req.TriggerPrice),
+		ParentID:      nullString(string(req.ParentID)),
 	})
 	if err != nil {
 		return false, fmt.Errorf("postgres: create pending order: %w", err)
@@ -65,6 +124,31 @@ func (s *OrderStore) CreatePending(ctx context.Context, req order.Request) (bool
 	return n == 1, nil
 }
 
+// CreateUntriggered inserts a stop held by the local trigger engine with
+// its child's client order ID. Re-inserting the same ClientOrderID is a
+// no-op, so a retried place keeps the child it was first given.
+func (s *OrderStore) CreateUntriggered(ctx context.Context, req order.Request, child order.ClientOrderID) (bool, error) {
+	n, err := s.q.InsertUntriggeredOrder(ctx, sqlcgen.InsertUntriggeredOrderParams{
+		ClientOrderID:      string(req.ClientOrderID),
+		Venue:              string(req.Instrument.Venue),
+		Base:               string(req.Instrument.Base),
+		Quote:              string(req.Instrument.Quote),
+		VenueSymbol:        req.Instrument.VenueSymbol,
+		Side:               string(req.Side),
+		Type:               string(req.Type),
+		Price:              req.Price,
+		Qty:                req.Qty,
+		BotID:              req.BotID,
+		TimeInForce:        string(timeInForce(req.TimeInForce)),
+		TriggerPrice:       nullNumeric(req.TriggerPrice),
+		ChildClientOrderID: nullString(string(child)),
+	})
+	if err != nil {
+		return false, fmt.Errorf("postgres: create untriggered order: %w", err)
+	}
+	return n == 1, nil
+}
+
 // ApplyEvent locks the order row, decides via the domain state machine,
 // and persists whatever the decision carries. Dropped state events write
 // nothing unless they supply a missing venue order ID.
@@ -185,6 +269,9 @@ func (s *OrderStore) persistDecision(ctx context.Context, q *sqlcgen.Queries, ro
 		return fmt.Errorf("postgres: update order: %w", err)
 	}
 
+	if err := settleReservation(ctx, q, row, newFilled, decision.To.Terminal(), ev.At); err != nil {
+		return err
+	}
 	if err := s.insertEventOutbox(ctx, q, row, source, ev, decision, newFilled); err != nil {
 		return err
 	}
@@ -193,9 +280,12 @@ func (s *OrderStore) persistDecision(ctx context.Context, q *sqlcgen.Queries, ro
 
 func (s *OrderStore) insertEventOutbox(ctx context.Context, q *sqlcgen.Queries, row sqlcgen.Order, source order.Source, ev order.Event, decision order.Decision, newFilled decimal.Decimal) error {
 	id := order.ClientOrderID(row.ClientOrderID)
+	parent := order.ParentID(fromNullString(row.ParentID))
 	venue := instrument.VenueID(row.Venue)
 	if err := insertOutboxJSON(ctx, q, events.SubjectOrderUpdated, events.OrderUpdatedPayload{
 		ClientOrderID: id,
+		ParentID:      parent,
+		BotID:         row.BotID,
 		Venue:         venue,
 		Base:          money.Currency(row.Base),
 		Quote:         money.Currency(row.Quote),
@@ -211,6 +301,8 @@ func (s *OrderStore) insertEventOutbox(ctx context.Context, q *sqlcgen.Queries,
 	}
 	return insertOutboxJSON(ctx, q, events.SubjectOrderFilled, events.OrderFilledPayload{
 		ClientOrderID: id,
+		ParentID:      parent,
+		BotID:         row.BotID,
 		Venue:         venue,
 		Base:          money.Currency(row.Base),
 		Quote:         money.Currency(row.Quote),
@@ -269,6 +361,103 @@ func (s *OrderStore) MarkCancelRequested(ctx context.Context, id order.ClientOrd
 	return nil
 }
 
+// RecordAmend stores an amend the venue accepted: the order's new price
+// and quantity, the replacement row and the rescaled reservation, in one
+// transaction.
+func (s *OrderStore) RecordAmend(ctx context.Context, r order.Replacement) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("postgres: begin record amend: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	q := s.q.WithTx(tx)
+	row, err := q.GetOrderForUpdate(ctx, string(r.Old))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("postgres: lock order: %w", err)
+	}
+	n, err := q.AmendOrderTerms(ctx, sqlcgen.AmendOrderTermsParams{
+		ClientOrderID: string(r.Old),
+		Price:         r.Price,
+		Qty:           r.Qty,
+	})
+	if err != nil {
+		return fmt.Errorf("postgres: amend order terms: %w", err)
+	}
+	if n == 0 {
+		return ports.ErrNotFound
+	}
+	if err := q.InsertOrderReplacement(ctx, sqlcgen.InsertOrderReplacementParams{
+		OldClientOrderID: string(r.Old),
+		NewClientOrderID: string(r.New),
+		Mode:             string(order.ReplaceAmend),
+		Price:            r.Price,
+		Qty:              r.Qty,
+		RequestedAt:      pgtype.Timestamptz{Time: r.RequestedAt.UTC(), Valid: true},
+	}); err != nil {
+		return fmt.Errorf("postgres: insert order replacement: %w", err)
+	}
+	if err := amendReservation(ctx, q, row, r); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("postgres: commit record amend: %w", err)
+	}
+	return nil
+}
+
+// ListReplacements returns the replacements the order took part in, on
+// either side, oldest first.
+func (s *OrderStore) ListReplacements(ctx context.Context, id order.ClientOrderID) ([]order.Replacement, error) {
+	rows, err := s.q.ListOrderReplacements(ctx, string(id))
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list order replacements: %w", err)
+	}
+	out := make([]order.Replacement, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, order.Replacement{
+			Old:         order.ClientOrderID(row.OldClientOrderID),
+			New:         order.ClientOrderID(row.NewClientOrderID),
+			Mode:        order.ReplaceMode(row.Mode),
+			Price:       row.Price,
+			Qty:         row.Qty,
+			RequestedAt: row.RequestedAt,
+		})
+	}
+	return out, nil
+}
+
+// RecordReplaceIntent stores the intent unless one is already recorded for
+// the old order, and returns whichever is stored.
+func (s *OrderStore) RecordReplaceIntent(ctx context.Context, r order.Replacement) (order.Replacement, error) {
+	err := s.q.InsertReplaceIntent(ctx, sqlcgen.InsertReplaceIntentParams{
+		OldClientOrderID: string(r.Old), NewClientOrderID: string(r.New),
+		Price: r.Price, Qty: r.Qty, RequestedAt: r.RequestedAt.UTC(),
+	})
+	if err != nil {
+		return order.Replacement{}, fmt.Errorf("postgres: insert replace intent: %w", err)
+	}
+	return s.GetReplaceIntent(ctx, r.Old)
+}
+
+// GetReplaceIntent returns the old order's cancel-replace intent, or
+// ports.ErrNotFound.
+func (s *OrderStore) GetReplaceIntent(ctx context.Context, old order.ClientOrderID) (order.Replacement, error) {
+	row, err := s.q.GetReplaceIntent(ctx, string(old))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return order.Replacement{}, ports.ErrNotFound
+	}
+	if err != nil {
+		return order.Replacement{}, fmt.Errorf("postgres: get replace intent: %w", err)
+	}
+	return order.Replacement{
+		Old: order.ClientOrderID(row.OldClientOrderID), New: order.ClientOrderID(row.NewClientOrderID),
+		Mode: order.ReplaceCancelPlace, Price: row.Price, Qty: row.Qty, RequestedAt: row.RequestedAt,
+	}, nil
+}
+
 // GetOrder returns the stored order, or ports.ErrNotFound.
 func (s *OrderStore) GetOrder(ctx context.Context, id order.ClientOrderID) (order.Record, error) {
 	row, err := s.q.GetOrder(ctx, string(id))
@@ -294,6 +483,32 @@ func (s *OrderStore) ListActiveOrders(ctx context.Context, venue instrument.Venu
 	return orders, nil
 }
 
+// ListUntriggeredStops returns the local stops waiting for their trigger,
+// on one venue or, when venue is empty, on all of them.
+func (s *OrderStore) ListUntriggeredStops(ctx context.Context, venue instrument.VenueID) ([]order.Record, error) {
+	rows, err := s.q.ListUntriggeredStops(ctx, nullString(string(venue)))
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list untriggered stops: %w", err)
+	}
+	stops := make([]order.Record, 0, len(rows))
+	for _, row := range rows {
+		stops = append(stops, orderRecord(row))
+	}
+	return stops, nil
+}
+
+// CountActiveOrders counts non-terminal orders, narrowed to one venue
+// and/or one bot when those are non-empty.
+func (s *OrderStore) CountActiveOrders(ctx context.Context, venue instrument.VenueID, botID string) (int, error) {
+	n, err := s.q.CountActiveOrders(ctx, sqlcgen.CountActiveOrdersParams{
+		Venue: nullString(string(venue)), BotID: nullString(botID),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("postgres: count active orders: %w", err)
+	}
+	return int(n), nil
+}
+
 // ListOrders returns a keyset-paginated order page.
 func (s *OrderStore) ListOrders(ctx context.Context, query order.Query) ([]order.Record, error) {
 	statuses := query.Statuses
@@ -316,16 +531,41 @@ func (s *OrderStore) ListOrders(ctx context.Context, query order.Query) ([]order
 	for _, row := range rows {
 		orders = append(orders, orderRecord(row))
 	}
-	return orders, nil
+	if !query.Parents {
+		return orders, nil
+	}
+	// Both lists are keyset pages in the same order under the same
+	// cursor, and ULIDs sort alike across them, so their merge is the page.
+	rollups, err := s.listParentRollups(ctx, sqlcgen.ListParentRollupsParams{
+		Venue: query.Venue, Statuses: statuses, BotID: query.BotID,
+		CursorCreatedAt: cursorCreatedAt, CursorID: query.CursorID,
+		RowLimit: int64(query.Limit) + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	orders = append(orders, rollups...)
+	slices.SortFunc(orders, func(a, b order.Record) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.ClientOrderID, a.ClientOrderID)
+	})
+	return orders[:min(len(orders), int(query.Limit)+1)], nil
 }
 
 func orderRecord(row sqlcgen.Order) order.Record {
-	var cancelRequestedAt time.Time
+	var cancelRequestedAt, expiresAt time.Time
 	if row.CancelRequestedAt.Valid {
 		cancelRequestedAt = row.CancelRequestedAt.Time
 	}
+	if row.ExpiresAt.Valid {
+		expiresAt = row.ExpiresAt.Time
+	}
 	return order.Record{
 		ClientOrderID: order.ClientOrderID(row.ClientOrderID),
+		Child:         order.ClientOrderID(fromNullString(row.ChildClientOrderID)),
+		ParentID:      order.ParentID(fromNullString(row.ParentID)),
 		BotID:         row.BotID,
 		Instrument: instrument.Instrument{
 			Venue: instrument.VenueID(row.Venue),
@@ -342,6 +582,10 @@ func orderRecord(row sqlcgen.Order) order.Record {
 		Qty:               row.Qty,
 		FilledQty:         row.FilledQty,
 		AvgFillPrice:      fromNumeric(row.AvgFillPrice),
+		TriggerPrice:      fromNumeric(row.TriggerPrice),
+		TimeInForce:       order.TimeInForce(row.TimeInForce),
+		ExpiresAt:         expiresAt,
+		PostOnly:          row.PostOnly,
 		Status:            order.Status(row.Status),
 		VenueOrderID:      fromNullString(row.VenueOrderID),
 		CancelRequestedAt: cancelRequestedAt,
@@ -351,6 +595,21 @@ func orderRecord(row sqlcgen.Order) order.Record {
 	}
 }
 
+// timeInForce stores the domain's empty default as the GTC it means.
+func timeInForce(tif order.TimeInForce) order.TimeInForce {
+	if tif == "" {
+		return order.GTC
+	}
+	return tif
+}
+
+func nullTimestamp(t time.Time) pgtype.Timestamptz {
+	if t.IsZero() {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: t.UTC(), Valid: true}
+}
+
 func nullString(s string) *string {
 	if s == "" {
 		return nil
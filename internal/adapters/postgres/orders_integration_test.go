@@ -5,6 +5,7 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
 	"slices"
 	"testing"
 	"time"
@@ -12,7 +13,10 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/shopspring/decimal"
 
+	"github.com/romanornr/delta-works/internal/domain/arb"
+	"github.com/romanornr/delta-works/internal/domain/execution"
 	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/ledger"
 	"github.com/romanornr/delta-works/internal/domain/money"
 	"github.com/romanornr/delta-works/internal/domain/order"
 	"github.com/romanornr/delta-works/internal/events"
@@ -73,7 +77,7 @@ func TestOrderStoreApplyEvent(t *testing.T) {
 		t.Fatalf("Connect: %v", err)
 	}
 	defer pool.Close()
-	store := NewOrderStore(pool)
+	store := NewOrderStore(pool, ledger.Selectors{})
 
 	t.Run("idempotent apply", func(t *testing.T) {
 		req := newPendingOrder(ctx, t, store)
@@ -230,7 +234,7 @@ func TestOrderStoreListActiveOrders(t *testing.T) {
 		t.Fatalf("Connect: %v", err)
 	}
 	defer pool.Close()
-	store := NewOrderStore(pool)
+	store := NewOrderStore(pool, ledger.Selectors{})
 
 	create := func(venue instrument.VenueID) order.Request {
 		t.Helper()
@@ -283,6 +287,19 @@ func TestOrderStoreListActiveOrders(t *testing.T) {
 	if len(want) != 0 {
 		t.Fatalf("missing active orders: %v", want)
 	}
+
+	for _, tt := range []struct {
+		venue instrument.VenueID
+		bot   string
+		want  int
+	}{
+		{"bybit", "", 3}, {"", "", 4}, {"", "manual", 4}, {"kraken", "manual", 1}, {"", "grid", 0},
+	} {
+		n, err := store.CountActiveOrders(ctx, tt.venue, tt.bot)
+		if err != nil || n != tt.want {
+			t.Fatalf("CountActiveOrders(%q, %q) = %d, %v; want %d", tt.venue, tt.bot, n, err, tt.want)
+		}
+	}
 }
 
 func TestOrderStoreListOrders(t *testing.T) {
@@ -292,7 +309,7 @@ func TestOrderStoreListOrders(t *testing.T) {
 		t.Fatalf("Connect: %v", err)
 	}
 	defer pool.Close()
-	store := NewOrderStore(pool)
+	store := NewOrderStore(pool, ledger.Selectors{})
 	createdAt := time.Date(2026, 7, 12, 12, 0, 0, 0, time.UTC)
 	var ids []string
 	for i := 0; i < 4; i++ {
@@ -331,6 +348,410 @@ func TestOrderStoreListOrders(t *testing.T) {
 	}
 }
 
+func TestOrderStoreExecutionFlags(t *testing.T) {
+	ctx := context.Background()
+	pool, err := Connect(ctx, startPostgres(t))
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer pool.Close()
+	store := NewOrderStore(pool, ledger.Selectors{})
+
+	plain := newPendingOrder(ctx, t, store)
+	stored, err := store.GetOrder(ctx, plain.ClientOrderID)
+	if err != nil || stored.TimeInForce != order.GTC || stored.PostOnly || !stored.ExpiresAt.IsZero() {
+		t.Fatalf("default flags = %+v, err=%v; want gtc", stored, err)
+	}
+	gtd := plain
+	gtd.ClientOrderID = order.ClientOrderID(id.New())
+	gtd.TimeInForce, gtd.PostOnly = order.GTD, true
+	gtd.ExpiresAt = time.Date(2026, 7, 12, 12, 0, 0, 0, time.UTC)
+	if _, err := store.CreatePending(ctx, gtd); err != nil {
+		t.Fatalf("CreatePending gtd: %v", err)
+	}
+	stored, err = store.GetOrder(ctx, gtd.ClientOrderID)
+	if err != nil || stored.TimeInForce != order.GTD || !stored.PostOnly || !stored.ExpiresAt.Equal(gtd.ExpiresAt) {
+		t.Fatalf("gtd flags = %+v, err=%v", stored, err)
+	}
+	bad := plain
+	bad.ClientOrderID = order.ClientOrderID(id.New())
+	bad.TimeInForce, bad.PostOnly = order.IOC, true
+	if _, err := store.CreatePending(ctx, bad); err == nil {
+		t.Fatal("post-only ioc stored; want the check constraint to refuse it")
+	}
+}
+
+func TestOrderStoreStops(t *testing.T) {
+	ctx := context.Background()
+	pool, err := Connect(ctx, startPostgres(t))
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer pool.Close()
+	store := NewOrderStore(pool, ledger.Selectors{})
+
+	stop := order.Request{
+		ClientOrderID: order.ClientOrderID(id.New()),
+		BotID:         "manual",
+		Instrument:    testInstrument(),
+		Side:          order.Sell,
+		Type:          order.StopMarket,
+		Qty:           decimal.RequireFromString("1"),
+		TriggerPrice:  decimal.RequireFromString("49000"),
+		TimeInForce:   order.GTC,
+	}
+	child := order.ClientOrderID(id.New())
+	for i, want := range []bool{true, false} {
+		inserted, err := store.CreateUntriggered(ctx, stop, child)
+		if err != nil || inserted != want {
+			t.Fatalf("CreateUntriggered #%d = %v, %v; want %v", i, inserted, err, want)
+		}
+	}
+	if _, err := store.CreateUntriggered(ctx, order.Request{
+		ClientOrderID: order.ClientOrderID(id.New()), BotID: "manual", Instrument: testInstrument(),
+		Side: order.Sell, Type: order.StopMarket, Qty: decimal.RequireFromString("1"), TriggerPrice: stop.TriggerPrice,
+	}, child); err == nil {
+		t.Fatal("second stop under the same child stored; want the unique constraint to refuse it")
+	}
+	native := stop
+	native.ClientOrderID = order.ClientOrderID(id.New())
+	if _, err := store.CreatePending(ctx, native); err != nil {
+		t.Fatalf("CreatePending native stop: %v", err)
+	}
+	noTrigger := native
+	noTrigger.ClientOrderID, noTrigger.TriggerPrice = order.ClientOrderID(id.New()), decimal.Zero
+	if _, err := store.CreatePending(ctx, noTrigger); err == nil {
+		t.Fatal("stop without a trigger stored; want the check constraint to refuse it")
+	}
+
+	stops, err := store.ListUntriggeredStops(ctx, "")
+	if err != nil || len(stops) != 1 {
+		t.Fatalf("ListUntriggeredStops = %+v, %v; want the local stop only", stops, err)
+	}
+	if got := stops[0]; got.ClientOrderID != stop.ClientOrderID || got.Child != child ||
+		!got.TriggerPrice.Equal(stop.TriggerPrice) || got.Status != order.StatusUntriggered || !got.HeldLocally() {
+		t.Fatalf("stop = %+v", got)
+	}
+	if other, err := store.ListUntriggeredStops(ctx, "kraken"); err != nil || len(other) != 0 {
+		t.Fatalf("ListUntriggeredStops(kraken) = %+v, %v", other, err)
+	}
+	active, err := store.ListActiveOrders(ctx, "bybit")
+	if err != nil || !slices.ContainsFunc(active, func(r order.Record) bool { return r.ClientOrderID == stop.ClientOrderID }) {
+		t.Fatalf("ListActiveOrders = %+v, %v; want the stop listed", active, err)
+	}
+	if n, err := store.CountActiveOrders(ctx, "bybit", ""); err != nil || n != 1 {
+		t.Fatalf("CountActiveOrders = %d, %v; want only the pending native stop", n, err)
+	}
+
+	if _, err := store.ApplyEvent(ctx, order.SourceLocal, order.Event{
+		Ref:    order.Ref{Instrument: stop.Instrument, ClientOrderID: stop.ClientOrderID},
+		Status: order.StatusTriggered, Reason: "crossed", At: time.Now().UTC(),
+	}); err != nil {
+		t.Fatalf("ApplyEvent triggered: %v", err)
+	}
+	if stops, err := store.ListUntriggeredStops(ctx, "bybit"); err != nil || len(stops) != 0 {
+		t.Fatalf("ListUntriggeredStops after trigger = %+v, %v", stops, err)
+	}
+}
+
+func TestOrderStoreReplacements(t *testing.T) {
+	ctx := context.Background()
+	pool, err := Connect(ctx, startPostgres(t))
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer pool.Close()
+	store := NewOrderStore(pool, ledger.Selectors{})
+
+	old := newPendingOrder(ctx, t, store)
+	amended := order.Replacement{
+		Old: old.ClientOrderID, New: old.ClientOrderID, Mode: order.ReplaceAmend,
+		Price: decimal.RequireFromString("49000"), Qty: decimal.RequireFromString("2"),
+		RequestedAt: time.Now().UTC(),
+	}
+	if err := store.RecordAmend(ctx, amended); err != nil {
+		t.Fatalf("RecordAmend: %v", err)
+	}
+	stored, err := store.GetOrder(ctx, old.ClientOrderID)
+	if err != nil || !stored.Price.Equal(amended.Price) || !stored.Qty.Equal(amended.Qty) {
+		t.Fatalf("amended order = %+v, err=%v", stored, err)
+	}
+	missing := amended
+	missing.Old, missing.New = "missing", "missing"
+	if err := store.RecordAmend(ctx, missing); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("RecordAmend on a missing order = %v, want ErrNotFound", err)
+	}
+
+	if _, err := store.GetReplaceIntent(ctx, old.ClientOrderID); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("GetReplaceIntent before any = %v, want ErrNotFound", err)
+	}
+	next := old
+	next.ClientOrderID = order.ClientOrderID(id.New())
+	next.Replaces = old.ClientOrderID
+	intent := order.Replacement{
+		Old: old.ClientOrderID, New: next.ClientOrderID, Mode: order.ReplaceCancelPlace,
+		Price: decimal.RequireFromString("48000"), Qty: decimal.RequireFromString("1"),
+		RequestedAt: time.Now().UTC(),
+	}
+	if got, err := store.RecordReplaceIntent(ctx, intent); err != nil || got.New != next.ClientOrderID {
+		t.Fatalf("RecordReplaceIntent = %+v, %v", got, err)
+	}
+	other := intent
+	other.New = "other"
+	if got, err := store.RecordReplaceIntent(ctx, other); err != nil || got.New != next.ClientOrderID || !got.Price.Equal(intent.Price) {
+		t.Fatalf("second RecordReplaceIntent = %+v, %v; want the first intent", got, err)
+	}
+	for range 2 {
+		if _, err := store.CreatePending(ctx, next); err != nil {
+			t.Fatalf("CreatePending replacement: %v", err)
+		}
+	}
+	links, err := store.ListReplacements(ctx, old.ClientOrderID)
+	if err != nil {
+		t.Fatalf("ListReplacements: %v", err)
+	}
+	if len(links) != 2 || links[0].Mode != order.ReplaceAmend || links[1].Mode != order.ReplaceCancelPlace ||
+		links[1].Old != old.ClientOrderID || links[1].New != next.ClientOrderID {
+		t.Fatalf("replacements = %+v, want the amend then one cancel-replace link", links)
+	}
+	if n := countRows(ctx, t, pool, "SELECT count(*) FROM order_replacements WHERE new_client_order_id=$1", next.ClientOrderID); n != 1 {
+		t.Fatalf("replacement links for %s = %d, want 1", next.ClientOrderID, n)
+	}
+}
+
+func TestOrderStoreGroups(t *testing.T) {
+	ctx := context.Background()
+	pool, err := Connect(ctx, startPostgres(t))
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer pool.Close()
+	store := NewOrderStore(pool, ledger.Selectors{})
+
+	inst := testInstrument()
+	inst.VenueSymbol = ""
+	group := order.Group{
+		ID: order.GroupID(id.New()), Kind: order.GroupBracket, Status: order.GroupPending, BotID: "manual",
+		Instrument: inst, CreatedAt: time.Now().UTC(),
+		Legs: []order.Leg{
+			{Role: order.RoleEntry, ClientOrderID: order.ClientOrderID(id.New()), Side: order.Buy, Type: order.Limit,
+				Price: decimal.RequireFromString("50000"), Qty: decimal.RequireFromString("1")},
+			{Role: order.RoleTakeProfit, ClientOrderID: order.ClientOrderID(id.New()), Side: order.Sell, Type: order.Limit,
+				Price: decimal.RequireFromString("55000"), Qty: decimal.RequireFromString("1")},
+			{Role: order.RoleStopLoss, ClientOrderID: order.ClientOrderID(id.New()), Side: order.Sell, Type: order.StopMarket,
+				Qty: decimal.RequireFromString("1"), TriggerPrice: decimal.RequireFromString("48000")},
+		},
+	}
+	for i, want := range []bool{true, false} {
+		created, err := store.CreateGroup(ctx, group)
+		if err != nil || created != want {
+			t.Fatalf("CreateGroup #%d = %v, %v; want %v", i, created, err, want)
+		}
+	}
+	oco := group
+	oco.ID, oco.Kind, oco.Legs = order.GroupID(id.New()), order.GroupOCO, group.Legs[1:]
+	if _, err := store.CreateGroup(ctx, oco); err == nil {
+		t.Fatal("legs reused by a second group stored; want the unique constraint to refuse them")
+	}
+	if n := countRows(ctx, t, pool, "SELECT count(*) FROM order_groups WHERE group_id=$1", oco.ID); n != 0 {
+		t.Fatalf("refused group rows = %d, want the transaction rolled back", n)
+	}
+
+	stored, err := store.GroupOf(ctx, group.Legs[2].ClientOrderID)
+	if err != nil || stored.ID != group.ID || stored.Kind != order.GroupBracket || len(stored.Legs) != 3 {
+		t.Fatalf("GroupOf = %+v, %v", stored, err)
+	}
+	if sl := stored.Legs[2]; sl.Role != order.RoleStopLoss || !sl.TriggerPrice.Equal(group.Legs[2].TriggerPrice) || !sl.Price.IsZero() {
+		t.Fatalf("stop-loss leg = %+v", sl)
+	}
+	if _, err := store.GroupOf(ctx, "missing"); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("GroupOf missing = %v, want ErrNotFound", err)
+	}
+
+	if err := store.SetGroupStatus(ctx, group.ID, order.GroupCanceled, "entry canceled", time.Now()); err != nil {
+		t.Fatalf("SetGroupStatus: %v", err)
+	}
+	if got, err := store.GetGroup(ctx, group.ID); err != nil || got.Status != order.GroupCanceled || got.Reason != "entry canceled" {
+		t.Fatalf("GetGroup = %+v, %v", got, err)
+	}
+	if err := store.SetGroupStatus(ctx, "missing", order.GroupCanceled, "", time.Now()); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("SetGroupStatus missing = %v, want ErrNotFound", err)
+	}
+	if open, err := store.ListGroups(ctx, "bybit", true, 10); err != nil || len(open) != 0 {
+		t.Fatalf("open groups = %+v, %v; want none", open, err)
+	}
+	if open, err := store.ListOpenGroups(ctx); err != nil || len(open) != 0 {
+		t.Fatalf("ListOpenGroups = %+v, %v; want none", open, err)
+	}
+	if all, err := store.ListGroups(ctx, "", false, 10); err != nil || len(all) != 1 || len(all[0].Legs) != 3 {
+		t.Fatalf("ListGroups = %+v, %v", all, err)
+	}
+}
+
+func TestOrderStoreParents(t *testing.T) {
+	ctx := context.Background()
+	pool, err := Connect(ctx, startPostgres(t))
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer pool.Close()
+	store := NewOrderStore(pool, ledger.Selectors{})
+
+	inst := testInstrument()
+	inst.VenueSymbol = ""
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	parent := execution.Parent{
+		ID: order.ParentID(id.New()), Algo: execution.AlgoTWAP, Status: execution.StatusRunning, BotID: "manual",
+		Instrument: inst, Side: order.Buy, Qty: decimal.RequireFromString("2"), Slices: 4, Interval: time.Minute,
+		Jitter: 0.2, NextChildID: order.ClientOrderID(id.New()), NextAt: now, CreatedAt: now, UpdatedAt: now,
+	}
+	for i, want := range []bool{true, false} {
+		created, err := store.CreateParent(ctx, parent)
+		if err != nil || created != want {
+			t.Fatalf("CreateParent #%d = %v, %v; want %v", i, created, err, want)
+		}
+	}
+	stored, err := store.GetParent(ctx, parent.ID)
+	if err != nil || stored.Interval != time.Minute || stored.Jitter != 0.2 || !stored.LimitPrice.IsZero() || !stored.NextAt.Equal(now) {
+		t.Fatalf("GetParent = %+v, %v", stored, err)
+	}
+	if _, err := store.GetParent(ctx, "missing"); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("GetParent missing = %v, want ErrNotFound", err)
+	}
+
+	child := parent.Child(parent.NextChildID, decimal.RequireFromString("0.5"), parent.LimitPrice)
+	if _, err := store.CreatePending(ctx, child); err != nil {
+		t.Fatalf("CreatePending child: %v", err)
+	}
+	if _, err := store.ApplyEvent(ctx, order.SourceStream, fillEvent(child, order.StatusFilled, "0.5", "50000")); err != nil {
+		t.Fatalf("fill child: %v", err)
+	}
+	children, err := store.ListChildren(ctx, parent.ID)
+	if err != nil || len(children) != 1 || children[0].ParentID != parent.ID || !children[0].FilledQty.Equal(child.Qty) {
+		t.Fatalf("ListChildren = %+v, %v", children, err)
+	}
+	if n := countRows(ctx, t, pool, "SELECT count(*) FROM outbox WHERE payload->>'parent_id' = $1", parent.ID); n != 2 {
+		t.Fatalf("outbox rows carrying the parent = %d, want the update and the fill", n)
+	}
+
+	parent.Status, parent.SlicesSent, parent.Reason, parent.UpdatedAt = execution.StatusCompleted, 1, "filled", time.Now()
+	if err := store.UpdateParent(ctx, parent); err != nil {
+		t.Fatalf("UpdateParent: %v", err)
+	}
+	if live, err := store.ListLiveParents(ctx); err != nil || len(live) != 0 {
+		t.Fatalf("ListLiveParents = %+v, %v; want none", live, err)
+	}
+	if all, err := store.ListParents(ctx, "bybit", false, 10); err != nil || len(all) != 1 || all[0].Status != execution.StatusCompleted || all[0].SlicesSent != 1 {
+		t.Fatalf("ListParents = %+v, %v", all, err)
+	}
+	parent.ID = "missing"
+	if err := store.UpdateParent(ctx, parent); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("UpdateParent missing = %v, want ErrNotFound", err)
+	}
+}
+
+func TestOrderStoreVWAPParent(t *testing.T) {
+	ctx := context.Background()
+	pool, err := Connect(ctx, startPostgres(t))
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer pool.Close()
+	store := NewOrderStore(pool, ledger.Selectors{})
+
+	inst := testInstrument()
+	inst.VenueSymbol = ""
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	curve := []decimal.Decimal{decimal.RequireFromString("0.25"), decimal.RequireFromString("0.75")}
+	parent := execution.Parent{
+		ID: order.ParentID(id.New()), Algo: execution.AlgoVWAP, Status: execution.StatusRunning, BotID: "manual",
+		Instrument: inst, Side: order.Sell, Qty: decimal.RequireFromString("2"), Slices: 2, Interval: time.Minute, Curve: curve,
+		NextChildID: order.ClientOrderID(id.New()), NextAt: now, CreatedAt: now, UpdatedAt: now,
+	}
+	if _, err := store.CreateParent(ctx, parent); err != nil {
+		t.Fatalf("CreateParent: %v", err)
+	}
+	stored, err := store.GetParent(ctx, parent.ID)
+	if err != nil || len(stored.Curve) != 2 || !stored.Curve[1].Equal(curve[1]) || !stored.Benchmark.IsZero() {
+		t.Fatalf("GetParent = %+v, %v", stored, err)
+	}
+
+	parent.Status, parent.Benchmark, parent.UpdatedAt = execution.StatusCompleted, decimal.RequireFromString("50010.5"), time.Now()
+	if err := store.UpdateParent(ctx, parent); err != nil {
+		t.Fatalf("UpdateParent: %v", err)
+	}
+	if stored, err := store.GetParent(ctx, parent.ID); err != nil || !stored.Benchmark.Equal(parent.Benchmark) {
+		t.Fatalf("GetParent after settle = %+v, %v", stored, err)
+	}
+
+	short := parent
+	short.ID, short.Curve = order.ParentID(id.New()), curve[:1]
+	if _, err := store.CreateParent(ctx, short); err == nil {
+		t.Fatal("CreateParent with a curve shorter than its slices succeeded")
+	}
+}
+
+func TestOrderStoreIcebergRollup(t *testing.T) {
+	ctx := context.Background()
+	pool, err := Connect(ctx, startPostgres(t))
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer pool.Close()
+	store := NewOrderStore(pool, ledger.Selectors{})
+
+	inst := testInstrument()
+	inst.VenueSymbol = ""
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	parent := execution.Parent{
+		ID: order.ParentID(id.New()), Algo: execution.AlgoIceberg, Status: execution.StatusRunning, BotID: "manual",
+		Instrument: inst, Side: order.Buy, Qty: decimal.RequireFromString("2"), LimitPrice: decimal.RequireFromString("50000"),
+		DisplayQty: decimal.RequireFromString("0.5"), PriceJitter: decimal.RequireFromString("10"),
+		NextChildID: order.ClientOrderID(id.New()), NextAt: now, CreatedAt: now, UpdatedAt: now,
+	}
+	if _, err := store.CreateParent(ctx, parent); err != nil {
+		t.Fatalf("CreateParent: %v", err)
+	}
+	stored, err := store.GetParent(ctx, parent.ID)
+	if err != nil || !stored.DisplayQty.Equal(parent.DisplayQty) || !stored.PriceJitter.Equal(parent.PriceJitter) || stored.Slices != 0 {
+		t.Fatalf("GetParent = %+v, %v", stored, err)
+	}
+	invalid := parent
+	invalid.ID, invalid.DisplayQty = order.ParentID(id.New()), decimal.Zero
+	if _, err := store.CreateParent(ctx, invalid); err == nil {
+		t.Fatal("CreateParent stored an iceberg without a display size")
+	}
+
+	child := parent.Child(parent.NextChildID, parent.DisplayQty, parent.LimitPrice)
+	if _, err := store.CreatePending(ctx, child); err != nil {
+		t.Fatalf("CreatePending child: %v", err)
+	}
+	if _, err := store.ApplyEvent(ctx, order.SourceStream, fillEvent(child, order.StatusFilled, "0.5", "49990")); err != nil {
+		t.Fatalf("fill child: %v", err)
+	}
+
+	plain, err := store.ListOrders(ctx, order.Query{Limit: 10})
+	if err != nil || len(plain) != 1 || plain[0].Rollup {
+		t.Fatalf("ListOrders without parents = %+v, %v; want the child alone", plain, err)
+	}
+	rows, err := store.ListOrders(ctx, order.Query{Limit: 10, Parents: true})
+	if err != nil || len(rows) != 2 {
+		t.Fatalf("ListOrders with parents = %+v, %v", rows, err)
+	}
+	rollup := rows[0]
+	if !rollup.Rollup {
+		rollup = rows[1]
+	}
+	if string(rollup.ClientOrderID) != string(parent.ID) || rollup.Type != order.Limit || rollup.Status != order.StatusPartiallyFilled ||
+		!rollup.FilledQty.Equal(decimal.RequireFromString("0.5")) || !rollup.AvgFillPrice.Equal(decimal.RequireFromString("49990")) {
+		t.Fatalf("rollup = %+v", rollup)
+	}
+	filled, err := store.ListOrders(ctx, order.Query{Limit: 10, Parents: true, Statuses: []string{string(order.StatusFilled)}})
+	if err != nil || len(filled) != 1 || filled[0].Rollup {
+		t.Fatalf("filled orders = %+v, %v; want the child alone", filled, err)
+	}
+}
+
 func TestOutboxRoundTrip(t *testing.T) {
 	ctx := context.Background()
 	pool, err := Connect(ctx, startPostgres(t))
@@ -338,7 +759,7 @@ func TestOutboxRoundTrip(t *testing.T) {
 		t.Fatalf("Connect: %v", err)
 	}
 	defer pool.Close()
-	orders := NewOrderStore(pool)
+	orders := NewOrderStore(pool, ledger.Selectors{})
 	outbox := NewOutboxStore(pool)
 
 	req := newPendingOrder(ctx, t, orders)
@@ -354,9 +775,31 @@ func TestOutboxRoundTrip(t *testing.T) {
 		t.Fatalf("UnpublishedStats = %d rows, oldest %v, err=%v; want 3 rows", rows, oldest, err)
 	}
 
-	var subjects []string
+	// Resumable streams read rows back in publish order, once published.
+	if page, err := outbox.ListAfter(ctx, 0, "", 100); err != nil || len(page) != 0 {
+		t.Fatalf("ListAfter before publish = %+v, err=%v; want none", page, err)
+	}
+
+	// A read made while a batch is in flight waits for it to commit.
+	var (
+		subjects []string
+		seqs     []int64
+		during   = make(chan []events.OutboxMessage, 1)
+	)
 	published, err := outbox.PublishPending(ctx, 100, func(m events.OutboxMessage) error {
 		subjects = append(subjects, m.Subject)
+		seqs = append(seqs, m.PublishSeq)
+		if len(subjects) == 3 {
+			go func() {
+				page, _ := outbox.ListAfter(ctx, 0, "", 100)
+				during <- page
+			}()
+			select {
+			case page := <-during:
+				return fmt.Errorf("ListAfter returned %d rows while the batch was in flight", len(page))
+			case <-time.After(100 * time.Millisecond):
+			}
+		}
 		return nil
 	})
 	if err != nil || published != 3 {
@@ -368,6 +811,27 @@ func TestOutboxRoundTrip(t *testing.T) {
 			t.Fatalf("subjects = %v, want %v", subjects, want)
 		}
 	}
+	if seqs[0] == 0 || seqs[1] <= seqs[0] || seqs[2] <= seqs[1] {
+		t.Fatalf("publish seqs = %v, want increasing", seqs)
+	}
+	if page := <-during; len(page) != 3 {
+		t.Fatalf("ListAfter during publish = %+v; want the batch once it committed", page)
+	}
+
+	page, err := outbox.ListAfter(ctx, 0, "", 2)
+	if err != nil || len(page) != 2 || page[0].PublishSeq != seqs[0] || page[1].PublishSeq != seqs[1] {
+		t.Fatalf("ListAfter first page = %+v, err=%v; want 2 rows in publish order", page, err)
+	}
+	first := page[0].PublishSeq
+	if rest, err := outbox.ListAfter(ctx, first, "order.", 100); err != nil || len(rest) != 2 || rest[0].ID != page[1].ID {
+		t.Fatalf("ListAfter(%d) = %+v, err=%v; want the last 2 rows", first, rest, err)
+	}
+	if fills, err := outbox.ListAfter(ctx, 0, "order.fil", 100); err != nil || len(fills) != 1 || fills[0].Subject != events.SubjectOrderFilled {
+		t.Fatalf("ListAfter by prefix = %+v, err=%v; want the fill", fills, err)
+	}
+	if held, err := outbox.HasRow(ctx, first); err != nil || !held {
+		t.Fatalf("HasRow(%d) = %v, err=%v; want true", first, held, err)
+	}
 
 	published, err = outbox.PublishPending(ctx, 100, func(events.OutboxMessage) error { return nil })
 	if err != nil || published != 0 {
@@ -378,6 +842,9 @@ func TestOutboxRoundTrip(t *testing.T) {
 	if err != nil || deleted != 3 {
 		t.Fatalf("DeletePublishedBefore = %d, err=%v; want 3", deleted, err)
 	}
+	if held, err := outbox.HasRow(ctx, first); err != nil || held {
+		t.Fatalf("HasRow(%d) after cleanup = %v, err=%v; want false", first, held, err)
+	}
 }
 
 func TestOutboxPublishErrorKeepsRows(t *testing.T) {
@@ -387,7 +854,7 @@ func TestOutboxPublishErrorKeepsRows(t *testing.T) {
 		t.Fatalf("Connect: %v", err)
 	}
 	defer pool.Close()
-	orders := NewOrderStore(pool)
+	orders := NewOrderStore(pool, ledger.Selectors{})
 	outbox := NewOutboxStore(pool)
 
 	req := newPendingOrder(ctx, t, orders)
@@ -406,3 +873,70 @@ func TestOutboxPublishErrorKeepsRows(t *testing.T) {
 		t.Fatalf("UnpublishedStats after failed publish = %d, err=%v; want 1", rows, err)
 	}
 }
+
+func TestOrderStoreArbTrades(t *testing.T) {
+	ctx := context.Background()
+	pool, err := Connect(ctx, startPostgres(t))
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer pool.Close()
+	store := NewOrderStore(pool, ledger.Selectors{})
+
+	buy, sell := testInstrument(), testInstrument()
+	buy.VenueSymbol, sell.VenueSymbol, sell.Venue = "", "", "binance"
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	trade := arb.Trade{
+		ID: arb.TradeID(id.New()), BotID: "arb-1", Buy: buy, Sell: sell,
+		BuyOrderID: order.ClientOrderID(id.New()), SellOrderID: order.ClientOrderID(id.New()),
+		Qty: decimal.RequireFromString("0.5"), BuyPrice: decimal.RequireFromString("50000"), SellPrice: decimal.RequireFromString("50100"),
+		EdgeBps: decimal.RequireFromString("-0.02"), Status: arb.StatusOpen, CreatedAt: now, UpdatedAt: now,
+	}
+	if err := store.CreateArbTrade(ctx, trade); err != nil {
+		t.Fatalf("CreateArbTrade: %v", err)
+	}
+	if unsettled, err := store.ListUnsettledArbTrades(ctx, "arb-1"); err != nil || len(unsettled) != 1 || unsettled[0].Sell.Venue != "binance" {
+		t.Fatalf("ListUnsettledArbTrades = %+v, %v", unsettled, err)
+	}
+
+	settled, ok := trade.Settle(order.Record{Status: order.StatusFilled, FilledQty: trade.Qty}, order.Record{Status: order.StatusRejected}, now)
+	if !ok {
+		t.Fatal("Settle did not settle")
+	}
+	if err := store.UpdateArbTrade(ctx, settled); err != nil {
+		t.Fatalf("UpdateArbTrade: %v", err)
+	}
+	stored, err := store.GetArbTrade(ctx, trade.ID)
+	if err != nil || stored.Status != arb.StatusHedgeNeeded || stored.HedgeSide != order.Sell || !stored.HedgeQty.Equal(trade.Qty) ||
+		!stored.EdgeBps.Equal(trade.EdgeBps) || stored.Reason != settled.Reason {
+		t.Fatalf("GetArbTrade = %+v, %v", stored, err)
+	}
+
+	hedged, err := stored.Resolve("sold on bybit", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.UpdateArbTrade(ctx, hedged); err != nil {
+		t.Fatalf("UpdateArbTrade(hedged): %v", err)
+	}
+	if unsettled, err := store.ListArbTrades(ctx, "arb-1", true, 10); err != nil || len(unsettled) != 0 {
+		t.Fatalf("unsettled after resolve = %+v, %v", unsettled, err)
+	}
+	if all, err := store.ListArbTrades(ctx, "", false, 10); err != nil || len(all) != 1 || all[0].Status != arb.StatusHedged {
+		t.Fatalf("ListArbTrades = %+v, %v", all, err)
+	}
+
+	if _, err := store.GetArbTrade(ctx, "unknown"); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("GetArbTrade(unknown) err = %v", err)
+	}
+	missing := hedged
+	missing.ID = "unknown"
+	if err := store.UpdateArbTrade(ctx, missing); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("UpdateArbTrade(unknown) err = %v", err)
+	}
+	balanced := hedged
+	balanced.Status = arb.StatusBalanced // hedge fields belong to hedged trades only
+	if err := store.UpdateArbTrade(ctx, balanced); err == nil {
+		t.Fatal("UpdateArbTrade of a balanced trade with a hedge succeeded")
+	}
+}
@@ -21,7 +21,15 @@ type OutboxStore struct {
 	q    *sqlcgen.Queries
 }
 
-var _ ports.OutboxStore = (*OutboxStore)(nil)
+var (
+	_ ports.OutboxStore  = (*OutboxStore)(nil)
+	_ ports.OutboxReader = (*OutboxStore)(nil)
+)
+
+// publishLockKey is the advisory lock the relay holds while a batch is in
+// flight. Readers take it shared, so a batch is either committed before
+// they read or published after they do.
+var publishLockKey = inventoryLockKey("outbox", "publish")
 
 // NewOutboxStore returns an OutboxStore backed by pool.
 func NewOutboxStore(pool *pgxpool.Pool) *OutboxStore {
@@ -29,10 +37,10 @@ func NewOutboxStore(pool *pgxpool.Pool) *OutboxStore {
 }
 
 // PublishPending claims up to limit rows with FOR UPDATE SKIP LOCKED,
-// publishes each in id order, and marks the batch published in the same
-// transaction. A publish error rolls the batch back for the next poll.
-// Holding the row locks across publish is safe because the in-process bus
-// never blocks (ADR-0005).
+// stamps them published with their publish_seq, and publishes each in id
+// order before committing. A publish error rolls the batch back for the
+// next poll. Holding the locks across publish is safe because the
+// in-process bus never blocks (ADR-0005).
 func (s *OutboxStore) PublishPending(ctx context.Context, limit int, publish func(events.OutboxMessage) error) (int, error) {
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
@@ -41,6 +49,9 @@ func (s *OutboxStore) PublishPending(ctx context.Context, limit int, publish fun
 	defer func() { _ = tx.Rollback(ctx) }()
 
 	q := s.q.WithTx(tx)
+	if err := q.LockOutboxPublish(ctx, publishLockKey); err != nil {
+		return 0, fmt.Errorf("postgres: lock outbox publish: %w", err)
+	}
 	rows, err := q.ClaimUnpublishedOutbox(ctx, int64(limit))
 	if err != nil {
 		return 0, fmt.Errorf("postgres: claim outbox rows: %w", err)
@@ -50,20 +61,27 @@ func (s *OutboxStore) PublishPending(ctx context.Context, limit int, publish fun
 	}
 
 	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	stamped, err := q.MarkOutboxPublished(ctx, ids)
+	if err != nil {
+		return 0, fmt.Errorf("postgres: mark outbox published: %w", err)
+	}
+	seqs := make(map[int64]int64, len(stamped))
+	for _, st := range stamped {
+		seqs[st.ID] = st.Seq
+	}
 	for _, row := range rows {
 		if err := publish(events.OutboxMessage{
-			ID:        row.ID,
-			Subject:   row.Subject,
-			Payload:   row.Payload,
-			CreatedAt: row.CreatedAt,
+			ID:         row.ID,
+			Subject:    row.Subject,
+			Payload:    row.Payload,
+			CreatedAt:  row.CreatedAt,
+			PublishSeq: seqs[row.ID],
 		}); err != nil {
 			return 0, fmt.Errorf("postgres: publish outbox row %d: %w", row.ID, err)
 		}
-		ids = append(ids, row.ID)
-	}
-
-	if err := q.MarkOutboxPublished(ctx, ids); err != nil {
-		return 0, fmt.Errorf("postgres: mark outbox published: %w", err)
 	}
 	if err := tx.Commit(ctx); err != nil {
 		return 0, fmt.Errorf("postgres: commit outbox publish: %w", err)
@@ -71,7 +89,8 @@ func (s *OutboxStore) PublishPending(ctx context.Context, limit int, publish fun
 	return len(rows), nil
 }
 
-// DeletePublishedBefore removes rows published before cutoff.
+// DeletePublishedBefore removes rows published before cutoff, keeping
+// those a sink has yet to deliver.
 func (s *OutboxStore) DeletePublishedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
 	n, err := s.q.DeleteOutboxPublishedBefore(ctx, pgtype.Timestamptz{Time: cutoff, Valid: true})
 	if err != nil {
@@ -89,3 +108,56 @@ func (s *OutboxStore) UnpublishedStats(ctx context.Context) (int64, time.Time, e
 	}
 	return row.Unpublished, row.Oldest, nil
 }
+
+// ListAfter returns up to limit rows published after the given
+// publish_seq whose subject starts with prefix, in publish order.
+func (s *OutboxStore) ListAfter(ctx context.Context, after int64, prefix string, limit int) ([]events.OutboxMessage, error) {
+	var rows []sqlcgen.ListOutboxAfterRow
+	err := s.afterPublish(ctx, func(q *sqlcgen.Queries) (err error) {
+		rows, err = q.ListOutboxAfter(ctx, sqlcgen.ListOutboxAfterParams{After: after, Prefix: prefix, RowLimit: int64(limit)})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list outbox rows after %d: %w", after, err)
+	}
+	out := make([]events.OutboxMessage, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, events.OutboxMessage{
+			ID: row.ID, Subject: row.Subject, Payload: row.Payload, CreatedAt: row.CreatedAt, PublishSeq: row.PublishSeq,
+		})
+	}
+	return out, nil
+}
+
+// HasRow reports whether cleanup has not yet removed the row published at
+// seq.
+func (s *OutboxStore) HasRow(ctx context.Context, seq int64) (bool, error) {
+	var ok bool
+	err := s.afterPublish(ctx, func(q *sqlcgen.Queries) (err error) {
+		ok, err = q.OutboxHasRow(ctx, seq)
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("postgres: outbox row at %d: %w", seq, err)
+	}
+	return ok, nil
+}
+
+// afterPublish runs read once a batch the relay has in flight commits, so
+// it sees every row already published to the bus.
+func (s *OutboxStore) afterPublish(ctx context.Context, read func(q *sqlcgen.Queries) error) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	q := s.q.WithTx(tx)
+	if err := q.WaitOutboxPublish(ctx, publishLockKey); err != nil {
+		return fmt.Errorf("wait for publish: %w", err)
+	}
+	if err := read(q); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/romanornr/delta-works/internal/adapters/postgres/sqlcgen"
+	"github.com/romanornr/delta-works/internal/domain/execution"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+var _ ports.ParentOrderStore = (*OrderStore)(nil)
+
+// CreateParent inserts the parent. Re-inserting the same ID is a no-op, so
+// a retried placement keeps the terms it was first given.
+func (s *OrderStore) CreateParent(ctx context.Context, p execution.Parent) (bool, error) {
+	n, err := s.q.InsertParentOrder(ctx, sqlcgen.InsertParentOrderParams{
+		ParentID:        string(p.ID),
+		Algo:            string(p.Algo),
+		Status:          string(p.Status),
+		Venue:           string(p.Instrument.Venue),
+		Base:            string(p.Instrument.Base),
+		Quote:           string(p.Instrument.Quote),
+		BotID:           p.BotID,
+		Side:            string(p.Side),
+		Qty:             p.Qty,
+		LimitPrice:      nullNumeric(p.LimitPrice),
+		Slices:          int32(p.Slices), //nolint:gosec // bounded by execution.MaxSlices
+		SliceIntervalMs: p.Interval.Milliseconds(),
+		Jitter:          p.Jitter,
+		DisplayQty:      nullNumeric(p.DisplayQty),
+		PriceJitter:     p.PriceJitter,
+		Curve:           p.Curve,
+		NextChildID:     string(p.NextChildID),
+		NextAt:          p.NextAt.UTC(),
+		At:              p.CreatedAt.UTC(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("postgres: insert parent order: %w", err)
+	}
+	return n == 1, nil
+}
+
+// GetParent returns the parent, or ports.ErrNotFound.
+func (s *OrderStore) GetParent(ctx context.Context, id order.ParentID) (execution.Parent, error) {
+	row, err := s.q.GetParentOrder(ctx, string(id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return execution.Parent{}, ports.ErrNotFound
+	}
+	if err != nil {
+		return execution.Parent{}, fmt.Errorf("postgres: get parent order: %w", err)
+	}
+	return parentRecord(row), nil
+}
+
+// ListParents returns at most limit parents, newest first.
+func (s *OrderStore) ListParents(ctx context.Context, venue instrument.VenueID, liveOnly bool, limit int32) ([]execution.Parent, error) {
+	rows, err := s.q.ListParentOrders(ctx, sqlcgen.ListParentOrdersParams{
+		Venue: nullString(string(venue)), LiveOnly: liveOnly, RowLimit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list parent orders: %w", err)
+	}
+	return parentRecords(rows), nil
+}
+
+// ListLiveParents returns every running or paused parent, oldest first.
+func (s *OrderStore) ListLiveParents(ctx context.Context) ([]execution.Parent, error) {
+	rows, err := s.q.ListLiveParentOrders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list live parent orders: %w", err)
+	}
+	return parentRecords(rows), nil
+}
+
+// UpdateParent stores the parent's status, schedule, reason and benchmark.
+func (s *OrderStore) UpdateParent(ctx context.Context, p execution.Parent) error {
+	n, err := s.q.UpdateParentOrder(ctx, sqlcgen.UpdateParentOrderParams{
+		ParentID:       string(p.ID),
+		Status:         string(p.Status),
+		SlicesSent:     int32(p.SlicesSent), //nolint:gosec // bounded by execution.MaxSlices
+		NextChildID:    string(p.NextChildID),
+		NextAt:         p.NextAt.UTC(),
+		Reason:         p.Reason,
+		BenchmarkPrice: nullNumeric(p.Benchmark),
+		At:             p.UpdatedAt.UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("postgres: update parent order: %w", err)
+	}
+	if n == 0 {
+		return ports.ErrNotFound
+	}
+	return nil
+}
+
+// ListChildren returns the parent's child orders, oldest first.
+func (s *OrderStore) ListChildren(ctx context.Context, id order.ParentID) ([]order.Record, error) {
+	rows, err := s.q.ListParentChildren(ctx, nullString(string(id)))
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list parent children: %w", err)
+	}
+	children := make([]order.Record, 0, len(rows))
+	for _, row := range rows {
+		children = append(children, orderRecord(row))
+	}
+	return children, nil
+}
+
+// listParentRollups returns the parents on query's page as rollup records.
+func (s *OrderStore) listParentRollups(ctx context.Context, params sqlcgen.ListParentRollupsParams) ([]order.Record, error) {
+	rows, err := s.q.ListParentRollups(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list parent rollups: %w", err)
+	}
+	rollups := make([]order.Record, 0, len(rows))
+	for _, row := range rows {
+		rec := order.Record{
+			ClientOrderID: order.ClientOrderID(row.ParentID),
+			Rollup:        true,
+			BotID:         row.BotID,
+			Reason:        row.Reason,
+			Instrument: instrument.Instrument{
+				Venue: instrument.VenueID(row.Venue), Type: instrument.TypeSpot,
+				Base: money.Currency(row.Base), Quote: money.Currency(row.Quote),
+			},
+			Side:         order.Side(row.Side),
+			Type:         order.Market,
+			Price:        fromNumeric(row.LimitPrice),
+			Qty:          row.Qty,
+			FilledQty:    row.FilledQty,
+			AvgFillPrice: row.AvgFillPrice,
+			Status:       order.Status(row.Status),
+			CreatedAt:    row.CreatedAt,
+			UpdatedAt:    row.UpdatedAt,
+		}
+		if rec.Price.IsPositive() {
+			rec.Type = order.Limit
+		}
+		rollups = append(rollups, rec)
+	}
+	return rollups, nil
+}
+
+func parentRecords(rows []sqlcgen.ParentOrder) []execution.Parent {
+	parents := make([]execution.Parent, 0, len(rows))
+	for _, row := range rows {
+		parents = append(parents, parentRecord(row))
+	}
+	return parents
+}
+
+func parentRecord(row sqlcgen.ParentOrder) execution.Parent {
+	return execution.Parent{
+		ID:     order.ParentID(row.ParentID),
+		Algo:   execution.Algo(row.Algo),
+		Status: execution.Status(row.Status),
+		BotID:  row.BotID,
+		Instrument: instrument.Instrument{
+			Venue: instrument.VenueID(row.Venue), Type: instrument.TypeSpot,
+			Base: money.Currency(row.Base), Quote: money.Currency(row.Quote),
+		},
+		Side:        order.Side(row.Side),
+		Qty:         row.Qty,
+		LimitPrice:  fromNumeric(row.LimitPrice),
+		Slices:      int(row.Slices),
+		Interval:    time.Duration(row.SliceIntervalMs) * time.Millisecond,
+		Jitter:      row.Jitter,
+		DisplayQty:  fromNumeric(row.DisplayQty),
+		PriceJitter: row.PriceJitter,
+		Curve:       row.Curve,
+		Benchmark:   fromNumeric(row.BenchmarkPrice),
+		SlicesSent:  int(row.SlicesSent),
+		NextChildID: order.ClientOrderID(row.NextChildID),
+		NextAt:      row.NextAt,
+		Reason:      row.Reason,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}
+}
@@ -58,7 +58,8 @@ func migrate(ctx context.Context, pool *pgxpool.Pool) error {
 
 // CheckpointStore records and reads snapshot checkpoints.
 type CheckpointStore struct {
-	q *sqlcgen.Queries
+	pool *pgxpool.Pool
+	q    *sqlcgen.Queries
 }
 
 var (
@@ -68,12 +69,19 @@ var (
 
 // NewCheckpointStore builds a store over the pool.
 func NewCheckpointStore(pool *pgxpool.Pool) *CheckpointStore {
-	return &CheckpointStore{q: sqlcgen.New(pool)}
+	return &CheckpointStore{pool: pool, q: sqlcgen.New(pool)}
 }
 
-// RecordSnapshot stores one durable checkpoint.
+// RecordSnapshot stores one durable checkpoint. A successful one replaces
+// the account's latest balances in the same transaction.
 func (s *CheckpointStore) RecordSnapshot(ctx context.Context, c snapshot.Checkpoint) error {
-	return s.q.RecordSnapshot(ctx, sqlcgen.RecordSnapshotParams{
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("postgres: begin record snapshot: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	q := s.q.WithTx(tx)
+	if err := q.RecordSnapshot(ctx, sqlcgen.RecordSnapshotParams{
 		ID:           c.ID,
 		Venue:        string(c.Account.Venue),
 		AccountType:  string(c.Account.Type),
@@ -81,7 +89,26 @@ func (s *CheckpointStore) RecordSnapshot(ctx context.Context, c snapshot.Checkpo
 		BalanceCount: int32(c.BalanceCount), //nolint:gosec // balance counts are tiny
 		Status:       string(c.Status),
 		Error:        c.Error,
-	})
+	}); err != nil {
+		return fmt.Errorf("postgres: record snapshot: %w", err)
+	}
+	if c.Status == snapshot.StatusOK {
+		if err := q.DeleteBalances(ctx, sqlcgen.DeleteBalancesParams{Venue: string(c.Account.Venue), AccountType: string(c.Account.Type)}); err != nil {
+			return fmt.Errorf("postgres: delete balances: %w", err)
+		}
+		for _, b := range c.Balances {
+			if err := q.InsertBalance(ctx, sqlcgen.InsertBalanceParams{
+				Venue: string(c.Account.Venue), AccountType: string(c.Account.Type), Currency: string(b.Currency),
+				Total: b.Total, Free: b.Free, Locked: b.Locked, TakenAt: c.TakenAt,
+			}); err != nil {
+				return fmt.Errorf("postgres: insert balance: %w", err)
+			}
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("postgres: commit record snapshot: %w", err)
+	}
+	return nil
 }
 
 // LastSnapshot returns the most recent checkpoint for an account.
@@ -9,10 +9,13 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
 
 	"github.com/romanornr/delta-works/internal/config"
 	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/order"
 	"github.com/romanornr/delta-works/internal/ports"
 	"github.com/romanornr/delta-works/internal/snapshot"
 )
@@ -93,3 +96,83 @@ func TestMigrationsAndCheckpointRoundTrip(t *testing.T) {
 	}
 	pool2.Close()
 }
+
+func TestKillSwitchRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	pool, err := Connect(ctx, startPostgres(t))
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer pool.Close()
+	store := NewKillSwitchStore(pool)
+
+	at := time.Now().UTC().Truncate(time.Microsecond)
+	global, err := store.EngageKillSwitch(ctx, order.Halt{Reason: "drill", EngagedAt: at})
+	if err != nil || global.Venue != "" || !global.EngagedAt.Equal(at) {
+		t.Fatalf("EngageKillSwitch = %+v, %v", global, err)
+	}
+	// Engaging again keeps the original switch.
+	again, err := store.EngageKillSwitch(ctx, order.Halt{Reason: "second", EngagedAt: at.Add(time.Minute)})
+	if err != nil || again.Reason != "drill" || !again.EngagedAt.Equal(at) {
+		t.Fatalf("re-engage = %+v, %v", again, err)
+	}
+	if _, err := store.EngageKillSwitch(ctx, order.Halt{Venue: "bybit", EngagedAt: at}); err != nil {
+		t.Fatal(err)
+	}
+	halts, err := store.ListKillSwitches(ctx)
+	if err != nil || len(halts) != 2 || halts[0].Venue != "" || halts[1].Venue != "bybit" {
+		t.Fatalf("ListKillSwitches = %+v, %v", halts, err)
+	}
+	if err := store.ReleaseKillSwitch(ctx, "bybit"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.ReleaseKillSwitch(ctx, "bybit"); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("second release = %v, want ErrNotFound", err)
+	}
+}
+
+func TestInstrumentCatalogRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	pool, err := Connect(ctx, startPostgres(t))
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer pool.Close()
+	store := NewInstrumentStore(pool)
+
+	btc := instrument.Instrument{
+		Venue: "bybit", Type: instrument.TypeSpot, Base: "BTC", Quote: "USDT", VenueSymbol: "BTCUSDT",
+		Rules: instrument.Rules{PriceIncrement: decimal.RequireFromString("0.1"), MinNotional: decimal.NewFromInt(5)},
+	}
+	at := time.Now().UTC().Truncate(time.Microsecond)
+	if err := store.ApplyInstrumentChanges(ctx, instrument.Diff(nil, []instrument.Instrument{btc}), at); err != nil {
+		t.Fatal(err)
+	}
+	listed, err := store.ListInstruments(ctx, "bybit")
+	if err != nil || len(listed) != 1 || listed[0].Status != instrument.StatusTrading || !listed[0].Rules.Equal(btc.Rules) {
+		t.Fatalf("ListInstruments = %+v, %v", listed, err)
+	}
+
+	delisted := instrument.Diff(listed, nil)
+	if err := store.ApplyInstrumentChanges(ctx, delisted, at.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.ApplyInstrumentChanges(ctx, instrument.Diff([]instrument.Instrument{delisted[0].Instrument}, []instrument.Instrument{btc}), at.Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	var listedAt time.Time
+	var history, outbox int
+	if err := pool.QueryRow(ctx, `
+		SELECT listed_at,
+		       (SELECT count(*) FROM instrument_history WHERE venue = 'bybit'),
+		       (SELECT count(*) FROM outbox WHERE subject = 'instrument.changed')
+		FROM instruments WHERE venue = 'bybit' AND base = 'BTC'`).Scan(&listedAt, &history, &outbox); err != nil {
+		t.Fatal(err)
+	}
+	if !listedAt.Equal(at.Add(2*time.Hour)) || history != 3 || outbox != 3 {
+		t.Fatalf("listed_at=%s history=%d outbox=%d, want the relisting time and 3 rows each", listedAt, history, outbox)
+	}
+	if all, err := store.ListInstruments(ctx, ""); err != nil || len(all) != 1 {
+		t.Fatalf("ListInstruments(all) = %+v, %v", all, err)
+	}
+}
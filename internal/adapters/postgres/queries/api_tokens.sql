@@ -0,0 +1,15 @@
+-- name: InsertAPIToken :execrows
+INSERT INTO api_tokens (token_id, name, scopes, hash, created_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT DO NOTHING;
+
+-- name: GetValidAPITokenByHash :one
+SELECT * FROM api_tokens WHERE hash = $1 AND revoked_at IS NULL;
+
+-- name: ListAPITokens :many
+SELECT * FROM api_tokens
+WHERE sqlc.arg(include_revoked)::boolean OR revoked_at IS NULL
+ORDER BY created_at, token_id;
+
+-- name: RevokeAPIToken :execrows
+UPDATE api_tokens SET revoked_at = $2 WHERE token_id = $1 AND revoked_at IS NULL;
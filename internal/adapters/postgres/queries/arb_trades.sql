@@ -0,0 +1,24 @@
+-- name: InsertArbTrade :exec
+INSERT INTO arb_trades (trade_id, bot_id, base, quote, buy_venue, sell_venue, buy_order_id, sell_order_id, qty,
+                        buy_price, sell_price, edge_bps, status, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, sqlc.arg(at), sqlc.arg(at));
+
+-- name: GetArbTrade :one
+SELECT * FROM arb_trades WHERE trade_id = $1;
+
+-- name: ListArbTrades :many
+SELECT * FROM arb_trades
+WHERE (sqlc.narg(bot_id)::text IS NULL OR bot_id = sqlc.narg(bot_id))
+  AND (NOT sqlc.arg(unsettled_only)::boolean OR status IN ('open', 'hedge_needed'))
+ORDER BY created_at DESC, trade_id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListUnsettledArbTrades :many
+SELECT * FROM arb_trades
+WHERE bot_id = $1 AND status IN ('open', 'hedge_needed')
+ORDER BY created_at, trade_id;
+
+-- name: UpdateArbTrade :execrows
+UPDATE arb_trades
+SET status = $2, hedge_side = sqlc.narg(hedge_side), hedge_qty = sqlc.narg(hedge_qty), reason = $3, updated_at = sqlc.arg(at)
+WHERE trade_id = $1;
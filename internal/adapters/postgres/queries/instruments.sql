@@ -0,0 +1,33 @@
+-- name: ListInstruments :many
+SELECT * FROM instruments
+WHERE (sqlc.narg(venue)::text IS NULL OR venue = sqlc.narg(venue))
+ORDER BY venue, type, base, quote;
+
+-- name: UpsertInstrument :exec
+-- listed_at moves only on a (re)listing, so it dates the current listing.
+INSERT INTO instruments (
+    venue, type, base, quote, venue_symbol, status,
+    price_increment, qty_increment, min_qty, min_notional, listed_at, updated_at
+) VALUES (
+    @venue, @type, @base, @quote, @venue_symbol, @status,
+    @price_increment, @qty_increment, @min_qty, @min_notional, @at, @at
+)
+ON CONFLICT (venue, type, base, quote) DO UPDATE SET
+    venue_symbol    = EXCLUDED.venue_symbol,
+    status          = EXCLUDED.status,
+    price_increment = EXCLUDED.price_increment,
+    qty_increment   = EXCLUDED.qty_increment,
+    min_qty         = EXCLUDED.min_qty,
+    min_notional    = EXCLUDED.min_notional,
+    listed_at       = CASE WHEN instruments.status = 'delisted' THEN EXCLUDED.listed_at ELSE instruments.listed_at END,
+    updated_at      = EXCLUDED.updated_at;
+
+-- name: InsertInstrumentHistory :exec
+INSERT INTO instrument_history (
+    venue, type, base, quote, change, venue_symbol, status,
+    price_increment, qty_increment, min_qty, min_notional, recorded_at
+) VALUES (
+    @venue, @type, @base, @quote, @change, @venue_symbol, @status,
+    @price_increment, @qty_increment, @min_qty, @min_notional, @recorded_at
+);
+
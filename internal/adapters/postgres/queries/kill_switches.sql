@@ -0,0 +1,13 @@
+-- name: EngageKillSwitch :one
+-- Re-engaging keeps the original row; the no-op update makes RETURNING
+-- yield it.
+INSERT INTO kill_switches (venue, reason, engaged_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (venue) DO UPDATE SET venue = EXCLUDED.venue
+RETURNING *;
+
+-- name: ReleaseKillSwitch :execrows
+DELETE FROM kill_switches WHERE venue = $1;
+
+-- name: ListKillSwitches :many
+SELECT * FROM kill_switches ORDER BY venue;
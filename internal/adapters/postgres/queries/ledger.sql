@@ -3,28 +3,119 @@ SELECT pg_advisory_xact_lock(sqlc.arg(key)::bigint);
 
 -- name: InsertLot :exec
 INSERT INTO lots (
-    id, bot_id, venue, base, quote, qty, remaining_qty, cost_price,
+    id, bot_id, venue, base, quote, qty, remaining_qty, cost_price, cost, remaining_cost,
     opened_by_fill_id, status, opened_at
 )
-VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $8, 'open', $9);
+VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $8, $8, $9, 'open', $10);
 
 -- name: ListOpenLotsForUpdate :many
+SELECT sqlc.embed(lots), orders.price AS order_price
+FROM lots
+JOIN fills ON fills.id = lots.opened_by_fill_id
+JOIN orders ON orders.client_order_id = fills.client_order_id
+WHERE lots.bot_id = $1 AND lots.venue = $2 AND lots.base = $3 AND lots.quote = $4 AND lots.status = 'open'
+ORDER BY lots.opened_at, lots.id
+FOR UPDATE OF lots;
+
+-- name: ListFeeLotsForUpdate :many
+-- Lots of every quote held in a fee currency, for a third-currency fee to
+-- consume oldest first.
 SELECT * FROM lots
-WHERE bot_id = $1 AND venue = $2 AND base = $3 AND quote = $4 AND status = 'open'
+WHERE bot_id = $1 AND venue = $2 AND base = $3 AND status = 'open'
 ORDER BY opened_at, id
 FOR UPDATE;
 
 -- name: InsertLotClosure :exec
-INSERT INTO lot_closures (lot_id, sell_fill_id, qty, price, closed_at)
-VALUES ($1, $2, $3, $4, $5);
+INSERT INTO lot_closures (lot_id, sell_fill_id, kind, qty, price, cost, fee, closed_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
 
 -- name: DecrementLot :exec
 UPDATE lots
 SET remaining_qty = remaining_qty - sqlc.arg(qty),
+    remaining_cost = remaining_cost - sqlc.arg(cost),
     status = CASE WHEN remaining_qty - sqlc.arg(qty) = 0 THEN 'closed' ELSE 'open' END,
     closed_at = CASE WHEN remaining_qty - sqlc.arg(qty) = 0 THEN sqlc.arg(closed_at)::timestamptz ELSE NULL END
 WHERE id = sqlc.arg(id);
 
 -- name: InsertUnmatchedSell :exec
-INSERT INTO unmatched_sells (sell_fill_id, bot_id, venue, base, quote, qty, occurred_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7);
+INSERT INTO unmatched_sells (sell_fill_id, bot_id, venue, base, quote, qty, fee, occurred_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: InsertFeeCharge :exec
+INSERT INTO fee_charges (fill_id, bot_id, venue, base, quote, currency, amount, occurred_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: ListClosedLots :many
+SELECT
+    lot_closures.id, lot_closures.lot_id, lot_closures.sell_fill_id, lot_closures.kind,
+    lot_closures.qty, lot_closures.price AS sell_price, lot_closures.cost, lot_closures.fee,
+    lot_closures.closed_at,
+    lots.bot_id, lots.venue, lots.base, lots.quote, lots.cost_price, lots.opened_by_fill_id
+FROM lot_closures
+JOIN lots ON lots.id = lot_closures.lot_id
+WHERE lot_closures.closed_at >= sqlc.arg(closed_from)::timestamptz
+  AND lot_closures.closed_at < sqlc.arg(closed_to)::timestamptz
+  AND (sqlc.narg(bot_id)::text IS NULL OR lots.bot_id = sqlc.narg(bot_id))
+  AND (sqlc.narg(venue)::text IS NULL OR lots.venue = sqlc.narg(venue))
+  AND (sqlc.narg(base)::text IS NULL OR lots.base = sqlc.narg(base))
+  AND (sqlc.narg(quote)::text IS NULL OR lots.quote = sqlc.narg(quote))
+  AND (
+    sqlc.narg(cursor_closed_at)::timestamptz IS NULL OR
+    (lot_closures.closed_at, lot_closures.id) > (
+      sqlc.narg(cursor_closed_at)::timestamptz,
+      sqlc.narg(cursor_id)::bigint
+    )
+  )
+ORDER BY lot_closures.closed_at, lot_closures.id
+LIMIT sqlc.arg(row_limit)::bigint;
+
+-- name: SumClosedLots :many
+-- Mirrors ledger.SumClosures: a closure is priced when its lot has a cost
+-- price and, for a sale, the sell has a price too.
+WITH closed AS (
+    SELECT
+        lots.bot_id, lots.venue, lots.base, lots.quote,
+        lot_closures.kind, lot_closures.qty, lot_closures.cost,
+        lot_closures.qty * lot_closures.price - lot_closures.fee AS proceeds,
+        lots.cost_price > 0 AND (lot_closures.kind = 'fee' OR lot_closures.price > 0) AS priced
+    FROM lot_closures
+    JOIN lots ON lots.id = lot_closures.lot_id
+    WHERE lot_closures.closed_at >= sqlc.arg(closed_from)::timestamptz
+      AND lot_closures.closed_at < sqlc.arg(closed_to)::timestamptz
+      AND (sqlc.narg(bot_id)::text IS NULL OR lots.bot_id = sqlc.narg(bot_id))
+      AND (sqlc.narg(venue)::text IS NULL OR lots.venue = sqlc.narg(venue))
+      AND (sqlc.narg(base)::text IS NULL OR lots.base = sqlc.narg(base))
+      AND (sqlc.narg(quote)::text IS NULL OR lots.quote = sqlc.narg(quote))
+)
+SELECT
+    bot_id, venue, base, quote,
+    COALESCE(sum(qty) FILTER (WHERE priced AND kind = 'sale'), 0)::numeric AS qty,
+    COALESCE(sum(qty) FILTER (WHERE priced AND kind = 'fee'), 0)::numeric AS fee_qty,
+    COALESCE(sum(cost) FILTER (WHERE priced), 0)::numeric AS cost,
+    COALESCE(sum(proceeds) FILTER (WHERE priced AND kind = 'sale'), 0)::numeric AS proceeds,
+    COALESCE(sum(qty) FILTER (WHERE NOT priced), 0)::numeric AS unpriced_qty
+FROM closed
+GROUP BY bot_id, venue, base, quote
+ORDER BY bot_id, venue, base, quote;
+
+-- name: SumFeeCharges :many
+SELECT bot_id, venue, base, quote, currency, sum(amount)::numeric AS amount
+FROM fee_charges
+WHERE occurred_at >= sqlc.arg(occurred_from)::timestamptz
+  AND occurred_at < sqlc.arg(occurred_to)::timestamptz
+  AND (sqlc.narg(bot_id)::text IS NULL OR bot_id = sqlc.narg(bot_id))
+  AND (sqlc.narg(venue)::text IS NULL OR venue = sqlc.narg(venue))
+  AND (sqlc.narg(base)::text IS NULL OR base = sqlc.narg(base))
+  AND (sqlc.narg(quote)::text IS NULL OR quote = sqlc.narg(quote))
+GROUP BY bot_id, venue, base, quote, currency
+ORDER BY bot_id, venue, base, quote, currency;
+
+-- name: ListOpenLots :many
+SELECT * FROM lots
+WHERE status = 'open'
+ORDER BY bot_id, venue, base, quote, opened_at, id;
+
+-- name: SumOpenLotQty :one
+SELECT COALESCE(sum(remaining_qty), 0)::numeric AS qty
+FROM lots
+WHERE bot_id = $1 AND venue = $2 AND base = $3 AND quote = $4 AND status = 'open';
@@ -0,0 +1,37 @@
+-- name: InsertOrderGroup :execrows
+INSERT INTO order_groups (group_id, kind, status, venue, base, quote, bot_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, sqlc.arg(at), sqlc.arg(at))
+ON CONFLICT (group_id) DO NOTHING;
+
+-- name: InsertOrderGroupLeg :exec
+INSERT INTO order_group_legs (group_id, role, client_order_id, side, type, price, qty, trigger_price)
+VALUES ($1, $2, $3, $4, $5, $6, $7, sqlc.narg(trigger_price));
+
+-- name: GetOrderGroup :one
+SELECT * FROM order_groups WHERE group_id = $1;
+
+-- name: GetOrderGroupOfLeg :one
+SELECT g.* FROM order_groups g
+JOIN order_group_legs l ON l.group_id = g.group_id
+WHERE l.client_order_id = $1;
+
+-- name: ListOrderGroups :many
+SELECT * FROM order_groups
+WHERE (sqlc.narg(venue)::text IS NULL OR venue = sqlc.narg(venue))
+  AND (NOT sqlc.arg(open_only)::boolean OR status IN ('pending', 'active'))
+ORDER BY created_at DESC, group_id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListOpenOrderGroups :many
+SELECT * FROM order_groups
+WHERE status IN ('pending', 'active')
+ORDER BY created_at, group_id;
+
+-- name: ListOrderGroupLegs :many
+SELECT * FROM order_group_legs
+WHERE group_id = ANY(sqlc.arg(group_ids)::text[])
+ORDER BY group_id, CASE role WHEN 'entry' THEN 0 WHEN 'take_profit' THEN 1 ELSE 2 END;
+
+-- name: SetOrderGroupStatus :execrows
+UPDATE order_groups SET status = $2, reason = $3, updated_at = sqlc.arg(at)
+WHERE group_id = $1;
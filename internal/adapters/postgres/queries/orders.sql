@@ -1,16 +1,35 @@
 -- name: InsertPendingOrder :execrows
-INSERT INTO orders (client_order_id, venue, base, quote, venue_symbol, side, type, price, qty, bot_id, status)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, 'pending')
+INSERT INTO orders (client_order_id, venue, base, quote, venue_symbol, side, type, price, qty, bot_id,
+                    time_in_force, expires_at, post_only, trigger_price, parent_id, status)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, sqlc.narg(trigger_price), sqlc.narg(parent_id), 'pending')
 ON CONFLICT (client_order_id) DO NOTHING;
 
+-- name: InsertUntriggeredOrder :execrows
+INSERT INTO orders (client_order_id, venue, base, quote, venue_symbol, side, type, price, qty, bot_id,
+                    time_in_force, trigger_price, child_client_order_id, status)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, 'untriggered')
+ON CONFLICT (client_order_id) DO NOTHING;
+
+-- name: ListUntriggeredStops :many
+SELECT * FROM orders
+WHERE status = 'untriggered' AND child_client_order_id IS NOT NULL
+  AND (sqlc.narg(venue)::text IS NULL OR venue = sqlc.narg(venue))
+ORDER BY created_at, client_order_id;
+
 -- name: GetOrder :one
 SELECT * FROM orders WHERE client_order_id = $1;
 
 -- name: ListActiveOrders :many
 SELECT * FROM orders
-WHERE venue = $1 AND status IN ('pending', 'open', 'partially_filled')
+WHERE venue = $1 AND status IN ('pending', 'untriggered', 'open', 'partially_filled')
 ORDER BY created_at;
 
+-- name: CountActiveOrders :one
+SELECT count(*) FROM orders
+WHERE status IN ('pending', 'open', 'partially_filled')
+  AND (sqlc.narg(venue)::text IS NULL OR venue = sqlc.narg(venue))
+  AND (sqlc.narg(bot_id)::text IS NULL OR bot_id = sqlc.narg(bot_id));
+
 -- name: ListOrders :many
 SELECT * FROM orders
 WHERE (sqlc.narg(venue)::text IS NULL OR venue = sqlc.narg(venue))
@@ -65,3 +84,28 @@ INSERT INTO fills (client_order_id, transition_id, qty, price, fee, fee_currency
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 ON CONFLICT (client_order_id, venue_fill_id) WHERE venue_fill_id IS NOT NULL DO NOTHING
 RETURNING id;
+
+-- name: AmendOrderTerms :execrows
+UPDATE orders
+SET price      = $2,
+    qty        = $3,
+    updated_at = now()
+WHERE client_order_id = $1;
+
+-- name: InsertOrderReplacement :exec
+INSERT INTO order_replacements (old_client_order_id, new_client_order_id, mode, price, qty, requested_at)
+VALUES ($1, $2, $3, $4, $5, COALESCE(sqlc.narg(requested_at)::timestamptz, now()))
+ON CONFLICT DO NOTHING;
+
+-- name: InsertReplaceIntent :exec
+INSERT INTO replace_intents (old_client_order_id, new_client_order_id, price, qty, requested_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (old_client_order_id) DO NOTHING;
+
+-- name: GetReplaceIntent :one
+SELECT * FROM replace_intents WHERE old_client_order_id = $1;
+
+-- name: ListOrderReplacements :many
+SELECT * FROM order_replacements
+WHERE old_client_order_id = $1 OR new_client_order_id = $1
+ORDER BY id;
@@ -8,13 +8,42 @@ ORDER BY id
 LIMIT $1::bigint
 FOR UPDATE SKIP LOCKED;
 
--- name: MarkOutboxPublished :exec
-UPDATE outbox SET published_at = now() WHERE id = ANY($1::bigint[]);
+-- name: MarkOutboxPublished :many
+-- Rows are stamped in ID order, the order the relay publishes them; the
+-- sort runs before nextval is evaluated.
+UPDATE outbox SET published_at = now(), publish_seq = stamped.seq
+FROM (
+    SELECT id, nextval('outbox_publish_seq') AS seq FROM outbox
+    WHERE id = ANY($1::bigint[])
+    ORDER BY id
+) AS stamped
+WHERE outbox.id = stamped.id
+RETURNING outbox.id, stamped.seq;
+
+-- name: LockOutboxPublish :exec
+-- Held by the relay from claiming a batch until it commits.
+SELECT pg_advisory_xact_lock(sqlc.arg(key)::bigint);
+
+-- name: WaitOutboxPublish :exec
+-- Waits for a batch the relay holds LockOutboxPublish for to commit.
+SELECT pg_advisory_xact_lock_shared(sqlc.arg(key)::bigint);
 
 -- name: DeleteOutboxPublishedBefore :execrows
-DELETE FROM outbox WHERE published_at IS NOT NULL AND published_at < $1;
+-- Rows a sink has yet to pass are kept however old they are.
+DELETE FROM outbox
+WHERE published_at IS NOT NULL AND published_at < $1
+  AND (publish_seq IS NULL OR publish_seq <= COALESCE((SELECT MIN(after) FROM sink_cursors), publish_seq));
 
 -- name: OutboxUnpublishedStats :one
 SELECT COUNT(*) AS unpublished, COALESCE(MIN(created_at), now())::timestamptz AS oldest
 FROM outbox
 WHERE published_at IS NULL;
+
+-- name: ListOutboxAfter :many
+SELECT id, subject, payload, created_at, publish_seq::bigint AS publish_seq FROM outbox
+WHERE publish_seq > sqlc.arg(after)::bigint AND starts_with(subject, sqlc.arg(prefix)::text)
+ORDER BY publish_seq
+LIMIT sqlc.arg(row_limit)::bigint;
+
+-- name: OutboxHasRow :one
+SELECT EXISTS (SELECT 1 FROM outbox WHERE publish_seq = sqlc.arg(publish_seq)::bigint);
@@ -0,0 +1,69 @@
+-- name: InsertParentOrder :execrows
+INSERT INTO parent_orders (parent_id, algo, status, venue, base, quote, bot_id, side, qty, limit_price,
+                           slices, slice_interval_ms, jitter, display_qty, price_jitter, curve, next_child_id, next_at,
+                           created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, sqlc.narg(limit_price), $10, $11, $12, sqlc.narg(display_qty), $13,
+        sqlc.narg(curve), $14, $15, sqlc.arg(at), sqlc.arg(at))
+ON CONFLICT (parent_id) DO NOTHING;
+
+-- name: GetParentOrder :one
+SELECT * FROM parent_orders WHERE parent_id = $1;
+
+-- name: ListParentOrders :many
+SELECT * FROM parent_orders
+WHERE (sqlc.narg(venue)::text IS NULL OR venue = sqlc.narg(venue))
+  AND (NOT sqlc.arg(live_only)::boolean OR status IN ('running', 'paused'))
+ORDER BY created_at DESC, parent_id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListLiveParentOrders :many
+SELECT * FROM parent_orders
+WHERE status IN ('running', 'paused')
+ORDER BY created_at, parent_id;
+
+-- name: UpdateParentOrder :execrows
+UPDATE parent_orders
+SET status = $2, slices_sent = $3, next_child_id = $4, next_at = $5, reason = $6,
+    benchmark_price = sqlc.narg(benchmark_price), updated_at = sqlc.arg(at)
+WHERE parent_id = $1;
+
+-- name: ListParentChildren :many
+SELECT * FROM orders WHERE parent_id = $1 ORDER BY created_at, client_order_id;
+
+-- name: ListParentRollups :many
+-- Each parent as one order-shaped row: its children's fills added up, and
+-- the order status they amount to. Filters and the keyset cursor are
+-- ListOrders', so the two merge into one page. The parents are filtered
+-- and walked in keyset order first, and each one's children are summed
+-- only as it is reached, so a page stops after row_limit parents instead
+-- of rolling up every parent ever placed.
+SELECT p.parent_id, p.venue, p.base, p.quote, p.bot_id, p.side, p.qty, p.limit_price, c.filled_qty, c.avg_fill_price,
+       s.status, p.reason, p.created_at, p.updated_at
+FROM parent_orders p
+CROSS JOIN LATERAL (
+    SELECT COALESCE(SUM(o.filled_qty), 0)::numeric AS filled_qty,
+           COALESCE(SUM(o.filled_qty * o.avg_fill_price) / NULLIF(SUM(o.filled_qty), 0), 0)::numeric AS avg_fill_price
+    FROM orders o
+    WHERE o.parent_id = p.parent_id
+) c
+CROSS JOIN LATERAL (
+    SELECT (CASE
+                WHEN c.filled_qty >= p.qty THEN 'filled'
+                WHEN p.status = 'canceled' THEN 'canceled'
+                WHEN p.status = 'completed' THEN 'expired'
+                WHEN c.filled_qty > 0 THEN 'partially_filled'
+                ELSE 'open'
+            END)::text AS status
+) s
+WHERE (sqlc.narg(venue)::text IS NULL OR p.venue = sqlc.narg(venue))
+  AND (sqlc.narg(statuses)::text[] IS NULL OR s.status = ANY(sqlc.narg(statuses)::text[]))
+  AND (sqlc.narg(bot_id)::text IS NULL OR p.bot_id = sqlc.narg(bot_id))
+  AND (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL OR
+    (p.created_at, p.parent_id) < (
+      sqlc.narg(cursor_created_at)::timestamptz,
+      sqlc.narg(cursor_id)::text
+    )
+  )
+ORDER BY p.created_at DESC, p.parent_id DESC
+LIMIT sqlc.arg(row_limit)::bigint;
@@ -0,0 +1,66 @@
+-- name: DeleteBalances :exec
+DELETE FROM balances WHERE venue = $1 AND account_type = $2;
+
+-- name: InsertBalance :exec
+INSERT INTO balances (venue, account_type, currency, total, free, locked, taken_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (venue, account_type, currency) DO UPDATE
+SET total = EXCLUDED.total, free = EXCLUDED.free, locked = EXCLUDED.locked, taken_at = EXCLUDED.taken_at;
+
+-- name: LatestBalance :one
+-- The free balance of one currency in the account's latest successful
+-- snapshot; a currency the snapshot did not list is zero.
+SELECT snapshot_checkpoints.taken_at, coalesce(balances.free, 0)::numeric AS free
+FROM snapshot_checkpoints
+LEFT JOIN balances ON balances.venue = snapshot_checkpoints.venue
+    AND balances.account_type = snapshot_checkpoints.account_type
+    AND balances.currency = sqlc.arg(currency)
+WHERE snapshot_checkpoints.venue = sqlc.arg(venue)
+  AND snapshot_checkpoints.account_type = sqlc.arg(account_type)
+  AND snapshot_checkpoints.status = 'ok'
+ORDER BY snapshot_checkpoints.taken_at DESC, snapshot_checkpoints.created_at DESC
+LIMIT 1;
+
+-- name: SumReservedSince :one
+-- What the reservations made after a snapshot hold or spent: the snapshot
+-- cannot have seen them. The order being replaced is left out.
+SELECT coalesce(sum(amount - freed), 0)::numeric AS held
+FROM reservations
+WHERE venue = sqlc.arg(venue) AND account_type = sqlc.arg(account_type) AND currency = sqlc.arg(currency)
+  AND created_at > sqlc.arg(since)::timestamptz
+  AND client_order_id <> sqlc.arg(excluding)::text;
+
+-- name: SumUnreservedLots :one
+-- The base in open lots that no working sell has reserved, on one venue;
+-- narrowed to one bot's lots of one pair when bot_id is set.
+SELECT (
+    coalesce((
+        SELECT sum(remaining_qty) FROM lots
+        WHERE lots.venue = sqlc.arg(venue) AND lots.base = sqlc.arg(base) AND lots.status = 'open'
+          AND (sqlc.narg(bot_id)::text IS NULL OR (lots.bot_id = sqlc.narg(bot_id) AND lots.quote = sqlc.arg(quote)))
+    ), 0) - coalesce((
+        SELECT sum(lot_remaining) FROM reservations
+        WHERE reservations.venue = sqlc.arg(venue) AND reservations.currency = sqlc.arg(base)
+          AND reservations.side = 'sell' AND reservations.released_at IS NULL
+          AND reservations.client_order_id <> sqlc.arg(excluding)::text
+          AND (sqlc.narg(bot_id)::text IS NULL OR (reservations.bot_id = sqlc.narg(bot_id) AND reservations.quote = sqlc.arg(quote)))
+    ), 0)
+)::numeric AS qty;
+
+-- name: InsertReservation :exec
+INSERT INTO reservations (
+    client_order_id, bot_id, venue, account_type, base, quote, side, currency,
+    amount, lot_qty, remaining, lot_remaining, created_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);
+
+-- name: GetReservationForUpdate :one
+SELECT * FROM reservations WHERE client_order_id = $1 FOR UPDATE;
+
+-- name: UpdateReservation :exec
+UPDATE reservations
+SET amount = $2, lot_qty = $3, remaining = $4, lot_remaining = $5, freed = $6, released_at = $7
+WHERE client_order_id = $1;
+
+-- name: GetReservation :one
+SELECT * FROM reservations WHERE client_order_id = $1;
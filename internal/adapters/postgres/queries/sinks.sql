@@ -0,0 +1,37 @@
+-- name: OpenSinkCursor :one
+-- A new sink starts at the newest published row: it delivers what is
+-- published from now on, not the retained history.
+INSERT INTO sink_cursors (sink, after)
+SELECT $1, COALESCE(MAX(publish_seq), 0) FROM outbox
+ON CONFLICT (sink) DO UPDATE SET after = sink_cursors.after
+RETURNING after;
+
+-- name: AdvanceSinkCursor :exec
+UPDATE sink_cursors SET after = $2, updated_at = now() WHERE sink = $1 AND after < $2;
+
+-- name: DeleteSinkCursorsExcept :execrows
+DELETE FROM sink_cursors WHERE NOT (sink = ANY(sqlc.arg(keep)::text[]));
+
+-- name: ListOutboxPublishedAfter :many
+SELECT id, subject, payload, created_at, publish_seq::bigint AS publish_seq FROM outbox
+WHERE publish_seq > sqlc.arg(after)::bigint
+ORDER BY publish_seq
+LIMIT sqlc.arg(row_limit)::bigint;
+
+-- name: InsertSinkDeadLetter :exec
+INSERT INTO sink_dead_letters (sink, outbox_id, subject, at, body, attempts, last_error, failed_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (sink, outbox_id) DO NOTHING;
+
+-- name: ListSinkDeadLetters :many
+SELECT * FROM sink_dead_letters
+WHERE (sqlc.narg(sink)::text IS NULL OR sink = sqlc.narg(sink))
+  AND (cardinality(sqlc.arg(ids)::bigint[]) = 0 OR id = ANY(sqlc.arg(ids)::bigint[]))
+ORDER BY id
+LIMIT sqlc.arg(row_limit)::bigint;
+
+-- name: DeleteSinkDeadLetter :exec
+DELETE FROM sink_dead_letters WHERE id = $1;
+
+-- name: FailSinkDeadLetter :exec
+UPDATE sink_dead_letters SET attempts = attempts + 1, last_error = $2, failed_at = $3 WHERE id = $1;
@@ -0,0 +1,206 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/adapters/postgres/sqlcgen"
+	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/funds"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+var _ ports.ReservationStore = (*OrderStore)(nil)
+
+// CreateReserved inserts the pending row and its reservation in one
+// transaction, refusing the order when the account's latest balances
+// cannot cover it. Reservations of one currency on one account are drawn
+// under an advisory lock, so two orders never both count on the same
+// balance. Re-inserting the same ClientOrderID is a no-op that checks
+// nothing, like CreatePending.
+func (s *OrderStore) CreateReserved(ctx context.Context, req order.Request, r funds.Reservation) (bool, error) {
+	return s.createPending(ctx, req, func(q *sqlcgen.Queries) error {
+		venue, acct := string(r.Account.Venue), string(r.Account.Type)
+		if err := q.LockInventory(ctx, inventoryLockKey("funds", venue, acct, string(r.Currency))); err != nil {
+			return fmt.Errorf("postgres: lock funds: %w", err)
+		}
+		balance, err := q.LatestBalance(ctx, sqlcgen.LatestBalanceParams{Currency: string(r.Currency), Venue: venue, AccountType: acct})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: %s %s account", funds.ErrNoBalance, venue, acct)
+		}
+		if err != nil {
+			return fmt.Errorf("postgres: latest balance: %w", err)
+		}
+		pool := funds.Pool{Free: balance.Free}
+		pool.Held, err = q.SumReservedSince(ctx, sqlcgen.SumReservedSinceParams{
+			Venue: venue, AccountType: acct, Currency: string(r.Currency), Since: balance.TakenAt, Excluding: string(req.Replaces),
+		})
+		if err != nil {
+			return fmt.Errorf("postgres: sum reservations: %w", err)
+		}
+		if r.Side == order.Sell {
+			lots := sqlcgen.SumUnreservedLotsParams{Venue: venue, Base: string(r.Base), Quote: string(r.Quote), Excluding: string(req.Replaces)}
+			if pool.Lots, err = q.SumUnreservedLots(ctx, lots); err != nil {
+				return fmt.Errorf("postgres: sum open lots: %w", err)
+			}
+			lots.BotID = &r.BotID
+			if pool.OwnLots, err = q.SumUnreservedLots(ctx, lots); err != nil {
+				return fmt.Errorf("postgres: sum open lots: %w", err)
+			}
+		}
+		if r, err = r.Draw(pool); err != nil {
+			return err
+		}
+		if err := q.InsertReservation(ctx, sqlcgen.InsertReservationParams{
+			ClientOrderID: string(r.ClientOrderID), BotID: r.BotID, Venue: venue, AccountType: acct,
+			Base: string(r.Base), Quote: string(r.Quote), Side: string(r.Side), Currency: string(r.Currency),
+			Amount: r.Amount, LotQty: r.LotQty, Remaining: r.Remaining, LotRemaining: r.LotRemaining,
+			CreatedAt: r.CreatedAt.UTC(),
+		}); err != nil {
+			return fmt.Errorf("postgres: insert reservation: %w", err)
+		}
+		return nil
+	})
+}
+
+// CheckAmend checks an amend against the latest balances before the venue
+// is asked for it, under the advisory lock CreateReserved draws under. It
+// only reads: the reservation is rescaled when RecordAmend stores the
+// terms the venue accepted.
+func (s *OrderStore) CheckAmend(ctx context.Context, amend order.Replacement) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("postgres: begin check amend: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	q := s.q.WithTx(tx)
+	row, err := q.GetOrder(ctx, string(amend.Old))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ports.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("postgres: get order: %w", err)
+	}
+	stored, err := q.GetReservation(ctx, row.ClientOrderID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("postgres: get reservation: %w", err)
+	}
+	r := reservationRecord(stored)
+	amended := r.Amend(row.Price, row.Qty, amend.Price, amend.Qty, row.FilledQty, amend.RequestedAt.UTC())
+	if !amended.Remaining.GreaterThan(r.Remaining) {
+		return nil
+	}
+	venue, acct := string(r.Account.Venue), string(r.Account.Type)
+	if err := q.LockInventory(ctx, inventoryLockKey("funds", venue, acct, string(r.Currency))); err != nil {
+		return fmt.Errorf("postgres: lock funds: %w", err)
+	}
+	balance, err := q.LatestBalance(ctx, sqlcgen.LatestBalanceParams{Currency: string(r.Currency), Venue: venue, AccountType: acct})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: %s %s account", funds.ErrNoBalance, venue, acct)
+	}
+	if err != nil {
+		return fmt.Errorf("postgres: latest balance: %w", err)
+	}
+	pool := funds.Pool{Free: balance.Free}
+	pool.Held, err = q.SumReservedSince(ctx, sqlcgen.SumReservedSinceParams{
+		Venue: venue, AccountType: acct, Currency: string(r.Currency), Since: balance.TakenAt,
+	})
+	if err != nil {
+		return fmt.Errorf("postgres: sum reservations: %w", err)
+	}
+	return r.CheckAmend(amended, pool)
+}
+
+// GetReservation returns the order's reservation, or ErrNotFound for an
+// order placed without one.
+func (s *OrderStore) GetReservation(ctx context.Context, id order.ClientOrderID) (funds.Reservation, error) {
+	row, err := s.q.GetReservation(ctx, string(id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return funds.Reservation{}, ports.ErrNotFound
+	}
+	if err != nil {
+		return funds.Reservation{}, fmt.Errorf("postgres: get reservation: %w", err)
+	}
+	return reservationRecord(row), nil
+}
+
+// settleReservation follows the order's reservation to its new fill and
+// status inside ApplyEvent's transaction. Orders placed without one are
+// left alone.
+func settleReservation(ctx context.Context, q *sqlcgen.Queries, row sqlcgen.Order, filled decimal.Decimal, ended bool, at time.Time) error {
+	r, found, err := lockReservation(ctx, q, row.ClientOrderID)
+	if err != nil || !found {
+		return err
+	}
+	return updateReservation(ctx, q, r.Settle(row.Qty, filled, ended, at.UTC()))
+}
+
+// amendReservation rescales the reservation of an order the venue amended
+// from row's terms to the replacement's.
+func amendReservation(ctx context.Context, q *sqlcgen.Queries, row sqlcgen.Order, amend order.Replacement) error {
+	r, found, err := lockReservation(ctx, q, row.ClientOrderID)
+	if err != nil || !found {
+		return err
+	}
+	return updateReservation(ctx, q, r.Amend(row.Price, row.Qty, amend.Price, amend.Qty, row.FilledQty, amend.RequestedAt.UTC()))
+}
+
+func lockReservation(ctx context.Context, q *sqlcgen.Queries, id string) (funds.Reservation, bool, error) {
+	row, err := q.GetReservationForUpdate(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return funds.Reservation{}, false, nil
+	}
+	if err != nil {
+		return funds.Reservation{}, false, fmt.Errorf("postgres: lock reservation: %w", err)
+	}
+	return reservationRecord(row), true, nil
+}
+
+func updateReservation(ctx context.Context, q *sqlcgen.Queries, r funds.Reservation) error {
+	if err := q.UpdateReservation(ctx, sqlcgen.UpdateReservationParams{
+		ClientOrderID: string(r.ClientOrderID),
+		Amount:        r.Amount,
+		LotQty:        r.LotQty,
+		Remaining:     r.Remaining,
+		LotRemaining:  r.LotRemaining,
+		Freed:         r.Freed,
+		ReleasedAt:    nullTimestamp(r.ReleasedAt),
+	}); err != nil {
+		return fmt.Errorf("postgres: update reservation: %w", err)
+	}
+	return nil
+}
+
+func reservationRecord(row sqlcgen.Reservation) funds.Reservation {
+	var releasedAt time.Time
+	if row.ReleasedAt.Valid {
+		releasedAt = row.ReleasedAt.Time
+	}
+	return funds.Reservation{
+		ClientOrderID: order.ClientOrderID(row.ClientOrderID),
+		BotID:         row.BotID,
+		Account:       account.Ref{Venue: instrument.VenueID(row.Venue), Type: account.Type(row.AccountType)},
+		Base:          money.Currency(row.Base),
+		Quote:         money.Currency(row.Quote),
+		Side:          order.Side(row.Side),
+		Currency:      money.Currency(row.Currency),
+		Amount:        row.Amount,
+		LotQty:        row.LotQty,
+		Remaining:     row.Remaining,
+		LotRemaining:  row.LotRemaining,
+		Freed:         row.Freed,
+		CreatedAt:     row.CreatedAt,
+		ReleasedAt:    releasedAt,
+	}
+}
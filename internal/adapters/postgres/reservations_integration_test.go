@@ -0,0 +1,107 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/funds"
+	"github.com/romanornr/delta-works/internal/domain/ledger"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/id"
+	"github.com/romanornr/delta-works/internal/ports"
+	"github.com/romanornr/delta-works/internal/snapshot"
+)
+
+func TestOrderStoreReservations(t *testing.T) {
+	ctx := context.Background()
+	pool, err := Connect(ctx, startPostgres(t))
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer pool.Close()
+	store := NewOrderStore(pool, ledger.Selectors{})
+	checkpoints := NewCheckpointStore(pool)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	reserve := func(side order.Side, price, qty string) (order.Request, error) {
+		req := order.Request{
+			ClientOrderID: order.ClientOrderID(id.New()), BotID: "grid-1", Instrument: testInstrument(),
+			Side: side, Type: order.Limit, Price: decimal.RequireFromString(price), Qty: decimal.RequireFromString(qty),
+		}
+		r := funds.New(req, account.TypeSpot, req.Price, decimal.Zero, now)
+		_, err := store.CreateReserved(ctx, req, r)
+		return req, err
+	}
+
+	if _, err := reserve(order.Buy, "500", "1"); !errors.Is(err, funds.ErrNoBalance) {
+		t.Fatalf("reserve before any snapshot: err = %v, want ErrNoBalance", err)
+	}
+	checkpoint := snapshot.Checkpoint{
+		ID: uuid.New(), Account: account.Ref{Venue: "bybit", Type: account.TypeSpot},
+		TakenAt: now.Add(-time.Minute), Status: snapshot.StatusOK,
+		Balances: []account.Balance{
+			{Currency: "USDT", Total: decimal.RequireFromString("1000"), Free: decimal.RequireFromString("1000")},
+			{Currency: "BTC", Total: decimal.RequireFromString("1"), Free: decimal.RequireFromString("1")},
+		},
+	}
+	if err := checkpoints.RecordSnapshot(ctx, checkpoint); err != nil {
+		t.Fatalf("RecordSnapshot: %v", err)
+	}
+
+	buy, err := reserve(order.Buy, "500", "1")
+	if err != nil {
+		t.Fatalf("reserve a buy within the balance: %v", err)
+	}
+	refused, err := reserve(order.Buy, "600", "1")
+	if !errors.Is(err, funds.ErrInsufficient) {
+		t.Fatalf("reserve past the balance: err = %v, want ErrInsufficient", err)
+	}
+	if _, err := store.GetOrder(ctx, refused.ClientOrderID); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("refused order was stored: err = %v", err)
+	}
+
+	if _, err := store.ApplyEvent(ctx, order.SourceAck, fillEvent(buy, order.StatusPartiallyFilled, "0.4", "500")); err != nil {
+		t.Fatalf("partial fill: %v", err)
+	}
+	r, err := store.GetReservation(ctx, buy.ClientOrderID)
+	if err != nil || !r.Remaining.Equal(decimal.RequireFromString("300")) || r.Released() {
+		t.Fatalf("after a partial fill = %+v, %v", r, err)
+	}
+	if _, err := store.ApplyEvent(ctx, order.SourceStream, fillEvent(buy, order.StatusCanceled, "0.4", "500")); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	r, err = store.GetReservation(ctx, buy.ClientOrderID)
+	if err != nil || !r.Freed.Equal(decimal.RequireFromString("300")) || !r.Remaining.IsZero() || !r.Released() {
+		t.Fatalf("after the cancel = %+v, %v", r, err)
+	}
+	// The fill spent 200 of the 1000; the cancel handed back the rest.
+	if _, err := reserve(order.Buy, "800", "1"); err != nil {
+		t.Fatalf("reserve what the cancel freed: %v", err)
+	}
+	if _, err := reserve(order.Buy, "1", "1"); !errors.Is(err, funds.ErrInsufficient) {
+		t.Fatalf("reserve past what is left: err = %v, want ErrInsufficient", err)
+	}
+
+	// The 0.4 filled opened a lot for grid-1, which its sell draws first.
+	sell, err := reserve(order.Sell, "50000", "0.6")
+	if err != nil {
+		t.Fatalf("reserve a sell of the bot's lot and unclaimed base: %v", err)
+	}
+	if r, err := store.GetReservation(ctx, sell.ClientOrderID); err != nil || r.Currency != "BTC" || !r.LotQty.Equal(decimal.RequireFromString("0.4")) {
+		t.Fatalf("sell reservation = %+v, %v", r, err)
+	}
+	if _, err := reserve(order.Sell, "50000", "0.6"); !errors.Is(err, funds.ErrInsufficient) {
+		t.Fatalf("reserve a second sell: err = %v, want ErrInsufficient", err)
+	}
+	if _, err := store.GetReservation(ctx, newPendingOrder(ctx, t, store).ClientOrderID); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("GetReservation of an unreserved order: err = %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/romanornr/delta-works/internal/adapters/postgres/sqlcgen"
+	"github.com/romanornr/delta-works/internal/domain/sink"
+	"github.com/romanornr/delta-works/internal/events"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// SinkStore keeps sink delivery cursors and dead letters, and reads the
+// outbox in publish order for them.
+type SinkStore struct {
+	pool *pgxpool.Pool
+	q    *sqlcgen.Queries
+}
+
+var _ ports.SinkStore = (*SinkStore)(nil)
+
+// NewSinkStore returns a SinkStore backed by pool.
+func NewSinkStore(pool *pgxpool.Pool) *SinkStore {
+	return &SinkStore{pool: pool, q: sqlcgen.New(pool)}
+}
+
+// OpenCursor returns the sink's cursor, creating it at the newest
+// published row the first time.
+func (s *SinkStore) OpenCursor(ctx context.Context, name string) (int64, error) {
+	after, err := s.q.OpenSinkCursor(ctx, name)
+	if err != nil {
+		return 0, fmt.Errorf("postgres: open sink %s cursor: %w", name, err)
+	}
+	return after, nil
+}
+
+// AdvanceCursor moves the sink's cursor forward, never back.
+func (s *SinkStore) AdvanceCursor(ctx context.Context, name string, after int64) error {
+	if err := s.q.AdvanceSinkCursor(ctx, sqlcgen.AdvanceSinkCursorParams{Sink: name, After: after}); err != nil {
+		return fmt.Errorf("postgres: advance sink %s cursor: %w", name, err)
+	}
+	return nil
+}
+
+// PruneCursors deletes the cursors of sinks not in keep.
+func (s *SinkStore) PruneCursors(ctx context.Context, keep []string) (int64, error) {
+	if keep == nil {
+		keep = []string{}
+	}
+	n, err := s.q.DeleteSinkCursorsExcept(ctx, keep)
+	if err != nil {
+		return 0, fmt.Errorf("postgres: prune sink cursors: %w", err)
+	}
+	return n, nil
+}
+
+// ListPublishedAfter returns published outbox rows after the given
+// publish_seq, in publish order.
+func (s *SinkStore) ListPublishedAfter(ctx context.Context, after int64, limit int) ([]events.OutboxMessage, error) {
+	rows, err := s.q.ListOutboxPublishedAfter(ctx, sqlcgen.ListOutboxPublishedAfterParams{After: after, RowLimit: int64(limit)})
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list outbox rows published after %d: %w", after, err)
+	}
+	out := make([]events.OutboxMessage, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, events.OutboxMessage{
+			ID: row.ID, Subject: row.Subject, Payload: row.Payload, CreatedAt: row.CreatedAt, PublishSeq: row.PublishSeq,
+		})
+	}
+	return out, nil
+}
+
+// DeadLetter stores the letter and moves its sink's cursor past it in one
+// transaction, so a crash can neither lose the letter nor deliver it again.
+func (s *SinkStore) DeadLetter(ctx context.Context, letter sink.DeadLetter, seq int64) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("postgres: begin dead letter: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	q := s.q.WithTx(tx)
+	d := letter.Delivery
+	if err := q.InsertSinkDeadLetter(ctx, sqlcgen.InsertSinkDeadLetterParams{
+		Sink: letter.Sink, OutboxID: d.OutboxID, Subject: d.Subject, At: d.At, Body: d.Body,
+		Attempts:  int32(letter.Attempts), //nolint:gosec // bounded by sinks.max_attempts
+		LastError: letter.LastError, FailedAt: letter.FailedAt,
+	}); err != nil {
+		return fmt.Errorf("postgres: insert sink %s dead letter for outbox row %d: %w", letter.Sink, d.OutboxID, err)
+	}
+	if err := q.AdvanceSinkCursor(ctx, sqlcgen.AdvanceSinkCursorParams{Sink: letter.Sink, After: seq}); err != nil {
+		return fmt.Errorf("postgres: advance sink %s cursor: %w", letter.Sink, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("postgres: commit dead letter: %w", err)
+	}
+	return nil
+}
+
+// ListDeadLetters returns the dead letters matching query, oldest first.
+func (s *SinkStore) ListDeadLetters(ctx context.Context, query sink.DeadLetterQuery) ([]sink.DeadLetter, error) {
+	params := sqlcgen.ListSinkDeadLettersParams{Ids: query.IDs, RowLimit: int64(query.Limit)}
+	if params.Ids == nil {
+		params.Ids = []int64{}
+	}
+	if query.Sink != "" {
+		params.Sink = &query.Sink
+	}
+	rows, err := s.q.ListSinkDeadLetters(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list sink dead letters: %w", err)
+	}
+	out := make([]sink.DeadLetter, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, sink.DeadLetter{
+			ID: row.ID, Sink: row.Sink,
+			Delivery:  sink.Delivery{OutboxID: row.OutboxID, Subject: row.Subject, At: row.At, Body: row.Body},
+			Attempts:  int(row.Attempts),
+			LastError: row.LastError,
+			FailedAt:  row.FailedAt,
+		})
+	}
+	return out, nil
+}
+
+// DeleteDeadLetter removes a delivered dead letter.
+func (s *SinkStore) DeleteDeadLetter(ctx context.Context, id int64) error {
+	if err := s.q.DeleteSinkDeadLetter(ctx, id); err != nil {
+		return fmt.Errorf("postgres: delete sink dead letter %d: %w", id, err)
+	}
+	return nil
+}
+
+// FailDeadLetter counts another failed attempt at a dead letter.
+func (s *SinkStore) FailDeadLetter(ctx context.Context, id int64, lastErr string, at time.Time) error {
+	if err := s.q.FailSinkDeadLetter(ctx, sqlcgen.FailSinkDeadLetterParams{ID: id, LastError: lastErr, FailedAt: at}); err != nil {
+		return fmt.Errorf("postgres: record sink dead letter %d failure: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/romanornr/delta-works/internal/domain/ledger"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/domain/sink"
+	"github.com/romanornr/delta-works/internal/events"
+)
+
+func TestSinkStore(t *testing.T) {
+	ctx := context.Background()
+	pool, err := Connect(ctx, startPostgres(t))
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer pool.Close()
+	orders := NewOrderStore(pool, ledger.Selectors{})
+	outbox := NewOutboxStore(pool)
+	sinks := NewSinkStore(pool)
+
+	// A sink opened before anything is published starts from the beginning.
+	if after, err := sinks.OpenCursor(ctx, "hooks"); err != nil || after != 0 {
+		t.Fatalf("OpenCursor(hooks) = %d, err=%v; want 0", after, err)
+	}
+	req := newPendingOrder(ctx, t, orders)
+	if _, err := orders.ApplyEvent(ctx, order.SourceAck, fillEvent(req, order.StatusOpen, "0", "0")); err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if _, err := orders.ApplyEvent(ctx, order.SourceStream, fillEvent(req, order.StatusFilled, "1", "50000")); err != nil {
+		t.Fatalf("filled: %v", err)
+	}
+	if rows, err := sinks.ListPublishedAfter(ctx, 0, 100); err != nil || len(rows) != 0 {
+		t.Fatalf("ListPublishedAfter before the relay = %+v, err=%v; want none", rows, err)
+	}
+	if _, err := outbox.PublishPending(ctx, 100, func(events.OutboxMessage) error { return nil }); err != nil {
+		t.Fatalf("PublishPending: %v", err)
+	}
+	rows, err := sinks.ListPublishedAfter(ctx, 0, 100)
+	if err != nil || len(rows) != 3 || rows[0].ID >= rows[1].ID || rows[1].ID >= rows[2].ID {
+		t.Fatalf("ListPublishedAfter = %+v, err=%v; want 3 rows, stamped in the ID order they were published", rows, err)
+	}
+	last := rows[2].PublishSeq
+
+	// A sink opened later starts at the newest published row.
+	if after, err := sinks.OpenCursor(ctx, "audit"); err != nil || after != last {
+		t.Fatalf("OpenCursor(audit) = %d, err=%v; want %d", after, err, last)
+	}
+	if err := sinks.AdvanceCursor(ctx, "audit", rows[0].PublishSeq); err != nil {
+		t.Fatalf("AdvanceCursor back: %v", err)
+	}
+	if after, err := sinks.OpenCursor(ctx, "audit"); err != nil || after != last {
+		t.Fatalf("OpenCursor(audit) after moving back = %d, err=%v; want it unmoved at %d", after, err, last)
+	}
+
+	letter := sink.DeadLetter{
+		Sink:     "hooks",
+		Delivery: sink.Delivery{OutboxID: rows[1].ID, Subject: rows[1].Subject, At: rows[1].CreatedAt, Body: []byte(`{"cursor":"1"}`)},
+		Attempts: 5, LastError: "503 Service Unavailable", FailedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	for range 2 { // a dead letter stored again after a crash is kept once
+		if err := sinks.DeadLetter(ctx, letter, rows[1].PublishSeq); err != nil {
+			t.Fatalf("DeadLetter: %v", err)
+		}
+	}
+	if after, err := sinks.OpenCursor(ctx, "hooks"); err != nil || after != rows[1].PublishSeq {
+		t.Fatalf("OpenCursor(hooks) after the dead letter = %d, err=%v; want %d", after, err, rows[1].PublishSeq)
+	}
+	letters, err := sinks.ListDeadLetters(ctx, sink.DeadLetterQuery{Sink: "hooks", Limit: 10})
+	if err != nil || len(letters) != 1 || letters[0].Delivery.OutboxID != rows[1].ID || letters[0].Attempts != 5 || !letters[0].FailedAt.Equal(letter.FailedAt) {
+		t.Fatalf("ListDeadLetters = %+v, err=%v", letters, err)
+	}
+	if others, err := sinks.ListDeadLetters(ctx, sink.DeadLetterQuery{Sink: "audit", Limit: 10}); err != nil || len(others) != 0 {
+		t.Fatalf("ListDeadLetters(audit) = %+v, err=%v; want none", others, err)
+	}
+	id := letters[0].ID
+	if err := sinks.FailDeadLetter(ctx, id, "timeout", time.Now()); err != nil {
+		t.Fatalf("FailDeadLetter: %v", err)
+	}
+	if letters, err := sinks.ListDeadLetters(ctx, sink.DeadLetterQuery{IDs: []int64{id}, Limit: 10}); err != nil || len(letters) != 1 ||
+		letters[0].Attempts != 6 || letters[0].LastError != "timeout" {
+		t.Fatalf("after FailDeadLetter = %+v, err=%v", letters, err)
+	}
+
+	// Cleanup keeps what the slowest sink has not delivered.
+	deleted, err := outbox.DeletePublishedBefore(ctx, time.Now().UTC().Add(time.Minute))
+	if err != nil || deleted != 2 {
+		t.Fatalf("DeletePublishedBefore = %d, err=%v; want the 2 rows every sink is past", deleted, err)
+	}
+	if pruned, err := sinks.PruneCursors(ctx, []string{"audit"}); err != nil || pruned != 1 {
+		t.Fatalf("PruneCursors = %d, err=%v; want hooks dropped", pruned, err)
+	}
+	if deleted, err := outbox.DeletePublishedBefore(ctx, time.Now().UTC().Add(time.Minute)); err != nil || deleted != 1 {
+		t.Fatalf("DeletePublishedBefore after pruning = %d, err=%v; want 1", deleted, err)
+	}
+
+	if err := sinks.DeleteDeadLetter(ctx, id); err != nil {
+		t.Fatalf("DeleteDeadLetter: %v", err)
+	}
+	if letters, err := sinks.ListDeadLetters(ctx, sink.DeadLetterQuery{Limit: 10}); err != nil || len(letters) != 0 {
+		t.Fatalf("ListDeadLetters after delete = %+v, err=%v", letters, err)
+	}
+}
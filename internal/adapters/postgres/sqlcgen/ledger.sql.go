@@ -9,34 +9,72 @@ import (
 	"context"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 )
 
 const decrementLot = `-- name: DecrementLot :exec
 UPDATE lots
 SET remaining_qty = remaining_qty - $1,
+    remaining_cost = remaining_cost - $2,
     status = CASE WHEN remaining_qty - $1 = 0 THEN 'closed' ELSE 'open' END,
-    closed_at = CASE WHEN remaining_qty - $1 = 0 THEN $2::timestamptz ELSE NULL END
-WHERE id = $3
+    closed_at = CASE WHEN remaining_qty - $1 = 0 THEN $3::timestamptz ELSE NULL END
+WHERE id = $4
 `
 
 type DecrementLotParams struct {
 	Qty      decimal.Decimal
+	Cost     decimal.Decimal
 	ClosedAt time.Time
 	ID       string
 }
 
 func (q *Queries) DecrementLot(ctx context.Context, arg DecrementLotParams) error {
-	_, err := q.db.Exec(ctx, decrementLot, arg.Qty, arg.ClosedAt, arg.ID)
+	_, err := q.db.Exec(ctx, decrementLot,
+		arg.Qty,
+		arg.Cost,
+		arg.ClosedAt,
+		arg.ID,
+	)
+	return err
+}
+
+const insertFeeCharge = `-- name: InsertFeeCharge :exec
+INSERT INTO fee_charges (fill_id, bot_id, venue, base, quote, currency, amount, occurred_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+
+type InsertFeeChargeParams struct {
+	FillID     int64
+	BotID      string
+	Venue      string
+	Base       string
+	Quote      string
+	Currency   string
+	Amount     decimal.Decimal
+	OccurredAt time.Time
+}
+
+func (q *Queries) InsertFeeCharge(ctx context.Context, arg InsertFeeChargeParams) error {
+	_, err := q.db.Exec(ctx, insertFeeCharge,
+		arg.FillID,
+		arg.BotID,
+		arg.Venue,
+		arg.Base,
+		arg.Quote,
+		arg.Currency,
+		arg.Amount,
+		arg.OccurredAt,
+	)
 	return err
 }
 
 const insertLot = `-- name: InsertLot :exec
 INSERT INTO lots (
-    id, bot_id, venue, base, quote, qty, remaining_qty, cost_price,
+    id, bot_id, venue, base, quote, qty, remaining_qty, cost_price, cost, remaining_cost,
     opened_by_fill_id, status, opened_at
 )
-VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $8, 'open', $9)
+VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $8, $8, $9, 'open', $10)
 `
 
 type InsertLotParams struct {
@@ -47,6 +85,7 @@ type InsertLotParams struct {
 	Quote          string
 	Qty            decimal.Decimal
 	CostPrice      decimal.Decimal
+	Cost           decimal.Decimal
 	OpenedByFillID int64
 	OpenedAt       time.Time
 }
@@ -60,6 +99,7 @@ func (q *Queries) InsertLot(ctx context.Context, arg InsertLotParams) error {
 		arg.Quote,
 		arg.Qty,
 		arg.CostPrice,
+		arg.Cost,
 		arg.OpenedByFillID,
 		arg.OpenedAt,
 	)
@@ -67,15 +107,18 @@ func (q *Queries) InsertLot(ctx context.Context, arg InsertLotParams) error {
 }
 
 const insertLotClosure = `-- name: InsertLotClosure :exec
-INSERT INTO lot_closures (lot_id, sell_fill_id, qty, price, closed_at)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO lot_closures (lot_id, sell_fill_id, kind, qty, price, cost, fee, closed_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 `
 
 type InsertLotClosureParams struct {
 	LotID      string
 	SellFillID int64
+	Kind       string
 	Qty        decimal.Decimal
 	Price      decimal.Decimal
+	Cost       decimal.Decimal
+	Fee        decimal.Decimal
 	ClosedAt   time.Time
 }
 
@@ -83,16 +126,19 @@ func (q *Queries) InsertLotClosure(ctx context.Context, arg InsertLotClosurePara
 	_, err := q.db.Exec(ctx, insertLotClosure,
 		arg.LotID,
 		arg.SellFillID,
+		arg.Kind,
 		arg.Qty,
 		arg.Price,
+		arg.Cost,
+		arg.Fee,
 		arg.ClosedAt,
 	)
 	return err
 }
 
 const insertUnmatchedSell = `-- name: InsertUnmatchedSell :exec
-INSERT INTO unmatched_sells (sell_fill_id, bot_id, venue, base, quote, qty, occurred_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO unmatched_sells (sell_fill_id, bot_id, venue, base, quote, qty, fee, occurred_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 `
 
 type InsertUnmatchedSellParams struct {
@@ -102,6 +148,7 @@ type InsertUnmatchedSellParams struct {
 	Base       string
 	Quote      string
 	Qty        decimal.Decimal
+	Fee        decimal.Decimal
 	OccurredAt time.Time
 }
 
@@ -113,36 +160,175 @@ func (q *Queries) InsertUnmatchedSell(ctx context.Context, arg InsertUnmatchedSe
 		arg.Base,
 		arg.Quote,
 		arg.Qty,
+		arg.Fee,
 		arg.OccurredAt,
 	)
 	return err
 }
 
-const listOpenLotsForUpdate = `-- name: ListOpenLotsForUpdate :many
-SELECT id, bot_id, venue, base, quote, qty, remaining_qty, cost_price, opened_by_fill_id, status, opened_at, closed_at FROM lots
-WHERE bot_id = $1 AND venue = $2 AND base = $3 AND quote = $4 AND status = 'open'
-ORDER BY opened_at, id
-FOR UPDATE
+const listClosedLots = `-- name: ListClosedLots :many
+SELECT
+    lot_closures.id, lot_closures.lot_id, lot_closures.sell_fill_id, lot_closures.kind,
+    lot_closures.qty, lot_closures.price AS sell_price, lot_closures.cost, lot_closures.fee,
+    lot_closures.closed_at,
+    lots.bot_id, lots.venue, lots.base, lots.quote, lots.cost_price, lots.opened_by_fill_id
+FROM lot_closures
+JOIN lots ON lots.id = lot_closures.lot_id
+WHERE lot_closures.closed_at >= $1::timestamptz
+  AND lot_closures.closed_at < $2::timestamptz
+  AND ($3::text IS NULL OR lots.bot_id = $3)
+  AND ($4::text IS NULL OR lots.venue = $4)
+  AND ($5::text IS NULL OR lots.base = $5)
+  AND ($6::text IS NULL OR lots.quote = $6)
+  AND (
+    $7::timestamptz IS NULL OR
+    (lot_closures.closed_at, lot_closures.id) > (
+      $7::timestamptz,
+      $8::bigint
+    )
+  )
+ORDER BY lot_closures.closed_at, lot_closures.id
+LIMIT $9::bigint
 `
 
-type ListOpenLotsForUpdateParams struct {
-	BotID string
-	Venue string
-	Base  string
-	Quote string
+type ListClosedLotsParams struct {
+	ClosedFrom     time.Time
+	ClosedTo       time.Time
+	BotID          *string
+	Venue          *string
+	Base           *string
+	Quote          *string
+	CursorClosedAt pgtype.Timestamptz
+	CursorID       *int64
+	RowLimit       int64
 }
 
-func (q *Queries) ListOpenLotsForUpdate(ctx context.Context, arg ListOpenLotsForUpdateParams) ([]Lot, error) {
-	rows, err := q.db.Query(ctx, listOpenLotsForUpdate,
+type ListClosedLotsRow struct {
+	ID             int64
+	LotID          string
+	SellFillID     int64
+	Kind           string
+	Qty            decimal.Decimal
+	SellPrice      decimal.Decimal
+	Cost           decimal.Decimal
+	Fee            decimal.Decimal
+	ClosedAt       time.Time
+	BotID          string
+	Venue          string
+	Base           string
+	Quote          string
+	CostPrice      decimal.Decimal
+	OpenedByFillID int64
+}
+
+func (q *Queries) ListClosedLots(ctx context.Context, arg ListClosedLotsParams) ([]ListClosedLotsRow, error) {
+	rows, err := q.db.Query(ctx, listClosedLots,
+		arg.ClosedFrom,
+		arg.ClosedTo,
 		arg.BotID,
 		arg.Venue,
 		arg.Base,
 		arg.Quote,
+		arg.CursorClosedAt,
+		arg.CursorID,
+		arg.RowLimit,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
+	var items []ListClosedLotsRow
+	for rows.Next() {
+		var i ListClosedLotsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.LotID,
+			&i.SellFillID,
+			&i.Kind,
+			&i.Qty,
+			&i.SellPrice,
+			&i.Cost,
+			&i.Fee,
+			&i.ClosedAt,
+			&i.BotID,
+			&i.Venue,
+			&i.Base,
+			&i.Quote,
+			&i.CostPrice,
+			&i.OpenedByFillID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFeeLotsForUpdate = `-- name: ListFeeLotsForUpdate :many
+SELECT id, bot_id, venue, base, quote, qty, remaining_qty, cost_price, opened_by_fill_id, status, opened_at, closed_at, cost, remaining_cost FROM lots
+WHERE bot_id = $1 AND venue = $2 AND base = $3 AND status = 'open'
+ORDER BY opened_at, id
+FOR UPDATE
+`
+
+type ListFeeLotsForUpdateParams struct {
+	BotID string
+	Venue string
+	Base  string
+}
+
+// Lots of every quote held in a fee currency, for a third-currency fee to
+// consume oldest first.
+func (q *Queries) ListFeeLotsForUpdate(ctx context.Context, arg ListFeeLotsForUpdateParams) ([]Lot, error) {
+	rows, err := q.db.Query(ctx, listFeeLotsForUpdate, arg.BotID, arg.Venue, arg.Base)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Lot
+	for rows.Next() {
+		var i Lot
+		if err := rows.Scan(
+			&i.ID,
+			&i.BotID,
+			&i.Venue,
+			&i.Base,
+			&i.Quote,
+			&i.Qty,
+			&i.RemainingQty,
+			&i.CostPrice,
+			&i.OpenedByFillID,
+			&i.Status,
+			&i.OpenedAt,
+			&i.ClosedAt,
+			&i.Cost,
+			&i.RemainingCost,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOpenLots = `-- name: ListOpenLots :many
+SELECT id, bot_id, venue, base, quote, qty, remaining_qty, cost_price, opened_by_fill_id, status, opened_at, closed_at, cost, remaining_cost FROM lots
+WHERE status = 'open'
+ORDER BY bot_id, venue, base, quote, opened_at, id
+`
+
+func (q *Queries) ListOpenLots(ctx context.Context) ([]Lot, error) {
+	rows, err := q.db.Query(ctx, listOpenLots)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 	var items []Lot
 	for rows.Next() {
 		var i Lot
@@ -159,6 +345,71 @@ func (q *Queries) ListOpenLotsForUpdate(ctx context.Context, arg ListOpenLotsFor
 			&i.Status,
 			&i.OpenedAt,
 			&i.ClosedAt,
+			&i.Cost,
+			&i.RemainingCost,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOpenLotsForUpdate = `-- name: ListOpenLotsForUpdate :many
+SELECT lots.id, lots.bot_id, lots.venue, lots.base, lots.quote, lots.qty, lots.remaining_qty, lots.cost_price, lots.opened_by_fill_id, lots.status, lots.opened_at, lots.closed_at, lots.cost, lots.remaining_cost, orders.price AS order_price
+FROM lots
+JOIN fills ON fills.id = lots.opened_by_fill_id
+JOIN orders ON orders.client_order_id = fills.client_order_id
+WHERE lots.bot_id = $1 AND lots.venue = $2 AND lots.base = $3 AND lots.quote = $4 AND lots.status = 'open'
+ORDER BY lots.opened_at, lots.id
+FOR UPDATE OF lots
+`
+
+type ListOpenLotsForUpdateParams struct {
+	BotID string
+	Venue string
+	Base  string
+	Quote string
+}
+
+type ListOpenLotsForUpdateRow struct {
+	Lot        Lot
+	OrderPrice decimal.Decimal
+}
+
+func (q *Queries) ListOpenLotsForUpdate(ctx context.Context, arg ListOpenLotsForUpdateParams) ([]ListOpenLotsForUpdateRow, error) {
+	rows, err := q.db.Query(ctx, listOpenLotsForUpdate,
+		arg.BotID,
+		arg.Venue,
+		arg.Base,
+		arg.Quote,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOpenLotsForUpdateRow
+	for rows.Next() {
+		var i ListOpenLotsForUpdateRow
+		if err := rows.Scan(
+			&i.Lot.ID,
+			&i.Lot.BotID,
+			&i.Lot.Venue,
+			&i.Lot.Base,
+			&i.Lot.Quote,
+			&i.Lot.Qty,
+			&i.Lot.RemainingQty,
+			&i.Lot.CostPrice,
+			&i.Lot.OpenedByFillID,
+			&i.Lot.Status,
+			&i.Lot.OpenedAt,
+			&i.Lot.ClosedAt,
+			&i.Lot.Cost,
+			&i.Lot.RemainingCost,
+			&i.OrderPrice,
 		); err != nil {
 			return nil, err
 		}
@@ -178,3 +429,181 @@ func (q *Queries) LockInventory(ctx context.Context, key int64) error {
 	_, err := q.db.Exec(ctx, lockInventory, key)
 	return err
 }
+
+const sumClosedLots = `-- name: SumClosedLots :many
+WITH closed AS (
+    SELECT
+        lots.bot_id, lots.venue, lots.base, lots.quote,
+        lot_closures.kind, lot_closures.qty, lot_closures.cost,
+        lot_closures.qty * lot_closures.price - lot_closures.fee AS proceeds,
+        lots.cost_price > 0 AND (lot_closures.kind = 'fee' OR lot_closures.price > 0) AS priced
+    FROM lot_closures
+    JOIN lots ON lots.id = lot_closures.lot_id
+    WHERE lot_closures.closed_at >= $1::timestamptz
+      AND lot_closures.closed_at < $2::timestamptz
+      AND ($3::text IS NULL OR lots.bot_id = $3)
+      AND ($4::text IS NULL OR lots.venue = $4)
+      AND ($5::text IS NULL OR lots.base = $5)
+      AND ($6::text IS NULL OR lots.quote = $6)
+)
+SELECT
+    bot_id, venue, base, quote,
+    COALESCE(sum(qty) FILTER (WHERE priced AND kind = 'sale'), 0)::numeric AS qty,
+    COALESCE(sum(qty) FILTER (WHERE priced AND kind = 'fee'), 0)::numeric AS fee_qty,
+    COALESCE(sum(cost) FILTER (WHERE priced), 0)::numeric AS cost,
+    COALESCE(sum(proceeds) FILTER (WHERE priced AND kind = 'sale'), 0)::numeric AS proceeds,
+    COALESCE(sum(qty) FILTER (WHERE NOT priced), 0)::numeric AS unpriced_qty
+FROM closed
+GROUP BY bot_id, venue, base, quote
+ORDER BY bot_id, venue, base, quote
+`
+
+type SumClosedLotsParams struct {
+	ClosedFrom time.Time
+	ClosedTo   time.Time
+	BotID      *string
+	Venue      *string
+	Base       *string
+	Quote      *string
+}
+
+type SumClosedLotsRow struct {
+	BotID       string
+	Venue       string
+	Base        string
+	Quote       string
+	Qty         decimal.Decimal
+	FeeQty      decimal.Decimal
+	Cost        decimal.Decimal
+	Proceeds    decimal.Decimal
+	UnpricedQty decimal.Decimal
+}
+
+// Mirrors ledger.SumClosures: a closure is priced when its lot has a cost
+// price and, for a sale, the sell has a price too.
+func (q *Queries) SumClosedLots(ctx context.Context, arg SumClosedLotsParams) ([]SumClosedLotsRow, error) {
+	rows, err := q.db.Query(ctx, sumClosedLots,
+		arg.ClosedFrom,
+		arg.ClosedTo,
+		arg.BotID,
+		arg.Venue,
+		arg.Base,
+		arg.Quote,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SumClosedLotsRow
+	for rows.Next() {
+		var i SumClosedLotsRow
+		if err := rows.Scan(
+			&i.BotID,
+			&i.Venue,
+			&i.Base,
+			&i.Quote,
+			&i.Qty,
+			&i.FeeQty,
+			&i.Cost,
+			&i.Proceeds,
+			&i.UnpricedQty,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const sumFeeCharges = `-- name: SumFeeCharges :many
+SELECT bot_id, venue, base, quote, currency, sum(amount)::numeric AS amount
+FROM fee_charges
+WHERE occurred_at >= $1::timestamptz
+  AND occurred_at < $2::timestamptz
+  AND ($3::text IS NULL OR bot_id = $3)
+  AND ($4::text IS NULL OR venue = $4)
+  AND ($5::text IS NULL OR base = $5)
+  AND ($6::text IS NULL OR quote = $6)
+GROUP BY bot_id, venue, base, quote, currency
+ORDER BY bot_id, venue, base, quote, currency
+`
+
+type SumFeeChargesParams struct {
+	OccurredFrom time.Time
+	OccurredTo   time.Time
+	BotID        *string
+	Venue        *string
+	Base         *string
+	Quote        *string
+}
+
+type SumFeeChargesRow struct {
+	BotID    string
+	Venue    string
+	Base     string
+	Quote    string
+	Currency string
+	Amount   decimal.Decimal
+}
+
+func (q *Queries) SumFeeCharges(ctx context.Context, arg SumFeeChargesParams) ([]SumFeeChargesRow, error) {
+	rows, err := q.db.Query(ctx, sumFeeCharges,
+		arg.OccurredFrom,
+		arg.OccurredTo,
+		arg.BotID,
+		arg.Venue,
+		arg.Base,
+		arg.Quote,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SumFeeChargesRow
+	for rows.Next() {
+		var i SumFeeChargesRow
+		if err := rows.Scan(
+			&i.BotID,
+			&i.Venue,
+			&i.Base,
+			&i.Quote,
+			&i.Currency,
+			&i.Amount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const sumOpenLotQty = `-- name: SumOpenLotQty :one
+SELECT COALESCE(sum(remaining_qty), 0)::numeric AS qty
+FROM lots
+WHERE bot_id = $1 AND venue = $2 AND base = $3 AND quote = $4 AND status = 'open'
+`
+
+type SumOpenLotQtyParams struct {
+	BotID string
+	Venue string
+	Base  string
+	Quote string
+}
+
+func (q *Queries) SumOpenLotQty(ctx context.Context, arg SumOpenLotQtyParams) (decimal.Decimal, error) {
+	row := q.db.QueryRow(ctx, sumOpenLotQty,
+		arg.BotID,
+		arg.Venue,
+		arg.Base,
+		arg.Quote,
+	)
+	var qty decimal.Decimal
+	err := row.Scan(&qty)
+	return qty, err
+}
@@ -12,6 +12,57 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+type ApiToken struct {
+	TokenID   string
+	Name      string
+	Scopes    []string
+	Hash      []byte
+	CreatedAt time.Time
+	RevokedAt pgtype.Timestamptz
+}
+
+type ArbTrade struct {
+	TradeID     string
+	BotID       string
+	Base        string
+	Quote       string
+	BuyVenue    string
+	SellVenue   string
+	BuyOrderID  string
+	SellOrderID string
+	Qty         decimal.Decimal
+	BuyPrice    decimal.Decimal
+	SellPrice   decimal.Decimal
+	EdgeBps     decimal.Decimal
+	Status      string
+	HedgeSide   *string
+	HedgeQty    pgtype.Numeric
+	Reason      string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type Balance struct {
+	Venue       string
+	AccountType string
+	Currency    string
+	Total       decimal.Decimal
+	Free        decimal.Decimal
+	Locked      decimal.Decimal
+	TakenAt     time.Time
+}
+
+type FeeCharge struct {
+	FillID     int64
+	BotID      string
+	Venue      string
+	Base       string
+	Quote      string
+	Currency   string
+	Amount     decimal.Decimal
+	OccurredAt time.Time
+}
+
 type Fill struct {
 	ID            int64
 	ClientOrderID string
@@ -24,6 +75,43 @@ type Fill struct {
 	OccurredAt    time.Time
 }
 
+type Instrument struct {
+	Venue          string
+	Type           string
+	Base           string
+	Quote          string
+	VenueSymbol    string
+	Status         string
+	PriceIncrement decimal.Decimal
+	QtyIncrement   decimal.Decimal
+	MinQty         decimal.Decimal
+	MinNotional    decimal.Decimal
+	ListedAt       time.Time
+	UpdatedAt      time.Time
+}
+
+type InstrumentHistory struct {
+	ID             int64
+	Venue          string
+	Type           string
+	Base           string
+	Quote          string
+	Change         string
+	VenueSymbol    string
+	Status         string
+	PriceIncrement decimal.Decimal
+	QtyIncrement   decimal.Decimal
+	MinQty         decimal.Decimal
+	MinNotional    decimal.Decimal
+	RecordedAt     time.Time
+}
+
+type KillSwitch struct {
+	Venue     string
+	Reason    string
+	EngagedAt time.Time
+}
+
 type Lot struct {
 	ID             string
 	BotID          string
@@ -37,6 +125,8 @@ type Lot struct {
 	Status         string
 	OpenedAt       time.Time
 	ClosedAt       pgtype.Timestamptz
+	Cost           decimal.Decimal
+	RemainingCost  decimal.Decimal
 }
 
 type LotClosure struct {
@@ -46,27 +136,70 @@ type LotClosure struct {
 	Qty        decimal.Decimal
 	Price      decimal.Decimal
 	ClosedAt   time.Time
+	Kind       string
+	Cost       decimal.Decimal
+	Fee        decimal.Decimal
 }
 
 type Order struct {
-	ClientOrderID     string
-	Venue             string
-	Base              string
-	Quote             string
-	VenueSymbol       string
-	Side              string
-	Type              string
-	Price             decimal.Decimal
-	Qty               decimal.Decimal
-	FilledQty         decimal.Decimal
-	AvgFillPrice      pgtype.Numeric
-	Status            string
-	VenueOrderID      *string
-	BotID             string
-	CancelRequestedAt pgtype.Timestamptz
-	Reason            *string
-	CreatedAt         time.Time
-	UpdatedAt         time.Time
+	ClientOrderID      string
+	Venue              string
+	Base               string
+	Quote              string
+	VenueSymbol        string
+	Side               string
+	Type               string
+	Price              decimal.Decimal
+	Qty                decimal.Decimal
+	FilledQty          decimal.Decimal
+	AvgFillPrice       pgtype.Numeric
+	Status             string
+	VenueOrderID       *string
+	BotID              string
+	CancelRequestedAt  pgtype.Timestamptz
+	Reason             *string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	TimeInForce        string
+	ExpiresAt          pgtype.Timestamptz
+	PostOnly           bool
+	TriggerPrice       pgtype.Numeric
+	ChildClientOrderID *string
+	ParentID           *string
+}
+
+type OrderGroup struct {
+	GroupID   string
+	Kind      string
+	Status    string
+	Venue     string
+	Base      string
+	Quote     string
+	BotID     string
+	Reason    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type OrderGroupLeg struct {
+	GroupID       string
+	Role          string
+	ClientOrderID string
+	Side          string
+	Type          string
+	Price         decimal.Decimal
+	Qty           decimal.Decimal
+	TriggerPrice  pgtype.Numeric
+}
+
+type OrderReplacement struct {
+	ID               int64
+	OldClientOrderID string
+	NewClientOrderID string
+	Mode             string
+	Price            decimal.Decimal
+	Qty              decimal.Decimal
+	RequestedAt      time.Time
 }
 
 type OrderTransition struct {
@@ -88,6 +221,77 @@ type Outbox struct {
 	Payload     []byte
 	CreatedAt   time.Time
 	PublishedAt pgtype.Timestamptz
+	PublishSeq  *int64
+}
+
+type ParentOrder struct {
+	ParentID        string
+	Algo            string
+	Status          string
+	Venue           string
+	Base            string
+	Quote           string
+	BotID           string
+	Side            string
+	Qty             decimal.Decimal
+	LimitPrice      pgtype.Numeric
+	Slices          int32
+	SliceIntervalMs int64
+	Jitter          float64
+	SlicesSent      int32
+	NextChildID     string
+	NextAt          time.Time
+	Reason          string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DisplayQty      pgtype.Numeric
+	PriceJitter     decimal.Decimal
+	Curve           []decimal.Decimal
+	BenchmarkPrice  pgtype.Numeric
+}
+
+type ReplaceIntent struct {
+	OldClientOrderID string
+	NewClientOrderID string
+	Price            decimal.Decimal
+	Qty              decimal.Decimal
+	RequestedAt      time.Time
+}
+
+type Reservation struct {
+	ClientOrderID string
+	BotID         string
+	Venue         string
+	AccountType   string
+	Base          string
+	Quote         string
+	Side          string
+	Currency      string
+	Amount        decimal.Decimal
+	LotQty        decimal.Decimal
+	Remaining     decimal.Decimal
+	LotRemaining  decimal.Decimal
+	Freed         decimal.Decimal
+	CreatedAt     time.Time
+	ReleasedAt    pgtype.Timestamptz
+}
+
+type SinkCursor struct {
+	Sink      string
+	After     int64
+	UpdatedAt time.Time
+}
+
+type SinkDeadLetter struct {
+	ID        int64
+	Sink      string
+	OutboxID  int64
+	Subject   string
+	At        time.Time
+	Body      []byte
+	Attempts  int32
+	LastError string
+	FailedAt  time.Time
 }
 
 type SnapshotCheckpoint struct {
@@ -109,4 +313,5 @@ type UnmatchedSell struct {
 	Quote      string
 	Qty        decimal.Decimal
 	OccurredAt time.Time
+	Fee        decimal.Decimal
 }
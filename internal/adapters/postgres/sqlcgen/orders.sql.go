@@ -33,6 +33,28 @@ func (q *Queries) AdoptVenueOrderID(ctx context.Context, arg AdoptVenueOrderIDPa
 	return result.RowsAffected(), nil
 }
 
+const amendOrderTerms = `-- name: AmendOrderTerms :execrows
+UPDATE orders
+SET price      = $2,
+    qty        = $3,
+    updated_at = now()
+WHERE client_order_id = $1
+`
+
+type AmendOrderTermsParams struct {
+	ClientOrderID string
+	Price         decimal.Decimal
+	Qty           decimal.Decimal
+}
+
+func (q *Queries) AmendOrderTerms(ctx context.Context, arg AmendOrderTermsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, amendOrderTerms, arg.ClientOrderID, arg.Price, arg.Qty)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const applyOrderUpdate = `-- name: ApplyOrderUpdate :exec
 UPDATE orders
 SET status         = $2,
@@ -65,8 +87,27 @@ func (q *Queries) ApplyOrderUpdate(ctx context.Context, arg ApplyOrderUpdatePara
 	return err
 }
 
+const countActiveOrders = `-- name: CountActiveOrders :one
+SELECT count(*) FROM orders
+WHERE status IN ('pending', 'open', 'partially_filled')
+  AND ($1::text IS NULL OR venue = $1)
+  AND ($2::text IS NULL OR bot_id = $2)
+`
+
+type CountActiveOrdersParams struct {
+	Venue *string
+	BotID *string
+}
+
+func (q *Queries) CountActiveOrders(ctx context.Context, arg CountActiveOrdersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countActiveOrders, arg.Venue, arg.BotID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const getOrder = `-- name: GetOrder :one
-SELECT client_order_id, venue, base, quote, venue_symbol, side, type, price, qty, filled_qty, avg_fill_price, status, venue_order_id, bot_id, cancel_requested_at, reason, created_at, updated_at FROM orders WHERE client_order_id = $1
+SELECT client_order_id, venue, base, quote, venue_symbol, side, type, price, qty, filled_qty, avg_fill_price, status, venue_order_id, bot_id, cancel_requested_at, reason, created_at, updated_at, time_in_force, expires_at, post_only, trigger_price, child_client_order_id, parent_id FROM orders WHERE client_order_id = $1
 `
 
 func (q *Queries) GetOrder(ctx context.Context, clientOrderID string) (Order, error) {
@@ -91,12 +132,18 @@ func (q *Queries) GetOrder(ctx context.Context, clientOrderID string) (Order, er
 		&i.Reason,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.TimeInForce,
+		&i.ExpiresAt,
+		&i.PostOnly,
+		&i.TriggerPrice,
+		&i.ChildClientOrderID,
+		&i.ParentID,
 	)
 	return i, err
 }
 
 const getOrderForUpdate = `-- name: GetOrderForUpdate :one
-SELECT client_order_id, venue, base, quote, venue_symbol, side, type, price, qty, filled_qty, avg_fill_price, status, venue_order_id, bot_id, cancel_requested_at, reason, created_at, updated_at FROM orders WHERE client_order_id = $1 FOR UPDATE
+SELECT client_order_id, venue, base, quote, venue_symbol, side, type, price, qty, filled_qty, avg_fill_price, status, venue_order_id, bot_id, cancel_requested_at, reason, created_at, updated_at, time_in_force, expires_at, post_only, trigger_price, child_client_order_id, parent_id FROM orders WHERE client_order_id = $1 FOR UPDATE
 `
 
 func (q *Queries) GetOrderForUpdate(ctx context.Context, clientOrderID string) (Order, error) {
@@ -121,6 +168,29 @@ func (q *Queries) GetOrderForUpdate(ctx context.Context, clientOrderID string) (
 		&i.Reason,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.TimeInForce,
+		&i.ExpiresAt,
+		&i.PostOnly,
+		&i.TriggerPrice,
+		&i.ChildClientOrderID,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const getReplaceIntent = `-- name: GetReplaceIntent :one
+SELECT old_client_order_id, new_client_order_id, price, qty, requested_at FROM replace_intents WHERE old_client_order_id = $1
+`
+
+func (q *Queries) GetReplaceIntent(ctx context.Context, oldClientOrderID string) (ReplaceIntent, error) {
+	row := q.db.QueryRow(ctx, getReplaceIntent, oldClientOrderID)
+	var i ReplaceIntent
+	err := row.Scan(
+		&i.OldClientOrderID,
+		&i.NewClientOrderID,
+		&i.Price,
+		&i.Qty,
+		&i.RequestedAt,
 	)
 	return i, err
 }
@@ -159,9 +229,37 @@ func (q *Queries) InsertFill(ctx context.Context, arg InsertFillParams) (int64,
 	return id, err
 }
 
+const insertOrderReplacement = `-- name: InsertOrderReplacement :exec
+INSERT INTO order_replacements (old_client_order_id, new_client_order_id, mode, price, qty, requested_at)
+VALUES ($1, $2, $3, $4, $5, COALESCE($6::timestamptz, now()))
+ON CONFLICT DO NOTHING
+`
+
+type InsertOrderReplacementParams struct {
+	OldClientOrderID string
+	NewClientOrderID string
+	Mode             string
+	Price            decimal.Decimal
+	Qty              decimal.Decimal
+	RequestedAt      pgtype.Timestamptz
+}
+
+func (q *Queries) InsertOrderReplacement(ctx context.Context, arg InsertOrderReplacementParams) error {
+	_, err := q.db.Exec(ctx, insertOrderReplacement,
+		arg.OldClientOrderID,
+		arg.NewClientOrderID,
+		arg.Mode,
+		arg.Price,
+		arg.Qty,
+		arg.RequestedAt,
+	)
+	return err
+}
+
 const insertPendingOrder = `-- name: InsertPendingOrder :execrows
-INSERT INTO orders (client_order_id, venue, base, quote, venue_symbol, side, type, price, qty, bot_id, status)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, 'pending')
+INSERT INTO orders (client_order_id, venue, base, quote, venue_symbol, side, type, price, qty, bot_id,
+                    time_in_force, expires_at, post_only, trigger_price, parent_id, status)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, 'pending')
 ON CONFLICT (client_order_id) DO NOTHING
 `
 
@@ -176,6 +274,11 @@ type InsertPendingOrderParams struct {
 	Price         decimal.Decimal
 	Qty           decimal.Decimal
 	BotID         string
+	TimeInForce   string
+	ExpiresAt     pgtype.Timestamptz
+	PostOnly      bool
+	TriggerPrice  pgtype.Numeric
+	ParentID      *string
 }
 
 func (q *Queries) InsertPendingOrder(ctx context.Context, arg InsertPendingOrderParams) (int64, error) {
@@ -190,6 +293,11 @@ func (q *Queries) InsertPendingOrder(ctx context.Context, arg InsertPendingOrder
 		arg.Price,
 		arg.Qty,
 		arg.BotID,
+		arg.TimeInForce,
+		arg.ExpiresAt,
+		arg.PostOnly,
+		arg.TriggerPrice,
+		arg.ParentID,
 	)
 	if err != nil {
 		return 0, err
@@ -197,6 +305,31 @@ func (q *Queries) InsertPendingOrder(ctx context.Context, arg InsertPendingOrder
 	return result.RowsAffected(), nil
 }
 
+const insertReplaceIntent = `-- name: InsertReplaceIntent :exec
+INSERT INTO replace_intents (old_client_order_id, new_client_order_id, price, qty, requested_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (old_client_order_id) DO NOTHING
+`
+
+type InsertReplaceIntentParams struct {
+	OldClientOrderID string
+	NewClientOrderID string
+	Price            decimal.Decimal
+	Qty              decimal.Decimal
+	RequestedAt      time.Time
+}
+
+func (q *Queries) InsertReplaceIntent(ctx context.Context, arg InsertReplaceIntentParams) error {
+	_, err := q.db.Exec(ctx, insertReplaceIntent,
+		arg.OldClientOrderID,
+		arg.NewClientOrderID,
+		arg.Price,
+		arg.Qty,
+		arg.RequestedAt,
+	)
+	return err
+}
+
 const insertTransition = `-- name: InsertTransition :one
 INSERT INTO order_transitions (client_order_id, seq, from_status, to_status, filled_qty, source, reason, occurred_at)
 VALUES (
@@ -237,9 +370,54 @@ func (q *Queries) InsertTransition(ctx context.Context, arg InsertTransitionPara
 	return i, err
 }
 
+const insertUntriggeredOrder = `-- name: InsertUntriggeredOrder :execrows
+INSERT INTO orders (client_order_id, venue, base, quote, venue_symbol, side, type, price, qty, bot_id,
+                    time_in_force, trigger_price, child_client_order_id, status)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, 'untriggered')
+ON CONFLICT (client_order_id) DO NOTHING
+`
+
+type InsertUntriggeredOrderParams struct {
+	ClientOrderID      string
+	Venue              string
+	Base               string
+	Quote              string
+	VenueSymbol        string
+	Side               string
+	Type               string
+	Price              decimal.Decimal
+	Qty                decimal.Decimal
+	BotID              string
+	TimeInForce        string
+	TriggerPrice       pgtype.Numeric
+	ChildClientOrderID *string
+}
+
+func (q *Queries) InsertUntriggeredOrder(ctx context.Context, arg InsertUntriggeredOrderParams) (int64, error) {
+	result, err := q.db.Exec(ctx, insertUntriggeredOrder,
+		arg.ClientOrderID,
+		arg.Venue,
+		arg.Base,
+		arg.Quote,
+		arg.VenueSymbol,
+		arg.Side,
+		arg.Type,
+		arg.Price,
+		arg.Qty,
+		arg.BotID,
+		arg.TimeInForce,
+		arg.TriggerPrice,
+		arg.ChildClientOrderID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const listActiveOrders = `-- name: ListActiveOrders :many
-SELECT client_order_id, venue, base, quote, venue_symbol, side, type, price, qty, filled_qty, avg_fill_price, status, venue_order_id, bot_id, cancel_requested_at, reason, created_at, updated_at FROM orders
-WHERE venue = $1 AND status IN ('pending', 'open', 'partially_filled')
+SELECT client_order_id, venue, base, quote, venue_symbol, side, type, price, qty, filled_qty, avg_fill_price, status, venue_order_id, bot_id, cancel_requested_at, reason, created_at, updated_at, time_in_force, expires_at, post_only, trigger_price, child_client_order_id, parent_id FROM orders
+WHERE venue = $1 AND status IN ('pending', 'untriggered', 'open', 'partially_filled')
 ORDER BY created_at
 `
 
@@ -271,6 +449,46 @@ func (q *Queries) ListActiveOrders(ctx context.Context, venue string) ([]Order,
 			&i.Reason,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.TimeInForce,
+			&i.ExpiresAt,
+			&i.PostOnly,
+			&i.TriggerPrice,
+			&i.ChildClientOrderID,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrderReplacements = `-- name: ListOrderReplacements :many
+SELECT id, old_client_order_id, new_client_order_id, mode, price, qty, requested_at FROM order_replacements
+WHERE old_client_order_id = $1 OR new_client_order_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListOrderReplacements(ctx context.Context, oldClientOrderID string) ([]OrderReplacement, error) {
+	rows, err := q.db.Query(ctx, listOrderReplacements, oldClientOrderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrderReplacement
+	for rows.Next() {
+		var i OrderReplacement
+		if err := rows.Scan(
+			&i.ID,
+			&i.OldClientOrderID,
+			&i.NewClientOrderID,
+			&i.Mode,
+			&i.Price,
+			&i.Qty,
+			&i.RequestedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -283,7 +501,7 @@ func (q *Queries) ListActiveOrders(ctx context.Context, venue string) ([]Order,
 }
 
 const listOrders = `-- name: ListOrders :many
-SELECT client_order_id, venue, base, quote, venue_symbol, side, type, price, qty, filled_qty, avg_fill_price, status, venue_order_id, bot_id, cancel_requested_at, reason, created_at, updated_at FROM orders
+SELECT client_order_id, venue, base, quote, venue_symbol, side, type, price, qty, filled_qty, avg_fill_price, status, venue_order_id, bot_id, cancel_requested_at, reason, created_at, updated_at, time_in_force, expires_at, post_only, trigger_price, child_client_order_id, parent_id FROM orders
 WHERE ($1::text IS NULL OR venue = $1)
   AND ($2::text[] IS NULL OR status = ANY($2::text[]))
   AND ($3::text IS NULL OR bot_id = $3)
@@ -342,6 +560,64 @@ func (q *Queries) ListOrders(ctx context.Context, arg ListOrdersParams) ([]Order
 			&i.Reason,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.TimeInForce,
+			&i.ExpiresAt,
+			&i.PostOnly,
+			&i.TriggerPrice,
+			&i.ChildClientOrderID,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUntriggeredStops = `-- name: ListUntriggeredStops :many
+SELECT client_order_id, venue, base, quote, venue_symbol, side, type, price, qty, filled_qty, avg_fill_price, status, venue_order_id, bot_id, cancel_requested_at, reason, created_at, updated_at, time_in_force, expires_at, post_only, trigger_price, child_client_order_id, parent_id FROM orders
+WHERE status = 'untriggered' AND child_client_order_id IS NOT NULL
+  AND ($1::text IS NULL OR venue = $1)
+ORDER BY created_at, client_order_id
+`
+
+func (q *Queries) ListUntriggeredStops(ctx context.Context, venue *string) ([]Order, error) {
+	rows, err := q.db.Query(ctx, listUntriggeredStops, venue)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Order
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.ClientOrderID,
+			&i.Venue,
+			&i.Base,
+			&i.Quote,
+			&i.VenueSymbol,
+			&i.Side,
+			&i.Type,
+			&i.Price,
+			&i.Qty,
+			&i.FilledQty,
+			&i.AvgFillPrice,
+			&i.Status,
+			&i.VenueOrderID,
+			&i.BotID,
+			&i.CancelRequestedAt,
+			&i.Reason,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.TimeInForce,
+			&i.ExpiresAt,
+			&i.PostOnly,
+			&i.TriggerPrice,
+			&i.ChildClientOrderID,
+			&i.ParentID,
 		); err != nil {
 			return nil, err
 		}
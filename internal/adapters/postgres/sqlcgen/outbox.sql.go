@@ -53,9 +53,12 @@ func (q *Queries) ClaimUnpublishedOutbox(ctx context.Context, dollar_1 int64) ([
 }
 
 const deleteOutboxPublishedBefore = `-- name: DeleteOutboxPublishedBefore :execrows
-DELETE FROM outbox WHERE published_at IS NOT NULL AND published_at < $1
+DELETE FROM outbox
+WHERE published_at IS NOT NULL AND published_at < $1
+  AND (publish_seq IS NULL OR publish_seq <= COALESCE((SELECT MIN(after) FROM sink_cursors), publish_seq))
 `
 
+// Rows a sink has yet to pass are kept however old they are.
 func (q *Queries) DeleteOutboxPublishedBefore(ctx context.Context, publishedAt pgtype.Timestamptz) (int64, error) {
 	result, err := q.db.Exec(ctx, deleteOutboxPublishedBefore, publishedAt)
 	if err != nil {
@@ -78,15 +81,112 @@ func (q *Queries) InsertOutbox(ctx context.Context, arg InsertOutboxParams) erro
 	return err
 }
 
-const markOutboxPublished = `-- name: MarkOutboxPublished :exec
-UPDATE outbox SET published_at = now() WHERE id = ANY($1::bigint[])
+const listOutboxAfter = `-- name: ListOutboxAfter :many
+SELECT id, subject, payload, created_at, publish_seq::bigint AS publish_seq FROM outbox
+WHERE publish_seq > $1::bigint AND starts_with(subject, $2::text)
+ORDER BY publish_seq
+LIMIT $3::bigint
 `
 
-func (q *Queries) MarkOutboxPublished(ctx context.Context, dollar_1 []int64) error {
-	_, err := q.db.Exec(ctx, markOutboxPublished, dollar_1)
+type ListOutboxAfterParams struct {
+	After    int64
+	Prefix   string
+	RowLimit int64
+}
+
+type ListOutboxAfterRow struct {
+	ID         int64
+	Subject    string
+	Payload    []byte
+	CreatedAt  time.Time
+	PublishSeq int64
+}
+
+func (q *Queries) ListOutboxAfter(ctx context.Context, arg ListOutboxAfterParams) ([]ListOutboxAfterRow, error) {
+	rows, err := q.db.Query(ctx, listOutboxAfter, arg.After, arg.Prefix, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOutboxAfterRow
+	for rows.Next() {
+		var i ListOutboxAfterRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Subject,
+			&i.Payload,
+			&i.CreatedAt,
+			&i.PublishSeq,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockOutboxPublish = `-- name: LockOutboxPublish :exec
+SELECT pg_advisory_xact_lock($1::bigint)
+`
+
+// Held by the relay from claiming a batch until it commits.
+func (q *Queries) LockOutboxPublish(ctx context.Context, key int64) error {
+	_, err := q.db.Exec(ctx, lockOutboxPublish, key)
 	return err
 }
 
+const markOutboxPublished = `-- name: MarkOutboxPublished :many
+UPDATE outbox SET published_at = now(), publish_seq = stamped.seq
+FROM (
+    SELECT id, nextval('outbox_publish_seq') AS seq FROM outbox
+    WHERE id = ANY($1::bigint[])
+    ORDER BY id
+) AS stamped
+WHERE outbox.id = stamped.id
+RETURNING outbox.id, stamped.seq
+`
+
+type MarkOutboxPublishedRow struct {
+	ID  int64
+	Seq int64
+}
+
+// Rows are stamped in ID order, the order the relay publishes them; the
+// sort runs before nextval is evaluated.
+func (q *Queries) MarkOutboxPublished(ctx context.Context, dollar_1 []int64) ([]MarkOutboxPublishedRow, error) {
+	rows, err := q.db.Query(ctx, markOutboxPublished, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MarkOutboxPublishedRow
+	for rows.Next() {
+		var i MarkOutboxPublishedRow
+		if err := rows.Scan(&i.ID, &i.Seq); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const outboxHasRow = `-- name: OutboxHasRow :one
+SELECT EXISTS (SELECT 1 FROM outbox WHERE publish_seq = $1::bigint)
+`
+
+func (q *Queries) OutboxHasRow(ctx context.Context, publishSeq int64) (bool, error) {
+	row := q.db.QueryRow(ctx, outboxHasRow, publishSeq)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const outboxUnpublishedStats = `-- name: OutboxUnpublishedStats :one
 SELECT COUNT(*) AS unpublished, COALESCE(MIN(created_at), now())::timestamptz AS oldest
 FROM outbox
@@ -104,3 +204,13 @@ func (q *Queries) OutboxUnpublishedStats(ctx context.Context) (OutboxUnpublished
 	err := row.Scan(&i.Unpublished, &i.Oldest)
 	return i, err
 }
+
+const waitOutboxPublish = `-- name: WaitOutboxPublish :exec
+SELECT pg_advisory_xact_lock_shared($1::bigint)
+`
+
+// Waits for a batch the relay holds LockOutboxPublish for to commit.
+func (q *Queries) WaitOutboxPublish(ctx context.Context, key int64) error {
+	_, err := q.db.Exec(ctx, waitOutboxPublish, key)
+	return err
+}
@@ -15,20 +15,26 @@ import (
 
 	"github.com/romanornr/delta-works/internal/config"
 	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/ledger"
 	"github.com/romanornr/delta-works/internal/domain/marketdata"
 	"github.com/romanornr/delta-works/internal/ports"
 )
 
-// Writer implements the balance and ticker series ports over one ILP line
-// sender. The sender is not safe for concurrent use, so writes are serialized.
+// Writer implements the balance, ticker, trade and valuation series ports
+// over one ILP line sender. The sender is not safe for concurrent use, so writes are
+// serialized. A flush sends every buffered row, whoever wrote it: give each
+// service its own Writer so its flush result describes its own rows.
 type Writer struct {
 	mu     sync.Mutex
 	sender qdb.LineSender
+	trades string
 }
 
 var (
-	_ ports.BalanceSeriesWriter = (*Writer)(nil)
-	_ ports.TickerSeriesWriter  = (*Writer)(nil)
+	_ ports.BalanceSeriesWriter   = (*Writer)(nil)
+	_ ports.TickerSeriesWriter    = (*Writer)(nil)
+	_ ports.TradeSeriesWriter     = (*Writer)(nil)
+	_ ports.ValuationSeriesWriter = (*Writer)(nil)
 )
 
 // New connects a line sender from a QuestDB configuration string, e.g.
@@ -38,7 +44,7 @@ func New(ctx context.Context, cfg config.QuestDB) (*Writer, error) {
 	if err != nil {
 		return nil, fmt.Errorf("questdb: connect: %w", err)
 	}
-	return &Writer{sender: sender}, nil
+	return &Writer{sender: sender, trades: cfg.TradesTable}, nil
 }
 
 // WriteBalanceSnapshot converts decimal to float64 only at the analytics edge (ADR-0004).
@@ -80,6 +86,45 @@ func (w *Writer) WriteTicker(ctx context.Context, t marketdata.Ticker) error {
 	return nil
 }
 
+// WriteTrade appends one public trade to the trade table the Reader
+// queries.
+func (w *Writer) WriteTrade(ctx context.Context, t marketdata.Trade) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	err := w.sender.Table(w.trades).
+		Symbol("venue", string(t.Instrument.Venue)).
+		Symbol("symbol", t.Instrument.Pair()).
+		StringColumn("trade_id", t.ID).
+		Float64Column("price", t.Price.InexactFloat64()).
+		Float64Column("qty", t.Qty.InexactFloat64()).
+		At(ctx, t.At)
+	if err != nil {
+		return fmt.Errorf("questdb: write trade %s: %w", t.Instrument.Key(), err)
+	}
+	return nil
+}
+
+// WriteValuation appends one position's mark-to-market valuation.
+func (w *Writer) WriteValuation(ctx context.Context, v ledger.Valuation) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	err := w.sender.Table("unrealized_pnl").
+		Symbol("bot", v.BotID).
+		Symbol("venue", string(v.Venue)).
+		Symbol("symbol", v.Instrument().Pair()).
+		Float64Column("qty", v.Qty.InexactFloat64()).
+		Float64Column("cost", v.Cost.InexactFloat64()).
+		Float64Column("mark", v.Mark.InexactFloat64()).
+		Float64Column("value", v.Value.InexactFloat64()).
+		Float64Column("unrealized", v.Unrealized.InexactFloat64()).
+		Float64Column("unpriced_qty", v.UnpricedQty.InexactFloat64()).
+		At(ctx, v.At)
+	if err != nil {
+		return fmt.Errorf("questdb: write valuation %s %s: %w", v.BotID, v.Instrument().Key(), err)
+	}
+	return nil
+}
+
 // Flush waits until the sender has accepted its buffered rows.
 func (w *Writer) Flush(ctx context.Context) error {
 	w.mu.Lock()
@@ -105,6 +150,11 @@ type Health struct {
 
 // NewHealth builds the QuestDB readiness check.
 func NewHealth(cfg config.QuestDB) *Health {
+	return &Health{url: httpBase(cfg) + "/ping"}
+}
+
+// httpBase derives QuestDB's HTTP endpoint from the configuration string.
+func httpBase(cfg config.QuestDB) string {
 	addr := "localhost:9000"
 	scheme := "http"
 	for part := range strings.SplitSeq(cfg.Conf, ";") {
@@ -117,7 +167,7 @@ func NewHealth(cfg config.QuestDB) *Health {
 			addr = v
 		}
 	}
-	return &Health{url: scheme + "://" + addr + "/ping"}
+	return scheme + "://" + addr
 }
 
 // Name implements ports.HealthChecker.
@@ -17,6 +17,8 @@ import (
 
 	"github.com/romanornr/delta-works/internal/config"
 	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
 )
 
 func startQuestDB(t *testing.T) (config.QuestDB, string) {
@@ -90,6 +92,45 @@ func TestBalanceSnapshotRoundTrip(t *testing.T) {
 	}
 }
 
+func TestTradesFeedTheReader(t *testing.T) {
+	ctx := context.Background()
+	cfg, addr := startQuestDB(t)
+	cfg.TradesTable = "trades"
+
+	w, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer w.Close(ctx)
+
+	inst := instrument.Instrument{Venue: "bybit", Type: instrument.TypeSpot, Base: "BTC", Quote: "USDT"}
+	at := time.Now().UTC().Truncate(time.Minute)
+	for i, trade := range []marketdata.Trade{
+		{Instrument: inst, ID: "1", Price: decimal.NewFromInt(100), Qty: decimal.NewFromInt(1), At: at},
+		{Instrument: inst, ID: "2", Price: decimal.NewFromInt(110), Qty: decimal.NewFromInt(3), At: at.Add(time.Second)},
+	} {
+		if err := w.WriteTrade(ctx, trade); err != nil {
+			t.Fatalf("WriteTrade %d: %v", i, err)
+		}
+	}
+	if err := w.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if n := pollCount(t, addr, "select count() from trades"); n != 2 {
+		t.Fatalf("trades rows: got %d, want 2", n)
+	}
+
+	r := NewReader(cfg)
+	vwap, err := r.VWAP(ctx, inst, at, at.Add(time.Minute))
+	if err != nil || !vwap.Equal(decimal.RequireFromString("107.5")) {
+		t.Fatalf("VWAP = %s, %v; want 107.5", vwap, err)
+	}
+	profile, err := r.VolumeProfile(ctx, inst, at)
+	if err != nil || !profile[at.Hour()*60+at.Minute()].Equal(decimal.NewFromInt(4)) {
+		t.Fatalf("VolumeProfile = %v, %v; want 4 in the trades' minute", profile, err)
+	}
+}
+
 func pollCount(t *testing.T, addr, query string) int {
 	t.Helper()
 	deadline := time.Now().Add(30 * time.Second)
@@ -0,0 +1,119 @@
+package questdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/config"
+	"github.com/romanornr/delta-works/internal/domain/execution"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// minutesPerDay is the length of the volume profile the reader returns.
+const minutesPerDay = 24 * 60
+
+// Reader implements the trade series port over QuestDB's HTTP query
+// endpoint. The trade table (symbols venue, symbol; doubles price, qty) is
+// the one Writer.WriteTrade appends to as the ticker service records
+// public trades. Figures
+// come back as float64 and are converted to decimal at this edge, which is
+// fine for schedules and benchmarks and never used for accounting
+// (ADR-0004).
+type Reader struct {
+	base   string
+	table  string
+	client *http.Client
+}
+
+var _ ports.TradeSeriesReader = (*Reader)(nil)
+
+// NewReader builds the reader. The configuration's trade table name has
+// been validated as a plain identifier, so it is safe to splice into a
+// query.
+func NewReader(cfg config.QuestDB) *Reader {
+	return &Reader{base: httpBase(cfg), table: cfg.TradesTable, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// VolumeProfile adds up the volume traded in each minute of the UTC day
+// since since.
+func (r *Reader) VolumeProfile(ctx context.Context, inst instrument.Instrument, since time.Time) (execution.VolumeProfile, error) {
+	rows, err := r.query(ctx, fmt.Sprintf(
+		"SELECT hour(timestamp) h, minute(timestamp) m, sum(qty) v FROM %s WHERE venue = %s AND symbol = %s AND timestamp >= %s GROUP BY h, m",
+		r.table, literal(string(inst.Venue)), literal(inst.Pair()), timestamp(since)))
+	if err != nil {
+		return nil, fmt.Errorf("questdb: volume profile %s: %w", inst.Key(), err)
+	}
+	profile := make(execution.VolumeProfile, minutesPerDay)
+	for _, row := range rows {
+		h, hok := row[0].(float64)
+		m, mok := row[1].(float64)
+		v, vok := row[2].(float64)
+		minute := int(h)*60 + int(m)
+		if !hok || !mok || !vok || minute < 0 || minute >= minutesPerDay {
+			return nil, fmt.Errorf("questdb: volume profile %s: unexpected row %v", inst.Key(), row)
+		}
+		profile[minute] = decimal.NewFromFloat(v)
+	}
+	return profile, nil
+}
+
+// VWAP is the market's volume-weighted average trade price in [from, to).
+func (r *Reader) VWAP(ctx context.Context, inst instrument.Instrument, from, to time.Time) (decimal.Decimal, error) {
+	rows, err := r.query(ctx, fmt.Sprintf(
+		"SELECT sum(price * qty) / sum(qty) FROM %s WHERE venue = %s AND symbol = %s AND timestamp >= %s AND timestamp < %s",
+		r.table, literal(string(inst.Venue)), literal(inst.Pair()), timestamp(from), timestamp(to)))
+	if err != nil {
+		return decimal.Decimal{}, fmt.Errorf("questdb: vwap %s: %w", inst.Key(), err)
+	}
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return decimal.Decimal{}, nil
+	}
+	// No trades in the window sum to NULL.
+	price, ok := rows[0][0].(float64)
+	if !ok || price <= 0 {
+		return decimal.Decimal{}, nil
+	}
+	return decimal.NewFromFloat(price), nil
+}
+
+// query runs one SQL statement through /exec and returns its rows.
+func (r *Reader) query(ctx context.Context, sql string) ([][]any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.base+"/exec?query="+url.QueryEscape(sql), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck // read-only response
+	var body struct {
+		Dataset [][]any `json:"dataset"`
+		Error   string  `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode status %d response: %w", resp.StatusCode, err)
+	}
+	if body.Error != "" || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, body.Error)
+	}
+	return body.Dataset, nil
+}
+
+// literal quotes s as a SQL string.
+func literal(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// timestamp renders t as a QuestDB timestamp literal.
+func timestamp(t time.Time) string {
+	return "'" + t.UTC().Format("2006-01-02T15:04:05.000000Z") + "'"
+}
@@ -0,0 +1,51 @@
+package sinks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/romanornr/delta-works/internal/domain/sink"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// File appends each event's JSON to a file as one line. Every write is
+// synced before Deliver returns, so a delivered event survives a crash;
+// one redelivered after a crash appears twice, and readers dedupe by the
+// event's cursor.
+type File struct {
+	path string
+	mu   sync.Mutex
+}
+
+var _ ports.Sink = (*File)(nil)
+
+// NewFile builds a file sink. The file is created on first delivery.
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+// Deliver implements ports.Sink. Every failure is worth another attempt: a
+// full disk or a missing directory can be fixed while the sink retries.
+func (f *File) Deliver(_ context.Context, d sink.Delivery) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) //nolint:gosec // the operator chooses the sink path
+	if err != nil {
+		return fmt.Errorf("file sink: %w", err)
+	}
+	line := append(append(make([]byte, 0, len(d.Body)+1), d.Body...), '\n')
+	if _, err := file.Write(line); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("file sink: write %s: %w", f.path, err)
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("file sink: sync %s: %w", f.path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("file sink: close %s: %w", f.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package sinks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/romanornr/delta-works/internal/domain/sink"
+)
+
+func TestFileAppendsLines(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	f := NewFile(path)
+	for _, body := range []string{`{"cursor":"1"}`, `{"cursor":"2"}`} {
+		if err := f.Deliver(t.Context(), sink.Delivery{Body: []byte(body)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := os.ReadFile(path)
+	if err != nil || string(got) != "{\"cursor\":\"1\"}\n{\"cursor\":\"2\"}\n" {
+		t.Fatalf("file = %q, %v", got, err)
+	}
+	if err := NewFile(filepath.Join(t.TempDir(), "missing", "events.jsonl")).Deliver(t.Context(), sink.Delivery{Body: []byte("{}")}); err == nil {
+		t.Fatal("delivery into a missing directory succeeded")
+	}
+}
@@ -0,0 +1,114 @@
+// Package sinks implements ports.Sink: HTTP webhooks and append-only
+// files.
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/romanornr/delta-works/internal/domain/sink"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// Webhook headers, laid out as the Standard Webhooks specification does so
+// receivers can verify with its libraries.
+const (
+	headerID        = "webhook-id"
+	headerTimestamp = "webhook-timestamp"
+	headerSignature = "webhook-signature"
+)
+
+// secretPrefix starts a Standard Webhooks signing secret; the base64 after
+// it is the HMAC key.
+const secretPrefix = "whsec_"
+
+// drainLimit bounds how much of a response body is read so the connection
+// can be reused; receivers answer with a status, not content.
+const drainLimit = 4 << 10
+
+// Webhook POSTs each event's JSON to a URL, signed with a shared secret.
+// webhook-id is the delivery's message ID, the same across retries, so a
+// receiver can drop repeats; webhook-timestamp is the Unix second it was
+// sent; webhook-signature is "v1," and the base64 HMAC-SHA256, keyed with
+// the key the secret encodes, of "id.timestamp.body". A receiver recomputes it and
+// refuses stale timestamps, so a captured request cannot be replayed.
+type Webhook struct {
+	url    string
+	key    []byte
+	client *http.Client
+	clk    clockwork.Clock
+}
+
+var _ ports.Sink = (*Webhook)(nil)
+
+// NewWebhook builds a webhook sink. secret is a Standard Webhooks signing
+// secret, "whsec_" and the base64 of the key. Redirects are not followed:
+// a POST that lands elsewhere is the receiver's misconfiguration, not a
+// delivery.
+func NewWebhook(url, secret string, timeout time.Duration, clk clockwork.Clock) (*Webhook, error) {
+	encoded, ok := strings.CutPrefix(secret, secretPrefix)
+	if !ok {
+		return nil, fmt.Errorf("webhook: secret must start with %s", secretPrefix)
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("webhook: secret key: %w", err)
+	}
+	return &Webhook{
+		url: url,
+		key: key,
+		client: &http.Client{
+			Timeout:       timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		clk: clk,
+	}, nil
+}
+
+// Deliver implements ports.Sink. A 2xx status is delivered; 408, 429 and
+// 5xx are worth another attempt; any other status rejects the event.
+func (w *Webhook) Deliver(ctx context.Context, d sink.Delivery) error {
+	id, ts := d.MessageID(), w.clk.Now().Unix()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(d.Body))
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(headerID, id)
+	req.Header.Set(headerTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(headerSignature, Sign(w.key, id, ts, d.Body))
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, drainLimit))
+	_ = resp.Body.Close()
+	switch code := resp.StatusCode; {
+	case code >= 200 && code < 300:
+		return nil
+	case code == http.StatusRequestTimeout, code == http.StatusTooManyRequests, code >= 500:
+		return fmt.Errorf("webhook: status %d", code)
+	default:
+		return fmt.Errorf("webhook: status %d: %w", code, sink.ErrRejected)
+	}
+}
+
+// Sign returns the webhook-signature header value for a delivery, keyed
+// with the decoded signing key.
+func Sign(key []byte, id string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	_, _ = fmt.Fprintf(mac, "%s.%d.", id, timestamp)
+	_, _ = mac.Write(body)
+	return "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,115 @@
+package sinks
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/romanornr/delta-works/internal/domain/sink"
+)
+
+// testSecret is the signing secret of the Standard Webhooks examples.
+const testSecret = "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"
+
+func newTestWebhook(t *testing.T, url string, clk clockwork.Clock) *Webhook {
+	t.Helper()
+	w, err := NewWebhook(url, testSecret, time.Second, clk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+// TestSignMatchesStandardWebhooks checks Sign against the example in the
+// Standard Webhooks specification.
+func TestSignMatchesStandardWebhooks(t *testing.T) {
+	t.Parallel()
+	key, err := base64.StdEncoding.DecodeString("MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := Sign(key, "msg_p5jXN8AQM9LWM0D4loKWxJek", 1614265330, []byte(`{"test": 2432232314}`))
+	if want := "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE="; got != want {
+		t.Fatalf("Sign = %s, want %s", got, want)
+	}
+}
+
+func TestWebhookRefusesMalformedSecrets(t *testing.T) {
+	t.Parallel()
+	for _, secret := range []string{"s3cret", "whsec_not base64!"} {
+		if _, err := NewWebhook("https://example.com/hook", secret, time.Second, clockwork.NewRealClock()); err == nil {
+			t.Errorf("NewWebhook(%q) accepted the secret", secret)
+		}
+	}
+}
+
+func TestWebhookSignsDeliveries(t *testing.T) {
+	t.Parallel()
+	clk := clockwork.NewFakeClockAt(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC))
+	body := []byte(`{"subject":"order.filled","cursor":"42"}`)
+	received := make(chan *http.Request, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ := io.ReadAll(r.Body)
+		if string(got) != string(body) {
+			t.Errorf("body = %s", got)
+		}
+		received <- r
+	}))
+	t.Cleanup(srv.Close)
+
+	if err := newTestWebhook(t, srv.URL, clk).Deliver(t.Context(), sink.Delivery{OutboxID: 42, Body: body}); err != nil {
+		t.Fatal(err)
+	}
+	r := <-received
+	ts := clk.Now().Unix()
+	if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" ||
+		r.Header.Get(headerID) != "outbox-42" || r.Header.Get(headerTimestamp) != strconv.FormatInt(ts, 10) {
+		t.Fatalf("request = %s %v", r.Method, r.Header)
+	}
+	key, _ := base64.StdEncoding.DecodeString("MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw")
+	if got, want := r.Header.Get(headerSignature), Sign(key, "outbox-42", ts, body); got != want {
+		t.Fatalf("signature = %s, want %s", got, want)
+	}
+	// A different key or body signs differently.
+	if Sign([]byte("other"), "outbox-42", ts, body) == r.Header.Get(headerSignature) || Sign(key, "outbox-42", ts, []byte("{}")) == r.Header.Get(headerSignature) {
+		t.Fatal("signature does not depend on the secret and body")
+	}
+}
+
+func TestWebhookClassifiesStatus(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		status       int
+		wantErr      bool
+		wantRejected bool
+	}{
+		{http.StatusNoContent, false, false},
+		{http.StatusInternalServerError, true, false},
+		{http.StatusTooManyRequests, true, false},
+		{http.StatusRequestTimeout, true, false},
+		{http.StatusBadRequest, true, true},
+		{http.StatusUnauthorized, true, true},
+		{http.StatusFound, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			t.Parallel()
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Location", "/elsewhere")
+				w.WriteHeader(tt.status)
+			}))
+			t.Cleanup(srv.Close)
+			err := newTestWebhook(t, srv.URL, clockwork.NewRealClock()).Deliver(t.Context(), sink.Delivery{OutboxID: 1, Body: []byte("{}")})
+			if (err != nil) != tt.wantErr || errors.Is(err, sink.ErrRejected) != tt.wantRejected {
+				t.Fatalf("err = %v, want error %v, rejected %v", err, tt.wantErr, tt.wantRejected)
+			}
+		})
+	}
+}
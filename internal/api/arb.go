@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/domain/arb"
+	arbservice "github.com/romanornr/delta-works/internal/service/arb"
+)
+
+const defaultArbTradeLimit int32 = 50
+
+// ArbServer serves control.v1.ArbService.
+type ArbServer struct {
+	arbs *arbservice.Service
+}
+
+// NewArbServer builds the ArbService handler.
+func NewArbServer(service *arbservice.Service) *ArbServer {
+	return &ArbServer{arbs: service}
+}
+
+// ListArbTrades returns the newest arbitrage trades.
+func (s *ArbServer) ListArbTrades(ctx context.Context, req *connect.Request[controlv1.ListArbTradesRequest]) (*connect.Response[controlv1.ListArbTradesResponse], error) {
+	limit := req.Msg.GetLimit()
+	if limit == 0 {
+		limit = defaultArbTradeLimit
+	}
+	trades, err := s.arbs.List(ctx, req.Msg.GetBotId(), req.Msg.GetUnsettledOnly(), limit)
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	response := &controlv1.ListArbTradesResponse{Trades: make([]*controlv1.ArbTrade, 0, len(trades))}
+	for _, t := range trades {
+		response.Trades = append(response.Trades, toProtoArbTrade(t))
+	}
+	return connect.NewResponse(response), nil
+}
+
+// ResolveArbHedge marks a hedge-needed trade hedged.
+func (s *ArbServer) ResolveArbHedge(ctx context.Context, req *connect.Request[controlv1.ResolveArbHedgeRequest]) (*connect.Response[controlv1.ResolveArbHedgeResponse], error) {
+	t, err := s.arbs.Resolve(ctx, arb.TradeID(req.Msg.GetTradeId()), req.Msg.GetNote())
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	return connect.NewResponse(&controlv1.ResolveArbHedgeResponse{Trade: toProtoArbTrade(t)}), nil
+}
+
+func toProtoArbTrade(t arb.Trade) *controlv1.ArbTrade {
+	msg := &controlv1.ArbTrade{
+		TradeId: string(t.ID), BotId: t.BotID, Status: toProtoArbTradeStatus(t.Status),
+		Base: string(t.Buy.Base), Quote: string(t.Buy.Quote),
+		BuyVenue: string(t.Buy.Venue), SellVenue: string(t.Sell.Venue),
+		BuyOrderId: string(t.BuyOrderID), SellOrderId: string(t.SellOrderID),
+		Qty: t.Qty.String(), BuyPrice: t.BuyPrice.String(), SellPrice: t.SellPrice.String(), EdgeBps: t.EdgeBps.String(),
+		HedgeSide: toProtoSide(t.HedgeSide), Reason: t.Reason,
+		CreatedAt: timestamppb.New(t.CreatedAt), UpdatedAt: timestamppb.New(t.UpdatedAt),
+	}
+	if t.HedgeQty.IsPositive() {
+		msg.HedgeQty = t.HedgeQty.String()
+	}
+	return msg
+}
+
+func toProtoArbTradeStatus(status arb.Status) controlv1.ArbTradeStatus {
+	switch status {
+	case arb.StatusOpen:
+		return controlv1.ArbTradeStatus_ARB_TRADE_STATUS_OPEN
+	case arb.StatusBalanced:
+		return controlv1.ArbTradeStatus_ARB_TRADE_STATUS_BALANCED
+	case arb.StatusHedgeNeeded:
+		return controlv1.ArbTradeStatus_ARB_TRADE_STATUS_HEDGE_NEEDED
+	case arb.StatusHedged:
+		return controlv1.ArbTradeStatus_ARB_TRADE_STATUS_HEDGED
+	default:
+		return controlv1.ArbTradeStatus_ARB_TRADE_STATUS_UNSPECIFIED
+	}
+}
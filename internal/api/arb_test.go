@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shopspring/decimal"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/api/gen/control/v1/controlv1connect"
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/arb"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	domain "github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/exchange"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+	arbservice "github.com/romanornr/delta-works/internal/service/arb"
+)
+
+type fakeArbTrades struct {
+	mu     sync.Mutex
+	trades []arb.Trade
+}
+
+func (f *fakeArbTrades) CreateArbTrade(_ context.Context, t arb.Trade) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.trades = append(f.trades, t)
+	return nil
+}
+
+func (f *fakeArbTrades) GetArbTrade(_ context.Context, id arb.TradeID) (arb.Trade, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, t := range f.trades {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return arb.Trade{}, ports.ErrNotFound
+}
+
+func (f *fakeArbTrades) ListArbTrades(_ context.Context, botID string, unsettledOnly bool, _ int32) ([]arb.Trade, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []arb.Trade
+	for _, t := range f.trades {
+		if (botID == "" || t.BotID == botID) && (!unsettledOnly || !t.Status.Final()) {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeArbTrades) ListUnsettledArbTrades(ctx context.Context, botID string) ([]arb.Trade, error) {
+	return f.ListArbTrades(ctx, botID, true, 0)
+}
+
+func (f *fakeArbTrades) UpdateArbTrade(_ context.Context, t arb.Trade) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.trades {
+		if f.trades[i].ID == t.ID {
+			f.trades[i] = t
+			return nil
+		}
+	}
+	return ports.ErrNotFound
+}
+
+func (*fakeArbTrades) GetOrder(context.Context, domain.ClientOrderID) (domain.Record, error) {
+	return domain.Record{}, ports.ErrNotFound
+}
+
+func TestArbService(t *testing.T) {
+	t.Parallel()
+	leg := func(venue instrument.VenueID) instrument.Instrument {
+		return instrument.Instrument{Venue: venue, Type: instrument.TypeSpot, Base: "BTC", Quote: "USDT"}
+	}
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	store := &fakeArbTrades{trades: []arb.Trade{
+		{
+			ID: "01J00000000000000000000001", BotID: "arb-1", Buy: leg("bybit"), Sell: leg("binance"),
+			BuyOrderID: "01J0000000000000000000000B", SellOrderID: "01J0000000000000000000000S",
+			Qty: decimal.NewFromInt(1), BuyPrice: decimal.NewFromInt(100), SellPrice: decimal.NewFromInt(101),
+			EdgeBps: decimal.RequireFromString("79.8"), Status: arb.StatusHedgeNeeded,
+			HedgeSide: domain.Sell, HedgeQty: decimal.NewFromInt(1), Reason: "bought 1 on bybit, sold 0 on binance",
+			CreatedAt: now, UpdatedAt: now,
+		},
+		{ID: "01J00000000000000000000002", BotID: "arb-1", Buy: leg("bybit"), Sell: leg("binance"), Status: arb.StatusBalanced},
+	}}
+	metrics, err := arbservice.NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventBus := bus.NewInProc()
+	t.Cleanup(eventBus.Close)
+	service := arbservice.New(nil, nil, store, exchange.NewRegistry(nil), nil, nil, eventBus, clockwork.NewFakeClock(), log.Nop(), time.Second, metrics)
+	srv := httptest.NewServer(NewServer(Handlers{Events: testEventServer(t, eventBus), Arbs: NewArbServer(service)}, nil).Handler)
+	t.Cleanup(srv.Close)
+	client := controlv1connect.NewArbServiceClient(srv.Client(), srv.URL)
+
+	list, err := client.ListArbTrades(t.Context(), connect.NewRequest(&controlv1.ListArbTradesRequest{BotId: "arb-1", UnsettledOnly: true}))
+	if err != nil || len(list.Msg.GetTrades()) != 1 {
+		t.Fatalf("unsettled trades = %v, %v", list, err)
+	}
+	tr := list.Msg.GetTrades()[0]
+	if tr.GetStatus() != controlv1.ArbTradeStatus_ARB_TRADE_STATUS_HEDGE_NEEDED || tr.GetHedgeSide() != controlv1.Side_SIDE_SELL ||
+		tr.GetHedgeQty() != "1" || tr.GetBuyVenue() != "bybit" || tr.GetSellVenue() != "binance" || tr.GetEdgeBps() != "79.8" {
+		t.Fatalf("trade = %v", tr)
+	}
+
+	resolved, err := client.ResolveArbHedge(t.Context(), connect.NewRequest(&controlv1.ResolveArbHedgeRequest{TradeId: tr.GetTradeId(), Note: "sold 1 on bybit"}))
+	if err != nil || resolved.Msg.GetTrade().GetStatus() != controlv1.ArbTradeStatus_ARB_TRADE_STATUS_HEDGED ||
+		resolved.Msg.GetTrade().GetReason() != "hedged: sold 1 on bybit" {
+		t.Fatalf("ResolveArbHedge = %v, %v", resolved, err)
+	}
+	_, err = client.ResolveArbHedge(t.Context(), connect.NewRequest(&controlv1.ResolveArbHedgeRequest{TradeId: tr.GetTradeId()}))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("second resolve = %v, want FailedPrecondition", err)
+	}
+	_, err = client.ResolveArbHedge(t.Context(), connect.NewRequest(&controlv1.ResolveArbHedgeRequest{TradeId: "01J00000000000000000000009"}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("unknown trade = %v, want NotFound", err)
+	}
+	_, err = client.ResolveArbHedge(t.Context(), connect.NewRequest(&controlv1.ResolveArbHedgeRequest{TradeId: "not-a-ulid"}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("malformed trade ID = %v, want InvalidArgument", err)
+	}
+}
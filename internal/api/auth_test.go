@@ -0,0 +1,211 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/api/gen/control/v1/controlv1connect"
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/auth"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+	authservice "github.com/romanornr/delta-works/internal/service/auth"
+)
+
+// fakeTokens keeps stored tokens in memory.
+type fakeTokens struct {
+	mu     sync.Mutex
+	tokens []auth.Token
+}
+
+func (f *fakeTokens) InsertToken(_ context.Context, t auth.Token) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tokens = append(f.tokens, t)
+	return true, nil
+}
+
+func (f *fakeTokens) TokenByHash(_ context.Context, hash auth.Hash) (auth.Token, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, t := range f.tokens {
+		if t.Hash == hash && !t.Revoked() {
+			return t, nil
+		}
+	}
+	return auth.Token{}, ports.ErrNotFound
+}
+
+func (f *fakeTokens) ListTokens(context.Context, bool) ([]auth.Token, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.tokens), nil
+}
+
+func (f *fakeTokens) RevokeToken(_ context.Context, id string, at time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.tokens {
+		if f.tokens[i].ID == id && !f.tokens[i].Revoked() {
+			f.tokens[i].RevokedAt = at
+			return nil
+		}
+	}
+	return ports.ErrNotFound
+}
+
+// TestEveryProcedureHasAScope keeps procedureScopes complete: an RPC added
+// without a scope would be refused to every token.
+func TestEveryProcedureHasAScope(t *testing.T) {
+	t.Parallel()
+	seen := 0
+	protoregistry.GlobalFiles.RangeFilesByPackage("control.v1", func(fd protoreflect.FileDescriptor) bool {
+		for i := range fd.Services().Len() {
+			service := fd.Services().Get(i)
+			for j := range service.Methods().Len() {
+				procedure := "/" + string(service.FullName()) + "/" + string(service.Methods().Get(j).Name())
+				if _, ok := procedureScopes[procedure]; !ok {
+					t.Errorf("%s has no scope in procedureScopes", procedure)
+				}
+				seen++
+			}
+		}
+		return true
+	})
+	if seen != len(procedureScopes) {
+		t.Fatalf("control.v1 has %d procedures, procedureScopes %d", seen, len(procedureScopes))
+	}
+}
+
+func TestAuthorizer(t *testing.T) {
+	t.Parallel()
+	const adminSecret = "bootstrap-admin-secret"
+	bootstrap := auth.Token{Name: "ops", Scopes: []auth.Scope{auth.ScopeAdmin}, Hash: auth.HashSecret(adminSecret), Source: auth.SourceFile}
+	tokens := authservice.New(&fakeTokens{}, []auth.Token{bootstrap}, clockwork.NewFakeClock(), log.Nop())
+	metrics, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventBus := bus.NewInProc()
+	t.Cleanup(eventBus.Close)
+	server := NewServer(Handlers{
+		Snapshots: NewSnapshotServer(&fakeCheckpointStore{err: ports.ErrNotFound}),
+		Events:    NewEventServer(eventBus, &fakeOutbox{}, log.Nop(), metrics),
+		Orders:    NewOrderServer(nil, nil),
+		Tokens:    NewTokenServer(tokens),
+	}, NewAuthorizer(tokens, metrics))
+	srv := httptest.NewServer(server.Handler)
+	t.Cleanup(srv.Close)
+	addr := strings.TrimPrefix(srv.URL, "http://")
+	connectAs := func(secret string) (controlv1connect.SnapshotServiceClient, controlv1connect.OrderServiceClient, controlv1connect.TokenServiceClient) {
+		httpClient, baseURL := NewHTTPClient(addr, secret)
+		return controlv1connect.NewSnapshotServiceClient(httpClient, baseURL),
+			controlv1connect.NewOrderServiceClient(httpClient, baseURL),
+			controlv1connect.NewTokenServiceClient(httpClient, baseURL)
+	}
+	snapshotRequest := func() *connect.Request[controlv1.GetLastSnapshotRequest] {
+		return connect.NewRequest(&controlv1.GetLastSnapshotRequest{Venue: "bybit", Account: "spot"})
+	}
+
+	anonymous, _, _ := connectAs("")
+	_, err = anonymous.GetLastSnapshot(t.Context(), snapshotRequest())
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeUnauthenticated {
+		t.Fatalf("no token = %v, want Unauthenticated", err)
+	}
+	if got := connectErr.Meta().Get("WWW-Authenticate"); got != "Bearer" {
+		t.Fatalf("WWW-Authenticate = %q, want Bearer", got)
+	}
+	// A request the validator would refuse is still refused as
+	// unauthenticated: validation runs after the token check.
+	if _, err := anonymous.GetLastSnapshot(t.Context(), connect.NewRequest(&controlv1.GetLastSnapshotRequest{})); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("invalid request without a token = %v, want Unauthenticated", err)
+	}
+	stream, err := controlv1connect.NewEventServiceClient(srv.Client(), srv.URL).StreamEvents(t.Context(), connect.NewRequest(&controlv1.StreamEventsRequest{}))
+	if err == nil {
+		for stream.Receive() {
+			t.Fatal("stream sent a message without a token")
+		}
+		err = stream.Err()
+		_ = stream.Close()
+	}
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("stream without a token = %v, want Unauthenticated", err)
+	}
+
+	_, _, admin := connectAs(adminSecret)
+	created, err := admin.CreateToken(t.Context(), connect.NewRequest(&controlv1.CreateTokenRequest{
+		Name: "dashboard", Scopes: []controlv1.TokenScope{controlv1.TokenScope_TOKEN_SCOPE_READ},
+	}))
+	if err != nil || created.Msg.GetSecret() == "" || created.Msg.GetToken().GetSource() != controlv1.TokenSource_TOKEN_SOURCE_STORE {
+		t.Fatalf("CreateToken = %v, %v", created, err)
+	}
+	if _, err := admin.CreateToken(t.Context(), connect.NewRequest(&controlv1.CreateTokenRequest{
+		Name: "ops", Scopes: []controlv1.TokenScope{controlv1.TokenScope_TOKEN_SCOPE_READ},
+	})); connect.CodeOf(err) != connect.CodeAlreadyExists {
+		t.Fatalf("CreateToken with the file token's name = %v, want AlreadyExists", err)
+	}
+
+	snapshots, orders, dashboardTokens := connectAs(created.Msg.GetSecret())
+	// The read scope gets through to the handler, which finds nothing.
+	if _, err := snapshots.GetLastSnapshot(t.Context(), snapshotRequest()); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("read with a read token = %v, want NotFound from the handler", err)
+	}
+	if _, err := orders.PlaceOrder(t.Context(), connect.NewRequest(&controlv1.PlaceOrderRequest{})); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("PlaceOrder with a read token = %v, want PermissionDenied", err)
+	}
+	if _, err := dashboardTokens.ListTokens(t.Context(), connect.NewRequest(&controlv1.ListTokensRequest{})); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("ListTokens with a read token = %v, want PermissionDenied", err)
+	}
+
+	list, err := admin.ListTokens(t.Context(), connect.NewRequest(&controlv1.ListTokensRequest{}))
+	if err != nil || len(list.Msg.GetTokens()) != 2 || list.Msg.GetTokens()[0].GetName() != "ops" ||
+		list.Msg.GetTokens()[0].GetSource() != controlv1.TokenSource_TOKEN_SOURCE_FILE {
+		t.Fatalf("ListTokens = %v, %v", list, err)
+	}
+	if _, err := admin.RevokeToken(t.Context(), connect.NewRequest(&controlv1.RevokeTokenRequest{TokenId: created.Msg.GetToken().GetTokenId()})); err != nil {
+		t.Fatalf("RevokeToken: %v", err)
+	}
+	if _, err := snapshots.GetLastSnapshot(t.Context(), snapshotRequest()); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("read with a revoked token = %v, want Unauthenticated", err)
+	}
+
+	if got := testutil.ToFloat64(metrics.denied.WithLabelValues(controlv1connect.OrderServicePlaceOrderProcedure, "forbidden")); got != 1 {
+		t.Fatalf("forbidden PlaceOrder count = %v, want 1", got)
+	}
+}
+
+func TestBearerSecret(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"Bearer abc", "abc"},
+		{"bearer  abc ", "abc"},
+		{"Basic abc", ""},
+		{"abc", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("POST", "/", nil)
+		req.Header.Set("Authorization", tt.header)
+		if got := bearerSecret(req.Header); got != tt.want {
+			t.Errorf("bearerSecret(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
@@ -11,22 +11,43 @@ import (
 // NewHTTPClient returns an HTTP client and base URL for a control-plane
 // address in the same forms Listen accepts: "unix:///path" or host:port.
 // The base URL's host is a placeholder for unix sockets; the dialer ignores
-// it and connects to the socket.
-func NewHTTPClient(addr string) (*http.Client, string) {
+// it and connects to the socket. A non-empty token is sent as the bearer
+// token on every request (ADR-0012).
+func NewHTTPClient(addr, token string) (*http.Client, string) {
 	path, ok := strings.CutPrefix(addr, "unix://")
 	if !ok {
 		// A bare ":port" listener address would leave the URL host empty.
 		if strings.HasPrefix(addr, ":") {
 			addr = "localhost" + addr
 		}
-		return http.DefaultClient, "http://" + addr
+		if token == "" {
+			return http.DefaultClient, "http://" + addr
+		}
+		return &http.Client{Transport: bearerTransport{token: token, next: http.DefaultTransport}}, "http://" + addr
 	}
-	return &http.Client{Transport: &http.Transport{
+	var transport http.RoundTripper = &http.Transport{
 		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
 			if path == "" {
 				return nil, errors.New("empty unix socket path")
 			}
 			return (&net.Dialer{}).DialContext(ctx, "unix", path)
 		},
-	}}, "http://localhost"
+	}
+	if token != "" {
+		transport = bearerTransport{token: token, next: transport}
+	}
+	return &http.Client{Transport: transport}, "http://localhost"
+}
+
+// bearerTransport adds the Authorization header to each request.
+type bearerTransport struct {
+	token string
+	next  http.RoundTripper
+}
+
+func (t bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the caller's request.
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.next.RoundTrip(req)
 }
@@ -3,81 +3,249 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
 
 	"connectrpc.com/connect"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
 	"github.com/romanornr/delta-works/internal/bus"
 	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
 	"github.com/romanornr/delta-works/internal/events"
 	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
 )
 
 // streamBuffer absorbs bursts between the bus goroutine and the stream
 // writer. When it is full events are dropped, matching the bus's
-// at-most-once delivery contract.
+// at-most-once delivery contract, and the client is sent a gap.
 const streamBuffer = 64
 
-// EventServer serves control.v1.EventService from the event bus.
+// replayPage is how many outbox rows one replay query reads.
+const replayPage = 500
+
+// EventServer serves control.v1.EventService from the event bus, and from
+// the outbox for streams that resume.
 type EventServer struct {
 	bus     bus.Bus
+	outbox  ports.OutboxReader
 	log     log.Logger
 	metrics *Metrics
 }
 
 // NewEventServer builds the EventService handler.
-func NewEventServer(b bus.Bus, logger log.Logger, metrics *Metrics) *EventServer {
-	return &EventServer{bus: b, log: log.Component(logger, "api"), metrics: metrics}
+func NewEventServer(b bus.Bus, outbox ports.OutboxReader, logger log.Logger, metrics *Metrics) *EventServer {
+	return &EventServer{bus: b, outbox: outbox, log: log.Component(logger, "api"), metrics: metrics}
 }
 
 // StreamEvents forwards bus events matching the subject prefix until the
-// client disconnects or the server stops.
+// client disconnects or the server stops. A stream with resume_after
+// subscribes first and then replays the outbox after the cursor, so no
+// row falls between the replay and the live tail. Cursors count in publish
+// order, which both the replay and the tail follow, so a live event at or
+// below the last cursor sent was replayed already and is skipped.
 func (s *EventServer) StreamEvents(
 	ctx context.Context,
 	req *connect.Request[controlv1.StreamEventsRequest],
 	stream *connect.ServerStream[controlv1.StreamEventsResponse],
 ) error {
-	events := make(chan bus.Event, streamBuffer)
-	unsubscribe, err := s.bus.Subscribe(req.Msg.GetSubjectPrefix(), func(_ context.Context, e bus.Event) {
-		select {
-		case events <- e:
-		default:
-		}
-	})
+	st := &eventStream{
+		server: s,
+		send:   stream.Send,
+		prefix: req.Msg.GetSubjectPrefix(),
+		resume: req.Msg.ResumeAfter != nil,
+		last:   req.Msg.GetResumeAfter(),
+		events: make(chan bus.Event, streamBuffer),
+	}
+	unsubscribe, err := s.bus.Subscribe(st.prefix, st.offer)
 	if err != nil {
 		return connect.NewError(connect.CodeInternal, err)
 	}
 	defer unsubscribe()
 
+	if st.resume {
+		if err := st.checkRetention(ctx); err != nil {
+			return err
+		}
+		if err := st.replay(ctx); err != nil {
+			return err
+		}
+	}
+	return st.tail(ctx)
+}
+
+// eventStream is the state of one StreamEvents call. offer runs on the bus
+// goroutine; everything else runs on the handler's.
+type eventStream struct {
+	server *EventServer
+	send   func(*controlv1.StreamEventsResponse) error
+	prefix string
+	resume bool
+	last   int64 // cursor of the last outbox event sent, or the resume cursor
+	events chan bus.Event
+
+	// outboxDropped counts outbox events a resumed stream discarded, which
+	// a replay recovers; dropped counts the rest, which only a gap reports.
+	outboxDropped atomic.Uint64
+	dropped       atomic.Uint64
+	replayedTo    uint64 // outboxDropped as of the last complete replay
+	reported      uint64 // dropped as of the last gap sent
+}
+
+func (st *eventStream) offer(_ context.Context, e bus.Event) {
+	select {
+	case st.events <- e:
+	default:
+		if st.resume && e.PublishSeq != 0 {
+			st.outboxDropped.Add(1)
+			return
+		}
+		st.dropped.Add(1)
+	}
+}
+
+// checkRetention sends a gap when the resume cursor's row has been cleaned
+// up: whatever followed it may have gone too. Cursor 0 asks for whatever
+// is held, so it never gaps.
+func (st *eventStream) checkRetention(ctx context.Context) error {
+	if st.last == 0 {
+		return nil
+	}
+	held, err := st.server.outbox.HasRow(ctx, st.last)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if held {
+		return nil
+	}
+	return st.sendGap(controlv1.GapReason_GAP_REASON_RETENTION, 0)
+}
+
+// replay sends the outbox rows published after the last cursor sent,
+// page by page. It ends on a short page read with no outbox event dropped
+// meanwhile: a read waits for the batch the relay is publishing to
+// commit, so one that starts after a drop finds the dropped row.
+func (st *eventStream) replay(ctx context.Context) error {
+	for {
+		drops := st.outboxDropped.Load()
+		rows, err := st.server.outbox.ListAfter(ctx, st.last, st.prefix, replayPage)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		for _, m := range rows {
+			e := bus.Event{Subject: m.Subject, At: m.CreatedAt, Payload: json.RawMessage(m.Payload), OutboxID: m.ID, PublishSeq: m.PublishSeq}
+			if err := st.forward(e); err != nil {
+				return err
+			}
+		}
+		if len(rows) == replayPage {
+			continue
+		}
+		if st.outboxDropped.Load() == drops {
+			st.replayedTo = drops
+			return nil
+		}
+	}
+}
+
+// tail forwards live events, replaying after outbox drops and sending a
+// gap for the rest. Drops are only checked between events: the buffer is
+// full whenever one happens, so the loop runs again before it can block.
+func (st *eventStream) tail(ctx context.Context) error {
 	for {
+		if st.resume && st.outboxDropped.Load() != st.replayedTo {
+			if err := st.replay(ctx); err != nil {
+				return err
+			}
+		}
+		if n := st.dropped.Load(); n != st.reported {
+			if err := st.sendGap(controlv1.GapReason_GAP_REASON_SLOW_CLIENT, n-st.reported); err != nil {
+				return err
+			}
+			st.reported = n
+		}
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case e := <-events:
-			msg, ok := s.toProtoEvent(e)
-			if !ok {
+		case e := <-st.events:
+			if st.resume && e.PublishSeq != 0 && e.PublishSeq <= st.last {
 				continue
 			}
-			if err := stream.Send(msg); err != nil {
+			if err := st.forward(e); err != nil {
 				return err
 			}
 		}
 	}
 }
 
+func (st *eventStream) forward(e bus.Event) error {
+	if st.resume && e.PublishSeq != 0 {
+		st.last = e.PublishSeq
+	}
+	msg, ok := st.server.toProtoEvent(e)
+	if !ok {
+		return nil
+	}
+	return st.send(msg)
+}
+
+func (st *eventStream) sendGap(reason controlv1.GapReason, dropped uint64) error {
+	st.server.metrics.gaps.WithLabelValues(gapReasonLabel(reason)).Inc()
+	return st.send(&controlv1.StreamEventsResponse{Gap: &controlv1.Gap{Reason: reason, Dropped: dropped}})
+}
+
+func gapReasonLabel(r controlv1.GapReason) string {
+	switch r {
+	case controlv1.GapReason_GAP_REASON_SLOW_CLIENT:
+		return "slow_client"
+	case controlv1.GapReason_GAP_REASON_RETENTION:
+		return "retention"
+	default:
+		return "unspecified"
+	}
+}
+
 // toProtoEvent maps a bus event onto the wire envelope. Payload types
 // without a proto arm yet are skipped rather than sent untyped.
 func (s *EventServer) toProtoEvent(e bus.Event) (*controlv1.StreamEventsResponse, bool) {
+	event, err := protoEvent(e)
+	if err != nil {
+		s.recordMalformed(e.Subject, err)
+		return nil, false
+	}
+	return &controlv1.StreamEventsResponse{Event: event}, true
+}
+
+// EventJSON renders a bus event as the JSON encoding of its wire Event,
+// the same shape StreamEvents sends, for consumers outside the control
+// plane such as the sinks.
+func EventJSON(e bus.Event) ([]byte, error) {
+	event, err := protoEvent(e)
+	if err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(event)
+}
+
+// errMalformed is a payload that does not match its subject.
+var errMalformed = errors.New("malformed event payload")
+
+func protoEvent(e bus.Event) (*controlv1.Event, error) {
 	event := &controlv1.Event{
 		Subject: e.Subject,
 		At:      timestamppb.New(e.At),
+		Cursor:  e.PublishSeq,
 	}
 	switch e.Subject {
 	case events.SubjectOrderUpdated:
 		var payload events.OrderUpdatedPayload
-		if !s.decodeOrderPayload(e, &payload) {
-			return nil, false
+		if err := decodeOutboxPayload(e, &payload); err != nil {
+			return nil, err
 		}
 		event.Payload = &controlv1.Event_OrderUpdated{OrderUpdated: &controlv1.OrderUpdated{
 			ClientOrderId: string(payload.ClientOrderID), Venue: string(payload.Venue),
@@ -86,8 +254,8 @@ func (s *EventServer) toProtoEvent(e bus.Event) (*controlv1.StreamEventsResponse
 		}}
 	case events.SubjectOrderFilled:
 		var payload events.OrderFilledPayload
-		if !s.decodeOrderPayload(e, &payload) {
-			return nil, false
+		if err := decodeOutboxPayload(e, &payload); err != nil {
+			return nil, err
 		}
 		event.Payload = &controlv1.Event_OrderFilled{OrderFilled: &controlv1.OrderFilled{
 			ClientOrderId: string(payload.ClientOrderID), Venue: string(payload.Venue),
@@ -98,40 +266,80 @@ func (s *EventServer) toProtoEvent(e bus.Event) (*controlv1.StreamEventsResponse
 	case events.SubjectReconcileOrphan:
 		payload, ok := e.Payload.(events.ReconcileOrphanPayload)
 		if !ok {
-			s.recordMalformed(e.Subject)
-			return nil, false
+			return nil, fmt.Errorf("%w: %s carries %T", errMalformed, e.Subject, e.Payload)
 		}
 		event.Payload = &controlv1.Event_ReconcileDiff{ReconcileDiff: &controlv1.ReconcileDiff{
 			Kind:  controlv1.ReconcileDiffKind_RECONCILE_DIFF_KIND_ORPHAN,
 			Venue: string(payload.Venue), VenueOrderId: payload.VenueOrderID,
 			ClientOrderId: string(payload.ClientOrderID), Base: payload.Base, Quote: payload.Quote,
 		}}
+	case events.SubjectTickerUpdated:
+		payload, ok := e.Payload.(marketdata.Ticker)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s carries %T", errMalformed, e.Subject, e.Payload)
+		}
+		event.Payload = &controlv1.Event_TickerUpdated{TickerUpdated: toProtoTicker(payload)}
+	case events.SubjectInstrumentChanged:
+		var payload events.InstrumentChangedPayload
+		if err := decodeOutboxPayload(e, &payload); err != nil {
+			return nil, err
+		}
+		event.Payload = &controlv1.Event_InstrumentChanged{InstrumentChanged: &controlv1.InstrumentChanged{
+			Change: toProtoInstrumentChange(payload.Change),
+			Instrument: toProtoInstrument(instrument.Instrument{
+				Venue: payload.Venue, Type: payload.Type, Base: payload.Base, Quote: payload.Quote,
+				VenueSymbol: payload.VenueSymbol, Status: payload.Status,
+				Rules: instrument.Rules{
+					PriceIncrement: payload.PriceIncrement, QtyIncrement: payload.QtyIncrement,
+					MinQty: payload.MinQty, MinNotional: payload.MinNotional,
+				},
+			}),
+		}}
 	default:
 		payload, ok := e.Payload.(account.Snapshot)
 		if !ok {
-			s.recordMalformed(e.Subject)
-			return nil, false
+			return nil, fmt.Errorf("%w: %s carries %T", errMalformed, e.Subject, e.Payload)
 		}
 		event.Payload = &controlv1.Event_SnapshotTaken{SnapshotTaken: toProtoSnapshot(payload)}
 	}
-	return &controlv1.StreamEventsResponse{Event: event}, true
+	return event, nil
 }
 
-func (s *EventServer) decodeOrderPayload(e bus.Event, dst any) bool {
+func decodeOutboxPayload(e bus.Event, dst any) error {
 	raw, ok := e.Payload.(json.RawMessage)
-	if ok {
-		ok = json.Unmarshal(raw, dst) == nil
+	if !ok {
+		return fmt.Errorf("%w: %s carries %T", errMalformed, e.Subject, e.Payload)
 	}
-	if ok {
-		return true
+	if err := json.Unmarshal(raw, dst); err != nil {
+		return fmt.Errorf("%w: %s: %w", errMalformed, e.Subject, err)
 	}
-	s.recordMalformed(e.Subject)
-	return false
+	return nil
 }
 
-func (s *EventServer) recordMalformed(subject string) {
+func (s *EventServer) recordMalformed(subject string, err error) {
 	s.metrics.malformed.WithLabelValues(subject).Inc()
-	s.log.Error().Str("subject", subject).Msg("malformed event payload skipped")
+	s.log.Error().Err(err).Str("subject", subject).Msg("malformed event payload skipped")
+}
+
+func toProtoTicker(t marketdata.Ticker) *controlv1.Ticker {
+	return &controlv1.Ticker{
+		Venue: string(t.Instrument.Venue), Base: string(t.Instrument.Base), Quote: string(t.Instrument.Quote),
+		Bid: t.Bid.String(), Ask: t.Ask.String(), Last: t.Last.String(),
+		BidSize: t.BidSize.String(), AskSize: t.AskSize.String(),
+	}
+}
+
+func toProtoInstrumentChange(k instrument.ChangeKind) controlv1.InstrumentChangeKind {
+	switch k {
+	case instrument.ChangeListed:
+		return controlv1.InstrumentChangeKind_INSTRUMENT_CHANGE_KIND_LISTED
+	case instrument.ChangeUpdated:
+		return controlv1.InstrumentChangeKind_INSTRUMENT_CHANGE_KIND_UPDATED
+	case instrument.ChangeDelisted:
+		return controlv1.InstrumentChangeKind_INSTRUMENT_CHANGE_KIND_DELISTED
+	default:
+		return controlv1.InstrumentChangeKind_INSTRUMENT_CHANGE_KIND_UNSPECIFIED
+	}
 }
 
 func toProtoSnapshot(s account.Snapshot) *controlv1.AccountSnapshot {
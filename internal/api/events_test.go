@@ -3,10 +3,14 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,12 +18,14 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/proto"
 
 	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
 	"github.com/romanornr/delta-works/internal/api/gen/control/v1/controlv1connect"
 	"github.com/romanornr/delta-works/internal/bus"
 	"github.com/romanornr/delta-works/internal/domain/account"
 	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
 	"github.com/romanornr/delta-works/internal/domain/money"
 	domainorder "github.com/romanornr/delta-works/internal/domain/order"
 	"github.com/romanornr/delta-works/internal/events"
@@ -34,7 +40,48 @@ func testEventServer(t *testing.T, eventBus bus.Bus) *EventServer {
 	if err != nil {
 		t.Fatal(err)
 	}
-	return NewEventServer(eventBus, log.Nop(), metrics)
+	return NewEventServer(eventBus, &fakeOutbox{}, log.Nop(), metrics)
+}
+
+// fakeOutbox holds published outbox rows in publish order.
+type fakeOutbox struct {
+	rows []events.OutboxMessage
+}
+
+func (f *fakeOutbox) ListAfter(_ context.Context, after int64, prefix string, limit int) ([]events.OutboxMessage, error) {
+	var out []events.OutboxMessage
+	for _, m := range f.rows {
+		if m.PublishSeq > after && strings.HasPrefix(m.Subject, prefix) && len(out) < limit {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeOutbox) HasRow(_ context.Context, seq int64) (bool, error) {
+	return slices.ContainsFunc(f.rows, func(m events.OutboxMessage) bool { return m.PublishSeq == seq }), nil
+}
+
+// orderRows returns order.updated rows published at the given seqs. Their
+// IDs run against publish order, as rows that commit out of ID order do.
+func orderRows(t *testing.T, seqs ...int64) []events.OutboxMessage {
+	t.Helper()
+	rows := make([]events.OutboxMessage, 0, len(seqs))
+	for _, seq := range seqs {
+		payload, err := json.Marshal(events.OrderUpdatedPayload{ClientOrderID: domainorder.ClientOrderID(fmt.Sprintf("cid-%d", seq)), Status: domainorder.StatusOpen})
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows = append(rows, events.OutboxMessage{
+			ID: 1000 - seq, Subject: events.SubjectOrderUpdated, Payload: payload,
+			CreatedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC), PublishSeq: seq,
+		})
+	}
+	return rows
+}
+
+func outboxEvent(m events.OutboxMessage) bus.Event {
+	return bus.Event{Subject: m.Subject, At: m.CreatedAt, Payload: json.RawMessage(m.Payload), OutboxID: m.ID, PublishSeq: m.PublishSeq}
 }
 
 func testSnapshot() account.Snapshot {
@@ -82,7 +129,11 @@ func newTestServer(t *testing.T) (*http.Server, bus.Bus) {
 	t.Helper()
 	eventBus := bus.NewInProc()
 	t.Cleanup(eventBus.Close)
-	server := NewServer(NewSnapshotServer(&fakeCheckpointStore{err: ports.ErrNotFound}), testEventServer(t, eventBus), NewOrderServer(nil, nil))
+	server := NewServer(Handlers{
+		Snapshots: NewSnapshotServer(&fakeCheckpointStore{err: ports.ErrNotFound}),
+		Events:    testEventServer(t, eventBus),
+		Orders:    NewOrderServer(nil, nil),
+	}, nil)
 	return server, eventBus
 }
 
@@ -152,7 +203,7 @@ func TestShutdownInterruptsStream(t *testing.T) {
 	}
 	go func() { _ = srv.Serve(ln) }()
 
-	httpClient, baseURL := NewHTTPClient("unix://" + path)
+	httpClient, baseURL := NewHTTPClient("unix://"+path, "")
 	client := controlv1connect.NewEventServiceClient(httpClient, baseURL)
 
 	pumpEvents(t, eventBus, []bus.Event{
@@ -176,7 +227,136 @@ func TestShutdownInterruptsStream(t *testing.T) {
 	}
 }
 
-func TestOrderAndReconcileEventMapping(t *testing.T) {
+func TestStreamEventsResumes(t *testing.T) {
+	t.Parallel()
+	eventBus := bus.NewInProc()
+	t.Cleanup(eventBus.Close)
+	metrics, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := orderRows(t, 1, 2, 3, 5)
+	server := NewEventServer(eventBus, &fakeOutbox{rows: rows}, log.Nop(), metrics)
+	srv := httptest.NewServer(NewServer(Handlers{Events: server}, nil).Handler)
+	t.Cleanup(srv.Close)
+	client := controlv1connect.NewEventServiceClient(srv.Client(), srv.URL)
+
+	receive := func(stream *connect.ServerStreamForClient[controlv1.StreamEventsResponse]) *controlv1.StreamEventsResponse {
+		t.Helper()
+		if !stream.Receive() {
+			t.Fatalf("stream ended: %v", stream.Err())
+		}
+		return stream.Msg()
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+	stream, err := client.StreamEvents(ctx, connect.NewRequest(&controlv1.StreamEventsRequest{
+		SubjectPrefix: "order.", ResumeAfter: proto.Int64(1),
+	}))
+	if err != nil {
+		t.Fatalf("open stream: %v", err)
+	}
+	t.Cleanup(func() { _ = stream.Close() })
+	for _, want := range []int64{2, 3, 5} {
+		msg := receive(stream)
+		if msg.GetEvent().GetCursor() != want || msg.GetEvent().GetOrderUpdated().GetClientOrderId() != fmt.Sprintf("cid-%d", want) {
+			t.Fatalf("replayed %v, want cursor %d", msg, want)
+		}
+	}
+	// The replay is done, so the subscription is live: rows the replay
+	// already sent are skipped, and the next one published is forwarded.
+	for _, m := range slices.Concat(rows[2:], orderRows(t, 6)) {
+		if err := eventBus.Publish(ctx, outboxEvent(m)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if msg := receive(stream); msg.GetEvent().GetCursor() != 6 {
+		t.Fatalf("live event = %v, want cursor 6", msg)
+	}
+
+	// A cursor the outbox no longer holds is reported before the replay.
+	expired, err := client.StreamEvents(ctx, connect.NewRequest(&controlv1.StreamEventsRequest{ResumeAfter: proto.Int64(4)}))
+	if err != nil {
+		t.Fatalf("open stream: %v", err)
+	}
+	t.Cleanup(func() { _ = expired.Close() })
+	if gap := receive(expired).GetGap(); gap.GetReason() != controlv1.GapReason_GAP_REASON_RETENTION {
+		t.Fatalf("first message = %v, want a retention gap", gap)
+	}
+	if msg := receive(expired); msg.GetEvent().GetCursor() != 5 {
+		t.Fatalf("replayed %v, want cursor 5", msg)
+	}
+	if got := testutil.ToFloat64(metrics.gaps.WithLabelValues("retention")); got != 1 {
+		t.Fatalf("retention gaps = %v, want 1", got)
+	}
+}
+
+// collectStream runs tail with a send that stops it after n messages.
+func collectStream(t *testing.T, st *eventStream, n int) []*controlv1.StreamEventsResponse {
+	t.Helper()
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	var sent []*controlv1.StreamEventsResponse
+	st.send = func(msg *controlv1.StreamEventsResponse) error {
+		sent = append(sent, msg)
+		if len(sent) == n {
+			cancel()
+		}
+		return nil
+	}
+	if err := st.tail(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("tail = %v", err)
+	}
+	return sent
+}
+
+func TestStreamGapsOnDrops(t *testing.T) {
+	t.Parallel()
+	server := testEventServer(t, nil)
+	st := &eventStream{server: server, events: make(chan bus.Event, streamBuffer)}
+	ticker := bus.Event{Subject: events.SubjectTickerUpdated, Payload: marketdata.Ticker{}}
+	for range streamBuffer + 2 {
+		st.offer(t.Context(), ticker)
+	}
+	sent := collectStream(t, st, 1+streamBuffer)
+	if gap := sent[0].GetGap(); gap.GetReason() != controlv1.GapReason_GAP_REASON_SLOW_CLIENT || gap.GetDropped() != 2 {
+		t.Fatalf("first message = %v, want a gap of 2", sent[0])
+	}
+	if got := testutil.ToFloat64(server.metrics.gaps.WithLabelValues("slow_client")); got != 1 {
+		t.Fatalf("slow client gaps = %v, want 1", got)
+	}
+}
+
+func TestResumedStreamRefillsDrops(t *testing.T) {
+	t.Parallel()
+	server := testEventServer(t, nil)
+	var seqs []int64
+	for seq := range int64(streamBuffer + 6) {
+		seqs = append(seqs, seq+1)
+	}
+	rows := orderRows(t, seqs...)
+	server.outbox = &fakeOutbox{rows: rows}
+	st := &eventStream{server: server, resume: true, events: make(chan bus.Event, streamBuffer)}
+	for _, m := range rows {
+		st.offer(t.Context(), outboxEvent(m))
+	}
+	if st.outboxDropped.Load() != 6 || st.dropped.Load() != 0 {
+		t.Fatalf("dropped %d outbox, %d other; want 6, 0", st.outboxDropped.Load(), st.dropped.Load())
+	}
+	// The refill sends every row once, and the buffered copies are skipped.
+	sent := collectStream(t, st, len(rows))
+	for i, msg := range sent {
+		if msg.GetEvent().GetCursor() != int64(i+1) {
+			t.Fatalf("message %d = %v, want cursor %d", i, msg, i+1)
+		}
+	}
+	if len(sent) != len(rows) {
+		t.Fatalf("sent %d messages, want %d", len(sent), len(rows))
+	}
+}
+
+func TestTypedEventMapping(t *testing.T) {
 	t.Parallel()
 	eventBus := bus.NewInProc()
 	t.Cleanup(eventBus.Close)
@@ -184,6 +364,10 @@ func TestOrderAndReconcileEventMapping(t *testing.T) {
 	at := time.Date(2026, 7, 12, 12, 0, 0, 0, time.UTC)
 	updated, _ := json.Marshal(events.OrderUpdatedPayload{ClientOrderID: "cid", Venue: "bybit", Base: "BTC", Quote: "USDT", Status: domainorder.StatusOpen, FilledQty: decimal.RequireFromString("0.4")})
 	filled, _ := json.Marshal(events.OrderFilledPayload{ClientOrderID: "cid", Venue: "bybit", Base: "BTC", Quote: "USDT", Status: domainorder.StatusPartiallyFilled, FilledQty: decimal.RequireFromString("0.4"), Qty: decimal.RequireFromString("0.4"), Price: decimal.RequireFromString("50000")})
+	changed, _ := json.Marshal(events.InstrumentChangedPayload{
+		Venue: "bybit", Type: instrument.TypeSpot, Base: "BTC", Quote: "USDT", VenueSymbol: "BTCUSDT",
+		Status: instrument.StatusHalted, Change: instrument.ChangeUpdated, PriceIncrement: decimal.RequireFromString("0.1"),
+	})
 	tests := []struct {
 		name    string
 		event   bus.Event
@@ -202,6 +386,20 @@ func TestOrderAndReconcileEventMapping(t *testing.T) {
 				payload.GetVenue() == "bybit" && payload.GetVenueOrderId() == "v-1" &&
 				payload.GetClientOrderId() == "cid" && payload.GetBase() == "BTC" && payload.GetQuote() == "USDT"
 		}},
+		{"ticker", bus.Event{Subject: events.SubjectTickerUpdated, At: at, Payload: marketdata.Ticker{
+			Instrument: instrument.Instrument{Venue: "bybit", Type: instrument.TypeSpot, Base: "BTC", Quote: "USDT"},
+			Bid:        decimal.RequireFromString("49999.5"), Ask: decimal.RequireFromString("50000.5"), Last: decimal.NewFromInt(50000),
+		}}, func(e *controlv1.Event) bool {
+			payload := e.GetTickerUpdated()
+			return payload.GetVenue() == "bybit" && payload.GetBase() == "BTC" && payload.GetBid() == "49999.5" &&
+				payload.GetAsk() == "50000.5" && payload.GetLast() == "50000"
+		}},
+		{"instrument", bus.Event{Subject: events.SubjectInstrumentChanged, At: at, Payload: json.RawMessage(changed)}, func(e *controlv1.Event) bool {
+			payload := e.GetInstrumentChanged()
+			return payload.GetChange() == controlv1.InstrumentChangeKind_INSTRUMENT_CHANGE_KIND_UPDATED &&
+				payload.GetInstrument().GetVenueSymbol() == "BTCUSDT" && payload.GetInstrument().GetPriceIncrement() == "0.1" &&
+				payload.GetInstrument().GetStatus() == controlv1.InstrumentStatus_INSTRUMENT_STATUS_HALTED
+		}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
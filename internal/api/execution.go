@@ -0,0 +1,269 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/domain/execution"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	domain "github.com/romanornr/delta-works/internal/domain/order"
+	executionservice "github.com/romanornr/delta-works/internal/service/execution"
+)
+
+const defaultParentLimit int32 = 50
+
+// ExecutionServer serves control.v1.ExecutionService.
+type ExecutionServer struct {
+	executions *executionservice.Service
+}
+
+// NewExecutionServer builds the ExecutionService handler.
+func NewExecutionServer(service *executionservice.Service) *ExecutionServer {
+	return &ExecutionServer{executions: service}
+}
+
+// PlaceTWAP stores a TWAP parent and places its first slice.
+func (s *ExecutionServer) PlaceTWAP(ctx context.Context, req *connect.Request[controlv1.PlaceTWAPRequest]) (*connect.Response[controlv1.PlaceTWAPResponse], error) {
+	p, err := fromProtoTWAPRequest(req.Msg)
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	view, err := s.executions.PlaceTWAP(ctx, p)
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	return connect.NewResponse(&controlv1.PlaceTWAPResponse{Parent: toProtoParentView(view)}), nil
+}
+
+// PlaceIceberg stores an iceberg parent and shows its first child.
+func (s *ExecutionServer) PlaceIceberg(ctx context.Context, req *connect.Request[controlv1.PlaceIcebergRequest]) (*connect.Response[controlv1.PlaceIcebergResponse], error) {
+	p, err := fromProtoIcebergRequest(req.Msg)
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	view, err := s.executions.PlaceIceberg(ctx, p)
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	return connect.NewResponse(&controlv1.PlaceIcebergResponse{Parent: toProtoParentView(view)}), nil
+}
+
+// PlaceVWAP stores a VWAP parent and places its first slice.
+func (s *ExecutionServer) PlaceVWAP(ctx context.Context, req *connect.Request[controlv1.PlaceVWAPRequest]) (*connect.Response[controlv1.PlaceVWAPResponse], error) {
+	p, profile, err := fromProtoVWAPRequest(req.Msg)
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	view, err := s.executions.PlaceVWAP(ctx, p, profile)
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	return connect.NewResponse(&controlv1.PlaceVWAPResponse{Parent: toProtoParentView(view)}), nil
+}
+
+// PauseParentOrder stops the parent placing slices.
+func (s *ExecutionServer) PauseParentOrder(ctx context.Context, req *connect.Request[controlv1.PauseParentOrderRequest]) (*connect.Response[controlv1.PauseParentOrderResponse], error) {
+	view, err := s.executions.Pause(ctx, domain.ParentID(req.Msg.GetParentId()))
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	return connect.NewResponse(&controlv1.PauseParentOrderResponse{Parent: toProtoParentView(view)}), nil
+}
+
+// ResumeParentOrder sets a paused parent running.
+func (s *ExecutionServer) ResumeParentOrder(ctx context.Context, req *connect.Request[controlv1.ResumeParentOrderRequest]) (*connect.Response[controlv1.ResumeParentOrderResponse], error) {
+	view, err := s.executions.Resume(ctx, domain.ParentID(req.Msg.GetParentId()))
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	return connect.NewResponse(&controlv1.ResumeParentOrderResponse{Parent: toProtoParentView(view)}), nil
+}
+
+// CancelParentOrder cancels the parent's working children and the parent.
+func (s *ExecutionServer) CancelParentOrder(ctx context.Context, req *connect.Request[controlv1.CancelParentOrderRequest]) (*connect.Response[controlv1.CancelParentOrderResponse], error) {
+	view, err := s.executions.Cancel(ctx, domain.ParentID(req.Msg.GetParentId()))
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	return connect.NewResponse(&controlv1.CancelParentOrderResponse{Parent: toProtoParentView(view)}), nil
+}
+
+// GetParentOrder returns the parent with its children and progress.
+func (s *ExecutionServer) GetParentOrder(ctx context.Context, req *connect.Request[controlv1.GetParentOrderRequest]) (*connect.Response[controlv1.GetParentOrderResponse], error) {
+	view, err := s.executions.Get(ctx, domain.ParentID(req.Msg.GetParentId()))
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	return connect.NewResponse(&controlv1.GetParentOrderResponse{Parent: toProtoParentView(view)}), nil
+}
+
+// ListParentOrders returns the newest parents.
+func (s *ExecutionServer) ListParentOrders(ctx context.Context, req *connect.Request[controlv1.ListParentOrdersRequest]) (*connect.Response[controlv1.ListParentOrdersResponse], error) {
+	limit := req.Msg.GetLimit()
+	if limit == 0 {
+		limit = defaultParentLimit
+	}
+	parents, err := s.executions.List(ctx, instrument.NewVenueID(req.Msg.GetVenue()), req.Msg.GetLiveOnly(), limit)
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	response := &controlv1.ListParentOrdersResponse{Parents: make([]*controlv1.ParentOrder, 0, len(parents))}
+	for _, p := range parents {
+		response.Parents = append(response.Parents, toProtoParent(p))
+	}
+	return connect.NewResponse(response), nil
+}
+
+// fromProtoTWAPRequest spreads the request's duration evenly over its
+// slices.
+func fromProtoTWAPRequest(msg *controlv1.PlaceTWAPRequest) (execution.Parent, error) {
+	qty, err := decimal.NewFromString(msg.GetQty())
+	if err != nil {
+		return execution.Parent{}, fmt.Errorf("%w: qty", errInvalidArgument)
+	}
+	var limit decimal.Decimal
+	if msg.GetLimitPrice() != "" {
+		if limit, err = decimal.NewFromString(msg.GetLimitPrice()); err != nil {
+			return execution.Parent{}, fmt.Errorf("%w: limit_price", errInvalidArgument)
+		}
+	}
+	slices := int(msg.GetSlices())
+	return execution.Parent{
+		ID: domain.ParentID(msg.GetParentId()), Algo: execution.AlgoTWAP, BotID: "manual",
+		Instrument: instrument.Instrument{
+			Venue: instrument.NewVenueID(msg.GetVenue()), Type: instrument.TypeSpot,
+			Base: money.NewCurrency(msg.GetBase()), Quote: money.NewCurrency(msg.GetQuote()),
+		},
+		Side: fromProtoSide(msg.GetSide()), Qty: qty, LimitPrice: limit,
+		Slices: slices, Interval: msg.GetDuration().AsDuration() / time.Duration(max(slices, 1)), Jitter: msg.GetJitter(),
+	}, nil
+}
+
+// fromProtoVWAPRequest schedules as fromProtoTWAPRequest does and returns
+// the request's volume profile beside the parent.
+func fromProtoVWAPRequest(msg *controlv1.PlaceVWAPRequest) (execution.Parent, execution.VolumeProfile, error) {
+	p, err := fromProtoTWAPRequest(&controlv1.PlaceTWAPRequest{
+		Venue: msg.GetVenue(), Base: msg.GetBase(), Quote: msg.GetQuote(), Side: msg.GetSide(), Qty: msg.GetQty(),
+		Duration: msg.GetDuration(), Slices: msg.GetSlices(), LimitPrice: msg.GetLimitPrice(), ParentId: msg.GetParentId(),
+	})
+	if err != nil {
+		return execution.Parent{}, nil, err
+	}
+	p.Algo = execution.AlgoVWAP
+	var profile execution.VolumeProfile
+	for _, raw := range msg.GetProfile() {
+		volume, err := decimal.NewFromString(raw)
+		if err != nil {
+			return execution.Parent{}, nil, fmt.Errorf("%w: profile", errInvalidArgument)
+		}
+		profile = append(profile, volume)
+	}
+	return p, profile, nil
+}
+
+func fromProtoIcebergRequest(msg *controlv1.PlaceIcebergRequest) (execution.Parent, error) {
+	var terms [4]decimal.Decimal
+	for i, field := range []struct{ name, value string }{
+		{"qty", msg.GetQty()}, {"limit_price", msg.GetLimitPrice()}, {"display_qty", msg.GetDisplayQty()},
+		{"price_jitter", msg.GetPriceJitter()},
+	} {
+		if field.value == "" {
+			continue
+		}
+		value, err := decimal.NewFromString(field.value)
+		if err != nil {
+			return execution.Parent{}, fmt.Errorf("%w: %s", errInvalidArgument, field.name)
+		}
+		terms[i] = value
+	}
+	return execution.Parent{
+		ID: domain.ParentID(msg.GetParentId()), Algo: execution.AlgoIceberg, BotID: "manual",
+		Instrument: instrument.Instrument{
+			Venue: instrument.NewVenueID(msg.GetVenue()), Type: instrument.TypeSpot,
+			Base: money.NewCurrency(msg.GetBase()), Quote: money.NewCurrency(msg.GetQuote()),
+		},
+		Side: fromProtoSide(msg.GetSide()), Qty: terms[0], LimitPrice: terms[1], DisplayQty: terms[2], PriceJitter: terms[3],
+	}, nil
+}
+
+// toProtoParentView is the parent with its children and the progress they
+// add up to.
+func toProtoParentView(view executionservice.View) *controlv1.ParentOrder {
+	msg := toProtoParent(view.Parent)
+	msg.FilledQty, msg.AvgFillPrice = view.Progress.FilledQty.String(), view.Progress.AvgFillPrice.String()
+	msg.WorkingQty = view.Progress.Working.String()
+	if view.Benchmark.IsPositive() {
+		msg.BenchmarkPrice, msg.SlippageBps = view.Benchmark.String(), view.SlippageBps.String()
+	}
+	for _, child := range view.Children {
+		msg.Children = append(msg.Children, toProtoOrder(child))
+	}
+	return msg
+}
+
+func toProtoParent(p execution.Parent) *controlv1.ParentOrder {
+	msg := &controlv1.ParentOrder{
+		ParentId: string(p.ID), Algo: toProtoExecutionAlgo(p.Algo), Status: toProtoParentStatus(p.Status),
+		Venue: string(p.Instrument.Venue), Base: string(p.Instrument.Base), Quote: string(p.Instrument.Quote),
+		BotId: p.BotID, Side: toProtoSide(p.Side), Qty: p.Qty.String(),
+		Slices:     int32(p.Slices),     //nolint:gosec // bounded by execution.MaxSlices
+		SlicesSent: int32(p.SlicesSent), //nolint:gosec // bounded by execution.MaxSlices
+		Interval:   durationpb.New(p.Interval), Jitter: p.Jitter, Reason: p.Reason,
+		CreatedAt: timestamppb.New(p.CreatedAt), UpdatedAt: timestamppb.New(p.UpdatedAt),
+	}
+	if p.LimitPrice.IsPositive() {
+		msg.LimitPrice = p.LimitPrice.String()
+	}
+	if p.DisplayQty.IsPositive() {
+		msg.DisplayQty = p.DisplayQty.String()
+	}
+	if p.PriceJitter.IsPositive() {
+		msg.PriceJitter = p.PriceJitter.String()
+	}
+	for _, w := range p.Curve {
+		msg.Curve = append(msg.Curve, w.String())
+	}
+	if p.Benchmark.IsPositive() {
+		msg.BenchmarkPrice = p.Benchmark.String()
+	}
+	if p.Algo.Scheduled() && p.Status == execution.StatusRunning && p.SlicesSent < p.Slices {
+		msg.NextSliceAt = timestamppb.New(p.NextAt)
+	}
+	return msg
+}
+
+func toProtoExecutionAlgo(algo execution.Algo) controlv1.ExecutionAlgo {
+	switch algo {
+	case execution.AlgoTWAP:
+		return controlv1.ExecutionAlgo_EXECUTION_ALGO_TWAP
+	case execution.AlgoIceberg:
+		return controlv1.ExecutionAlgo_EXECUTION_ALGO_ICEBERG
+	case execution.AlgoVWAP:
+		return controlv1.ExecutionAlgo_EXECUTION_ALGO_VWAP
+	default:
+		return controlv1.ExecutionAlgo_EXECUTION_ALGO_UNSPECIFIED
+	}
+}
+
+func toProtoParentStatus(status execution.Status) controlv1.ParentOrderStatus {
+	switch status {
+	case execution.StatusRunning:
+		return controlv1.ParentOrderStatus_PARENT_ORDER_STATUS_RUNNING
+	case execution.StatusPaused:
+		return controlv1.ParentOrderStatus_PARENT_ORDER_STATUS_PAUSED
+	case execution.StatusCompleted:
+		return controlv1.ParentOrderStatus_PARENT_ORDER_STATUS_COMPLETED
+	case execution.StatusCanceled:
+		return controlv1.ParentOrderStatus_PARENT_ORDER_STATUS_CANCELED
+	default:
+		return controlv1.ParentOrderStatus_PARENT_ORDER_STATUS_UNSPECIFIED
+	}
+}
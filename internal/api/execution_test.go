@@ -0,0 +1,273 @@
+package api
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/api/gen/control/v1/controlv1connect"
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/execution"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	domain "github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+	executionservice "github.com/romanornr/delta-works/internal/service/execution"
+	orderservice "github.com/romanornr/delta-works/internal/service/order"
+)
+
+// fakeParents is a parent store and an order service in one: placed
+// children rest open, canceled ones end canceled.
+type fakeParents struct {
+	mu      sync.Mutex
+	parents []execution.Parent
+	orders  []domain.Record
+}
+
+func (f *fakeParents) CreateParent(_ context.Context, p execution.Parent) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.parents = append(f.parents, p)
+	return true, nil
+}
+
+func (f *fakeParents) GetParent(_ context.Context, id domain.ParentID) (execution.Parent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, p := range f.parents {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return execution.Parent{}, ports.ErrNotFound
+}
+
+func (f *fakeParents) ListParents(_ context.Context, venue instrument.VenueID, liveOnly bool, _ int32) ([]execution.Parent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []execution.Parent
+	for _, p := range f.parents {
+		if (venue == "" || p.Instrument.Venue == venue) && (!liveOnly || !p.Status.Final()) {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeParents) ListLiveParents(ctx context.Context) ([]execution.Parent, error) {
+	return f.ListParents(ctx, "", true, 0)
+}
+
+func (f *fakeParents) UpdateParent(_ context.Context, p execution.Parent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.parents {
+		if f.parents[i].ID == p.ID {
+			f.parents[i] = p
+			return nil
+		}
+	}
+	return ports.ErrNotFound
+}
+
+func (f *fakeParents) ListChildren(_ context.Context, id domain.ParentID) ([]domain.Record, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []domain.Record
+	for _, rec := range f.orders {
+		if rec.ParentID == id {
+			out = append(out, rec)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeParents) GetOrder(_ context.Context, id domain.ClientOrderID) (domain.Record, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, rec := range f.orders {
+		if rec.ClientOrderID == id {
+			return rec, nil
+		}
+	}
+	return domain.Record{}, ports.ErrNotFound
+}
+
+func (f *fakeParents) Place(_ context.Context, req domain.Request) (orderservice.PlaceResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.orders = append(f.orders, domain.Record{
+		ClientOrderID: req.ClientOrderID, ParentID: req.ParentID, Side: req.Side, Type: req.Type, Price: req.Price, Qty: req.Qty,
+		TimeInForce: req.TimeInForce, Status: domain.StatusOpen,
+	})
+	return orderservice.PlaceResult{ClientOrderID: req.ClientOrderID, Status: domain.StatusOpen}, nil
+}
+
+func (f *fakeParents) Cancel(_ context.Context, id domain.ClientOrderID) (domain.Status, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.orders {
+		if f.orders[i].ClientOrderID == id {
+			f.orders[i].Status = domain.StatusCanceled
+		}
+	}
+	return domain.StatusCanceled, nil
+}
+
+func newExecutionClient(t *testing.T) controlv1connect.ExecutionServiceClient {
+	t.Helper()
+	fake := &fakeParents{}
+	metrics, err := executionservice.NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventBus := bus.NewInProc()
+	t.Cleanup(eventBus.Close)
+	service := executionservice.New(fake, fake, nil, nil, eventBus, clockwork.NewFakeClock(), log.Nop(), time.Second, time.Hour, metrics)
+	srv := httptest.NewServer(NewServer(Handlers{Events: testEventServer(t, eventBus), Executions: NewExecutionServer(service)}, nil).Handler)
+	t.Cleanup(srv.Close)
+	return controlv1connect.NewExecutionServiceClient(srv.Client(), srv.URL)
+}
+
+func TestExecutionService(t *testing.T) {
+	t.Parallel()
+	client := newExecutionClient(t)
+
+	placed, err := client.PlaceTWAP(t.Context(), connect.NewRequest(&controlv1.PlaceTWAPRequest{
+		Venue: "ByBit", Base: "btc", Quote: "usdt", Side: controlv1.Side_SIDE_BUY, Qty: "4",
+		Duration: durationpb.New(time.Hour), Slices: 4, LimitPrice: "100",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := placed.Msg.GetParent()
+	if p.GetStatus() != controlv1.ParentOrderStatus_PARENT_ORDER_STATUS_RUNNING || p.GetVenue() != "bybit" ||
+		p.GetInterval().AsDuration() != 15*time.Minute || p.GetSlicesSent() != 1 || p.GetWorkingQty() != "1" {
+		t.Fatalf("parent = %v", p)
+	}
+	if children := p.GetChildren(); len(children) != 1 || children[0].GetParentId() != p.GetParentId() ||
+		children[0].GetType() != controlv1.OrderType_ORDER_TYPE_LIMIT {
+		t.Fatalf("children = %v", children)
+	}
+
+	paused, err := client.PauseParentOrder(t.Context(), connect.NewRequest(&controlv1.PauseParentOrderRequest{ParentId: p.GetParentId()}))
+	if err != nil || paused.Msg.GetParent().GetStatus() != controlv1.ParentOrderStatus_PARENT_ORDER_STATUS_PAUSED ||
+		paused.Msg.GetParent().GetNextSliceAt() != nil {
+		t.Fatalf("PauseParentOrder = %v, %v", paused, err)
+	}
+	canceled, err := client.CancelParentOrder(t.Context(), connect.NewRequest(&controlv1.CancelParentOrderRequest{ParentId: p.GetParentId()}))
+	if err != nil || canceled.Msg.GetParent().GetStatus() != controlv1.ParentOrderStatus_PARENT_ORDER_STATUS_CANCELED ||
+		canceled.Msg.GetParent().GetChildren()[0].GetStatus() != controlv1.OrderStatus_ORDER_STATUS_CANCELED {
+		t.Fatalf("CancelParentOrder = %v, %v", canceled, err)
+	}
+	_, err = client.ResumeParentOrder(t.Context(), connect.NewRequest(&controlv1.ResumeParentOrderRequest{ParentId: p.GetParentId()}))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("resume after cancel = %v, want FailedPrecondition", err)
+	}
+	list, err := client.ListParentOrders(t.Context(), connect.NewRequest(&controlv1.ListParentOrdersRequest{LiveOnly: true}))
+	if err != nil || len(list.Msg.GetParents()) != 0 {
+		t.Fatalf("live parents = %v, %v", list, err)
+	}
+	_, err = client.GetParentOrder(t.Context(), connect.NewRequest(&controlv1.GetParentOrderRequest{ParentId: "01J00000000000000000000009"}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("unknown parent = %v, want NotFound", err)
+	}
+}
+
+func TestPlaceTWAPValidation(t *testing.T) {
+	t.Parallel()
+	client := newExecutionClient(t)
+	tests := []struct {
+		name string
+		edit func(*controlv1.PlaceTWAPRequest)
+	}{
+		{"no duration", func(r *controlv1.PlaceTWAPRequest) { r.Duration = nil }},
+		{"slices closer than a second", func(r *controlv1.PlaceTWAPRequest) { r.Duration = durationpb.New(time.Second) }},
+		{"too many slices", func(r *controlv1.PlaceTWAPRequest) { r.Slices = 1001 }},
+		{"jitter too wide", func(r *controlv1.PlaceTWAPRequest) { r.Jitter = 0.9 }},
+		{"malformed limit price", func(r *controlv1.PlaceTWAPRequest) { r.LimitPrice = "-1" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := &controlv1.PlaceTWAPRequest{
+				Venue: "bybit", Base: "BTC", Quote: "USDT", Side: controlv1.Side_SIDE_SELL, Qty: "1",
+				Duration: durationpb.New(time.Minute), Slices: 4,
+			}
+			tt.edit(req)
+			_, err := client.PlaceTWAP(t.Context(), connect.NewRequest(req))
+			if connect.CodeOf(err) != connect.CodeInvalidArgument {
+				t.Fatalf("err = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestPlaceIceberg(t *testing.T) {
+	t.Parallel()
+	client := newExecutionClient(t)
+	placed, err := client.PlaceIceberg(t.Context(), connect.NewRequest(&controlv1.PlaceIcebergRequest{
+		Venue: "bybit", Base: "BTC", Quote: "USDT", Side: controlv1.Side_SIDE_SELL, Qty: "3", LimitPrice: "100", DisplayQty: "1",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := placed.Msg.GetParent()
+	if p.GetAlgo() != controlv1.ExecutionAlgo_EXECUTION_ALGO_ICEBERG || p.GetDisplayQty() != "1" || p.GetPriceJitter() != "" ||
+		p.GetNextSliceAt() != nil || p.GetWorkingQty() != "1" {
+		t.Fatalf("parent = %v", p)
+	}
+	if children := p.GetChildren(); len(children) != 1 || children[0].GetTimeInForce() != controlv1.TimeInForce_TIME_IN_FORCE_GTC {
+		t.Fatalf("children = %v, want one resting child", children)
+	}
+
+	for name, req := range map[string]*controlv1.PlaceIcebergRequest{
+		"no display size":         {Venue: "bybit", Base: "BTC", Quote: "USDT", Side: controlv1.Side_SIDE_SELL, Qty: "3", LimitPrice: "100"},
+		"display above quantity":  {Venue: "bybit", Base: "BTC", Quote: "USDT", Side: controlv1.Side_SIDE_SELL, Qty: "3", LimitPrice: "100", DisplayQty: "4"},
+		"price jitter past limit": {Venue: "bybit", Base: "BTC", Quote: "USDT", Side: controlv1.Side_SIDE_SELL, Qty: "3", LimitPrice: "100", DisplayQty: "1", PriceJitter: "100"},
+	} {
+		if _, err := client.PlaceIceberg(t.Context(), connect.NewRequest(req)); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Fatalf("%s: err = %v, want InvalidArgument", name, err)
+		}
+	}
+}
+
+func TestPlaceVWAP(t *testing.T) {
+	t.Parallel()
+	client := newExecutionClient(t)
+	// Hourly buckets, all trading alike: the curve is even.
+	profile := make([]string, 24)
+	for i := range profile {
+		profile[i] = "5"
+	}
+	placed, err := client.PlaceVWAP(t.Context(), connect.NewRequest(&controlv1.PlaceVWAPRequest{
+		Venue: "bybit", Base: "BTC", Quote: "USDT", Side: controlv1.Side_SIDE_BUY, Qty: "4",
+		Duration: durationpb.New(time.Hour), Slices: 4, Profile: profile,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := placed.Msg.GetParent()
+	if p.GetAlgo() != controlv1.ExecutionAlgo_EXECUTION_ALGO_VWAP || len(p.GetCurve()) != 4 || p.GetCurve()[0] != "0.25" ||
+		p.GetNextSliceAt() == nil || p.GetWorkingQty() != "1" || p.GetBenchmarkPrice() != "" {
+		t.Fatalf("parent = %v", p)
+	}
+
+	for name, req := range map[string]*controlv1.PlaceVWAPRequest{
+		"no profile and no trade history": {Venue: "bybit", Base: "BTC", Quote: "USDT", Side: controlv1.Side_SIDE_BUY, Qty: "4", Duration: durationpb.New(time.Hour), Slices: 4},
+		"profile not splitting the day":   {Venue: "bybit", Base: "BTC", Quote: "USDT", Side: controlv1.Side_SIDE_BUY, Qty: "4", Duration: durationpb.New(time.Hour), Slices: 4, Profile: []string{"1", "2", "3", "4", "5", "6", "7"}},
+		"negative volume":                 {Venue: "bybit", Base: "BTC", Quote: "USDT", Side: controlv1.Side_SIDE_BUY, Qty: "4", Duration: durationpb.New(time.Hour), Slices: 4, Profile: []string{"-1"}},
+	} {
+		if _, err := client.PlaceVWAP(t.Context(), connect.NewRequest(req)); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Fatalf("%s: err = %v, want InvalidArgument", name, err)
+		}
+	}
+}
@@ -41,9 +41,12 @@ const (
 // EventServiceClient is a client for the control.v1.EventService service.
 type EventServiceClient interface {
 	// StreamEvents delivers bus events whose subject starts with the given
-	// prefix; an empty prefix subscribes to everything. Delivery is
-	// at-most-once, matching the bus contract: a slow client drops events
-	// rather than stalling the daemon.
+	// prefix; an empty prefix subscribes to everything. Without resume_after
+	// delivery is live and at-most-once, matching the bus contract: a slow
+	// client drops events rather than stalling the daemon, and is told so
+	// with a gap. With resume_after the outbox-backed subjects are replayed
+	// from Postgres first and refilled from it after a drop, so they arrive
+	// at least once; hint subjects stay live-only.
 	StreamEvents(context.Context, *connect.Request[v1.StreamEventsRequest]) (*connect.ServerStreamForClient[v1.StreamEventsResponse], error)
 }
 
@@ -80,9 +83,12 @@ func (c *eventServiceClient) StreamEvents(ctx context.Context, req *connect.Requ
 // EventServiceHandler is an implementation of the control.v1.EventService service.
 type EventServiceHandler interface {
 	// StreamEvents delivers bus events whose subject starts with the given
-	// prefix; an empty prefix subscribes to everything. Delivery is
-	// at-most-once, matching the bus contract: a slow client drops events
-	// rather than stalling the daemon.
+	// prefix; an empty prefix subscribes to everything. Without resume_after
+	// delivery is live and at-most-once, matching the bus contract: a slow
+	// client drops events rather than stalling the daemon, and is told so
+	// with a gap. With resume_after the outbox-backed subjects are replayed
+	// from Postgres first and refilled from it after a drop, so they arrive
+	// at least once; hint subjects stay live-only.
 	StreamEvents(context.Context, *connect.Request[v1.StreamEventsRequest], *connect.ServerStream[v1.StreamEventsResponse]) error
 }
 
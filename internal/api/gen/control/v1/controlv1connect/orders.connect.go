@@ -38,6 +38,9 @@ const (
 	// OrderServiceCancelOrderProcedure is the fully-qualified name of the OrderService's CancelOrder
 	// RPC.
 	OrderServiceCancelOrderProcedure = "/control.v1.OrderService/CancelOrder"
+	// OrderServiceReplaceOrderProcedure is the fully-qualified name of the OrderService's ReplaceOrder
+	// RPC.
+	OrderServiceReplaceOrderProcedure = "/control.v1.OrderService/ReplaceOrder"
 	// OrderServiceListOrdersProcedure is the fully-qualified name of the OrderService's ListOrders RPC.
 	OrderServiceListOrdersProcedure = "/control.v1.OrderService/ListOrders"
 )
@@ -46,6 +49,11 @@ const (
 type OrderServiceClient interface {
 	PlaceOrder(context.Context, *connect.Request[v1.PlaceOrderRequest]) (*connect.Response[v1.PlaceOrderResponse], error)
 	CancelOrder(context.Context, *connect.Request[v1.CancelOrderRequest]) (*connect.Response[v1.CancelOrderResponse], error)
+	// ReplaceOrder gives an active limit order a new price and quantity. A
+	// venue that amends natively keeps the order and its IDs; elsewhere the
+	// order is canceled and, once terminal, a new one is placed for what it
+	// left unfilled. The two orders are never working together.
+	ReplaceOrder(context.Context, *connect.Request[v1.ReplaceOrderRequest]) (*connect.Response[v1.ReplaceOrderResponse], error)
 	ListOrders(context.Context, *connect.Request[v1.ListOrdersRequest]) (*connect.Response[v1.ListOrdersResponse], error)
 }
 
@@ -72,6 +80,12 @@ func NewOrderServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(orderServiceMethods.ByName("CancelOrder")),
 			connect.WithClientOptions(opts...),
 		),
+		replaceOrder: connect.NewClient[v1.ReplaceOrderRequest, v1.ReplaceOrderResponse](
+			httpClient,
+			baseURL+OrderServiceReplaceOrderProcedure,
+			connect.WithSchema(orderServiceMethods.ByName("ReplaceOrder")),
+			connect.WithClientOptions(opts...),
+		),
 		listOrders: connect.NewClient[v1.ListOrdersRequest, v1.ListOrdersResponse](
 			httpClient,
 			baseURL+OrderServiceListOrdersProcedure,
@@ -83,9 +97,10 @@ func NewOrderServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 
 // orderServiceClient implements OrderServiceClient.
 type orderServiceClient struct {
-	placeOrder  *connect.Client[v1.PlaceOrderRequest, v1.PlaceOrderResponse]
-	cancelOrder *connect.Client[v1.CancelOrderRequest, v1.CancelOrderResponse]
-	listOrders  *connect.Client[v1.ListOrdersRequest, v1.ListOrdersResponse]
+	placeOrder   *connect.Client[v1.PlaceOrderRequest, v1.PlaceOrderResponse]
+	cancelOrder  *connect.Client[v1.CancelOrderRequest, v1.CancelOrderResponse]
+	replaceOrder *connect.Client[v1.ReplaceOrderRequest, v1.ReplaceOrderResponse]
+	listOrders   *connect.Client[v1.ListOrdersRequest, v1.ListOrdersResponse]
 }
 
 // PlaceOrder calls control.v1.OrderService.PlaceOrder.
@@ -98,6 +113,11 @@ func (c *orderServiceClient) CancelOrder(ctx context.Context, req *connect.Reque
 	return c.cancelOrder.CallUnary(ctx, req)
 }
 
+// ReplaceOrder calls control.v1.OrderService.ReplaceOrder.
+func (c *orderServiceClient) ReplaceOrder(ctx context.Context, req *connect.Request[v1.ReplaceOrderRequest]) (*connect.Response[v1.ReplaceOrderResponse], error) {
+	return c.replaceOrder.CallUnary(ctx, req)
+}
+
 // ListOrders calls control.v1.OrderService.ListOrders.
 func (c *orderServiceClient) ListOrders(ctx context.Context, req *connect.Request[v1.ListOrdersRequest]) (*connect.Response[v1.ListOrdersResponse], error) {
 	return c.listOrders.CallUnary(ctx, req)
@@ -107,6 +127,11 @@ func (c *orderServiceClient) ListOrders(ctx context.Context, req *connect.Reques
 type OrderServiceHandler interface {
 	PlaceOrder(context.Context, *connect.Request[v1.PlaceOrderRequest]) (*connect.Response[v1.PlaceOrderResponse], error)
 	CancelOrder(context.Context, *connect.Request[v1.CancelOrderRequest]) (*connect.Response[v1.CancelOrderResponse], error)
+	// ReplaceOrder gives an active limit order a new price and quantity. A
+	// venue that amends natively keeps the order and its IDs; elsewhere the
+	// order is canceled and, once terminal, a new one is placed for what it
+	// left unfilled. The two orders are never working together.
+	ReplaceOrder(context.Context, *connect.Request[v1.ReplaceOrderRequest]) (*connect.Response[v1.ReplaceOrderResponse], error)
 	ListOrders(context.Context, *connect.Request[v1.ListOrdersRequest]) (*connect.Response[v1.ListOrdersResponse], error)
 }
 
@@ -129,6 +154,12 @@ func NewOrderServiceHandler(svc OrderServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(orderServiceMethods.ByName("CancelOrder")),
 		connect.WithHandlerOptions(opts...),
 	)
+	orderServiceReplaceOrderHandler := connect.NewUnaryHandler(
+		OrderServiceReplaceOrderProcedure,
+		svc.ReplaceOrder,
+		connect.WithSchema(orderServiceMethods.ByName("ReplaceOrder")),
+		connect.WithHandlerOptions(opts...),
+	)
 	orderServiceListOrdersHandler := connect.NewUnaryHandler(
 		OrderServiceListOrdersProcedure,
 		svc.ListOrders,
@@ -141,6 +172,8 @@ func NewOrderServiceHandler(svc OrderServiceHandler, opts ...connect.HandlerOpti
 			orderServicePlaceOrderHandler.ServeHTTP(w, r)
 		case OrderServiceCancelOrderProcedure:
 			orderServiceCancelOrderHandler.ServeHTTP(w, r)
+		case OrderServiceReplaceOrderProcedure:
+			orderServiceReplaceOrderHandler.ServeHTTP(w, r)
 		case OrderServiceListOrdersProcedure:
 			orderServiceListOrdersHandler.ServeHTTP(w, r)
 		default:
@@ -160,6 +193,10 @@ func (UnimplementedOrderServiceHandler) CancelOrder(context.Context, *connect.Re
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("control.v1.OrderService.CancelOrder is not implemented"))
 }
 
+func (UnimplementedOrderServiceHandler) ReplaceOrder(context.Context, *connect.Request[v1.ReplaceOrderRequest]) (*connect.Response[v1.ReplaceOrderResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("control.v1.OrderService.ReplaceOrder is not implemented"))
+}
+
 func (UnimplementedOrderServiceHandler) ListOrders(context.Context, *connect.Request[v1.ListOrdersRequest]) (*connect.Response[v1.ListOrdersResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("control.v1.OrderService.ListOrders is not implemented"))
 }
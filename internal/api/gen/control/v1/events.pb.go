@@ -7,6 +7,7 @@
 package controlv1
 
 import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -22,6 +23,111 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type GapReason int32
+
+const (
+	GapReason_GAP_REASON_UNSPECIFIED GapReason = 0
+	// GAP_REASON_SLOW_CLIENT: events arrived faster than the client read
+	// them and were discarded.
+	GapReason_GAP_REASON_SLOW_CLIENT GapReason = 1
+	// GAP_REASON_RETENTION: the resume cursor is older than the outbox
+	// keeps, so events after it may be gone.
+	GapReason_GAP_REASON_RETENTION GapReason = 2
+)
+
+// Enum value maps for GapReason.
+var (
+	GapReason_name = map[int32]string{
+		0: "GAP_REASON_UNSPECIFIED",
+		1: "GAP_REASON_SLOW_CLIENT",
+		2: "GAP_REASON_RETENTION",
+	}
+	GapReason_value = map[string]int32{
+		"GAP_REASON_UNSPECIFIED": 0,
+		"GAP_REASON_SLOW_CLIENT": 1,
+		"GAP_REASON_RETENTION":   2,
+	}
+)
+
+func (x GapReason) Enum() *GapReason {
+	p := new(GapReason)
+	*p = x
+	return p
+}
+
+func (x GapReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GapReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_control_v1_events_proto_enumTypes[0].Descriptor()
+}
+
+func (GapReason) Type() protoreflect.EnumType {
+	return &file_control_v1_events_proto_enumTypes[0]
+}
+
+func (x GapReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GapReason.Descriptor instead.
+func (GapReason) EnumDescriptor() ([]byte, []int) {
+	return file_control_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+type InstrumentChangeKind int32
+
+const (
+	InstrumentChangeKind_INSTRUMENT_CHANGE_KIND_UNSPECIFIED InstrumentChangeKind = 0
+	InstrumentChangeKind_INSTRUMENT_CHANGE_KIND_LISTED      InstrumentChangeKind = 1
+	InstrumentChangeKind_INSTRUMENT_CHANGE_KIND_UPDATED     InstrumentChangeKind = 2
+	InstrumentChangeKind_INSTRUMENT_CHANGE_KIND_DELISTED    InstrumentChangeKind = 3
+)
+
+// Enum value maps for InstrumentChangeKind.
+var (
+	InstrumentChangeKind_name = map[int32]string{
+		0: "INSTRUMENT_CHANGE_KIND_UNSPECIFIED",
+		1: "INSTRUMENT_CHANGE_KIND_LISTED",
+		2: "INSTRUMENT_CHANGE_KIND_UPDATED",
+		3: "INSTRUMENT_CHANGE_KIND_DELISTED",
+	}
+	InstrumentChangeKind_value = map[string]int32{
+		"INSTRUMENT_CHANGE_KIND_UNSPECIFIED": 0,
+		"INSTRUMENT_CHANGE_KIND_LISTED":      1,
+		"INSTRUMENT_CHANGE_KIND_UPDATED":     2,
+		"INSTRUMENT_CHANGE_KIND_DELISTED":    3,
+	}
+)
+
+func (x InstrumentChangeKind) Enum() *InstrumentChangeKind {
+	p := new(InstrumentChangeKind)
+	*p = x
+	return p
+}
+
+func (x InstrumentChangeKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InstrumentChangeKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_control_v1_events_proto_enumTypes[1].Descriptor()
+}
+
+func (InstrumentChangeKind) Type() protoreflect.EnumType {
+	return &file_control_v1_events_proto_enumTypes[1]
+}
+
+func (x InstrumentChangeKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InstrumentChangeKind.Descriptor instead.
+func (InstrumentChangeKind) EnumDescriptor() ([]byte, []int) {
+	return file_control_v1_events_proto_rawDescGZIP(), []int{1}
+}
+
 type ReconcileDiffKind int32
 
 const (
@@ -52,11 +158,11 @@ func (x ReconcileDiffKind) String() string {
 }
 
 func (ReconcileDiffKind) Descriptor() protoreflect.EnumDescriptor {
-	return file_control_v1_events_proto_enumTypes[0].Descriptor()
+	return file_control_v1_events_proto_enumTypes[2].Descriptor()
 }
 
 func (ReconcileDiffKind) Type() protoreflect.EnumType {
-	return &file_control_v1_events_proto_enumTypes[0]
+	return &file_control_v1_events_proto_enumTypes[2]
 }
 
 func (x ReconcileDiffKind) Number() protoreflect.EnumNumber {
@@ -65,12 +171,16 @@ func (x ReconcileDiffKind) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ReconcileDiffKind.Descriptor instead.
 func (ReconcileDiffKind) EnumDescriptor() ([]byte, []int) {
-	return file_control_v1_events_proto_rawDescGZIP(), []int{0}
+	return file_control_v1_events_proto_rawDescGZIP(), []int{2}
 }
 
 type StreamEventsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SubjectPrefix string                 `protobuf:"bytes,1,opt,name=subject_prefix,json=subjectPrefix,proto3" json:"subject_prefix,omitempty"`
+	// resume_after is the cursor of the last event the client handled. When
+	// set, outbox events after it are replayed before the live tail; 0
+	// replays everything the outbox still holds.
+	ResumeAfter   *int64 `protobuf:"varint,2,opt,name=resume_after,json=resumeAfter,proto3,oneof" json:"resume_after,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -112,9 +222,18 @@ func (x *StreamEventsRequest) GetSubjectPrefix() string {
 	return ""
 }
 
+func (x *StreamEventsRequest) GetResumeAfter() int64 {
+	if x != nil && x.ResumeAfter != nil {
+		return *x.ResumeAfter
+	}
+	return 0
+}
+
+// StreamEventsResponse carries exactly one of event and gap.
 type StreamEventsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Event         *Event                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Gap           *Gap                   `protobuf:"bytes,2,opt,name=gap,proto3" json:"gap,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -156,18 +275,87 @@ func (x *StreamEventsResponse) GetEvent() *Event {
 	return nil
 }
 
+func (x *StreamEventsResponse) GetGap() *Gap {
+	if x != nil {
+		return x.Gap
+	}
+	return nil
+}
+
+// Gap marks events this stream lost. Outbox events in a gap can be
+// recovered by reopening the stream with resume_after set to the last
+// cursor received; hint events cannot.
+type Gap struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Reason GapReason              `protobuf:"varint,1,opt,name=reason,proto3,enum=control.v1.GapReason" json:"reason,omitempty"`
+	// dropped counts the events discarded; 0 when it is unknown.
+	Dropped       uint64 `protobuf:"varint,2,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Gap) Reset() {
+	*x = Gap{}
+	mi := &file_control_v1_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Gap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Gap) ProtoMessage() {}
+
+func (x *Gap) ProtoReflect() protoreflect.Message {
+	mi := &file_control_v1_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Gap.ProtoReflect.Descriptor instead.
+func (*Gap) Descriptor() ([]byte, []int) {
+	return file_control_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Gap) GetReason() GapReason {
+	if x != nil {
+		return x.Reason
+	}
+	return GapReason_GAP_REASON_UNSPECIFIED
+}
+
+func (x *Gap) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
 // Event is the bus envelope. New payload types are added as oneof arms;
 // existing arms are never renumbered.
 type Event struct {
 	state   protoimpl.MessageState `protogen:"open.v1"`
 	Subject string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
 	At      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=at,proto3" json:"at,omitempty"`
+	// cursor is the place of an outbox-backed event in the order the relay
+	// published it, the value to resume after; 0 for hint events, which are
+	// never replayed. Outbox events arrive in cursor order.
+	Cursor int64 `protobuf:"varint,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	// Types that are valid to be assigned to Payload:
 	//
 	//	*Event_SnapshotTaken
 	//	*Event_OrderUpdated
 	//	*Event_OrderFilled
 	//	*Event_ReconcileDiff
+	//	*Event_TickerUpdated
+	//	*Event_InstrumentChanged
 	Payload       isEvent_Payload `protobuf_oneof:"payload"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -175,7 +363,7 @@ type Event struct {
 
 func (x *Event) Reset() {
 	*x = Event{}
-	mi := &file_control_v1_events_proto_msgTypes[2]
+	mi := &file_control_v1_events_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -187,7 +375,7 @@ func (x *Event) String() string {
 func (*Event) ProtoMessage() {}
 
 func (x *Event) ProtoReflect() protoreflect.Message {
-	mi := &file_control_v1_events_proto_msgTypes[2]
+	mi := &file_control_v1_events_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -200,7 +388,7 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Event.ProtoReflect.Descriptor instead.
 func (*Event) Descriptor() ([]byte, []int) {
-	return file_control_v1_events_proto_rawDescGZIP(), []int{2}
+	return file_control_v1_events_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Event) GetSubject() string {
@@ -217,6 +405,13 @@ func (x *Event) GetAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Event) GetCursor() int64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
 func (x *Event) GetPayload() isEvent_Payload {
 	if x != nil {
 		return x.Payload
@@ -260,6 +455,24 @@ func (x *Event) GetReconcileDiff() *ReconcileDiff {
 	return nil
 }
 
+func (x *Event) GetTickerUpdated() *Ticker {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_TickerUpdated); ok {
+			return x.TickerUpdated
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetInstrumentChanged() *InstrumentChanged {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_InstrumentChanged); ok {
+			return x.InstrumentChanged
+		}
+	}
+	return nil
+}
+
 type isEvent_Payload interface {
 	isEvent_Payload()
 }
@@ -280,6 +493,14 @@ type Event_ReconcileDiff struct {
 	ReconcileDiff *ReconcileDiff `protobuf:"bytes,13,opt,name=reconcile_diff,json=reconcileDiff,proto3,oneof"`
 }
 
+type Event_TickerUpdated struct {
+	TickerUpdated *Ticker `protobuf:"bytes,14,opt,name=ticker_updated,json=tickerUpdated,proto3,oneof"`
+}
+
+type Event_InstrumentChanged struct {
+	InstrumentChanged *InstrumentChanged `protobuf:"bytes,15,opt,name=instrument_changed,json=instrumentChanged,proto3,oneof"`
+}
+
 func (*Event_SnapshotTaken) isEvent_Payload() {}
 
 func (*Event_OrderUpdated) isEvent_Payload() {}
@@ -288,6 +509,164 @@ func (*Event_OrderFilled) isEvent_Payload() {}
 
 func (*Event_ReconcileDiff) isEvent_Payload() {}
 
+func (*Event_TickerUpdated) isEvent_Payload() {}
+
+func (*Event_InstrumentChanged) isEvent_Payload() {}
+
+// Ticker is one top-of-book plus last-trade observation of a spot pair.
+type Ticker struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Venue         string                 `protobuf:"bytes,1,opt,name=venue,proto3" json:"venue,omitempty"`
+	Base          string                 `protobuf:"bytes,2,opt,name=base,proto3" json:"base,omitempty"`
+	Quote         string                 `protobuf:"bytes,3,opt,name=quote,proto3" json:"quote,omitempty"`
+	Bid           string                 `protobuf:"bytes,4,opt,name=bid,proto3" json:"bid,omitempty"`
+	Ask           string                 `protobuf:"bytes,5,opt,name=ask,proto3" json:"ask,omitempty"`
+	Last          string                 `protobuf:"bytes,6,opt,name=last,proto3" json:"last,omitempty"`
+	BidSize       string                 `protobuf:"bytes,7,opt,name=bid_size,json=bidSize,proto3" json:"bid_size,omitempty"`
+	AskSize       string                 `protobuf:"bytes,8,opt,name=ask_size,json=askSize,proto3" json:"ask_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ticker) Reset() {
+	*x = Ticker{}
+	mi := &file_control_v1_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ticker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ticker) ProtoMessage() {}
+
+func (x *Ticker) ProtoReflect() protoreflect.Message {
+	mi := &file_control_v1_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ticker.ProtoReflect.Descriptor instead.
+func (*Ticker) Descriptor() ([]byte, []int) {
+	return file_control_v1_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Ticker) GetVenue() string {
+	if x != nil {
+		return x.Venue
+	}
+	return ""
+}
+
+func (x *Ticker) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *Ticker) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *Ticker) GetBid() string {
+	if x != nil {
+		return x.Bid
+	}
+	return ""
+}
+
+func (x *Ticker) GetAsk() string {
+	if x != nil {
+		return x.Ask
+	}
+	return ""
+}
+
+func (x *Ticker) GetLast() string {
+	if x != nil {
+		return x.Last
+	}
+	return ""
+}
+
+func (x *Ticker) GetBidSize() string {
+	if x != nil {
+		return x.BidSize
+	}
+	return ""
+}
+
+func (x *Ticker) GetAskSize() string {
+	if x != nil {
+		return x.AskSize
+	}
+	return ""
+}
+
+// InstrumentChanged carries the catalog entry after the change.
+type InstrumentChanged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Change        InstrumentChangeKind   `protobuf:"varint,1,opt,name=change,proto3,enum=control.v1.InstrumentChangeKind" json:"change,omitempty"`
+	Instrument    *Instrument            `protobuf:"bytes,2,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstrumentChanged) Reset() {
+	*x = InstrumentChanged{}
+	mi := &file_control_v1_events_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstrumentChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstrumentChanged) ProtoMessage() {}
+
+func (x *InstrumentChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_control_v1_events_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstrumentChanged.ProtoReflect.Descriptor instead.
+func (*InstrumentChanged) Descriptor() ([]byte, []int) {
+	return file_control_v1_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *InstrumentChanged) GetChange() InstrumentChangeKind {
+	if x != nil {
+		return x.Change
+	}
+	return InstrumentChangeKind_INSTRUMENT_CHANGE_KIND_UNSPECIFIED
+}
+
+func (x *InstrumentChanged) GetInstrument() *Instrument {
+	if x != nil {
+		return x.Instrument
+	}
+	return nil
+}
+
 type OrderUpdated struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ClientOrderId string                 `protobuf:"bytes,1,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
@@ -302,7 +681,7 @@ type OrderUpdated struct {
 
 func (x *OrderUpdated) Reset() {
 	*x = OrderUpdated{}
-	mi := &file_control_v1_events_proto_msgTypes[3]
+	mi := &file_control_v1_events_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -314,7 +693,7 @@ func (x *OrderUpdated) String() string {
 func (*OrderUpdated) ProtoMessage() {}
 
 func (x *OrderUpdated) ProtoReflect() protoreflect.Message {
-	mi := &file_control_v1_events_proto_msgTypes[3]
+	mi := &file_control_v1_events_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -327,7 +706,7 @@ func (x *OrderUpdated) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderUpdated.ProtoReflect.Descriptor instead.
 func (*OrderUpdated) Descriptor() ([]byte, []int) {
-	return file_control_v1_events_proto_rawDescGZIP(), []int{3}
+	return file_control_v1_events_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *OrderUpdated) GetClientOrderId() string {
@@ -388,7 +767,7 @@ type OrderFilled struct {
 
 func (x *OrderFilled) Reset() {
 	*x = OrderFilled{}
-	mi := &file_control_v1_events_proto_msgTypes[4]
+	mi := &file_control_v1_events_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -400,7 +779,7 @@ func (x *OrderFilled) String() string {
 func (*OrderFilled) ProtoMessage() {}
 
 func (x *OrderFilled) ProtoReflect() protoreflect.Message {
-	mi := &file_control_v1_events_proto_msgTypes[4]
+	mi := &file_control_v1_events_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -413,7 +792,7 @@ func (x *OrderFilled) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderFilled.ProtoReflect.Descriptor instead.
 func (*OrderFilled) Descriptor() ([]byte, []int) {
-	return file_control_v1_events_proto_rawDescGZIP(), []int{4}
+	return file_control_v1_events_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *OrderFilled) GetClientOrderId() string {
@@ -486,7 +865,7 @@ type ReconcileDiff struct {
 
 func (x *ReconcileDiff) Reset() {
 	*x = ReconcileDiff{}
-	mi := &file_control_v1_events_proto_msgTypes[5]
+	mi := &file_control_v1_events_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -498,7 +877,7 @@ func (x *ReconcileDiff) String() string {
 func (*ReconcileDiff) ProtoMessage() {}
 
 func (x *ReconcileDiff) ProtoReflect() protoreflect.Message {
-	mi := &file_control_v1_events_proto_msgTypes[5]
+	mi := &file_control_v1_events_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -511,7 +890,7 @@ func (x *ReconcileDiff) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReconcileDiff.ProtoReflect.Descriptor instead.
 func (*ReconcileDiff) Descriptor() ([]byte, []int) {
-	return file_control_v1_events_proto_rawDescGZIP(), []int{5}
+	return file_control_v1_events_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ReconcileDiff) GetKind() ReconcileDiffKind {
@@ -569,7 +948,7 @@ type AccountSnapshot struct {
 
 func (x *AccountSnapshot) Reset() {
 	*x = AccountSnapshot{}
-	mi := &file_control_v1_events_proto_msgTypes[6]
+	mi := &file_control_v1_events_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -581,7 +960,7 @@ func (x *AccountSnapshot) String() string {
 func (*AccountSnapshot) ProtoMessage() {}
 
 func (x *AccountSnapshot) ProtoReflect() protoreflect.Message {
-	mi := &file_control_v1_events_proto_msgTypes[6]
+	mi := &file_control_v1_events_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -594,7 +973,7 @@ func (x *AccountSnapshot) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AccountSnapshot.ProtoReflect.Descriptor instead.
 func (*AccountSnapshot) Descriptor() ([]byte, []int) {
-	return file_control_v1_events_proto_rawDescGZIP(), []int{6}
+	return file_control_v1_events_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AccountSnapshot) GetVenue() string {
@@ -639,7 +1018,7 @@ type Balance struct {
 
 func (x *Balance) Reset() {
 	*x = Balance{}
-	mi := &file_control_v1_events_proto_msgTypes[7]
+	mi := &file_control_v1_events_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -651,7 +1030,7 @@ func (x *Balance) String() string {
 func (*Balance) ProtoMessage() {}
 
 func (x *Balance) ProtoReflect() protoreflect.Message {
-	mi := &file_control_v1_events_proto_msgTypes[7]
+	mi := &file_control_v1_events_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -664,7 +1043,7 @@ func (x *Balance) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Balance.ProtoReflect.Descriptor instead.
 func (*Balance) Descriptor() ([]byte, []int) {
-	return file_control_v1_events_proto_rawDescGZIP(), []int{7}
+	return file_control_v1_events_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Balance) GetCurrency() string {
@@ -700,20 +1079,43 @@ var File_control_v1_events_proto protoreflect.FileDescriptor
 const file_control_v1_events_proto_rawDesc = "" +
 	"\n" +
 	"\x17control/v1/events.proto\x12\n" +
-	"control.v1\x1a\x17control/v1/orders.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"<\n" +
+	"control.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1ccontrol/v1/instruments.proto\x1a\x17control/v1/orders.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"~\n" +
 	"\x13StreamEventsRequest\x12%\n" +
-	"\x0esubject_prefix\x18\x01 \x01(\tR\rsubjectPrefix\"?\n" +
+	"\x0esubject_prefix\x18\x01 \x01(\tR\rsubjectPrefix\x12/\n" +
+	"\fresume_after\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02(\x00H\x00R\vresumeAfter\x88\x01\x01B\x0f\n" +
+	"\r_resume_after\"b\n" +
 	"\x14StreamEventsResponse\x12'\n" +
-	"\x05event\x18\x01 \x01(\v2\x11.control.v1.EventR\x05event\"\xe1\x02\n" +
+	"\x05event\x18\x01 \x01(\v2\x11.control.v1.EventR\x05event\x12!\n" +
+	"\x03gap\x18\x02 \x01(\v2\x0f.control.v1.GapR\x03gap\"N\n" +
+	"\x03Gap\x12-\n" +
+	"\x06reason\x18\x01 \x01(\x0e2\x15.control.v1.GapReasonR\x06reason\x12\x18\n" +
+	"\adropped\x18\x02 \x01(\x04R\adropped\"\x86\x04\n" +
 	"\x05Event\x12\x18\n" +
 	"\asubject\x18\x01 \x01(\tR\asubject\x12*\n" +
-	"\x02at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12D\n" +
+	"\x02at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\x03R\x06cursor\x12D\n" +
 	"\x0esnapshot_taken\x18\n" +
 	" \x01(\v2\x1b.control.v1.AccountSnapshotH\x00R\rsnapshotTaken\x12?\n" +
 	"\rorder_updated\x18\v \x01(\v2\x18.control.v1.OrderUpdatedH\x00R\forderUpdated\x12<\n" +
 	"\forder_filled\x18\f \x01(\v2\x17.control.v1.OrderFilledH\x00R\vorderFilled\x12B\n" +
-	"\x0ereconcile_diff\x18\r \x01(\v2\x19.control.v1.ReconcileDiffH\x00R\rreconcileDiffB\t\n" +
-	"\apayload\"\xc6\x01\n" +
+	"\x0ereconcile_diff\x18\r \x01(\v2\x19.control.v1.ReconcileDiffH\x00R\rreconcileDiff\x12;\n" +
+	"\x0eticker_updated\x18\x0e \x01(\v2\x12.control.v1.TickerH\x00R\rtickerUpdated\x12N\n" +
+	"\x12instrument_changed\x18\x0f \x01(\v2\x1d.control.v1.InstrumentChangedH\x00R\x11instrumentChangedB\t\n" +
+	"\apayload\"\xb6\x01\n" +
+	"\x06Ticker\x12\x14\n" +
+	"\x05venue\x18\x01 \x01(\tR\x05venue\x12\x12\n" +
+	"\x04base\x18\x02 \x01(\tR\x04base\x12\x14\n" +
+	"\x05quote\x18\x03 \x01(\tR\x05quote\x12\x10\n" +
+	"\x03bid\x18\x04 \x01(\tR\x03bid\x12\x10\n" +
+	"\x03ask\x18\x05 \x01(\tR\x03ask\x12\x12\n" +
+	"\x04last\x18\x06 \x01(\tR\x04last\x12\x19\n" +
+	"\bbid_size\x18\a \x01(\tR\abidSize\x12\x19\n" +
+	"\bask_size\x18\b \x01(\tR\aaskSize\"\x85\x01\n" +
+	"\x11InstrumentChanged\x128\n" +
+	"\x06change\x18\x01 \x01(\x0e2 .control.v1.InstrumentChangeKindR\x06change\x126\n" +
+	"\n" +
+	"instrument\x18\x02 \x01(\v2\x16.control.v1.InstrumentR\n" +
+	"instrument\"\xc6\x01\n" +
 	"\fOrderUpdated\x12&\n" +
 	"\x0fclient_order_id\x18\x01 \x01(\tR\rclientOrderId\x12\x14\n" +
 	"\x05venue\x18\x02 \x01(\tR\x05venue\x12\x12\n" +
@@ -748,7 +1150,16 @@ const file_control_v1_events_proto_rawDesc = "" +
 	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\tR\x05total\x12\x12\n" +
 	"\x04free\x18\x03 \x01(\tR\x04free\x12\x16\n" +
-	"\x06locked\x18\x04 \x01(\tR\x06locked*X\n" +
+	"\x06locked\x18\x04 \x01(\tR\x06locked*]\n" +
+	"\tGapReason\x12\x1a\n" +
+	"\x16GAP_REASON_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16GAP_REASON_SLOW_CLIENT\x10\x01\x12\x18\n" +
+	"\x14GAP_REASON_RETENTION\x10\x02*\xaa\x01\n" +
+	"\x14InstrumentChangeKind\x12&\n" +
+	"\"INSTRUMENT_CHANGE_KIND_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dINSTRUMENT_CHANGE_KIND_LISTED\x10\x01\x12\"\n" +
+	"\x1eINSTRUMENT_CHANGE_KIND_UPDATED\x10\x02\x12#\n" +
+	"\x1fINSTRUMENT_CHANGE_KIND_DELISTED\x10\x03*X\n" +
 	"\x11ReconcileDiffKind\x12#\n" +
 	"\x1fRECONCILE_DIFF_KIND_UNSPECIFIED\x10\x00\x12\x1e\n" +
 	"\x1aRECONCILE_DIFF_KIND_ORPHAN\x10\x012e\n" +
@@ -770,40 +1181,52 @@ func file_control_v1_events_proto_rawDescGZIP() []byte {
 	return file_control_v1_events_proto_rawDescData
 }
 
-var file_control_v1_events_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_control_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_control_v1_events_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_control_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_control_v1_events_proto_goTypes = []any{
-	(ReconcileDiffKind)(0),        // 0: control.v1.ReconcileDiffKind
-	(*StreamEventsRequest)(nil),   // 1: control.v1.StreamEventsRequest
-	(*StreamEventsResponse)(nil),  // 2: control.v1.StreamEventsResponse
-	(*Event)(nil),                 // 3: control.v1.Event
-	(*OrderUpdated)(nil),          // 4: control.v1.OrderUpdated
-	(*OrderFilled)(nil),           // 5: control.v1.OrderFilled
-	(*ReconcileDiff)(nil),         // 6: control.v1.ReconcileDiff
-	(*AccountSnapshot)(nil),       // 7: control.v1.AccountSnapshot
-	(*Balance)(nil),               // 8: control.v1.Balance
-	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
-	(OrderStatus)(0),              // 10: control.v1.OrderStatus
+	(GapReason)(0),                // 0: control.v1.GapReason
+	(InstrumentChangeKind)(0),     // 1: control.v1.InstrumentChangeKind
+	(ReconcileDiffKind)(0),        // 2: control.v1.ReconcileDiffKind
+	(*StreamEventsRequest)(nil),   // 3: control.v1.StreamEventsRequest
+	(*StreamEventsResponse)(nil),  // 4: control.v1.StreamEventsResponse
+	(*Gap)(nil),                   // 5: control.v1.Gap
+	(*Event)(nil),                 // 6: control.v1.Event
+	(*Ticker)(nil),                // 7: control.v1.Ticker
+	(*InstrumentChanged)(nil),     // 8: control.v1.InstrumentChanged
+	(*OrderUpdated)(nil),          // 9: control.v1.OrderUpdated
+	(*OrderFilled)(nil),           // 10: control.v1.OrderFilled
+	(*ReconcileDiff)(nil),         // 11: control.v1.ReconcileDiff
+	(*AccountSnapshot)(nil),       // 12: control.v1.AccountSnapshot
+	(*Balance)(nil),               // 13: control.v1.Balance
+	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
+	(*Instrument)(nil),            // 15: control.v1.Instrument
+	(OrderStatus)(0),              // 16: control.v1.OrderStatus
 }
 var file_control_v1_events_proto_depIdxs = []int32{
-	3,  // 0: control.v1.StreamEventsResponse.event:type_name -> control.v1.Event
-	9,  // 1: control.v1.Event.at:type_name -> google.protobuf.Timestamp
-	7,  // 2: control.v1.Event.snapshot_taken:type_name -> control.v1.AccountSnapshot
-	4,  // 3: control.v1.Event.order_updated:type_name -> control.v1.OrderUpdated
-	5,  // 4: control.v1.Event.order_filled:type_name -> control.v1.OrderFilled
-	6,  // 5: control.v1.Event.reconcile_diff:type_name -> control.v1.ReconcileDiff
-	10, // 6: control.v1.OrderUpdated.status:type_name -> control.v1.OrderStatus
-	10, // 7: control.v1.OrderFilled.status:type_name -> control.v1.OrderStatus
-	0,  // 8: control.v1.ReconcileDiff.kind:type_name -> control.v1.ReconcileDiffKind
-	9,  // 9: control.v1.AccountSnapshot.taken_at:type_name -> google.protobuf.Timestamp
-	8,  // 10: control.v1.AccountSnapshot.balances:type_name -> control.v1.Balance
-	1,  // 11: control.v1.EventService.StreamEvents:input_type -> control.v1.StreamEventsRequest
-	2,  // 12: control.v1.EventService.StreamEvents:output_type -> control.v1.StreamEventsResponse
-	12, // [12:13] is the sub-list for method output_type
-	11, // [11:12] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	6,  // 0: control.v1.StreamEventsResponse.event:type_name -> control.v1.Event
+	5,  // 1: control.v1.StreamEventsResponse.gap:type_name -> control.v1.Gap
+	0,  // 2: control.v1.Gap.reason:type_name -> control.v1.GapReason
+	14, // 3: control.v1.Event.at:type_name -> google.protobuf.Timestamp
+	12, // 4: control.v1.Event.snapshot_taken:type_name -> control.v1.AccountSnapshot
+	9,  // 5: control.v1.Event.order_updated:type_name -> control.v1.OrderUpdated
+	10, // 6: control.v1.Event.order_filled:type_name -> control.v1.OrderFilled
+	11, // 7: control.v1.Event.reconcile_diff:type_name -> control.v1.ReconcileDiff
+	7,  // 8: control.v1.Event.ticker_updated:type_name -> control.v1.Ticker
+	8,  // 9: control.v1.Event.instrument_changed:type_name -> control.v1.InstrumentChanged
+	1,  // 10: control.v1.InstrumentChanged.change:type_name -> control.v1.InstrumentChangeKind
+	15, // 11: control.v1.InstrumentChanged.instrument:type_name -> control.v1.Instrument
+	16, // 12: control.v1.OrderUpdated.status:type_name -> control.v1.OrderStatus
+	16, // 13: control.v1.OrderFilled.status:type_name -> control.v1.OrderStatus
+	2,  // 14: control.v1.ReconcileDiff.kind:type_name -> control.v1.ReconcileDiffKind
+	14, // 15: control.v1.AccountSnapshot.taken_at:type_name -> google.protobuf.Timestamp
+	13, // 16: control.v1.AccountSnapshot.balances:type_name -> control.v1.Balance
+	3,  // 17: control.v1.EventService.StreamEvents:input_type -> control.v1.StreamEventsRequest
+	4,  // 18: control.v1.EventService.StreamEvents:output_type -> control.v1.StreamEventsResponse
+	18, // [18:19] is the sub-list for method output_type
+	17, // [17:18] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_control_v1_events_proto_init() }
@@ -811,20 +1234,24 @@ func file_control_v1_events_proto_init() {
 	if File_control_v1_events_proto != nil {
 		return
 	}
+	file_control_v1_instruments_proto_init()
 	file_control_v1_orders_proto_init()
-	file_control_v1_events_proto_msgTypes[2].OneofWrappers = []any{
+	file_control_v1_events_proto_msgTypes[0].OneofWrappers = []any{}
+	file_control_v1_events_proto_msgTypes[3].OneofWrappers = []any{
 		(*Event_SnapshotTaken)(nil),
 		(*Event_OrderUpdated)(nil),
 		(*Event_OrderFilled)(nil),
 		(*Event_ReconcileDiff)(nil),
+		(*Event_TickerUpdated)(nil),
+		(*Event_InstrumentChanged)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_control_v1_events_proto_rawDesc), len(file_control_v1_events_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   8,
+			NumEnums:      3,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -78,6 +78,10 @@ const (
 	OrderType_ORDER_TYPE_UNSPECIFIED OrderType = 0
 	OrderType_ORDER_TYPE_LIMIT       OrderType = 1
 	OrderType_ORDER_TYPE_MARKET      OrderType = 2
+	// Stops rest until the last trade crosses trigger_price, then work as a
+	// market or limit order.
+	OrderType_ORDER_TYPE_STOP_MARKET OrderType = 3
+	OrderType_ORDER_TYPE_STOP_LIMIT  OrderType = 4
 )
 
 // Enum value maps for OrderType.
@@ -86,11 +90,15 @@ var (
 		0: "ORDER_TYPE_UNSPECIFIED",
 		1: "ORDER_TYPE_LIMIT",
 		2: "ORDER_TYPE_MARKET",
+		3: "ORDER_TYPE_STOP_MARKET",
+		4: "ORDER_TYPE_STOP_LIMIT",
 	}
 	OrderType_value = map[string]int32{
 		"ORDER_TYPE_UNSPECIFIED": 0,
 		"ORDER_TYPE_LIMIT":       1,
 		"ORDER_TYPE_MARKET":      2,
+		"ORDER_TYPE_STOP_MARKET": 3,
+		"ORDER_TYPE_STOP_LIMIT":  4,
 	}
 )
 
@@ -121,6 +129,63 @@ func (OrderType) EnumDescriptor() ([]byte, []int) {
 	return file_control_v1_orders_proto_rawDescGZIP(), []int{1}
 }
 
+type TimeInForce int32
+
+const (
+	// Unspecified places a GTC order.
+	TimeInForce_TIME_IN_FORCE_UNSPECIFIED TimeInForce = 0
+	TimeInForce_TIME_IN_FORCE_GTC         TimeInForce = 1
+	TimeInForce_TIME_IN_FORCE_IOC         TimeInForce = 2
+	TimeInForce_TIME_IN_FORCE_FOK         TimeInForce = 3
+	// GTD rests until expires_at.
+	TimeInForce_TIME_IN_FORCE_GTD TimeInForce = 4
+)
+
+// Enum value maps for TimeInForce.
+var (
+	TimeInForce_name = map[int32]string{
+		0: "TIME_IN_FORCE_UNSPECIFIED",
+		1: "TIME_IN_FORCE_GTC",
+		2: "TIME_IN_FORCE_IOC",
+		3: "TIME_IN_FORCE_FOK",
+		4: "TIME_IN_FORCE_GTD",
+	}
+	TimeInForce_value = map[string]int32{
+		"TIME_IN_FORCE_UNSPECIFIED": 0,
+		"TIME_IN_FORCE_GTC":         1,
+		"TIME_IN_FORCE_IOC":         2,
+		"TIME_IN_FORCE_FOK":         3,
+		"TIME_IN_FORCE_GTD":         4,
+	}
+)
+
+func (x TimeInForce) Enum() *TimeInForce {
+	p := new(TimeInForce)
+	*p = x
+	return p
+}
+
+func (x TimeInForce) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TimeInForce) Descriptor() protoreflect.EnumDescriptor {
+	return file_control_v1_orders_proto_enumTypes[2].Descriptor()
+}
+
+func (TimeInForce) Type() protoreflect.EnumType {
+	return &file_control_v1_orders_proto_enumTypes[2]
+}
+
+func (x TimeInForce) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TimeInForce.Descriptor instead.
+func (TimeInForce) EnumDescriptor() ([]byte, []int) {
+	return file_control_v1_orders_proto_rawDescGZIP(), []int{2}
+}
+
 type OrderStatus int32
 
 const (
@@ -132,6 +197,11 @@ const (
 	OrderStatus_ORDER_STATUS_CANCELED         OrderStatus = 5
 	OrderStatus_ORDER_STATUS_REJECTED         OrderStatus = 6
 	OrderStatus_ORDER_STATUS_EXPIRED          OrderStatus = 7
+	// Untriggered is a stop waiting for its trigger price.
+	OrderStatus_ORDER_STATUS_UNTRIGGERED OrderStatus = 8
+	// Triggered is a stop the daemon held that fired; its child order,
+	// child_client_order_id, carries on.
+	OrderStatus_ORDER_STATUS_TRIGGERED OrderStatus = 9
 )
 
 // Enum value maps for OrderStatus.
@@ -145,6 +215,8 @@ var (
 		5: "ORDER_STATUS_CANCELED",
 		6: "ORDER_STATUS_REJECTED",
 		7: "ORDER_STATUS_EXPIRED",
+		8: "ORDER_STATUS_UNTRIGGERED",
+		9: "ORDER_STATUS_TRIGGERED",
 	}
 	OrderStatus_value = map[string]int32{
 		"ORDER_STATUS_UNSPECIFIED":      0,
@@ -155,6 +227,8 @@ var (
 		"ORDER_STATUS_CANCELED":         5,
 		"ORDER_STATUS_REJECTED":         6,
 		"ORDER_STATUS_EXPIRED":          7,
+		"ORDER_STATUS_UNTRIGGERED":      8,
+		"ORDER_STATUS_TRIGGERED":        9,
 	}
 )
 
@@ -169,11 +243,11 @@ func (x OrderStatus) String() string {
 }
 
 func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_control_v1_orders_proto_enumTypes[2].Descriptor()
+	return file_control_v1_orders_proto_enumTypes[3].Descriptor()
 }
 
 func (OrderStatus) Type() protoreflect.EnumType {
-	return &file_control_v1_orders_proto_enumTypes[2]
+	return &file_control_v1_orders_proto_enumTypes[3]
 }
 
 func (x OrderStatus) Number() protoreflect.EnumNumber {
@@ -182,7 +256,58 @@ func (x OrderStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OrderStatus.Descriptor instead.
 func (OrderStatus) EnumDescriptor() ([]byte, []int) {
-	return file_control_v1_orders_proto_rawDescGZIP(), []int{2}
+	return file_control_v1_orders_proto_rawDescGZIP(), []int{3}
+}
+
+type ReplaceMode int32
+
+const (
+	ReplaceMode_REPLACE_MODE_UNSPECIFIED ReplaceMode = 0
+	// The venue changed the order in place.
+	ReplaceMode_REPLACE_MODE_AMEND ReplaceMode = 1
+	// The order was canceled and a new one placed.
+	ReplaceMode_REPLACE_MODE_CANCEL_REPLACE ReplaceMode = 2
+)
+
+// Enum value maps for ReplaceMode.
+var (
+	ReplaceMode_name = map[int32]string{
+		0: "REPLACE_MODE_UNSPECIFIED",
+		1: "REPLACE_MODE_AMEND",
+		2: "REPLACE_MODE_CANCEL_REPLACE",
+	}
+	ReplaceMode_value = map[string]int32{
+		"REPLACE_MODE_UNSPECIFIED":    0,
+		"REPLACE_MODE_AMEND":          1,
+		"REPLACE_MODE_CANCEL_REPLACE": 2,
+	}
+)
+
+func (x ReplaceMode) Enum() *ReplaceMode {
+	p := new(ReplaceMode)
+	*p = x
+	return p
+}
+
+func (x ReplaceMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReplaceMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_control_v1_orders_proto_enumTypes[4].Descriptor()
+}
+
+func (ReplaceMode) Type() protoreflect.EnumType {
+	return &file_control_v1_orders_proto_enumTypes[4]
+}
+
+func (x ReplaceMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReplaceMode.Descriptor instead.
+func (ReplaceMode) EnumDescriptor() ([]byte, []int) {
+	return file_control_v1_orders_proto_rawDescGZIP(), []int{4}
 }
 
 type PlaceOrderRequest struct {
@@ -195,6 +320,17 @@ type PlaceOrderRequest struct {
 	Qty           string                 `protobuf:"bytes,6,opt,name=qty,proto3" json:"qty,omitempty"`
 	Price         string                 `protobuf:"bytes,7,opt,name=price,proto3" json:"price,omitempty"`
 	ClientOrderId string                 `protobuf:"bytes,8,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	TimeInForce   TimeInForce            `protobuf:"varint,9,opt,name=time_in_force,json=timeInForce,proto3,enum=control.v1.TimeInForce" json:"time_in_force,omitempty"`
+	// expires_at is required for GTD and refused otherwise; the daemon also
+	// checks that it lies in the future.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// post_only makes the venue reject the order rather than let it take
+	// liquidity. It needs a limit order that is GTC or GTD.
+	PostOnly bool `protobuf:"varint,11,opt,name=post_only,json=postOnly,proto3" json:"post_only,omitempty"`
+	// trigger_price is the last trade price that fires a stop. Stops are
+	// GTC; a venue without native stops has the daemon hold them, which
+	// needs the instrument's ticker to be polled.
+	TriggerPrice  string `protobuf:"bytes,12,opt,name=trigger_price,json=triggerPrice,proto3" json:"trigger_price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -285,6 +421,34 @@ func (x *PlaceOrderRequest) GetClientOrderId() string {
 	return ""
 }
 
+func (x *PlaceOrderRequest) GetTimeInForce() TimeInForce {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return TimeInForce_TIME_IN_FORCE_UNSPECIFIED
+}
+
+func (x *PlaceOrderRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *PlaceOrderRequest) GetPostOnly() bool {
+	if x != nil {
+		return x.PostOnly
+	}
+	return false
+}
+
+func (x *PlaceOrderRequest) GetTriggerPrice() string {
+	if x != nil {
+		return x.TriggerPrice
+	}
+	return ""
+}
+
 type PlaceOrderResponse struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	ClientOrderId   string                 `protobuf:"bytes,1,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
@@ -433,20 +597,173 @@ func (x *CancelOrderResponse) GetStatus() OrderStatus {
 	return OrderStatus_ORDER_STATUS_UNSPECIFIED
 }
 
-type ListOrdersRequest struct {
+type ReplaceOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Venue         string                 `protobuf:"bytes,1,opt,name=venue,proto3" json:"venue,omitempty"`
-	Statuses      []OrderStatus          `protobuf:"varint,2,rep,packed,name=statuses,proto3,enum=control.v1.OrderStatus" json:"statuses,omitempty"`
-	BotId         string                 `protobuf:"bytes,3,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
-	PageToken     string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	ClientOrderId string                 `protobuf:"bytes,1,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	Price         string                 `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	// qty is the new total, fills included.
+	Qty string `protobuf:"bytes,3,opt,name=qty,proto3" json:"qty,omitempty"`
+	// new_client_order_id names the order a cancel-replace places. Retrying
+	// with the same value resumes a replace that did not settle; empty
+	// generates one.
+	NewClientOrderId string `protobuf:"bytes,4,opt,name=new_client_order_id,json=newClientOrderId,proto3" json:"new_client_order_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ReplaceOrderRequest) Reset() {
+	*x = ReplaceOrderRequest{}
+	mi := &file_control_v1_orders_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplaceOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceOrderRequest) ProtoMessage() {}
+
+func (x *ReplaceOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_control_v1_orders_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceOrderRequest.ProtoReflect.Descriptor instead.
+func (*ReplaceOrderRequest) Descriptor() ([]byte, []int) {
+	return file_control_v1_orders_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReplaceOrderRequest) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *ReplaceOrderRequest) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *ReplaceOrderRequest) GetQty() string {
+	if x != nil {
+		return x.Qty
+	}
+	return ""
+}
+
+func (x *ReplaceOrderRequest) GetNewClientOrderId() string {
+	if x != nil {
+		return x.NewClientOrderId
+	}
+	return ""
+}
+
+// ReplaceOrderResponse names the working order: client_order_id equals
+// replaced_client_order_id after an amend.
+type ReplaceOrderResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	ReplacedClientOrderId string                 `protobuf:"bytes,1,opt,name=replaced_client_order_id,json=replacedClientOrderId,proto3" json:"replaced_client_order_id,omitempty"`
+	ClientOrderId         string                 `protobuf:"bytes,2,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	Mode                  ReplaceMode            `protobuf:"varint,3,opt,name=mode,proto3,enum=control.v1.ReplaceMode" json:"mode,omitempty"`
+	Status                OrderStatus            `protobuf:"varint,4,opt,name=status,proto3,enum=control.v1.OrderStatus" json:"status,omitempty"`
+	SubmitUnsettled       bool                   `protobuf:"varint,5,opt,name=submit_unsettled,json=submitUnsettled,proto3" json:"submit_unsettled,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ReplaceOrderResponse) Reset() {
+	*x = ReplaceOrderResponse{}
+	mi := &file_control_v1_orders_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplaceOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceOrderResponse) ProtoMessage() {}
+
+func (x *ReplaceOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_control_v1_orders_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceOrderResponse.ProtoReflect.Descriptor instead.
+func (*ReplaceOrderResponse) Descriptor() ([]byte, []int) {
+	return file_control_v1_orders_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReplaceOrderResponse) GetReplacedClientOrderId() string {
+	if x != nil {
+		return x.ReplacedClientOrderId
+	}
+	return ""
+}
+
+func (x *ReplaceOrderResponse) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *ReplaceOrderResponse) GetMode() ReplaceMode {
+	if x != nil {
+		return x.Mode
+	}
+	return ReplaceMode_REPLACE_MODE_UNSPECIFIED
+}
+
+func (x *ReplaceOrderResponse) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *ReplaceOrderResponse) GetSubmitUnsettled() bool {
+	if x != nil {
+		return x.SubmitUnsettled
+	}
+	return false
+}
+
+type ListOrdersRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Venue     string                 `protobuf:"bytes,1,opt,name=venue,proto3" json:"venue,omitempty"`
+	Statuses  []OrderStatus          `protobuf:"varint,2,rep,packed,name=statuses,proto3,enum=control.v1.OrderStatus" json:"statuses,omitempty"`
+	BotId     string                 `protobuf:"bytes,3,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
+	Limit     int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	PageToken string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// include_parents adds each execution parent to the page as one order,
+	// rollup set, its fills its children's added up.
+	IncludeParents bool `protobuf:"varint,6,opt,name=include_parents,json=includeParents,proto3" json:"include_parents,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ListOrdersRequest) Reset() {
 	*x = ListOrdersRequest{}
-	mi := &file_control_v1_orders_proto_msgTypes[4]
+	mi := &file_control_v1_orders_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -458,7 +775,7 @@ func (x *ListOrdersRequest) String() string {
 func (*ListOrdersRequest) ProtoMessage() {}
 
 func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_control_v1_orders_proto_msgTypes[4]
+	mi := &file_control_v1_orders_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -471,7 +788,7 @@ func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
 func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_control_v1_orders_proto_rawDescGZIP(), []int{4}
+	return file_control_v1_orders_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListOrdersRequest) GetVenue() string {
@@ -509,6 +826,13 @@ func (x *ListOrdersRequest) GetPageToken() string {
 	return ""
 }
 
+func (x *ListOrdersRequest) GetIncludeParents() bool {
+	if x != nil {
+		return x.IncludeParents
+	}
+	return false
+}
+
 type ListOrdersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
@@ -519,7 +843,7 @@ type ListOrdersResponse struct {
 
 func (x *ListOrdersResponse) Reset() {
 	*x = ListOrdersResponse{}
-	mi := &file_control_v1_orders_proto_msgTypes[5]
+	mi := &file_control_v1_orders_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -531,7 +855,7 @@ func (x *ListOrdersResponse) String() string {
 func (*ListOrdersResponse) ProtoMessage() {}
 
 func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_control_v1_orders_proto_msgTypes[5]
+	mi := &file_control_v1_orders_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -544,7 +868,7 @@ func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
 func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_control_v1_orders_proto_rawDescGZIP(), []int{5}
+	return file_control_v1_orders_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ListOrdersResponse) GetOrders() []*Order {
@@ -578,13 +902,27 @@ type Order struct {
 	BotId         string                 `protobuf:"bytes,13,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	TimeInForce   TimeInForce            `protobuf:"varint,16,opt,name=time_in_force,json=timeInForce,proto3,enum=control.v1.TimeInForce" json:"time_in_force,omitempty"`
+	// expires_at is set only for GTD orders.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	PostOnly  bool                   `protobuf:"varint,18,opt,name=post_only,json=postOnly,proto3" json:"post_only,omitempty"`
+	// trigger_price is set only for stops.
+	TriggerPrice string `protobuf:"bytes,19,opt,name=trigger_price,json=triggerPrice,proto3" json:"trigger_price,omitempty"`
+	// child_client_order_id is set only for a stop the daemon holds: the
+	// order it places when it fires.
+	ChildClientOrderId string `protobuf:"bytes,20,opt,name=child_client_order_id,json=childClientOrderId,proto3" json:"child_client_order_id,omitempty"`
+	// parent_id is set only on an execution algorithm's slice.
+	ParentId string `protobuf:"bytes,21,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// rollup marks an execution parent listed as an order: client_order_id
+	// is its parent_id and its fills are its children's added up.
+	Rollup        bool `protobuf:"varint,22,opt,name=rollup,proto3" json:"rollup,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Order) Reset() {
 	*x = Order{}
-	mi := &file_control_v1_orders_proto_msgTypes[6]
+	mi := &file_control_v1_orders_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -596,7 +934,7 @@ func (x *Order) String() string {
 func (*Order) ProtoMessage() {}
 
 func (x *Order) ProtoReflect() protoreflect.Message {
-	mi := &file_control_v1_orders_proto_msgTypes[6]
+	mi := &file_control_v1_orders_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -609,7 +947,7 @@ func (x *Order) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Order.ProtoReflect.Descriptor instead.
 func (*Order) Descriptor() ([]byte, []int) {
-	return file_control_v1_orders_proto_rawDescGZIP(), []int{6}
+	return file_control_v1_orders_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Order) GetClientOrderId() string {
@@ -717,12 +1055,61 @@ func (x *Order) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Order) GetTimeInForce() TimeInForce {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return TimeInForce_TIME_IN_FORCE_UNSPECIFIED
+}
+
+func (x *Order) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Order) GetPostOnly() bool {
+	if x != nil {
+		return x.PostOnly
+	}
+	return false
+}
+
+func (x *Order) GetTriggerPrice() string {
+	if x != nil {
+		return x.TriggerPrice
+	}
+	return ""
+}
+
+func (x *Order) GetChildClientOrderId() string {
+	if x != nil {
+		return x.ChildClientOrderId
+	}
+	return ""
+}
+
+func (x *Order) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Order) GetRollup() bool {
+	if x != nil {
+		return x.Rollup
+	}
+	return false
+}
+
 var File_control_v1_orders_proto protoreflect.FileDescriptor
 
 const file_control_v1_orders_proto_rawDesc = "" +
 	"\n" +
 	"\x17control/v1/orders.proto\x12\n" +
-	"control.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe7\x05\n" +
+	"control.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfd\v\n" +
 	"\x11PlaceOrderRequest\x12\x1f\n" +
 	"\x05venue\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\x05venue\x12\x1d\n" +
 	"\x04base\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18\x10R\x04base\x12\x1f\n" +
@@ -733,9 +1120,18 @@ const file_control_v1_orders_proto_rawDesc = "" +
 	"\xbaH\a\x82\x01\x04\x10\x01 \x00R\x04type\x12P\n" +
 	"\x03qty\x18\x06 \x01(\tB>\xbaH;r9\x10\x01\x18@23^(?:[1-9][0-9]*(?:\\.[0-9]+)?|0\\.[0-9]*[1-9][0-9]*)$R\x03qty\x12\x1d\n" +
 	"\x05price\x18\a \x01(\tB\a\xbaH\x04r\x02\x18@R\x05price\x12N\n" +
-	"\x0fclient_order_id\x18\b \x01(\tB&\xbaH#r!\x18\x1a2\x1d^(?:|[0-9A-HJKMNP-TV-Z]{26})$R\rclientOrderId:\xc6\x02\xbaH\xc2\x02\x1aM\n" +
-	"\x16place_order.base_quote\x12\x1abase and quote must differ\x1a\x17this.base != this.quote\x1a\xf0\x01\n" +
-	"\x11place_order.price\x12Vlimit orders require a positive decimal price and market orders require an empty price\x1a\x82\x01this.type == 1 ? this.price.matches(r'^(?:[1-9][0-9]*(?:\\.[0-9]+)?|0\\.[0-9]*[1-9][0-9]*)$') : (this.type == 2 && this.price == '')\"\x98\x01\n" +
+	"\x0fclient_order_id\x18\b \x01(\tB&\xbaH#r!\x18\x1a2\x1d^(?:|[0-9A-HJKMNP-TV-Z]{26})$R\rclientOrderId\x12E\n" +
+	"\rtime_in_force\x18\t \x01(\x0e2\x17.control.v1.TimeInForceB\b\xbaH\x05\x82\x01\x02\x10\x01R\vtimeInForce\x129\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1b\n" +
+	"\tpost_only\x18\v \x01(\bR\bpostOnly\x12,\n" +
+	"\rtrigger_price\x18\f \x01(\tB\a\xbaH\x04r\x02\x18@R\ftriggerPrice:\x8f\a\xbaH\x8b\a\x1aM\n" +
+	"\x16place_order.base_quote\x12\x1abase and quote must differ\x1a\x17this.base != this.quote\x1a\x8b\x01\n" +
+	"\x16place_order.expires_at\x12>gtd orders require expires_at and other orders must not set it\x1a1(this.time_in_force == 4) == has(this.expires_at)\x1a\x94\x01\n" +
+	"\x15place_order.post_only\x123post_only requires a limit order that is gtc or gtd\x1aF!this.post_only || (this.type == 1 && this.time_in_force in [0, 1, 4])\x1a\x99\x02\n" +
+	"\x11place_order.price\x12ulimit and stop-limit orders require a positive decimal price and market and stop-market orders require an empty price\x1a\x8c\x01this.type in [1, 4] ? this.price.matches(r'^(?:[1-9][0-9]*(?:\\.[0-9]+)?|0\\.[0-9]*[1-9][0-9]*)$') : (this.type in [2, 3] && this.price == '')\x1a\xf8\x01\n" +
+	"\x19place_order.trigger_price\x12Ustop orders require a positive decimal trigger_price and other orders must not set it\x1a\x83\x01this.type in [3, 4] ? this.trigger_price.matches(r'^(?:[1-9][0-9]*(?:\\.[0-9]+)?|0\\.[0-9]*[1-9][0-9]*)$') : this.trigger_price == ''\"\x98\x01\n" +
 	"\x12PlaceOrderResponse\x12&\n" +
 	"\x0fclient_order_id\x18\x01 \x01(\tR\rclientOrderId\x12/\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x17.control.v1.OrderStatusR\x06status\x12)\n" +
@@ -743,7 +1139,18 @@ const file_control_v1_orders_proto_rawDesc = "" +
 	"\x12CancelOrderRequest\x12J\n" +
 	"\x0fclient_order_id\x18\x01 \x01(\tB\"\xbaH\x1fr\x1d2\x18^[0-9A-HJKMNP-TV-Z]{26}$\x98\x01\x1aR\rclientOrderId\"F\n" +
 	"\x13CancelOrderResponse\x12/\n" +
-	"\x06status\x18\x01 \x01(\x0e2\x17.control.v1.OrderStatusR\x06status\"\xe4\x01\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x17.control.v1.OrderStatusR\x06status\"\xe0\x02\n" +
+	"\x13ReplaceOrderRequest\x12J\n" +
+	"\x0fclient_order_id\x18\x01 \x01(\tB\"\xbaH\x1fr\x1d2\x18^[0-9A-HJKMNP-TV-Z]{26}$\x98\x01\x1aR\rclientOrderId\x12T\n" +
+	"\x05price\x18\x02 \x01(\tB>\xbaH;r9\x10\x01\x18@23^(?:[1-9][0-9]*(?:\\.[0-9]+)?|0\\.[0-9]*[1-9][0-9]*)$R\x05price\x12P\n" +
+	"\x03qty\x18\x03 \x01(\tB>\xbaH;r9\x10\x01\x18@23^(?:[1-9][0-9]*(?:\\.[0-9]+)?|0\\.[0-9]*[1-9][0-9]*)$R\x03qty\x12U\n" +
+	"\x13new_client_order_id\x18\x04 \x01(\tB&\xbaH#r!\x18\x1a2\x1d^(?:|[0-9A-HJKMNP-TV-Z]{26})$R\x10newClientOrderId\"\x80\x02\n" +
+	"\x14ReplaceOrderResponse\x127\n" +
+	"\x18replaced_client_order_id\x18\x01 \x01(\tR\x15replacedClientOrderId\x12&\n" +
+	"\x0fclient_order_id\x18\x02 \x01(\tR\rclientOrderId\x12+\n" +
+	"\x04mode\x18\x03 \x01(\x0e2\x17.control.v1.ReplaceModeR\x04mode\x12/\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x17.control.v1.OrderStatusR\x06status\x12)\n" +
+	"\x10submit_unsettled\x18\x05 \x01(\bR\x0fsubmitUnsettled\"\x8d\x02\n" +
 	"\x11ListOrdersRequest\x12\x1d\n" +
 	"\x05venue\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x18@R\x05venue\x12D\n" +
 	"\bstatuses\x18\x02 \x03(\x0e2\x17.control.v1.OrderStatusB\x0f\xbaH\f\x92\x01\t\"\a\x82\x01\x04\x10\x01 \x00R\bstatuses\x12\x1f\n" +
@@ -751,10 +1158,11 @@ const file_control_v1_orders_proto_rawDesc = "" +
 	"\x05limit\x18\x04 \x01(\x05B\n" +
 	"\xbaH\a\x1a\x05\x18\xf4\x03(\x00R\x05limit\x12'\n" +
 	"\n" +
-	"page_token\x18\x05 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x10R\tpageToken\"g\n" +
+	"page_token\x18\x05 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x10R\tpageToken\x12'\n" +
+	"\x0finclude_parents\x18\x06 \x01(\bR\x0eincludeParents\"g\n" +
 	"\x12ListOrdersResponse\x12)\n" +
 	"\x06orders\x18\x01 \x03(\v2\x11.control.v1.OrderR\x06orders\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x91\x04\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xb3\x06\n" +
 	"\x05Order\x12&\n" +
 	"\x0fclient_order_id\x18\x01 \x01(\tR\rclientOrderId\x12$\n" +
 	"\x0evenue_order_id\x18\x02 \x01(\tR\fvenueOrderId\x12\x14\n" +
@@ -774,15 +1182,31 @@ const file_control_v1_orders_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt*9\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12;\n" +
+	"\rtime_in_force\x18\x10 \x01(\x0e2\x17.control.v1.TimeInForceR\vtimeInForce\x129\n" +
+	"\n" +
+	"expires_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1b\n" +
+	"\tpost_only\x18\x12 \x01(\bR\bpostOnly\x12#\n" +
+	"\rtrigger_price\x18\x13 \x01(\tR\ftriggerPrice\x121\n" +
+	"\x15child_client_order_id\x18\x14 \x01(\tR\x12childClientOrderId\x12\x1b\n" +
+	"\tparent_id\x18\x15 \x01(\tR\bparentId\x12\x16\n" +
+	"\x06rollup\x18\x16 \x01(\bR\x06rollup*9\n" +
 	"\x04Side\x12\x14\n" +
 	"\x10SIDE_UNSPECIFIED\x10\x00\x12\f\n" +
 	"\bSIDE_BUY\x10\x01\x12\r\n" +
-	"\tSIDE_SELL\x10\x02*T\n" +
+	"\tSIDE_SELL\x10\x02*\x8b\x01\n" +
 	"\tOrderType\x12\x1a\n" +
 	"\x16ORDER_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
 	"\x10ORDER_TYPE_LIMIT\x10\x01\x12\x15\n" +
-	"\x11ORDER_TYPE_MARKET\x10\x02*\xe8\x01\n" +
+	"\x11ORDER_TYPE_MARKET\x10\x02\x12\x1a\n" +
+	"\x16ORDER_TYPE_STOP_MARKET\x10\x03\x12\x19\n" +
+	"\x15ORDER_TYPE_STOP_LIMIT\x10\x04*\x88\x01\n" +
+	"\vTimeInForce\x12\x1d\n" +
+	"\x19TIME_IN_FORCE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11TIME_IN_FORCE_GTC\x10\x01\x12\x15\n" +
+	"\x11TIME_IN_FORCE_IOC\x10\x02\x12\x15\n" +
+	"\x11TIME_IN_FORCE_FOK\x10\x03\x12\x15\n" +
+	"\x11TIME_IN_FORCE_GTD\x10\x04*\xa2\x02\n" +
 	"\vOrderStatus\x12\x1c\n" +
 	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
 	"\x14ORDER_STATUS_PENDING\x10\x01\x12\x15\n" +
@@ -791,11 +1215,18 @@ const file_control_v1_orders_proto_rawDesc = "" +
 	"\x13ORDER_STATUS_FILLED\x10\x04\x12\x19\n" +
 	"\x15ORDER_STATUS_CANCELED\x10\x05\x12\x19\n" +
 	"\x15ORDER_STATUS_REJECTED\x10\x06\x12\x18\n" +
-	"\x14ORDER_STATUS_EXPIRED\x10\a2\xfe\x01\n" +
+	"\x14ORDER_STATUS_EXPIRED\x10\a\x12\x1c\n" +
+	"\x18ORDER_STATUS_UNTRIGGERED\x10\b\x12\x1a\n" +
+	"\x16ORDER_STATUS_TRIGGERED\x10\t*d\n" +
+	"\vReplaceMode\x12\x1c\n" +
+	"\x18REPLACE_MODE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12REPLACE_MODE_AMEND\x10\x01\x12\x1f\n" +
+	"\x1bREPLACE_MODE_CANCEL_REPLACE\x10\x022\xd3\x02\n" +
 	"\fOrderService\x12M\n" +
 	"\n" +
 	"PlaceOrder\x12\x1d.control.v1.PlaceOrderRequest\x1a\x1e.control.v1.PlaceOrderResponse\"\x00\x12P\n" +
-	"\vCancelOrder\x12\x1e.control.v1.CancelOrderRequest\x1a\x1f.control.v1.CancelOrderResponse\"\x00\x12M\n" +
+	"\vCancelOrder\x12\x1e.control.v1.CancelOrderRequest\x1a\x1f.control.v1.CancelOrderResponse\"\x00\x12S\n" +
+	"\fReplaceOrder\x12\x1f.control.v1.ReplaceOrderRequest\x1a .control.v1.ReplaceOrderResponse\"\x00\x12M\n" +
 	"\n" +
 	"ListOrders\x12\x1d.control.v1.ListOrdersRequest\x1a\x1e.control.v1.ListOrdersResponse\"\x00B\xae\x01\n" +
 	"\x0ecom.control.v1B\vOrdersProtoP\x01ZFgithub.com/romanornr/delta-works/internal/api/gen/control/v1;controlv1\xa2\x02\x03CXX\xaa\x02\n" +
@@ -814,44 +1245,56 @@ func file_control_v1_orders_proto_rawDescGZIP() []byte {
 	return file_control_v1_orders_proto_rawDescData
 }
 
-var file_control_v1_orders_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_control_v1_orders_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_control_v1_orders_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_control_v1_orders_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_control_v1_orders_proto_goTypes = []any{
 	(Side)(0),                     // 0: control.v1.Side
 	(OrderType)(0),                // 1: control.v1.OrderType
-	(OrderStatus)(0),              // 2: control.v1.OrderStatus
-	(*PlaceOrderRequest)(nil),     // 3: control.v1.PlaceOrderRequest
-	(*PlaceOrderResponse)(nil),    // 4: control.v1.PlaceOrderResponse
-	(*CancelOrderRequest)(nil),    // 5: control.v1.CancelOrderRequest
-	(*CancelOrderResponse)(nil),   // 6: control.v1.CancelOrderResponse
-	(*ListOrdersRequest)(nil),     // 7: control.v1.ListOrdersRequest
-	(*ListOrdersResponse)(nil),    // 8: control.v1.ListOrdersResponse
-	(*Order)(nil),                 // 9: control.v1.Order
-	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+	(TimeInForce)(0),              // 2: control.v1.TimeInForce
+	(OrderStatus)(0),              // 3: control.v1.OrderStatus
+	(ReplaceMode)(0),              // 4: control.v1.ReplaceMode
+	(*PlaceOrderRequest)(nil),     // 5: control.v1.PlaceOrderRequest
+	(*PlaceOrderResponse)(nil),    // 6: control.v1.PlaceOrderResponse
+	(*CancelOrderRequest)(nil),    // 7: control.v1.CancelOrderRequest
+	(*CancelOrderResponse)(nil),   // 8: control.v1.CancelOrderResponse
+	(*ReplaceOrderRequest)(nil),   // 9: control.v1.ReplaceOrderRequest
+	(*ReplaceOrderResponse)(nil),  // 10: control.v1.ReplaceOrderResponse
+	(*ListOrdersRequest)(nil),     // 11: control.v1.ListOrdersRequest
+	(*ListOrdersResponse)(nil),    // 12: control.v1.ListOrdersResponse
+	(*Order)(nil),                 // 13: control.v1.Order
+	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
 }
 var file_control_v1_orders_proto_depIdxs = []int32{
 	0,  // 0: control.v1.PlaceOrderRequest.side:type_name -> control.v1.Side
 	1,  // 1: control.v1.PlaceOrderRequest.type:type_name -> control.v1.OrderType
-	2,  // 2: control.v1.PlaceOrderResponse.status:type_name -> control.v1.OrderStatus
-	2,  // 3: control.v1.CancelOrderResponse.status:type_name -> control.v1.OrderStatus
-	2,  // 4: control.v1.ListOrdersRequest.statuses:type_name -> control.v1.OrderStatus
-	9,  // 5: control.v1.ListOrdersResponse.orders:type_name -> control.v1.Order
-	0,  // 6: control.v1.Order.side:type_name -> control.v1.Side
-	1,  // 7: control.v1.Order.type:type_name -> control.v1.OrderType
-	2,  // 8: control.v1.Order.status:type_name -> control.v1.OrderStatus
-	10, // 9: control.v1.Order.created_at:type_name -> google.protobuf.Timestamp
-	10, // 10: control.v1.Order.updated_at:type_name -> google.protobuf.Timestamp
-	3,  // 11: control.v1.OrderService.PlaceOrder:input_type -> control.v1.PlaceOrderRequest
-	5,  // 12: control.v1.OrderService.CancelOrder:input_type -> control.v1.CancelOrderRequest
-	7,  // 13: control.v1.OrderService.ListOrders:input_type -> control.v1.ListOrdersRequest
-	4,  // 14: control.v1.OrderService.PlaceOrder:output_type -> control.v1.PlaceOrderResponse
-	6,  // 15: control.v1.OrderService.CancelOrder:output_type -> control.v1.CancelOrderResponse
-	8,  // 16: control.v1.OrderService.ListOrders:output_type -> control.v1.ListOrdersResponse
-	14, // [14:17] is the sub-list for method output_type
-	11, // [11:14] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	2,  // 2: control.v1.PlaceOrderRequest.time_in_force:type_name -> control.v1.TimeInForce
+	14, // 3: control.v1.PlaceOrderRequest.expires_at:type_name -> google.protobuf.Timestamp
+	3,  // 4: control.v1.PlaceOrderResponse.status:type_name -> control.v1.OrderStatus
+	3,  // 5: control.v1.CancelOrderResponse.status:type_name -> control.v1.OrderStatus
+	4,  // 6: control.v1.ReplaceOrderResponse.mode:type_name -> control.v1.ReplaceMode
+	3,  // 7: control.v1.ReplaceOrderResponse.status:type_name -> control.v1.OrderStatus
+	3,  // 8: control.v1.ListOrdersRequest.statuses:type_name -> control.v1.OrderStatus
+	13, // 9: control.v1.ListOrdersResponse.orders:type_name -> control.v1.Order
+	0,  // 10: control.v1.Order.side:type_name -> control.v1.Side
+	1,  // 11: control.v1.Order.type:type_name -> control.v1.OrderType
+	3,  // 12: control.v1.Order.status:type_name -> control.v1.OrderStatus
+	14, // 13: control.v1.Order.created_at:type_name -> google.protobuf.Timestamp
+	14, // 14: control.v1.Order.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 15: control.v1.Order.time_in_force:type_name -> control.v1.TimeInForce
+	14, // 16: control.v1.Order.expires_at:type_name -> google.protobuf.Timestamp
+	5,  // 17: control.v1.OrderService.PlaceOrder:input_type -> control.v1.PlaceOrderRequest
+	7,  // 18: control.v1.OrderService.CancelOrder:input_type -> control.v1.CancelOrderRequest
+	9,  // 19: control.v1.OrderService.ReplaceOrder:input_type -> control.v1.ReplaceOrderRequest
+	11, // 20: control.v1.OrderService.ListOrders:input_type -> control.v1.ListOrdersRequest
+	6,  // 21: control.v1.OrderService.PlaceOrder:output_type -> control.v1.PlaceOrderResponse
+	8,  // 22: control.v1.OrderService.CancelOrder:output_type -> control.v1.CancelOrderResponse
+	10, // 23: control.v1.OrderService.ReplaceOrder:output_type -> control.v1.ReplaceOrderResponse
+	12, // 24: control.v1.OrderService.ListOrders:output_type -> control.v1.ListOrdersResponse
+	21, // [21:25] is the sub-list for method output_type
+	17, // [17:21] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_control_v1_orders_proto_init() }
@@ -864,8 +1307,8 @@ func file_control_v1_orders_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_control_v1_orders_proto_rawDesc), len(file_control_v1_orders_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   7,
+			NumEnums:      5,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	gridservice "github.com/romanornr/delta-works/internal/service/grid"
+)
+
+// GridServer serves control.v1.GridService.
+type GridServer struct {
+	grids *gridservice.Service
+}
+
+// NewGridServer builds the GridService handler.
+func NewGridServer(service *gridservice.Service) *GridServer {
+	return &GridServer{grids: service}
+}
+
+// ListGridBots reports every configured bot.
+func (s *GridServer) ListGridBots(ctx context.Context, _ *connect.Request[controlv1.ListGridBotsRequest]) (*connect.Response[controlv1.ListGridBotsResponse], error) {
+	statuses, err := s.grids.List(ctx)
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	response := &controlv1.ListGridBotsResponse{Bots: make([]*controlv1.GridBot, 0, len(statuses))}
+	for _, st := range statuses {
+		response.Bots = append(response.Bots, toProtoGridBot(st))
+	}
+	return connect.NewResponse(response), nil
+}
+
+// GetGridBot reports one bot.
+func (s *GridServer) GetGridBot(ctx context.Context, req *connect.Request[controlv1.GetGridBotRequest]) (*connect.Response[controlv1.GetGridBotResponse], error) {
+	st, err := s.grids.Status(ctx, req.Msg.GetBotId())
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	return connect.NewResponse(&controlv1.GetGridBotResponse{Bot: toProtoGridBot(st)}), nil
+}
+
+// PauseGridBot stops a bot placing orders.
+func (s *GridServer) PauseGridBot(ctx context.Context, req *connect.Request[controlv1.PauseGridBotRequest]) (*connect.Response[controlv1.PauseGridBotResponse], error) {
+	st, err := s.grids.Pause(ctx, req.Msg.GetBotId())
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	return connect.NewResponse(&controlv1.PauseGridBotResponse{Bot: toProtoGridBot(st)}), nil
+}
+
+// ResumeGridBot sets a paused bot placing again.
+func (s *GridServer) ResumeGridBot(ctx context.Context, req *connect.Request[controlv1.ResumeGridBotRequest]) (*connect.Response[controlv1.ResumeGridBotResponse], error) {
+	st, err := s.grids.Resume(ctx, req.Msg.GetBotId())
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	return connect.NewResponse(&controlv1.ResumeGridBotResponse{Bot: toProtoGridBot(st)}), nil
+}
+
+// StopGridBot cancels a bot's resting orders and ends it.
+func (s *GridServer) StopGridBot(ctx context.Context, req *connect.Request[controlv1.StopGridBotRequest]) (*connect.Response[controlv1.StopGridBotResponse], error) {
+	st, err := s.grids.Stop(ctx, req.Msg.GetBotId())
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	return connect.NewResponse(&controlv1.StopGridBotResponse{Bot: toProtoGridBot(st)}), nil
+}
+
+func toProtoGridBot(st gridservice.Status) *controlv1.GridBot {
+	return &controlv1.GridBot{
+		BotId: st.BotID, State: toProtoGridBotState(st.State),
+		OpenOrders: int32(st.Open), //nolint:gosec // bounded by the configured levels
+	}
+}
+
+func toProtoGridBotState(state gridservice.State) controlv1.GridBotState {
+	switch state {
+	case gridservice.StateRunning:
+		return controlv1.GridBotState_GRID_BOT_STATE_RUNNING
+	case gridservice.StatePaused:
+		return controlv1.GridBotState_GRID_BOT_STATE_PAUSED
+	case gridservice.StateStopped:
+		return controlv1.GridBotState_GRID_BOT_STATE_STOPPED
+	default:
+		return controlv1.GridBotState_GRID_BOT_STATE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shopspring/decimal"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/api/gen/control/v1/controlv1connect"
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/grid"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	domain "github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/exchange"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+	gridservice "github.com/romanornr/delta-works/internal/service/grid"
+	orderservice "github.com/romanornr/delta-works/internal/service/order"
+)
+
+// unreachablePlacer fails every placement, so a bot starts with an empty
+// ladder and has nothing to cancel when stopped.
+type unreachablePlacer struct{}
+
+func (unreachablePlacer) Place(context.Context, domain.Request) (orderservice.PlaceResult, error) {
+	return orderservice.PlaceResult{}, ports.ErrVenueUnavailable
+}
+
+func (unreachablePlacer) Cancel(context.Context, domain.ClientOrderID) (domain.Status, error) {
+	return domain.StatusCanceled, nil
+}
+
+type noActiveOrders struct{}
+
+func (noActiveOrders) ListOrders(context.Context, domain.Query) ([]domain.Record, error) {
+	return nil, nil
+}
+
+func (noActiveOrders) GetOrder(context.Context, domain.ClientOrderID) (domain.Record, error) {
+	return domain.Record{}, ports.ErrNotFound
+}
+
+// postOnlyMarketData is a venue that can place grid orders post-only.
+type postOnlyMarketData struct{ fakeMarketData }
+
+func (postOnlyMarketData) HonorsPostOnly() bool { return true }
+
+func TestGridService(t *testing.T) {
+	t.Parallel()
+	metrics, err := gridservice.NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventBus := bus.NewInProc()
+	t.Cleanup(eventBus.Close)
+	spec := grid.Spec{
+		BotID:      "grid-1",
+		Instrument: instrument.Instrument{Venue: "bybit", Type: instrument.TypeSpot, Base: "BTC", Quote: "USDT"},
+		Lower:      decimal.NewFromInt(100), Upper: decimal.NewFromInt(120),
+		Levels: 5, Qty: decimal.NewFromInt(1),
+	}
+	offTick := spec
+	offTick.BotID, offTick.Instrument.Base = "grid-2", "ETH"
+	catalog := fakeCatalog{
+		{Venue: "bybit", Type: instrument.TypeSpot, Base: "BTC", Quote: "USDT", Status: instrument.StatusTrading},
+		{
+			Venue: "bybit", Type: instrument.TypeSpot, Base: "ETH", Quote: "USDT", Status: instrument.StatusTrading,
+			Rules: instrument.Rules{PriceIncrement: decimal.NewFromInt(3)},
+		},
+	}
+	registry := exchange.NewRegistry([]ports.Exchange{postOnlyMarketData{}})
+	service := gridservice.New([]grid.Spec{spec, offTick}, unreachablePlacer{}, noActiveOrders{}, catalog, registry, eventBus,
+		clockwork.NewFakeClock(), log.Nop(), time.Minute, metrics)
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() { done <- service.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Run: %v", err)
+		}
+	})
+	srv := httptest.NewServer(NewServer(Handlers{Events: testEventServer(t, eventBus), Grids: NewGridServer(service)}, nil).Handler)
+	t.Cleanup(srv.Close)
+	client := controlv1connect.NewGridServiceClient(srv.Client(), srv.URL)
+
+	list, err := client.ListGridBots(t.Context(), connect.NewRequest(&controlv1.ListGridBotsRequest{}))
+	if err != nil || len(list.Msg.GetBots()) != 2 || list.Msg.GetBots()[0].GetState() != controlv1.GridBotState_GRID_BOT_STATE_RUNNING ||
+		list.Msg.GetBots()[1].GetState() != controlv1.GridBotState_GRID_BOT_STATE_STOPPED {
+		t.Fatalf("ListGridBots = %v, %v", list, err)
+	}
+	_, err = client.GetGridBot(t.Context(), connect.NewRequest(&controlv1.GetGridBotRequest{BotId: "grid-2"}))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition || !strings.Contains(err.Error(), "price increment 3") {
+		t.Fatalf("off-tick bot = %v, want FailedPrecondition naming the increment", err)
+	}
+	paused, err := client.PauseGridBot(t.Context(), connect.NewRequest(&controlv1.PauseGridBotRequest{BotId: "grid-1"}))
+	if err != nil || paused.Msg.GetBot().GetState() != controlv1.GridBotState_GRID_BOT_STATE_PAUSED {
+		t.Fatalf("PauseGridBot = %v, %v", paused, err)
+	}
+	resumed, err := client.ResumeGridBot(t.Context(), connect.NewRequest(&controlv1.ResumeGridBotRequest{BotId: "grid-1"}))
+	if err != nil || resumed.Msg.GetBot().GetState() != controlv1.GridBotState_GRID_BOT_STATE_RUNNING {
+		t.Fatalf("ResumeGridBot = %v, %v", resumed, err)
+	}
+	stopped, err := client.StopGridBot(t.Context(), connect.NewRequest(&controlv1.StopGridBotRequest{BotId: "grid-1"}))
+	if err != nil || stopped.Msg.GetBot().GetState() != controlv1.GridBotState_GRID_BOT_STATE_STOPPED {
+		t.Fatalf("StopGridBot = %v, %v", stopped, err)
+	}
+	got, err := client.GetGridBot(t.Context(), connect.NewRequest(&controlv1.GetGridBotRequest{BotId: "grid-1"}))
+	if err != nil || got.Msg.GetBot().GetState() != controlv1.GridBotState_GRID_BOT_STATE_STOPPED {
+		t.Fatalf("GetGridBot after stop = %v, %v", got, err)
+	}
+	_, err = client.ResumeGridBot(t.Context(), connect.NewRequest(&controlv1.ResumeGridBotRequest{BotId: "grid-1"}))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("resume after stop = %v, want FailedPrecondition", err)
+	}
+	_, err = client.PauseGridBot(t.Context(), connect.NewRequest(&controlv1.PauseGridBotRequest{BotId: "missing"}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("unknown bot = %v, want NotFound", err)
+	}
+	_, err = client.GetGridBot(t.Context(), connect.NewRequest(&controlv1.GetGridBotRequest{}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("empty bot ID = %v, want InvalidArgument", err)
+	}
+}
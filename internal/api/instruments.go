@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// InstrumentServer serves control.v1.InstrumentService from the local
+// instrument catalog.
+type InstrumentServer struct {
+	catalog ports.InstrumentCatalog
+}
+
+// NewInstrumentServer builds the InstrumentService handler.
+func NewInstrumentServer(catalog ports.InstrumentCatalog) *InstrumentServer {
+	return &InstrumentServer{catalog: catalog}
+}
+
+// ListInstruments filters the catalog; empty filters match everything.
+func (s *InstrumentServer) ListInstruments(ctx context.Context, req *connect.Request[controlv1.ListInstrumentsRequest]) (*connect.Response[controlv1.ListInstrumentsResponse], error) {
+	venue := instrument.NewVenueID(req.Msg.GetVenue())
+	typ := instrument.Type(req.Msg.GetType())
+	base, quote := money.NewCurrency(req.Msg.GetBase()), money.NewCurrency(req.Msg.GetQuote())
+	listed, err := s.catalog.ListInstruments(ctx, venue)
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	response := &controlv1.ListInstrumentsResponse{}
+	for _, inst := range listed {
+		if (typ != "" && inst.Type != typ) || (base != "" && inst.Base != base) || (quote != "" && inst.Quote != quote) {
+			continue
+		}
+		response.Instruments = append(response.Instruments, toProtoInstrument(inst))
+	}
+	return connect.NewResponse(response), nil
+}
+
+// GetInstrument returns one catalog entry.
+func (s *InstrumentServer) GetInstrument(ctx context.Context, req *connect.Request[controlv1.GetInstrumentRequest]) (*connect.Response[controlv1.GetInstrumentResponse], error) {
+	want := instrument.Instrument{
+		Venue: instrument.NewVenueID(req.Msg.GetVenue()),
+		Type:  instrument.Type(req.Msg.GetType()),
+		Base:  money.NewCurrency(req.Msg.GetBase()),
+		Quote: money.NewCurrency(req.Msg.GetQuote()),
+	}
+	if want.Type == "" {
+		want.Type = instrument.TypeSpot
+	}
+	listed, err := s.catalog.ListInstruments(ctx, want.Venue)
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	for _, inst := range listed {
+		if inst.Key() == want.Key() {
+			return connect.NewResponse(&controlv1.GetInstrumentResponse{Instrument: toProtoInstrument(inst)}), nil
+		}
+	}
+	return nil, mapOrderError(ports.ErrNotFound)
+}
+
+func toProtoInstrument(i instrument.Instrument) *controlv1.Instrument {
+	return &controlv1.Instrument{
+		Venue: string(i.Venue), Type: string(i.Type), Base: string(i.Base), Quote: string(i.Quote),
+		VenueSymbol: i.VenueSymbol, Status: toProtoInstrumentStatus(i.Status),
+		PriceIncrement: i.Rules.PriceIncrement.String(), QtyIncrement: i.Rules.QtyIncrement.String(),
+		MinQty: i.Rules.MinQty.String(), MinNotional: i.Rules.MinNotional.String(),
+	}
+}
+
+func toProtoInstrumentStatus(s instrument.Status) controlv1.InstrumentStatus {
+	switch s {
+	case instrument.StatusTrading:
+		return controlv1.InstrumentStatus_INSTRUMENT_STATUS_TRADING
+	case instrument.StatusHalted:
+		return controlv1.InstrumentStatus_INSTRUMENT_STATUS_HALTED
+	case instrument.StatusDelisted:
+		return controlv1.InstrumentStatus_INSTRUMENT_STATUS_DELISTED
+	default:
+		return controlv1.InstrumentStatus_INSTRUMENT_STATUS_UNSPECIFIED
+	}
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/shopspring/decimal"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/api/gen/control/v1/controlv1connect"
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/money"
+)
+
+type fakeCatalog []instrument.Instrument
+
+func (f fakeCatalog) ListInstruments(_ context.Context, venue instrument.VenueID) ([]instrument.Instrument, error) {
+	var out []instrument.Instrument
+	for _, inst := range f {
+		if venue == "" || inst.Venue == venue {
+			out = append(out, inst)
+		}
+	}
+	return out, nil
+}
+
+func TestInstrumentService(t *testing.T) {
+	t.Parallel()
+	spot := func(venue string, base money.Currency, status instrument.Status) instrument.Instrument {
+		return instrument.Instrument{
+			Venue: instrument.VenueID(venue), Type: instrument.TypeSpot, Base: base, Quote: "USDT",
+			VenueSymbol: string(base) + "USDT", Status: status,
+			Rules: instrument.Rules{PriceIncrement: decimal.RequireFromString("0.10"), MinNotional: decimal.NewFromInt(5)},
+		}
+	}
+	catalog := fakeCatalog{
+		spot("binance", "BTC", instrument.StatusTrading),
+		spot("bybit", "BTC", instrument.StatusTrading),
+		spot("bybit", "ETH", instrument.StatusDelisted),
+	}
+	eventBus := bus.NewInProc()
+	t.Cleanup(eventBus.Close)
+	srv := httptest.NewServer(NewServer(Handlers{Events: testEventServer(t, eventBus), Instruments: NewInstrumentServer(catalog)}, nil).Handler)
+	t.Cleanup(srv.Close)
+	client := controlv1connect.NewInstrumentServiceClient(srv.Client(), srv.URL)
+
+	list, err := client.ListInstruments(t.Context(), connect.NewRequest(&controlv1.ListInstrumentsRequest{Venue: "ByBit", Base: "eth"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := list.Msg.GetInstruments(); len(got) != 1 || got[0].GetStatus() != controlv1.InstrumentStatus_INSTRUMENT_STATUS_DELISTED {
+		t.Fatalf("ListInstruments = %v", got)
+	}
+	all, err := client.ListInstruments(t.Context(), connect.NewRequest(&controlv1.ListInstrumentsRequest{Quote: "USDT"}))
+	if err != nil || len(all.Msg.GetInstruments()) != 3 {
+		t.Fatalf("unfiltered venues = %v, %v", all, err)
+	}
+
+	got, err := client.GetInstrument(t.Context(), connect.NewRequest(&controlv1.GetInstrumentRequest{Venue: "bybit", Base: "btc", Quote: "usdt"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inst := got.Msg.GetInstrument(); inst.GetVenueSymbol() != "BTCUSDT" || inst.GetPriceIncrement() != "0.1" || inst.GetMinNotional() != "5" || inst.GetQtyIncrement() != "0" {
+		t.Fatalf("GetInstrument = %v", inst)
+	}
+	_, err = client.GetInstrument(t.Context(), connect.NewRequest(&controlv1.GetInstrumentRequest{Venue: "bybit", Base: "SOL", Quote: "USDT"}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("unknown instrument = %v, want NotFound", err)
+	}
+	_, err = client.GetInstrument(t.Context(), connect.NewRequest(&controlv1.GetInstrumentRequest{Venue: "bybit"}))
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeInvalidArgument {
+		t.Fatalf("missing pair = %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	domain "github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/service/kill"
+)
+
+// KillSwitchServer serves control.v1.KillSwitchService.
+type KillSwitchServer struct {
+	kill *kill.Service
+}
+
+// NewKillSwitchServer builds the KillSwitchService handler.
+func NewKillSwitchServer(service *kill.Service) *KillSwitchServer {
+	return &KillSwitchServer{kill: service}
+}
+
+// Kill engages the switch and streams the cancels as they settle. A send
+// failure means the client left; the kill itself carries on.
+func (s *KillSwitchServer) Kill(ctx context.Context, req *connect.Request[controlv1.KillRequest], stream *connect.ServerStream[controlv1.KillProgress]) error {
+	venue := instrument.NewVenueID(req.Msg.GetVenue())
+	err := s.kill.Kill(ctx, venue, req.Msg.GetReason(), func(p kill.Progress) error {
+		return stream.Send(toProtoKillProgress(p))
+	})
+	return mapOrderError(err)
+}
+
+// ReleaseKillSwitch disengages one switch.
+func (s *KillSwitchServer) ReleaseKillSwitch(ctx context.Context, req *connect.Request[controlv1.ReleaseKillSwitchRequest]) (*connect.Response[controlv1.ReleaseKillSwitchResponse], error) {
+	if err := s.kill.Release(ctx, instrument.NewVenueID(req.Msg.GetVenue())); err != nil {
+		return nil, mapOrderError(err)
+	}
+	return connect.NewResponse(&controlv1.ReleaseKillSwitchResponse{}), nil
+}
+
+// ListKillSwitches returns the engaged switches.
+func (s *KillSwitchServer) ListKillSwitches(context.Context, *connect.Request[controlv1.ListKillSwitchesRequest]) (*connect.Response[controlv1.ListKillSwitchesResponse], error) {
+	response := &controlv1.ListKillSwitchesResponse{}
+	for _, h := range s.kill.Halts() {
+		response.KillSwitches = append(response.KillSwitches, toProtoKillSwitch(h))
+	}
+	return connect.NewResponse(response), nil
+}
+
+func toProtoKillProgress(p kill.Progress) *controlv1.KillProgress {
+	msg := &controlv1.KillProgress{
+		ClientOrderId: string(p.ClientOrderID),
+		Venue:         string(p.Venue),
+	}
+	if p.Status != "" {
+		msg.Status = toProtoOrderStatus(p.Status)
+	}
+	if p.Err != nil {
+		// Same redaction as unary errors: the client sees the class, the
+		// daemon log keeps the cause.
+		msg.Error = mapOrderError(p.Err).Error()
+	}
+	switch p.Kind {
+	case kill.ProgressEngaged:
+		msg.Kind = controlv1.KillProgressKind_KILL_PROGRESS_KIND_ENGAGED
+		msg.KillSwitch = toProtoKillSwitch(p.Halt)
+		msg.ActiveOrders = uint32(p.Active) //nolint:gosec // an order count
+	case kill.ProgressCancelRequested:
+		msg.Kind = controlv1.KillProgressKind_KILL_PROGRESS_KIND_CANCEL_REQUESTED
+	case kill.ProgressCancelFailed:
+		msg.Kind = controlv1.KillProgressKind_KILL_PROGRESS_KIND_CANCEL_FAILED
+	case kill.ProgressSettled:
+		msg.Kind = controlv1.KillProgressKind_KILL_PROGRESS_KIND_SETTLED
+	case kill.ProgressUnsettled:
+		msg.Kind = controlv1.KillProgressKind_KILL_PROGRESS_KIND_UNSETTLED
+	}
+	return msg
+}
+
+func toProtoKillSwitch(h domain.Halt) *controlv1.KillSwitch {
+	return &controlv1.KillSwitch{Venue: string(h.Venue), Reason: h.Reason, EngagedAt: timestamppb.New(h.EngagedAt)}
+}
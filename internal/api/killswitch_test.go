@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/jonboulle/clockwork"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/api/gen/control/v1/controlv1connect"
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	domain "github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+	"github.com/romanornr/delta-works/internal/service/kill"
+)
+
+// fakeHaltOrders is an order service with one active, already canceled
+// order on bybit.
+type fakeHaltOrders struct {
+	halts []domain.Halt
+}
+
+func (f *fakeHaltOrders) Halt(_ context.Context, venue instrument.VenueID, reason string) (domain.Halt, error) {
+	h := domain.Halt{Venue: venue, Reason: reason, EngagedAt: time.Date(2026, 7, 12, 12, 0, 0, 0, time.UTC)}
+	f.halts = append(f.halts, h)
+	return h, nil
+}
+
+func (f *fakeHaltOrders) Release(context.Context, instrument.VenueID) error { return ports.ErrNotFound }
+func (f *fakeHaltOrders) Halts() []domain.Halt                              { return f.halts }
+func (f *fakeHaltOrders) Venues() []instrument.VenueID                      { return []instrument.VenueID{"bybit"} }
+
+func (f *fakeHaltOrders) Cancel(context.Context, domain.ClientOrderID) (domain.Status, error) {
+	return domain.StatusOpen, nil
+}
+
+func (f *fakeHaltOrders) GetOrder(_ context.Context, id domain.ClientOrderID) (domain.Record, error) {
+	return domain.Record{ClientOrderID: id, Instrument: instrument.Instrument{Venue: "bybit"}, Status: domain.StatusCanceled}, nil
+}
+
+func (f *fakeHaltOrders) ListActiveOrders(_ context.Context, venue instrument.VenueID) ([]domain.Record, error) {
+	return []domain.Record{{ClientOrderID: "01J00000000000000000000001", Instrument: instrument.Instrument{Venue: venue}, Status: domain.StatusOpen}}, nil
+}
+
+func TestKillSwitchService(t *testing.T) {
+	t.Parallel()
+	orders := &fakeHaltOrders{}
+	eventBus := bus.NewInProc()
+	t.Cleanup(eventBus.Close)
+	service := kill.New(orders, orders, clockwork.NewFakeClock(), log.Nop(), time.Second)
+	srv := httptest.NewServer(NewServer(Handlers{Events: testEventServer(t, eventBus), KillSwitch: NewKillSwitchServer(service)}, nil).Handler)
+	t.Cleanup(srv.Close)
+	client := controlv1connect.NewKillSwitchServiceClient(srv.Client(), srv.URL)
+
+	stream, err := client.Kill(t.Context(), connect.NewRequest(&controlv1.KillRequest{Venue: "ByBit", Reason: "drill"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var kinds []controlv1.KillProgressKind
+	var first *controlv1.KillProgress
+	for stream.Receive() {
+		if first == nil {
+			first = stream.Msg()
+		}
+		kinds = append(kinds, stream.Msg().GetKind())
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatal(err)
+	}
+	want := []controlv1.KillProgressKind{
+		controlv1.KillProgressKind_KILL_PROGRESS_KIND_ENGAGED,
+		controlv1.KillProgressKind_KILL_PROGRESS_KIND_CANCEL_REQUESTED,
+		controlv1.KillProgressKind_KILL_PROGRESS_KIND_SETTLED,
+	}
+	if len(kinds) != len(want) || kinds[0] != want[0] || kinds[1] != want[1] || kinds[2] != want[2] {
+		t.Fatalf("kinds = %v, want %v", kinds, want)
+	}
+	if first.GetKillSwitch().GetVenue() != "bybit" || first.GetKillSwitch().GetReason() != "drill" || first.GetActiveOrders() != 1 {
+		t.Fatalf("engaged = %+v", first)
+	}
+
+	list, err := client.ListKillSwitches(t.Context(), connect.NewRequest(&controlv1.ListKillSwitchesRequest{}))
+	if err != nil || len(list.Msg.GetKillSwitches()) != 1 {
+		t.Fatalf("ListKillSwitches = %v, %v", list, err)
+	}
+	_, err = client.ReleaseKillSwitch(t.Context(), connect.NewRequest(&controlv1.ReleaseKillSwitchRequest{Venue: "binance"}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("ReleaseKillSwitch code = %s", connect.CodeOf(err))
+	}
+}
@@ -0,0 +1,218 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/ledger"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/ports"
+	"github.com/romanornr/delta-works/internal/service/mark"
+)
+
+const defaultClosureLimit int32 = 1000
+
+// LedgerServer serves control.v1.LedgerService: realized profit from the
+// ledger tables, unrealized profit from the mark-to-market service.
+type LedgerServer struct {
+	store ports.LedgerQueryStore
+	marks *mark.Service
+}
+
+// NewLedgerServer builds the LedgerService handler.
+func NewLedgerServer(store ports.LedgerQueryStore, marks *mark.Service) *LedgerServer {
+	return &LedgerServer{store: store, marks: marks}
+}
+
+// GetRealizedPnL reports each inventory's totals over the whole window,
+// summed in SQL, on the first page. Only the closures are paged: later
+// pages carry the next closures under entries without totals, so no page
+// repeats or splits a total.
+func (s *LedgerServer) GetRealizedPnL(ctx context.Context, req *connect.Request[controlv1.GetRealizedPnLRequest]) (*connect.Response[controlv1.GetRealizedPnLResponse], error) {
+	query, err := inventoryFilter(req.Msg.GetBotId(), req.Msg.GetVenue(), req.Msg.GetPair())
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	query.From, query.To = req.Msg.GetStartTime().AsTime(), req.Msg.GetEndTime().AsTime()
+	query.Limit = req.Msg.GetLimit()
+	if query.Limit == 0 {
+		query.Limit = defaultClosureLimit
+	}
+	digest := closureFilterDigest(query)
+	first := req.Msg.GetPageToken() == ""
+	var totals []ledger.ClosureTotal
+	var fees []ledger.FeeTotal
+	if first {
+		if totals, err = s.store.SumClosedLots(ctx, query); err != nil {
+			return nil, mapOrderError(err)
+		}
+		if fees, err = s.store.SumFeeCharges(ctx, query); err != nil {
+			return nil, mapOrderError(err)
+		}
+	} else {
+		cursor, err := decodeClosurePageToken(req.Msg.GetPageToken(), digest)
+		if err != nil {
+			return nil, mapOrderError(err)
+		}
+		query.CursorClosedAt, query.CursorID = &cursor.ClosedAt, &cursor.ClosureID
+	}
+	var closures []ledger.ClosedLot
+	hasMore := false
+	if req.Msg.GetIncludeClosures() {
+		if closures, err = s.store.ListClosedLots(ctx, query); err != nil {
+			return nil, mapOrderError(err)
+		}
+		if hasMore = len(closures) > int(query.Limit); hasMore {
+			closures = closures[:query.Limit]
+		}
+	}
+	response := &controlv1.GetRealizedPnLResponse{}
+	for _, pnl := range ledger.Realize(totals, fees, closures) {
+		response.Entries = append(response.Entries, toProtoPnL(pnl, first))
+	}
+	if hasMore {
+		last := closures[len(closures)-1]
+		response.NextPageToken, err = encodePageToken(closurePageToken{V: 1, ClosedAt: last.ClosedAt, ClosureID: last.ClosureID, FilterDigest: digest})
+		if err != nil {
+			return nil, mapOrderError(err)
+		}
+	}
+	return connect.NewResponse(response), nil
+}
+
+type closurePageToken struct {
+	V            int       `json:"v"`
+	ClosedAt     time.Time `json:"closed_at"`
+	ClosureID    int64     `json:"closure_id"`
+	FilterDigest string    `json:"filter_digest"`
+}
+
+func decodeClosurePageToken(encoded, digest string) (closurePageToken, error) {
+	var token closurePageToken
+	if err := decodeToken(encoded, &token); err != nil {
+		return closurePageToken{}, err
+	}
+	if token.V != 1 || token.ClosedAt.IsZero() || token.ClosureID <= 0 || token.FilterDigest != digest {
+		return closurePageToken{}, fmt.Errorf("%w: page token", errInvalidArgument)
+	}
+	return token, nil
+}
+
+// closureFilterDigest binds a page token to the filters and window it was
+// issued for.
+func closureFilterDigest(query ledger.ClosureQuery) string {
+	deref := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	canonical, _ := json.Marshal(struct {
+		BotID string    `json:"bot_id"`
+		Venue string    `json:"venue"`
+		Base  string    `json:"base"`
+		Quote string    `json:"quote"`
+		From  time.Time `json:"from"`
+		To    time.Time `json:"to"`
+	}{deref(query.BotID), deref(query.Venue), deref(query.Base), deref(query.Quote), query.From.UTC(), query.To.UTC()})
+	digest := sha256.Sum256(canonical)
+	return hex.EncodeToString(digest[:])
+}
+
+// GetUnrealizedPnL filters the mark service's latest valuations.
+func (s *LedgerServer) GetUnrealizedPnL(_ context.Context, req *connect.Request[controlv1.GetUnrealizedPnLRequest]) (*connect.Response[controlv1.GetUnrealizedPnLResponse], error) {
+	filter, err := inventoryFilter(req.Msg.GetBotId(), req.Msg.GetVenue(), req.Msg.GetPair())
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	response := &controlv1.GetUnrealizedPnLResponse{}
+	for _, v := range s.marks.Current() {
+		if !matches(filter.BotID, v.BotID) || !matches(filter.Venue, string(v.Venue)) ||
+			!matches(filter.Base, string(v.Base)) || !matches(filter.Quote, string(v.Quote)) {
+			continue
+		}
+		response.Entries = append(response.Entries, &controlv1.UnrealizedPnL{
+			BotId: v.BotID, Venue: string(v.Venue), Base: string(v.Base), Quote: string(v.Quote),
+			Qty: v.Qty.String(), Cost: v.Cost.String(), Mark: v.Mark.String(), Value: v.Value.String(),
+			Unrealized: v.Unrealized.String(), UnpricedQty: v.UnpricedQty.String(), MarkedAt: timestamppb.New(v.At),
+		})
+	}
+	return connect.NewResponse(response), nil
+}
+
+// inventoryFilter parses the bot, venue and pair filters shared by the
+// ledger RPCs. Empty values stay nil and match everything.
+func inventoryFilter(bot, venue, pair string) (ledger.ClosureQuery, error) {
+	var query ledger.ClosureQuery
+	if bot != "" {
+		query.BotID = &bot
+	}
+	if venue != "" {
+		normalized := string(instrument.NewVenueID(venue))
+		query.Venue = &normalized
+	}
+	if pair != "" {
+		base, quote, err := instrument.ParsePair(pair)
+		if err != nil {
+			return ledger.ClosureQuery{}, fmt.Errorf("%w: pair: %w", errInvalidArgument, err)
+		}
+		baseCode, quoteCode := string(base), string(quote)
+		query.Base, query.Quote = &baseCode, &quoteCode
+	}
+	return query, nil
+}
+
+func matches(filter *string, value string) bool {
+	return filter == nil || *filter == value
+}
+
+// toProtoPnL converts one inventory's PnL; withTotals false leaves only
+// its identity and closures, for the pages after the first.
+func toProtoPnL(pnl ledger.PnL, withTotals bool) *controlv1.RealizedPnL {
+	out := &controlv1.RealizedPnL{
+		BotId: pnl.BotID, Venue: string(pnl.Venue), Base: string(pnl.Base), Quote: string(pnl.Quote),
+		Closures: toProtoClosures(pnl.Closures),
+	}
+	if !withTotals {
+		return out
+	}
+	out.Qty, out.Cost, out.Proceeds = pnl.Qty.String(), pnl.Cost.String(), pnl.Proceeds.String()
+	out.Realized, out.UnpricedQty, out.FeeQty = pnl.Realized.String(), pnl.UnpricedQty.String(), pnl.FeeQty.String()
+	currencies := make([]money.Currency, 0, len(pnl.OtherFees))
+	for currency := range pnl.OtherFees {
+		currencies = append(currencies, currency)
+	}
+	slices.Sort(currencies)
+	for _, currency := range currencies {
+		out.OtherFees = append(out.OtherFees, &controlv1.FeeAmount{
+			Currency: string(currency), Amount: pnl.OtherFees[currency].String(),
+		})
+	}
+	return out
+}
+
+func toProtoClosures(closures []ledger.ClosedLot) []*controlv1.LotClosure {
+	var out []*controlv1.LotClosure
+	for _, c := range closures {
+		closure := &controlv1.LotClosure{
+			ClosureId: c.ClosureID, LotId: c.LotID, BuyFillId: c.BuyFillID, SellFillId: c.SellFillID,
+			Kind: string(c.Kind), Qty: c.Qty.String(), CostPrice: c.CostPrice.String(), SellPrice: c.SellPrice.String(),
+			Cost: c.Cost.String(), Fee: c.Fee.String(), ClosedAt: timestamppb.New(c.ClosedAt),
+		}
+		if c.Priced() {
+			closure.Realized = c.Realized().String()
+		}
+		out = append(out, closure)
+	}
+	return out
+}
@@ -0,0 +1,249 @@
+package api
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/api/gen/control/v1/controlv1connect"
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/ledger"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/exchange"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+	"github.com/romanornr/delta-works/internal/service/mark"
+)
+
+type fakeLedgerStore struct {
+	query    ledger.ClosureQuery
+	closures []ledger.ClosedLot
+	fees     []ledger.FeeTotal
+}
+
+// ListClosedLots pages the closures like the store: after the cursor, at
+// most Limit+1 rows.
+func (f *fakeLedgerStore) ListClosedLots(_ context.Context, query ledger.ClosureQuery) ([]ledger.ClosedLot, error) {
+	f.query = query
+	var out []ledger.ClosedLot
+	for _, c := range f.closures {
+		if query.CursorID != nil && c.ClosureID <= *query.CursorID {
+			continue
+		}
+		if len(out) > int(query.Limit) {
+			break
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// SumClosedLots totals every closure, whatever the page.
+func (f *fakeLedgerStore) SumClosedLots(context.Context, ledger.ClosureQuery) ([]ledger.ClosureTotal, error) {
+	return ledger.SumClosures(f.closures), nil
+}
+
+func (f *fakeLedgerStore) SumFeeCharges(context.Context, ledger.ClosureQuery) ([]ledger.FeeTotal, error) {
+	return f.fees, nil
+}
+
+func newLedgerClient(t *testing.T, store *fakeLedgerStore, marks *mark.Service) controlv1connect.LedgerServiceClient {
+	t.Helper()
+	eventBus := bus.NewInProc()
+	t.Cleanup(eventBus.Close)
+	srv := httptest.NewServer(NewServer(Handlers{Events: testEventServer(t, eventBus), Ledger: NewLedgerServer(store, marks)}, nil).Handler)
+	t.Cleanup(srv.Close)
+	return controlv1connect.NewLedgerServiceClient(srv.Client(), srv.URL)
+}
+
+func TestGetRealizedPnL(t *testing.T) {
+	t.Parallel()
+	from := time.Date(2026, 7, 12, 0, 0, 0, 0, time.UTC)
+	store := &fakeLedgerStore{closures: []ledger.ClosedLot{{
+		ClosureID: 1, LotID: "lot-1", BotID: "grid-1", Venue: "bybit", Base: "BTC", Quote: "USDT",
+		Kind: ledger.ClosureSale, Qty: decimal.RequireFromString("0.5"), CostPrice: decimal.NewFromInt(100),
+		SellPrice: decimal.NewFromInt(102), Cost: decimal.NewFromInt(50), Fee: decimal.RequireFromString("0.1"),
+		BuyFillID: 10, SellFillID: 11, ClosedAt: from.Add(time.Hour),
+	}}, fees: []ledger.FeeTotal{{
+		BotID: "grid-1", Venue: "bybit", Base: "BTC", Quote: "USDT",
+		Currency: "BNB", Amount: decimal.RequireFromString("0.002"),
+	}}}
+	client := newLedgerClient(t, store, nil)
+
+	resp, err := client.GetRealizedPnL(t.Context(), connect.NewRequest(&controlv1.GetRealizedPnLRequest{
+		BotId: "grid-1", Venue: "ByBit", Pair: "btc/usdt",
+		StartTime: timestamppb.New(from), EndTime: timestamppb.New(from.Add(24 * time.Hour)),
+		IncludeClosures: true,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := store.query
+	if *q.BotID != "grid-1" || *q.Venue != "bybit" || *q.Base != "BTC" || *q.Quote != "USDT" || !q.From.Equal(from) || q.Limit != defaultClosureLimit {
+		t.Fatalf("query = %+v", q)
+	}
+	entries := resp.Msg.GetEntries()
+	if len(entries) != 1 || entries[0].GetRealized() != "0.9" || entries[0].GetProceeds() != "50.9" {
+		t.Fatalf("entries = %+v", entries)
+	}
+	if fees := entries[0].GetOtherFees(); len(fees) != 1 || fees[0].GetCurrency() != "BNB" || fees[0].GetAmount() != "0.002" {
+		t.Fatalf("other fees = %+v", fees)
+	}
+	closures := entries[0].GetClosures()
+	if len(closures) != 1 || closures[0].GetBuyFillId() != 10 || closures[0].GetSellFillId() != 11 ||
+		closures[0].GetRealized() != "0.9" || closures[0].GetKind() != "sale" || closures[0].GetFee() != "0.1" {
+		t.Fatalf("closures = %+v", closures)
+	}
+
+	bad := []*controlv1.GetRealizedPnLRequest{
+		{Pair: "BTCUSDT", StartTime: timestamppb.New(from), EndTime: timestamppb.New(from.Add(time.Hour))},
+		{StartTime: timestamppb.New(from), EndTime: timestamppb.New(from)},
+		{EndTime: timestamppb.New(from)},
+	}
+	for _, request := range bad {
+		if _, err := client.GetRealizedPnL(t.Context(), connect.NewRequest(request)); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Fatalf("request %+v: code = %s, want invalid argument", request, connect.CodeOf(err))
+		}
+	}
+}
+
+func TestGetRealizedPnLPages(t *testing.T) {
+	t.Parallel()
+	from := time.Date(2026, 7, 12, 0, 0, 0, 0, time.UTC)
+	store := &fakeLedgerStore{fees: []ledger.FeeTotal{{
+		BotID: "grid-1", Venue: "bybit", Base: "BTC", Quote: "USDT", Currency: "BNB", Amount: decimal.RequireFromString("0.002"),
+	}}}
+	for i := range 3 {
+		store.closures = append(store.closures, ledger.ClosedLot{
+			ClosureID: int64(i + 1), LotID: "lot-1", BotID: "grid-1", Venue: "bybit", Base: "BTC", Quote: "USDT",
+			Kind: ledger.ClosureSale, Qty: decimal.NewFromInt(1), CostPrice: decimal.NewFromInt(100),
+			SellPrice: decimal.NewFromInt(101), Cost: decimal.NewFromInt(100), ClosedAt: from.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	client := newLedgerClient(t, store, nil)
+	request := &controlv1.GetRealizedPnLRequest{
+		BotId: "grid-1", StartTime: timestamppb.New(from), EndTime: timestamppb.New(from.Add(time.Hour)), Limit: 2,
+	}
+
+	// Without closures there is nothing to page: the totals cover the window.
+	totals, err := client.GetRealizedPnL(t.Context(), connect.NewRequest(request))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entries := totals.Msg.GetEntries(); len(entries) != 1 || entries[0].GetQty() != "3" || entries[0].GetRealized() != "3" ||
+		len(entries[0].GetClosures()) != 0 || totals.Msg.GetNextPageToken() != "" {
+		t.Fatalf("totals = %+v", totals.Msg)
+	}
+
+	request.IncludeClosures = true
+	first, err := client.GetRealizedPnL(t.Context(), connect.NewRequest(request))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entries := first.Msg.GetEntries(); len(entries) != 1 || entries[0].GetQty() != "3" || len(entries[0].GetClosures()) != 2 ||
+		len(entries[0].GetOtherFees()) != 1 || first.Msg.GetNextPageToken() == "" {
+		t.Fatalf("first page = %+v", first.Msg)
+	}
+	request.PageToken = first.Msg.GetNextPageToken()
+	second, err := client.GetRealizedPnL(t.Context(), connect.NewRequest(request))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Later pages carry closures only, so no total is counted twice.
+	if entries := second.Msg.GetEntries(); len(entries) != 1 || entries[0].GetQty() != "" || entries[0].GetRealized() != "" ||
+		len(entries[0].GetOtherFees()) != 0 || len(entries[0].GetClosures()) != 1 || second.Msg.GetNextPageToken() != "" {
+		t.Fatalf("second page = %+v", second.Msg)
+	}
+	if *store.query.CursorID != 2 || !store.query.CursorClosedAt.Equal(from.Add(time.Minute)) {
+		t.Fatalf("cursor = %+v", store.query)
+	}
+
+	request.BotId = "grid-2"
+	if _, err := client.GetRealizedPnL(t.Context(), connect.NewRequest(request)); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("token reused with other filters: code = %s, want invalid argument", connect.CodeOf(err))
+	}
+}
+
+type fakeMarketData struct{}
+
+func (fakeMarketData) ID() instrument.VenueID { return "bybit" }
+
+func (fakeMarketData) Ticker(_ context.Context, inst instrument.Instrument) (marketdata.Ticker, error) {
+	return marketdata.Ticker{Instrument: inst, Last: decimal.NewFromInt(110)}, nil
+}
+
+func (fakeMarketData) Instruments(context.Context, instrument.Type) ([]instrument.Instrument, error) {
+	return nil, nil
+}
+
+func (fakeMarketData) Balances(context.Context, account.Type) ([]account.Balance, error) {
+	return nil, nil
+}
+
+type fakeOpenLots []ledger.Lot
+
+func (f fakeOpenLots) ListOpenLots(context.Context) ([]ledger.Lot, error) { return f, nil }
+
+type discardValuations struct{}
+
+func (discardValuations) WriteValuation(context.Context, ledger.Valuation) error { return nil }
+func (discardValuations) Flush(context.Context) error                            { return nil }
+
+func TestGetUnrealizedPnL(t *testing.T) {
+	t.Parallel()
+	lot := func(bot, base string) ledger.Lot {
+		return ledger.Lot{
+			BotID: bot, Venue: "bybit", Base: money.Currency(base), Quote: "USDT",
+			Qty: decimal.NewFromInt(1), RemainingQty: decimal.NewFromInt(1),
+			CostPrice: decimal.NewFromInt(100), Cost: decimal.NewFromInt(100), RemainingCost: decimal.NewFromInt(100),
+		}
+	}
+	metrics, err := mark.NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	marks := mark.New(fakeOpenLots{lot("grid-1", "BTC"), lot("grid-1", "ETH"), lot("manual", "BTC")},
+		exchange.NewRegistry([]ports.Exchange{fakeMarketData{}}), discardValuations{},
+		clockwork.NewFakeClock(), log.Nop(), time.Minute, marketdata.PriceLast, metrics)
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() { done <- marks.Run(ctx) }()
+	t.Cleanup(func() { cancel(); <-done })
+	for deadline := time.Now().Add(5 * time.Second); len(marks.Current()) != 3; {
+		if time.Now().After(deadline) {
+			t.Fatal("mark service never valued the lots")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	client := newLedgerClient(t, &fakeLedgerStore{}, marks)
+
+	resp, err := client.GetUnrealizedPnL(t.Context(), connect.NewRequest(&controlv1.GetUnrealizedPnLRequest{
+		BotId: "grid-1", Pair: "btc/usdt",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := resp.Msg.GetEntries()
+	if len(entries) != 1 || entries[0].GetBotId() != "grid-1" || entries[0].GetBase() != "BTC" ||
+		entries[0].GetMark() != "110" || entries[0].GetUnrealized() != "10" || entries[0].GetMarkedAt() == nil {
+		t.Fatalf("entries = %+v", entries)
+	}
+	all, err := client.GetUnrealizedPnL(t.Context(), connect.NewRequest(&controlv1.GetUnrealizedPnLRequest{}))
+	if err != nil || len(all.Msg.GetEntries()) != 3 {
+		t.Fatalf("unfiltered = %v, %v", all, err)
+	}
+	if _, err := client.GetUnrealizedPnL(t.Context(), connect.NewRequest(&controlv1.GetUnrealizedPnLRequest{Pair: "BTCUSDT"})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("bad pair: code = %s, want invalid argument", connect.CodeOf(err))
+	}
+}
@@ -11,9 +11,10 @@ import (
 )
 
 // Listen opens the control-plane listener. A "unix://" address listens on a
-// Unix socket restricted to the owning user: file permissions are the whole
-// auth story until a TCP deployment adds token auth (ADR-0007). Anything
-// else is a TCP host:port.
+// Unix socket restricted to the owning user, where file permissions are the
+// whole auth story (ADR-0007). Anything else is a TCP host:port, which
+// config only allows with api.auth set so the server carries an Authorizer
+// (ADR-0012).
 func Listen(ctx context.Context, addr string) (net.Listener, error) {
 	path, ok := strings.CutPrefix(addr, "unix://")
 	if !ok {
@@ -2,19 +2,33 @@ package api
 
 import "github.com/prometheus/client_golang/prometheus"
 
-// Metrics holds control-plane event conversion instruments.
+// Metrics holds control-plane event stream and authorization instruments.
 type Metrics struct {
 	malformed *prometheus.CounterVec
+	gaps      *prometheus.CounterVec
+	denied    *prometheus.CounterVec
 }
 
 // NewMetrics registers control-plane metrics.
 func NewMetrics(reg *prometheus.Registry) (*Metrics, error) {
-	m := &Metrics{malformed: prometheus.NewCounterVec(prometheus.CounterOpts{
-		Name: "api_event_payload_malformed_total",
-		Help: "Bus events skipped because their payload could not be decoded for the subject.",
-	}, []string{"subject"})}
-	if err := reg.Register(m.malformed); err != nil {
-		return nil, err
+	m := &Metrics{
+		malformed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "api_event_payload_malformed_total",
+			Help: "Bus events skipped because their payload could not be decoded for the subject.",
+		}, []string{"subject"}),
+		gaps: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "api_event_stream_gaps_total",
+			Help: "Gap markers sent on event streams, by reason.",
+		}, []string{"reason"}),
+		denied: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "api_requests_denied_total",
+			Help: "Control-plane requests refused by token authorization: unauthenticated or forbidden.",
+		}, []string{"procedure", "reason"}),
+	}
+	for _, c := range []prometheus.Collector{m.malformed, m.gaps, m.denied} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
 	}
 	return m, nil
 }
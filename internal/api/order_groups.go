@@ -0,0 +1,215 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	domain "github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/service/group"
+	orderservice "github.com/romanornr/delta-works/internal/service/order"
+)
+
+const defaultGroupLimit int32 = 50
+
+// OrderGroupServer serves control.v1.OrderGroupService.
+type OrderGroupServer struct {
+	groups *group.Service
+}
+
+// NewOrderGroupServer builds the OrderGroupService handler.
+func NewOrderGroupServer(service *group.Service) *OrderGroupServer {
+	return &OrderGroupServer{groups: service}
+}
+
+// PlaceOrderGroup stores the group and places its first orders.
+func (s *OrderGroupServer) PlaceOrderGroup(ctx context.Context, req *connect.Request[controlv1.PlaceOrderGroupRequest]) (*connect.Response[controlv1.PlaceOrderGroupResponse], error) {
+	g, err := fromProtoGroupRequest(req.Msg)
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	view, err := s.groups.Place(ctx, g)
+	unsettled := errors.Is(err, orderservice.ErrSubmitUnsettled)
+	if err != nil && !unsettled {
+		// A refused leg leaves the group stored and canceled, its reason
+		// recording the refusal returned here.
+		return nil, mapOrderError(err)
+	}
+	return connect.NewResponse(&controlv1.PlaceOrderGroupResponse{Group: toProtoGroup(view), SubmitUnsettled: unsettled}), nil
+}
+
+// CancelOrderGroup cancels the group's working orders and the group.
+func (s *OrderGroupServer) CancelOrderGroup(ctx context.Context, req *connect.Request[controlv1.CancelOrderGroupRequest]) (*connect.Response[controlv1.CancelOrderGroupResponse], error) {
+	view, err := s.groups.Cancel(ctx, domain.GroupID(req.Msg.GetGroupId()))
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	return connect.NewResponse(&controlv1.CancelOrderGroupResponse{Group: toProtoGroup(view)}), nil
+}
+
+// GetOrderGroup returns the group with its legs' orders.
+func (s *OrderGroupServer) GetOrderGroup(ctx context.Context, req *connect.Request[controlv1.GetOrderGroupRequest]) (*connect.Response[controlv1.GetOrderGroupResponse], error) {
+	view, err := s.groups.Get(ctx, domain.GroupID(req.Msg.GetGroupId()))
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	return connect.NewResponse(&controlv1.GetOrderGroupResponse{Group: toProtoGroup(view)}), nil
+}
+
+// ListOrderGroups returns the newest groups.
+func (s *OrderGroupServer) ListOrderGroups(ctx context.Context, req *connect.Request[controlv1.ListOrderGroupsRequest]) (*connect.Response[controlv1.ListOrderGroupsResponse], error) {
+	limit := req.Msg.GetLimit()
+	if limit == 0 {
+		limit = defaultGroupLimit
+	}
+	groups, err := s.groups.List(ctx, instrument.NewVenueID(req.Msg.GetVenue()), req.Msg.GetOpenOnly(), limit)
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	response := &controlv1.ListOrderGroupsResponse{Groups: make([]*controlv1.OrderGroup, 0, len(groups))}
+	for _, g := range groups {
+		response.Groups = append(response.Groups, toProtoGroup(group.View{Group: g}))
+	}
+	return connect.NewResponse(response), nil
+}
+
+// fromProtoGroupRequest builds the group's legs from the request's terms:
+// a bracket's entry on side with its exits on the other, or an OCO pair on
+// side.
+func fromProtoGroupRequest(msg *controlv1.PlaceOrderGroupRequest) (domain.Group, error) {
+	qty, err := decimal.NewFromString(msg.GetQty())
+	if err != nil {
+		return domain.Group{}, fmt.Errorf("%w: qty", errInvalidArgument)
+	}
+	prices := map[string]decimal.Decimal{}
+	for field, value := range map[string]string{
+		"entry_price":             msg.GetEntryPrice(),
+		"take_profit_price":       msg.GetTakeProfitPrice(),
+		"stop_loss_trigger_price": msg.GetStopLossTriggerPrice(),
+		"stop_loss_price":         msg.GetStopLossPrice(),
+	} {
+		if value == "" {
+			continue
+		}
+		if prices[field], err = decimal.NewFromString(value); err != nil {
+			return domain.Group{}, fmt.Errorf("%w: %s", errInvalidArgument, field)
+		}
+	}
+	g := domain.Group{
+		ID: domain.GroupID(msg.GetGroupId()), Kind: fromProtoGroupKind(msg.GetKind()), BotID: "manual",
+		Instrument: instrument.Instrument{
+			Venue: instrument.NewVenueID(msg.GetVenue()), Type: instrument.TypeSpot,
+			Base: money.NewCurrency(msg.GetBase()), Quote: money.NewCurrency(msg.GetQuote()),
+		},
+	}
+	exitSide := fromProtoSide(msg.GetSide())
+	if g.Kind == domain.GroupBracket {
+		entrySide := exitSide
+		exitSide = domain.Buy
+		if entrySide == domain.Buy {
+			exitSide = domain.Sell
+		}
+		g.Legs = append(g.Legs, domain.Leg{
+			Role: domain.RoleEntry, ClientOrderID: domain.ClientOrderID(msg.GetEntryClientOrderId()),
+			Side: entrySide, Type: fromProtoOrderType(msg.GetEntryType()), Price: prices["entry_price"], Qty: qty,
+		})
+	}
+	stopType := domain.StopMarket
+	if msg.GetStopLossPrice() != "" {
+		stopType = domain.StopLimit
+	}
+	g.Legs = append(g.Legs,
+		domain.Leg{
+			Role: domain.RoleTakeProfit, ClientOrderID: domain.ClientOrderID(msg.GetTakeProfitClientOrderId()),
+			Side: exitSide, Type: domain.Limit, Price: prices["take_profit_price"], Qty: qty,
+		},
+		domain.Leg{
+			Role: domain.RoleStopLoss, ClientOrderID: domain.ClientOrderID(msg.GetStopLossClientOrderId()),
+			Side: exitSide, Type: stopType, Price: prices["stop_loss_price"], Qty: qty,
+			TriggerPrice: prices["stop_loss_trigger_price"],
+		},
+	)
+	return g, nil
+}
+
+func toProtoGroup(view group.View) *controlv1.OrderGroup {
+	g := view.Group
+	msg := &controlv1.OrderGroup{
+		GroupId: string(g.ID), Kind: toProtoGroupKind(g.Kind), Status: toProtoGroupStatus(g.Status),
+		Venue: string(g.Instrument.Venue), Base: string(g.Instrument.Base), Quote: string(g.Instrument.Quote),
+		BotId: g.BotID, Reason: g.Reason,
+		CreatedAt: timestamppb.New(g.CreatedAt), UpdatedAt: timestamppb.New(g.UpdatedAt),
+	}
+	for _, leg := range g.Legs {
+		l := &controlv1.OrderGroupLeg{
+			Role: toProtoGroupRole(leg.Role), ClientOrderId: string(leg.ClientOrderID),
+			Side: toProtoSide(leg.Side), Type: toProtoOrderType(leg.Type),
+			Price: leg.Price.String(), Qty: leg.Qty.String(),
+		}
+		if leg.Type.Stop() {
+			l.TriggerPrice = leg.TriggerPrice.String()
+		}
+		if rec, ok := view.Orders[leg.ClientOrderID]; ok {
+			l.Status, l.FilledQty = toProtoOrderStatus(rec.Status), rec.FilledQty.String()
+		}
+		msg.Legs = append(msg.Legs, l)
+	}
+	return msg
+}
+
+func fromProtoGroupKind(kind controlv1.OrderGroupKind) domain.GroupKind {
+	switch kind {
+	case controlv1.OrderGroupKind_ORDER_GROUP_KIND_OCO:
+		return domain.GroupOCO
+	case controlv1.OrderGroupKind_ORDER_GROUP_KIND_BRACKET:
+		return domain.GroupBracket
+	default:
+		return ""
+	}
+}
+
+func toProtoGroupKind(kind domain.GroupKind) controlv1.OrderGroupKind {
+	switch kind {
+	case domain.GroupOCO:
+		return controlv1.OrderGroupKind_ORDER_GROUP_KIND_OCO
+	case domain.GroupBracket:
+		return controlv1.OrderGroupKind_ORDER_GROUP_KIND_BRACKET
+	default:
+		return controlv1.OrderGroupKind_ORDER_GROUP_KIND_UNSPECIFIED
+	}
+}
+
+func toProtoGroupStatus(status domain.GroupStatus) controlv1.OrderGroupStatus {
+	switch status {
+	case domain.GroupPending:
+		return controlv1.OrderGroupStatus_ORDER_GROUP_STATUS_PENDING
+	case domain.GroupActive:
+		return controlv1.OrderGroupStatus_ORDER_GROUP_STATUS_ACTIVE
+	case domain.GroupCompleted:
+		return controlv1.OrderGroupStatus_ORDER_GROUP_STATUS_COMPLETED
+	case domain.GroupCanceled:
+		return controlv1.OrderGroupStatus_ORDER_GROUP_STATUS_CANCELED
+	default:
+		return controlv1.OrderGroupStatus_ORDER_GROUP_STATUS_UNSPECIFIED
+	}
+}
+
+func toProtoGroupRole(role domain.Role) controlv1.OrderGroupRole {
+	switch role {
+	case domain.RoleEntry:
+		return controlv1.OrderGroupRole_ORDER_GROUP_ROLE_ENTRY
+	case domain.RoleTakeProfit:
+		return controlv1.OrderGroupRole_ORDER_GROUP_ROLE_TAKE_PROFIT
+	case domain.RoleStopLoss:
+		return controlv1.OrderGroupRole_ORDER_GROUP_ROLE_STOP_LOSS
+	default:
+		return controlv1.OrderGroupRole_ORDER_GROUP_ROLE_UNSPECIFIED
+	}
+}
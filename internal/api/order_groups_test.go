@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/api/gen/control/v1/controlv1connect"
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	domain "github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+	"github.com/romanornr/delta-works/internal/service/group"
+	orderservice "github.com/romanornr/delta-works/internal/service/order"
+)
+
+// fakeGroups is a group store and an order service in one: placed orders
+// rest open, canceled ones end canceled.
+type fakeGroups struct {
+	mu     sync.Mutex
+	groups []domain.Group
+	orders map[domain.ClientOrderID]domain.Record
+}
+
+func (f *fakeGroups) CreateGroup(_ context.Context, g domain.Group) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.groups = append(f.groups, g)
+	return true, nil
+}
+
+func (f *fakeGroups) GetGroup(_ context.Context, id domain.GroupID) (domain.Group, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, g := range f.groups {
+		if g.ID == id {
+			return g, nil
+		}
+	}
+	return domain.Group{}, ports.ErrNotFound
+}
+
+func (f *fakeGroups) GroupOf(context.Context, domain.ClientOrderID) (domain.Group, error) {
+	return domain.Group{}, ports.ErrNotFound
+}
+
+func (f *fakeGroups) ListGroups(_ context.Context, venue instrument.VenueID, openOnly bool, _ int32) ([]domain.Group, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []domain.Group
+	for _, g := range f.groups {
+		if (venue == "" || g.Instrument.Venue == venue) && (!openOnly || !g.Status.Final()) {
+			out = append(out, g)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeGroups) ListOpenGroups(ctx context.Context) ([]domain.Group, error) {
+	return f.ListGroups(ctx, "", true, 0)
+}
+
+func (f *fakeGroups) SetGroupStatus(_ context.Context, id domain.GroupID, status domain.GroupStatus, reason string, at time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, g := range f.groups {
+		if g.ID == id {
+			f.groups[i].Status, f.groups[i].Reason, f.groups[i].UpdatedAt = status, reason, at
+			return nil
+		}
+	}
+	return ports.ErrNotFound
+}
+
+func (f *fakeGroups) GetOrder(_ context.Context, id domain.ClientOrderID) (domain.Record, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	rec, ok := f.orders[id]
+	if !ok {
+		return domain.Record{}, ports.ErrNotFound
+	}
+	return rec, nil
+}
+
+func (f *fakeGroups) Place(_ context.Context, req domain.Request) (orderservice.PlaceResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.orders[req.ClientOrderID] = domain.Record{ClientOrderID: req.ClientOrderID, Status: domain.StatusOpen}
+	return orderservice.PlaceResult{ClientOrderID: req.ClientOrderID, Status: domain.StatusOpen}, nil
+}
+
+func (f *fakeGroups) Cancel(_ context.Context, id domain.ClientOrderID) (domain.Status, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	rec := f.orders[id]
+	rec.Status = domain.StatusCanceled
+	f.orders[id] = rec
+	return domain.StatusCanceled, nil
+}
+
+func TestOrderGroupService(t *testing.T) {
+	t.Parallel()
+	fake := &fakeGroups{orders: map[domain.ClientOrderID]domain.Record{}}
+	metrics, err := group.NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventBus := bus.NewInProc()
+	t.Cleanup(eventBus.Close)
+	service := group.New(fake, fake, eventBus, clockwork.NewFakeClock(), log.Nop(), time.Minute, metrics)
+	srv := httptest.NewServer(NewServer(Handlers{Events: testEventServer(t, eventBus), OrderGroups: NewOrderGroupServer(service)}, nil).Handler)
+	t.Cleanup(srv.Close)
+	client := controlv1connect.NewOrderGroupServiceClient(srv.Client(), srv.URL)
+
+	bracket := &controlv1.PlaceOrderGroupRequest{
+		Kind: controlv1.OrderGroupKind_ORDER_GROUP_KIND_BRACKET, Venue: "ByBit", Base: "btc", Quote: "usdt",
+		Side: controlv1.Side_SIDE_BUY, Qty: "2", EntryType: controlv1.OrderType_ORDER_TYPE_LIMIT, EntryPrice: "100",
+		TakeProfitPrice: "110", StopLossTriggerPrice: "95",
+	}
+	placed, err := client.PlaceOrderGroup(t.Context(), connect.NewRequest(bracket))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := placed.Msg.GetGroup()
+	legs := g.GetLegs()
+	if g.GetStatus() != controlv1.OrderGroupStatus_ORDER_GROUP_STATUS_PENDING || g.GetVenue() != "bybit" || len(legs) != 3 {
+		t.Fatalf("group = %v", g)
+	}
+	if legs[0].GetStatus() != controlv1.OrderStatus_ORDER_STATUS_OPEN || legs[1].GetSide() != controlv1.Side_SIDE_SELL ||
+		legs[1].GetStatus() != controlv1.OrderStatus_ORDER_STATUS_UNSPECIFIED ||
+		legs[2].GetType() != controlv1.OrderType_ORDER_TYPE_STOP_MARKET || legs[2].GetTriggerPrice() != "95" {
+		t.Fatalf("legs = %v; want a working entry and unplaced sell exits", legs)
+	}
+
+	got, err := client.GetOrderGroup(t.Context(), connect.NewRequest(&controlv1.GetOrderGroupRequest{GroupId: g.GetGroupId()}))
+	if err != nil || got.Msg.GetGroup().GetLegs()[0].GetClientOrderId() != legs[0].GetClientOrderId() {
+		t.Fatalf("GetOrderGroup = %v, %v", got, err)
+	}
+	canceled, err := client.CancelOrderGroup(t.Context(), connect.NewRequest(&controlv1.CancelOrderGroupRequest{GroupId: g.GetGroupId()}))
+	if err != nil || canceled.Msg.GetGroup().GetStatus() != controlv1.OrderGroupStatus_ORDER_GROUP_STATUS_CANCELED {
+		t.Fatalf("CancelOrderGroup = %v, %v", canceled, err)
+	}
+	_, err = client.CancelOrderGroup(t.Context(), connect.NewRequest(&controlv1.CancelOrderGroupRequest{GroupId: g.GetGroupId()}))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("second cancel = %v, want FailedPrecondition", err)
+	}
+	list, err := client.ListOrderGroups(t.Context(), connect.NewRequest(&controlv1.ListOrderGroupsRequest{OpenOnly: true}))
+	if err != nil || len(list.Msg.GetGroups()) != 0 {
+		t.Fatalf("open groups = %v, %v", list, err)
+	}
+	_, err = client.GetOrderGroup(t.Context(), connect.NewRequest(&controlv1.GetOrderGroupRequest{GroupId: "01J00000000000000000000009"}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("unknown group = %v, want NotFound", err)
+	}
+}
+
+func TestPlaceOrderGroupValidation(t *testing.T) {
+	t.Parallel()
+	fake := &fakeGroups{orders: map[domain.ClientOrderID]domain.Record{}}
+	metrics, err := group.NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventBus := bus.NewInProc()
+	t.Cleanup(eventBus.Close)
+	service := group.New(fake, fake, eventBus, clockwork.NewFakeClock(), log.Nop(), time.Minute, metrics)
+	srv := httptest.NewServer(NewServer(Handlers{Events: testEventServer(t, eventBus), OrderGroups: NewOrderGroupServer(service)}, nil).Handler)
+	t.Cleanup(srv.Close)
+	client := controlv1connect.NewOrderGroupServiceClient(srv.Client(), srv.URL)
+
+	oco := func() *controlv1.PlaceOrderGroupRequest {
+		return &controlv1.PlaceOrderGroupRequest{
+			Kind: controlv1.OrderGroupKind_ORDER_GROUP_KIND_OCO, Venue: "bybit", Base: "BTC", Quote: "USDT",
+			Side: controlv1.Side_SIDE_SELL, Qty: "1", TakeProfitPrice: "110", StopLossTriggerPrice: "95",
+		}
+	}
+	tests := []struct {
+		name string
+		edit func(*controlv1.PlaceOrderGroupRequest)
+	}{
+		{"oco with an entry", func(r *controlv1.PlaceOrderGroupRequest) { r.EntryType = controlv1.OrderType_ORDER_TYPE_MARKET }},
+		{"bracket without an entry", func(r *controlv1.PlaceOrderGroupRequest) { r.Kind = controlv1.OrderGroupKind_ORDER_GROUP_KIND_BRACKET }},
+		{"market entry with a price", func(r *controlv1.PlaceOrderGroupRequest) {
+			r.Kind, r.Side = controlv1.OrderGroupKind_ORDER_GROUP_KIND_BRACKET, controlv1.Side_SIDE_BUY
+			r.EntryType, r.EntryPrice = controlv1.OrderType_ORDER_TYPE_MARKET, "100"
+		}},
+		{"take-profit below the stop", func(r *controlv1.PlaceOrderGroupRequest) { r.TakeProfitPrice = "90" }},
+		{"malformed stop-loss price", func(r *controlv1.PlaceOrderGroupRequest) { r.StopLossPrice = "-1" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := oco()
+			tt.edit(req)
+			_, err := client.PlaceOrderGroup(t.Context(), connect.NewRequest(req))
+			if connect.CodeOf(err) != connect.CodeInvalidArgument {
+				t.Fatalf("err = %v, want InvalidArgument", err)
+			}
+		})
+	}
+	placed, err := client.PlaceOrderGroup(t.Context(), connect.NewRequest(oco()))
+	if err != nil || placed.Msg.GetGroup().GetStatus() != controlv1.OrderGroupStatus_ORDER_GROUP_STATUS_ACTIVE {
+		t.Fatalf("oco = %v, %v", placed, err)
+	}
+}
@@ -16,18 +16,40 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/shopspring/decimal"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/domain/arb"
+	"github.com/romanornr/delta-works/internal/domain/auth"
+	"github.com/romanornr/delta-works/internal/domain/execution"
+	"github.com/romanornr/delta-works/internal/domain/funds"
 	"github.com/romanornr/delta-works/internal/domain/instrument"
 	"github.com/romanornr/delta-works/internal/domain/money"
 	domain "github.com/romanornr/delta-works/internal/domain/order"
 	"github.com/romanornr/delta-works/internal/ports"
+	executionservice "github.com/romanornr/delta-works/internal/service/execution"
+	gridservice "github.com/romanornr/delta-works/internal/service/grid"
+	"github.com/romanornr/delta-works/internal/service/group"
 	orderservice "github.com/romanornr/delta-works/internal/service/order"
+	"github.com/romanornr/delta-works/internal/service/risk"
+	sinkservice "github.com/romanornr/delta-works/internal/service/sink"
 )
 
 const defaultOrderLimit int32 = 50
 
+// ErrorInfo domains scoping the reasons of pre-trade rejections, of
+// instrument rule violations and of order lifecycle outcomes.
+const (
+	riskErrorDomain  = "risk.delta-works"
+	ruleErrorDomain  = "instrument.delta-works"
+	orderErrorDomain = "order.delta-works"
+)
+
+// reasonReplaceUnsettled tags an Aborted cancel-replace; its metadata
+// carries the new client order ID that resumes it.
+const reasonReplaceUnsettled = "replace_unsettled"
+
 var errInvalidArgument = errors.New("invalid argument")
 
 // OrderServer serves control.v1.OrderService.
@@ -54,6 +76,13 @@ func (s *OrderServer) PlaceOrder(ctx context.Context, req *connect.Request[contr
 			return nil, mapOrderError(fmt.Errorf("%w: price", errInvalidArgument))
 		}
 	}
+	trigger := decimal.Zero
+	if req.Msg.GetTriggerPrice() != "" {
+		trigger, err = decimal.NewFromString(req.Msg.GetTriggerPrice())
+		if err != nil {
+			return nil, mapOrderError(fmt.Errorf("%w: trigger_price", errInvalidArgument))
+		}
+	}
 	request := domain.Request{
 		ClientOrderID: domain.ClientOrderID(req.Msg.GetClientOrderId()), BotID: "manual",
 		Instrument: instrument.Instrument{
@@ -61,7 +90,11 @@ func (s *OrderServer) PlaceOrder(ctx context.Context, req *connect.Request[contr
 			Base: money.NewCurrency(req.Msg.GetBase()), Quote: money.NewCurrency(req.Msg.GetQuote()),
 		},
 		Side: fromProtoSide(req.Msg.GetSide()), Type: fromProtoOrderType(req.Msg.GetType()),
-		Qty: qty, Price: price,
+		Qty: qty, Price: price, TriggerPrice: trigger,
+		TimeInForce: fromProtoTimeInForce(req.Msg.GetTimeInForce()), PostOnly: req.Msg.GetPostOnly(),
+	}
+	if req.Msg.GetExpiresAt() != nil {
+		request.ExpiresAt = req.Msg.GetExpiresAt().AsTime()
 	}
 	result, err := s.service.Place(ctx, request)
 	unsettled := errors.Is(err, orderservice.ErrSubmitUnsettled)
@@ -82,6 +115,36 @@ func (s *OrderServer) CancelOrder(ctx context.Context, req *connect.Request[cont
 	return connect.NewResponse(&controlv1.CancelOrderResponse{Status: toProtoOrderStatus(status)}), nil
 }
 
+// ReplaceOrder gives an active limit order new terms, amending it at the
+// venue when possible and canceling and replacing it otherwise.
+func (s *OrderServer) ReplaceOrder(ctx context.Context, req *connect.Request[controlv1.ReplaceOrderRequest]) (*connect.Response[controlv1.ReplaceOrderResponse], error) {
+	price, err := decimal.NewFromString(req.Msg.GetPrice())
+	if err != nil {
+		return nil, mapOrderError(fmt.Errorf("%w: price", errInvalidArgument))
+	}
+	qty, err := decimal.NewFromString(req.Msg.GetQty())
+	if err != nil {
+		return nil, mapOrderError(fmt.Errorf("%w: qty", errInvalidArgument))
+	}
+	result, err := s.service.Replace(ctx, orderservice.ReplaceRequest{
+		ClientOrderID:    domain.ClientOrderID(req.Msg.GetClientOrderId()),
+		NewClientOrderID: domain.ClientOrderID(req.Msg.GetNewClientOrderId()),
+		Price:            price,
+		Qty:              qty,
+	})
+	if errors.Is(err, orderservice.ErrReplaceUnsettled) {
+		return nil, replaceUnsettledError(result.ClientOrderID)
+	}
+	unsettled := errors.Is(err, orderservice.ErrSubmitUnsettled)
+	if err != nil && !unsettled {
+		return nil, mapOrderError(err)
+	}
+	return connect.NewResponse(&controlv1.ReplaceOrderResponse{
+		ReplacedClientOrderId: string(result.Replaced), ClientOrderId: string(result.ClientOrderID),
+		Mode: toProtoReplaceMode(result.Mode), Status: toProtoOrderStatus(result.Status), SubmitUnsettled: unsettled,
+	}), nil
+}
+
 // ListOrders returns one keyset-paginated page.
 func (s *OrderServer) ListOrders(ctx context.Context, req *connect.Request[controlv1.ListOrdersRequest]) (*connect.Response[controlv1.ListOrdersResponse], error) {
 	limit := req.Msg.GetLimit()
@@ -125,7 +188,7 @@ type pageToken struct {
 	FilterDigest  string    `json:"filter_digest"`
 }
 
-func encodePageToken(token pageToken) (string, error) {
+func encodePageToken(token any) (string, error) {
 	body, err := json.Marshal(token)
 	if err != nil {
 		return "", err
@@ -134,20 +197,32 @@ func encodePageToken(token pageToken) (string, error) {
 }
 
 func decodePageToken(encoded, digest string) (pageToken, error) {
+	var token pageToken
+	if err := decodeToken(encoded, &token); err != nil {
+		return pageToken{}, err
+	}
+	if token.V != 1 || token.CreatedAt.IsZero() || token.ClientOrderID == "" || token.FilterDigest != digest {
+		return pageToken{}, fmt.Errorf("%w: page token", errInvalidArgument)
+	}
+	return token, nil
+}
+
+// decodeToken strictly decodes a page token into dst: unknown fields and
+// trailing data are refused like a malformed encoding.
+func decodeToken(encoded string, dst any) error {
 	body, err := base64.RawURLEncoding.DecodeString(encoded)
 	if err != nil {
-		return pageToken{}, fmt.Errorf("%w: page token", errInvalidArgument)
+		return fmt.Errorf("%w: page token", errInvalidArgument)
 	}
 	dec := json.NewDecoder(bytes.NewReader(body))
 	dec.DisallowUnknownFields()
-	var token pageToken
-	if err := dec.Decode(&token); err != nil {
-		return pageToken{}, fmt.Errorf("%w: page token", errInvalidArgument)
+	if err := dec.Decode(dst); err != nil {
+		return fmt.Errorf("%w: page token", errInvalidArgument)
 	}
-	if err := dec.Decode(new(any)); !errors.Is(err, io.EOF) || token.V != 1 || token.CreatedAt.IsZero() || token.ClientOrderID == "" || token.FilterDigest != digest {
-		return pageToken{}, fmt.Errorf("%w: page token", errInvalidArgument)
+	if err := dec.Decode(new(any)); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: page token", errInvalidArgument)
 	}
-	return token, nil
+	return nil
 }
 
 func orderFilter(req *controlv1.ListOrdersRequest, limit int32) (domain.Query, string) {
@@ -158,7 +233,7 @@ func orderFilter(req *controlv1.ListOrdersRequest, limit int32) (domain.Query, s
 	slices.Sort(statuses)
 	statuses = slices.Compact(statuses)
 	venue, bot := strings.TrimSpace(req.GetVenue()), strings.TrimSpace(req.GetBotId())
-	filter := domain.Query{Statuses: statuses, Limit: limit}
+	filter := domain.Query{Statuses: statuses, Limit: limit, Parents: req.GetIncludeParents()}
 	if venue != "" {
 		filter.Venue = &venue
 	}
@@ -169,7 +244,8 @@ func orderFilter(req *controlv1.ListOrdersRequest, limit int32) (domain.Query, s
 		Venue    string   `json:"venue"`
 		Statuses []string `json:"statuses"`
 		BotID    string   `json:"bot_id"`
-	}{venue, statuses, bot})
+		Parents  bool     `json:"parents,omitempty"`
+	}{venue, statuses, bot, req.GetIncludeParents()})
 	digest := sha256.Sum256(canonical)
 	return filter, hex.EncodeToString(digest[:])
 }
@@ -178,6 +254,18 @@ func mapOrderError(err error) error {
 	if err == nil {
 		return nil
 	}
+	var rejection *risk.Rejection
+	if errors.As(err, &rejection) {
+		return reasonError(connect.CodeFailedPrecondition, string(rejection.Reason), riskErrorDomain, rejection)
+	}
+	var violation *domain.RuleViolation
+	if errors.As(err, &violation) {
+		return reasonError(connect.CodeInvalidArgument, string(violation.Rule), ruleErrorDomain, violation)
+	}
+	var unsupported *ports.UnsupportedError
+	if errors.As(err, &unsupported) {
+		return connect.NewError(connect.CodeFailedPrecondition, unsupported)
+	}
 	var code connect.Code
 	var public error
 	switch {
@@ -185,12 +273,38 @@ func mapOrderError(err error) error {
 		code, public = connect.CodeCanceled, context.Canceled
 	case errors.Is(err, context.DeadlineExceeded):
 		code, public = connect.CodeDeadlineExceeded, context.DeadlineExceeded
-	case errors.Is(err, errInvalidArgument):
+	case errors.Is(err, errInvalidArgument), errors.Is(err, domain.ErrInvalidExecution), errors.Is(err, domain.ErrInvalidPostOnly),
+		errors.Is(err, domain.ErrInvalidStop),
+		errors.Is(err, domain.ErrInvalidGroup), errors.Is(err, execution.ErrInvalidParent), errors.Is(err, auth.ErrInvalidToken):
 		code, public = connect.CodeInvalidArgument, err
+	case errors.Is(err, auth.ErrNameTaken):
+		code, public = connect.CodeAlreadyExists, auth.ErrNameTaken
+	case errors.Is(err, group.ErrGroupFinished):
+		code, public = connect.CodeFailedPrecondition, group.ErrGroupFinished
+	case errors.Is(err, executionservice.ErrParentFinished):
+		code, public = connect.CodeFailedPrecondition, executionservice.ErrParentFinished
+	case errors.Is(err, gridservice.ErrBotStopped), errors.Is(err, gridservice.ErrBotRefused):
+		code, public = connect.CodeFailedPrecondition, err
+	case errors.Is(err, arb.ErrNoHedgeNeeded):
+		code, public = connect.CodeFailedPrecondition, arb.ErrNoHedgeNeeded
+	case errors.Is(err, orderservice.ErrNoTriggerFeed), errors.Is(err, funds.ErrInsufficient), errors.Is(err, funds.ErrNoBalance):
+		code, public = connect.CodeFailedPrecondition, err
+	case errors.Is(err, funds.ErrNoPrice):
+		code, public = connect.CodeFailedPrecondition, funds.ErrNoPrice
 	case errors.Is(err, ports.ErrNotFound):
 		code, public = connect.CodeNotFound, ports.ErrNotFound
+	case errors.Is(err, sinkservice.ErrUnknownSink), errors.Is(err, gridservice.ErrUnknownBot):
+		code, public = connect.CodeNotFound, err
+	case errors.Is(err, orderservice.ErrHalted):
+		code, public = connect.CodeFailedPrecondition, orderservice.ErrHalted
 	case errors.Is(err, orderservice.ErrTerminal), errors.Is(err, orderservice.ErrVenueNotConfigured):
 		code, public = connect.CodeFailedPrecondition, errors.New("failed precondition")
+	case errors.Is(err, orderservice.ErrNotReplaceable):
+		code, public = connect.CodeFailedPrecondition, orderservice.ErrNotReplaceable
+	case errors.Is(err, orderservice.ErrReplaceFilled):
+		code, public = connect.CodeFailedPrecondition, orderservice.ErrReplaceFilled
+	case errors.Is(err, orderservice.ErrReplaceUnsettled):
+		code, public = connect.CodeAborted, orderservice.ErrReplaceUnsettled
 	case errors.Is(err, orderservice.ErrIdentityMismatch):
 		code, public = connect.CodeAlreadyExists, errors.New("order already exists with different identity")
 	case errors.Is(err, ports.ErrAuth):
@@ -203,15 +317,54 @@ func mapOrderError(err error) error {
 	return connect.NewError(code, public)
 }
 
+// reasonError carries a machine-readable reason as an ErrorInfo detail so
+// clients can branch on it without parsing the message.
+func reasonError(code connect.Code, reason, errorDomain string, cause error) error {
+	connectErr := connect.NewError(code, cause)
+	detail, err := connect.NewErrorDetail(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: errorDomain,
+	})
+	if err == nil {
+		connectErr.AddDetail(detail)
+	}
+	return connectErr
+}
+
+// replaceUnsettledError returns the new client order ID with the Aborted
+// error: the server may have generated it, and only a retry under it
+// resumes the replace.
+func replaceUnsettledError(newID domain.ClientOrderID) error {
+	connectErr := connect.NewError(connect.CodeAborted, orderservice.ErrReplaceUnsettled)
+	detail, err := connect.NewErrorDetail(&errdetails.ErrorInfo{
+		Reason:   reasonReplaceUnsettled,
+		Domain:   orderErrorDomain,
+		Metadata: map[string]string{"client_order_id": string(newID)},
+	})
+	if err == nil {
+		connectErr.AddDetail(detail)
+	}
+	return connectErr
+}
+
 func toProtoOrder(row domain.Record) *controlv1.Order {
-	return &controlv1.Order{
+	msg := &controlv1.Order{
 		ClientOrderId: string(row.ClientOrderID), VenueOrderId: row.VenueOrderID,
 		Venue: string(row.Instrument.Venue), Base: string(row.Instrument.Base), Quote: string(row.Instrument.Quote),
 		Side: toProtoSide(row.Side), Type: toProtoOrderType(row.Type),
 		Price: row.Price.String(), Qty: row.Qty.String(), FilledQty: row.FilledQty.String(), AvgFillPrice: row.AvgFillPrice.String(),
 		Status: toProtoOrderStatus(row.Status), BotId: row.BotID,
 		CreatedAt: timestamppb.New(row.CreatedAt), UpdatedAt: timestamppb.New(row.UpdatedAt),
+		TimeInForce: toProtoTimeInForce(row.TimeInForce), PostOnly: row.PostOnly, ParentId: string(row.ParentID),
+		Rollup: row.Rollup,
+	}
+	if !row.ExpiresAt.IsZero() {
+		msg.ExpiresAt = timestamppb.New(row.ExpiresAt)
+	}
+	if row.Type.Stop() {
+		msg.TriggerPrice, msg.ChildClientOrderId = row.TriggerPrice.String(), string(row.Child)
 	}
+	return msg
 }
 
 func fromProtoSide(side controlv1.Side) domain.Side {
@@ -241,19 +394,67 @@ func fromProtoOrderType(kind controlv1.OrderType) domain.Type {
 		return domain.Limit
 	case controlv1.OrderType_ORDER_TYPE_MARKET:
 		return domain.Market
+	case controlv1.OrderType_ORDER_TYPE_STOP_MARKET:
+		return domain.StopMarket
+	case controlv1.OrderType_ORDER_TYPE_STOP_LIMIT:
+		return domain.StopLimit
 	default:
 		return ""
 	}
 }
 
 func toProtoOrderType(kind domain.Type) controlv1.OrderType {
-	if kind == domain.Limit {
+	switch kind {
+	case domain.Limit:
 		return controlv1.OrderType_ORDER_TYPE_LIMIT
-	}
-	if kind == domain.Market {
+	case domain.Market:
 		return controlv1.OrderType_ORDER_TYPE_MARKET
+	case domain.StopMarket:
+		return controlv1.OrderType_ORDER_TYPE_STOP_MARKET
+	case domain.StopLimit:
+		return controlv1.OrderType_ORDER_TYPE_STOP_LIMIT
+	default:
+		return controlv1.OrderType_ORDER_TYPE_UNSPECIFIED
+	}
+}
+
+func fromProtoTimeInForce(tif controlv1.TimeInForce) domain.TimeInForce {
+	switch tif {
+	case controlv1.TimeInForce_TIME_IN_FORCE_IOC:
+		return domain.IOC
+	case controlv1.TimeInForce_TIME_IN_FORCE_FOK:
+		return domain.FOK
+	case controlv1.TimeInForce_TIME_IN_FORCE_GTD:
+		return domain.GTD
+	default:
+		return domain.GTC
+	}
+}
+
+func toProtoTimeInForce(tif domain.TimeInForce) controlv1.TimeInForce {
+	switch tif {
+	case domain.GTC:
+		return controlv1.TimeInForce_TIME_IN_FORCE_GTC
+	case domain.IOC:
+		return controlv1.TimeInForce_TIME_IN_FORCE_IOC
+	case domain.FOK:
+		return controlv1.TimeInForce_TIME_IN_FORCE_FOK
+	case domain.GTD:
+		return controlv1.TimeInForce_TIME_IN_FORCE_GTD
+	default:
+		return controlv1.TimeInForce_TIME_IN_FORCE_UNSPECIFIED
+	}
+}
+
+func toProtoReplaceMode(mode domain.ReplaceMode) controlv1.ReplaceMode {
+	switch mode {
+	case domain.ReplaceAmend:
+		return controlv1.ReplaceMode_REPLACE_MODE_AMEND
+	case domain.ReplaceCancelPlace:
+		return controlv1.ReplaceMode_REPLACE_MODE_CANCEL_REPLACE
+	default:
+		return controlv1.ReplaceMode_REPLACE_MODE_UNSPECIFIED
 	}
-	return controlv1.OrderType_ORDER_TYPE_UNSPECIFIED
 }
 
 func fromProtoOrderStatus(status controlv1.OrderStatus) domain.Status {
@@ -272,6 +473,10 @@ func fromProtoOrderStatus(status controlv1.OrderStatus) domain.Status {
 		return domain.StatusRejected
 	case controlv1.OrderStatus_ORDER_STATUS_EXPIRED:
 		return domain.StatusExpired
+	case controlv1.OrderStatus_ORDER_STATUS_UNTRIGGERED:
+		return domain.StatusUntriggered
+	case controlv1.OrderStatus_ORDER_STATUS_TRIGGERED:
+		return domain.StatusTriggered
 	default:
 		return ""
 	}
@@ -293,6 +498,10 @@ func toProtoOrderStatus(status domain.Status) controlv1.OrderStatus {
 		return controlv1.OrderStatus_ORDER_STATUS_REJECTED
 	case domain.StatusExpired:
 		return controlv1.OrderStatus_ORDER_STATUS_EXPIRED
+	case domain.StatusUntriggered:
+		return controlv1.OrderStatus_ORDER_STATUS_UNTRIGGERED
+	case domain.StatusTriggered:
+		return controlv1.OrderStatus_ORDER_STATUS_TRIGGERED
 	default:
 		return controlv1.OrderStatus_ORDER_STATUS_UNSPECIFIED
 	}
@@ -4,16 +4,23 @@ import (
 	"context"
 	"encoding/base64"
 	"errors"
+	"fmt"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
 	"github.com/romanornr/delta-works/internal/api/gen/control/v1/controlv1connect"
+	"github.com/romanornr/delta-works/internal/domain/funds"
+	domain "github.com/romanornr/delta-works/internal/domain/order"
 	"github.com/romanornr/delta-works/internal/ports"
 	orderservice "github.com/romanornr/delta-works/internal/service/order"
+	"github.com/romanornr/delta-works/internal/service/risk"
 )
 
 func TestPageToken(t *testing.T) {
@@ -41,6 +48,10 @@ func TestPageToken(t *testing.T) {
 	if _, err := decodePageToken(encoded, "different"); !errors.Is(err, errInvalidArgument) {
 		t.Fatalf("digest mismatch error = %v", err)
 	}
+	request.IncludeParents = true
+	if filter, parents := orderFilter(request, 50); !filter.Parents || parents == digest {
+		t.Fatalf("include_parents filter = %+v, digest %s; want parents and a new digest", filter, parents)
+	}
 }
 
 func TestOrderErrorMapping(t *testing.T) {
@@ -54,9 +65,22 @@ func TestOrderErrorMapping(t *testing.T) {
 		{"not found", ports.ErrNotFound, connect.CodeNotFound},
 		{"terminal", orderservice.ErrTerminal, connect.CodeFailedPrecondition},
 		{"venue config", orderservice.ErrVenueNotConfigured, connect.CodeFailedPrecondition},
+		{"halted", fmt.Errorf("%w: bybit", orderservice.ErrHalted), connect.CodeFailedPrecondition},
 		{"identity", orderservice.ErrIdentityMismatch, connect.CodeAlreadyExists},
+		{"not replaceable", fmt.Errorf("%w: market order", orderservice.ErrNotReplaceable), connect.CodeFailedPrecondition},
+		{"replace filled", orderservice.ErrReplaceFilled, connect.CodeFailedPrecondition},
+		{"replace unsettled", orderservice.ErrReplaceUnsettled, connect.CodeAborted},
 		{"auth", errors.Join(errors.New("secret venue text"), ports.ErrAuth), connect.CodePermissionDenied},
 		{"unavailable", ports.ErrVenueUnavailable, connect.CodeUnavailable},
+		{"pre-trade", &risk.Rejection{Reason: risk.ReasonMaxNotional, Detail: "too big"}, connect.CodeFailedPrecondition},
+		{"instrument rule", &domain.RuleViolation{Rule: domain.RuleTickSize, Detail: "off tick"}, connect.CodeInvalidArgument},
+		{"execution", fmt.Errorf("%w: gtd needs an expiry", domain.ErrInvalidExecution), connect.CodeInvalidArgument},
+		{"post-only", fmt.Errorf("%w: post-only needs a limit order", domain.ErrInvalidPostOnly), connect.CodeInvalidArgument},
+		{"stop", fmt.Errorf("%w: the trigger price must be positive", domain.ErrInvalidStop), connect.CodeInvalidArgument},
+		{"no trigger feed", fmt.Errorf("%w: BTC/USDT on bybit", orderservice.ErrNoTriggerFeed), connect.CodeFailedPrecondition},
+		{"insufficient funds", fmt.Errorf("%w: buy on bybit needs 201 USDT, 100 available", funds.ErrInsufficient), connect.CodeFailedPrecondition},
+		{"no price", fmt.Errorf("%w: ticker BTC/USDT: secret venue text", funds.ErrNoPrice), connect.CodeFailedPrecondition},
+		{"unsupported flag", fmt.Errorf("gct: %w", &ports.UnsupportedError{Venue: "bybit", Flag: "time in force gtd"}), connect.CodeFailedPrecondition},
 		{"canceled", context.Canceled, connect.CodeCanceled},
 		{"deadline", context.DeadlineExceeded, connect.CodeDeadlineExceeded},
 		{"internal", errors.New("database password leaked"), connect.CodeInternal},
@@ -74,6 +98,44 @@ func TestOrderErrorMapping(t *testing.T) {
 	}
 }
 
+func TestRejectionCarriesReason(t *testing.T) {
+	t.Parallel()
+	wrapped := fmt.Errorf("place: %w", &risk.Rejection{Reason: risk.ReasonPriceBand, Detail: "buy at 70000 is more than 500 bps above the reference 60000"})
+	var connectErr *connect.Error
+	if !errors.As(mapOrderError(wrapped), &connectErr) || connectErr.Code() != connect.CodeFailedPrecondition {
+		t.Fatalf("mapped = %v", connectErr)
+	}
+	if !strings.Contains(connectErr.Message(), "bps above the reference") {
+		t.Fatalf("message = %q, want the operator-facing detail", connectErr.Message())
+	}
+	details := connectErr.Details()
+	if len(details) != 1 {
+		t.Fatalf("details = %d, want one ErrorInfo", len(details))
+	}
+	value, err := details[0].Value()
+	info, ok := value.(*errdetails.ErrorInfo)
+	if err != nil || !ok || info.GetReason() != string(risk.ReasonPriceBand) || info.GetDomain() != riskErrorDomain {
+		t.Fatalf("detail = %v, err=%v", value, err)
+	}
+}
+
+func TestReplaceUnsettledCarriesNewID(t *testing.T) {
+	t.Parallel()
+	var connectErr *connect.Error
+	if !errors.As(replaceUnsettledError("gen-1"), &connectErr) || connectErr.Code() != connect.CodeAborted {
+		t.Fatalf("mapped = %v", connectErr)
+	}
+	details := connectErr.Details()
+	if len(details) != 1 {
+		t.Fatalf("details = %d, want one ErrorInfo", len(details))
+	}
+	value, err := details[0].Value()
+	info, ok := value.(*errdetails.ErrorInfo)
+	if err != nil || !ok || info.GetReason() != reasonReplaceUnsettled || info.GetMetadata()["client_order_id"] != "gen-1" {
+		t.Fatalf("detail = %v, err=%v", value, err)
+	}
+}
+
 func TestPlaceOrderValidation(t *testing.T) {
 	t.Parallel()
 	server, _ := newTestServer(t)
@@ -86,6 +148,15 @@ func TestPlaceOrderValidation(t *testing.T) {
 		{Venue: "bybit", Base: "BTC", Quote: "USDT", Side: valid.Side, Type: valid.Type, Qty: "1e2", Price: valid.Price},
 		{Venue: "bybit", Base: "BTC", Quote: "USDT", Side: valid.Side, Type: valid.Type, Qty: valid.Qty},
 		{Venue: "bybit", Base: "BTC", Quote: "USDT", Side: valid.Side, Type: controlv1.OrderType_ORDER_TYPE_MARKET, Qty: valid.Qty, Price: valid.Price},
+		{Venue: "bybit", Base: "BTC", Quote: "USDT", Side: valid.Side, Type: valid.Type, Qty: valid.Qty, Price: valid.Price, TimeInForce: controlv1.TimeInForce_TIME_IN_FORCE_GTD},
+		{Venue: "bybit", Base: "BTC", Quote: "USDT", Side: valid.Side, Type: valid.Type, Qty: valid.Qty, Price: valid.Price, ExpiresAt: timestamppb.Now()},
+		{Venue: "bybit", Base: "BTC", Quote: "USDT", Side: valid.Side, Type: valid.Type, Qty: valid.Qty, Price: valid.Price, TimeInForce: controlv1.TimeInForce_TIME_IN_FORCE_IOC, PostOnly: true},
+		{Venue: "bybit", Base: "BTC", Quote: "USDT", Side: valid.Side, Type: controlv1.OrderType_ORDER_TYPE_MARKET, Qty: valid.Qty, PostOnly: true},
+		{Venue: "bybit", Base: "BTC", Quote: "USDT", Side: valid.Side, Type: valid.Type, Qty: valid.Qty, Price: valid.Price, TimeInForce: 9},
+		{Venue: "bybit", Base: "BTC", Quote: "USDT", Side: valid.Side, Type: valid.Type, Qty: valid.Qty, Price: valid.Price, TriggerPrice: "49000"},
+		{Venue: "bybit", Base: "BTC", Quote: "USDT", Side: valid.Side, Type: controlv1.OrderType_ORDER_TYPE_STOP_MARKET, Qty: valid.Qty},
+		{Venue: "bybit", Base: "BTC", Quote: "USDT", Side: valid.Side, Type: controlv1.OrderType_ORDER_TYPE_STOP_MARKET, Qty: valid.Qty, Price: valid.Price, TriggerPrice: "49000"},
+		{Venue: "bybit", Base: "BTC", Quote: "USDT", Side: valid.Side, Type: controlv1.OrderType_ORDER_TYPE_STOP_LIMIT, Qty: valid.Qty, TriggerPrice: "49000"},
 	}
 	for _, request := range tests {
 		_, err := client.PlaceOrder(t.Context(), connect.NewRequest(request))
@@ -94,3 +165,25 @@ func TestPlaceOrderValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestReplaceOrderValidation(t *testing.T) {
+	t.Parallel()
+	server, _ := newTestServer(t)
+	srv := httptest.NewServer(server.Handler)
+	t.Cleanup(srv.Close)
+	client := controlv1connect.NewOrderServiceClient(srv.Client(), srv.URL)
+	const id = "01J2Z3Y4X5W6V7T8S9R0QPNMKH"
+	tests := []*controlv1.ReplaceOrderRequest{
+		{ClientOrderId: "short", Price: "50000", Qty: "1"},
+		{ClientOrderId: id, Qty: "1"},
+		{ClientOrderId: id, Price: "50000", Qty: "0"},
+		{ClientOrderId: id, Price: "-1", Qty: "1"},
+		{ClientOrderId: id, Price: "50000", Qty: "1", NewClientOrderId: "not-a-ulid"},
+	}
+	for _, request := range tests {
+		_, err := client.ReplaceOrder(t.Context(), connect.NewRequest(request))
+		if connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Fatalf("request %+v code = %s, err=%v", request, connect.CodeOf(err), err)
+		}
+	}
+}
@@ -20,22 +20,90 @@ import (
 
 const readHeaderTimeout = 5 * time.Second
 
+// Handlers are the control-plane services a server exposes. A nil handler
+// leaves its service unregistered, so a caller wires only what it serves.
+type Handlers struct {
+	Snapshots   *SnapshotServer
+	Events      *EventServer
+	Orders      *OrderServer
+	Ledger      *LedgerServer
+	KillSwitch  *KillSwitchServer
+	Instruments *InstrumentServer
+	OrderGroups *OrderGroupServer
+	Executions  *ExecutionServer
+	Grids       *GridServer
+	Arbs        *ArbServer
+	Sinks       *SinkServer
+	Tokens      *TokenServer
+}
+
 // NewServer builds the control-plane HTTP server. It does not start it;
 // lifecycle is managed by the application (fx hooks). No write timeout is
-// set because event streams stay open indefinitely.
-func NewServer(snapshots *SnapshotServer, events *EventServer, orders *OrderServer) *http.Server {
+// set because event streams stay open indefinitely. A nil authorizer
+// serves every RPC to anyone who can connect, which is right only behind
+// a Unix socket's permissions (ADR-0012); health and reflection are never
+// authorized, since they expose no state beyond the schema.
+func NewServer(h Handlers, authorizer *Authorizer) *http.Server {
 	interceptors := connect.WithInterceptors(validate.NewInterceptor())
+	if authorizer != nil {
+		interceptors = connect.WithInterceptors(authorizer, validate.NewInterceptor())
+	}
 
 	mux := http.NewServeMux()
-	mux.Handle(controlv1connect.NewSnapshotServiceHandler(snapshots, interceptors))
-	mux.Handle(controlv1connect.NewEventServiceHandler(events, interceptors))
-	mux.Handle(controlv1connect.NewOrderServiceHandler(orders, interceptors))
-
-	services := []string{
-		controlv1connect.SnapshotServiceName,
-		controlv1connect.EventServiceName,
-		controlv1connect.OrderServiceName,
+	var services []string
+	register := func(name, path string, handler http.Handler) {
+		mux.Handle(path, handler)
+		services = append(services, name)
+	}
+	if h.Snapshots != nil {
+		path, handler := controlv1connect.NewSnapshotServiceHandler(h.Snapshots, interceptors)
+		register(controlv1connect.SnapshotServiceName, path, handler)
+	}
+	if h.Events != nil {
+		path, handler := controlv1connect.NewEventServiceHandler(h.Events, interceptors)
+		register(controlv1connect.EventServiceName, path, handler)
+	}
+	if h.Orders != nil {
+		path, handler := controlv1connect.NewOrderServiceHandler(h.Orders, interceptors)
+		register(controlv1connect.OrderServiceName, path, handler)
+	}
+	if h.Ledger != nil {
+		path, handler := controlv1connect.NewLedgerServiceHandler(h.Ledger, interceptors)
+		register(controlv1connect.LedgerServiceName, path, handler)
+	}
+	if h.KillSwitch != nil {
+		path, handler := controlv1connect.NewKillSwitchServiceHandler(h.KillSwitch, interceptors)
+		register(controlv1connect.KillSwitchServiceName, path, handler)
 	}
+	if h.Instruments != nil {
+		path, handler := controlv1connect.NewInstrumentServiceHandler(h.Instruments, interceptors)
+		register(controlv1connect.InstrumentServiceName, path, handler)
+	}
+	if h.OrderGroups != nil {
+		path, handler := controlv1connect.NewOrderGroupServiceHandler(h.OrderGroups, interceptors)
+		register(controlv1connect.OrderGroupServiceName, path, handler)
+	}
+	if h.Executions != nil {
+		path, handler := controlv1connect.NewExecutionServiceHandler(h.Executions, interceptors)
+		register(controlv1connect.ExecutionServiceName, path, handler)
+	}
+	if h.Grids != nil {
+		path, handler := controlv1connect.NewGridServiceHandler(h.Grids, interceptors)
+		register(controlv1connect.GridServiceName, path, handler)
+	}
+	if h.Arbs != nil {
+		path, handler := controlv1connect.NewArbServiceHandler(h.Arbs, interceptors)
+		register(controlv1connect.ArbServiceName, path, handler)
+	}
+	if h.Sinks != nil {
+		path, handler := controlv1connect.NewSinkServiceHandler(h.Sinks, interceptors)
+		register(controlv1connect.SinkServiceName, path, handler)
+	}
+	if h.Tokens != nil {
+		path, handler := controlv1connect.NewTokenServiceHandler(h.Tokens, interceptors)
+		register(controlv1connect.TokenServiceName, path, handler)
+	}
+
 	mux.Handle(grpchealth.NewHandler(grpchealth.NewStaticChecker(services...)))
 	reflector := grpcreflect.NewStaticReflector(services...)
 	mux.Handle(grpcreflect.NewHandlerV1(reflector))
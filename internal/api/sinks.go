@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/domain/sink"
+	sinkservice "github.com/romanornr/delta-works/internal/service/sink"
+)
+
+const defaultDeadLetterLimit = 100
+
+// SinkServer serves control.v1.SinkService.
+type SinkServer struct {
+	sinks *sinkservice.Service
+}
+
+// NewSinkServer builds the SinkService handler.
+func NewSinkServer(service *sinkservice.Service) *SinkServer {
+	return &SinkServer{sinks: service}
+}
+
+// ListDeadLetters returns the oldest dead letters.
+func (s *SinkServer) ListDeadLetters(ctx context.Context, req *connect.Request[controlv1.ListDeadLettersRequest]) (*connect.Response[controlv1.ListDeadLettersResponse], error) {
+	letters, err := s.sinks.ListDeadLetters(ctx, deadLetterQuery(req.Msg.GetSink(), nil, req.Msg.GetLimit()))
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	response := &controlv1.ListDeadLettersResponse{Letters: make([]*controlv1.DeadLetter, 0, len(letters))}
+	for _, letter := range letters {
+		response.Letters = append(response.Letters, toProtoDeadLetter(letter))
+	}
+	return connect.NewResponse(response), nil
+}
+
+// RetryDeadLetters redelivers the matching dead letters once each.
+func (s *SinkServer) RetryDeadLetters(ctx context.Context, req *connect.Request[controlv1.RetryDeadLettersRequest]) (*connect.Response[controlv1.RetryDeadLettersResponse], error) {
+	results, err := s.sinks.RetryDeadLetters(ctx, deadLetterQuery(req.Msg.GetSink(), req.Msg.GetIds(), req.Msg.GetLimit()))
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	response := &controlv1.RetryDeadLettersResponse{Results: make([]*controlv1.DeadLetterRetry, 0, len(results))}
+	for _, r := range results {
+		response.Results = append(response.Results, &controlv1.DeadLetterRetry{Letter: toProtoDeadLetter(r.Letter), Delivered: r.Err == nil})
+	}
+	return connect.NewResponse(response), nil
+}
+
+// deadLetterQuery defaults the limit, raising it to cover every ID asked
+// for by name.
+func deadLetterQuery(sinkName string, ids []int64, limit int32) sink.DeadLetterQuery {
+	if limit == 0 {
+		limit = defaultDeadLetterLimit
+	}
+	return sink.DeadLetterQuery{Sink: sinkName, IDs: ids, Limit: max(int(limit), len(ids))}
+}
+
+func toProtoDeadLetter(letter sink.DeadLetter) *controlv1.DeadLetter {
+	return &controlv1.DeadLetter{
+		Id: letter.ID, Sink: letter.Sink, Cursor: letter.Delivery.OutboxID, Subject: letter.Delivery.Subject,
+		At: timestamppb.New(letter.Delivery.At), Attempts: int32(letter.Attempts), //nolint:gosec // attempts are small
+		LastError: letter.LastError, FailedAt: timestamppb.New(letter.FailedAt),
+	}
+}
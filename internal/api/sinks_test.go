@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/api/gen/control/v1/controlv1connect"
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/sink"
+	"github.com/romanornr/delta-works/internal/events"
+	"github.com/romanornr/delta-works/internal/log"
+	sinkservice "github.com/romanornr/delta-works/internal/service/sink"
+)
+
+// fakeDeadLetters serves dead letters; the sink service's delivery side
+// is not exercised here.
+type fakeDeadLetters struct {
+	letters []sink.DeadLetter
+}
+
+func (*fakeDeadLetters) OpenCursor(context.Context, string) (int64, error)        { return 0, nil }
+func (*fakeDeadLetters) AdvanceCursor(context.Context, string, int64) error       { return nil }
+func (*fakeDeadLetters) PruneCursors(context.Context, []string) (int64, error)    { return 0, nil }
+func (*fakeDeadLetters) DeadLetter(context.Context, sink.DeadLetter, int64) error { return nil }
+func (*fakeDeadLetters) ListPublishedAfter(context.Context, int64, int) ([]events.OutboxMessage, error) {
+	return nil, nil
+}
+
+func (f *fakeDeadLetters) ListDeadLetters(_ context.Context, query sink.DeadLetterQuery) ([]sink.DeadLetter, error) {
+	var out []sink.DeadLetter
+	for _, letter := range f.letters {
+		if (query.Sink == "" || letter.Sink == query.Sink) && (len(query.IDs) == 0 || slices.Contains(query.IDs, letter.ID)) && len(out) < query.Limit {
+			out = append(out, letter)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeDeadLetters) DeleteDeadLetter(_ context.Context, id int64) error {
+	f.letters = slices.DeleteFunc(f.letters, func(letter sink.DeadLetter) bool { return letter.ID == id })
+	return nil
+}
+
+func (f *fakeDeadLetters) FailDeadLetter(_ context.Context, id int64, lastErr string, at time.Time) error {
+	for i := range f.letters {
+		if f.letters[i].ID == id {
+			f.letters[i].Attempts++
+			f.letters[i].LastError, f.letters[i].FailedAt = lastErr, at
+		}
+	}
+	return nil
+}
+
+type sinkFunc func(sink.Delivery) error
+
+func (f sinkFunc) Deliver(_ context.Context, d sink.Delivery) error { return f(d) }
+
+func TestSinkService(t *testing.T) {
+	t.Parallel()
+	failedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	store := &fakeDeadLetters{letters: []sink.DeadLetter{
+		{ID: 1, Sink: "hooks", Delivery: sink.Delivery{OutboxID: 41, Subject: events.SubjectOrderFilled}, Attempts: 5, LastError: "503", FailedAt: failedAt},
+		{ID: 2, Sink: "hooks", Delivery: sink.Delivery{OutboxID: 42, Subject: events.SubjectOrderUpdated}, Attempts: 5, LastError: "503", FailedAt: failedAt},
+	}}
+	deliver := sinkFunc(func(d sink.Delivery) error {
+		if d.OutboxID == 42 {
+			return errors.New("still down")
+		}
+		return nil
+	})
+	metrics, err := sinkservice.NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventBus := bus.NewInProc()
+	t.Cleanup(eventBus.Close)
+	service := sinkservice.New([]sinkservice.Target{{Name: "hooks", Sink: deliver}}, store, EventJSON, eventBus,
+		clockwork.NewFakeClock(), log.Nop(), time.Second, sinkservice.Retry{MaxAttempts: 1}, metrics)
+	srv := httptest.NewServer(NewServer(Handlers{Events: testEventServer(t, eventBus), Sinks: NewSinkServer(service)}, nil).Handler)
+	t.Cleanup(srv.Close)
+	client := controlv1connect.NewSinkServiceClient(srv.Client(), srv.URL)
+
+	list, err := client.ListDeadLetters(t.Context(), connect.NewRequest(&controlv1.ListDeadLettersRequest{Sink: "hooks"}))
+	if err != nil || len(list.Msg.GetLetters()) != 2 {
+		t.Fatalf("ListDeadLetters = %v, %v", list, err)
+	}
+	if letter := list.Msg.GetLetters()[0]; letter.GetCursor() != 41 || letter.GetSubject() != events.SubjectOrderFilled ||
+		letter.GetAttempts() != 5 || !letter.GetFailedAt().AsTime().Equal(failedAt) {
+		t.Fatalf("letter = %v", letter)
+	}
+
+	retried, err := client.RetryDeadLetters(t.Context(), connect.NewRequest(&controlv1.RetryDeadLettersRequest{Ids: []int64{1, 2}}))
+	if err != nil || len(retried.Msg.GetResults()) != 2 {
+		t.Fatalf("RetryDeadLetters = %v, %v", retried, err)
+	}
+	if results := retried.Msg.GetResults(); !results[0].GetDelivered() || results[1].GetDelivered() ||
+		results[1].GetLetter().GetAttempts() != 6 || results[1].GetLetter().GetLastError() != "still down" {
+		t.Fatalf("results = %v", results)
+	}
+	if len(store.letters) != 1 || store.letters[0].ID != 2 {
+		t.Fatalf("letters left = %+v", store.letters)
+	}
+
+	_, err = client.RetryDeadLetters(t.Context(), connect.NewRequest(&controlv1.RetryDeadLettersRequest{Sink: "audit"}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("retry an unknown sink = %v, want NotFound", err)
+	}
+	_, err = client.RetryDeadLetters(t.Context(), connect.NewRequest(&controlv1.RetryDeadLettersRequest{Ids: []int64{0}}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("retry ID 0 = %v, want InvalidArgument", err)
+	}
+}
+
+func TestEventJSON(t *testing.T) {
+	t.Parallel()
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	body, err := EventJSON(bus.Event{
+		Subject: events.SubjectOrderFilled, At: at, OutboxID: 7, PublishSeq: 42,
+		Payload: json.RawMessage(`{"client_order_id":"cid-1","venue":"bybit","base":"BTC","quote":"USDT","status":"filled","filled_qty":"1","qty":"1","price":"50000"}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Subject     string `json:"subject"`
+		Cursor      string `json:"cursor"`
+		OrderFilled struct {
+			ClientOrderID string `json:"clientOrderId"`
+			Price         string `json:"price"`
+		} `json:"orderFilled"`
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Subject != events.SubjectOrderFilled || got.Cursor != "42" || got.OrderFilled.ClientOrderID != "cid-1" || got.OrderFilled.Price != "50000" {
+		t.Fatalf("EventJSON = %s", body)
+	}
+	if _, err := EventJSON(bus.Event{Subject: events.SubjectOrderFilled, Payload: json.RawMessage("{")}); err == nil {
+		t.Fatal("a malformed payload was encoded")
+	}
+}
@@ -32,7 +32,7 @@ func newTestClient(t *testing.T, store ports.SnapshotReader) controlv1connect.Sn
 	t.Helper()
 	eventBus := bus.NewInProc()
 	t.Cleanup(eventBus.Close)
-	srv := httptest.NewServer(NewServer(NewSnapshotServer(store), testEventServer(t, eventBus), nil).Handler)
+	srv := httptest.NewServer(NewServer(Handlers{Snapshots: NewSnapshotServer(store), Events: testEventServer(t, eventBus)}, nil).Handler)
 	t.Cleanup(srv.Close)
 	return controlv1connect.NewSnapshotServiceClient(srv.Client(), srv.URL)
 }
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	controlv1 "github.com/romanornr/delta-works/internal/api/gen/control/v1"
+	"github.com/romanornr/delta-works/internal/domain/auth"
+	authservice "github.com/romanornr/delta-works/internal/service/auth"
+)
+
+// TokenServer serves control.v1.TokenService.
+type TokenServer struct {
+	tokens *authservice.Service
+}
+
+// NewTokenServer builds the TokenService handler.
+func NewTokenServer(service *authservice.Service) *TokenServer {
+	return &TokenServer{tokens: service}
+}
+
+// CreateToken stores a token and returns its secret once.
+func (s *TokenServer) CreateToken(ctx context.Context, req *connect.Request[controlv1.CreateTokenRequest]) (*connect.Response[controlv1.CreateTokenResponse], error) {
+	scopes := make([]auth.Scope, 0, len(req.Msg.GetScopes()))
+	for _, scope := range req.Msg.GetScopes() {
+		scopes = append(scopes, fromProtoTokenScope(scope))
+	}
+	token, secret, err := s.tokens.Create(ctx, req.Msg.GetName(), scopes)
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	return connect.NewResponse(&controlv1.CreateTokenResponse{Token: toProtoToken(token), Secret: secret}), nil
+}
+
+// ListTokens returns the file tokens and the stored ones.
+func (s *TokenServer) ListTokens(ctx context.Context, req *connect.Request[controlv1.ListTokensRequest]) (*connect.Response[controlv1.ListTokensResponse], error) {
+	tokens, err := s.tokens.List(ctx, req.Msg.GetIncludeRevoked())
+	if err != nil {
+		return nil, mapOrderError(err)
+	}
+	response := &controlv1.ListTokensResponse{Tokens: make([]*controlv1.Token, 0, len(tokens))}
+	for _, token := range tokens {
+		response.Tokens = append(response.Tokens, toProtoToken(token))
+	}
+	return connect.NewResponse(response), nil
+}
+
+// RevokeToken revokes a stored token.
+func (s *TokenServer) RevokeToken(ctx context.Context, req *connect.Request[controlv1.RevokeTokenRequest]) (*connect.Response[controlv1.RevokeTokenResponse], error) {
+	if err := s.tokens.Revoke(ctx, req.Msg.GetTokenId()); err != nil {
+		return nil, mapOrderError(err)
+	}
+	return connect.NewResponse(&controlv1.RevokeTokenResponse{}), nil
+}
+
+func toProtoToken(t auth.Token) *controlv1.Token {
+	msg := &controlv1.Token{TokenId: t.ID, Name: t.Name, Scopes: make([]controlv1.TokenScope, 0, len(t.Scopes))}
+	for _, scope := range t.Scopes {
+		msg.Scopes = append(msg.Scopes, toProtoTokenScope(scope))
+	}
+	switch t.Source {
+	case auth.SourceStore:
+		msg.Source = controlv1.TokenSource_TOKEN_SOURCE_STORE
+	case auth.SourceFile:
+		msg.Source = controlv1.TokenSource_TOKEN_SOURCE_FILE
+	}
+	if !t.CreatedAt.IsZero() {
+		msg.CreatedAt = timestamppb.New(t.CreatedAt)
+	}
+	if t.Revoked() {
+		msg.RevokedAt = timestamppb.New(t.RevokedAt)
+	}
+	return msg
+}
+
+func toProtoTokenScope(s auth.Scope) controlv1.TokenScope {
+	switch s {
+	case auth.ScopeRead:
+		return controlv1.TokenScope_TOKEN_SCOPE_READ
+	case auth.ScopeTrade:
+		return controlv1.TokenScope_TOKEN_SCOPE_TRADE
+	case auth.ScopeAdmin:
+		return controlv1.TokenScope_TOKEN_SCOPE_ADMIN
+	default:
+		return controlv1.TokenScope_TOKEN_SCOPE_UNSPECIFIED
+	}
+}
+
+// fromProtoTokenScope maps a validated scope; UNSPECIFIED never reaches it.
+func fromProtoTokenScope(s controlv1.TokenScope) auth.Scope {
+	switch s {
+	case controlv1.TokenScope_TOKEN_SCOPE_READ:
+		return auth.ScopeRead
+	case controlv1.TokenScope_TOKEN_SCOPE_TRADE:
+		return auth.ScopeTrade
+	case controlv1.TokenScope_TOKEN_SCOPE_ADMIN:
+		return auth.ScopeAdmin
+	default:
+		return ""
+	}
+}
@@ -7,30 +7,58 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net"
 	"net/http"
+	"os"
+	"slices"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jonboulle/clockwork"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shopspring/decimal"
 	"go.uber.org/fx"
 
 	"github.com/romanornr/delta-works/internal/adapters/gct"
+	"github.com/romanornr/delta-works/internal/adapters/paper"
 	"github.com/romanornr/delta-works/internal/adapters/postgres"
 	"github.com/romanornr/delta-works/internal/adapters/questdb"
+	"github.com/romanornr/delta-works/internal/adapters/sinks"
 	"github.com/romanornr/delta-works/internal/api"
 	"github.com/romanornr/delta-works/internal/bus"
+	natsbus "github.com/romanornr/delta-works/internal/bus/nats"
 	"github.com/romanornr/delta-works/internal/config"
 	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/arb"
+	"github.com/romanornr/delta-works/internal/domain/auth"
+	"github.com/romanornr/delta-works/internal/domain/grid"
 	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/ledger"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/events"
 	"github.com/romanornr/delta-works/internal/exchange"
 	"github.com/romanornr/delta-works/internal/log"
 	"github.com/romanornr/delta-works/internal/ports"
+	arbservice "github.com/romanornr/delta-works/internal/service/arb"
+	authservice "github.com/romanornr/delta-works/internal/service/auth"
+	"github.com/romanornr/delta-works/internal/service/catalog"
+	executionservice "github.com/romanornr/delta-works/internal/service/execution"
+	fundsservice "github.com/romanornr/delta-works/internal/service/funds"
+	gridservice "github.com/romanornr/delta-works/internal/service/grid"
+	groupservice "github.com/romanornr/delta-works/internal/service/group"
+	"github.com/romanornr/delta-works/internal/service/kill"
+	"github.com/romanornr/delta-works/internal/service/mark"
 	orderservice "github.com/romanornr/delta-works/internal/service/order"
 	"github.com/romanornr/delta-works/internal/service/outbox"
 	"github.com/romanornr/delta-works/internal/service/reconcile"
+	"github.com/romanornr/delta-works/internal/service/risk"
+	sinkservice "github.com/romanornr/delta-works/internal/service/sink"
 	"github.com/romanornr/delta-works/internal/service/snapshot"
+	"github.com/romanornr/delta-works/internal/service/ticker"
+	"github.com/romanornr/delta-works/internal/service/trigger"
 	"github.com/romanornr/delta-works/internal/telemetry"
 )
 
@@ -50,7 +78,7 @@ func New(configPath string, configExplicit bool) *fx.App {
 			clockwork.NewRealClock,
 			telemetry.NewRegistry,
 			newBus,
-			func(b *bus.InProc) bus.Bus { return b },
+			func(b droppingBus) bus.Bus { return b },
 			fx.Annotate(
 				func(cfg config.Config, reg *prometheus.Registry, checks []ports.HealthChecker, l log.Logger) *http.Server {
 					return telemetry.NewServer(cfg.HTTP, reg, checks, l)
@@ -60,28 +88,79 @@ func New(configPath string, configExplicit bool) *fx.App {
 			newExchangeProducts,
 			newPostgres,
 			fx.Annotate(postgres.NewCheckpointStore, fx.As(new(ports.SnapshotRecorder), new(ports.SnapshotReader))),
-			fx.Annotate(postgres.NewOutboxStore, fx.As(new(ports.OutboxStore))),
+			fx.Annotate(postgres.NewOutboxStore, fx.As(new(ports.OutboxStore), new(ports.OutboxReader))),
+			fx.Annotate(postgres.NewKillSwitchStore, fx.As(new(ports.KillSwitchStore))),
+			fx.Annotate(postgres.NewSinkStore, fx.As(new(ports.SinkStore))),
+			fx.Annotate(postgres.NewTokenStore, fx.As(new(ports.TokenStore))),
+			fx.Annotate(postgres.NewInstrumentStore, fx.As(new(ports.InstrumentStore), new(ports.InstrumentCatalog))),
+			newGridSpecs,
+			newArbSpecs,
+			newLotSelectors,
 			fx.Annotate(postgres.NewOrderStore, fx.As(
 				new(ports.OrderCommandStore), new(ports.OrderEventStore),
 				new(ports.OrderReconcileStore), new(ports.OrderQueryStore),
+				new(ports.LedgerQueryStore), new(ports.OpenLotStore), new(ports.HeldQtyReader), new(ports.ActiveOrderCounter),
+				new(ports.StopStore), new(ports.OrderGroupStore), new(ports.ParentOrderStore),
+				new(ports.ArbTradeStore), new(ports.ReservationStore), new(ports.GridOrderStore),
 			)),
-			fx.Annotate(newQuestDB, fx.As(new(ports.BalanceSeriesWriter), new(ports.TickerSeriesWriter))),
+			// One writer per series: a flush sends every row its sender
+			// holds, so a shared sender would let one service's flush carry,
+			// or fail on, another's rows.
+			fx.Annotate(newQuestDB, fx.As(new(ports.BalanceSeriesWriter))),
+			fx.Annotate(newQuestDB, fx.As(new(ports.TickerSeriesWriter))),
+			fx.Annotate(newQuestDB, fx.As(new(ports.TradeSeriesWriter))),
+			fx.Annotate(newQuestDB, fx.As(new(ports.ValuationSeriesWriter))),
+			fx.Annotate(newQuestDBReader, fx.As(new(ports.TradeSeriesReader))),
 			fx.Annotate(postgres.NewHealth, fx.As(new(ports.HealthChecker)), fx.ResultTags(`group:"health"`)),
 			fx.Annotate(newQuestDBHealth, fx.As(new(ports.HealthChecker)), fx.ResultTags(`group:"health"`)),
 			snapshot.NewMetrics,
 			newSnapshotService,
 			outbox.NewMetrics,
 			newOutboxService,
+			catalog.NewMetrics,
+			newCatalogService,
+			risk.NewMetrics,
+			newRiskChain,
+			fundsservice.NewMetrics,
+			newReserver,
 			orderservice.NewMetrics,
 			newOrderService,
+			newKillService,
 			reconcile.NewMetrics,
 			newReconcileService,
+			gridservice.NewMetrics,
+			newGridService,
+			arbservice.NewMetrics,
+			newArbService,
+			sinkservice.NewMetrics,
+			newSinkTargets,
+			newSinkService,
+			mark.NewMetrics,
+			newMarkService,
+			ticker.NewMetrics,
+			newTickerService,
+			trigger.NewMetrics,
+			newTriggerService,
+			groupservice.NewMetrics,
+			newGroupService,
+			executionservice.NewMetrics,
+			newExecutionService,
+			newAuthService,
 			api.NewMetrics,
 			api.NewSnapshotServer,
 			api.NewEventServer,
 			api.NewOrderServer,
+			api.NewLedgerServer,
+			api.NewKillSwitchServer,
+			api.NewInstrumentServer,
+			api.NewOrderGroupServer,
+			api.NewExecutionServer,
+			api.NewGridServer,
+			api.NewArbServer,
+			api.NewSinkServer,
+			api.NewTokenServer,
 		),
-		fx.Invoke(registerBusMetrics, startSnapshotService, startTelemetryServer, startOutboxService, startReconcileService, startOrderService, startAPIServer, logStartup),
+		fx.Invoke(registerBusMetrics, startSnapshotService, startTelemetryServer, startOutboxService, startCatalogService, startReconcileService, startOrderService, startTriggerService, startGroupService, startExecutionService, startGridService, startArbService, startSinkService, startMarkService, startTickerService, startAPIServer, logStartup),
 	)
 }
 
@@ -89,6 +168,7 @@ type tradingVenue struct {
 	ID       instrument.VenueID
 	Placer   ports.OrderPlacer
 	Streamer ports.PrivateStreamer
+	Tickers  []instrument.Instrument
 }
 
 type exchangeProducts struct {
@@ -98,8 +178,9 @@ type exchangeProducts struct {
 	Trading  []tradingVenue
 }
 
-// newExchangeProducts connects every enabled venue through the GCT adapter
-// and wraps it in the standard resilience stack (rate limit + breaker).
+// newExchangeProducts connects every enabled venue through its configured
+// adapter (GCT or the paper simulator) and wraps it in the standard
+// resilience stack (rate limit + breaker).
 func newExchangeProducts(cfg config.Config, l log.Logger, eventBus bus.Bus, clk clockwork.Clock) (exchangeProducts, error) {
 	logger := log.Component(l, "exchange")
 	ctx, cancel := context.WithTimeout(context.Background(), startupTimeout)
@@ -108,7 +189,13 @@ func newExchangeProducts(cfg config.Config, l log.Logger, eventBus bus.Bus, clk
 	var trading []tradingVenue
 	for _, name := range cfg.EnabledVenues() {
 		venueCfg := cfg.Venues[name]
-		ex, err := gct.New(ctx, name, venueCfg)
+		venueID := instrument.NewVenueID(name)
+		ex, streamer, err := connectVenue(ctx, name, venueCfg, clk, func() {
+			_ = eventBus.Publish(context.Background(), bus.Event{
+				Subject: orderservice.SubjectStreamReconnected,
+				At:      clk.Now(), Payload: venueID,
+			})
+		})
 		if err != nil {
 			return exchangeProducts{}, err
 		}
@@ -119,27 +206,122 @@ func newExchangeProducts(cfg config.Config, l log.Logger, eventBus bus.Bus, clk
 			if !ok {
 				return exchangeProducts{}, fmt.Errorf("venue %q: decorated exchange does not implement order placement", name)
 			}
-			venueID := instrument.NewVenueID(name)
-			streamer := gct.NewStreamer(ex, func() {
-				_ = eventBus.Publish(context.Background(), bus.Event{
-					Subject: orderservice.SubjectStreamReconnected,
-					At:      clk.Now(), Payload: venueID,
-				})
-			})
-			trading = append(trading, tradingVenue{ID: venueID, Placer: placer, Streamer: streamer})
+			tickers, err := tickerInstruments(name, venueCfg.Tickers)
+			if err != nil {
+				return exchangeProducts{}, err
+			}
+			trading = append(trading, tradingVenue{ID: venueID, Placer: placer, Streamer: streamer, Tickers: tickers})
 		}
-		logger.Info().Str("venue", name).Strs("accounts", venueCfg.Accounts).
+		logger.Info().Str("venue", name).Str("adapter", venueCfg.Adapter).Strs("accounts", venueCfg.Accounts).
 			Bool("authenticated", venueCfg.APIKey != "").Msg("venue connected")
 	}
 	return exchangeProducts{Registry: exchange.NewRegistry(exchanges), Trading: trading}, nil
 }
 
-func newOrderService(cfg config.Config, venues []tradingVenue, commands ports.OrderCommandStore, events ports.OrderEventStore, clk clockwork.Clock, l log.Logger, m *orderservice.Metrics) *orderservice.Service {
+// connectVenue builds the raw adapter and its private stream. The paper
+// simulator is its own streamer and never reconnects.
+func connectVenue(ctx context.Context, name string, venueCfg config.Venue, clk clockwork.Clock, onReconnect func()) (ports.Exchange, ports.PrivateStreamer, error) {
+	if venueCfg.Adapter == config.AdapterPaper {
+		ex, err := paper.New(name, venueCfg.Paper, clk)
+		if err != nil {
+			return nil, nil, err
+		}
+		return ex, ex, nil
+	}
+	ex, err := gct.New(ctx, name, venueCfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ex, gct.NewStreamer(ex, onReconnect), nil
+}
+
+// newOrderService restores the engaged kill switches before anything can
+// place an order.
+func newOrderService(cfg config.Config, venues []tradingVenue, instruments ports.InstrumentCatalog, commands ports.OrderCommandStore, events ports.OrderEventStore, killSwitches ports.KillSwitchStore, preTrade *risk.Chain, reserver orderservice.Reserver, clk clockwork.Clock, l log.Logger, m *orderservice.Metrics) (*orderservice.Service, error) {
 	converted := make([]orderservice.Venue, 0, len(venues))
 	for _, venue := range venues {
 		converted = append(converted, orderservice.Venue(venue))
 	}
-	return orderservice.New(converted, commands, events, clk, l, cfg.Order.SubmitBudget, m)
+	rules := orderservice.NewRuleBook(instruments, clk, l, cfg.Order.RulesTTL, order.RulePolicy(cfg.Order.RulePolicy))
+	svc := orderservice.New(converted, commands, events, killSwitches, rules, preTrade, reserver, clk, l, cfg.Order.SubmitBudget, cfg.Order.ReplaceSettleTimeout, m)
+	ctx, cancel := context.WithTimeout(context.Background(), startupTimeout)
+	defer cancel()
+	if err := svc.LoadHalts(ctx); err != nil {
+		return nil, fmt.Errorf("load kill switches: %w", err)
+	}
+	return svc, nil
+}
+
+func newKillService(cfg config.Config, orders *orderservice.Service, store ports.OrderReconcileStore, clk clockwork.Clock, l log.Logger) *kill.Service {
+	return kill.New(orders, store, clk, l, cfg.Order.KillSettleTimeout)
+}
+
+func newRiskChain(cfg config.Config, orders ports.ActiveOrderCounter, held ports.HeldQtyReader, registry exchange.Registry, clk clockwork.Clock, l log.Logger, m *risk.Metrics) (*risk.Chain, error) {
+	limits, err := riskLimits(cfg.Risk)
+	if err != nil {
+		return nil, err
+	}
+	return risk.New(l, m, risk.Standard(limits, orders, held, registry, clk)...), nil
+}
+
+// riskLimits parses the configured decimals and pairs.
+// newReserver builds the funds reservation hook, or nil to place orders
+// without reserving when funds.reserve is off.
+func newReserver(cfg config.Config, store ports.ReservationStore, registry exchange.Registry, clk clockwork.Clock, l log.Logger, m *fundsservice.Metrics) (orderservice.Reserver, error) {
+	if !cfg.Funds.Reserve {
+		return nil, nil
+	}
+	feeBuffer, err := decimal.NewFromString(cfg.Funds.FeeBuffer)
+	if err != nil || feeBuffer.IsNegative() || feeBuffer.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return nil, fmt.Errorf("funds.fee_buffer %q: must be a decimal from 0 to below 1", cfg.Funds.FeeBuffer)
+	}
+	return fundsservice.New(store, registry, tradingAccounts(cfg), feeBuffer, clk, l, m), nil
+}
+
+// tradingAccounts names the account each venue trades from where it is not
+// spot: its unified account when it has no spot one.
+func tradingAccounts(cfg config.Config) map[instrument.VenueID]account.Type {
+	accounts := map[instrument.VenueID]account.Type{}
+	for name, venue := range cfg.Venues {
+		if !slices.Contains(venue.Accounts, string(account.TypeSpot)) && slices.Contains(venue.Accounts, string(account.TypeUnified)) {
+			accounts[instrument.NewVenueID(name)] = account.TypeUnified
+		}
+	}
+	return accounts
+}
+
+func riskLimits(cfg config.Risk) (risk.Limits, error) {
+	limits := risk.Limits{
+		MaxOpenOrdersPerBot:   cfg.MaxOpenOrdersPerBot,
+		MaxOpenOrdersPerVenue: cfg.MaxOpenOrdersPerVenue,
+		PriceBandBps:          cfg.PriceBandBps,
+	}
+	if len(cfg.MaxNotional) > 0 {
+		limits.MaxNotional = make(map[money.Currency]decimal.Decimal, len(cfg.MaxNotional))
+	}
+	for quote, raw := range cfg.MaxNotional {
+		limit, err := decimal.NewFromString(raw)
+		if err != nil || !limit.IsPositive() {
+			return risk.Limits{}, fmt.Errorf("risk.max_notional.%s %q: must be a positive decimal", quote, raw)
+		}
+		limits.MaxNotional[money.NewCurrency(quote)] = limit
+	}
+	if len(cfg.Positions) > 0 {
+		limits.Positions = make(map[risk.PositionKey]decimal.Decimal, len(cfg.Positions))
+	}
+	for i, p := range cfg.Positions {
+		base, quote, err := instrument.ParsePair(p.Pair)
+		if err != nil {
+			return risk.Limits{}, fmt.Errorf("risk.positions[%d].pair: %w", i, err)
+		}
+		limit, err := decimal.NewFromString(p.MaxQty)
+		if err != nil || limit.IsNegative() {
+			return risk.Limits{}, fmt.Errorf("risk.positions[%d].max_qty %q: must be a non-negative decimal", i, p.MaxQty)
+		}
+		key := risk.PositionKey{BotID: p.Bot, Venue: instrument.NewVenueID(p.Venue), Base: base, Quote: quote}
+		limits.Positions[key] = limit
+	}
+	return limits, nil
 }
 
 func newReconcileService(cfg config.Config, venues []tradingVenue, orders ports.OrderReconcileStore, events ports.OrderEventStore, eventBus bus.Bus, clk clockwork.Clock, l log.Logger, m *reconcile.Metrics) *reconcile.Service {
@@ -150,6 +332,162 @@ func newReconcileService(cfg config.Config, venues []tradingVenue, orders ports.
 	return reconcile.New(converted, orders, events, eventBus, clk, l, cfg.Reconcile.Interval, m)
 }
 
+func newGridSpecs(cfg config.Config) ([]grid.Spec, error) {
+	specs := make([]grid.Spec, 0, len(cfg.Grid.Bots))
+	for i, bot := range cfg.Grid.Bots {
+		spec, err := gridSpec(bot)
+		if err != nil {
+			return nil, fmt.Errorf("grid.bots[%d]: %w", i, err)
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// newLotSelectors pairs every grid bot's sells with the lots bought one
+// level below; everyone else closes FIFO.
+func newLotSelectors(cfg config.Config, specs []grid.Spec) ledger.Selectors {
+	selectors := ledger.Selectors{Default: ledger.FIFO{}, ByBot: map[string]ledger.LotSelector{}}
+	for i, spec := range specs {
+		var fallback ledger.LotSelector = ledger.FIFO{}
+		if cfg.Grid.Bots[i].Fallback == config.FallbackUnmatched {
+			fallback = ledger.Unmatched{}
+		}
+		selectors.ByBot[spec.BotID] = ledger.GridPairing{Levels: spec.Prices(), Fallback: fallback}
+	}
+	return selectors
+}
+
+func newGridService(cfg config.Config, specs []grid.Spec, placer *orderservice.Service, orders ports.GridOrderStore, catalog ports.InstrumentCatalog, registry exchange.Registry, eventBus bus.Bus, clk clockwork.Clock, l log.Logger, m *gridservice.Metrics) *gridservice.Service {
+	return gridservice.New(specs, placer, orders, catalog, registry, eventBus, clk, l, cfg.Grid.RetryInterval, m)
+}
+
+// gridSpec parses one configured bot. The instrument carries no venue
+// symbol; adapters resolve it from base and quote.
+func gridSpec(bot config.GridBot) (grid.Spec, error) {
+	base, quote, err := instrument.ParsePair(bot.Pair)
+	if err != nil {
+		return grid.Spec{}, fmt.Errorf("pair: %w", err)
+	}
+	spec := grid.Spec{
+		BotID:      bot.ID,
+		Instrument: instrument.Instrument{Venue: instrument.NewVenueID(bot.Venue), Type: instrument.TypeSpot, Base: base, Quote: quote},
+		Levels:     bot.Levels,
+	}
+	fields := []struct {
+		name string
+		raw  string
+		dst  *decimal.Decimal
+	}{
+		{"lower", bot.Lower, &spec.Lower},
+		{"upper", bot.Upper, &spec.Upper},
+		{"qty", bot.Qty, &spec.Qty},
+	}
+	for _, field := range fields {
+		if *field.dst, err = decimal.NewFromString(field.raw); err != nil {
+			return grid.Spec{}, fmt.Errorf("%s %q: not a decimal", field.name, field.raw)
+		}
+	}
+	return spec, spec.Validate()
+}
+
+func newArbSpecs(cfg config.Config) ([]arb.Spec, error) {
+	specs := make([]arb.Spec, 0, len(cfg.Arb.Bots))
+	for i, bot := range cfg.Arb.Bots {
+		spec, err := arbSpec(bot)
+		if err != nil {
+			return nil, fmt.Errorf("arb.bots[%d]: %w", i, err)
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+func newArbService(cfg config.Config, specs []arb.Spec, placer *orderservice.Service, store ports.ArbTradeStore, registry exchange.Registry, catalog ports.InstrumentCatalog, eventBus bus.Bus, clk clockwork.Clock, l log.Logger, m *arbservice.Metrics) *arbservice.Service {
+	return arbservice.New(specs, placer, store, registry, catalog, tradingAccounts(cfg), eventBus, clk, l, cfg.Arb.Interval, m)
+}
+
+// newSinkTargets builds the configured sinks in name order.
+func newSinkTargets(cfg config.Config, clk clockwork.Clock) ([]sinkservice.Target, error) {
+	names := slices.Sorted(maps.Keys(cfg.Sinks.Targets))
+	targets := make([]sinkservice.Target, 0, len(names))
+	for _, name := range names {
+		t := cfg.Sinks.Targets[name]
+		var deliver ports.Sink
+		switch t.Kind {
+		case config.SinkWebhook:
+			webhook, err := sinks.NewWebhook(t.URL, t.Secret, t.Timeout, clk)
+			if err != nil {
+				return nil, fmt.Errorf("sink %s: %w", name, err)
+			}
+			deliver = webhook
+		default:
+			deliver = sinks.NewFile(t.Path)
+		}
+		targets = append(targets, sinkservice.Target{Name: name, Subjects: t.Subjects, Sink: deliver})
+	}
+	return targets, nil
+}
+
+func newSinkService(cfg config.Config, targets []sinkservice.Target, store ports.SinkStore, eventBus bus.Bus, clk clockwork.Clock, l log.Logger, m *sinkservice.Metrics) *sinkservice.Service {
+	retry := sinkservice.Retry{MaxAttempts: cfg.Sinks.MaxAttempts, Backoff: cfg.Sinks.Backoff, MaxBackoff: cfg.Sinks.MaxBackoff}
+	return sinkservice.New(targets, store, api.EventJSON, eventBus, clk, l, cfg.Sinks.Interval, retry, m)
+}
+
+// newAuthService loads api.tokens_file, so a malformed file stops startup
+// rather than silently locking every client out.
+func newAuthService(cfg config.Config, store ports.TokenStore, clk clockwork.Clock, l log.Logger) (*authservice.Service, error) {
+	var file []auth.Token
+	if cfg.API.TokensFile != "" {
+		data, err := os.ReadFile(cfg.API.TokensFile)
+		if err != nil {
+			return nil, fmt.Errorf("api.tokens_file: %w", err)
+		}
+		if file, err = auth.ParseFile(data); err != nil {
+			return nil, fmt.Errorf("api.tokens_file %s: %w", cfg.API.TokensFile, err)
+		}
+	}
+	return authservice.New(store, file, clk, l), nil
+}
+
+// arbSpec parses one configured bot. Venues have been checked to be two
+// by config validation; the rest is checked here.
+func arbSpec(bot config.ArbBot) (arb.Spec, error) {
+	base, quote, err := instrument.ParsePair(bot.Pair)
+	if err != nil {
+		return arb.Spec{}, fmt.Errorf("pair: %w", err)
+	}
+	spec := arb.Spec{BotID: bot.ID, Fees: make(map[instrument.VenueID]decimal.Decimal, len(bot.Fees))}
+	for i, venue := range bot.Venues[:min(len(bot.Venues), len(spec.Legs))] {
+		spec.Legs[i] = instrument.Instrument{Venue: instrument.NewVenueID(venue), Type: instrument.TypeSpot, Base: base, Quote: quote}
+	}
+	minEdge := bot.MinEdgeBps
+	if minEdge == "" {
+		minEdge = "0"
+	}
+	fields := []struct {
+		name string
+		raw  string
+		dst  *decimal.Decimal
+	}{
+		{"qty", bot.Qty, &spec.Qty},
+		{"min_edge_bps", minEdge, &spec.MinEdgeBps},
+	}
+	for _, field := range fields {
+		if *field.dst, err = decimal.NewFromString(field.raw); err != nil {
+			return arb.Spec{}, fmt.Errorf("%s %q: not a decimal", field.name, field.raw)
+		}
+	}
+	for venue, raw := range bot.Fees {
+		fee, err := decimal.NewFromString(raw)
+		if err != nil {
+			return arb.Spec{}, fmt.Errorf("fees.%s %q: not a decimal", venue, raw)
+		}
+		spec.Fees[instrument.NewVenueID(venue)] = fee
+	}
+	return spec, spec.Validate()
+}
+
 func newPostgres(lc fx.Lifecycle, cfg config.Config) (*pgxpool.Pool, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), startupTimeout)
 	defer cancel()
@@ -179,6 +517,10 @@ func newQuestDBHealth(cfg config.Config) *questdb.Health {
 	return questdb.NewHealth(cfg.QuestDB)
 }
 
+func newQuestDBReader(cfg config.Config) *questdb.Reader {
+	return questdb.NewReader(cfg.QuestDB)
+}
+
 func newSnapshotService(
 	cfg config.Config,
 	registry exchange.Registry,
@@ -201,6 +543,58 @@ func newSnapshotService(
 	return snapshot.New(registry, series, checkpoints, eventBus, clk, l, cfg.Snapshot.Interval, targets, m)
 }
 
+func newMarkService(cfg config.Config, lots ports.OpenLotStore, registry exchange.Registry, series ports.ValuationSeriesWriter, clk clockwork.Clock, l log.Logger, m *mark.Metrics) *mark.Service {
+	return mark.New(lots, registry, series, clk, l, cfg.Mark.Interval, marketdata.PriceSource(cfg.Mark.Price), m)
+}
+
+// newTickerService builds one poll target per enabled venue that lists
+// ticker pairs.
+func newTickerService(cfg config.Config, registry exchange.Registry, series ports.TickerSeriesWriter, trades ports.TradeSeriesWriter, eventBus bus.Bus, clk clockwork.Clock, l log.Logger, m *ticker.Metrics) (*ticker.Service, error) {
+	var targets []ticker.Target
+	for _, name := range cfg.EnabledVenues() {
+		venueCfg := cfg.Venues[name].Tickers
+		if len(venueCfg.Pairs) == 0 {
+			continue
+		}
+		instruments, err := tickerInstruments(name, venueCfg)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, ticker.Target{Venue: instrument.NewVenueID(name), Interval: venueCfg.Interval, Instruments: instruments})
+	}
+	return ticker.New(registry, series, trades, eventBus, clk, l, targets, m), nil
+}
+
+// tickerInstruments parses a venue's ticker pairs into spot instruments.
+func tickerInstruments(name string, cfg config.Tickers) ([]instrument.Instrument, error) {
+	venue := instrument.NewVenueID(name)
+	instruments := make([]instrument.Instrument, 0, len(cfg.Pairs))
+	for _, pair := range cfg.Pairs {
+		base, quote, err := instrument.ParsePair(pair)
+		if err != nil {
+			return nil, fmt.Errorf("venues.%s.tickers.pairs: %w", name, err)
+		}
+		instruments = append(instruments, instrument.Instrument{Venue: venue, Type: instrument.TypeSpot, Base: base, Quote: quote})
+	}
+	return instruments, nil
+}
+
+func newTriggerService(stops ports.StopStore, orders *orderservice.Service, eventBus bus.Bus, l log.Logger, m *trigger.Metrics) *trigger.Service {
+	return trigger.New(stops, orders, eventBus, l, m)
+}
+
+func newGroupService(cfg config.Config, store ports.OrderGroupStore, orders *orderservice.Service, eventBus bus.Bus, clk clockwork.Clock, l log.Logger, m *groupservice.Metrics) *groupservice.Service {
+	return groupservice.New(store, orders, eventBus, clk, l, cfg.Order.GroupSweepInterval, m)
+}
+
+func newExecutionService(cfg config.Config, store ports.ParentOrderStore, orders *orderservice.Service, instruments ports.InstrumentCatalog, trades ports.TradeSeriesReader, eventBus bus.Bus, clk clockwork.Clock, l log.Logger, m *executionservice.Metrics) *executionservice.Service {
+	return executionservice.New(store, orders, instruments, trades, eventBus, clk, l, cfg.Execution.Tick, cfg.Execution.VWAPLookback, m)
+}
+
+func newCatalogService(cfg config.Config, registry exchange.Registry, store ports.InstrumentStore, clk clockwork.Clock, l log.Logger, m *catalog.Metrics) *catalog.Service {
+	return catalog.New(registry, store, clk, l, cfg.Catalog.Interval, m)
+}
+
 func newOutboxService(cfg config.Config, store ports.OutboxStore, eventBus bus.Bus, clk clockwork.Clock, l log.Logger, m *outbox.Metrics) *outbox.Service {
 	return outbox.New(store, eventBus, clk, l, cfg.Outbox.Interval, cfg.Outbox.Batch, m)
 }
@@ -209,6 +603,34 @@ func startSnapshotService(lc fx.Lifecycle, svc *snapshot.Service, l log.Logger,
 	startService(lc, "snapshot", svc.Run, l, shutdowner)
 }
 
+func startMarkService(lc fx.Lifecycle, svc *mark.Service, l log.Logger, shutdowner fx.Shutdowner) {
+	startService(lc, "mark", svc.Run, l, shutdowner)
+}
+
+func startTickerService(lc fx.Lifecycle, cfg config.Config, svc *ticker.Service, l log.Logger, shutdowner fx.Shutdowner) {
+	for _, name := range cfg.EnabledVenues() {
+		if len(cfg.Venues[name].Tickers.Pairs) > 0 {
+			startService(lc, "ticker", svc.Run, l, shutdowner)
+			return
+		}
+	}
+}
+
+// startCatalogService syncs the catalog once before the order service, the
+// bots and the API start, so placement resolves against current listings
+// from the first order.
+func startCatalogService(lc fx.Lifecycle, svc *catalog.Service, l log.Logger, shutdowner fx.Shutdowner) {
+	lc.Append(fx.Hook{OnStart: func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, startupTimeout)
+		defer cancel()
+		if err := svc.Sync(ctx); err != nil {
+			return fmt.Errorf("sync instrument catalog: %w", err)
+		}
+		return nil
+	}})
+	startService(lc, "catalog", svc.Run, l, shutdowner)
+}
+
 func startOutboxService(lc fx.Lifecycle, svc *outbox.Service, l log.Logger, shutdowner fx.Shutdowner) {
 	startService(lc, "outbox", svc.Run, l, shutdowner)
 }
@@ -228,6 +650,49 @@ func startOrderService(lc fx.Lifecycle, venues []tradingVenue, svc *orderservice
 	startServiceAfter(lc, "order", reconcileService.Ready(), svc.Run, l, shutdowner)
 }
 
+// startTriggerService holds local stops once the order service is up. It
+// runs with any trading venue: a venue without ticker pairs refuses local
+// stops, but one stored before the pairs were removed still resumes.
+func startTriggerService(lc fx.Lifecycle, venues []tradingVenue, svc *trigger.Service, l log.Logger, shutdowner fx.Shutdowner) {
+	if len(venues) > 0 {
+		startService(lc, "trigger", svc.Run, l, shutdowner)
+	}
+}
+
+// startGroupService coordinates order groups with any trading venue, so
+// groups left open by a previous run are resumed.
+func startGroupService(lc fx.Lifecycle, venues []tradingVenue, svc *groupservice.Service, l log.Logger, shutdowner fx.Shutdowner) {
+	if len(venues) > 0 {
+		startService(lc, "group", svc.Run, l, shutdowner)
+	}
+}
+
+// startExecutionService works parent orders with any trading venue, so
+// schedules left running by a previous run are resumed.
+func startExecutionService(lc fx.Lifecycle, venues []tradingVenue, svc *executionservice.Service, l log.Logger, shutdowner fx.Shutdowner) {
+	if len(venues) > 0 {
+		startService(lc, "execution", svc.Run, l, shutdowner)
+	}
+}
+
+func startGridService(lc fx.Lifecycle, cfg config.Config, svc *gridservice.Service, l log.Logger, shutdowner fx.Shutdowner) {
+	if len(cfg.Grid.Bots) > 0 {
+		startService(lc, "grid", svc.Run, l, shutdowner)
+	}
+}
+
+func startArbService(lc fx.Lifecycle, cfg config.Config, svc *arbservice.Service, l log.Logger, shutdowner fx.Shutdowner) {
+	if len(cfg.Arb.Bots) > 0 {
+		startService(lc, "arb", svc.Run, l, shutdowner)
+	}
+}
+
+// startSinkService runs even with no sinks configured: it drops the
+// cursors of removed sinks, which would otherwise hold outbox cleanup back.
+func startSinkService(lc fx.Lifecycle, svc *sinkservice.Service, l log.Logger, shutdowner fx.Shutdowner) {
+	startService(lc, "sink", svc.Run, l, shutdowner)
+}
+
 // startService ties a background service to the fx lifecycle. A non-nil
 // error from run means infrastructure loss; the process exits non-zero so
 // the supervisor (compose, systemd) restarts it.
@@ -269,18 +734,53 @@ func startServiceAfter(lc fx.Lifecycle, name string, ready <-chan struct{}, run
 	})
 }
 
-func newBus(lc fx.Lifecycle) *bus.InProc {
-	b := bus.NewInProc()
+// droppingBus is a Bus that counts the events it drops.
+type droppingBus interface {
+	bus.Bus
+	Dropped() uint64
+	Close()
+}
+
+// newBus builds the bus bus.kind selects. The NATS bus connects and
+// creates its stream here, so an unreachable server fails startup.
+func newBus(cfg config.Config, lc fx.Lifecycle) (droppingBus, error) {
+	var b droppingBus = bus.NewInProc()
+	if cfg.Bus.Kind == "nats" {
+		ctx, cancel := context.WithTimeout(context.Background(), startupTimeout)
+		defer cancel()
+		nb, err := natsbus.Connect(ctx, natsbus.Options{
+			URL: cfg.Bus.NATS.URL, Name: "oms", Stream: cfg.Bus.NATS.Stream, MaxAge: cfg.Bus.NATS.MaxAge,
+		}, busSubjects())
+		if err != nil {
+			return nil, err
+		}
+		b = nb
+	}
 	lc.Append(fx.Hook{
 		OnStop: func(context.Context) error {
 			b.Close()
 			return nil
 		},
 	})
-	return b
+	return b, nil
+}
+
+// busSubjects registers every subject the daemon publishes with the type
+// its payload travels as. A subject missing here fails its publish on the
+// NATS bus, so a new subject is added in the same change that publishes it.
+func busSubjects() *natsbus.Subjects {
+	subjects := natsbus.NewSubjects()
+	natsbus.RegisterOutbox(subjects, events.SubjectOrderUpdated)
+	natsbus.RegisterOutbox(subjects, events.SubjectOrderFilled)
+	natsbus.RegisterOutbox(subjects, events.SubjectInstrumentChanged)
+	natsbus.Register[events.ReconcileOrphanPayload](subjects, events.SubjectReconcileOrphan)
+	natsbus.Register[marketdata.Ticker](subjects, events.SubjectTickerUpdated)
+	natsbus.Register[account.Snapshot](subjects, snapshot.SubjectTaken)
+	natsbus.Register[instrument.VenueID](subjects, orderservice.SubjectStreamReconnected)
+	return subjects
 }
 
-func registerBusMetrics(b *bus.InProc, reg *prometheus.Registry) error {
+func registerBusMetrics(b droppingBus, reg *prometheus.Registry) error {
 	return reg.Register(prometheus.NewCounterFunc(prometheus.CounterOpts{
 		Name: "bus_dropped_total",
 		Help: "Events dropped due to slow bus subscribers.",
@@ -322,15 +822,27 @@ func startTelemetryServer(lc fx.Lifecycle, srv *http.Server, l log.Logger, shutd
 }
 
 // startAPIServer serves the control plane (ADR-0007) when api.addr is
-// configured. The server is built here rather than provided because fx
-// already carries the telemetry *http.Server.
+// configured, behind token auth when api.auth is set (ADR-0012). The server
+// is built here rather than provided because fx already carries the
+// telemetry *http.Server.
 func startAPIServer(lc fx.Lifecycle, cfg config.Config, snapshots *api.SnapshotServer,
-	events *api.EventServer, orders *api.OrderServer, l log.Logger, shutdowner fx.Shutdowner,
+	events *api.EventServer, orders *api.OrderServer, ledgerServer *api.LedgerServer, killServer *api.KillSwitchServer, instrumentServer *api.InstrumentServer, groupServer *api.OrderGroupServer,
+	executionServer *api.ExecutionServer, gridServer *api.GridServer, arbServer *api.ArbServer, sinkServer *api.SinkServer, tokenServer *api.TokenServer, tokens *authservice.Service,
+	m *api.Metrics, l log.Logger, shutdowner fx.Shutdowner,
 ) {
 	if cfg.API.Addr == "" {
 		return
 	}
-	serveHTTP(lc, "api", api.NewServer(snapshots, events, orders), func(ctx context.Context) (net.Listener, error) {
+	var authorizer *api.Authorizer
+	if cfg.API.Auth {
+		authorizer = api.NewAuthorizer(tokens, m)
+	}
+	server := api.NewServer(api.Handlers{
+		Snapshots: snapshots, Events: events, Orders: orders, Ledger: ledgerServer, KillSwitch: killServer,
+		Instruments: instrumentServer, OrderGroups: groupServer, Executions: executionServer, Grids: gridServer, Arbs: arbServer,
+		Sinks: sinkServer, Tokens: tokenServer,
+	}, authorizer)
+	serveHTTP(lc, "api", server, func(ctx context.Context) (net.Listener, error) {
 		return api.Listen(ctx, cfg.API.Addr)
 	}, l, shutdowner)
 }
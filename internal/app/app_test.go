@@ -6,11 +6,14 @@ import (
 	"time"
 
 	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
 	"go.uber.org/fx"
 
 	"github.com/romanornr/delta-works/internal/bus"
 	"github.com/romanornr/delta-works/internal/config"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
 	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/service/risk"
 )
 
 type hookRecorder struct{ hooks []fx.Hook }
@@ -70,3 +73,21 @@ func TestOrderStartWaitsForReconcileReady(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestRiskLimits(t *testing.T) {
+	t.Parallel()
+	limits, err := riskLimits(config.Risk{
+		MaxNotional: map[string]string{"usdt": "10000"},
+		Positions:   []config.PositionLimit{{Bot: "grid", Venue: "Bybit", Pair: "BTC/USDT", MaxQty: "0.5"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := risk.PositionKey{BotID: "grid", Venue: instrument.NewVenueID("Bybit"), Base: "BTC", Quote: "USDT"}
+	if !limits.MaxNotional["USDT"].Equal(decimal.NewFromInt(10000)) || !limits.Positions[key].Equal(decimal.RequireFromString("0.5")) {
+		t.Fatalf("limits = %+v", limits)
+	}
+	if _, err := riskLimits(config.Risk{MaxNotional: map[string]string{"USDT": "lots"}}); err == nil {
+		t.Fatal("unparsable notional accepted")
+	}
+}
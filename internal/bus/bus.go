@@ -20,6 +20,13 @@ type Event struct {
 	Subject string
 	At      time.Time
 	Payload any
+	// OutboxID is the outbox row the relay published the event from, zero
+	// for hints. A retried row carries the same ID, so it identifies
+	// duplicates.
+	OutboxID int64
+	// PublishSeq is the row's place in publish order, the cursor a
+	// resumed stream counts in; zero for hints.
+	PublishSeq int64
 }
 
 // Handler consumes events. It runs on the subscriber's own goroutine and
@@ -0,0 +1,278 @@
+// Package nats implements bus.Bus over NATS and JetStream (ADR-0005), so
+// events can reach subscribers in other processes. Payloads travel as JSON
+// and are decoded back to the type registered for their subject, so a
+// subscriber sees the same values it would on the in-process bus.
+//
+// Subjects the outbox relays are published to a JetStream stream and
+// acknowledged by the server, which keeps them for max age; the relay only
+// marks a row published once the stream has it. Everything else is a plain
+// NATS message. Either way subscribers get live, at-most-once delivery
+// through a bounded buffer, exactly as on bus.InProc: the stream is for
+// other processes to replay, not a redelivery promise to this one.
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/romanornr/delta-works/internal/bus"
+)
+
+// Headers carry what bus.Event holds beside the payload.
+const (
+	headerAt         = "Event-At"
+	headerOutboxID   = "Outbox-Id"
+	headerPublishSeq = "Outbox-Publish-Seq"
+)
+
+// subscriberBuffer matches bus.InProc, so a slow subscriber drops at the
+// same depth whichever bus it is on.
+const subscriberBuffer = 64
+
+// flushTimeout bounds how long Close waits for buffered publishes.
+const flushTimeout = time.Second
+
+var _ bus.Bus = (*Bus)(nil)
+
+// Options configures the connection and the durable stream.
+type Options struct {
+	// URL is the server, e.g. "nats://127.0.0.1:4222"; a comma-separated
+	// list names a cluster.
+	URL string
+	// Name identifies this process's connection in the server's monitoring.
+	Name string
+	// Stream is the JetStream stream outbox subjects are stored in.
+	Stream string
+	// MaxAge is how long the stream keeps a message.
+	MaxAge time.Duration
+}
+
+// Bus is the NATS Bus implementation.
+type Bus struct {
+	conn     *natsgo.Conn
+	js       jetstream.JetStream
+	subjects *Subjects
+
+	mu      sync.Mutex
+	nextID  int
+	subs    map[int]*subscriber
+	baseCtx context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	dropped atomic.Uint64
+	closed  bool
+}
+
+type subscriber struct {
+	prefix string
+	ch     chan bus.Event
+}
+
+// Connect dials the server and creates or updates the stream for the
+// registry's outbox subjects. The connection reconnects on its own for as
+// long as the process runs; publishes made while it is down are buffered,
+// or for outbox subjects fail and stay in the outbox. Close releases it.
+func Connect(ctx context.Context, opts Options, subjects *Subjects) (*Bus, error) {
+	conn, err := natsgo.Connect(opts.URL, natsgo.Name(opts.Name), natsgo.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("nats: connect: %w", err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("nats: jetstream: %w", err)
+	}
+	if durable := subjects.outbox(); len(durable) > 0 {
+		if _, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+			Name:     opts.Stream,
+			Subjects: durable,
+			Storage:  jetstream.FileStorage,
+			MaxAge:   opts.MaxAge,
+		}); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("nats: stream %s: %w", opts.Stream, err)
+		}
+	}
+	baseCtx, cancel := context.WithCancel(context.Background())
+	return &Bus{
+		conn:     conn,
+		js:       js,
+		subjects: subjects,
+		subs:     map[int]*subscriber{},
+		baseCtx:  baseCtx,
+		cancel:   cancel,
+	}, nil
+}
+
+// Publish implements Bus. An outbox subject returns once the stream has
+// stored the event, deduplicated by its OutboxID; any other subject
+// returns once the message is buffered for the server.
+func (b *Bus) Publish(ctx context.Context, e bus.Event) error {
+	b.mu.Lock()
+	closed := b.closed
+	b.mu.Unlock()
+	if closed {
+		return nil
+	}
+	c, err := b.subjects.lookup(e.Subject)
+	if err != nil {
+		return err
+	}
+	data, err := c.encode(e.Payload)
+	if err != nil {
+		return err
+	}
+	msg := natsgo.NewMsg(e.Subject)
+	msg.Data = data
+	msg.Header.Set(headerAt, e.At.UTC().Format(time.RFC3339Nano))
+	if e.OutboxID != 0 {
+		msg.Header.Set(headerOutboxID, strconv.FormatInt(e.OutboxID, 10))
+	}
+	if e.PublishSeq != 0 {
+		msg.Header.Set(headerPublishSeq, strconv.FormatInt(e.PublishSeq, 10))
+	}
+	if !c.outbox {
+		if err := b.conn.PublishMsg(msg); err != nil {
+			return fmt.Errorf("nats: publish %s: %w", e.Subject, err)
+		}
+		return nil
+	}
+	var opts []jetstream.PublishOpt
+	if e.OutboxID != 0 {
+		opts = append(opts, jetstream.WithMsgID("outbox-"+strconv.FormatInt(e.OutboxID, 10)))
+	}
+	if _, err := b.js.PublishMsg(ctx, msg, opts...); err != nil {
+		return fmt.Errorf("nats: publish %s to stream: %w", e.Subject, err)
+	}
+	return nil
+}
+
+// Subscribe implements Bus. NATS matches whole tokens, so the prefix is
+// subscribed as the wildcard over its last complete token, "order." as
+// "order.>" and "ticker.updated" as "ticker.>", and narrowed to the prefix
+// here. Events on subjects this process never registered are ignored.
+func (b *Bus) Subscribe(subjectPrefix string, h bus.Handler) (func(), error) {
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	s := &subscriber{prefix: subjectPrefix, ch: make(chan bus.Event, subscriberBuffer)}
+	b.subs[id] = s
+	b.mu.Unlock()
+
+	sub, err := b.conn.Subscribe(wildcard(subjectPrefix), func(m *natsgo.Msg) { b.deliver(id, m) })
+	if err != nil {
+		b.mu.Lock()
+		delete(b.subs, id)
+		b.mu.Unlock()
+		return nil, fmt.Errorf("nats: subscribe %q: %w", subjectPrefix, err)
+	}
+
+	b.wg.Go(func() {
+		for {
+			select {
+			case <-b.baseCtx.Done():
+				return
+			case e, ok := <-s.ch:
+				if !ok {
+					return
+				}
+				h(b.baseCtx, e)
+			}
+		}
+	})
+
+	return func() {
+		_ = sub.Unsubscribe()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if s, ok := b.subs[id]; ok {
+			delete(b.subs, id)
+			close(s.ch)
+		}
+	}, nil
+}
+
+// deliver decodes a message for subscriber id and queues it without
+// blocking: a full buffer or an undecodable payload counts as a drop.
+func (b *Bus) deliver(id int, m *natsgo.Msg) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s, ok := b.subs[id]
+	if !ok || b.closed || !strings.HasPrefix(m.Subject, s.prefix) {
+		return
+	}
+	e, err := b.event(m)
+	if errors.Is(err, ErrUnknownSubject) {
+		return
+	}
+	if err != nil {
+		b.dropped.Add(1)
+		return
+	}
+	select {
+	case s.ch <- e:
+	default:
+		b.dropped.Add(1)
+	}
+}
+
+func (b *Bus) event(m *natsgo.Msg) (bus.Event, error) {
+	c, err := b.subjects.lookup(m.Subject)
+	if err != nil {
+		return bus.Event{}, err
+	}
+	payload, err := c.decode(m.Data)
+	if err != nil {
+		return bus.Event{}, fmt.Errorf("nats: decode %s: %w", m.Subject, err)
+	}
+	e := bus.Event{Subject: m.Subject, Payload: payload}
+	if at := m.Header.Get(headerAt); at != "" {
+		if e.At, err = time.Parse(time.RFC3339Nano, at); err != nil {
+			return bus.Event{}, fmt.Errorf("nats: %s %s header: %w", m.Subject, headerAt, err)
+		}
+	}
+	if id := m.Header.Get(headerOutboxID); id != "" {
+		if e.OutboxID, err = strconv.ParseInt(id, 10, 64); err != nil {
+			return bus.Event{}, fmt.Errorf("nats: %s %s header: %w", m.Subject, headerOutboxID, err)
+		}
+	}
+	if seq := m.Header.Get(headerPublishSeq); seq != "" {
+		if e.PublishSeq, err = strconv.ParseInt(seq, 10, 64); err != nil {
+			return bus.Event{}, fmt.Errorf("nats: %s %s header: %w", m.Subject, headerPublishSeq, err)
+		}
+	}
+	return e, nil
+}
+
+// wildcard is the NATS subject that covers every subject with prefix.
+func wildcard(prefix string) string {
+	if i := strings.LastIndexByte(prefix, '.'); i >= 0 {
+		return prefix[:i+1] + ">"
+	}
+	return ">"
+}
+
+// Dropped reports how many events were discarded for slow subscribers or
+// payloads that did not decode.
+func (b *Bus) Dropped() uint64 { return b.dropped.Load() }
+
+// Close flushes buffered publishes, closes the connection and waits for
+// the subscriber goroutines to exit.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+	_ = b.conn.FlushTimeout(flushTimeout)
+	b.conn.Close()
+	b.cancel()
+	b.wg.Wait()
+}
@@ -0,0 +1,196 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/romanornr/delta-works/internal/bus"
+)
+
+type tick struct {
+	Pair  string
+	Price string
+}
+
+// startServer runs an in-process JetStream server for the test.
+func startServer(t *testing.T) string {
+	t.Helper()
+	srv, err := server.NewServer(&server.Options{Host: "127.0.0.1", Port: -1, JetStream: true, StoreDir: t.TempDir(), NoLog: true, NoSigs: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Start()
+	t.Cleanup(srv.Shutdown)
+	if !srv.ReadyForConnections(5 * time.Second) {
+		t.Fatal("nats server not ready")
+	}
+	return srv.ClientURL()
+}
+
+func connect(t *testing.T, url string) *Bus {
+	t.Helper()
+	subjects := NewSubjects()
+	Register[tick](subjects, "ticker.updated")
+	Register[string](subjects, "stream.reconnected")
+	RegisterOutbox(subjects, "order.updated")
+	RegisterOutbox(subjects, "order.filled")
+	b, err := Connect(t.Context(), Options{URL: url, Name: t.Name(), Stream: "TEST_OUTBOX", MaxAge: time.Hour}, subjects)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(b.Close)
+	return b
+}
+
+func subscribe(t *testing.T, b *Bus, prefix string) <-chan bus.Event {
+	t.Helper()
+	got := make(chan bus.Event, 10)
+	unsubscribe, err := b.Subscribe(prefix, func(_ context.Context, e bus.Event) { got <- e })
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(unsubscribe)
+	return got
+}
+
+func receive(t *testing.T, ch <-chan bus.Event) bus.Event {
+	t.Helper()
+	select {
+	case e := <-ch:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+		return bus.Event{}
+	}
+}
+
+func TestPublishAcrossConnections(t *testing.T) {
+	t.Parallel()
+	url := startServer(t)
+	publisher, subscriber := connect(t, url), connect(t, url)
+	tickers := subscribe(t, subscriber, "ticker.updated")
+	orders := subscribe(t, subscriber, "order.")
+	partial := subscribe(t, subscriber, "order.fil")
+	// Subscriptions reach the server asynchronously.
+	if err := subscriber.conn.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	at := time.Date(2026, 10, 1, 12, 0, 0, 123456789, time.UTC)
+	if err := publisher.Publish(t.Context(), bus.Event{Subject: "ticker.updated", At: at, Payload: tick{Pair: "BTC/USDT", Price: "60000"}}); err != nil {
+		t.Fatal(err)
+	}
+	if e := receive(t, tickers); e.Payload != (tick{Pair: "BTC/USDT", Price: "60000"}) || !e.At.Equal(at) {
+		t.Fatalf("ticker event = %+v", e)
+	}
+
+	for i, subject := range []string{"order.updated", "order.filled"} {
+		raw := json.RawMessage(`{"client_order_id":"cid-1"}`)
+		if err := publisher.Publish(t.Context(), bus.Event{Subject: subject, At: at, Payload: raw, OutboxID: int64(i + 1), PublishSeq: int64(i + 11)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, subject := range []string{"order.updated", "order.filled"} {
+		e := receive(t, orders)
+		raw, ok := e.Payload.(json.RawMessage)
+		if e.Subject != subject || !ok || string(raw) != `{"client_order_id":"cid-1"}` || e.OutboxID != int64(i+1) || e.PublishSeq != int64(i+11) {
+			t.Fatalf("order event %d = %+v", i, e)
+		}
+	}
+	if e := receive(t, partial); e.Subject != "order.filled" {
+		t.Fatalf("prefix order.fil got %s", e.Subject)
+	}
+	select {
+	case e := <-partial:
+		t.Fatalf("prefix order.fil got a second event %s", e.Subject)
+	case <-time.After(50 * time.Millisecond):
+	}
+	select {
+	case e := <-tickers:
+		t.Fatalf("prefix ticker.updated got %s", e.Subject)
+	default:
+	}
+}
+
+func TestOutboxSubjectsAreStored(t *testing.T) {
+	t.Parallel()
+	b := connect(t, startServer(t))
+	raw := json.RawMessage(`{"client_order_id":"cid-1"}`)
+	for range 2 { // a row the relay retries carries the same ID
+		if err := b.Publish(t.Context(), bus.Event{Subject: "order.updated", At: time.Now(), Payload: raw, OutboxID: 42}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := b.Publish(t.Context(), bus.Event{Subject: "ticker.updated", At: time.Now(), Payload: tick{}}); err != nil {
+		t.Fatal(err)
+	}
+	stream, err := b.js.Stream(t.Context(), "TEST_OUTBOX")
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := stream.Info(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.State.Msgs != 1 {
+		t.Fatalf("stream holds %d messages, want 1", info.State.Msgs)
+	}
+	msg, err := stream.GetLastMsgForSubject(t.Context(), "order.updated")
+	if err != nil || string(msg.Data) != string(raw) || msg.Header.Get(jetstream.MsgIDHeader) != "outbox-42" {
+		t.Fatalf("stored message = %+v, %v", msg, err)
+	}
+}
+
+func TestPublishChecksTheRegistry(t *testing.T) {
+	t.Parallel()
+	b := connect(t, startServer(t))
+	if err := b.Publish(t.Context(), bus.Event{Subject: "snapshot.taken", Payload: tick{}}); !errors.Is(err, ErrUnknownSubject) {
+		t.Fatalf("unregistered subject: err = %v", err)
+	}
+	if err := b.Publish(t.Context(), bus.Event{Subject: "ticker.updated", Payload: "BTC/USDT"}); err == nil {
+		t.Fatal("a payload of the wrong type was published")
+	}
+	if err := b.Publish(t.Context(), bus.Event{Subject: "order.updated", Payload: tick{}}); err == nil {
+		t.Fatal("an outbox payload that is not JSON was published")
+	}
+}
+
+func TestUnsubscribeStopsDelivery(t *testing.T) {
+	t.Parallel()
+	b := connect(t, startServer(t))
+	delivered := make(chan bus.Event, 1)
+	unsubscribe, err := b.Subscribe("", func(_ context.Context, e bus.Event) { delivered <- e })
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsubscribe()
+	if err := b.Publish(t.Context(), bus.Event{Subject: "stream.reconnected", Payload: "bybit"}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-delivered:
+		t.Error("received event after unsubscribe")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestWildcard(t *testing.T) {
+	t.Parallel()
+	for prefix, want := range map[string]string{
+		"":               ">",
+		"order":          ">",
+		"order.":         "order.>",
+		"order.fil":      "order.>",
+		"ticker.updated": "ticker.>",
+	} {
+		if got := wildcard(prefix); got != want {
+			t.Errorf("wildcard(%q) = %q, want %q", prefix, got, want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package nats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrUnknownSubject refuses a publish on a subject nobody registered: a
+// subscriber in another process could not decode it.
+var ErrUnknownSubject = errors.New("unregistered subject")
+
+// Subjects is the typed subject registry: the Go type each subject's
+// payload is encoded from and decoded back to. Registration happens at
+// startup, before the bus connects; the registry is read-only afterwards.
+type Subjects struct {
+	byName map[string]codec
+}
+
+type codec struct {
+	outbox bool
+	encode func(payload any) ([]byte, error)
+	decode func(data []byte) (any, error)
+}
+
+// NewSubjects returns an empty registry.
+func NewSubjects() *Subjects {
+	return &Subjects{byName: map[string]codec{}}
+}
+
+// Register declares a subject whose payload is a T, carried as its JSON
+// encoding and handed to subscribers as a T again. Publishing anything
+// else on the subject is an error.
+func Register[T any](s *Subjects, subject string) {
+	s.byName[subject] = codec{
+		encode: func(payload any) ([]byte, error) {
+			v, ok := payload.(T)
+			if !ok {
+				var want T
+				return nil, fmt.Errorf("nats: %s payload is %T, want %T", subject, payload, want)
+			}
+			return json.Marshal(v)
+		},
+		decode: func(data []byte) (any, error) {
+			var v T
+			if err := json.Unmarshal(data, &v); err != nil {
+				return nil, err
+			}
+			return v, nil
+		},
+	}
+}
+
+// RegisterOutbox declares a subject the outbox relay publishes. Its
+// payload is already JSON, a json.RawMessage on both sides, and it is
+// stored in the durable stream rather than only fanned out.
+func RegisterOutbox(s *Subjects, subject string) {
+	s.byName[subject] = codec{
+		outbox: true,
+		encode: func(payload any) ([]byte, error) {
+			raw, ok := payload.(json.RawMessage)
+			if !ok {
+				return nil, fmt.Errorf("nats: %s payload is %T, want json.RawMessage", subject, payload)
+			}
+			return raw, nil
+		},
+		decode: func(data []byte) (any, error) {
+			if !json.Valid(data) {
+				return nil, errors.New("invalid JSON")
+			}
+			return json.RawMessage(data), nil
+		},
+	}
+}
+
+func (s *Subjects) lookup(subject string) (codec, error) {
+	c, ok := s.byName[subject]
+	if !ok {
+		return codec{}, fmt.Errorf("nats: %w %q", ErrUnknownSubject, subject)
+	}
+	return c, nil
+}
+
+// outbox lists the subjects the durable stream captures.
+func (s *Subjects) outbox() []string {
+	var subjects []string
+	for name, c := range s.byName {
+		if c.outbox {
+			subjects = append(subjects, name)
+		}
+	}
+	slices.Sort(subjects)
+	return subjects
+}
@@ -5,8 +5,13 @@
 package config
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -22,9 +27,18 @@ type Config struct {
 	Postgres  Postgres         `koanf:"postgres"`
 	QuestDB   QuestDB          `koanf:"questdb"`
 	Snapshot  Snapshot         `koanf:"snapshot"`
+	Bus       Bus              `koanf:"bus"`
 	Outbox    Outbox           `koanf:"outbox"`
 	Reconcile Reconcile        `koanf:"reconcile"`
+	Catalog   Catalog          `koanf:"catalog"`
 	Order     Order            `koanf:"order"`
+	Execution Execution        `koanf:"execution"`
+	Grid      Grid             `koanf:"grid"`
+	Arb       Arb              `koanf:"arb"`
+	Mark      Mark             `koanf:"mark"`
+	Risk      Risk             `koanf:"risk"`
+	Funds     Funds            `koanf:"funds"`
+	Sinks     Sinks            `koanf:"sinks"`
 	Venues    map[string]Venue `koanf:"venues"`
 }
 
@@ -41,9 +55,15 @@ type HTTP struct {
 
 // API configures the control-plane RPC server (ADR-0007). An empty Addr
 // disables it. "unix:///path/to.sock" listens on a Unix socket with 0600
-// permissions; anything else is a TCP host:port.
+// permissions; anything else is a TCP host:port, which requires Auth.
+// Auth admits only requests bearing a token whose scopes cover the RPC
+// (ADR-0012): tokens created over the API and kept hashed in Postgres,
+// and those in TokensFile, one "name scopes sha256-hex" line each, read at
+// startup. The file is how the first admin token exists.
 type API struct {
-	Addr string `koanf:"addr"`
+	Addr       string `koanf:"addr"`
+	Auth       bool   `koanf:"auth"`
+	TokensFile string `koanf:"tokens_file"`
 }
 
 // Postgres configures the durable state store.
@@ -52,9 +72,12 @@ type Postgres struct {
 }
 
 // QuestDB configures the time-series store. Conf is a QuestDB client
-// configuration string, e.g. "http::addr=localhost:9000;".
+// configuration string, e.g. "http::addr=localhost:9000;". TradesTable
+// names the market trade series the ticker service records and the
+// execution algorithms read volume and benchmarks from.
 type QuestDB struct {
-	Conf string `koanf:"conf"`
+	Conf        string `koanf:"conf"`
+	TradesTable string `koanf:"trades_table"`
 }
 
 // Snapshot configures the portfolio snapshot poller.
@@ -62,6 +85,23 @@ type Snapshot struct {
 	Interval time.Duration `koanf:"interval"`
 }
 
+// Bus selects the event bus (ADR-0005): inproc keeps events inside the
+// daemon, nats publishes them to a NATS server other processes can
+// subscribe to.
+type Bus struct {
+	Kind string `koanf:"kind"`
+	NATS NATS   `koanf:"nats"`
+}
+
+// NATS configures the NATS bus. Outbox subjects are stored in the
+// JetStream stream named Stream for MaxAge; URL may carry credentials,
+// like the Postgres DSN.
+type NATS struct {
+	URL    string        `koanf:"url"`
+	Stream string        `koanf:"stream"`
+	MaxAge time.Duration `koanf:"max_age"`
+}
+
 // Outbox configures the outbox relay (ADR-0008).
 type Outbox struct {
 	Interval time.Duration `koanf:"interval"`
@@ -73,24 +113,235 @@ type Reconcile struct {
 	Interval time.Duration `koanf:"interval"`
 }
 
+// Catalog configures the instrument catalog sync. Each venue's listing is
+// synced at startup and then every Interval.
+type Catalog struct {
+	Interval time.Duration `koanf:"interval"`
+}
+
 // Order configures venue order submission retries. SubmitBudget bounds one
 // invocation's venue-submit retries, not the end-to-end RPC duration.
+// KillSettleTimeout bounds how long a kill waits for its cancels to reach
+// a terminal status before reporting the rest unsettled.
+// ReplaceSettleTimeout bounds how long a cancel-replace waits for the old
+// order to reach a terminal status before giving up without placing the
+// new one. RulePolicy is
+// "reject" or "round": what to do with an order off its instrument's tick
+// or step size. RulesTTL is how long placement caches a venue's catalog
+// entries, so it bounds how late a catalog change takes effect.
+// GroupSweepInterval spaces the order-group coordinator's passes over
+// every open group, which repair what a lost event left undone.
 type Order struct {
-	SubmitBudget time.Duration `koanf:"submit_budget"`
+	SubmitBudget         time.Duration `koanf:"submit_budget"`
+	KillSettleTimeout    time.Duration `koanf:"kill_settle_timeout"`
+	ReplaceSettleTimeout time.Duration `koanf:"replace_settle_timeout"`
+	RulePolicy           string        `koanf:"rule_policy"`
+	RulesTTL             time.Duration `koanf:"rules_ttl"`
+	GroupSweepInterval   time.Duration `koanf:"group_sweep_interval"`
+}
+
+// Execution configures the execution algorithms that work parent orders
+// through child orders. Tick spaces the passes that place the slices
+// falling due, so it bounds how late a slice goes out. VWAPLookback is how
+// much trade history a VWAP's volume profile is read from when none is
+// supplied.
+type Execution struct {
+	Tick         time.Duration `koanf:"tick"`
+	VWAPLookback time.Duration `koanf:"vwap_lookback"`
+}
+
+// Grid configures the grid bots. Each bot trades one pair on one trading
+// venue; RetryInterval spaces re-placement of levels whose order failed and
+// retries of a bot's startup when its venue is unreachable.
+type Grid struct {
+	RetryInterval time.Duration `koanf:"retry_interval"`
+	Bots          []GridBot     `koanf:"bots"`
+}
+
+// GridBot is one grid bot: Levels evenly spaced prices from Lower to Upper
+// inclusive, each order for Qty of the base currency. Decimal values are
+// strings so they reach the bot exactly. The bot's sells close the lots
+// bought one level below; Fallback picks what closes the rest.
+type GridBot struct {
+	ID       string `koanf:"id"`
+	Venue    string `koanf:"venue"`
+	Pair     string `koanf:"pair"`
+	Lower    string `koanf:"lower"`
+	Upper    string `koanf:"upper"`
+	Levels   int    `koanf:"levels"`
+	Qty      string `koanf:"qty"`
+	Fallback string `koanf:"fallback"`
+}
+
+// Arb configures the cross-venue arbitrage bots. Every Interval each bot
+// settles its open trades and, when it has none, compares its venues'
+// tickers for a new one.
+type Arb struct {
+	Interval time.Duration `koanf:"interval"`
+	Bots     []ArbBot      `koanf:"bots"`
+}
+
+// ArbBot is one arbitrage bot: Pair on the two Venues, at most Qty of the
+// base currency a trade, traded when the spread clears MinEdgeBps net of
+// Fees. Fees maps a venue to its taker fee rate, "0.001" for 0.1%; an
+// absent venue trades free. Decimal values are strings so they reach the
+// bot exactly.
+type ArbBot struct {
+	ID         string            `koanf:"id"`
+	Venues     []string          `koanf:"venues"`
+	Pair       string            `koanf:"pair"`
+	Qty        string            `koanf:"qty"`
+	MinEdgeBps string            `koanf:"min_edge_bps"`
+	Fees       map[string]string `koanf:"fees"`
+}
+
+// Mark configures mark-to-market valuation of open lots. Price is the
+// ticker price lots are valued at: "mid" (falling back to the last trade
+// on a one-sided book) or "last".
+type Mark struct {
+	Interval time.Duration `koanf:"interval"`
+	Price    string        `koanf:"price"`
+}
+
+// Risk configures the pre-trade checks every new order passes before it
+// is stored or sent. A zero or absent limit is not enforced. MaxNotional
+// maps a quote currency to the largest qty*price one order may carry;
+// PriceBandBps caps how far a limit order may cross the ticker price.
+// Decimal values are strings so they reach the checks exactly.
+type Risk struct {
+	MaxNotional           map[string]string `koanf:"max_notional"`
+	MaxOpenOrdersPerBot   int               `koanf:"max_open_orders_per_bot"`
+	MaxOpenOrdersPerVenue int               `koanf:"max_open_orders_per_venue"`
+	PriceBandBps          int64             `koanf:"price_band_bps"`
+	Positions             []PositionLimit   `koanf:"positions"`
+}
+
+// Funds configures the reservation ledger. With Reserve set, every order
+// placed on a trading venue reserves what it will spend as it is stored
+// and is refused when the venue's latest balance snapshot, less what
+// other orders reserved since, cannot cover it. A buy reserves its
+// notional plus FeeBuffer, a fee rate such as "0.002"; it is a string so
+// it reaches the check exactly. Balances are read from the venue's spot
+// account, or its unified account when it has no spot one.
+type Funds struct {
+	Reserve   bool   `koanf:"reserve"`
+	FeeBuffer string `koanf:"fee_buffer"`
+}
+
+// Sinks configures delivery of outbox events to external systems. Each
+// target follows the outbox on its own cursor and also polls every
+// Interval for events the bus did not wake it for. A delivery is tried up
+// to MaxAttempts times, waiting Backoff and then twice as long each time
+// up to MaxBackoff, before it becomes a dead letter.
+type Sinks struct {
+	Interval    time.Duration   `koanf:"interval"`
+	MaxAttempts int             `koanf:"max_attempts"`
+	Backoff     time.Duration   `koanf:"backoff"`
+	MaxBackoff  time.Duration   `koanf:"max_backoff"`
+	Targets     map[string]Sink `koanf:"targets"`
+}
+
+// Sink kinds.
+const (
+	SinkWebhook = "webhook"
+	SinkFile    = "file"
+)
+
+// Sink is one delivery target, named by its key under sinks.targets; the
+// name keys its cursor and dead letters, so renaming a sink starts it over
+// from the newest event. A webhook POSTs each event to URL signed with
+// Secret, either a direct value or read from SecretFile, and waits Timeout
+// for the answer. Secret takes the Standard Webhooks form, "whsec_" and
+// the base64 of the signing key, so receivers can verify with its
+// libraries. A file appends each event as a line to Path. Subjects
+// are the subject prefixes the sink takes; none means every outbox
+// subject.
+type Sink struct {
+	Kind       string        `koanf:"kind"`
+	URL        string        `koanf:"url"`
+	Secret     string        `koanf:"secret"`
+	SecretFile string        `koanf:"secret_file"`
+	Timeout    time.Duration `koanf:"timeout"`
+	Path       string        `koanf:"path"`
+	Subjects   []string      `koanf:"subjects"`
 }
 
+// PositionLimit caps the base quantity one bot may hold of a pair on a
+// venue; a buy that could take it past MaxQty is refused.
+type PositionLimit struct {
+	Bot    string `koanf:"bot"`
+	Venue  string `koanf:"venue"`
+	Pair   string `koanf:"pair"`
+	MaxQty string `koanf:"max_qty"`
+}
+
+// Lot-pairing fallbacks for grid sells.
+const (
+	// FallbackFIFO closes the oldest remaining lots.
+	FallbackFIFO = "fifo"
+	// FallbackUnmatched records the remainder as an unmatched sell.
+	FallbackUnmatched = "unmatched"
+)
+
+// Venue adapters selectable per venue.
+const (
+	AdapterGCT   = "gct"
+	AdapterPaper = "paper"
+)
+
 // Venue configures one exchange connection. Each credential is either a
 // direct value or a path to a secret file, not both. Files carry multiline
-// secrets such as PEM keys (ADR-0006).
+// secrets such as PEM keys (ADR-0006). Adapter selects the implementation:
+// empty or "gct" connects the real venue, "paper" simulates one from Paper.
 type Venue struct {
 	Enabled       bool     `koanf:"enabled"`
 	Trading       bool     `koanf:"trading"`
+	Adapter       string   `koanf:"adapter"`
 	Accounts      []string `koanf:"accounts"`
 	Rate          Rate     `koanf:"rate"`
 	APIKey        string   `koanf:"api_key"`
 	APISecret     string   `koanf:"api_secret"`
 	APIKeyFile    string   `koanf:"api_key_file"`
 	APISecretFile string   `koanf:"api_secret_file"`
+	Paper         Paper    `koanf:"paper"`
+	Tickers       Tickers  `koanf:"tickers"`
+}
+
+// Tickers configures the venue's market-data poller: every Interval the
+// ticker of each spot pair in Pairs ("BTC/USDT") goes to the time-series
+// store. No pairs means the venue is not polled.
+type Tickers struct {
+	Interval time.Duration `koanf:"interval"`
+	Pairs    []string      `koanf:"pairs"`
+}
+
+// Paper configures a simulated venue. Decimal values are strings so they
+// reach the simulator exactly. Feed is a recorded ticker CSV
+// (pair,bid,ask,last per row) replayed one row per pair each Interval;
+// without it prices follow a seeded random walk from each instrument's
+// Price. FillRatio is the share of an order's quantity filled per step
+// (zero means whole fills); OrderTTL expires resting orders (zero never
+// does); FeeRate is charged in the quote currency.
+type Paper struct {
+	Feed        string            `koanf:"feed"`
+	Interval    time.Duration     `koanf:"interval"`
+	Seed        uint64            `koanf:"seed"`
+	Volatility  float64           `koanf:"volatility"`
+	FillRatio   float64           `koanf:"fill_ratio"`
+	OrderTTL    time.Duration     `koanf:"order_ttl"`
+	FeeRate     string            `koanf:"fee_rate"`
+	Balances    map[string]string `koanf:"balances"`
+	Instruments []PaperInstrument `koanf:"instruments"`
+}
+
+// PaperInstrument is one simulated market and its trading rules.
+type PaperInstrument struct {
+	Pair           string `koanf:"pair"`
+	Price          string `koanf:"price"`
+	PriceIncrement string `koanf:"price_increment"`
+	QtyIncrement   string `koanf:"qty_increment"`
+	MinQty         string `koanf:"min_qty"`
+	MinNotional    string `koanf:"min_notional"`
 }
 
 // Rate configures the client-side rate limiter for a venue.
@@ -99,6 +350,15 @@ type Rate struct {
 	Burst int     `koanf:"burst"`
 }
 
+// sqlIdentifier matches a table name safe to splice into a query.
+var sqlIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// streamName is what JetStream accepts as a stream name.
+var streamName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// sinkName keeps sink names usable as metric labels and CLI arguments.
+var sinkName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
 var validLevels = map[string]bool{
 	"trace": true, "debug": true, "info": true, "warn": true, "error": true,
 }
@@ -116,9 +376,16 @@ func (c Config) Validate() error {
 	if c.HTTP.Addr == "" {
 		errs = append(errs, errors.New("http.addr: must not be empty"))
 	}
+	if c.API.Addr != "" && !strings.HasPrefix(c.API.Addr, "unix://") && !c.API.Auth {
+		errs = append(errs, fmt.Errorf("api.addr %q: a TCP address requires api.auth=true; only a unix:// socket may go without tokens", c.API.Addr))
+	}
+	if c.API.TokensFile != "" && !c.API.Auth {
+		errs = append(errs, errors.New("api.tokens_file: requires api.auth=true"))
+	}
 	if c.Snapshot.Interval <= 0 {
 		errs = append(errs, fmt.Errorf("snapshot.interval %s: must be positive", c.Snapshot.Interval))
 	}
+	errs = append(errs, c.Bus.validate()...)
 	if c.Outbox.Interval < 200*time.Millisecond || c.Outbox.Interval > time.Second {
 		errs = append(errs, fmt.Errorf("outbox.interval %s: must be between 200ms and 1s", c.Outbox.Interval))
 	}
@@ -131,21 +398,88 @@ func (c Config) Validate() error {
 	if c.Order.SubmitBudget < time.Second || c.Order.SubmitBudget > time.Minute {
 		errs = append(errs, fmt.Errorf("order.submit_budget %s: must be between 1s and 1m", c.Order.SubmitBudget))
 	}
+	if c.Order.KillSettleTimeout < time.Second || c.Order.KillSettleTimeout > 10*time.Minute {
+		errs = append(errs, fmt.Errorf("order.kill_settle_timeout %s: must be between 1s and 10m", c.Order.KillSettleTimeout))
+	}
+	if c.Order.ReplaceSettleTimeout < time.Second || c.Order.ReplaceSettleTimeout > 5*time.Minute {
+		errs = append(errs, fmt.Errorf("order.replace_settle_timeout %s: must be between 1s and 5m", c.Order.ReplaceSettleTimeout))
+	}
+	if c.Order.RulePolicy != "reject" && c.Order.RulePolicy != "round" {
+		errs = append(errs, fmt.Errorf("order.rule_policy %q: must be reject or round", c.Order.RulePolicy))
+	}
+	if c.Order.RulesTTL < 10*time.Second || c.Order.RulesTTL > time.Hour {
+		errs = append(errs, fmt.Errorf("order.rules_ttl %s: must be between 10s and 1h", c.Order.RulesTTL))
+	}
+	if c.Order.GroupSweepInterval < time.Second || c.Order.GroupSweepInterval > 10*time.Minute {
+		errs = append(errs, fmt.Errorf("order.group_sweep_interval %s: must be between 1s and 10m", c.Order.GroupSweepInterval))
+	}
+	if c.Execution.Tick < 100*time.Millisecond || c.Execution.Tick > time.Minute {
+		errs = append(errs, fmt.Errorf("execution.tick %s: must be between 100ms and 1m", c.Execution.Tick))
+	}
+	if c.Execution.VWAPLookback < 24*time.Hour || c.Execution.VWAPLookback > 90*24*time.Hour {
+		errs = append(errs, fmt.Errorf("execution.vwap_lookback %s: must be between 24h and 2160h", c.Execution.VWAPLookback))
+	}
+	if c.Catalog.Interval < time.Minute || c.Catalog.Interval > 24*time.Hour {
+		errs = append(errs, fmt.Errorf("catalog.interval %s: must be between 1m and 24h", c.Catalog.Interval))
+	}
 	if c.Postgres.DSN == "" {
 		errs = append(errs, errors.New("postgres.dsn: must not be empty"))
 	}
 	if c.QuestDB.Conf == "" {
 		errs = append(errs, errors.New("questdb.conf: must not be empty"))
 	}
+	if !sqlIdentifier.MatchString(c.QuestDB.TradesTable) {
+		errs = append(errs, fmt.Errorf("questdb.trades_table %q: must be a plain table name", c.QuestDB.TradesTable))
+	}
+	if c.Mark.Interval < time.Second {
+		errs = append(errs, fmt.Errorf("mark.interval %s: must be at least 1s", c.Mark.Interval))
+	}
+	if c.Mark.Price != "mid" && c.Mark.Price != "last" {
+		errs = append(errs, fmt.Errorf("mark.price %q: must be mid or last", c.Mark.Price))
+	}
+	errs = append(errs, c.validateGrid()...)
+	errs = append(errs, c.validateArb()...)
+	errs = append(errs, c.Risk.validate()...)
+	errs = append(errs, c.Sinks.validate()...)
+	if c.Funds.FeeBuffer == "" {
+		errs = append(errs, errors.New("funds.fee_buffer: must not be empty"))
+	}
 	for name, v := range c.Venues {
 		if v.Trading && !v.Enabled {
 			errs = append(errs, fmt.Errorf("venues.%s.trading: requires enabled=true", name))
 		}
+		if c.Funds.Reserve && v.Trading && !slices.Contains(v.Accounts, "spot") && !slices.Contains(v.Accounts, "unified") {
+			errs = append(errs, fmt.Errorf("venues.%s.accounts: funds.reserve needs the spot or unified account snapshotted", name))
+		}
+		if len(v.Tickers.Pairs) > 0 && !v.Enabled {
+			errs = append(errs, fmt.Errorf("venues.%s.tickers: requires enabled=true", name))
+		}
 		errs = append(errs, v.validate(name)...)
 	}
 	return errors.Join(errs...)
 }
 
+func (b Bus) validate() []error {
+	switch b.Kind {
+	case "inproc":
+		return nil
+	case "nats":
+	default:
+		return []error{fmt.Errorf("bus.kind %q: must be inproc or nats", b.Kind)}
+	}
+	var errs []error
+	if b.NATS.URL == "" {
+		errs = append(errs, errors.New("bus.nats.url: must not be empty"))
+	}
+	if !streamName.MatchString(b.NATS.Stream) {
+		errs = append(errs, fmt.Errorf("bus.nats.stream %q: must be letters, digits, _ or -", b.NATS.Stream))
+	}
+	if b.NATS.MaxAge < time.Hour {
+		errs = append(errs, fmt.Errorf("bus.nats.max_age %s: must be at least 1h", b.NATS.MaxAge))
+	}
+	return errs
+}
+
 func (v Venue) validate(name string) []error {
 	if !v.Enabled {
 		return nil
@@ -157,8 +491,196 @@ func (v Venue) validate(name string) []error {
 	if len(v.Accounts) == 0 {
 		errs = append(errs, fmt.Errorf("venues.%s.accounts: at least one account required", name))
 	}
-	if v.APIKey == "" || v.APISecret == "" {
-		errs = append(errs, fmt.Errorf("venues.%s: api_key and api_secret are required for an enabled venue; balance snapshots authenticate", name))
+	if len(v.Tickers.Pairs) > 0 && v.Tickers.Interval < time.Second {
+		errs = append(errs, fmt.Errorf("venues.%s.tickers.interval %s: must be at least 1s", name, v.Tickers.Interval))
+	}
+	switch v.Adapter {
+	case "", AdapterGCT:
+		if v.APIKey == "" || v.APISecret == "" {
+			errs = append(errs, fmt.Errorf("venues.%s: api_key and api_secret are required for an enabled venue; balance snapshots authenticate", name))
+		}
+	case AdapterPaper:
+		errs = append(errs, v.Paper.validate(name, v.Accounts)...)
+	default:
+		errs = append(errs, fmt.Errorf("venues.%s.adapter %q: must be gct or paper", name, v.Adapter))
+	}
+	return errs
+}
+
+// validate checks the simulator's shape. Decimal strings are parsed by the
+// paper adapter at startup, which reports them with the same prefix.
+func (p Paper) validate(name string, accounts []string) []error {
+	var errs []error
+	for _, acct := range accounts {
+		if acct != "spot" {
+			errs = append(errs, fmt.Errorf("venues.%s.accounts: paper venues simulate only the spot account, got %q", name, acct))
+		}
+	}
+	if len(p.Instruments) == 0 {
+		errs = append(errs, fmt.Errorf("venues.%s.paper.instruments: at least one instrument required", name))
+	}
+	if p.Interval < 0 || p.OrderTTL < 0 {
+		errs = append(errs, fmt.Errorf("venues.%s.paper: interval and order_ttl must not be negative", name))
+	}
+	if p.FillRatio < 0 || p.FillRatio > 1 {
+		errs = append(errs, fmt.Errorf("venues.%s.paper.fill_ratio %v: must be between 0 and 1", name, p.FillRatio))
+	}
+	if p.Volatility < 0 {
+		errs = append(errs, fmt.Errorf("venues.%s.paper.volatility %v: must not be negative", name, p.Volatility))
+	}
+	for i, inst := range p.Instruments {
+		if inst.Pair == "" {
+			errs = append(errs, fmt.Errorf("venues.%s.paper.instruments[%d].pair: must not be empty", name, i))
+		}
+		if p.Feed == "" && inst.Price == "" {
+			errs = append(errs, fmt.Errorf("venues.%s.paper.instruments[%d].price: required without a recorded feed", name, i))
+		}
+	}
+	return errs
+}
+
+// validateGrid checks bot identity and venue wiring. Prices and the pair
+// are parsed, and the ladder validated, when the bots are built.
+func (c Config) validateGrid() []error {
+	var errs []error
+	if len(c.Grid.Bots) > 0 && c.Grid.RetryInterval < time.Second {
+		errs = append(errs, fmt.Errorf("grid.retry_interval %s: must be at least 1s", c.Grid.RetryInterval))
+	}
+	seen := map[string]bool{}
+	for i, bot := range c.Grid.Bots {
+		switch {
+		case bot.ID == "" || bot.ID == "manual":
+			errs = append(errs, fmt.Errorf("grid.bots[%d].id %q: must be set and not the reserved \"manual\"", i, bot.ID))
+		case seen[bot.ID]:
+			errs = append(errs, fmt.Errorf("grid.bots[%d].id %q: duplicate", i, bot.ID))
+		}
+		seen[bot.ID] = true
+		if bot.Fallback != "" && bot.Fallback != FallbackFIFO && bot.Fallback != FallbackUnmatched {
+			errs = append(errs, fmt.Errorf("grid.bots[%d].fallback %q: must be %q or %q", i, bot.Fallback, FallbackFIFO, FallbackUnmatched))
+		}
+		if !c.Venues[bot.Venue].Trading {
+			errs = append(errs, fmt.Errorf("grid.bots[%d].venue %q: must be a venue with trading enabled", i, bot.Venue))
+		}
+	}
+	return errs
+}
+
+// validateArb checks bot identity and venue wiring. The pair, quantity,
+// edge and fees are parsed, and the spec validated, when the bots are
+// built.
+func (c Config) validateArb() []error {
+	var errs []error
+	if len(c.Arb.Bots) > 0 && c.Arb.Interval < time.Second {
+		errs = append(errs, fmt.Errorf("arb.interval %s: must be at least 1s", c.Arb.Interval))
+	}
+	seen := map[string]bool{}
+	for i, bot := range c.Arb.Bots {
+		switch {
+		case bot.ID == "" || bot.ID == "manual":
+			errs = append(errs, fmt.Errorf("arb.bots[%d].id %q: must be set and not the reserved \"manual\"", i, bot.ID))
+		case seen[bot.ID] || c.gridBot(bot.ID):
+			errs = append(errs, fmt.Errorf("arb.bots[%d].id %q: duplicate", i, bot.ID))
+		}
+		seen[bot.ID] = true
+		if len(bot.Venues) != 2 || bot.Venues[0] == bot.Venues[1] {
+			errs = append(errs, fmt.Errorf("arb.bots[%d].venues %v: must be two different venues", i, bot.Venues))
+		}
+		for _, venue := range bot.Venues {
+			if !c.Venues[venue].Trading {
+				errs = append(errs, fmt.Errorf("arb.bots[%d].venues %q: must be a venue with trading enabled", i, venue))
+			}
+		}
+		for venue := range bot.Fees {
+			if !slices.Contains(bot.Venues, venue) {
+				errs = append(errs, fmt.Errorf("arb.bots[%d].fees %q: not one of the bot's venues", i, venue))
+			}
+		}
+	}
+	return errs
+}
+
+// gridBot reports whether a grid bot is named id.
+func (c Config) gridBot(id string) bool {
+	return slices.ContainsFunc(c.Grid.Bots, func(b GridBot) bool { return b.ID == id })
+}
+
+// validate checks the limits' shape. Decimal strings and pairs are parsed
+// when the checks are built.
+func (r Risk) validate() []error {
+	var errs []error
+	if r.MaxOpenOrdersPerBot < 0 || r.MaxOpenOrdersPerVenue < 0 || r.PriceBandBps < 0 {
+		errs = append(errs, errors.New("risk: open-order limits and price_band_bps must not be negative"))
+	}
+	for i, p := range r.Positions {
+		if p.Bot == "" || p.Venue == "" || p.Pair == "" || p.MaxQty == "" {
+			errs = append(errs, fmt.Errorf("risk.positions[%d]: bot, venue, pair and max_qty are required", i))
+		}
+	}
+	return errs
+}
+
+func (s Sinks) validate() []error {
+	if len(s.Targets) == 0 {
+		return nil
+	}
+	var errs []error
+	if s.Interval < time.Second || s.Interval > 5*time.Minute {
+		errs = append(errs, fmt.Errorf("sinks.interval %s: must be between 1s and 5m", s.Interval))
+	}
+	if s.MaxAttempts < 1 || s.MaxAttempts > 20 {
+		errs = append(errs, fmt.Errorf("sinks.max_attempts %d: must be between 1 and 20", s.MaxAttempts))
+	}
+	if s.Backoff < 100*time.Millisecond || s.MaxBackoff < s.Backoff || s.MaxBackoff > time.Hour {
+		errs = append(errs, fmt.Errorf("sinks.backoff %s and max_backoff %s: backoff must be at least 100ms and max_backoff between it and 1h", s.Backoff, s.MaxBackoff))
+	}
+	for name, t := range s.Targets {
+		if !sinkName.MatchString(name) {
+			errs = append(errs, fmt.Errorf("sinks.targets.%s: name must be lowercase letters, digits, _ or -, at most 64", name))
+		}
+		errs = append(errs, t.validate(name)...)
+	}
+	return errs
+}
+
+// Standard Webhooks signing keys are 24 to 64 bytes.
+const (
+	minWebhookKey = 24
+	maxWebhookKey = 64
+)
+
+func validWebhookSecret(secret string) bool {
+	encoded, ok := strings.CutPrefix(secret, "whsec_")
+	if !ok {
+		return false
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	return err == nil && len(key) >= minWebhookKey && len(key) <= maxWebhookKey
+}
+
+func (t Sink) validate(name string) []error {
+	var errs []error
+	switch t.Kind {
+	case SinkWebhook:
+		if u, err := url.Parse(t.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("sinks.targets.%s.url %q: must be an http or https URL", name, t.URL))
+		}
+		if t.Secret == "" {
+			errs = append(errs, fmt.Errorf("sinks.targets.%s.secret: required to sign webhook deliveries", name))
+		} else if !validWebhookSecret(t.Secret) {
+			errs = append(errs, fmt.Errorf("sinks.targets.%s.secret: must be whsec_ and the base64 of a %d to %d byte key", name, minWebhookKey, maxWebhookKey))
+		}
+		if t.Timeout < time.Second || t.Timeout > time.Minute {
+			errs = append(errs, fmt.Errorf("sinks.targets.%s.timeout %s: must be between 1s and 1m", name, t.Timeout))
+		}
+	case SinkFile:
+		if t.Path == "" {
+			errs = append(errs, fmt.Errorf("sinks.targets.%s.path: must not be empty", name))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("sinks.targets.%s.kind %q: must be %s or %s", name, t.Kind, SinkWebhook, SinkFile))
+	}
+	if slices.Contains(t.Subjects, "") {
+		errs = append(errs, fmt.Errorf("sinks.targets.%s.subjects: must not hold an empty prefix; leave the list out to take every subject", name))
 	}
 	return errs
 }
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/confmap"
@@ -15,16 +16,42 @@ import (
 	"github.com/knadh/koanf/v2"
 )
 
+// defaultSinkTimeout is how long a webhook sink waits for an answer.
+const defaultSinkTimeout = 10 * time.Second
+
 func defaults() map[string]any {
 	return map[string]any{
-		"log.level":           "info",
-		"log.format":          "console",
-		"http.addr":           ":8080",
-		"snapshot.interval":   "60s",
-		"outbox.interval":     "500ms",
-		"outbox.batch":        100,
-		"reconcile.interval":  "30s",
-		"order.submit_budget": "10s",
+		"log.level":                    "info",
+		"log.format":                   "console",
+		"http.addr":                    ":8080",
+		"snapshot.interval":            "60s",
+		"bus.kind":                     "inproc",
+		"bus.nats.url":                 "nats://127.0.0.1:4222",
+		"bus.nats.stream":              "OUTBOX",
+		"bus.nats.max_age":             "168h",
+		"outbox.interval":              "500ms",
+		"outbox.batch":                 100,
+		"reconcile.interval":           "30s",
+		"catalog.interval":             "1h",
+		"order.submit_budget":          "10s",
+		"order.kill_settle_timeout":    "30s",
+		"order.replace_settle_timeout": "10s",
+		"order.rule_policy":            "reject",
+		"order.rules_ttl":              "1m",
+		"order.group_sweep_interval":   "30s",
+		"execution.tick":               "1s",
+		"execution.vwap_lookback":      "168h",
+		"questdb.trades_table":         "trades",
+		"grid.retry_interval":          "30s",
+		"arb.interval":                 "5s",
+		"mark.interval":                "60s",
+		"mark.price":                   "mid",
+		"funds.reserve":                true,
+		"funds.fee_buffer":             "0.002",
+		"sinks.interval":               "5s",
+		"sinks.max_attempts":           5,
+		"sinks.backoff":                "1s",
+		"sinks.max_backoff":            "1m",
 	}
 }
 
@@ -55,7 +82,7 @@ func Load(path string, explicit bool) (Config, error) {
 		TransformFunc: func(key, value string) (string, any) {
 			key = strings.ToLower(strings.TrimPrefix(key, EnvPrefix))
 			key = strings.ReplaceAll(key, "__", ".")
-			if strings.HasSuffix(key, ".accounts") {
+			if strings.HasSuffix(key, ".accounts") || strings.HasSuffix(key, ".tickers.pairs") || strings.HasSuffix(key, ".subjects") {
 				parts := strings.Split(value, ",")
 				for i := range parts {
 					parts[i] = strings.TrimSpace(parts[i])
@@ -75,6 +102,9 @@ func Load(path string, explicit bool) (Config, error) {
 	if err := resolveSecretFiles(cfg.Venues); err != nil {
 		return Config{}, err
 	}
+	if err := resolveSinks(cfg.Sinks.Targets); err != nil {
+		return Config{}, err
+	}
 	if err := cfg.Validate(); err != nil {
 		return Config{}, fmt.Errorf("invalid config: %w", err)
 	}
@@ -87,14 +117,40 @@ func Load(path string, explicit bool) (Config, error) {
 // than a missing file (unreadable, invalid YAML) are returned so they are
 // not mistaken for an unconfigured address.
 func APIAddr(path string) (string, error) {
+	k, err := loadClientFile(path)
+	if err != nil {
+		return "", err
+	}
+	return k.String("api.addr"), nil
+}
+
+// APIToken returns the bearer token a client sends (ADR-0012), from
+// api.token or the file named by api.token_file in the config file, or ""
+// when neither is set or the file is absent. Only clients read these keys;
+// the daemon never sends a token.
+func APIToken(path string) (string, error) {
+	k, err := loadClientFile(path)
+	if err != nil {
+		return "", err
+	}
+	token, err := resolveSecret(k.String("api.token"), k.String("api.token_file"))
+	if err != nil {
+		return "", fmt.Errorf("api.token: %w", err)
+	}
+	return token, nil
+}
+
+// loadClientFile loads the config file alone, without defaults or env, for
+// clients reading their own keys. A missing file loads empty.
+func loadClientFile(path string) (*koanf.Koanf, error) {
 	k := koanf.New(".")
 	if err := k.Load(file.Provider(path), yaml.Parser()); err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
-			return "", nil
+			return k, nil
 		}
-		return "", fmt.Errorf("load config file %s: %w", path, err)
+		return nil, fmt.Errorf("load config file %s: %w", path, err)
 	}
-	return k.String("api.addr"), nil
+	return k, nil
 }
 
 // resolveSecretFiles reads secret files into APIKey and APISecret so the
@@ -117,6 +173,23 @@ func resolveSecretFiles(venues map[string]Venue) error {
 	return nil
 }
 
+// resolveSinks reads webhook secret files into Secret and gives webhooks
+// without a timeout the default; per-target keys have no entry in
+// defaults.
+func resolveSinks(targets map[string]Sink) error {
+	for name, t := range targets {
+		if t.Kind == SinkWebhook && t.Timeout == 0 {
+			t.Timeout = defaultSinkTimeout
+		}
+		var err error
+		if t.Secret, err = resolveSecret(t.Secret, t.SecretFile); err != nil {
+			return fmt.Errorf("sinks.targets.%s.secret: %w", name, err)
+		}
+		targets[name] = t
+	}
+	return nil
+}
+
 func resolveSecret(value, path string) (string, error) {
 	if path == "" {
 		return value, nil
@@ -0,0 +1,258 @@
+// Package arb models cross-venue arbitrage: one pair quoted on two venues,
+// bought where it is offered cheaper and sold where it is bid higher, when
+// the spread between them clears both venues' fees by a margin. Both legs
+// go out at once as IOC limits at the quoted prices, so a leg either
+// trades at once or not at all; a pair whose legs filled unequally leaves
+// the bot holding the difference until it is hedged.
+package arb
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+	"github.com/romanornr/delta-works/internal/domain/order"
+)
+
+// ErrNoHedgeNeeded reports a resolve of a trade that does not need a
+// hedge.
+var ErrNoHedgeNeeded = errors.New("arbitrage trade needs no hedge")
+
+var (
+	one         = decimal.NewFromInt(1)
+	tenThousand = decimal.NewFromInt(10_000)
+)
+
+// TradeID names one pair of legs. Like a parent's, it is a ULID.
+type TradeID string
+
+// Spec is one arbitrage bot's immutable configuration.
+type Spec struct {
+	BotID string
+	// Legs are the same spot pair on two different venues.
+	Legs [2]instrument.Instrument
+	// Qty is the most base currency one pair of legs trades.
+	Qty decimal.Decimal
+	// MinEdgeBps is the edge, net of fees, a pair of legs must clear.
+	MinEdgeBps decimal.Decimal
+	// Fees are each venue's taker fee rates, 0.001 for 0.1%; a venue
+	// missing from the map is taken as free.
+	Fees map[instrument.VenueID]decimal.Decimal
+}
+
+// Validate checks the spec's invariants.
+func (s Spec) Validate() error {
+	var errs []error
+	if s.BotID == "" || s.BotID == "manual" {
+		errs = append(errs, fmt.Errorf("arb: bot ID %q: must be set and not the reserved \"manual\"", s.BotID))
+	}
+	a, b := s.Legs[0], s.Legs[1]
+	if a.Venue == "" || a.Venue == b.Venue {
+		errs = append(errs, fmt.Errorf("arb %s: venues %q and %q: need two different venues", s.BotID, a.Venue, b.Venue))
+	}
+	if a.Base != b.Base || a.Quote != b.Quote || a.Base == "" || a.Quote == "" {
+		errs = append(errs, fmt.Errorf("arb %s: both legs must trade the same pair", s.BotID))
+	}
+	if !s.Qty.IsPositive() {
+		errs = append(errs, fmt.Errorf("arb %s: qty %s: must be positive", s.BotID, s.Qty))
+	}
+	if s.MinEdgeBps.IsNegative() {
+		errs = append(errs, fmt.Errorf("arb %s: min edge %s bps: must not be negative", s.BotID, s.MinEdgeBps))
+	}
+	for venue, fee := range s.Fees {
+		if fee.IsNegative() || fee.GreaterThanOrEqual(one) {
+			errs = append(errs, fmt.Errorf("arb %s: fee %s on %s: must be from 0 to below 1", s.BotID, fee, venue))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Opportunity is the better of the two directions the spread can be traded
+// in at the quoted prices.
+type Opportunity struct {
+	Buy, Sell           instrument.Instrument
+	BuyPrice, SellPrice decimal.Decimal
+	// Qty is the spec's quantity, cut to the size quoted at either price
+	// when the venues report it.
+	Qty decimal.Decimal
+	// EdgeBps is what the sale returns beyond the purchase, both net of
+	// their venue's fee, in basis points of the purchase. Negative when
+	// the spread does not pay the fees.
+	EdgeBps decimal.Decimal
+}
+
+// Evaluate compares the legs' tickers, given in the order of Legs, and
+// returns the better direction. It reports false when either book is
+// missing a side. Pure.
+func (s Spec) Evaluate(a, b marketdata.Ticker) (Opportunity, bool) {
+	tickers := [2]marketdata.Ticker{a, b}
+	var (
+		best  Opportunity
+		found bool
+	)
+	for buy := range 2 {
+		sell := 1 - buy
+		ask, bid := tickers[buy].Ask, tickers[sell].Bid
+		if !ask.IsPositive() || !bid.IsPositive() {
+			continue
+		}
+		cost := ask.Mul(one.Add(s.Fee(s.Legs[buy].Venue)))
+		proceeds := bid.Mul(one.Sub(s.Fee(s.Legs[sell].Venue)))
+		o := Opportunity{
+			Buy: s.Legs[buy], Sell: s.Legs[sell], BuyPrice: ask, SellPrice: bid, Qty: s.Qty,
+			EdgeBps: proceeds.Sub(cost).Mul(tenThousand).Div(cost).Round(2),
+		}
+		for _, size := range []decimal.Decimal{tickers[buy].AskSize, tickers[sell].BidSize} {
+			if size.IsPositive() {
+				o.Qty = decimal.Min(o.Qty, size)
+			}
+		}
+		if !found || o.EdgeBps.GreaterThan(best.EdgeBps) {
+			best, found = o, true
+		}
+	}
+	return best, found
+}
+
+// Tradable reports whether the opportunity clears the spec's minimum edge.
+func (s Spec) Tradable(o Opportunity) bool {
+	return o.EdgeBps.IsPositive() && o.EdgeBps.GreaterThanOrEqual(s.MinEdgeBps)
+}
+
+// Fee is the venue's taker fee rate.
+func (s Spec) Fee(venue instrument.VenueID) decimal.Decimal {
+	return s.Fees[venue]
+}
+
+// Affordable cuts the opportunity's quantity to what the free balances
+// fund: freeQuote on the buying venue paying the price and its fee,
+// freeBase on the selling venue delivering the quantity. Pure.
+func (s Spec) Affordable(o Opportunity, freeQuote, freeBase decimal.Decimal) decimal.Decimal {
+	perUnit := o.BuyPrice.Mul(one.Add(s.Fee(o.Buy.Venue)))
+	qty := decimal.Min(o.Qty, freeBase, freeQuote.Div(perUnit))
+	return decimal.Max(qty, decimal.Zero)
+}
+
+// Round floors qty to both venues' quantity increments and returns zero
+// when the result falls below either venue's minimum quantity or
+// notional at the opportunity's prices. Pure.
+func (o Opportunity) Round(qty decimal.Decimal, buyRules, sellRules instrument.Rules) decimal.Decimal {
+	for _, inc := range []decimal.Decimal{buyRules.QtyIncrement, sellRules.QtyIncrement} {
+		if inc.IsPositive() {
+			qty = qty.Div(inc).Floor().Mul(inc)
+		}
+	}
+	for _, leg := range []struct {
+		rules instrument.Rules
+		price decimal.Decimal
+	}{{buyRules, o.BuyPrice}, {sellRules, o.SellPrice}} {
+		if qty.LessThan(leg.rules.MinQty) || qty.Mul(leg.price).LessThan(leg.rules.MinNotional) {
+			return decimal.Zero
+		}
+	}
+	return decimal.Max(qty, decimal.Zero)
+}
+
+// Status is a trade's lifecycle state.
+type Status string
+
+// Trade statuses. A trade is open while either leg works; it ends balanced
+// when both legs filled alike, nothing at all included, and needs a hedge
+// when they did not. A hedge-needed trade is hedged once an operator has
+// evened the position out and said so.
+const (
+	StatusOpen        Status = "open"
+	StatusBalanced    Status = "balanced"
+	StatusHedgeNeeded Status = "hedge_needed"
+	StatusHedged      Status = "hedged"
+)
+
+// Final reports whether nothing more happens to the trade.
+func (s Status) Final() bool {
+	return s == StatusBalanced || s == StatusHedged
+}
+
+// Trade is one pair of legs and what came of them.
+type Trade struct {
+	ID                      TradeID
+	BotID                   string
+	Buy, Sell               instrument.Instrument
+	BuyOrderID, SellOrderID order.ClientOrderID
+	Qty                     decimal.Decimal
+	BuyPrice, SellPrice     decimal.Decimal
+	EdgeBps                 decimal.Decimal
+	Status                  Status
+	// HedgeSide and HedgeQty are the order that would even the legs out:
+	// a sell of what the buy leg took beyond the sell leg, or a buy of
+	// what the sell leg sold beyond the buy leg. Set once a hedge is
+	// needed.
+	HedgeSide order.Side
+	HedgeQty  decimal.Decimal
+	Reason    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewTrade is the open trade for qty of the opportunity, its legs to be
+// placed under buyID and sellID.
+func NewTrade(id TradeID, botID string, o Opportunity, qty decimal.Decimal, buyID, sellID order.ClientOrderID, now time.Time) Trade {
+	return Trade{
+		ID: id, BotID: botID, Buy: o.Buy, Sell: o.Sell, BuyOrderID: buyID, SellOrderID: sellID,
+		Qty: qty, BuyPrice: o.BuyPrice, SellPrice: o.SellPrice, EdgeBps: o.EdgeBps,
+		Status: StatusOpen, CreatedAt: now, UpdatedAt: now,
+	}
+}
+
+// Legs are the trade's two orders: IOC limits at the quoted prices, so
+// neither rests once the spread has moved.
+func (t Trade) Legs() (buy, sell order.Request) {
+	leg := func(id order.ClientOrderID, inst instrument.Instrument, side order.Side, price decimal.Decimal) order.Request {
+		return order.Request{
+			ClientOrderID: id, BotID: t.BotID, Instrument: inst, Side: side,
+			Type: order.Limit, Price: price, Qty: t.Qty, TimeInForce: order.IOC,
+		}
+	}
+	return leg(t.BuyOrderID, t.Buy, order.Buy, t.BuyPrice), leg(t.SellOrderID, t.Sell, order.Sell, t.SellPrice)
+}
+
+// Settle ends an open trade once both legs have: balanced when they filled
+// alike, needing a hedge for the difference when not. A leg that was never
+// stored is passed as a terminal record with nothing filled. It reports
+// false, changing nothing, while a leg still works. Pure.
+func (t Trade) Settle(buy, sell order.Record, now time.Time) (Trade, bool) {
+	if t.Status != StatusOpen || !buy.Status.Terminal() || !sell.Status.Terminal() {
+		return t, false
+	}
+	t.UpdatedAt = now
+	diff := buy.FilledQty.Sub(sell.FilledQty)
+	switch {
+	case diff.IsZero() && buy.FilledQty.IsZero():
+		t.Status, t.Reason = StatusBalanced, "neither leg filled"
+	case diff.IsZero():
+		t.Status, t.Reason = StatusBalanced, "both legs filled "+buy.FilledQty.String()
+	default:
+		t.Status, t.HedgeSide, t.HedgeQty = StatusHedgeNeeded, order.Sell, diff
+		if diff.IsNegative() {
+			t.HedgeSide, t.HedgeQty = order.Buy, diff.Neg()
+		}
+		t.Reason = fmt.Sprintf("bought %s on %s, sold %s on %s", buy.FilledQty, t.Buy.Venue, sell.FilledQty, t.Sell.Venue)
+	}
+	return t, true
+}
+
+// Resolve marks a hedge-needed trade hedged, keeping the operator's note.
+func (t Trade) Resolve(note string, now time.Time) (Trade, error) {
+	if t.Status != StatusHedgeNeeded {
+		return t, fmt.Errorf("%w: trade %s is %s", ErrNoHedgeNeeded, t.ID, t.Status)
+	}
+	t.Status, t.UpdatedAt = StatusHedged, now
+	t.Reason = "hedged"
+	if note != "" {
+		t.Reason += ": " + note
+	}
+	return t, nil
+}
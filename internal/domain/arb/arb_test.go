@@ -0,0 +1,184 @@
+package arb_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/arb"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+	"github.com/romanornr/delta-works/internal/domain/order"
+)
+
+func d(s string) decimal.Decimal { return decimal.RequireFromString(s) }
+
+// spec trades up to 1 BTC between bybit (0.1% taker) and kraken (0.2%).
+func spec() arb.Spec {
+	return arb.Spec{
+		BotID: "arb-1",
+		Legs: [2]instrument.Instrument{
+			{Venue: "bybit", Type: instrument.TypeSpot, Base: "BTC", Quote: "USDT"},
+			{Venue: "kraken", Type: instrument.TypeSpot, Base: "BTC", Quote: "USDT"},
+		},
+		Qty: d("1"), MinEdgeBps: d("10"),
+		Fees: map[instrument.VenueID]decimal.Decimal{"bybit": d("0.001"), "kraken": d("0.002")},
+	}
+}
+
+func ticker(bid, ask, bidSize, askSize string) marketdata.Ticker {
+	return marketdata.Ticker{Bid: d(bid), Ask: d(ask), BidSize: d(bidSize), AskSize: d(askSize)}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		edit    func(*arb.Spec)
+		wantErr string
+	}{
+		{name: "valid", edit: func(*arb.Spec) {}},
+		{name: "reserved bot ID", edit: func(s *arb.Spec) { s.BotID = "manual" }, wantErr: "reserved"},
+		{name: "one venue twice", edit: func(s *arb.Spec) { s.Legs[1].Venue = "bybit" }, wantErr: "two different venues"},
+		{name: "different pairs", edit: func(s *arb.Spec) { s.Legs[1].Quote = "USDC" }, wantErr: "same pair"},
+		{name: "no quantity", edit: func(s *arb.Spec) { s.Qty = decimal.Zero }, wantErr: "qty"},
+		{name: "fee of 100%", edit: func(s *arb.Spec) { s.Fees["kraken"] = d("1") }, wantErr: "fee"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := spec()
+			tt.edit(&s)
+			err := s.Validate()
+			if (tt.wantErr == "") != (err == nil) || (err != nil && !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("Validate() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	s := spec()
+	tests := []struct {
+		name      string
+		a, b      marketdata.Ticker
+		wantBuy   instrument.VenueID
+		wantEdge  string
+		wantQty   string
+		tradable  bool
+		wantFound bool
+	}{
+		{
+			// Buy 100 on bybit for 100.1, sell 101 on kraken for 100.798.
+			name: "kraken bids above bybit's offer", a: ticker("99.9", "100", "5", "5"), b: ticker("101", "101.1", "5", "5"),
+			wantBuy: "bybit", wantEdge: "69.73", wantQty: "1", tradable: true, wantFound: true,
+		},
+		{
+			// Buy 100 on kraken for 100.2, sell 101 on bybit for 100.899.
+			name: "the other way round", a: ticker("101", "101.1", "5", "5"), b: ticker("99.9", "100", "5", "5"),
+			wantBuy: "kraken", wantEdge: "69.76", wantQty: "1", tradable: true, wantFound: true,
+		},
+		{
+			name: "a spread the fees eat", a: ticker("99.9", "100", "5", "5"), b: ticker("100.2", "100.3", "5", "5"),
+			wantBuy: "bybit", wantEdge: "-10.03", wantQty: "1", wantFound: true,
+		},
+		{
+			name: "quoted size caps the quantity", a: ticker("99.9", "100", "5", "0.3"), b: ticker("101", "101.1", "0.2", "5"),
+			wantBuy: "bybit", wantEdge: "69.73", wantQty: "0.2", tradable: true, wantFound: true,
+		},
+		{name: "a one-sided book", a: ticker("0", "100", "0", "1"), b: ticker("0", "101", "0", "1")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, found := s.Evaluate(tt.a, tt.b)
+			if found != tt.wantFound {
+				t.Fatalf("found = %v, want %v", found, tt.wantFound)
+			}
+			if !found {
+				return
+			}
+			if o.Buy.Venue != tt.wantBuy || o.EdgeBps.String() != tt.wantEdge || o.Qty.String() != tt.wantQty || s.Tradable(o) != tt.tradable {
+				t.Fatalf("opportunity = %+v, tradable %v", o, s.Tradable(o))
+			}
+		})
+	}
+}
+
+func TestAffordableAndRound(t *testing.T) {
+	s := spec()
+	o, _ := s.Evaluate(ticker("99.9", "100", "5", "5"), ticker("101", "101.1", "5", "5"))
+	// 50.05 USDT buys half a BTC at 100 plus bybit's fee.
+	if got := s.Affordable(o, d("50.05"), d("3")); !got.Equal(d("0.5")) {
+		t.Fatalf("Affordable(quote-bound) = %s, want 0.5", got)
+	}
+	if got := s.Affordable(o, d("1000"), d("0.4")); !got.Equal(d("0.4")) {
+		t.Fatalf("Affordable(base-bound) = %s, want 0.4", got)
+	}
+	buyRules := instrument.Rules{QtyIncrement: d("0.01"), MinQty: d("0.01")}
+	sellRules := instrument.Rules{QtyIncrement: d("0.001"), MinNotional: d("5")}
+	if got := o.Round(d("0.4567"), buyRules, sellRules); !got.Equal(d("0.45")) {
+		t.Fatalf("Round = %s, want 0.45", got)
+	}
+	if got := o.Round(d("0.0499"), buyRules, sellRules); !got.IsZero() {
+		t.Fatalf("Round below the sell venue's notional = %s, want 0", got)
+	}
+}
+
+func TestSettle(t *testing.T) {
+	s := spec()
+	o, _ := s.Evaluate(ticker("99.9", "100", "5", "5"), ticker("101", "101.1", "5", "5"))
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	trade := arb.NewTrade("T", s.BotID, o, d("1"), "B", "S", now)
+	buy, sell := trade.Legs()
+	if buy.Side != order.Buy || buy.Instrument.Venue != "bybit" || !buy.Price.Equal(d("100")) || buy.TimeInForce != order.IOC ||
+		sell.Side != order.Sell || sell.Instrument.Venue != "kraken" || !sell.Price.Equal(d("101")) || sell.BotID != "arb-1" {
+		t.Fatalf("legs = %+v, %+v", buy, sell)
+	}
+
+	leg := func(status order.Status, filled string) order.Record {
+		return order.Record{Status: status, FilledQty: d(filled)}
+	}
+	tests := []struct {
+		name       string
+		buy, sell  order.Record
+		settled    bool
+		wantStatus arb.Status
+		wantSide   order.Side
+		wantQty    string
+	}{
+		{name: "a leg still working", buy: leg(order.StatusFilled, "1"), sell: leg(order.StatusOpen, "0")},
+		{name: "both filled", buy: leg(order.StatusFilled, "1"), sell: leg(order.StatusFilled, "1"), settled: true, wantStatus: arb.StatusBalanced},
+		{name: "neither filled", buy: leg(order.StatusCanceled, "0"), sell: leg(order.StatusRejected, "0"), settled: true, wantStatus: arb.StatusBalanced},
+		{
+			name: "the sell fell short", buy: leg(order.StatusFilled, "1"), sell: leg(order.StatusCanceled, "0.3"), settled: true,
+			wantStatus: arb.StatusHedgeNeeded, wantSide: order.Sell, wantQty: "0.7",
+		},
+		{
+			name: "the buy never traded", buy: leg(order.StatusRejected, "0"), sell: leg(order.StatusFilled, "1"), settled: true,
+			wantStatus: arb.StatusHedgeNeeded, wantSide: order.Buy, wantQty: "1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, settled := trade.Settle(tt.buy, tt.sell, now)
+			if settled != tt.settled {
+				t.Fatalf("settled = %v, want %v", settled, tt.settled)
+			}
+			if !settled {
+				return
+			}
+			if got.Status != tt.wantStatus || got.HedgeSide != tt.wantSide || (tt.wantQty != "" && got.HedgeQty.String() != tt.wantQty) {
+				t.Fatalf("trade = %+v", got)
+			}
+		})
+	}
+
+	if _, err := trade.Resolve("", now); err == nil {
+		t.Fatal("Resolve on an open trade succeeded")
+	}
+	needed, _ := trade.Settle(leg(order.StatusFilled, "1"), leg(order.StatusCanceled, "0"), now)
+	hedged, err := needed.Resolve("sold 1 on bybit", now)
+	if err != nil || hedged.Status != arb.StatusHedged || hedged.Reason != "hedged: sold 1 on bybit" {
+		t.Fatalf("Resolve = %+v, %v", hedged, err)
+	}
+}
@@ -0,0 +1,188 @@
+// Package auth models the bearer tokens that admit clients to the control
+// plane over TCP. A token is a random secret the client sends; the daemon
+// keeps only its SHA-256 hash, with a name for the operator and the scopes
+// that say which RPCs it may call.
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrUnauthenticated means a request carried no token, or one the daemon
+// does not hold or has revoked.
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// ErrNameTaken means a valid token already holds the name.
+var ErrNameTaken = errors.New("token name is taken")
+
+// ErrInvalidToken wraps malformed token names and scopes.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Scope is one class of RPCs a token may call. Scopes are independent: a
+// token that trades and also reads holds both.
+type Scope string
+
+// Scopes, from least to most dangerous.
+const (
+	// ScopeRead calls the RPCs that only report state.
+	ScopeRead Scope = "read"
+	// ScopeTrade places, changes and cancels orders, including kills.
+	ScopeTrade Scope = "trade"
+	// ScopeAdmin releases kill switches, retries sink dead letters and
+	// manages tokens.
+	ScopeAdmin Scope = "admin"
+)
+
+// AllScopes lists every scope in order.
+var AllScopes = []Scope{ScopeRead, ScopeTrade, ScopeAdmin}
+
+// ParseScopes parses a comma-separated scope list, dropping duplicates.
+func ParseScopes(s string) ([]Scope, error) {
+	var scopes []Scope
+	for part := range strings.SplitSeq(s, ",") {
+		scope := Scope(strings.TrimSpace(part))
+		if !slices.Contains(AllScopes, scope) {
+			return nil, fmt.Errorf("%w: unknown scope %q, want read, trade or admin", ErrInvalidToken, scope)
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
+// JoinScopes formats scopes the way ParseScopes reads them.
+func JoinScopes(scopes []Scope) string {
+	parts := make([]string, len(scopes))
+	for i, s := range scopes {
+		parts[i] = string(s)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Source says where a token is kept.
+type Source string
+
+// Token sources.
+const (
+	// SourceStore tokens live in Postgres and are managed over the API.
+	SourceStore Source = "store"
+	// SourceFile tokens are read from api.tokens_file at startup.
+	SourceFile Source = "file"
+)
+
+// Hash is the SHA-256 of a token's secret.
+type Hash [sha256.Size]byte
+
+// HashSecret hashes a token secret. Secrets are 256 random bits, so a fast
+// unsalted hash is enough: there is nothing to guess a dictionary against.
+func HashSecret(secret string) Hash {
+	return sha256.Sum256([]byte(secret))
+}
+
+// String returns the hash as hex, the form the tokens file holds.
+func (h Hash) String() string { return hex.EncodeToString(h[:]) }
+
+// ParseHash reads a hex hash.
+func ParseHash(s string) (Hash, error) {
+	var h Hash
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != len(h) {
+		return h, fmt.Errorf("%w: hash must be %d hex characters", ErrInvalidToken, 2*len(h))
+	}
+	copy(h[:], b)
+	return h, nil
+}
+
+// NewSecret returns a fresh token secret: 32 random bytes, URL-safe
+// base64 without padding, so it survives headers, env and shell quoting.
+func NewSecret() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b) // crypto/rand.Read never fails
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Token is a stored token: everything about it except the secret.
+type Token struct {
+	// ID is a ULID for store tokens and empty for file tokens, which are
+	// known by name.
+	ID        string
+	Name      string
+	Scopes    []Scope
+	Hash      Hash
+	Source    Source
+	CreatedAt time.Time
+	// RevokedAt is zero while the token is valid.
+	RevokedAt time.Time
+}
+
+// Allows reports whether the token holds scope.
+func (t Token) Allows(scope Scope) bool {
+	return slices.Contains(t.Scopes, scope)
+}
+
+// Revoked reports whether the token has been revoked.
+func (t Token) Revoked() bool {
+	return !t.RevokedAt.IsZero()
+}
+
+var tokenName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// ValidateName checks a token name: lowercase letters, digits, _ or -,
+// at most 64, the same shape as sink names.
+func ValidateName(name string) error {
+	if !tokenName.MatchString(name) {
+		return fmt.Errorf("%w: name %q must be lowercase letters, digits, _ or -, at most 64", ErrInvalidToken, name)
+	}
+	return nil
+}
+
+// FileLine formats a token as one line of the tokens file.
+func FileLine(name string, scopes []Scope, hash Hash) string {
+	return name + " " + JoinScopes(scopes) + " " + hash.String()
+}
+
+// ParseFile reads a tokens file: one token per line as "name scopes hash",
+// scopes comma-separated and hash the hex SHA-256 of the secret. Blank
+// lines and lines starting with # are skipped. Names must be unique.
+func ParseFile(data []byte) ([]Token, error) {
+	var tokens []Token
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: want \"name scopes hash\", got %d fields", n, len(fields))
+		}
+		if err := ValidateName(fields[0]); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		scopes, err := ParseScopes(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		hash, err := ParseHash(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		if slices.ContainsFunc(tokens, func(t Token) bool { return t.Name == fields[0] }) {
+			return nil, fmt.Errorf("line %d: duplicate token name %q", n, fields[0])
+		}
+		tokens = append(tokens, Token{Name: fields[0], Scopes: scopes, Hash: hash, Source: SourceFile})
+	}
+	return tokens, scanner.Err()
+}
@@ -0,0 +1,74 @@
+package auth_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/romanornr/delta-works/internal/domain/auth"
+)
+
+func TestParseScopes(t *testing.T) {
+	t.Parallel()
+	got, err := auth.ParseScopes("read, trade,read")
+	if err != nil || !slices.Equal(got, []auth.Scope{auth.ScopeRead, auth.ScopeTrade}) {
+		t.Fatalf("ParseScopes = %v, %v", got, err)
+	}
+	for _, bad := range []string{"", "read,", "write", "READ"} {
+		if _, err := auth.ParseScopes(bad); !errors.Is(err, auth.ErrInvalidToken) {
+			t.Errorf("ParseScopes(%q) = %v, want ErrInvalidToken", bad, err)
+		}
+	}
+}
+
+func TestSecretsHashApart(t *testing.T) {
+	t.Parallel()
+	a, b := auth.NewSecret(), auth.NewSecret()
+	if len(a) != 43 || a == b {
+		t.Fatalf("secrets %q and %q", a, b)
+	}
+	if auth.HashSecret(a) == auth.HashSecret(b) || auth.HashSecret(a) != auth.HashSecret(a) {
+		t.Fatal("hashes do not follow their secrets")
+	}
+	parsed, err := auth.ParseHash(auth.HashSecret(a).String())
+	if err != nil || parsed != auth.HashSecret(a) {
+		t.Fatalf("ParseHash round trip = %v, %v", parsed, err)
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	t.Parallel()
+	dashboard, ops := auth.HashSecret("dashboard-secret"), auth.HashSecret("ops-secret")
+	data := "# bootstrap tokens\n\n" +
+		auth.FileLine("dashboard", []auth.Scope{auth.ScopeRead}, dashboard) + "\n" +
+		"  " + auth.FileLine("ops", []auth.Scope{auth.ScopeAdmin, auth.ScopeRead}, ops) + "  \n"
+	tokens, err := auth.ParseFile([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 2 || tokens[0].Name != "dashboard" || tokens[0].Hash != dashboard || tokens[0].Source != auth.SourceFile ||
+		!tokens[1].Allows(auth.ScopeAdmin) || tokens[1].Allows(auth.ScopeTrade) {
+		t.Fatalf("tokens = %+v", tokens)
+	}
+
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"missing hash", "ops admin", "line 1: want"},
+		{"bad name", "Ops admin " + ops.String(), "line 1: invalid token: name"},
+		{"bad scope", "ops root " + ops.String(), "unknown scope"},
+		{"short hash", "ops admin abcd", "hash must be 64 hex"},
+		{"duplicate", "ops admin " + ops.String() + "\nops read " + dashboard.String(), "line 2: duplicate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := auth.ParseFile([]byte(tt.line)); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("ParseFile error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
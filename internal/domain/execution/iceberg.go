@@ -0,0 +1,54 @@
+package execution
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/order"
+)
+
+// CheckIceberg checks p's terms as an iceberg: a positive quantity on a
+// side, a positive limit price, a display size from zero to the quantity,
+// and a price jitter from zero to below the limit price. Pure.
+func CheckIceberg(p Parent) error {
+	switch {
+	case p.Algo != AlgoIceberg:
+		return fmt.Errorf("%w: algo %q is not iceberg", ErrInvalidParent, p.Algo)
+	case p.Side != order.Buy && p.Side != order.Sell:
+		return fmt.Errorf("%w: side %q", ErrInvalidParent, p.Side)
+	case !p.Qty.IsPositive():
+		return fmt.Errorf("%w: quantity must be positive", ErrInvalidParent)
+	case !p.LimitPrice.IsPositive():
+		return fmt.Errorf("%w: an iceberg needs a limit price", ErrInvalidParent)
+	case !p.DisplayQty.IsPositive() || p.DisplayQty.GreaterThan(p.Qty):
+		return fmt.Errorf("%w: display quantity must be positive and at most the quantity", ErrInvalidParent)
+	case p.PriceJitter.IsNegative() || p.PriceJitter.GreaterThanOrEqual(p.LimitPrice):
+		return fmt.Errorf("%w: price jitter must be at least zero and below the limit price", ErrInvalidParent)
+	}
+	return nil
+}
+
+// Refill is the quantity of the next visible child: the display size, or
+// what remains when that is less, floored to the instrument's quantity
+// increment. Zero means what remains is below the increment. Pure.
+func (p Parent) Refill(pr Progress, rules instrument.Rules) decimal.Decimal {
+	return floor(decimal.Min(p.DisplayQty, p.Remaining(pr)), rules.QtyIncrement)
+}
+
+// RefillPrice is the next visible child's price: LimitPrice moved up to
+// PriceJitter to the passive side, (u+1)/2 of the way for u in [-1, 1],
+// and rounded away from the market to the instrument's price increment.
+// A buy is never priced above LimitPrice, nor a sell below it. Pure.
+func (p Parent) RefillPrice(rules instrument.Rules, u float64) decimal.Decimal {
+	offset := p.PriceJitter.Mul(decimal.NewFromFloat((u + 1) / 2))
+	if p.Side == order.Buy {
+		return floor(p.LimitPrice.Sub(offset), rules.PriceIncrement)
+	}
+	price := p.LimitPrice.Add(offset)
+	if !rules.PriceIncrement.IsPositive() {
+		return price
+	}
+	return price.Div(rules.PriceIncrement).Ceil().Mul(rules.PriceIncrement)
+}
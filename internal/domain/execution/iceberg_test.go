@@ -0,0 +1,98 @@
+package execution_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/execution"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/order"
+)
+
+// iceberg buys 10 at 100 or better, 2 at a time.
+func iceberg() execution.Parent {
+	return execution.Parent{
+		ID: "P", Algo: execution.AlgoIceberg, Status: execution.StatusRunning, BotID: "manual",
+		Side: order.Buy, Qty: d("10"), LimitPrice: d("100"), DisplayQty: d("2"),
+	}
+}
+
+func TestCheckIceberg(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		edit    func(*execution.Parent)
+		wantErr bool
+	}{
+		{name: "plain", edit: func(*execution.Parent) {}},
+		{name: "with price jitter", edit: func(p *execution.Parent) { p.PriceJitter = d("0.5") }},
+		{name: "display the whole quantity", edit: func(p *execution.Parent) { p.DisplayQty = d("10") }},
+		{name: "no limit price", edit: func(p *execution.Parent) { p.LimitPrice = decimal.Zero }, wantErr: true},
+		{name: "no display size", edit: func(p *execution.Parent) { p.DisplayQty = decimal.Zero }, wantErr: true},
+		{name: "display above the quantity", edit: func(p *execution.Parent) { p.DisplayQty = d("11") }, wantErr: true},
+		{name: "negative price jitter", edit: func(p *execution.Parent) { p.PriceJitter = d("-1") }, wantErr: true},
+		{name: "price jitter reaching zero", edit: func(p *execution.Parent) { p.PriceJitter = d("100") }, wantErr: true},
+		{name: "no side", edit: func(p *execution.Parent) { p.Side = "" }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := iceberg()
+			tt.edit(&p)
+			err := execution.Check(p)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, execution.ErrInvalidParent)) {
+				t.Fatalf("Check = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRefill(t *testing.T) {
+	t.Parallel()
+	p := iceberg()
+	rules := instrument.Rules{QtyIncrement: d("0.3")}
+	if got := p.Refill(execution.Progress{}, instrument.Rules{}); !got.Equal(d("2")) {
+		t.Fatalf("first refill = %s, want 2", got)
+	}
+	if got := p.Refill(execution.Progress{FilledQty: d("9")}, instrument.Rules{}); !got.Equal(d("1")) {
+		t.Fatalf("last refill = %s, want the remaining 1", got)
+	}
+	if got := p.Refill(execution.Progress{}, rules); !got.Equal(d("1.8")) {
+		t.Fatalf("floored refill = %s, want 1.8", got)
+	}
+	if got := p.Refill(execution.Progress{FilledQty: d("9.8")}, rules); !got.IsZero() {
+		t.Fatalf("refill below the increment = %s, want 0", got)
+	}
+}
+
+func TestRefillPrice(t *testing.T) {
+	t.Parallel()
+	buy := iceberg()
+	buy.PriceJitter = d("1")
+	sell := buy
+	sell.Side = order.Sell
+	rules := instrument.Rules{PriceIncrement: d("0.3")}
+	tests := []struct {
+		name  string
+		p     execution.Parent
+		rules instrument.Rules
+		u     float64
+		want  string
+	}{
+		{name: "buy at the cap", p: buy, u: -1, want: "100"},
+		{name: "buy the whole jitter below", p: buy, u: 1, want: "99"},
+		{name: "buy floored to the tick", p: buy, rules: rules, u: 0, want: "99.3"},
+		{name: "sell halfway above", p: sell, u: 0, want: "100.5"},
+		{name: "sell ceiled to the tick", p: sell, rules: rules, u: 0.2, want: "100.8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.p.RefillPrice(tt.rules, tt.u); !got.Equal(d(tt.want)) {
+				t.Fatalf("RefillPrice = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
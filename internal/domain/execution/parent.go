@@ -0,0 +1,181 @@
+// Package execution holds the parent-order model of the execution
+// algorithms: a large order worked through child orders over time.
+// Children are ordinary orders carrying their parent's ID; a parent's
+// progress is read from them, never kept beside them.
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/order"
+)
+
+// ErrInvalidParent reports parent terms no algorithm can work.
+var ErrInvalidParent = errors.New("invalid parent order")
+
+// Algo is the algorithm working a parent.
+type Algo string
+
+// Algorithms.
+const (
+	// AlgoTWAP slices the quantity evenly over a duration.
+	AlgoTWAP Algo = "twap"
+	// AlgoIceberg shows a small limit child at the venue and refills it
+	// each time it fills.
+	AlgoIceberg Algo = "iceberg"
+	// AlgoVWAP slices the quantity over a duration in proportion to the
+	// market's usual volume at each time of day.
+	AlgoVWAP Algo = "vwap"
+)
+
+// Scheduled reports whether the algorithm places its children on a
+// schedule of slices.
+func (a Algo) Scheduled() bool {
+	return a == AlgoTWAP || a == AlgoVWAP
+}
+
+// Status is where a parent is in its life.
+type Status string
+
+// Parent statuses. Completed and canceled are final.
+const (
+	// StatusRunning is a parent placing children on its schedule.
+	StatusRunning Status = "running"
+	// StatusPaused is a parent placing no children until resumed. Its
+	// working children carry on.
+	StatusPaused Status = "paused"
+	// StatusCompleted is a parent that filled, or whose schedule ran out;
+	// Reason records any quantity left unfilled.
+	StatusCompleted Status = "completed"
+	// StatusCanceled is a parent stopped by request, or an iceberg whose
+	// visible child ended short of its quantity.
+	StatusCanceled Status = "canceled"
+)
+
+// Final reports whether the parent places no more children.
+func (s Status) Final() bool {
+	return s == StatusCompleted || s == StatusCanceled
+}
+
+// Parent is an order worked by an algorithm through child orders.
+//
+// LimitPrice caps every child: a buy never pays more, a sell never takes
+// less. Zero places market children. A TWAP places Slices children
+// Interval apart, each time and size varied by up to Jitter of their even
+// share. A VWAP places them Interval apart too, sized so that after each
+// the parent has taken its Curve's share of Qty so far. An iceberg keeps
+// one child of DisplayQty working, each refill priced up to PriceJitter
+// inside LimitPrice. SlicesSent counts the children taken so far;
+// NextChildID is the client order ID the next child is placed under, fixed
+// before it is placed so a slice interrupted by a restart is not placed
+// twice. NextAt is when the next scheduled slice is due. Benchmark is the
+// market's VWAP over a finished VWAP parent's life, zero when unknown.
+type Parent struct {
+	ID          order.ParentID
+	Algo        Algo
+	Status      Status
+	BotID       string
+	Instrument  instrument.Instrument
+	Side        order.Side
+	Qty         decimal.Decimal
+	LimitPrice  decimal.Decimal
+	Slices      int
+	Interval    time.Duration
+	Jitter      float64
+	DisplayQty  decimal.Decimal
+	PriceJitter decimal.Decimal
+	Curve       []decimal.Decimal
+	Benchmark   decimal.Decimal
+	SlicesSent  int
+	NextChildID order.ClientOrderID
+	NextAt      time.Time
+	Reason      string
+
+	CreatedAt, UpdatedAt time.Time
+}
+
+// Progress is what a parent's children have done so far. Working is the
+// unfilled quantity of children not yet terminal.
+type Progress struct {
+	FilledQty, AvgFillPrice, Working decimal.Decimal
+	Children                         int
+}
+
+// Remaining is the parent quantity neither filled nor working.
+func (p Parent) Remaining(pr Progress) decimal.Decimal {
+	return decimal.Max(p.Qty.Sub(pr.FilledQty).Sub(pr.Working), decimal.Zero)
+}
+
+// Summarize folds the parent's children into its progress, the average
+// fill price weighted by each child's fills. Pure.
+func Summarize(children []order.Record) Progress {
+	pr := Progress{Children: len(children)}
+	var notional decimal.Decimal
+	for _, child := range children {
+		pr.FilledQty = pr.FilledQty.Add(child.FilledQty)
+		notional = notional.Add(child.FilledQty.Mul(child.AvgFillPrice))
+		if !child.Status.Terminal() {
+			pr.Working = pr.Working.Add(decimal.Max(child.Qty.Sub(child.FilledQty), decimal.Zero))
+		}
+	}
+	if pr.FilledQty.IsPositive() {
+		pr.AvgFillPrice = notional.Div(pr.FilledQty)
+	}
+	return pr
+}
+
+// Check checks p's terms as its algorithm's. Pure.
+func Check(p Parent) error {
+	switch p.Algo {
+	case AlgoTWAP:
+		return CheckTWAP(p)
+	case AlgoIceberg:
+		return CheckIceberg(p)
+	case AlgoVWAP:
+		return CheckVWAP(p)
+	default:
+		return fmt.Errorf("%w: unknown algo %q", ErrInvalidParent, p.Algo)
+	}
+}
+
+// Child is the order that places a slice of qty under id at price, a
+// market order when price is zero. A TWAP child is IOC, so what it cannot
+// fill at once returns to the parent for a later slice instead of resting;
+// so is a VWAP child; an iceberg child rests until it fills.
+func (p Parent) Child(id order.ClientOrderID, qty, price decimal.Decimal) order.Request {
+	req := order.Request{
+		ClientOrderID: id,
+		ParentID:      p.ID,
+		BotID:         p.BotID,
+		Instrument:    p.Instrument,
+		Side:          p.Side,
+		Type:          order.Market,
+		Qty:           qty,
+		TimeInForce:   order.IOC,
+	}
+	if p.Algo == AlgoIceberg {
+		req.TimeInForce = order.GTC
+	}
+	if price.IsPositive() {
+		req.Type, req.Price = order.Limit, price
+	}
+	return req
+}
+
+// Done reports whether the parent is complete: filled, or, on a schedule,
+// out of slices with no child working. The reason records any quantity
+// left unfilled.
+func (p Parent) Done(pr Progress) (bool, string) {
+	switch {
+	case pr.FilledQty.GreaterThanOrEqual(p.Qty):
+		return true, "filled"
+	case p.Algo.Scheduled() && p.SlicesSent >= p.Slices && pr.Working.IsZero():
+		return true, "schedule ended with " + p.Qty.Sub(pr.FilledQty).String() + " unfilled"
+	}
+	return false, ""
+}
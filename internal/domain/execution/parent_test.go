@@ -0,0 +1,144 @@
+package execution_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/execution"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/order"
+)
+
+func d(s string) decimal.Decimal { return decimal.RequireFromString(s) }
+
+// twap buys 10 in 4 slices a minute apart.
+func twap() execution.Parent {
+	return execution.Parent{
+		ID: "P", Algo: execution.AlgoTWAP, Status: execution.StatusRunning, BotID: "manual",
+		Side: order.Buy, Qty: d("10"), Slices: 4, Interval: time.Minute,
+	}
+}
+
+func TestCheckTWAP(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		edit    func(*execution.Parent)
+		wantErr bool
+	}{
+		{name: "market children", edit: func(*execution.Parent) {}},
+		{name: "capped with jitter", edit: func(p *execution.Parent) { p.LimitPrice, p.Jitter = d("100"), 0.5 }},
+		{name: "one slice", edit: func(p *execution.Parent) { p.Slices = 1 }},
+		{name: "another algo", edit: func(p *execution.Parent) { p.Algo = "vwap" }, wantErr: true},
+		{name: "no side", edit: func(p *execution.Parent) { p.Side = "" }, wantErr: true},
+		{name: "zero quantity", edit: func(p *execution.Parent) { p.Qty = decimal.Zero }, wantErr: true},
+		{name: "negative limit", edit: func(p *execution.Parent) { p.LimitPrice = d("-1") }, wantErr: true},
+		{name: "no slices", edit: func(p *execution.Parent) { p.Slices = 0 }, wantErr: true},
+		{name: "too many slices", edit: func(p *execution.Parent) { p.Slices = execution.MaxSlices + 1 }, wantErr: true},
+		{name: "slices too close", edit: func(p *execution.Parent) { p.Interval = time.Millisecond }, wantErr: true},
+		{name: "jitter too wide", edit: func(p *execution.Parent) { p.Jitter = 0.6 }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := twap()
+			tt.edit(&p)
+			err := execution.CheckTWAP(p)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, execution.ErrInvalidParent)) {
+				t.Fatalf("CheckTWAP = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSlice(t *testing.T) {
+	t.Parallel()
+	rules := instrument.Rules{QtyIncrement: d("0.1")}
+	tests := []struct {
+		name     string
+		sent     int
+		jitter   float64
+		u        float64
+		progress execution.Progress
+		want     string
+	}{
+		{name: "even share", want: "2.5"},
+		{name: "jitter up", jitter: 0.5, u: 1, want: "3.7"},
+		{name: "jitter down", jitter: 0.5, u: -1, want: "1.2"},
+		{name: "fills and working come off", sent: 2, progress: execution.Progress{FilledQty: d("4"), Working: d("1")}, want: "2.5"},
+		{name: "last slice takes the rest", sent: 3, progress: execution.Progress{FilledQty: d("6.05")}, want: "3.9"},
+		{name: "floored to the increment", sent: 2, jitter: 0.5, u: 1, progress: execution.Progress{Working: d("9")}, want: "0.7"},
+		{name: "nothing left", sent: 1, progress: execution.Progress{FilledQty: d("10")}, want: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := twap()
+			p.SlicesSent, p.Jitter = tt.sent, tt.jitter
+			if got := p.Slice(tt.progress, rules, tt.u); !got.Equal(d(tt.want)) {
+				t.Fatalf("Slice = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+	pr := execution.Summarize([]order.Record{
+		{Qty: d("2"), FilledQty: d("2"), AvgFillPrice: d("100"), Status: order.StatusFilled},
+		{Qty: d("2"), FilledQty: d("1"), AvgFillPrice: d("103"), Status: order.StatusExpired},
+		{Qty: d("3"), FilledQty: d("1"), AvgFillPrice: d("104"), Status: order.StatusPartiallyFilled},
+	})
+	if pr.Children != 3 || !pr.FilledQty.Equal(d("4")) || !pr.AvgFillPrice.Equal(d("101.75")) || !pr.Working.Equal(d("2")) {
+		t.Fatalf("Summarize = %+v", pr)
+	}
+}
+
+func TestChildAndDone(t *testing.T) {
+	t.Parallel()
+	p := twap()
+	if req := p.Child("C", d("2.5"), p.LimitPrice); req.Type != order.Market || req.ParentID != "P" || req.TimeInForce != order.IOC || !req.Qty.Equal(d("2.5")) {
+		t.Fatalf("market child = %+v", req)
+	}
+	p.LimitPrice = d("100")
+	if req := p.Child("C", d("2.5"), p.LimitPrice); req.Type != order.Limit || !req.Price.Equal(d("100")) {
+		t.Fatalf("capped child = %+v", req)
+	}
+	iceberg := p
+	iceberg.Algo = execution.AlgoIceberg
+	if req := iceberg.Child("C", d("1"), d("99")); req.Type != order.Limit || req.TimeInForce != order.GTC || !req.Price.Equal(d("99")) {
+		t.Fatalf("iceberg child = %+v", req)
+	}
+	if done, _ := iceberg.Done(execution.Progress{FilledQty: d("9")}); done {
+		t.Fatal("iceberg done short of its quantity")
+	}
+
+	if done, _ := p.Done(execution.Progress{FilledQty: d("9")}); done {
+		t.Fatal("done with slices left")
+	}
+	if done, reason := p.Done(execution.Progress{FilledQty: d("10")}); !done || reason != "filled" {
+		t.Fatalf("filled = %v %q", done, reason)
+	}
+	p.SlicesSent = 4
+	if done, _ := p.Done(execution.Progress{FilledQty: d("9"), Working: d("1")}); done {
+		t.Fatal("done with a child working")
+	}
+	if done, reason := p.Done(execution.Progress{FilledQty: d("9")}); !done || reason != "schedule ended with 1 unfilled" {
+		t.Fatalf("schedule ended = %v %q", done, reason)
+	}
+}
+
+func TestWait(t *testing.T) {
+	t.Parallel()
+	p := twap()
+	p.Jitter = 0.25
+	if got := p.Wait(-1); got != 45*time.Second {
+		t.Fatalf("Wait(-1) = %s", got)
+	}
+	if got := p.Wait(1); got != 75*time.Second {
+		t.Fatalf("Wait(1) = %s", got)
+	}
+}
@@ -0,0 +1,76 @@
+package execution
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/order"
+)
+
+// TWAP bounds. A schedule shorter than a second per slice is a market
+// order with extra steps.
+const (
+	MaxSlices   = 1000
+	MinInterval = time.Second
+	MaxJitter   = 0.5
+)
+
+// CheckTWAP checks p's terms as a TWAP: a positive quantity on a side,
+// 1 to MaxSlices slices at least MinInterval apart, jitter between 0 and
+// MaxJitter, and a limit price that is zero or positive. Pure.
+func CheckTWAP(p Parent) error {
+	switch {
+	case p.Algo != AlgoTWAP:
+		return fmt.Errorf("%w: algo %q is not twap", ErrInvalidParent, p.Algo)
+	case p.Side != order.Buy && p.Side != order.Sell:
+		return fmt.Errorf("%w: side %q", ErrInvalidParent, p.Side)
+	case !p.Qty.IsPositive():
+		return fmt.Errorf("%w: quantity must be positive", ErrInvalidParent)
+	case p.LimitPrice.IsNegative():
+		return fmt.Errorf("%w: limit price must not be negative", ErrInvalidParent)
+	case p.Slices < 1 || p.Slices > MaxSlices:
+		return fmt.Errorf("%w: slices must be between 1 and %d", ErrInvalidParent, MaxSlices)
+	case p.Interval < MinInterval:
+		return fmt.Errorf("%w: slices must be at least %s apart", ErrInvalidParent, MinInterval)
+	case p.Jitter < 0 || p.Jitter > MaxJitter:
+		return fmt.Errorf("%w: jitter must be between 0 and %v", ErrInvalidParent, MaxJitter)
+	}
+	return nil
+}
+
+// Slice is the quantity of the next slice, floored to the instrument's
+// quantity increment. A TWAP shares the remaining quantity evenly over the
+// slices left, varied by Jitter×u for u in [-1, 1]; a VWAP takes what
+// brings the parent to its curve's share of the quantity so far. The last
+// slice takes everything remaining, so what earlier children did not fill
+// is caught up at the end. Zero means there is nothing to place this
+// slice. Pure.
+func (p Parent) Slice(pr Progress, rules instrument.Rules, u float64) decimal.Decimal {
+	remaining := p.Remaining(pr)
+	left := p.Slices - p.SlicesSent
+	if left <= 1 || !remaining.IsPositive() {
+		return floor(remaining, rules.QtyIncrement)
+	}
+	if p.Algo == AlgoVWAP {
+		return floor(p.vwapSlice(remaining), rules.QtyIncrement)
+	}
+	even := remaining.Div(decimal.NewFromInt(int64(left)))
+	qty := decimal.Min(even.Mul(decimal.NewFromFloat(1+p.Jitter*u)), remaining)
+	return floor(qty, rules.QtyIncrement)
+}
+
+// Wait is the time from one slice to the next: Interval varied by
+// Jitter×u for u in [-1, 1]. Pure.
+func (p Parent) Wait(u float64) time.Duration {
+	return time.Duration(float64(p.Interval) * (1 + p.Jitter*u))
+}
+
+func floor(qty, increment decimal.Decimal) decimal.Decimal {
+	if !increment.IsPositive() {
+		return qty
+	}
+	return qty.Div(increment).Floor().Mul(increment)
+}
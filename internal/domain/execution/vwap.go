@@ -0,0 +1,136 @@
+package execution
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/order"
+)
+
+// minutesPerDay bounds a volume profile's resolution.
+const minutesPerDay = 24 * 60
+
+var (
+	day         = 24 * time.Hour
+	tenThousand = decimal.NewFromInt(10_000)
+)
+
+// VolumeProfile is the volume usually traded in each equal bucket of the
+// UTC day, the first starting at midnight: 24 entries for hours, 1440 for
+// minutes. Only the proportions matter.
+type VolumeProfile []decimal.Decimal
+
+// Check reports a profile no curve can be read from: one whose buckets do
+// not split the day into whole minutes, with a negative volume, or with no
+// volume at all. Pure.
+func (vp VolumeProfile) Check() error {
+	if len(vp) == 0 || minutesPerDay%len(vp) != 0 {
+		return fmt.Errorf("%w: a volume profile needs a number of buckets dividing the day into whole minutes, not %d",
+			ErrInvalidParent, len(vp))
+	}
+	var total decimal.Decimal
+	for _, v := range vp {
+		if v.IsNegative() {
+			return fmt.Errorf("%w: a volume profile must not hold negative volume", ErrInvalidParent)
+		}
+		total = total.Add(v)
+	}
+	if !total.IsPositive() {
+		return fmt.Errorf("%w: the volume profile holds no volume", ErrInvalidParent)
+	}
+	return nil
+}
+
+// Curve is the share of the profile's volume falling in each of slices
+// windows, interval long and back to back from start. Volume is taken as
+// even within a bucket, and windows past midnight wrap to the start of the
+// profile. The shares sum to one; a schedule over hours the profile holds
+// no volume for is shared evenly. Pure; the profile must pass Check.
+func (vp VolumeProfile) Curve(start time.Time, interval time.Duration, slices int) []decimal.Decimal {
+	bucket := day / time.Duration(len(vp))
+	weights := make([]decimal.Decimal, slices)
+	var total decimal.Decimal
+	at := start.UTC()
+	for i := range weights {
+		end := at.Add(interval)
+		for at.Before(end) {
+			offset := at.Sub(at.Truncate(day))
+			next := at.Add(bucket - offset%bucket)
+			if next.After(end) {
+				next = end
+			}
+			share := decimal.NewFromInt(int64(next.Sub(at))).Div(decimal.NewFromInt(int64(bucket)))
+			weights[i] = weights[i].Add(vp[offset/bucket].Mul(share))
+			at = next
+		}
+		total = total.Add(weights[i])
+	}
+	for i := range weights {
+		if total.IsPositive() {
+			weights[i] = weights[i].Div(total)
+		} else {
+			weights[i] = decimal.NewFromInt(1).Div(decimal.NewFromInt(int64(slices)))
+		}
+	}
+	return weights
+}
+
+// CheckVWAP checks p's terms as a VWAP: a TWAP's, without jitter since the
+// curve is the schedule, and one non-negative curve weight per slice.
+// Pure.
+func CheckVWAP(p Parent) error {
+	twap := p
+	twap.Algo = AlgoTWAP
+	switch err := CheckTWAP(twap); {
+	case p.Algo != AlgoVWAP:
+		return fmt.Errorf("%w: algo %q is not vwap", ErrInvalidParent, p.Algo)
+	case err != nil:
+		return err
+	case p.Jitter != 0:
+		return fmt.Errorf("%w: a vwap follows its curve without jitter", ErrInvalidParent)
+	case len(p.Curve) != p.Slices:
+		return fmt.Errorf("%w: %d curve weights for %d slices", ErrInvalidParent, len(p.Curve), p.Slices)
+	}
+	var total decimal.Decimal
+	for _, w := range p.Curve {
+		if w.IsNegative() {
+			return fmt.Errorf("%w: curve weights must not be negative", ErrInvalidParent)
+		}
+		total = total.Add(w)
+	}
+	if !total.IsPositive() {
+		return fmt.Errorf("%w: the curve holds no weight", ErrInvalidParent)
+	}
+	return nil
+}
+
+// vwapSlice is what brings the parent to its curve's share of the quantity
+// through the next slice, given what remains neither filled nor working.
+func (p Parent) vwapSlice(remaining decimal.Decimal) decimal.Decimal {
+	var share, total decimal.Decimal
+	for i, w := range p.Curve {
+		if i <= p.SlicesSent {
+			share = share.Add(w)
+		}
+		total = total.Add(w)
+	}
+	target := p.Qty.Mul(share).Div(total)
+	taken := p.Qty.Sub(remaining)
+	return decimal.Min(decimal.Max(target.Sub(taken), decimal.Zero), remaining)
+}
+
+// SlippageBps is how far achieved sits from benchmark in basis points,
+// positive when it is worse for the side: a buy paying more, a sell
+// receiving less. Zero when either price is unknown. Pure.
+func SlippageBps(side order.Side, achieved, benchmark decimal.Decimal) decimal.Decimal {
+	if !achieved.IsPositive() || !benchmark.IsPositive() {
+		return decimal.Zero
+	}
+	diff := achieved.Sub(benchmark)
+	if side == order.Sell {
+		diff = diff.Neg()
+	}
+	return diff.Mul(tenThousand).Div(benchmark).Round(2)
+}
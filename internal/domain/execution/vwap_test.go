@@ -0,0 +1,151 @@
+package execution_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/execution"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/order"
+)
+
+// hourly is a profile trading 1 an hour, except 3 from 00:00 and 5 from
+// 23:00.
+func hourly() execution.VolumeProfile {
+	vp := make(execution.VolumeProfile, 24)
+	for i := range vp {
+		vp[i] = d("1")
+	}
+	vp[0], vp[23] = d("3"), d("5")
+	return vp
+}
+
+// vwap buys 10 in 4 slices weighted 1:2:3:4.
+func vwap() execution.Parent {
+	p := twap()
+	p.Algo = execution.AlgoVWAP
+	p.Curve = []decimal.Decimal{d("0.1"), d("0.2"), d("0.3"), d("0.4")}
+	return p
+}
+
+func TestVolumeProfileCheck(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		vp      execution.VolumeProfile
+		wantErr bool
+	}{
+		{name: "hourly", vp: hourly()},
+		{name: "one bucket", vp: execution.VolumeProfile{d("1")}},
+		{name: "empty", vp: nil, wantErr: true},
+		{name: "buckets of uneven minutes", vp: make(execution.VolumeProfile, 7), wantErr: true},
+		{name: "negative volume", vp: execution.VolumeProfile{d("1"), d("-1")}, wantErr: true},
+		{name: "no volume", vp: execution.VolumeProfile{decimal.Zero, decimal.Zero}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.vp.Check()
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, execution.ErrInvalidParent)) {
+				t.Fatalf("Check = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCurve(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2026, 7, 1, 22, 30, 0, 0, time.UTC)
+	// 22:30-23:30 holds 0.5+2.5, 23:30-00:30 holds 2.5+1.5, 00:30-01:30
+	// holds 1.5+0.5: 3, 4 and 2 of 9.
+	got := hourly().Curve(start, time.Hour, 3)
+	want := []string{"0.3333333333333333", "0.4444444444444444", "0.2222222222222222"}
+	for i := range want {
+		if got[i].StringFixed(16) != want[i] {
+			t.Fatalf("curve = %v, want %v", got, want)
+		}
+	}
+	quiet := execution.VolumeProfile{d("1"), decimal.Zero}
+	if got := quiet.Curve(start, time.Minute, 2); !got[0].Equal(d("0.5")) || !got[1].Equal(d("0.5")) {
+		t.Fatalf("curve over a quiet half day = %v, want even shares", got)
+	}
+}
+
+func TestCheckVWAP(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		edit    func(*execution.Parent)
+		wantErr bool
+	}{
+		{name: "valid", edit: func(*execution.Parent) {}},
+		{name: "jitter", edit: func(p *execution.Parent) { p.Jitter = 0.1 }, wantErr: true},
+		{name: "short curve", edit: func(p *execution.Parent) { p.Curve = p.Curve[:3] }, wantErr: true},
+		{name: "negative weight", edit: func(p *execution.Parent) { p.Curve[0] = d("-0.1") }, wantErr: true},
+		{name: "no weight", edit: func(p *execution.Parent) { p.Curve = make([]decimal.Decimal, 4) }, wantErr: true},
+		{name: "slices too close", edit: func(p *execution.Parent) { p.Interval = time.Millisecond }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := vwap()
+			tt.edit(&p)
+			err := execution.Check(p)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, execution.ErrInvalidParent)) {
+				t.Fatalf("Check = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVWAPSlice(t *testing.T) {
+	t.Parallel()
+	rules := instrument.Rules{QtyIncrement: d("0.01")}
+	tests := []struct {
+		name string
+		sent int
+		pr   execution.Progress
+		want string
+	}{
+		{name: "first slice takes its weight", want: "1"},
+		{name: "second slice catches up to the curve", sent: 1, pr: execution.Progress{FilledQty: d("0.5")}, want: "2.5"},
+		{name: "ahead of the curve places nothing", sent: 1, pr: execution.Progress{FilledQty: d("2"), Working: d("2")}, want: "0"},
+		{name: "last slice takes the rest", sent: 3, pr: execution.Progress{FilledQty: d("5")}, want: "5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := vwap()
+			p.SlicesSent = tt.sent
+			if got := p.Slice(tt.pr, rules, 1); !got.Equal(d(tt.want)) {
+				t.Fatalf("Slice = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlippageBps(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name                string
+		side                order.Side
+		achieved, benchmark string
+		want                string
+	}{
+		{name: "buy paying more", side: order.Buy, achieved: "100.5", benchmark: "100", want: "50"},
+		{name: "buy paying less", side: order.Buy, achieved: "99.9", benchmark: "100", want: "-10"},
+		{name: "sell receiving less", side: order.Sell, achieved: "99.9", benchmark: "100", want: "10"},
+		{name: "unknown benchmark", side: order.Sell, achieved: "99.9", benchmark: "0", want: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := execution.SlippageBps(tt.side, d(tt.achieved), d(tt.benchmark)); !got.Equal(d(tt.want)) {
+				t.Fatalf("SlippageBps = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Package funds models reservations: what a placed order will spend, held
+// from the moment it is stored until it ends, so two orders can never both
+// count on the same balance. A buy holds quote, its price times quantity
+// plus a fee buffer; a sell holds base, drawn first from its bot's open
+// lots and for the rest from inventory no bot's lots claim.
+//
+// Reservations are checked against the account's latest balance snapshot.
+// The venue's free balance already nets out what its resting orders lock
+// and what earlier fills spent, so only what was reserved after the
+// snapshot is taken off it: a reservation the snapshot cannot have seen
+// counts in full, less what it handed back unspent when its order ended.
+package funds
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/domain/order"
+)
+
+var (
+	// ErrInsufficient refuses an order the latest balances cannot cover
+	// once what other orders reserved is taken off.
+	ErrInsufficient = errors.New("insufficient funds")
+	// ErrNoBalance refuses an order on an account with no balance snapshot
+	// yet: funds that were never seen cannot be reserved.
+	ErrNoBalance = errors.New("no balance snapshot")
+	// ErrNoPrice refuses a market buy when no price is quoted to reserve
+	// its quote at.
+	ErrNoPrice = errors.New("no price to reserve at")
+)
+
+var one = decimal.NewFromInt(1)
+
+// Reservation is what one order holds of one currency.
+type Reservation struct {
+	ClientOrderID order.ClientOrderID
+	BotID         string
+	Account       account.Ref
+	Base, Quote   money.Currency
+	Side          order.Side
+	// Currency is the quote for a buy and the base for a sell.
+	Currency money.Currency
+	// Amount is what the order held when placed, rescaled by an amend.
+	Amount decimal.Decimal
+	// LotQty is the part of a sell drawn from its bot's open lots.
+	LotQty decimal.Decimal
+	// Remaining is what the order still holds; LotRemaining is the part of
+	// it still drawn from lots. Both shrink as it fills.
+	Remaining    decimal.Decimal
+	LotRemaining decimal.Decimal
+	// Freed is what the order handed back unspent when it ended.
+	Freed      decimal.Decimal
+	CreatedAt  time.Time
+	ReleasedAt time.Time
+}
+
+// New is the reservation for req on the given account, a buy priced at
+// price with feeBuffer, 0.002 for 0.2%, on top for its fee. Pure.
+func New(req order.Request, acct account.Type, price, feeBuffer decimal.Decimal, now time.Time) Reservation {
+	r := Reservation{
+		ClientOrderID: req.ClientOrderID,
+		BotID:         req.BotID,
+		Account:       account.Ref{Venue: req.Instrument.Venue, Type: acct},
+		Base:          req.Instrument.Base,
+		Quote:         req.Instrument.Quote,
+		Side:          req.Side,
+		Currency:      req.Instrument.Base,
+		Amount:        req.Qty,
+		CreatedAt:     now,
+	}
+	if req.Side == order.Buy {
+		r.Currency = req.Instrument.Quote
+		r.Amount = req.Qty.Mul(price).Mul(one.Add(feeBuffer))
+	}
+	r.Remaining = r.Amount
+	return r
+}
+
+// Released reports whether the order has ended and handed its funds back.
+func (r Reservation) Released() bool {
+	return !r.ReleasedAt.IsZero()
+}
+
+// Pool is what a new reservation draws on, read under the store's lock.
+type Pool struct {
+	// Free is the currency's free balance in the latest snapshot.
+	Free decimal.Decimal
+	// Held is what reservations made since that snapshot hold or spent.
+	Held decimal.Decimal
+	// OwnLots is the base the reserving bot holds in open lots of the pair
+	// that none of its sells has reserved. Sells only.
+	OwnLots decimal.Decimal
+	// Lots is the same across every bot and quote on the venue, the
+	// reserving bot included. Sells only.
+	Lots decimal.Decimal
+}
+
+// Draw takes the reservation from the pool, reporting ErrInsufficient
+// when it does not cover it. A sell takes what it can from its bot's lots
+// and the rest from the free balance beyond every bot's lots, so one bot
+// never sells inventory another bot bought; the whole sell must fit the
+// free balance as well. Pure.
+func (r Reservation) Draw(p Pool) (Reservation, error) {
+	available := p.Free.Sub(p.Held)
+	if r.Side == order.Buy {
+		if r.Amount.GreaterThan(available) {
+			return r, fmt.Errorf("%w: buy on %s needs %s %s, %s available", ErrInsufficient,
+				r.Account.Venue, r.Amount, r.Currency, decimal.Max(available, decimal.Zero))
+		}
+		return r, nil
+	}
+	r.LotQty = decimal.Min(r.Amount, decimal.Max(p.OwnLots, decimal.Zero))
+	r.LotRemaining = r.LotQty
+	rest := r.Amount.Sub(r.LotQty)
+	unclaimed := available.Sub(p.Lots)
+	if r.Amount.GreaterThan(available) || (rest.IsPositive() && rest.GreaterThan(unclaimed)) {
+		return r, fmt.Errorf("%w: sell on %s needs %s %s, bot %s has %s in open lots and %s more is unclaimed",
+			ErrInsufficient, r.Account.Venue, r.Amount, r.Currency, r.BotID, r.LotQty, decimal.Max(unclaimed, decimal.Zero))
+	}
+	return r, nil
+}
+
+// Settle follows the order to qty ordered and filled: what it holds
+// shrinks in proportion as it fills, a sell's fills closing its lots
+// first, and an order that ended hands the rest back. A released
+// reservation does not change. Pure.
+func (r Reservation) Settle(qty, filled decimal.Decimal, ended bool, at time.Time) Reservation {
+	if r.Released() {
+		return r
+	}
+	open := decimal.Max(qty.Sub(filled), decimal.Zero)
+	r.Remaining = decimal.Zero
+	if qty.IsPositive() {
+		r.Remaining = r.Amount.Mul(open).Div(qty)
+	}
+	r.LotRemaining = decimal.Min(decimal.Max(r.LotQty.Sub(filled), decimal.Zero), r.Remaining)
+	if ended {
+		r.Freed = r.Remaining
+		r.Remaining, r.LotRemaining = decimal.Zero, decimal.Zero
+		r.ReleasedAt = at
+	}
+	return r
+}
+
+// Amend rescales the reservation to an amended order's new price and
+// quantity, then settles it against what has filled. The venue accepted
+// the amend, which CheckAmend cleared before it was sent, so nothing is
+// checked here. Pure.
+func (r Reservation) Amend(oldPrice, oldQty, newPrice, newQty, filled decimal.Decimal, at time.Time) Reservation {
+	if r.Released() {
+		return r
+	}
+	switch {
+	case r.Side == order.Sell:
+		r.Amount = newQty
+		r.LotQty = decimal.Min(r.LotQty, newQty)
+	case oldPrice.IsPositive() && oldQty.IsPositive():
+		r.Amount = r.Amount.Mul(newPrice.Mul(newQty)).Div(oldPrice.Mul(oldQty))
+	}
+	return r.Settle(newQty, filled, false, at)
+}
+
+// CheckAmend reports ErrInsufficient when an amend that rescales r to
+// amended would hold more than the pool has available: the increase is a
+// new draw, while what r already holds is counted in p.Held or netted out
+// of p.Free. An amend that holds less always fits. Pure.
+func (r Reservation) CheckAmend(amended Reservation, p Pool) error {
+	increase := amended.Remaining.Sub(r.Remaining)
+	if !increase.IsPositive() {
+		return nil
+	}
+	if available := p.Free.Sub(p.Held); increase.GreaterThan(available) {
+		return fmt.Errorf("%w: amend on %s needs %s %s more, %s available", ErrInsufficient,
+			r.Account.Venue, increase, r.Currency, decimal.Max(available, decimal.Zero))
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package funds_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/funds"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/order"
+)
+
+func d(s string) decimal.Decimal { return decimal.RequireFromString(s) }
+
+var now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func request(side order.Side, price, qty string) order.Request {
+	return order.Request{
+		ClientOrderID: "01J00000000000000000000001", BotID: "grid-1",
+		Instrument: instrument.Instrument{Venue: "bybit", Type: instrument.TypeSpot, Base: "BTC", Quote: "USDT"},
+		Side:       side, Type: order.Limit, Price: d(price), Qty: d(qty),
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	buy := funds.New(request(order.Buy, "100", "2"), account.TypeSpot, d("100"), d("0.002"), now)
+	if buy.Currency != "USDT" || !buy.Amount.Equal(d("200.4")) || !buy.Remaining.Equal(buy.Amount) || buy.Account.Venue != "bybit" {
+		t.Fatalf("buy reservation = %+v", buy)
+	}
+	sell := funds.New(request(order.Sell, "100", "2"), account.TypeSpot, d("100"), d("0.002"), now)
+	if sell.Currency != "BTC" || !sell.Amount.Equal(d("2")) {
+		t.Fatalf("sell reservation = %+v", sell)
+	}
+}
+
+func TestDraw(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		side    order.Side
+		qty     string
+		pool    funds.Pool
+		wantLot string
+		wantErr bool
+	}{
+		{name: "buy within the free balance", side: order.Buy, qty: "1", pool: funds.Pool{Free: d("150"), Held: d("50")}},
+		{name: "buy held out by reservations since the snapshot", side: order.Buy, qty: "1", pool: funds.Pool{Free: d("150"), Held: d("60")}, wantErr: true},
+		{name: "sell from the bot's lots", side: order.Sell, qty: "1", pool: funds.Pool{Free: d("1"), OwnLots: d("1"), Lots: d("1")}, wantLot: "1"},
+		{name: "sell past the lots from unclaimed inventory", side: order.Sell, qty: "2", pool: funds.Pool{Free: d("3"), OwnLots: d("0.5"), Lots: d("1")}, wantLot: "0.5"},
+		{name: "sell of another bot's lots", side: order.Sell, qty: "1", pool: funds.Pool{Free: d("1"), Lots: d("1")}, wantErr: true},
+		{name: "sell of lots the venue no longer holds", side: order.Sell, qty: "1", pool: funds.Pool{Free: d("0.5"), OwnLots: d("1"), Lots: d("1")}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r, err := funds.New(request(tt.side, "100", tt.qty), account.TypeSpot, d("100"), decimal.Zero, now).Draw(tt.pool)
+			if tt.wantErr {
+				if !errors.Is(err, funds.ErrInsufficient) {
+					t.Fatalf("err = %v, want ErrInsufficient", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantLot != "" && (!r.LotQty.Equal(d(tt.wantLot)) || !r.LotRemaining.Equal(r.LotQty)) {
+				t.Fatalf("lot qty = %s, remaining %s, want %s", r.LotQty, r.LotRemaining, tt.wantLot)
+			}
+		})
+	}
+}
+
+func TestSettle(t *testing.T) {
+	t.Parallel()
+	buy := funds.New(request(order.Buy, "100", "4"), account.TypeSpot, d("100"), decimal.Zero, now)
+	partial := buy.Settle(d("4"), d("1"), false, now)
+	if !partial.Remaining.Equal(d("300")) || partial.Released() {
+		t.Fatalf("after a partial fill = %+v", partial)
+	}
+	ended := partial.Settle(d("4"), d("1"), true, now)
+	if !ended.Remaining.IsZero() || !ended.Freed.Equal(d("300")) || !ended.Released() {
+		t.Fatalf("after the cancel = %+v", ended)
+	}
+	if again := ended.Settle(d("4"), d("4"), true, now.Add(time.Minute)); again != ended {
+		t.Fatalf("a released reservation changed: %+v", again)
+	}
+
+	sell, err := funds.New(request(order.Sell, "100", "3"), account.TypeSpot, d("100"), decimal.Zero, now).
+		Draw(funds.Pool{Free: d("5"), OwnLots: d("2"), Lots: d("2")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sell = sell.Settle(d("3"), d("1.5"), false, now)
+	if !sell.Remaining.Equal(d("1.5")) || !sell.LotRemaining.Equal(d("0.5")) {
+		t.Fatalf("sell after 1.5 filled = %+v", sell)
+	}
+}
+
+func TestAmend(t *testing.T) {
+	t.Parallel()
+	buy := funds.New(request(order.Buy, "100", "2"), account.TypeSpot, d("100"), d("0.01"), now)
+	amended := buy.Amend(d("100"), d("2"), d("90"), d("3"), d("1"), now)
+	if !amended.Amount.Equal(d("272.7")) || !amended.Remaining.Equal(d("181.8")) {
+		t.Fatalf("amended buy = %+v", amended)
+	}
+	sell := funds.New(request(order.Sell, "100", "2"), account.TypeSpot, d("100"), decimal.Zero, now)
+	sell.LotQty = d("2")
+	if amended := sell.Amend(d("100"), d("2"), d("110"), d("1"), decimal.Zero, now); !amended.Amount.Equal(d("1")) || !amended.LotRemaining.Equal(d("1")) {
+		t.Fatalf("amended sell = %+v", amended)
+	}
+}
+
+func TestCheckAmend(t *testing.T) {
+	t.Parallel()
+	buy := funds.New(request(order.Buy, "100", "2"), account.TypeSpot, d("100"), decimal.Zero, now)
+	pool := funds.Pool{Free: d("300"), Held: d("250")}
+	up := buy.Amend(d("100"), d("2"), d("110"), d("2"), decimal.Zero, now) // holds 20 more
+	if err := buy.CheckAmend(up, pool); err != nil {
+		t.Fatalf("amend up within the balance: %v", err)
+	}
+	further := buy.Amend(d("100"), d("2"), d("100"), d("3"), decimal.Zero, now) // holds 100 more
+	if err := buy.CheckAmend(further, pool); !errors.Is(err, funds.ErrInsufficient) {
+		t.Fatalf("amend up past the balance: err = %v, want ErrInsufficient", err)
+	}
+	down := buy.Amend(d("100"), d("2"), d("90"), d("1"), decimal.Zero, now)
+	if err := buy.CheckAmend(down, funds.Pool{}); err != nil {
+		t.Fatalf("amend down with nothing free: %v", err)
+	}
+	sell := funds.New(request(order.Sell, "100", "2"), account.TypeSpot, d("100"), decimal.Zero, now)
+	if err := sell.CheckAmend(sell.Amend(d("100"), d("2"), d("100"), d("3"), decimal.Zero, now), funds.Pool{Free: d("2.5"), Held: d("2")}); !errors.Is(err, funds.ErrInsufficient) {
+		t.Fatalf("sell amended past the base: err = %v, want ErrInsufficient", err)
+	}
+}
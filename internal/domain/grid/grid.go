@@ -0,0 +1,147 @@
+// Package grid models arithmetic grid ladders: evenly spaced price levels
+// between a lower and an upper bound, with a buy resting on every level
+// below the price and a sell on every level above it. One level, the one
+// nearest the price, is always left empty; a fill moves that gap, which is
+// what makes every buy at level i pair with exactly one sell at level i+1.
+package grid
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/order"
+)
+
+// Spec is one grid bot's immutable configuration.
+type Spec struct {
+	BotID        string
+	Instrument   instrument.Instrument
+	Lower, Upper decimal.Decimal
+	Levels       int             // price levels including both bounds
+	Qty          decimal.Decimal // base quantity of every order
+}
+
+// Level is one rung of the ladder.
+type Level struct {
+	Index int
+	Side  order.Side
+	Price decimal.Decimal
+}
+
+// Validate checks the spec's invariants.
+func (s Spec) Validate() error {
+	var errs []error
+	if s.BotID == "" || s.BotID == "manual" {
+		errs = append(errs, fmt.Errorf("grid: bot ID %q: must be set and not the reserved \"manual\"", s.BotID))
+	}
+	if s.Levels < 3 {
+		errs = append(errs, fmt.Errorf("grid %s: %d levels: need at least 3", s.BotID, s.Levels))
+	}
+	if !s.Lower.IsPositive() || !s.Upper.GreaterThan(s.Lower) {
+		errs = append(errs, fmt.Errorf("grid %s: bounds %s..%s: need 0 < lower < upper", s.BotID, s.Lower, s.Upper))
+	}
+	if !s.Qty.IsPositive() {
+		errs = append(errs, fmt.Errorf("grid %s: qty %s: must be positive", s.BotID, s.Qty))
+	}
+	return errors.Join(errs...)
+}
+
+// CheckRules checks that every order the spec places already meets the
+// instrument's rules: each level on the price increment and the qty on
+// the qty increment and above the minimums. A ladder the venue would round
+// or refuse is refused up front instead, since a rounded level no longer
+// matches its own price and a refused one would be retried forever.
+func (s Spec) CheckRules(rules instrument.Rules) error {
+	var errs []error
+	if tick := rules.PriceIncrement; tick.IsPositive() {
+		steps := tick.Mul(decimal.NewFromInt(int64(s.Levels - 1)))
+		if !s.Lower.Mod(tick).IsZero() || !s.Upper.Sub(s.Lower).Mod(steps).IsZero() {
+			errs = append(errs, fmt.Errorf("grid %s: %d levels from %s to %s: not all on the price increment %s",
+				s.BotID, s.Levels, s.Lower, s.Upper, tick))
+		}
+	}
+	if step := rules.QtyIncrement; step.IsPositive() && !s.Qty.Mod(step).IsZero() {
+		errs = append(errs, fmt.Errorf("grid %s: qty %s: not a multiple of %s", s.BotID, s.Qty, step))
+	}
+	if s.Qty.LessThan(rules.MinQty) {
+		errs = append(errs, fmt.Errorf("grid %s: qty %s: below the minimum %s", s.BotID, s.Qty, rules.MinQty))
+	}
+	if notional := s.Qty.Mul(s.Lower); notional.LessThan(rules.MinNotional) {
+		errs = append(errs, fmt.Errorf("grid %s: notional %s at the lower bound: below the minimum %s",
+			s.BotID, notional, rules.MinNotional))
+	}
+	return errors.Join(errs...)
+}
+
+// Step is the price distance between adjacent levels.
+func (s Spec) Step() decimal.Decimal {
+	return s.Upper.Sub(s.Lower).Div(decimal.NewFromInt(int64(s.Levels - 1)))
+}
+
+// Price returns the price of level i. Level 0 is Lower and the last level
+// is exactly Upper.
+func (s Spec) Price(i int) decimal.Decimal {
+	if i == s.Levels-1 {
+		return s.Upper
+	}
+	return s.Lower.Add(s.Step().Mul(decimal.NewFromInt(int64(i))))
+}
+
+// Prices returns every level's price, ascending.
+func (s Spec) Prices() []decimal.Decimal {
+	out := make([]decimal.Decimal, s.Levels)
+	for i := range out {
+		out[i] = s.Price(i)
+	}
+	return out
+}
+
+// IndexOf returns the level whose price equals p.
+func (s Spec) IndexOf(p decimal.Decimal) (int, bool) {
+	for i := range s.Levels {
+		if s.Price(i).Equal(p) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Ladder returns the initial orders around price: buys below the gap,
+// sells above it. The gap is the level nearest price (the lower one on a
+// tie), so a price outside the bounds leaves the nearest bound empty.
+func (s Spec) Ladder(price decimal.Decimal) []Level {
+	gap := 0
+	for i := 1; i < s.Levels; i++ {
+		if s.Price(i).Sub(price).Abs().LessThan(s.Price(gap).Sub(price).Abs()) {
+			gap = i
+		}
+	}
+	levels := make([]Level, 0, s.Levels-1)
+	for i := range s.Levels {
+		switch {
+		case i < gap:
+			levels = append(levels, Level{Index: i, Side: order.Buy, Price: s.Price(i)})
+		case i > gap:
+			levels = append(levels, Level{Index: i, Side: order.Sell, Price: s.Price(i)})
+		}
+	}
+	return levels
+}
+
+// Counter returns the order that replaces a filled one: a filled buy at
+// level i is answered by a sell at i+1, a filled sell by a buy at i-1. It
+// reports false at the edges, where there is no level to move to.
+func (s Spec) Counter(filled Level) (Level, bool) {
+	next := Level{Index: filled.Index + 1, Side: order.Sell}
+	if filled.Side == order.Sell {
+		next = Level{Index: filled.Index - 1, Side: order.Buy}
+	}
+	if next.Index < 0 || next.Index >= s.Levels {
+		return Level{}, false
+	}
+	next.Price = s.Price(next.Index)
+	return next, true
+}
@@ -0,0 +1,139 @@
+package grid_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/grid"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/order"
+)
+
+func spec() grid.Spec {
+	return grid.Spec{
+		BotID: "grid-1",
+		Lower: decimal.NewFromInt(100), Upper: decimal.NewFromInt(110),
+		Levels: 6, Qty: decimal.RequireFromString("0.5"),
+	}
+}
+
+func render(levels []grid.Level) string {
+	out := ""
+	for _, l := range levels {
+		out += fmt.Sprintf("%d:%s@%s ", l.Index, l.Side, l.Price)
+	}
+	return out
+}
+
+func TestCheckRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules instrument.Rules
+		want  string // substring of the error; empty for none
+	}{
+		{"no rules", instrument.Rules{}, ""},
+		{"levels on the tick", instrument.Rules{PriceIncrement: decimal.RequireFromString("0.5")}, ""},
+		{"step off the tick", instrument.Rules{PriceIncrement: decimal.RequireFromString("4")}, "price increment 4"},
+		{"lower off the tick", instrument.Rules{PriceIncrement: decimal.RequireFromString("3")}, "price increment 3"},
+		{"qty off its increment", instrument.Rules{QtyIncrement: decimal.RequireFromString("0.3")}, "multiple of 0.3"},
+		{"qty below the minimum", instrument.Rules{MinQty: decimal.NewFromInt(1)}, "below the minimum 1"},
+		{"notional below the minimum", instrument.Rules{MinNotional: decimal.NewFromInt(60)}, "notional 50"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := spec().CheckRules(tt.rules)
+			if tt.want == "" && err != nil || tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)) {
+				t.Fatalf("CheckRules = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	uneven := spec()
+	uneven.Upper, uneven.Levels = decimal.NewFromInt(500), 4 // steps of 133.33…
+	if err := uneven.CheckRules(instrument.Rules{PriceIncrement: decimal.RequireFromString("0.01")}); err == nil {
+		t.Fatal("CheckRules accepted levels between ticks")
+	}
+}
+
+func TestLadder(t *testing.T) {
+	tests := []struct {
+		name  string
+		price string
+		want  string
+	}{
+		{"between levels gaps the nearest", "105.1", "0:buy@100 1:buy@102 2:buy@104 4:sell@108 5:sell@110 "},
+		{"on a level gaps that level", "104", "0:buy@100 1:buy@102 3:sell@106 4:sell@108 5:sell@110 "},
+		{"tie gaps the lower level", "105", "0:buy@100 1:buy@102 3:sell@106 4:sell@108 5:sell@110 "},
+		{"below the range is all sells", "90", "1:sell@102 2:sell@104 3:sell@106 4:sell@108 5:sell@110 "},
+		{"above the range is all buys", "200", "0:buy@100 1:buy@102 2:buy@104 3:buy@106 4:buy@108 "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := render(spec().Ladder(decimal.RequireFromString(tt.price))); got != tt.want {
+				t.Fatalf("Ladder(%s) = %q, want %q", tt.price, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCounter(t *testing.T) {
+	s := spec()
+	tests := []struct {
+		name   string
+		filled grid.Level
+		want   string
+		ok     bool
+	}{
+		{"buy answered one level up", grid.Level{Index: 2, Side: order.Buy}, "3:sell@106 ", true},
+		{"sell answered one level down", grid.Level{Index: 3, Side: order.Sell}, "2:buy@104 ", true},
+		{"top sell answered", grid.Level{Index: 5, Side: order.Sell}, "4:buy@108 ", true},
+		{"buy at the top has no counter", grid.Level{Index: 5, Side: order.Buy}, "", false},
+		{"sell at the bottom has no counter", grid.Level{Index: 0, Side: order.Sell}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := s.Counter(tt.filled)
+			if ok != tt.ok || (ok && render([]grid.Level{got}) != tt.want) {
+				t.Fatalf("Counter = %+v, %v; want %q, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestUnevenStepEndsOnUpper(t *testing.T) {
+	s := spec()
+	s.Levels = 4
+	if !s.Price(3).Equal(s.Upper) {
+		t.Fatalf("top level = %s, want %s", s.Price(3), s.Upper)
+	}
+	if i, ok := s.IndexOf(s.Price(1)); !ok || i != 1 {
+		t.Fatalf("IndexOf(level 1) = %d, %v", i, ok)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*grid.Spec)
+	}{
+		{"reserved bot ID", func(s *grid.Spec) { s.BotID = "manual" }},
+		{"too few levels", func(s *grid.Spec) { s.Levels = 2 }},
+		{"inverted bounds", func(s *grid.Spec) { s.Lower, s.Upper = s.Upper, s.Lower }},
+		{"zero qty", func(s *grid.Spec) { s.Qty = decimal.Zero }},
+	}
+	if err := spec().Validate(); err != nil {
+		t.Fatalf("valid spec: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := spec()
+			tt.mutate(&s)
+			if s.Validate() == nil {
+				t.Fatal("expected a validation error")
+			}
+		})
+	}
+}
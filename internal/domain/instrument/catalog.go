@@ -0,0 +1,60 @@
+package instrument
+
+import "sort"
+
+// ChangeKind classifies one catalog change.
+type ChangeKind string
+
+// Catalog change kinds.
+const (
+	// ChangeListed: the venue lists an instrument the catalog did not have,
+	// or had marked delisted.
+	ChangeListed ChangeKind = "listed"
+	// ChangeUpdated: the venue symbol, status or rules changed.
+	ChangeUpdated ChangeKind = "updated"
+	// ChangeDelisted: the venue stopped listing the instrument.
+	ChangeDelisted ChangeKind = "delisted"
+)
+
+// Change is one difference between the catalog and a venue listing.
+// Instrument is the state after the change.
+type Change struct {
+	Kind       ChangeKind
+	Instrument Instrument
+}
+
+// Diff compares one venue's catalog entries with its current listing and
+// returns the changes that bring the catalog up to date, ordered by key.
+// Listed instruments without a status are trading; catalog entries the
+// listing lacks become delisted and keep their last rules. Pure.
+func Diff(catalog, listing []Instrument) []Change {
+	known := make(map[string]Instrument, len(catalog))
+	for _, inst := range catalog {
+		known[inst.Key()] = inst
+	}
+	seen := make(map[string]bool, len(listing))
+	var changes []Change
+	for _, inst := range listing {
+		if inst.Status == "" {
+			inst.Status = StatusTrading
+		}
+		key := inst.Key()
+		seen[key] = true
+		old, ok := known[key]
+		switch {
+		case !ok || old.Status == StatusDelisted:
+			changes = append(changes, Change{Kind: ChangeListed, Instrument: inst})
+		case old.VenueSymbol != inst.VenueSymbol || old.Status != inst.Status || !old.Rules.Equal(inst.Rules):
+			changes = append(changes, Change{Kind: ChangeUpdated, Instrument: inst})
+		}
+	}
+	for _, inst := range catalog {
+		if seen[inst.Key()] || inst.Status == StatusDelisted {
+			continue
+		}
+		inst.Status = StatusDelisted
+		changes = append(changes, Change{Kind: ChangeDelisted, Instrument: inst})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Instrument.Key() < changes[j].Instrument.Key() })
+	return changes
+}
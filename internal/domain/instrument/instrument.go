@@ -36,8 +36,22 @@ type Instrument struct {
 	// Rules are the venue's trading constraints. Optional for snapshots; required
 	// by order validation.
 	Rules Rules
+	// Status is the venue's listing state. Adapters that cannot tell leave
+	// it empty, which reads as StatusTrading.
+	Status Status
 }
 
+// Status is an instrument's listing state at its venue.
+type Status string
+
+// Listing states. Delisted is never reported by a venue: the catalog
+// marks an instrument delisted when the venue stops listing it.
+const (
+	StatusTrading  Status = "trading"
+	StatusHalted   Status = "halted"
+	StatusDelisted Status = "delisted"
+)
+
 // Rules are venue trading constraints for an instrument.
 type Rules struct {
 	PriceIncrement decimal.Decimal
@@ -46,6 +60,12 @@ type Rules struct {
 	MinNotional    decimal.Decimal
 }
 
+// Equal reports whether both rule sets hold the same values.
+func (r Rules) Equal(o Rules) bool {
+	return r.PriceIncrement.Equal(o.PriceIncrement) && r.QtyIncrement.Equal(o.QtyIncrement) &&
+		r.MinQty.Equal(o.MinQty) && r.MinNotional.Equal(o.MinNotional)
+}
+
 // Key returns the canonical map key, e.g. "bybit:spot:BTC/USDT".
 func (i Instrument) Key() string {
 	return fmt.Sprintf("%s:%s:%s/%s", i.Venue, i.Type, i.Base, i.Quote)
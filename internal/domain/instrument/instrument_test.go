@@ -2,6 +2,10 @@ package instrument
 
 import (
 	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/money"
 )
 
 func TestParsePair(t *testing.T) {
@@ -54,3 +58,52 @@ func TestKeyStability(t *testing.T) {
 		t.Errorf("Pair: got %q", got)
 	}
 }
+
+func TestDiff(t *testing.T) {
+	spot := func(base, symbol string, status Status, tick string) Instrument {
+		return Instrument{
+			Venue: "bybit", Type: TypeSpot, Base: money.Currency(base), Quote: "USDT",
+			VenueSymbol: symbol, Status: status, Rules: Rules{PriceIncrement: decimal.RequireFromString(tick)},
+		}
+	}
+	catalog := []Instrument{
+		spot("BTC", "BTCUSDT", StatusTrading, "0.1"),
+		spot("ETH", "ETHUSDT", StatusTrading, "0.01"),
+		spot("LTC", "LTCUSDT", StatusTrading, "0.01"),
+		spot("XRP", "XRPUSDT", StatusDelisted, "0.0001"),
+		spot("SOL", "SOLUSDT", StatusTrading, "0.01"),
+	}
+	listing := []Instrument{
+		spot("BTC", "BTCUSDT", "", "0.10"),   // unchanged: empty status is trading, 0.10 == 0.1
+		spot("ETH", "ETHUSDT", "", "0.1"),    // tick changed
+		spot("XRP", "XRPUSDT", "", "0.0001"), // relisted
+		spot("DOGE", "DOGEUSDT", StatusHalted, "0.00001"),
+		spot("SOL", "SOLUSDT", StatusHalted, "0.01"), // halted
+	}
+	got := Diff(catalog, listing)
+	want := []struct {
+		base   string
+		kind   ChangeKind
+		status Status
+	}{
+		{"DOGE", ChangeListed, StatusHalted},
+		{"ETH", ChangeUpdated, StatusTrading},
+		{"LTC", ChangeDelisted, StatusDelisted},
+		{"SOL", ChangeUpdated, StatusHalted},
+		{"XRP", ChangeListed, StatusTrading},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("changes = %+v", got)
+	}
+	for i, w := range want {
+		if string(got[i].Instrument.Base) != w.base || got[i].Kind != w.kind || got[i].Instrument.Status != w.status {
+			t.Errorf("change %d = %s %s %s, want %s %s %s", i, got[i].Instrument.Base, got[i].Kind, got[i].Instrument.Status, w.base, w.kind, w.status)
+		}
+	}
+	if !got[2].Instrument.Rules.PriceIncrement.Equal(decimal.RequireFromString("0.01")) {
+		t.Errorf("delisted instrument lost its rules: %+v", got[2].Instrument.Rules)
+	}
+	if again := Diff(catalog, nil); len(again) != 4 {
+		t.Errorf("empty listing delists %d, want every listed entry", len(again))
+	}
+}
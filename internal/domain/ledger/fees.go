@@ -0,0 +1,206 @@
+package ledger
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/money"
+)
+
+// shareScale is the decimal places kept when a total is split
+// proportionally. The last share always takes the remainder, so shares
+// sum exactly to their total whatever the rounding.
+const shareScale = 18
+
+// ErrFeeExceedsFill reports a buy whose base-currency fee is not less than
+// the quantity bought: there is nothing left to open a lot with, and
+// dropping the fill would lose it from the ledger unseen.
+var ErrFeeExceedsFill = errors.New("base fee consumes the whole fill")
+
+// ClosureKind says why a lot was closed.
+type ClosureKind string
+
+// Closure kinds. A fee closure consumes inventory to pay a fee charged in
+// the lot's currency: it has cost but no proceeds.
+const (
+	ClosureSale ClosureKind = "sale"
+	ClosureFee  ClosureKind = "fee"
+)
+
+// FeeSplit is one fill's fee by the currency it is paid in.
+type FeeSplit struct {
+	Quote decimal.Decimal // folded into buy cost, taken out of sell proceeds
+	Base  decimal.Decimal // reduces the bought quantity, consumes sold inventory
+	Other decimal.Decimal // third currency (e.g. BNB): consumes lots held in it, never converted
+}
+
+// SplitFee classifies a fill's fee. A positive fee without a currency
+// counts as Other: it cannot be folded into anything.
+func SplitFee(amount decimal.Decimal, currency, base, quote money.Currency) FeeSplit {
+	if !amount.IsPositive() {
+		return FeeSplit{}
+	}
+	switch currency {
+	case quote:
+		return FeeSplit{Quote: amount}
+	case base:
+		return FeeSplit{Base: amount}
+	default:
+		return FeeSplit{Other: amount}
+	}
+}
+
+// FeeTotal sums the fees one inventory paid in a currency that is neither
+// side of its pair.
+type FeeTotal struct {
+	BotID       string
+	Venue       instrument.VenueID
+	Base, Quote money.Currency
+	Currency    money.Currency
+	Amount      decimal.Decimal
+}
+
+// BuyBasis returns the lot a buy fill opens: the quantity actually
+// received and its total cost in the quote currency. A base fee that
+// leaves nothing to hold is ErrFeeExceedsFill.
+func BuyBasis(qty, price decimal.Decimal, fee FeeSplit) (lotQty, cost decimal.Decimal, err error) {
+	lotQty = qty.Sub(fee.Base)
+	if !lotQty.IsPositive() {
+		return decimal.Zero, decimal.Zero, ErrFeeExceedsFill
+	}
+	return lotQty, qty.Mul(price).Add(fee.Quote), nil
+}
+
+// ConsumeFee allocates a third-currency fee across the open lots held in
+// that currency, oldest first, as fee closures carrying each lot's cost
+// share. Unmatched is the fee no lot covers: currency the ledger never saw
+// bought. Pure.
+func ConsumeFee(open []Lot, qty decimal.Decimal) Posting {
+	lots := append([]Lot(nil), open...)
+	sortFIFO(lots)
+	allocation := allocate(lots, qty)
+	byID := make(map[string]*Lot, len(lots))
+	for i := range lots {
+		byID[lots[i].ID] = &lots[i]
+	}
+	posting := Posting{Unmatched: allocation.Unmatched}
+	for _, c := range allocation.Closures {
+		posting.Closures = append(posting.Closures, PostedClosure{
+			LotID: c.LotID, Kind: ClosureFee, Qty: c.Qty, Cost: byID[c.LotID].takeCost(c.Qty),
+		})
+	}
+	return posting
+}
+
+// PostedClosure is one closure row a sell fill writes.
+type PostedClosure struct {
+	LotID string
+	Kind  ClosureKind
+	Qty   decimal.Decimal
+	Cost  decimal.Decimal // the lot's cost share for Qty
+	Fee   decimal.Decimal // the sell's quote fee share; zero for fee closures
+}
+
+// Posting is a sell fill's effect on the lots.
+type Posting struct {
+	Closures     []PostedClosure
+	Unmatched    decimal.Decimal // sold and fee quantity no lot covered
+	UnmatchedFee decimal.Decimal // quote fee share of the unmatched sold quantity
+}
+
+// PostSell allocates a sell fill and any base fee it paid across open
+// lots. The selector sees both as one sell, so a pairing selector consumes
+// the fee from the paired lot; the first sell.Qty of the allocation is the
+// sale and the rest pays the fee. The quote fee is spread over the sold
+// quantity, unmatched part included, and each closure carries its lot's
+// cost share. Pure.
+func PostSell(open []Lot, selector LotSelector, sell Sell, fee FeeSplit) Posting {
+	allocation := selector.Select(open, Sell{Qty: sell.Qty.Add(fee.Base), Price: sell.Price})
+	lots := make(map[string]*Lot, len(open))
+	for i := range open {
+		lot := open[i]
+		lots[lot.ID] = &lot
+	}
+
+	var posting Posting
+	saleLeft := sell.Qty
+	for _, c := range allocation.Closures {
+		sale := decimal.Min(saleLeft, c.Qty)
+		if sale.IsPositive() {
+			posting.Closures = append(posting.Closures, PostedClosure{LotID: c.LotID, Kind: ClosureSale, Qty: sale})
+			saleLeft = saleLeft.Sub(sale)
+		}
+		if feeQty := c.Qty.Sub(sale); feeQty.IsPositive() {
+			posting.Closures = append(posting.Closures, PostedClosure{LotID: c.LotID, Kind: ClosureFee, Qty: feeQty})
+		}
+	}
+	posting.Unmatched = allocation.Unmatched
+
+	for i := range posting.Closures {
+		c := &posting.Closures[i]
+		if lot, ok := lots[c.LotID]; ok {
+			c.Cost = lot.takeCost(c.Qty)
+		}
+	}
+
+	// saleLeft is now the unmatched sold quantity; it bears its share of
+	// the quote fee so matched closures are not charged for it.
+	parts := make([]decimal.Decimal, 0, len(posting.Closures)+1)
+	var sales []*PostedClosure
+	for i := range posting.Closures {
+		if posting.Closures[i].Kind == ClosureSale {
+			sales = append(sales, &posting.Closures[i])
+			parts = append(parts, posting.Closures[i].Qty)
+		}
+	}
+	parts = append(parts, saleLeft)
+	shares := Prorate(fee.Quote, parts)
+	for i, c := range sales {
+		c.Fee = shares[i]
+	}
+	posting.UnmatchedFee = shares[len(shares)-1]
+	return posting
+}
+
+// takeCost removes qty from the lot and returns its cost share. Closing
+// the rest of the lot takes the rest of its cost, so a lot's closures
+// always sum to its cost exactly.
+func (l *Lot) takeCost(qty decimal.Decimal) decimal.Decimal {
+	share := l.RemainingCost
+	if qty.LessThan(l.RemainingQty) {
+		share = l.RemainingCost.Mul(qty).DivRound(l.RemainingQty, shareScale)
+	}
+	l.RemainingQty = l.RemainingQty.Sub(qty)
+	l.RemainingCost = l.RemainingCost.Sub(share)
+	return share
+}
+
+// Prorate splits total in proportion to parts. The last non-zero part
+// takes the remainder, so the shares sum exactly to total. All-zero parts
+// get nothing.
+func Prorate(total decimal.Decimal, parts []decimal.Decimal) []decimal.Decimal {
+	shares := make([]decimal.Decimal, len(parts))
+	sum, last := decimal.Zero, -1
+	for i, p := range parts {
+		sum = sum.Add(p)
+		if p.IsPositive() {
+			last = i
+		}
+	}
+	if last < 0 || total.IsZero() {
+		return shares
+	}
+	given := decimal.Zero
+	for i, p := range parts {
+		switch {
+		case i == last:
+			shares[i] = total.Sub(given)
+		case p.IsPositive():
+			shares[i] = total.Mul(p).DivRound(sum, shareScale)
+			given = given.Add(shares[i])
+		}
+	}
+	return shares
+}
@@ -0,0 +1,182 @@
+package ledger_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"pgregory.net/rapid"
+
+	"github.com/romanornr/delta-works/internal/domain/ledger"
+	"github.com/romanornr/delta-works/internal/domain/money"
+)
+
+func costLot(id, qty, cost string) ledger.Lot {
+	l := lot(id, qty, openedAt)
+	l.Cost = decimal.RequireFromString(cost)
+	l.RemainingCost = l.Cost
+	return l
+}
+
+func TestSplitFee(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		currency money.Currency
+		want     ledger.FeeSplit
+	}{
+		{name: "quote", amount: "0.1", currency: "USDT", want: ledger.FeeSplit{Quote: decimal.RequireFromString("0.1")}},
+		{name: "base", amount: "0.001", currency: "BTC", want: ledger.FeeSplit{Base: decimal.RequireFromString("0.001")}},
+		{name: "third currency", amount: "0.002", currency: "BNB", want: ledger.FeeSplit{Other: decimal.RequireFromString("0.002")}},
+		{name: "no currency", amount: "0.002", want: ledger.FeeSplit{Other: decimal.RequireFromString("0.002")}},
+		{name: "zero", amount: "0", currency: "USDT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ledger.SplitFee(decimal.RequireFromString(tt.amount), tt.currency, "BTC", "USDT")
+			if !got.Quote.Equal(tt.want.Quote) || !got.Base.Equal(tt.want.Base) || !got.Other.Equal(tt.want.Other) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuyBasis(t *testing.T) {
+	qty, cost, err := ledger.BuyBasis(decimal.NewFromInt(1), decimal.NewFromInt(100),
+		ledger.FeeSplit{Quote: decimal.RequireFromString("0.1"), Base: decimal.RequireFromString("0.001")})
+	if err != nil || !qty.Equal(decimal.RequireFromString("0.999")) || !cost.Equal(decimal.RequireFromString("100.1")) {
+		t.Fatalf("basis = %s @ %s, %v; want 0.999 @ 100.1", qty, cost, err)
+	}
+	if _, _, err := ledger.BuyBasis(decimal.NewFromInt(1), decimal.NewFromInt(100),
+		ledger.FeeSplit{Base: decimal.NewFromInt(1)}); !errors.Is(err, ledger.ErrFeeExceedsFill) {
+		t.Fatalf("whole-fill base fee: err = %v, want ErrFeeExceedsFill", err)
+	}
+}
+
+func TestConsumeFee(t *testing.T) {
+	open := []ledger.Lot{costLot("b", "0.01", "6"), costLot("a", "0.01", "5")}
+	open[0].OpenedAt = openedAt.Add(1)
+	got := ledger.ConsumeFee(open, decimal.RequireFromString("0.015"))
+
+	want := []ledger.PostedClosure{
+		{LotID: "a", Kind: ledger.ClosureFee, Qty: decimal.RequireFromString("0.01"), Cost: decimal.NewFromInt(5)},
+		{LotID: "b", Kind: ledger.ClosureFee, Qty: decimal.RequireFromString("0.005"), Cost: decimal.NewFromInt(3)},
+	}
+	if len(got.Closures) != len(want) || !got.Unmatched.IsZero() {
+		t.Fatalf("posting = %+v", got)
+	}
+	for i, w := range want {
+		c := got.Closures[i]
+		if c.LotID != w.LotID || c.Kind != w.Kind || !c.Qty.Equal(w.Qty) || !c.Cost.Equal(w.Cost) || !c.Fee.IsZero() {
+			t.Errorf("closure %d = %+v, want %+v", i, c, w)
+		}
+	}
+	if !open[0].RemainingCost.Equal(decimal.NewFromInt(6)) {
+		t.Fatal("ConsumeFee must not mutate its input")
+	}
+	if short := ledger.ConsumeFee(nil, decimal.RequireFromString("0.002")); len(short.Closures) != 0 || !short.Unmatched.Equal(decimal.RequireFromString("0.002")) {
+		t.Fatalf("no lots: posting = %+v", short)
+	}
+}
+
+func TestPostSell(t *testing.T) {
+	open := []ledger.Lot{costLot("a", "1", "100.1"), costLot("b", "1", "110")}
+	open[1].OpenedAt = openedAt.Add(1)
+	got := ledger.PostSell(open, ledger.FIFO{}, ledger.Sell{Qty: decimal.RequireFromString("1.5")},
+		ledger.FeeSplit{Quote: decimal.RequireFromString("0.3"), Base: decimal.RequireFromString("0.1")})
+
+	want := []ledger.PostedClosure{
+		{LotID: "a", Kind: ledger.ClosureSale, Qty: decimal.NewFromInt(1), Cost: decimal.RequireFromString("100.1"), Fee: decimal.RequireFromString("0.2")},
+		{LotID: "b", Kind: ledger.ClosureSale, Qty: decimal.RequireFromString("0.5"), Cost: decimal.NewFromInt(55), Fee: decimal.RequireFromString("0.1")},
+		{LotID: "b", Kind: ledger.ClosureFee, Qty: decimal.RequireFromString("0.1"), Cost: decimal.NewFromInt(11)},
+	}
+	if len(got.Closures) != len(want) {
+		t.Fatalf("closures = %+v", got.Closures)
+	}
+	for i, w := range want {
+		c := got.Closures[i]
+		if c.LotID != w.LotID || c.Kind != w.Kind || !c.Qty.Equal(w.Qty) || !c.Cost.Equal(w.Cost) || !c.Fee.Equal(w.Fee) {
+			t.Errorf("closure %d = %+v, want %+v", i, c, w)
+		}
+	}
+	if !got.Unmatched.IsZero() || !got.UnmatchedFee.IsZero() {
+		t.Fatalf("unmatched = %s fee %s, want none", got.Unmatched, got.UnmatchedFee)
+	}
+	if !open[1].RemainingCost.Equal(decimal.NewFromInt(110)) {
+		t.Fatal("PostSell must not mutate its input")
+	}
+
+	short := ledger.PostSell([]ledger.Lot{costLot("a", "1", "100")}, ledger.FIFO{},
+		ledger.Sell{Qty: decimal.NewFromInt(2)}, ledger.FeeSplit{Quote: decimal.NewFromInt(2)})
+	if !short.Unmatched.Equal(decimal.NewFromInt(1)) || !short.UnmatchedFee.Equal(decimal.NewFromInt(1)) ||
+		!short.Closures[0].Fee.Equal(decimal.NewFromInt(1)) {
+		t.Fatalf("short posting = %+v", short)
+	}
+}
+
+func TestPropPostSellConservesCostAndFee(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		n := rapid.IntRange(1, 6).Draw(t, "lots")
+		open := make([]ledger.Lot, 0, n)
+		total := decimal.Zero
+		for i := range n {
+			l := lot(string(rune('a'+i)), decimal.New(rapid.Int64Range(1, 10_000).Draw(t, "qty"), -3).String(), openedAt.Add(time.Duration(i)))
+			l.Cost = decimal.New(rapid.Int64Range(0, 10_000_000).Draw(t, "cost"), -4)
+			l.RemainingCost = l.Cost
+			open = append(open, l)
+			total = total.Add(l.RemainingQty)
+		}
+		sell := decimal.New(rapid.Int64Range(1, 12_000).Draw(t, "sell"), -3)
+		fee := ledger.FeeSplit{
+			Quote: decimal.New(rapid.Int64Range(0, 1_000).Draw(t, "quoteFee"), -3),
+			Base:  decimal.New(rapid.Int64Range(0, 100).Draw(t, "baseFee"), -3),
+		}
+		got := ledger.PostSell(open, ledger.FIFO{}, ledger.Sell{Qty: sell}, fee)
+
+		closed, fees, sold := decimal.Zero, got.UnmatchedFee, decimal.Zero
+		costs := map[string]decimal.Decimal{}
+		for _, c := range got.Closures {
+			closed = closed.Add(c.Qty)
+			fees = fees.Add(c.Fee)
+			costs[c.LotID] = costs[c.LotID].Add(c.Cost)
+			if c.Kind == ledger.ClosureSale {
+				sold = sold.Add(c.Qty)
+			} else if !c.Fee.IsZero() {
+				t.Fatalf("fee closure carries a quote fee: %+v", c)
+			}
+		}
+		if !closed.Add(got.Unmatched).Equal(sell.Add(fee.Base)) {
+			t.Fatalf("closed %s + unmatched %s != sell %s + base fee %s", closed, got.Unmatched, sell, fee.Base)
+		}
+		if !fees.Equal(fee.Quote) {
+			t.Fatalf("quote fee shares sum to %s, want %s", fees, fee.Quote)
+		}
+		if sold.GreaterThan(sell) {
+			t.Fatalf("sold %s exceeds sell %s", sold, sell)
+		}
+		if sell.Add(fee.Base).GreaterThanOrEqual(total) {
+			for _, l := range open {
+				if !costs[l.ID].Equal(l.Cost) {
+					t.Fatalf("lot %s closed for %s, want its full cost %s", l.ID, costs[l.ID], l.Cost)
+				}
+			}
+		}
+	})
+}
+
+func TestProrate(t *testing.T) {
+	got := ledger.Prorate(decimal.NewFromInt(1), []decimal.Decimal{
+		decimal.NewFromInt(1), decimal.Zero, decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.Zero,
+	})
+	sum := decimal.Zero
+	for _, share := range got {
+		sum = sum.Add(share)
+	}
+	if !sum.Equal(decimal.NewFromInt(1)) || !got[1].IsZero() || !got[4].IsZero() {
+		t.Fatalf("shares = %v, sum %s", got, sum)
+	}
+	if shares := ledger.Prorate(decimal.NewFromInt(1), []decimal.Decimal{decimal.Zero}); !shares[0].IsZero() {
+		t.Fatalf("all-zero parts got %v", shares)
+	}
+}
@@ -13,14 +13,23 @@ import (
 
 // Lot is an open inventory position created by one buy fill.
 type Lot struct {
-	ID           string // ULID, opaque to the domain
-	BotID        string
-	Venue        instrument.VenueID
-	Base, Quote  money.Currency
-	Qty          decimal.Decimal
-	RemainingQty decimal.Decimal
-	CostPrice    decimal.Decimal // opening fill's execution price; zero when the venue reported none
-	OpenedAt     time.Time
+	ID            string // ULID, opaque to the domain
+	BotID         string
+	Venue         instrument.VenueID
+	Base, Quote   money.Currency
+	Qty           decimal.Decimal
+	RemainingQty  decimal.Decimal
+	CostPrice     decimal.Decimal // opening fill's execution price; zero when the venue reported none
+	Cost          decimal.Decimal // total cost in quote, quote fee included
+	RemainingCost decimal.Decimal // Cost not yet taken by closures
+	OrderPrice    decimal.Decimal // opening order's limit price; zero for market orders
+	OpenedAt      time.Time
+}
+
+// Sell is the sell fill a selector allocates.
+type Sell struct {
+	Qty   decimal.Decimal // fill quantity; always positive
+	Price decimal.Decimal // the sell order's limit price; zero for market orders
 }
 
 // Closure is one lot's share of a sell fill.
@@ -41,26 +50,120 @@ type Outcome struct {
 }
 
 // LotSelector decides which open lots a sell fill closes. Pure: same
-// inputs, same answer, input slice never mutated. sellQty must be
+// inputs, same answer, input slice never mutated. sell.Qty must be
 // positive; the caller validates before selecting.
 type LotSelector interface {
-	Select(open []Lot, sellQty decimal.Decimal) Allocation
+	Select(open []Lot, sell Sell) Allocation
 }
 
 // FIFO closes oldest lots first, ties broken by lot ID.
 type FIFO struct{}
 
-// Select allocates sellQty across open lots in FIFO order.
-func (FIFO) Select(open []Lot, sellQty decimal.Decimal) Allocation {
+// Select allocates the sell across open lots in FIFO order.
+func (FIFO) Select(open []Lot, sell Sell) Allocation {
+	lots := append([]Lot(nil), open...)
+	sortFIFO(lots)
+	return allocate(lots, sell.Qty)
+}
+
+// Unmatched closes nothing: the whole sell is reported unmatched. As a
+// fallback it keeps a pairing selector from borrowing inventory it cannot
+// attribute.
+type Unmatched struct{}
+
+// Select reports the whole sell unmatched.
+func (Unmatched) Select(_ []Lot, sell Sell) Allocation {
+	return Allocation{Unmatched: sell.Qty}
+}
+
+// GridPairing closes the lots bought exactly one grid level below the
+// sell's level, which makes each closure one grid cycle and its profit
+// exactly the level spacing times the quantity, less costs. Lots are
+// matched by their opening order's limit price, not the fill price, so
+// price improvement does not break the pairing. Whatever cannot be paired
+// (a sell off the grid, on the lowest level, or larger than the paired
+// lots) goes to Fallback over the lots left.
+type GridPairing struct {
+	Levels   []decimal.Decimal // grid prices, ascending, on the price increment
+	Fallback LotSelector
+}
+
+// Select pairs the sell with the level below, oldest lot first, then
+// hands the remainder to Fallback.
+func (g GridPairing) Select(open []Lot, sell Sell) Allocation {
 	lots := append([]Lot(nil), open...)
+	sortFIFO(lots)
+	var paired []Lot
+	if below, ok := g.levelBelow(sell.Price); ok {
+		for _, lot := range lots {
+			if lot.OrderPrice.Equal(below) {
+				paired = append(paired, lot)
+			}
+		}
+	}
+	allocation := allocate(paired, sell.Qty)
+	if !allocation.Unmatched.IsPositive() {
+		return allocation
+	}
+
+	closed := make(map[string]decimal.Decimal, len(allocation.Closures))
+	for _, c := range allocation.Closures {
+		closed[c.LotID] = c.Qty
+	}
+	rest := make([]Lot, 0, len(lots))
+	for _, lot := range lots {
+		lot.RemainingQty = lot.RemainingQty.Sub(closed[lot.ID])
+		rest = append(rest, lot)
+	}
+	fallback := g.Fallback
+	if fallback == nil {
+		fallback = Unmatched{}
+	}
+	more := fallback.Select(rest, Sell{Qty: allocation.Unmatched, Price: sell.Price})
+	allocation.Closures = append(allocation.Closures, more.Closures...)
+	allocation.Unmatched = more.Unmatched
+	return allocation
+}
+
+func (g GridPairing) levelBelow(price decimal.Decimal) (decimal.Decimal, bool) {
+	for i := 1; i < len(g.Levels); i++ {
+		if g.Levels[i].Equal(price) {
+			return g.Levels[i-1], true
+		}
+	}
+	return decimal.Zero, false
+}
+
+// Selectors picks the selector for each bot. The zero value selects FIFO
+// for everyone.
+type Selectors struct {
+	Default LotSelector
+	ByBot   map[string]LotSelector
+}
+
+// For returns the bot's selector, or the default.
+func (s Selectors) For(botID string) LotSelector {
+	if selector, ok := s.ByBot[botID]; ok {
+		return selector
+	}
+	if s.Default != nil {
+		return s.Default
+	}
+	return FIFO{}
+}
+
+func sortFIFO(lots []Lot) {
 	sort.Slice(lots, func(i, j int) bool {
 		if lots[i].OpenedAt.Equal(lots[j].OpenedAt) {
 			return lots[i].ID < lots[j].ID
 		}
 		return lots[i].OpenedAt.Before(lots[j].OpenedAt)
 	})
+}
 
-	remaining := sellQty
+// allocate closes lots in the given order until qty is covered.
+func allocate(lots []Lot, qty decimal.Decimal) Allocation {
+	remaining := qty
 	allocation := Allocation{}
 	for _, lot := range lots {
 		if !remaining.IsPositive() {
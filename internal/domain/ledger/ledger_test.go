@@ -65,7 +65,7 @@ func TestFIFOSelect(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			before := append([]ledger.Lot(nil), tt.lots...)
-			got := (ledger.FIFO{}).Select(tt.lots, decimal.RequireFromString(tt.sell))
+			got := (ledger.FIFO{}).Select(tt.lots, ledger.Sell{Qty: decimal.RequireFromString(tt.sell)})
 			if !equalAllocation(got, tt.want) {
 				t.Fatalf("Select = %+v, want %+v", got, tt.want)
 			}
@@ -101,7 +101,7 @@ func TestPropFIFOConservesQuantityAndOrder(t *testing.T) {
 			)
 		}
 		sellQty := decimal.New(int64(rapid.IntRange(1, 1000).Draw(t, "sell_qty")), -2)
-		allocation := (ledger.FIFO{}).Select(lots, sellQty)
+		allocation := (ledger.FIFO{}).Select(lots, ledger.Sell{Qty: sellQty})
 
 		sum := allocation.Unmatched
 		byID := make(map[string]ledger.Lot, len(lots))
@@ -136,3 +136,78 @@ func TestPropFIFOConservesQuantityAndOrder(t *testing.T) {
 		}
 	})
 }
+
+func gridLot(id, remaining, orderPrice string, at time.Time) ledger.Lot {
+	l := lot(id, remaining, at)
+	l.OrderPrice = decimal.RequireFromString(orderPrice)
+	return l
+}
+
+func TestGridPairingSelect(t *testing.T) {
+	levels := []decimal.Decimal{
+		decimal.NewFromInt(100), decimal.NewFromInt(102), decimal.NewFromInt(104), decimal.NewFromInt(106),
+	}
+	lots := []ledger.Lot{
+		gridLot("old-100", "1", "100", openedAt),
+		gridLot("a-102", "1", "102", openedAt.Add(time.Minute)),
+		gridLot("b-102", "1", "102", openedAt.Add(2*time.Minute)),
+	}
+	one, two := decimal.NewFromInt(1), decimal.NewFromInt(2)
+	tests := []struct {
+		name     string
+		fallback ledger.LotSelector
+		sell     ledger.Sell
+		want     ledger.Allocation
+	}{
+		{
+			name: "pairs with the level below, not FIFO", sell: ledger.Sell{Qty: one, Price: decimal.NewFromInt(104)},
+			want: ledger.Allocation{Closures: []ledger.Closure{{LotID: "a-102", Qty: one}}},
+		},
+		{
+			name: "oldest paired lot first", sell: ledger.Sell{Qty: two, Price: decimal.NewFromInt(104)},
+			want: ledger.Allocation{Closures: []ledger.Closure{{LotID: "a-102", Qty: one}, {LotID: "b-102", Qty: one}}},
+		},
+		{
+			name: "remainder falls back to FIFO", fallback: ledger.FIFO{},
+			sell: ledger.Sell{Qty: decimal.NewFromInt(3), Price: decimal.NewFromInt(104)},
+			want: ledger.Allocation{Closures: []ledger.Closure{
+				{LotID: "a-102", Qty: one}, {LotID: "b-102", Qty: one}, {LotID: "old-100", Qty: one},
+			}},
+		},
+		{
+			name: "nothing below reports unmatched", sell: ledger.Sell{Qty: one, Price: decimal.NewFromInt(106)},
+			want: ledger.Allocation{Unmatched: one},
+		},
+		{
+			name: "off-grid sell falls back", fallback: ledger.FIFO{}, sell: ledger.Sell{Qty: one, Price: decimal.NewFromInt(105)},
+			want: ledger.Allocation{Closures: []ledger.Closure{{LotID: "old-100", Qty: one}}},
+		},
+		{
+			name: "lowest level has no pair", fallback: ledger.Unmatched{}, sell: ledger.Sell{Qty: one, Price: decimal.NewFromInt(100)},
+			want: ledger.Allocation{Unmatched: one},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := append([]ledger.Lot(nil), lots...)
+			got := ledger.GridPairing{Levels: levels, Fallback: tt.fallback}.Select(lots, tt.sell)
+			if !equalAllocation(got, tt.want) {
+				t.Fatalf("Select = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(lots, before) {
+				t.Fatalf("input mutated: got %+v, want %+v", lots, before)
+			}
+		})
+	}
+}
+
+func TestSelectorsFor(t *testing.T) {
+	grid := ledger.GridPairing{}
+	selectors := ledger.Selectors{ByBot: map[string]ledger.LotSelector{"grid-1": grid}}
+	if _, ok := selectors.For("grid-1").(ledger.GridPairing); !ok {
+		t.Fatalf("grid-1 selector = %T", selectors.For("grid-1"))
+	}
+	if _, ok := selectors.For("manual").(ledger.FIFO); !ok {
+		t.Fatalf("default selector = %T, want FIFO", selectors.For("manual"))
+	}
+}
@@ -0,0 +1,178 @@
+package ledger
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/money"
+)
+
+// ClosedLot is one lot closure joined with the lot it closed: the unit of
+// realized profit. The fill IDs are what make every number traceable.
+type ClosedLot struct {
+	ClosureID   int64
+	LotID       string
+	BotID       string
+	Venue       instrument.VenueID
+	Base, Quote money.Currency
+	Kind        ClosureKind
+	Qty         decimal.Decimal
+	CostPrice   decimal.Decimal // buy fill price; zero when the venue reported none
+	SellPrice   decimal.Decimal // sell fill price; zero when the venue reported none
+	Cost        decimal.Decimal // the lot's cost share, buy quote fee included
+	Fee         decimal.Decimal // the sell's quote fee share; zero for fee closures
+	BuyFillID   int64
+	SellFillID  int64
+	ClosedAt    time.Time
+}
+
+// Priced reports whether both sides of the closure carry a price. A fee
+// closure has no sell side: it only needs the lot's price. An unpriced
+// closure has no knowable profit and is never counted as zero.
+func (c ClosedLot) Priced() bool {
+	return c.CostPrice.IsPositive() && (c.Kind == ClosureFee || c.SellPrice.IsPositive())
+}
+
+// Proceeds is what the closure brought in, net of the sell's quote fee.
+// Fee closures bring in nothing.
+func (c ClosedLot) Proceeds() decimal.Decimal {
+	if c.Kind == ClosureFee {
+		return decimal.Zero
+	}
+	return c.Qty.Mul(c.SellPrice).Sub(c.Fee)
+}
+
+// Realized is the closure's profit in the quote currency. A fee closure
+// realizes the loss of the inventory it consumed.
+func (c ClosedLot) Realized() decimal.Decimal {
+	return c.Proceeds().Sub(c.Cost)
+}
+
+// ClosureQuery selects closures, closure totals or fee charges in
+// [From, To). Nil filters match all. Closures are paged: Limit bounds a
+// page and the cursor, when set, resumes after the closure it names.
+// Totals ignore both and cover the whole window.
+type ClosureQuery struct {
+	BotID, Venue   *string
+	Base, Quote    *string
+	From, To       time.Time
+	Limit          int32
+	CursorClosedAt *time.Time
+	CursorID       *int64
+}
+
+// ClosureTotal sums one inventory's closures over a window. Qty, FeeQty,
+// Cost and Proceeds cover priced closures only, split by kind as PnL
+// documents; UnpricedQty is the rest.
+type ClosureTotal struct {
+	BotID       string
+	Venue       instrument.VenueID
+	Base, Quote money.Currency
+	Qty         decimal.Decimal
+	FeeQty      decimal.Decimal
+	Cost        decimal.Decimal
+	Proceeds    decimal.Decimal
+	UnpricedQty decimal.Decimal
+}
+
+// PnL is the realized profit of one (bot, venue, base, quote) inventory
+// over a window. Qty, FeeQty, Cost, Proceeds and Realized cover priced
+// closures only; UnpricedQty is the closed quantity whose profit cannot be
+// known. Proceeds are net of quote fees. OtherFees are third-currency fees
+// the inventory paid; they are not in Realized because converting them
+// needs a price the ledger does not have.
+type PnL struct {
+	BotID       string
+	Venue       instrument.VenueID
+	Base, Quote money.Currency
+	Qty         decimal.Decimal // sold
+	FeeQty      decimal.Decimal // consumed by base-currency fees
+	Cost        decimal.Decimal
+	Proceeds    decimal.Decimal
+	Realized    decimal.Decimal
+	UnpricedQty decimal.Decimal
+	OtherFees   map[money.Currency]decimal.Decimal
+	Closures    []ClosedLot // in closing order
+}
+
+type pnlKey struct {
+	bot         string
+	venue       instrument.VenueID
+	base, quote money.Currency
+}
+
+// SumClosures totals closures per inventory, ordered by bot, venue, base
+// and quote. The store sums a whole window the same way in SQL; this is
+// its reference.
+func SumClosures(closures []ClosedLot) []ClosureTotal {
+	groups := map[pnlKey]*ClosureTotal{}
+	var keys []pnlKey
+	for _, c := range closures {
+		key := pnlKey{bot: c.BotID, venue: c.Venue, base: c.Base, quote: c.Quote}
+		t, ok := groups[key]
+		if !ok {
+			t = &ClosureTotal{BotID: c.BotID, Venue: c.Venue, Base: c.Base, Quote: c.Quote}
+			groups[key] = t
+			keys = append(keys, key)
+		}
+		if !c.Priced() {
+			t.UnpricedQty = t.UnpricedQty.Add(c.Qty)
+			continue
+		}
+		if c.Kind == ClosureFee {
+			t.FeeQty = t.FeeQty.Add(c.Qty)
+		} else {
+			t.Qty = t.Qty.Add(c.Qty)
+		}
+		t.Cost = t.Cost.Add(c.Cost)
+		t.Proceeds = t.Proceeds.Add(c.Proceeds())
+	}
+	sortKeys(keys)
+	out := make([]ClosureTotal, 0, len(keys))
+	for _, key := range keys {
+		out = append(out, *groups[key])
+	}
+	return out
+}
+
+// Realize joins closure totals, fee totals and closures into one PnL per
+// inventory, ordered by bot, venue, base and quote. The totals are taken
+// as given, so a page of closures can ride along with the totals of the
+// whole window. Pure: closures keep their input order within a group.
+func Realize(totals []ClosureTotal, fees []FeeTotal, closures []ClosedLot) []PnL {
+	groups := map[pnlKey]*PnL{}
+	var keys []pnlKey
+	group := func(key pnlKey) *PnL {
+		p, ok := groups[key]
+		if !ok {
+			p = &PnL{BotID: key.bot, Venue: key.venue, Base: key.base, Quote: key.quote}
+			groups[key] = p
+			keys = append(keys, key)
+		}
+		return p
+	}
+	for _, t := range totals {
+		p := group(pnlKey{bot: t.BotID, venue: t.Venue, base: t.Base, quote: t.Quote})
+		p.Qty, p.FeeQty, p.UnpricedQty = t.Qty, t.FeeQty, t.UnpricedQty
+		p.Cost, p.Proceeds, p.Realized = t.Cost, t.Proceeds, t.Proceeds.Sub(t.Cost)
+	}
+	for _, f := range fees {
+		p := group(pnlKey{bot: f.BotID, venue: f.Venue, base: f.Base, quote: f.Quote})
+		if p.OtherFees == nil {
+			p.OtherFees = map[money.Currency]decimal.Decimal{}
+		}
+		p.OtherFees[f.Currency] = p.OtherFees[f.Currency].Add(f.Amount)
+	}
+	for _, c := range closures {
+		p := group(pnlKey{bot: c.BotID, venue: c.Venue, base: c.Base, quote: c.Quote})
+		p.Closures = append(p.Closures, c)
+	}
+	sortKeys(keys)
+	out := make([]PnL, 0, len(keys))
+	for _, key := range keys {
+		out = append(out, *groups[key])
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+package ledger_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/ledger"
+)
+
+func closed(bot, qty, cost, sell string, sellFill int64) ledger.ClosedLot {
+	q, price := decimal.RequireFromString(qty), decimal.RequireFromString(cost)
+	return ledger.ClosedLot{
+		BotID: bot, Venue: "bybit", Base: "BTC", Quote: "USDT", Kind: ledger.ClosureSale,
+		Qty: q, CostPrice: price, Cost: q.Mul(price),
+		SellPrice: decimal.RequireFromString(sell), SellFillID: sellFill,
+	}
+}
+
+func TestRealize(t *testing.T) {
+	// The spec's worked example: 0.30 × (63,000 − 50,000) + 0.10 × (63,000 − 61,000).
+	closures := []ledger.ClosedLot{
+		closed("manual", "0.30", "50000", "63000", 7),
+		closed("grid", "1", "100", "102", 9),
+		closed("manual", "0.10", "61000", "63000", 7),
+		closed("manual", "0.05", "0", "63000", 8),
+	}
+	got := ledger.Realize(ledger.SumClosures(closures), nil, closures)
+	if len(got) != 2 || got[0].BotID != "grid" || got[1].BotID != "manual" {
+		t.Fatalf("groups = %+v", got)
+	}
+	manual := got[1]
+	want := map[string][2]decimal.Decimal{
+		"qty":      {manual.Qty, decimal.RequireFromString("0.40")},
+		"cost":     {manual.Cost, decimal.RequireFromString("21100")},
+		"proceeds": {manual.Proceeds, decimal.RequireFromString("25200")},
+		"realized": {manual.Realized, decimal.RequireFromString("4100")},
+		"unpriced": {manual.UnpricedQty, decimal.RequireFromString("0.05")},
+	}
+	for name, pair := range want {
+		if !pair[0].Equal(pair[1]) {
+			t.Errorf("%s = %s, want %s", name, pair[0], pair[1])
+		}
+	}
+	if len(manual.Closures) != 3 || manual.Closures[0].SellFillID != 7 || manual.Closures[2].SellFillID != 8 {
+		t.Fatalf("closures = %+v", manual.Closures)
+	}
+	if !got[0].Realized.Equal(decimal.NewFromInt(2)) {
+		t.Fatalf("grid realized = %s, want 2", got[0].Realized)
+	}
+	if len(ledger.Realize(nil, nil, nil)) != 0 {
+		t.Fatal("no closures must report no PnL")
+	}
+}
+
+func TestRealizeFees(t *testing.T) {
+	// Bought 1 BTC at 100 paying 0.1 USDT; sold 0.5 at 110 paying 0.05 USDT;
+	// a 0.001 BTC fee consumed inventory; 0.002 BNB was paid on the side.
+	sale := closed("grid", "0.5", "100", "110", 7)
+	sale.Cost, sale.Fee = decimal.RequireFromString("50.05"), decimal.RequireFromString("0.05")
+	fee := closed("grid", "0.001", "100", "110", 7)
+	fee.Kind, fee.Cost = ledger.ClosureFee, decimal.RequireFromString("0.1001")
+	got := ledger.Realize(ledger.SumClosures([]ledger.ClosedLot{sale, fee}), []ledger.FeeTotal{
+		{BotID: "grid", Venue: "bybit", Base: "BTC", Quote: "USDT", Currency: "BNB", Amount: decimal.RequireFromString("0.002")},
+		{BotID: "other", Venue: "bybit", Base: "ETH", Quote: "USDT", Currency: "BNB", Amount: decimal.RequireFromString("0.001")},
+	}, nil)
+	if len(got) != 2 || got[0].BotID != "grid" || got[1].BotID != "other" || len(got[0].Closures) != 0 {
+		t.Fatalf("groups = %+v", got)
+	}
+	grid := got[0]
+	want := map[string][2]decimal.Decimal{
+		"qty":      {grid.Qty, decimal.RequireFromString("0.5")},
+		"fee qty":  {grid.FeeQty, decimal.RequireFromString("0.001")},
+		"cost":     {grid.Cost, decimal.RequireFromString("50.1501")},
+		"proceeds": {grid.Proceeds, decimal.RequireFromString("54.95")},
+		"realized": {grid.Realized, decimal.RequireFromString("4.7999")},
+		"bnb":      {grid.OtherFees["BNB"], decimal.RequireFromString("0.002")},
+	}
+	for name, pair := range want {
+		if !pair[0].Equal(pair[1]) {
+			t.Errorf("%s = %s, want %s", name, pair[0], pair[1])
+		}
+	}
+}
+
+func TestRealizeTakesTotalsAsGiven(t *testing.T) {
+	// A later page's closures do not add to the window's totals.
+	page := []ledger.ClosedLot{closed("grid", "1", "100", "102", 9)}
+	window := []ledger.ClosureTotal{{
+		BotID: "grid", Venue: "bybit", Base: "BTC", Quote: "USDT",
+		Qty: decimal.NewFromInt(3), Cost: decimal.NewFromInt(300), Proceeds: decimal.NewFromInt(306),
+	}}
+	got := ledger.Realize(window, nil, page)
+	if len(got) != 1 || !got[0].Qty.Equal(decimal.NewFromInt(3)) || !got[0].Realized.Equal(decimal.NewFromInt(6)) || len(got[0].Closures) != 1 {
+		t.Fatalf("pnl = %+v", got)
+	}
+	if got := ledger.Realize(nil, nil, page); len(got) != 1 || !got[0].Qty.IsZero() || len(got[0].Closures) != 1 {
+		t.Fatalf("closures alone = %+v", got)
+	}
+}
@@ -0,0 +1,88 @@
+package ledger
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/money"
+)
+
+// Position is the open inventory of one (bot, venue, base, quote): the sum
+// of its open lots. Qty and Cost cover lots with a known cost price;
+// UnpricedQty is held inventory whose cost the venue never reported.
+type Position struct {
+	BotID       string
+	Venue       instrument.VenueID
+	Base, Quote money.Currency
+	Qty         decimal.Decimal
+	Cost        decimal.Decimal
+	UnpricedQty decimal.Decimal
+}
+
+// Instrument is the spot market the position is marked against.
+func (p Position) Instrument() instrument.Instrument {
+	return instrument.Instrument{Venue: p.Venue, Type: instrument.TypeSpot, Base: p.Base, Quote: p.Quote}
+}
+
+// Positions sums open lots into one Position per inventory, ordered by
+// bot, venue, base and quote. Pure.
+func Positions(open []Lot) []Position {
+	groups := map[pnlKey]*Position{}
+	var keys []pnlKey
+	for _, lot := range open {
+		key := pnlKey{bot: lot.BotID, venue: lot.Venue, base: lot.Base, quote: lot.Quote}
+		p, ok := groups[key]
+		if !ok {
+			p = &Position{BotID: lot.BotID, Venue: lot.Venue, Base: lot.Base, Quote: lot.Quote}
+			groups[key] = p
+			keys = append(keys, key)
+		}
+		if !lot.CostPrice.IsPositive() {
+			p.UnpricedQty = p.UnpricedQty.Add(lot.RemainingQty)
+			continue
+		}
+		p.Qty = p.Qty.Add(lot.RemainingQty)
+		p.Cost = p.Cost.Add(lot.RemainingCost)
+	}
+	sortKeys(keys)
+	out := make([]Position, 0, len(keys))
+	for _, key := range keys {
+		out = append(out, *groups[key])
+	}
+	return out
+}
+
+// Valuation is a position marked to market: what its priced quantity is
+// worth at Mark, observed At, against what it cost.
+type Valuation struct {
+	Position
+	Mark       decimal.Decimal
+	Value      decimal.Decimal
+	Unrealized decimal.Decimal
+	At         time.Time
+}
+
+// MarkAt values the position at price.
+func (p Position) MarkAt(price decimal.Decimal, at time.Time) Valuation {
+	value := p.Qty.Mul(price)
+	return Valuation{Position: p, Mark: price, Value: value, Unrealized: value.Sub(p.Cost), At: at}
+}
+
+func sortKeys(keys []pnlKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.bot != b.bot {
+			return a.bot < b.bot
+		}
+		if a.venue != b.venue {
+			return a.venue < b.venue
+		}
+		if a.base != b.base {
+			return a.base < b.base
+		}
+		return a.quote < b.quote
+	})
+}
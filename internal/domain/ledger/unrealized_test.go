@@ -0,0 +1,41 @@
+package ledger_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/ledger"
+)
+
+func TestPositionsMarkAt(t *testing.T) {
+	open := func(bot, remaining, cost, costPrice string) ledger.Lot {
+		l := costLot(bot+remaining, remaining, cost)
+		l.BotID, l.Venue, l.Base, l.Quote = bot, "bybit", "BTC", "USDT"
+		l.CostPrice = decimal.RequireFromString(costPrice)
+		return l
+	}
+	got := ledger.Positions([]ledger.Lot{
+		open("manual", "0.3", "15000", "50000"),
+		open("grid", "1", "100.1", "100"),
+		open("manual", "0.2", "12200", "61000"),
+		open("manual", "0.05", "0", "0"),
+	})
+	if len(got) != 2 || got[0].BotID != "grid" || got[1].BotID != "manual" {
+		t.Fatalf("positions = %+v", got)
+	}
+	manual := got[1]
+	if !manual.Qty.Equal(decimal.RequireFromString("0.5")) || !manual.Cost.Equal(decimal.NewFromInt(27200)) ||
+		!manual.UnpricedQty.Equal(decimal.RequireFromString("0.05")) {
+		t.Fatalf("manual position = %+v", manual)
+	}
+	at := time.Date(2026, 7, 12, 12, 0, 0, 0, time.UTC)
+	v := manual.MarkAt(decimal.NewFromInt(60000), at)
+	if !v.Value.Equal(decimal.NewFromInt(30000)) || !v.Unrealized.Equal(decimal.NewFromInt(2800)) || !v.At.Equal(at) {
+		t.Fatalf("valuation = %+v", v)
+	}
+	if inst := manual.Instrument(); inst.Pair() != "BTC/USDT" || inst.Venue != "bybit" {
+		t.Fatalf("instrument = %+v", inst)
+	}
+}
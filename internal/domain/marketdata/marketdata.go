@@ -19,3 +19,39 @@ type Ticker struct {
 	AskSize    decimal.Decimal
 	At         time.Time
 }
+
+// Trade is one public trade on the venue: what the market traded, not one
+// of our fills. ID is the venue's trade ID, empty when it gives none.
+type Trade struct {
+	Instrument instrument.Instrument
+	ID         string
+	Price      decimal.Decimal
+	Qty        decimal.Decimal
+	At         time.Time
+}
+
+// PriceSource names the ticker price used to value inventory.
+type PriceSource string
+
+// Price sources.
+const (
+	// PriceMid is the top-of-book midpoint, falling back to the last trade
+	// when the book is one-sided or empty.
+	PriceMid PriceSource = "mid"
+	// PriceLast is the last trade price.
+	PriceLast PriceSource = "last"
+)
+
+var two = decimal.NewFromInt(2)
+
+// Price returns the ticker's price by source, and false when the ticker
+// carries no usable price for it.
+func (t Ticker) Price(source PriceSource) (decimal.Decimal, bool) {
+	if source == PriceMid && t.Bid.IsPositive() && t.Ask.IsPositive() {
+		return t.Bid.Add(t.Ask).Div(two), true
+	}
+	if t.Last.IsPositive() {
+		return t.Last, true
+	}
+	return decimal.Decimal{}, false
+}
@@ -0,0 +1,35 @@
+package marketdata_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+)
+
+func TestTickerPrice(t *testing.T) {
+	tests := []struct {
+		name           string
+		bid, ask, last int64
+		source         marketdata.PriceSource
+		want           int64
+		ok             bool
+	}{
+		{name: "mid", bid: 99, ask: 101, last: 105, source: marketdata.PriceMid, want: 100, ok: true},
+		{name: "one-sided book falls back to last", bid: 99, last: 105, source: marketdata.PriceMid, want: 105, ok: true},
+		{name: "last", bid: 99, ask: 101, last: 105, source: marketdata.PriceLast, want: 105, ok: true},
+		{name: "no last trade", bid: 99, ask: 101, source: marketdata.PriceLast},
+		{name: "empty", source: marketdata.PriceMid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := marketdata.Ticker{
+				Bid: decimal.NewFromInt(tt.bid), Ask: decimal.NewFromInt(tt.ask), Last: decimal.NewFromInt(tt.last),
+			}.Price(tt.source)
+			if ok != tt.ok || (ok && !got.Equal(decimal.NewFromInt(tt.want))) {
+				t.Fatalf("price = %s, %t; want %d, %t", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TimeInForce says how long an order works before the venue ends it.
+type TimeInForce string
+
+// Times in force. Orders stored before time in force existed are GTC.
+const (
+	// GTC rests until filled or canceled.
+	GTC TimeInForce = "gtc"
+	// IOC fills what it can on arrival; the venue expires the rest.
+	IOC TimeInForce = "ioc"
+	// FOK fills entirely on arrival or expires without a fill.
+	FOK TimeInForce = "fok"
+	// GTD rests until Request.ExpiresAt, then expires.
+	GTD TimeInForce = "gtd"
+)
+
+var (
+	// ErrInvalidExecution reports a time in force or expiry that does not
+	// fit the order.
+	ErrInvalidExecution = errors.New("invalid time in force")
+	// ErrInvalidPostOnly reports a post-only flag on an order that cannot
+	// rest.
+	ErrInvalidPostOnly = errors.New("invalid post-only")
+)
+
+// CheckExecution checks req's execution flags. An empty TimeInForce means
+// GTC. Post-only is a resting limit order's promise never to take
+// liquidity, so it excludes market orders and the immediate IOC and FOK;
+// a market order cannot rest, so it cannot be GTD either. A GTD expiry
+// must be after now. Pure.
+func CheckExecution(req Request, now time.Time) error {
+	tif := req.TimeInForce
+	switch tif {
+	case "", GTC, IOC, FOK, GTD:
+	default:
+		return fmt.Errorf("%w: unknown time in force %q", ErrInvalidExecution, tif)
+	}
+	switch {
+	case tif == GTD && req.ExpiresAt.IsZero():
+		return fmt.Errorf("%w: gtd needs an expiry", ErrInvalidExecution)
+	case tif != GTD && !req.ExpiresAt.IsZero():
+		return fmt.Errorf("%w: only gtd orders take an expiry", ErrInvalidExecution)
+	case tif == GTD && !req.ExpiresAt.After(now):
+		return fmt.Errorf("%w: expiry %s is not in the future", ErrInvalidExecution, req.ExpiresAt.UTC().Format(time.RFC3339))
+	case tif == GTD && req.Type == Market:
+		return fmt.Errorf("%w: market orders cannot be gtd", ErrInvalidExecution)
+	case req.PostOnly && req.Type != Limit:
+		return fmt.Errorf("%w: post-only needs a limit order", ErrInvalidPostOnly)
+	case req.PostOnly && (tif == IOC || tif == FOK):
+		return fmt.Errorf("%w: post-only cannot be %s", ErrInvalidPostOnly, tif)
+	}
+	return nil
+}
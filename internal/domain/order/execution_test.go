@@ -0,0 +1,45 @@
+package order_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/romanornr/delta-works/internal/domain/order"
+)
+
+func TestCheckExecution(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 7, 12, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		kind    order.Type
+		tif     order.TimeInForce
+		expires time.Time
+		post    bool
+		wantErr error
+	}{
+		{name: "empty is gtc", kind: order.Limit},
+		{name: "post-only gtc", kind: order.Limit, tif: order.GTC, post: true},
+		{name: "post-only gtd", kind: order.Limit, tif: order.GTD, expires: now.Add(time.Hour), post: true},
+		{name: "market ioc", kind: order.Market, tif: order.IOC},
+		{name: "limit fok", kind: order.Limit, tif: order.FOK},
+		{name: "unknown tif", kind: order.Limit, tif: "day", wantErr: order.ErrInvalidExecution},
+		{name: "gtd without expiry", kind: order.Limit, tif: order.GTD, wantErr: order.ErrInvalidExecution},
+		{name: "gtd expiry in the past", kind: order.Limit, tif: order.GTD, expires: now, wantErr: order.ErrInvalidExecution},
+		{name: "expiry without gtd", kind: order.Limit, tif: order.GTC, expires: now.Add(time.Hour), wantErr: order.ErrInvalidExecution},
+		{name: "market gtd", kind: order.Market, tif: order.GTD, expires: now.Add(time.Hour), wantErr: order.ErrInvalidExecution},
+		{name: "post-only market", kind: order.Market, post: true, wantErr: order.ErrInvalidPostOnly},
+		{name: "post-only ioc", kind: order.Limit, tif: order.IOC, post: true, wantErr: order.ErrInvalidPostOnly},
+		{name: "post-only fok", kind: order.Limit, tif: order.FOK, post: true, wantErr: order.ErrInvalidPostOnly},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := order.CheckExecution(order.Request{Type: tt.kind, TimeInForce: tt.tif, ExpiresAt: tt.expires, PostOnly: tt.post}, now)
+			if (tt.wantErr == nil && err != nil) || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+)
+
+// ErrInvalidGroup reports linked orders whose terms do not fit together.
+var ErrInvalidGroup = errors.New("invalid order group")
+
+// GroupID identifies an order group. Like a client order ID it is ours: a
+// ULID generated before anything is placed, and the idempotency key of the
+// group's placement.
+type GroupID string
+
+// GroupKind is how a group's orders are linked.
+type GroupKind string
+
+// Group kinds.
+const (
+	// GroupOCO is a take-profit limit and a stop-loss working together:
+	// whichever executes first cancels the other.
+	GroupOCO GroupKind = "oco"
+	// GroupBracket is an entry whose exits, an OCO pair on the other side,
+	// are placed once the entry finishes with a fill.
+	GroupBracket GroupKind = "bracket"
+)
+
+// GroupStatus is where a group is in its life.
+type GroupStatus string
+
+// Group statuses. Completed and canceled are final.
+const (
+	// GroupPending is a bracket waiting for its entry to finish.
+	GroupPending GroupStatus = "pending"
+	// GroupActive is an OCO pair working.
+	GroupActive GroupStatus = "active"
+	// GroupCompleted is a group one of whose exits executed; the other
+	// was canceled.
+	GroupCompleted GroupStatus = "completed"
+	// GroupCanceled is a group that ended without an exit executing.
+	GroupCanceled GroupStatus = "canceled"
+)
+
+// Final reports whether the group's linkage has done its job.
+func (s GroupStatus) Final() bool {
+	return s == GroupCompleted || s == GroupCanceled
+}
+
+// Role is a leg's part in its group.
+type Role string
+
+// Leg roles. An OCO pair is a take-profit and a stop-loss; a bracket adds
+// the entry.
+const (
+	RoleEntry      Role = "entry"
+	RoleTakeProfit Role = "take_profit"
+	RoleStopLoss   Role = "stop_loss"
+)
+
+// Leg is one order of a group with the terms it is placed on. A bracket's
+// exits are placed for what the entry filled, not for Qty.
+type Leg struct {
+	Role          Role
+	ClientOrderID ClientOrderID
+	Side          Side
+	Type          Type
+	Price, Qty    decimal.Decimal
+	TriggerPrice  decimal.Decimal
+}
+
+// Group links orders that act on each other. Reason explains the last
+// status change.
+type Group struct {
+	ID                   GroupID
+	Kind                 GroupKind
+	Status               GroupStatus
+	BotID                string
+	Instrument           instrument.Instrument
+	Legs                 []Leg
+	Reason               string
+	CreatedAt, UpdatedAt time.Time
+}
+
+// Leg returns the group's leg in role.
+func (g Group) Leg(role Role) (Leg, bool) {
+	for _, leg := range g.Legs {
+		if leg.Role == role {
+			return leg, true
+		}
+	}
+	return Leg{}, false
+}
+
+// Request is the order that places leg. Legs are GTC: a group outlives
+// any expiry one of its orders could carry.
+func (g Group) Request(leg Leg) Request {
+	return Request{
+		ClientOrderID: leg.ClientOrderID,
+		BotID:         g.BotID,
+		Instrument:    g.Instrument,
+		Side:          leg.Side,
+		Type:          leg.Type,
+		Price:         leg.Price,
+		Qty:           leg.Qty,
+		TriggerPrice:  leg.TriggerPrice,
+		TimeInForce:   GTC,
+	}
+}
+
+// Initial is the legs placed with the group: a bracket's entry, or both
+// legs of an OCO pair.
+func (g Group) Initial() []Leg {
+	if g.Kind == GroupBracket {
+		entry, _ := g.Leg(RoleEntry)
+		return []Leg{entry}
+	}
+	return g.exits()
+}
+
+func (g Group) exits() []Leg {
+	var exits []Leg
+	for _, role := range []Role{RoleTakeProfit, RoleStopLoss} {
+		if leg, ok := g.Leg(role); ok {
+			exits = append(exits, leg)
+		}
+	}
+	return exits
+}
+
+// CheckGroup checks that g's legs make the group its kind says. The exits
+// are a take-profit limit and a stop-loss stop on the same side for the
+// same quantity, the take-profit on the profitable side of the trigger: a
+// sell takes profit above its stop, a buy below. A bracket's entry is a
+// limit or market order on the other side for that quantity, and a limit
+// entry is priced between its exits. Pure.
+func CheckGroup(g Group) error {
+	want := 2
+	switch g.Kind {
+	case GroupOCO:
+	case GroupBracket:
+		want = 3
+	default:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidGroup, g.Kind)
+	}
+	if len(g.Legs) != want {
+		return fmt.Errorf("%w: %s groups have %d legs, got %d", ErrInvalidGroup, g.Kind, want, len(g.Legs))
+	}
+	ids := map[ClientOrderID]bool{}
+	for _, leg := range g.Legs {
+		if ids[leg.ClientOrderID] {
+			return fmt.Errorf("%w: legs share the client order ID %q", ErrInvalidGroup, leg.ClientOrderID)
+		}
+		ids[leg.ClientOrderID] = true
+		if leg.Side != Buy && leg.Side != Sell {
+			return fmt.Errorf("%w: %s side %q", ErrInvalidGroup, leg.Role, leg.Side)
+		}
+		if !leg.Qty.IsPositive() {
+			return fmt.Errorf("%w: %s quantity must be positive", ErrInvalidGroup, leg.Role)
+		}
+	}
+	tp, ok := g.Leg(RoleTakeProfit)
+	if !ok || tp.Type != Limit || !tp.Price.IsPositive() || !tp.TriggerPrice.IsZero() {
+		return fmt.Errorf("%w: the take-profit must be a limit order with a positive price", ErrInvalidGroup)
+	}
+	sl, ok := g.Leg(RoleStopLoss)
+	if !ok || !sl.Type.Stop() {
+		return fmt.Errorf("%w: the stop-loss must be a stop order", ErrInvalidGroup)
+	}
+	if err := CheckStop(g.Request(sl)); err != nil {
+		return fmt.Errorf("%w: stop-loss: %w", ErrInvalidGroup, err)
+	}
+	if tp.Side != sl.Side || !tp.Qty.Equal(sl.Qty) {
+		return fmt.Errorf("%w: the exits must share a side and a quantity", ErrInvalidGroup)
+	}
+	if !beyond(tp.Side, tp.Price, sl.TriggerPrice) {
+		return fmt.Errorf("%w: a %s take-profit at %s is not on the profitable side of the trigger %s",
+			ErrInvalidGroup, tp.Side, tp.Price, sl.TriggerPrice)
+	}
+	if g.Kind == GroupOCO {
+		return nil
+	}
+	entry, ok := g.Leg(RoleEntry)
+	switch {
+	case !ok || (entry.Type != Limit && entry.Type != Market):
+		return fmt.Errorf("%w: the entry must be a limit or market order", ErrInvalidGroup)
+	case entry.Side == tp.Side:
+		return fmt.Errorf("%w: the exits must be on the other side of the entry", ErrInvalidGroup)
+	case !entry.Qty.Equal(tp.Qty):
+		return fmt.Errorf("%w: the exits must be for the entry quantity", ErrInvalidGroup)
+	case entry.Type == Limit && (!beyond(tp.Side, tp.Price, entry.Price) || !beyond(tp.Side, entry.Price, sl.TriggerPrice)):
+		return fmt.Errorf("%w: the entry price %s must lie between the stop-loss trigger and the take-profit",
+			ErrInvalidGroup, entry.Price)
+	case entry.Type == Market && !entry.Price.IsZero():
+		return fmt.Errorf("%w: a market entry takes no price", ErrInvalidGroup)
+	}
+	return nil
+}
+
+// beyond reports whether a is strictly past b in the direction an exit on
+// side profits from: above for a sell, below for a buy.
+func beyond(side Side, a, b decimal.Decimal) bool {
+	if side == Sell {
+		return a.GreaterThan(b)
+	}
+	return a.LessThan(b)
+}
+
+// GroupStep is what a group needs next: orders to place and to cancel,
+// then the status and reason to store once both succeeded.
+type GroupStep struct {
+	Place  []Request
+	Cancel []ClientOrderID
+	Status GroupStatus
+	Reason string
+}
+
+// Step decides a group's next step from the stored orders of its legs,
+// keyed by client order ID; a leg without an order was never placed. It
+// reads state rather than events, so running it again after a crash or a
+// lost event repeats nothing that already happened. Pure.
+//
+// A bracket waits for its entry to finish. An entry that filled nothing
+// cancels the group; one that filled places both exits for what it
+// filled, and the group goes active. An active group is completed by the
+// first exit to execute, a fill or a local stop firing, and the other exit
+// is canceled. An exit that ends without executing, or was never placed,
+// cancels the other and the group.
+func (g Group) Step(orders map[ClientOrderID]Record) GroupStep {
+	if g.Status.Final() {
+		return GroupStep{Status: g.Status, Reason: g.Reason}
+	}
+	if g.Status == GroupPending {
+		entry, _ := g.Leg(RoleEntry)
+		rec, ok := orders[entry.ClientOrderID]
+		switch {
+		case !ok:
+			return GroupStep{Status: GroupCanceled, Reason: "entry was never placed"}
+		case !rec.Status.Terminal():
+			return GroupStep{Status: GroupPending, Reason: g.Reason}
+		case !rec.FilledQty.IsPositive():
+			return GroupStep{Status: GroupCanceled, Reason: ended(RoleEntry, rec)}
+		}
+		step := GroupStep{Status: GroupActive, Reason: fmt.Sprintf("entry %s %s", rec.Status, rec.FilledQty)}
+		for _, exit := range g.exits() {
+			req := g.Request(exit)
+			req.Qty = rec.FilledQty
+			step.Place = append(step.Place, req)
+		}
+		return step
+	}
+
+	exits := g.exits()
+	for _, exit := range exits {
+		rec, ok := orders[exit.ClientOrderID]
+		if ok && (rec.FilledQty.IsPositive() || rec.Status == StatusTriggered) {
+			return GroupStep{
+				Cancel: cancelable(exits, exit, orders),
+				Status: GroupCompleted,
+				Reason: fmt.Sprintf("%s executed", exit.Role),
+			}
+		}
+	}
+	for _, exit := range exits {
+		rec, ok := orders[exit.ClientOrderID]
+		if !ok || rec.Status.Terminal() {
+			reason := fmt.Sprintf("%s was never placed", exit.Role)
+			if ok {
+				reason = ended(exit.Role, rec)
+			}
+			return GroupStep{Cancel: cancelable(exits, exit, orders), Status: GroupCanceled, Reason: reason}
+		}
+	}
+	return GroupStep{Status: GroupActive, Reason: g.Reason}
+}
+
+// cancelable is the legs other than done whose orders are still working.
+func cancelable(legs []Leg, done Leg, orders map[ClientOrderID]Record) []ClientOrderID {
+	var ids []ClientOrderID
+	for _, leg := range legs {
+		rec, ok := orders[leg.ClientOrderID]
+		if leg.Role != done.Role && ok && !rec.Status.Terminal() {
+			ids = append(ids, leg.ClientOrderID)
+		}
+	}
+	return ids
+}
+
+func ended(role Role, rec Record) string {
+	if rec.Reason != "" {
+		return fmt.Sprintf("%s %s: %s", role, rec.Status, rec.Reason)
+	}
+	return fmt.Sprintf("%s %s", role, rec.Status)
+}
@@ -0,0 +1,136 @@
+package order_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/order"
+)
+
+func d(s string) decimal.Decimal { return decimal.RequireFromString(s) }
+
+// bracket buys 2 at 100 with a take-profit at 110 and a stop at 95.
+func bracket() order.Group {
+	return order.Group{
+		ID: "G", Kind: order.GroupBracket, Status: order.GroupPending, BotID: "manual",
+		Legs: []order.Leg{
+			{Role: order.RoleEntry, ClientOrderID: "E", Side: order.Buy, Type: order.Limit, Price: d("100"), Qty: d("2")},
+			{Role: order.RoleTakeProfit, ClientOrderID: "TP", Side: order.Sell, Type: order.Limit, Price: d("110"), Qty: d("2")},
+			{Role: order.RoleStopLoss, ClientOrderID: "SL", Side: order.Sell, Type: order.StopMarket, TriggerPrice: d("95"), Qty: d("2")},
+		},
+	}
+}
+
+func oco() order.Group {
+	g := bracket()
+	g.Kind, g.Status, g.Legs = order.GroupOCO, order.GroupActive, g.Legs[1:]
+	return g
+}
+
+func TestCheckGroup(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		edit    func(*order.Group)
+		wantErr bool
+	}{
+		{name: "bracket", edit: func(*order.Group) {}},
+		{name: "oco", edit: func(g *order.Group) { *g = oco() }},
+		{name: "market entry", edit: func(g *order.Group) { g.Legs[0].Type, g.Legs[0].Price = order.Market, decimal.Zero }},
+		{name: "stop-limit stop-loss", edit: func(g *order.Group) { g.Legs[2].Type, g.Legs[2].Price = order.StopLimit, d("94") }},
+		{name: "short bracket", edit: func(g *order.Group) {
+			g.Legs[0].Side, g.Legs[1].Side, g.Legs[2].Side = order.Sell, order.Buy, order.Buy
+			g.Legs[1].Price, g.Legs[2].TriggerPrice = d("90"), d("105")
+		}},
+		{name: "unknown kind", edit: func(g *order.Group) { g.Kind = "trailing" }, wantErr: true},
+		{name: "oco with an entry", edit: func(g *order.Group) { g.Kind = order.GroupOCO }, wantErr: true},
+		{name: "shared client order ID", edit: func(g *order.Group) { g.Legs[2].ClientOrderID = "TP" }, wantErr: true},
+		{name: "take-profit below the stop", edit: func(g *order.Group) { *g = oco(); g.Legs[0].Price = d("90") }, wantErr: true},
+		{name: "stop-loss not a stop", edit: func(g *order.Group) { g.Legs[2].Type, g.Legs[2].Price = order.Limit, d("95") }, wantErr: true},
+		{name: "exits on the entry side", edit: func(g *order.Group) { g.Legs[0].Side = order.Sell }, wantErr: true},
+		{name: "exits for another quantity", edit: func(g *order.Group) { g.Legs[0].Qty = d("3") }, wantErr: true},
+		{name: "entry above the take-profit", edit: func(g *order.Group) { g.Legs[0].Price = d("111") }, wantErr: true},
+		{name: "entry below the stop", edit: func(g *order.Group) { g.Legs[0].Price = d("94") }, wantErr: true},
+		{name: "mismatched exit quantities", edit: func(g *order.Group) { *g = oco(); g.Legs[1].Qty = d("1") }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := bracket()
+			tt.edit(&g)
+			err := order.CheckGroup(g)
+			if tt.wantErr != errors.Is(err, order.ErrInvalidGroup) || (!tt.wantErr && err != nil) {
+				t.Fatalf("err = %v, wantErr = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func rec(id order.ClientOrderID, status order.Status, filled string) order.Record {
+	return order.Record{ClientOrderID: id, Status: status, FilledQty: d(filled)}
+}
+
+func TestGroupStep(t *testing.T) {
+	t.Parallel()
+	active := bracket()
+	active.Status = order.GroupActive
+	tests := []struct {
+		name       string
+		group      order.Group
+		orders     []order.Record
+		wantStatus order.GroupStatus
+		wantPlace  []order.ClientOrderID
+		wantCancel []order.ClientOrderID
+	}{
+		{name: "entry working", group: bracket(), orders: []order.Record{rec("E", order.StatusPartiallyFilled, "1")}, wantStatus: order.GroupPending},
+		{name: "entry never placed", group: bracket(), wantStatus: order.GroupCanceled},
+		{name: "entry canceled unfilled", group: bracket(), orders: []order.Record{rec("E", order.StatusCanceled, "0")}, wantStatus: order.GroupCanceled},
+		{name: "entry filled", group: bracket(), orders: []order.Record{rec("E", order.StatusFilled, "2")},
+			wantStatus: order.GroupActive, wantPlace: []order.ClientOrderID{"TP", "SL"}},
+		{name: "oco working", group: oco(), orders: []order.Record{rec("TP", order.StatusOpen, "0"), rec("SL", order.StatusUntriggered, "0")},
+			wantStatus: order.GroupActive},
+		{name: "take-profit fills", group: oco(), orders: []order.Record{rec("TP", order.StatusPartiallyFilled, "0.5"), rec("SL", order.StatusUntriggered, "0")},
+			wantStatus: order.GroupCompleted, wantCancel: []order.ClientOrderID{"SL"}},
+		{name: "stop-loss fires", group: active, orders: []order.Record{rec("E", order.StatusFilled, "2"), rec("TP", order.StatusOpen, "0"), rec("SL", order.StatusTriggered, "0")},
+			wantStatus: order.GroupCompleted, wantCancel: []order.ClientOrderID{"TP"}},
+		{name: "take-profit canceled", group: oco(), orders: []order.Record{rec("TP", order.StatusCanceled, "0"), rec("SL", order.StatusOpen, "0")},
+			wantStatus: order.GroupCanceled, wantCancel: []order.ClientOrderID{"SL"}},
+		{name: "stop-loss never placed", group: oco(), orders: []order.Record{rec("TP", order.StatusOpen, "0")},
+			wantStatus: order.GroupCanceled, wantCancel: []order.ClientOrderID{"TP"}},
+		{name: "both exits ended", group: oco(), orders: []order.Record{rec("TP", order.StatusFilled, "2"), rec("SL", order.StatusCanceled, "0")},
+			wantStatus: order.GroupCompleted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			orders := map[order.ClientOrderID]order.Record{}
+			for _, r := range tt.orders {
+				orders[r.ClientOrderID] = r
+			}
+			step := tt.group.Step(orders)
+			var placed []order.ClientOrderID
+			for _, req := range step.Place {
+				placed = append(placed, req.ClientOrderID)
+			}
+			if step.Status != tt.wantStatus || !slices.Equal(placed, tt.wantPlace) || !slices.Equal(step.Cancel, tt.wantCancel) {
+				t.Fatalf("step = %+v, placed %v", step, placed)
+			}
+		})
+	}
+}
+
+func TestGroupStepSizesExitsByTheEntryFill(t *testing.T) {
+	t.Parallel()
+	step := bracket().Step(map[order.ClientOrderID]order.Record{"E": rec("E", order.StatusCanceled, "1.5")})
+	for _, req := range step.Place {
+		if !req.Qty.Equal(d("1.5")) || req.Side != order.Sell || req.TimeInForce != order.GTC {
+			t.Fatalf("exit = %+v", req)
+		}
+	}
+	if len(step.Place) != 2 || step.Place[1].Type != order.StopMarket || !step.Place[1].TriggerPrice.Equal(d("95")) {
+		t.Fatalf("place = %+v", step.Place)
+	}
+}
@@ -0,0 +1,15 @@
+package order
+
+import (
+	"time"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+)
+
+// Halt is an engaged kill switch: no new orders on Venue, or on any venue
+// when Venue is empty.
+type Halt struct {
+	Venue     instrument.VenueID
+	Reason    string
+	EngagedAt time.Time
+}
@@ -11,22 +11,28 @@ import (
 // The state machine is specified in docs/specs/manual-trading.md. Statuses have a
 // rank used as a monotonic guard against stale or reordered venue events;
 // all terminal statuses share the top rank because none can follow another.
+// An armed stop ranks between pending and open: a venue stop that fires
+// advances to whatever it then works as, and a late report of it armed
+// drops as stale. A local stop that fires ends as triggered, which is
+// terminal like the others.
 func rank(s Status) (int, error) {
 	switch s {
 	case StatusPending:
 		return 0, nil
-	case StatusOpen:
+	case StatusUntriggered:
 		return 1, nil
-	case StatusPartiallyFilled:
+	case StatusOpen:
 		return 2, nil
-	case StatusFilled, StatusCanceled, StatusRejected, StatusExpired:
+	case StatusPartiallyFilled:
 		return 3, nil
+	case StatusFilled, StatusCanceled, StatusRejected, StatusExpired, StatusTriggered:
+		return 4, nil
 	default:
 		return 0, fmt.Errorf("order: unknown status %q", s)
 	}
 }
 
-const terminalRank = 3
+const terminalRank = 4
 
 // Terminal reports whether s admits no further status changes.
 func (s Status) Terminal() bool {
@@ -11,16 +11,19 @@ import (
 
 var propertyStatuses = []order.Status{
 	order.StatusPending,
+	order.StatusUntriggered,
 	order.StatusOpen,
 	order.StatusPartiallyFilled,
 	order.StatusFilled,
 	order.StatusCanceled,
 	order.StatusRejected,
 	order.StatusExpired,
+	order.StatusTriggered,
 }
 
 var nonTerminalStatuses = []order.Status{
 	order.StatusPending,
+	order.StatusUntriggered,
 	order.StatusOpen,
 	order.StatusPartiallyFilled,
 }
@@ -30,6 +33,7 @@ var terminalStatuses = []order.Status{
 	order.StatusCanceled,
 	order.StatusRejected,
 	order.StatusExpired,
+	order.StatusTriggered,
 }
 
 var filledQtyGen = rapid.Map(rapid.IntRange(0, 500), func(n int) decimal.Decimal {
@@ -86,12 +90,14 @@ func specRank(status order.Status) int {
 	switch status {
 	case order.StatusPending:
 		return 0
-	case order.StatusOpen:
+	case order.StatusUntriggered:
 		return 1
-	case order.StatusPartiallyFilled:
+	case order.StatusOpen:
 		return 2
-	case order.StatusFilled, order.StatusCanceled, order.StatusRejected, order.StatusExpired:
+	case order.StatusPartiallyFilled:
 		return 3
+	case order.StatusFilled, order.StatusCanceled, order.StatusRejected, order.StatusExpired, order.StatusTriggered:
+		return 4
 	default:
 		panic("invalid generated status")
 	}
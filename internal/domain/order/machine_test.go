@@ -11,12 +11,14 @@ import (
 
 var allStatuses = []order.Status{
 	order.StatusPending,
+	order.StatusUntriggered,
 	order.StatusOpen,
 	order.StatusPartiallyFilled,
 	order.StatusFilled,
 	order.StatusCanceled,
 	order.StatusRejected,
 	order.StatusExpired,
+	order.StatusTriggered,
 }
 
 // applies encodes the spec's transition table (docs/specs/manual-trading.md) as
@@ -25,34 +27,47 @@ var allStatuses = []order.Status{
 // per pair, not derived, so the test is independent of the implementation.
 var applies = map[order.Status]map[order.Status]bool{
 	order.StatusPending: {
+		order.StatusUntriggered: true,
+		order.StatusOpen:        true, order.StatusPartiallyFilled: true,
+		order.StatusFilled: true, order.StatusCanceled: true,
+		order.StatusRejected: true, order.StatusExpired: true,
+		order.StatusTriggered: true,
+	},
+	order.StatusUntriggered: {
 		order.StatusOpen: true, order.StatusPartiallyFilled: true,
 		order.StatusFilled: true, order.StatusCanceled: true,
 		order.StatusRejected: true, order.StatusExpired: true,
+		order.StatusTriggered: true,
 	},
 	order.StatusOpen: {
 		order.StatusPartiallyFilled: true,
 		order.StatusFilled:          true, order.StatusCanceled: true,
 		order.StatusRejected: true, order.StatusExpired: true,
+		order.StatusTriggered: true,
 	},
 	order.StatusPartiallyFilled: {
 		order.StatusPartiallyFilled: true, // only with a new cumulative fill
 		order.StatusFilled:          true, order.StatusCanceled: true,
 		order.StatusRejected: true, order.StatusExpired: true,
+		order.StatusTriggered: true,
 	},
-	order.StatusFilled:   {},
-	order.StatusCanceled: {},
-	order.StatusRejected: {},
-	order.StatusExpired:  {},
+	order.StatusFilled:    {},
+	order.StatusCanceled:  {},
+	order.StatusRejected:  {},
+	order.StatusExpired:   {},
+	order.StatusTriggered: {},
 }
 
 var testRank = map[order.Status]int{
 	order.StatusPending:         0,
-	order.StatusOpen:            1,
-	order.StatusPartiallyFilled: 2,
-	order.StatusFilled:          3,
-	order.StatusCanceled:        3,
-	order.StatusRejected:        3,
-	order.StatusExpired:         3,
+	order.StatusUntriggered:     1,
+	order.StatusOpen:            2,
+	order.StatusPartiallyFilled: 3,
+	order.StatusFilled:          4,
+	order.StatusCanceled:        4,
+	order.StatusRejected:        4,
+	order.StatusExpired:         4,
+	order.StatusTriggered:       4,
 }
 
 func wantDecision(stored, ev order.Status, delta int) order.Decision {
@@ -168,6 +183,7 @@ func TestTerminal(t *testing.T) {
 	terminal := map[order.Status]bool{
 		order.StatusFilled: true, order.StatusCanceled: true,
 		order.StatusRejected: true, order.StatusExpired: true,
+		order.StatusTriggered: true,
 	}
 	for _, s := range allStatuses {
 		if got := s.Terminal(); got != terminal[s] {
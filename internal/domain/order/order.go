@@ -15,6 +15,10 @@ import (
 // idempotency key across placement retries and stream deduplication.
 type ClientOrderID string
 
+// ParentID identifies the execution-algorithm parent a child order was
+// sliced from. Like a client order ID it is a ULID of ours.
+type ParentID string
+
 // Side of an order.
 type Side string
 
@@ -27,11 +31,15 @@ const (
 // Type of an order.
 type Type string
 
-// Order types. Algo types (iceberg, pegged, ...) are compositions in the
-// execution layer, not venue order types.
+// Order types. Stops rest untriggered until the market trades through
+// their trigger price, then work as a market or limit order. Algo types
+// (iceberg, pegged, ...) are compositions in the execution layer, not
+// venue order types.
 const (
-	Limit  Type = "limit"
-	Market Type = "market"
+	Limit      Type = "limit"
+	Market     Type = "market"
+	StopMarket Type = "stop_market"
+	StopLimit  Type = "stop_limit"
 )
 
 // Status of an order at a venue.
@@ -39,13 +47,19 @@ type Status string
 
 // Order statuses (the state machine constrains transitions).
 const (
-	StatusPending         Status = "pending"
+	StatusPending Status = "pending"
+	// StatusUntriggered is a stop waiting for its trigger, at the venue or
+	// in the local trigger engine.
+	StatusUntriggered     Status = "untriggered"
 	StatusOpen            Status = "open"
 	StatusPartiallyFilled Status = "partially_filled"
 	StatusFilled          Status = "filled"
 	StatusCanceled        Status = "canceled"
 	StatusRejected        Status = "rejected"
 	StatusExpired         Status = "expired"
+	// StatusTriggered is a local stop that fired. It is final: the child
+	// order it placed carries on under its own ID.
+	StatusTriggered Status = "triggered"
 )
 
 // Request is an order to submit.
@@ -57,26 +71,53 @@ type Request struct {
 	Type          Type
 	Price         decimal.Decimal // zero for market orders
 	Qty           decimal.Decimal
+	// TriggerPrice is the trade price that fires a stop; zero otherwise.
+	TriggerPrice decimal.Decimal
+	// TimeInForce is empty for GTC. ExpiresAt is set only for GTD.
+	TimeInForce TimeInForce
+	ExpiresAt   time.Time
+	// PostOnly makes the venue reject the order rather than let it take
+	// liquidity on arrival.
+	PostOnly bool
+	// Replaces is the order this request replaces; empty for a new order.
+	// It equals ClientOrderID for an amend, which keeps the order's IDs.
+	Replaces ClientOrderID
+	// ParentID is the execution parent the order is a child of; empty for
+	// an order placed on its own.
+	ParentID ParentID
 }
 
 // Record is persisted order state; zero AvgFillPrice, VenueOrderID, and CancelRequestedAt mean unknown.
+// ExpiresAt is zero unless TimeInForce is GTD. Child is set only on a stop
+// the local trigger engine holds: the order it places when it fires.
+// ParentID is set only on an execution child. Rollup marks an execution
+// parent listed as an order: its ClientOrderID is the parent's ID and its
+// fills are its children's added up.
 type Record struct {
-	ClientOrderID                           ClientOrderID
+	ClientOrderID, Child                    ClientOrderID
+	ParentID                                ParentID
+	Rollup                                  bool
 	BotID, VenueOrderID, Reason             string
 	Instrument                              instrument.Instrument
 	Side                                    Side
 	Type                                    Type
 	Price, Qty, FilledQty, AvgFillPrice     decimal.Decimal
+	TriggerPrice                            decimal.Decimal
+	TimeInForce                             TimeInForce
+	ExpiresAt                               time.Time
+	PostOnly                                bool
 	Status                                  Status
 	CancelRequestedAt, CreatedAt, UpdatedAt time.Time
 }
 
-// Query selects one keyset-paginated order page.
+// Query selects one keyset-paginated order page. Parents adds each
+// execution parent's rollup record to the page beside its children.
 type Query struct {
 	Venue, BotID, CursorID *string
 	Statuses               []string
 	Limit                  int32
 	CursorCreatedAt        *time.Time
+	Parents                bool
 }
 
 // Ref identifies an order at a venue.
@@ -0,0 +1,30 @@
+package order
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ReplaceMode says how a replacement reached the venue.
+type ReplaceMode string
+
+// Replace modes.
+const (
+	// ReplaceAmend: the venue changed the order in place. The order keeps
+	// its client and venue IDs and its fills.
+	ReplaceAmend ReplaceMode = "amend"
+	// ReplaceCancelPlace: the old order was canceled and, once terminal, a
+	// new order was placed for the quantity it left unfilled.
+	ReplaceCancelPlace ReplaceMode = "cancel_replace"
+)
+
+// Replacement links an order to the one that replaced it. Old and New are
+// equal for ReplaceAmend. Price and Qty are the terms after the
+// replacement.
+type Replacement struct {
+	Old, New    ClientOrderID
+	Mode        ReplaceMode
+	Price, Qty  decimal.Decimal
+	RequestedAt time.Time
+}
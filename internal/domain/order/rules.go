@@ -0,0 +1,118 @@
+package order
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// Rule names one venue trading constraint an order can break.
+type Rule string
+
+// Rules checked before submission, mirroring instrument.Rules.
+const (
+	RuleTickSize    Rule = "tick_size"
+	RuleStepSize    Rule = "step_size"
+	RuleMinQty      Rule = "min_qty"
+	RuleMinNotional Rule = "min_notional"
+)
+
+// Catalog rules: the instrument must be known locally and trading.
+const (
+	RuleUnknownInstrument Rule = "unknown_instrument"
+	RuleInstrumentStatus  Rule = "instrument_status"
+)
+
+// RulePolicy says what to do with a price or quantity off its increment.
+type RulePolicy string
+
+// Rule policies. Minimums are never rounded up: that would trade more than
+// was asked for, so a request below one is always rejected.
+const (
+	// RuleReject refuses an order off its increments.
+	RuleReject RulePolicy = "reject"
+	// RuleRound snaps quantity down to the step, and price to the tick
+	// away from the market: buys down, sells up. A stop's trigger rounds
+	// away too, buy stops up and sell stops down. Rounding never makes an
+	// order bigger or more aggressive than requested.
+	RuleRound RulePolicy = "round"
+)
+
+// RuleViolation is an order the venue would refuse for its instrument
+// rules. Detail is safe to show to the operator.
+type RuleViolation struct {
+	Rule   Rule
+	Detail string
+}
+
+func (v *RuleViolation) Error() string {
+	return fmt.Sprintf("instrument rule %s: %s", v.Rule, v.Detail)
+}
+
+func violate(rule Rule, format string, args ...any) error {
+	return &RuleViolation{Rule: rule, Detail: fmt.Sprintf(format, args...)}
+}
+
+// Conform checks req against its instrument's rules, rounding under
+// RuleRound, and returns the request to submit or a *RuleViolation. Zero
+// rules are not enforced. Market orders carry no price, so only their
+// quantity is checked; their notional is the pre-trade checks' business.
+// A stop-market's notional is taken at its trigger. Pure.
+func Conform(req Request, policy RulePolicy) (Request, error) {
+	rules := req.Instrument.Rules
+	if step := rules.QtyIncrement; step.IsPositive() && !onIncrement(req.Qty, step) {
+		if policy != RuleRound {
+			return req, violate(RuleStepSize, "qty %s is not a multiple of %s", req.Qty, step)
+		}
+		req.Qty = req.Qty.Div(step).Floor().Mul(step)
+	}
+	if !req.Qty.IsPositive() || req.Qty.LessThan(rules.MinQty) {
+		return req, violate(RuleMinQty, "qty %s is below the minimum %s", req.Qty, rules.MinQty)
+	}
+	var err error
+	if req.Type.Stop() {
+		if req.TriggerPrice, err = onTick("trigger price", req.TriggerPrice, rules.PriceIncrement, req.Side == Buy, policy); err != nil {
+			return req, err
+		}
+	}
+	price := req.TriggerPrice
+	switch req.Type {
+	case Limit, StopLimit:
+		if req.Price, err = onTick("price", req.Price, rules.PriceIncrement, req.Side == Sell, policy); err != nil {
+			return req, err
+		}
+		price = req.Price
+	case StopMarket:
+	default:
+		return req, nil
+	}
+	if notional := req.Qty.Mul(price); notional.LessThan(rules.MinNotional) {
+		return req, violate(RuleMinNotional, "notional %s is below the minimum %s %s", notional, rules.MinNotional, req.Instrument.Quote)
+	}
+	return req, nil
+}
+
+// onTick checks a price against the tick, rounding it up or down under
+// RuleRound. what names the price in the violation.
+func onTick(what string, v, tick decimal.Decimal, up bool, policy RulePolicy) (decimal.Decimal, error) {
+	if !tick.IsPositive() || onIncrement(v, tick) {
+		return v, nil
+	}
+	if policy != RuleRound {
+		return v, violate(RuleTickSize, "%s %s is not a multiple of %s", what, v, tick)
+	}
+	ticks := v.Div(tick)
+	if up {
+		ticks = ticks.Ceil()
+	} else {
+		ticks = ticks.Floor()
+	}
+	if v = ticks.Mul(tick); !v.IsPositive() {
+		return v, violate(RuleTickSize, "%s rounds to zero at tick %s", what, tick)
+	}
+	return v, nil
+}
+
+func onIncrement(v, increment decimal.Decimal) bool {
+	return v.Mod(increment).IsZero()
+}
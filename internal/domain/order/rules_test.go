@@ -0,0 +1,80 @@
+package order_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/order"
+)
+
+func TestConform(t *testing.T) {
+	t.Parallel()
+	rules := instrument.Rules{
+		PriceIncrement: decimal.RequireFromString("0.5"),
+		QtyIncrement:   decimal.RequireFromString("0.001"),
+		MinQty:         decimal.RequireFromString("0.002"),
+		MinNotional:    decimal.NewFromInt(5),
+	}
+	request := func(side order.Side, kind order.Type, price, qty string) order.Request {
+		req := order.Request{
+			Instrument: instrument.Instrument{Venue: "bybit", Type: instrument.TypeSpot, Base: "BTC", Quote: "USDT", Rules: rules},
+			Side:       side, Type: kind, Qty: decimal.RequireFromString(qty),
+		}
+		if price != "" {
+			req.Price = decimal.RequireFromString(price)
+		}
+		return req
+	}
+	stop := func(side order.Side, kind order.Type, trigger, price, qty string) order.Request {
+		req := request(side, kind, price, qty)
+		req.TriggerPrice = decimal.RequireFromString(trigger)
+		return req
+	}
+	tests := []struct {
+		name                          string
+		req                           order.Request
+		policy                        order.RulePolicy
+		wantRule                      order.Rule
+		wantTrigger, wantPrice, wantQ string
+	}{
+		{name: "on increments passes", req: request(order.Buy, order.Limit, "100.5", "0.05"), policy: order.RuleReject, wantPrice: "100.5", wantQ: "0.05"},
+		{name: "off step rejected", req: request(order.Buy, order.Limit, "100", "0.0505"), policy: order.RuleReject, wantRule: order.RuleStepSize},
+		{name: "off tick rejected", req: request(order.Buy, order.Limit, "100.2", "0.05"), policy: order.RuleReject, wantRule: order.RuleTickSize},
+		{name: "buy rounds down", req: request(order.Buy, order.Limit, "100.7", "0.0509"), policy: order.RuleRound, wantPrice: "100.5", wantQ: "0.05"},
+		{name: "sell rounds price up", req: request(order.Sell, order.Limit, "100.2", "0.0509"), policy: order.RuleRound, wantPrice: "100.5", wantQ: "0.05"},
+		{name: "rounding below min qty rejected", req: request(order.Buy, order.Limit, "100", "0.0019"), policy: order.RuleRound, wantRule: order.RuleMinQty},
+		{name: "below min notional rejected", req: request(order.Buy, order.Limit, "100", "0.004"), policy: order.RuleRound, wantRule: order.RuleMinNotional},
+		{name: "buy price rounding to zero rejected", req: request(order.Buy, order.Limit, "0.3", "100"), policy: order.RuleRound, wantRule: order.RuleTickSize},
+		{name: "market checks qty only", req: request(order.Buy, order.Market, "", "0.002"), policy: order.RuleReject, wantPrice: "0", wantQ: "0.002"},
+		{name: "stop-limit rounds both prices away", req: stop(order.Buy, order.StopLimit, "101.2", "100.7", "0.05"), policy: order.RuleRound, wantTrigger: "101.5", wantPrice: "100.5", wantQ: "0.05"},
+		{name: "sell stop trigger rounds down", req: stop(order.Sell, order.StopMarket, "99.7", "", "0.06"), policy: order.RuleRound, wantTrigger: "99.5", wantPrice: "0", wantQ: "0.06"},
+		{name: "off-tick trigger rejected", req: stop(order.Sell, order.StopMarket, "99.7", "", "0.05"), policy: order.RuleReject, wantRule: order.RuleTickSize},
+		{name: "stop-market notional at the trigger", req: stop(order.Sell, order.StopMarket, "99.5", "", "0.04"), policy: order.RuleReject, wantRule: order.RuleMinNotional},
+		{name: "no rules pass anything", req: order.Request{Side: order.Buy, Type: order.Limit, Price: decimal.RequireFromString("0.123"), Qty: decimal.RequireFromString("0.0001")}, policy: order.RuleReject, wantPrice: "0.123", wantQ: "0.0001"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := order.Conform(tt.req, tt.policy)
+			if tt.wantRule != "" {
+				var violation *order.RuleViolation
+				if !errors.As(err, &violation) || violation.Rule != tt.wantRule {
+					t.Fatalf("err = %v, want %s violation", err, tt.wantRule)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Price.Equal(decimal.RequireFromString(tt.wantPrice)) || !got.Qty.Equal(decimal.RequireFromString(tt.wantQ)) {
+				t.Fatalf("conformed to %s @ %s, want %s @ %s", got.Qty, got.Price, tt.wantQ, tt.wantPrice)
+			}
+			if tt.wantTrigger != "" && !got.TriggerPrice.Equal(decimal.RequireFromString(tt.wantTrigger)) {
+				t.Fatalf("trigger = %s, want %s", got.TriggerPrice, tt.wantTrigger)
+			}
+		})
+	}
+}
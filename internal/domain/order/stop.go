@@ -0,0 +1,70 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrInvalidStop reports a trigger price that does not fit the order.
+var ErrInvalidStop = errors.New("invalid stop order")
+
+// Stop reports whether t rests until its trigger price trades.
+func (t Type) Stop() bool {
+	return t == StopMarket || t == StopLimit
+}
+
+// CheckStop checks req's trigger. A stop needs a positive trigger price,
+// a stop-limit a positive limit price too, and other orders take no
+// trigger. Stops are GTC: whoever holds one until it fires has no expiry
+// to honor, and the order it becomes inherits none. Pure.
+func CheckStop(req Request) error {
+	switch {
+	case !req.Type.Stop() && !req.TriggerPrice.IsZero():
+		return fmt.Errorf("%w: only stop orders take a trigger price", ErrInvalidStop)
+	case !req.Type.Stop():
+		return nil
+	case !req.TriggerPrice.IsPositive():
+		return fmt.Errorf("%w: the trigger price must be positive", ErrInvalidStop)
+	case req.Type == StopLimit && !req.Price.IsPositive():
+		return fmt.Errorf("%w: a stop-limit needs a positive limit price", ErrInvalidStop)
+	case req.TimeInForce != "" && req.TimeInForce != GTC:
+		return fmt.Errorf("%w: stops are gtc, not %s", ErrInvalidStop, req.TimeInForce)
+	}
+	return nil
+}
+
+// Crossed reports whether a trade at price fires a stop on side: a buy
+// stop fires at or above its trigger, a sell stop at or below. Pure.
+func Crossed(side Side, trigger, price decimal.Decimal) bool {
+	if side == Buy {
+		return price.GreaterThanOrEqual(trigger)
+	}
+	return price.LessThanOrEqual(trigger)
+}
+
+// HeldLocally reports whether the record is a stop the local trigger
+// engine holds, which no venue knows until it fires.
+func (r Record) HeldLocally() bool {
+	return r.Child != ""
+}
+
+// ChildOf is the order a local stop places when it fires: a market or
+// limit order for the stop's side and quantity, under the child ID fixed
+// when the stop was stored. Pure.
+func ChildOf(stop Record) Request {
+	child := Request{
+		ClientOrderID: stop.Child,
+		BotID:         stop.BotID,
+		Instrument:    stop.Instrument,
+		Side:          stop.Side,
+		Type:          Market,
+		Qty:           stop.Qty,
+		TimeInForce:   GTC,
+	}
+	if stop.Type == StopLimit {
+		child.Type, child.Price = Limit, stop.Price
+	}
+	return child
+}
@@ -0,0 +1,78 @@
+package order_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/order"
+)
+
+func TestCheckStop(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		kind           order.Type
+		trigger, price string
+		tif            order.TimeInForce
+		wantErr        bool
+	}{
+		{name: "limit without trigger", kind: order.Limit, trigger: "0", price: "100"},
+		{name: "stop-market", kind: order.StopMarket, trigger: "95", price: "0"},
+		{name: "stop-limit gtc", kind: order.StopLimit, trigger: "95", price: "94", tif: order.GTC},
+		{name: "limit with trigger", kind: order.Limit, trigger: "95", price: "100", wantErr: true},
+		{name: "stop without trigger", kind: order.StopMarket, trigger: "0", price: "0", wantErr: true},
+		{name: "stop-limit without price", kind: order.StopLimit, trigger: "95", price: "0", wantErr: true},
+		{name: "stop ioc", kind: order.StopMarket, trigger: "95", price: "0", tif: order.IOC, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := order.CheckStop(order.Request{
+				Type: tt.kind, TimeInForce: tt.tif,
+				TriggerPrice: decimal.RequireFromString(tt.trigger), Price: decimal.RequireFromString(tt.price),
+			})
+			if tt.wantErr != errors.Is(err, order.ErrInvalidStop) || (!tt.wantErr && err != nil) {
+				t.Fatalf("err = %v, wantErr = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCrossed(t *testing.T) {
+	t.Parallel()
+	trigger := decimal.NewFromInt(100)
+	tests := []struct {
+		side  order.Side
+		price int64
+		want  bool
+	}{
+		{order.Buy, 99, false}, {order.Buy, 100, true}, {order.Buy, 101, true},
+		{order.Sell, 101, false}, {order.Sell, 100, true}, {order.Sell, 99, true},
+	}
+	for _, tt := range tests {
+		if got := order.Crossed(tt.side, trigger, decimal.NewFromInt(tt.price)); got != tt.want {
+			t.Errorf("Crossed(%s, 100, %d) = %v, want %v", tt.side, tt.price, got, tt.want)
+		}
+	}
+}
+
+func TestChildOf(t *testing.T) {
+	t.Parallel()
+	stop := order.Record{
+		ClientOrderID: "STOP", Child: "CHILD", BotID: "manual",
+		Side: order.Sell, Type: order.StopLimit,
+		Price: decimal.NewFromInt(94), Qty: decimal.NewFromInt(2), TriggerPrice: decimal.NewFromInt(95),
+		Status: order.StatusUntriggered,
+	}
+	child := order.ChildOf(stop)
+	if child.ClientOrderID != "CHILD" || child.Type != order.Limit || !child.Price.Equal(stop.Price) ||
+		!child.Qty.Equal(stop.Qty) || child.Side != order.Sell || !child.TriggerPrice.IsZero() {
+		t.Fatalf("stop-limit child = %+v", child)
+	}
+	stop.Type, stop.Price = order.StopMarket, decimal.Zero
+	if child := order.ChildOf(stop); child.Type != order.Market || !child.Price.IsZero() {
+		t.Fatalf("stop-market child = %+v", child)
+	}
+}
@@ -0,0 +1,53 @@
+// Package sink models delivering outbox events to systems outside the
+// daemon: webhooks, HTTP receivers, files. A sink receives each event it
+// subscribes to at least once, in the order the relay published them, as
+// the event's public JSON; an event it cannot take after every attempt is
+// kept as a dead letter until an operator retries it.
+package sink
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ErrRejected marks a delivery the receiver refused for good, such as an
+// HTTP 400: sending the same body again cannot succeed, so it is
+// dead-lettered without spending the remaining attempts.
+var ErrRejected = errors.New("rejected by the receiver")
+
+// Delivery is one event as a sink receives it.
+type Delivery struct {
+	// OutboxID is the event's outbox row, stable across retries and
+	// redeliveries, so receivers dedupe by it.
+	OutboxID int64
+	Subject  string
+	At       time.Time
+	// Body is the event's JSON, the same encoding the event stream uses.
+	Body []byte
+}
+
+// MessageID is the delivery's identity on the wire, the same one the NATS
+// bus deduplicates the event by.
+func (d Delivery) MessageID() string {
+	return "outbox-" + strconv.FormatInt(d.OutboxID, 10)
+}
+
+// DeadLetter is a delivery a sink gave up on, kept until it is retried
+// successfully.
+type DeadLetter struct {
+	ID        int64
+	Sink      string
+	Delivery  Delivery
+	Attempts  int
+	LastError string
+	FailedAt  time.Time
+}
+
+// DeadLetterQuery selects dead letters: one sink's or, with Sink empty,
+// every sink's, restricted to IDs when any are given.
+type DeadLetterQuery struct {
+	Sink  string
+	IDs   []int64
+	Limit int
+}
@@ -18,9 +18,19 @@ const (
 	SubjectReconcileOrphan = "reconcile.orphan"
 )
 
+// SubjectTickerUpdated is a bus hint carrying a marketdata.Ticker after the
+// observation reached the series store.
+const SubjectTickerUpdated = "ticker.updated"
+
+// SubjectInstrumentChanged is outbox-only: the catalog change and its
+// history row commit with the event.
+const SubjectInstrumentChanged = "instrument.changed"
+
 // OrderUpdatedPayload is the outbox payload for SubjectOrderUpdated.
 type OrderUpdatedPayload struct {
 	ClientOrderID order.ClientOrderID `json:"client_order_id"`
+	ParentID      order.ParentID      `json:"parent_id,omitempty"`
+	BotID         string              `json:"bot_id,omitempty"`
 	Venue         instrument.VenueID  `json:"venue"`
 	Base          money.Currency      `json:"base"`
 	Quote         money.Currency      `json:"quote"`
@@ -33,6 +43,8 @@ type OrderUpdatedPayload struct {
 // OrderFilledPayload is the outbox payload for SubjectOrderFilled; Qty is a delta, not a cumulative value.
 type OrderFilledPayload struct {
 	ClientOrderID order.ClientOrderID `json:"client_order_id"`
+	ParentID      order.ParentID      `json:"parent_id,omitempty"`
+	BotID         string              `json:"bot_id,omitempty"`
 	Venue         instrument.VenueID  `json:"venue"`
 	Base          money.Currency      `json:"base"`
 	Quote         money.Currency      `json:"quote"`
@@ -54,10 +66,30 @@ type ReconcileOrphanPayload struct {
 	Base, Quote   string
 }
 
-// OutboxMessage is one unpublished transactional-outbox row.
+// InstrumentChangedPayload is the outbox payload for
+// SubjectInstrumentChanged; it carries the instrument after the change.
+type InstrumentChangedPayload struct {
+	Venue          instrument.VenueID    `json:"venue"`
+	Type           instrument.Type       `json:"type"`
+	Base           money.Currency        `json:"base"`
+	Quote          money.Currency        `json:"quote"`
+	VenueSymbol    string                `json:"venue_symbol"`
+	Status         instrument.Status     `json:"status"`
+	Change         instrument.ChangeKind `json:"change"`
+	PriceIncrement decimal.Decimal       `json:"price_increment"`
+	QtyIncrement   decimal.Decimal       `json:"qty_increment"`
+	MinQty         decimal.Decimal       `json:"min_qty"`
+	MinNotional    decimal.Decimal       `json:"min_notional"`
+	At             time.Time             `json:"at"`
+}
+
+// OutboxMessage is one transactional-outbox row.
 type OutboxMessage struct {
 	ID        int64
 	Subject   string
 	Payload   []byte // jsonb
 	CreatedAt time.Time
+	// PublishSeq orders rows by when the relay published them; 0 until
+	// it has.
+	PublishSeq int64
 }
@@ -15,12 +15,12 @@ func TestOrderPayloadJSONGoldenRoundTrips(t *testing.T) {
 		{
 			"updated",
 			&OrderUpdatedPayload{},
-			`{"client_order_id":"cid-1","venue":"coinbase","base":"BTC","quote":"USD","status":"partially_filled","filled_qty":"0.4","source":"stream","at":"2026-07-17T12:34:56Z"}`,
+			`{"client_order_id":"cid-1","bot_id":"grid-1","venue":"coinbase","base":"BTC","quote":"USD","status":"partially_filled","filled_qty":"0.4","source":"stream","at":"2026-07-17T12:34:56Z"}`,
 		},
 		{
 			"filled",
 			&OrderFilledPayload{},
-			`{"client_order_id":"cid-1","venue":"coinbase","base":"BTC","quote":"USD","status":"filled","filled_qty":"1","qty":"0.6","price":"50000.25","fee":"0.001","fee_currency":"USD","venue_fill_id":"fill-1","at":"2026-07-17T12:34:56Z"}`,
+			`{"client_order_id":"cid-1","bot_id":"manual","venue":"coinbase","base":"BTC","quote":"USD","status":"filled","filled_qty":"1","qty":"0.6","price":"50000.25","fee":"0.001","fee_currency":"USD","venue_fill_id":"fill-1","at":"2026-07-17T12:34:56Z"}`,
 		},
 		{
 			"filled optional fields omitted",
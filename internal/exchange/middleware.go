@@ -96,6 +96,33 @@ func (b *broken) Balances(ctx context.Context, acct account.Type) ([]account.Bal
 	return v.([]account.Balance), nil
 }
 
+// RecentTrades forwards to an adapter that reads public trades, through
+// the read path's limiter.
+func (r *rateLimited) RecentTrades(ctx context.Context, inst instrument.Instrument) ([]marketdata.Trade, error) {
+	op, ok := r.ex.(ports.TradeReader)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ports.ErrTradesUnsupported, r.ex.ID())
+	}
+	if err := r.lim.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("%w: %w", errLimiterWait, err)
+	}
+	return op.RecentTrades(ctx, inst)
+}
+
+// RecentTrades forwards to an adapter that reads public trades, through
+// the breaker.
+func (b *broken) RecentTrades(ctx context.Context, inst instrument.Instrument) ([]marketdata.Trade, error) {
+	op, ok := b.ex.(ports.TradeReader)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ports.ErrTradesUnsupported, b.ex.ID())
+	}
+	v, err := b.cb.Execute(func() (any, error) { return op.RecentTrades(ctx, inst) })
+	if err != nil {
+		return nil, err
+	}
+	return v.([]marketdata.Trade), nil
+}
+
 // Trading forwarding. The decorators pass order calls through the same
 // limiter and breaker as the read path: a failing venue is failing as a
 // venue. Adapters that cannot trade surface ports.ErrTradingUnsupported at
@@ -145,6 +172,27 @@ func (r *rateLimited) GetOrder(ctx context.Context, ref order.Ref) (order.Snapsh
 	return op.GetOrder(ctx, ref)
 }
 
+func (r *rateLimited) AmendOrder(ctx context.Context, ref order.Ref, req order.Request) (order.Ack, error) {
+	op, ok := r.ex.(ports.OrderAmender)
+	if !ok {
+		return order.Ack{}, fmt.Errorf("%w: %s", ports.ErrAmendUnsupported, r.ex.ID())
+	}
+	if err := r.lim.Wait(ctx); err != nil {
+		return order.Ack{}, fmt.Errorf("%w: %w", errLimiterWait, err)
+	}
+	return op.AmendOrder(ctx, ref, req)
+}
+
+func (r *rateLimited) NativeStops() bool {
+	op, ok := r.ex.(ports.StopPlacer)
+	return ok && op.NativeStops()
+}
+
+func (r *rateLimited) HonorsPostOnly() bool {
+	op, ok := r.ex.(ports.PostOnlyPlacer)
+	return ok && op.HonorsPostOnly()
+}
+
 func (b *broken) PlaceOrder(ctx context.Context, req order.Request) (order.Ack, error) {
 	op, ok := b.ex.(ports.OrderPlacer)
 	if !ok {
@@ -189,3 +237,25 @@ func (b *broken) GetOrder(ctx context.Context, ref order.Ref) (order.Snapshot, e
 	}
 	return v.(order.Snapshot), nil
 }
+
+func (b *broken) AmendOrder(ctx context.Context, ref order.Ref, req order.Request) (order.Ack, error) {
+	op, ok := b.ex.(ports.OrderAmender)
+	if !ok {
+		return order.Ack{}, fmt.Errorf("%w: %s", ports.ErrAmendUnsupported, b.ex.ID())
+	}
+	v, err := b.cb.Execute(func() (any, error) { return op.AmendOrder(ctx, ref, req) })
+	if err != nil {
+		return order.Ack{}, err
+	}
+	return v.(order.Ack), nil
+}
+
+func (b *broken) NativeStops() bool {
+	op, ok := b.ex.(ports.StopPlacer)
+	return ok && op.NativeStops()
+}
+
+func (b *broken) HonorsPostOnly() bool {
+	op, ok := b.ex.(ports.PostOnlyPlacer)
+	return ok && op.HonorsPostOnly()
+}
@@ -3,6 +3,7 @@ package exchange
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -105,6 +106,7 @@ func TestBreakerIgnoresNonVenueErrors(t *testing.T) {
 		{"unsupported account", ports.ErrUnsupportedAccount, true},
 		{"order not found", ports.ErrNotFound, true},
 		{"missing venue order ID", ports.ErrNoVenueOrderID, true},
+		{"unsupported order flag", fmt.Errorf("gct: %w", &ports.UnsupportedError{Venue: "bybit", Flag: "gtd"}), true},
 		{"caller canceled", context.Canceled, true},
 		{"limiter wait timeout", errLimiterWait, true},
 		{"venue failure", errors.New("venue down"), false},
@@ -176,6 +178,84 @@ func (f *fakeTradingExchange) GetOrder(context.Context, order.Ref) (order.Snapsh
 	return order.Snapshot{}, f.err
 }
 
+type fakeAmendingExchange struct {
+	fakeTradingExchange
+	amendCalls int
+}
+
+func (f *fakeAmendingExchange) AmendOrder(_ context.Context, ref order.Ref, _ order.Request) (order.Ack, error) {
+	f.amendCalls++
+	return order.Ack{Ref: ref}, f.err
+}
+
+func TestDecoratorsForwardAmend(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeAmendingExchange{fakeTradingExchange: fakeTradingExchange{fakeExchange: fakeExchange{id: "bybit"}}}
+	amender, ok := Decorate(fake, 100, 100).(ports.OrderAmender)
+	if !ok {
+		t.Fatal("decorated exchange must expose ports.OrderAmender")
+	}
+	ack, err := amender.AmendOrder(context.Background(), order.Ref{ClientOrderID: "cid-1"}, order.Request{})
+	if err != nil || ack.Ref.ClientOrderID != "cid-1" || fake.amendCalls != 1 {
+		t.Fatalf("AmendOrder = %+v, %v, calls=%d", ack, err, fake.amendCalls)
+	}
+}
+
+func TestDecoratorsReportAmendUnsupported(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeTradingExchange{fakeExchange: fakeExchange{id: "bybit"}}
+	amender := Decorate(fake, 100, 100).(ports.OrderAmender)
+	// Asked on every replace; the answer must never open the breaker.
+	for range 10 {
+		if _, err := amender.AmendOrder(context.Background(), order.Ref{}, order.Request{}); !errors.Is(err, ports.ErrAmendUnsupported) {
+			t.Fatalf("AmendOrder err = %v, want ErrAmendUnsupported", err)
+		}
+	}
+	if _, err := amender.(ports.OrderPlacer).PlaceOrder(context.Background(), order.Request{}); err != nil {
+		t.Fatalf("PlaceOrder after unsupported amends: %v", err)
+	}
+}
+
+type fakeStopExchange struct {
+	fakeTradingExchange
+}
+
+func (fakeStopExchange) NativeStops() bool { return true }
+
+func TestDecoratorsForwardNativeStops(t *testing.T) {
+	t.Parallel()
+
+	native := &fakeStopExchange{fakeTradingExchange{fakeExchange: fakeExchange{id: "bybit"}}}
+	if sp, ok := Decorate(native, 100, 100).(ports.StopPlacer); !ok || !sp.NativeStops() {
+		t.Fatal("decorated stop venue must report native stops")
+	}
+	plain := &fakeTradingExchange{fakeExchange: fakeExchange{id: "bybit"}}
+	if Decorate(plain, 100, 100).(ports.StopPlacer).NativeStops() {
+		t.Fatal("decorated venue without stops reports native stops")
+	}
+}
+
+type fakePostOnlyExchange struct {
+	fakeTradingExchange
+}
+
+func (fakePostOnlyExchange) HonorsPostOnly() bool { return true }
+
+func TestDecoratorsForwardPostOnly(t *testing.T) {
+	t.Parallel()
+
+	honoring := &fakePostOnlyExchange{fakeTradingExchange{fakeExchange: fakeExchange{id: "bybit"}}}
+	if po, ok := Decorate(honoring, 100, 100).(ports.PostOnlyPlacer); !ok || !po.HonorsPostOnly() {
+		t.Fatal("decorated post-only venue must report honoring post-only")
+	}
+	plain := &fakeTradingExchange{fakeExchange: fakeExchange{id: "bybit"}}
+	if Decorate(plain, 100, 100).(ports.PostOnlyPlacer).HonorsPostOnly() {
+		t.Fatal("decorated venue without post-only reports honoring it")
+	}
+}
+
 func TestDecoratorsForwardTrading(t *testing.T) {
 	t.Parallel()
 
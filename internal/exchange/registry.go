@@ -64,13 +64,19 @@ func Decorate(ex ports.Exchange, rps float64, burst int) ports.Exchange {
 // nothing about the venue, so they must not open a venue-wide circuit. A
 // venue positively reporting that an order does not exist is a healthy,
 // well-formed answer, not a venue failure. Reconciliation point lookups
-// encounter this in normal operation.
+// encounter this in normal operation. An adapter without native amend is
+// a fact about the adapter, asked on every replace, not a venue failure;
+// so are an order flag it cannot honor and public trades it cannot read.
 func isBreakerSuccess(err error) bool {
+	var unsupported *ports.UnsupportedError
 	return err == nil ||
 		errors.Is(err, ports.ErrAuth) ||
 		errors.Is(err, ports.ErrUnsupportedAccount) ||
 		errors.Is(err, ports.ErrNotFound) ||
 		errors.Is(err, ports.ErrNoVenueOrderID) ||
+		errors.Is(err, ports.ErrAmendUnsupported) ||
+		errors.Is(err, ports.ErrTradesUnsupported) ||
+		errors.As(err, &unsupported) ||
 		errors.Is(err, context.Canceled) ||
 		errors.Is(err, errLimiterWait)
 }
@@ -17,6 +17,7 @@ var (
 	ErrRateLimited        = errors.New("rate limited by venue")
 	ErrUnsupportedAccount = errors.New("unsupported account type")
 	ErrTradingUnsupported = errors.New("venue adapter does not support trading")
+	ErrTradesUnsupported  = errors.New("venue adapter does not read public trades")
 )
 
 // MarketDataReader provides public market data.
@@ -25,6 +26,13 @@ type MarketDataReader interface {
 	Instruments(ctx context.Context, typ instrument.Type) ([]instrument.Instrument, error)
 }
 
+// TradeReader provides the venue's recent public trades, newest last.
+// Adapters that cannot read them leave it unimplemented; the exchange
+// decorators then return ErrTradesUnsupported.
+type TradeReader interface {
+	RecentTrades(ctx context.Context, inst instrument.Instrument) ([]marketdata.Trade, error)
+}
+
 // AccountReader provides private account data.
 type AccountReader interface {
 	Balances(ctx context.Context, acct account.Type) ([]account.Balance, error)
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/romanornr/delta-works/internal/domain/sink"
+)
+
+// Sink delivers events to one external system. Deliver returns once the
+// receiver has accepted the event; an error wrapping sink.ErrRejected
+// means it never will, any other error that a later attempt may succeed.
+type Sink interface {
+	Deliver(ctx context.Context, d sink.Delivery) error
+}
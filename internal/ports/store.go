@@ -5,10 +5,18 @@ import (
 	"errors"
 	"time"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/arb"
+	"github.com/romanornr/delta-works/internal/domain/auth"
+	"github.com/romanornr/delta-works/internal/domain/execution"
+	"github.com/romanornr/delta-works/internal/domain/funds"
 	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/ledger"
 	"github.com/romanornr/delta-works/internal/domain/marketdata"
 	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/domain/sink"
 	"github.com/romanornr/delta-works/internal/events"
 	"github.com/romanornr/delta-works/internal/snapshot"
 )
@@ -30,6 +38,33 @@ type TickerSeriesWriter interface {
 	Flush(ctx context.Context) error
 }
 
+// ValuationSeriesWriter appends analytics-only unrealized-PnL rows and
+// durably flushes them (ADR-0004).
+type ValuationSeriesWriter interface {
+	WriteValuation(ctx context.Context, v ledger.Valuation) error
+	Flush(ctx context.Context) error
+}
+
+// TradeSeriesWriter appends analytics-only public trade rows and durably
+// flushes them (ADR-0004).
+type TradeSeriesWriter interface {
+	WriteTrade(ctx context.Context, t marketdata.Trade) error
+	Flush(ctx context.Context) error
+}
+
+// TradeSeriesReader reads the analytics-only market trade series (ADR-0004):
+// what the market traded, for schedules and benchmarks, never for
+// accounting.
+type TradeSeriesReader interface {
+	// VolumeProfile returns the volume traded in each minute of the UTC
+	// day since since, added up over the days it spans: 1440 entries, the
+	// first at midnight.
+	VolumeProfile(ctx context.Context, inst instrument.Instrument, since time.Time) (execution.VolumeProfile, error)
+	// VWAP returns the market's volume-weighted average trade price in
+	// [from, to), zero when nothing traded.
+	VWAP(ctx context.Context, inst instrument.Instrument, from, to time.Time) (decimal.Decimal, error)
+}
+
 // SnapshotRecorder records durable snapshot checkpoints.
 type SnapshotRecorder interface {
 	RecordSnapshot(ctx context.Context, checkpoint snapshot.Checkpoint) error
@@ -45,12 +80,75 @@ type SnapshotReader interface {
 type OrderCommandStore interface {
 	// CreatePending inserts the order in status pending before the venue
 	// submit. Idempotent: re-inserting the same ClientOrderID reports false.
+	// A request with Replaces set also stores the cancel-replace link.
 	CreatePending(ctx context.Context, req order.Request) (bool, error)
+	// CreateUntriggered inserts a stop the local trigger engine holds, in
+	// status untriggered, with the client order ID its child will be
+	// placed under. Idempotent like CreatePending.
+	CreateUntriggered(ctx context.Context, req order.Request, child order.ClientOrderID) (bool, error)
 	// GetOrder returns the stored order, or ErrNotFound.
 	GetOrder(ctx context.Context, id order.ClientOrderID) (order.Record, error)
 	// MarkCancelRequested stamps the cancel intent once; later calls keep
 	// the first timestamp. Returns ErrNotFound for unknown orders.
 	MarkCancelRequested(ctx context.Context, id order.ClientOrderID, at time.Time) error
+	// RecordAmend stores an amend the venue accepted: the order's new
+	// price and quantity and the replacement link, atomically. Returns
+	// ErrNotFound for unknown orders.
+	RecordAmend(ctx context.Context, r order.Replacement) error
+	// ListReplacements returns the replacements the order took part in,
+	// as either side, oldest first.
+	ListReplacements(ctx context.Context, id order.ClientOrderID) ([]order.Replacement, error)
+	// RecordReplaceIntent stores which order will replace r.Old before the
+	// old order is canceled, and returns the intent stored for it: the
+	// first one recorded wins.
+	RecordReplaceIntent(ctx context.Context, r order.Replacement) (order.Replacement, error)
+	// GetReplaceIntent returns the cancel-replace intent recorded for the
+	// old order, or ErrNotFound.
+	GetReplaceIntent(ctx context.Context, old order.ClientOrderID) (order.Replacement, error)
+}
+
+// ReservationStore stores new orders together with the funds they hold.
+type ReservationStore interface {
+	// CreateReserved inserts the order in status pending with its
+	// reservation drawn against the account's latest balance snapshot,
+	// atomically. It fails with funds.ErrInsufficient when the balances
+	// cannot cover the reservation and funds.ErrNoBalance when the account
+	// has no snapshot yet. Idempotent like CreatePending: re-inserting the
+	// same ClientOrderID checks nothing and reports false.
+	CreateReserved(ctx context.Context, req order.Request, r funds.Reservation) (bool, error)
+	// CheckAmend checks, under the same lock, that amending amend.Old to
+	// the new terms fits the account's latest balances, failing with
+	// funds.ErrInsufficient when what the order would hold grows past what
+	// is available. An order placed without a reservation passes.
+	CheckAmend(ctx context.Context, amend order.Replacement) error
+}
+
+// KillSwitchStore persists engaged kill switches so a halt survives a
+// restart.
+type KillSwitchStore interface {
+	// EngageKillSwitch stores the halt. Engaging an engaged switch keeps
+	// and returns the original halt.
+	EngageKillSwitch(ctx context.Context, h order.Halt) (order.Halt, error)
+	// ReleaseKillSwitch removes the venue's halt (the empty venue is the
+	// global switch). Returns ErrNotFound when it was not engaged.
+	ReleaseKillSwitch(ctx context.Context, venue instrument.VenueID) error
+	// ListKillSwitches returns every engaged halt.
+	ListKillSwitches(ctx context.Context) ([]order.Halt, error)
+}
+
+// InstrumentCatalog reads the local instrument catalog.
+type InstrumentCatalog interface {
+	// ListInstruments returns the venue's catalog entries, delisted ones
+	// included, ordered by key. The empty venue lists every venue.
+	ListInstruments(ctx context.Context, venue instrument.VenueID) ([]instrument.Instrument, error)
+}
+
+// InstrumentStore persists the instrument catalog.
+type InstrumentStore interface {
+	InstrumentCatalog
+	// ApplyInstrumentChanges stores the changes with a history row and an
+	// instrument.changed outbox event each, atomically.
+	ApplyInstrumentChanges(ctx context.Context, changes []instrument.Change, at time.Time) error
 }
 
 // OrderEventStore applies venue events to durable order state.
@@ -65,11 +163,106 @@ type OrderEventStore interface {
 type OrderReconcileStore interface {
 	// GetOrder returns the stored order, or ErrNotFound.
 	GetOrder(ctx context.Context, id order.ClientOrderID) (order.Record, error)
-	// ListActiveOrders returns every non-terminal order (pending, open,
-	// partially_filled) for one venue.
+	// ListActiveOrders returns every non-terminal order (pending,
+	// untriggered, open, partially_filled) for one venue, local stops
+	// included.
 	ListActiveOrders(ctx context.Context, venue instrument.VenueID) ([]order.Record, error)
 }
 
+// StopStore reads the stops the local trigger engine holds.
+type StopStore interface {
+	// GetOrder returns the stored order, or ErrNotFound.
+	GetOrder(ctx context.Context, id order.ClientOrderID) (order.Record, error)
+	// ListUntriggeredStops returns the local stops still waiting for their
+	// trigger on venue, oldest first. The empty venue lists every venue.
+	ListUntriggeredStops(ctx context.Context, venue instrument.VenueID) ([]order.Record, error)
+}
+
+// OrderGroupStore persists linked order groups and reads the orders of
+// their legs.
+type OrderGroupStore interface {
+	// CreateGroup inserts the group and its legs. Idempotent: re-inserting
+	// the same GroupID reports false and changes nothing.
+	CreateGroup(ctx context.Context, g order.Group) (bool, error)
+	// GetGroup returns the group with its legs, or ErrNotFound.
+	GetGroup(ctx context.Context, id order.GroupID) (order.Group, error)
+	// GroupOf returns the group the order is a leg of, or ErrNotFound.
+	GroupOf(ctx context.Context, id order.ClientOrderID) (order.Group, error)
+	// ListGroups returns at most limit groups newest first: the open ones
+	// (pending or active) when openOnly is set, narrowed to venue unless it
+	// is empty.
+	ListGroups(ctx context.Context, venue instrument.VenueID, openOnly bool, limit int32) ([]order.Group, error)
+	// ListOpenGroups returns every pending or active group, oldest first.
+	ListOpenGroups(ctx context.Context) ([]order.Group, error)
+	// SetGroupStatus stores the group's status and reason. Returns
+	// ErrNotFound for unknown groups.
+	SetGroupStatus(ctx context.Context, id order.GroupID, status order.GroupStatus, reason string, at time.Time) error
+	// GetOrder returns the stored order, or ErrNotFound.
+	GetOrder(ctx context.Context, id order.ClientOrderID) (order.Record, error)
+}
+
+// ParentOrderStore persists execution-algorithm parents and reads their
+// child orders.
+type ParentOrderStore interface {
+	// CreateParent inserts the parent. Idempotent: re-inserting the same ID
+	// reports false and changes nothing.
+	CreateParent(ctx context.Context, p execution.Parent) (bool, error)
+	// GetParent returns the parent, or ErrNotFound.
+	GetParent(ctx context.Context, id order.ParentID) (execution.Parent, error)
+	// ListParents returns at most limit parents newest first: the live ones
+	// (running or paused) when liveOnly is set, narrowed to venue unless it
+	// is empty.
+	ListParents(ctx context.Context, venue instrument.VenueID, liveOnly bool, limit int32) ([]execution.Parent, error)
+	// ListLiveParents returns every running or paused parent, oldest first.
+	ListLiveParents(ctx context.Context) ([]execution.Parent, error)
+	// UpdateParent stores the parent's status, schedule and reason. Returns
+	// ErrNotFound for unknown parents.
+	UpdateParent(ctx context.Context, p execution.Parent) error
+	// ListChildren returns the parent's child orders, oldest first.
+	ListChildren(ctx context.Context, id order.ParentID) ([]order.Record, error)
+	// GetOrder returns the stored order, or ErrNotFound.
+	GetOrder(ctx context.Context, id order.ClientOrderID) (order.Record, error)
+}
+
+// ArbTradeStore persists cross-venue arbitrage trades and reads the
+// orders of their legs.
+type ArbTradeStore interface {
+	// CreateArbTrade inserts the trade before its legs are placed.
+	CreateArbTrade(ctx context.Context, t arb.Trade) error
+	// GetArbTrade returns the trade, or ErrNotFound.
+	GetArbTrade(ctx context.Context, id arb.TradeID) (arb.Trade, error)
+	// ListArbTrades returns at most limit trades newest first: the open
+	// and hedge-needed ones when unsettledOnly is set, narrowed to botID
+	// unless it is empty.
+	ListArbTrades(ctx context.Context, botID string, unsettledOnly bool, limit int32) ([]arb.Trade, error)
+	// ListUnsettledArbTrades returns the bot's open and hedge-needed
+	// trades, oldest first.
+	ListUnsettledArbTrades(ctx context.Context, botID string) ([]arb.Trade, error)
+	// UpdateArbTrade stores the trade's status, hedge and reason. Returns
+	// ErrNotFound for unknown trades.
+	UpdateArbTrade(ctx context.Context, t arb.Trade) error
+	// GetOrder returns the stored order, or ErrNotFound.
+	GetOrder(ctx context.Context, id order.ClientOrderID) (order.Record, error)
+}
+
+// GridOrderStore reads the orders of grid bots.
+type GridOrderStore interface {
+	// ListOrders returns at most query.Limit+1 rows so the caller can
+	// derive a next-page token.
+	ListOrders(ctx context.Context, query order.Query) ([]order.Record, error)
+	// GetOrder returns the stored order, or ErrNotFound.
+	GetOrder(ctx context.Context, id order.ClientOrderID) (order.Record, error)
+}
+
+// ActiveOrderCounter counts non-terminal orders for pre-trade limits.
+type ActiveOrderCounter interface {
+	// CountActiveOrders counts pending, open and partially filled orders.
+	// Armed stops are not counted: a local one holds nothing at the venue,
+	// and its child must not be refused for the stop it replaces. An
+	// empty venue or bot ID matches every venue or bot.
+	CountActiveOrders(ctx context.Context, venue instrument.VenueID, botID string) (int, error)
+}
+
 // OrderQueryStore serves keyset-paginated order reads.
 type OrderQueryStore interface {
 	// ListOrders returns at most query.Limit+1 rows so the caller can
@@ -77,18 +270,104 @@ type OrderQueryStore interface {
 	ListOrders(ctx context.Context, query order.Query) ([]order.Record, error)
 }
 
+// LedgerQueryStore reads realized inventory for profit reporting.
+type LedgerQueryStore interface {
+	// ListClosedLots returns the lot closures matching query, each joined
+	// with its lot, ordered by closing time and closure ID. It returns at
+	// most query.Limit+1 rows so the caller can derive a next-page token.
+	ListClosedLots(ctx context.Context, query ledger.ClosureQuery) ([]ledger.ClosedLot, error)
+	// SumClosedLots totals the closures in the whole query window per
+	// inventory, ignoring the page, ordered by bot, venue, base and quote.
+	SumClosedLots(ctx context.Context, query ledger.ClosureQuery) ([]ledger.ClosureTotal, error)
+	// SumFeeCharges totals the third-currency fees matching query per
+	// inventory and currency, ordered by bot, venue, base, quote and
+	// currency.
+	SumFeeCharges(ctx context.Context, query ledger.ClosureQuery) ([]ledger.FeeTotal, error)
+}
+
+// HeldQtyReader sums held inventory for pre-trade position limits.
+type HeldQtyReader interface {
+	// HeldQty sums the remaining quantity of the bot's open lots in the
+	// instrument's venue and pair, priced or not.
+	HeldQty(ctx context.Context, botID string, inst instrument.Instrument) (decimal.Decimal, error)
+}
+
+// OpenLotStore reads the open inventory for mark-to-market valuation.
+type OpenLotStore interface {
+	// ListOpenLots returns every open lot, grouped by inventory.
+	ListOpenLots(ctx context.Context) ([]ledger.Lot, error)
+}
+
 // OutboxStore drains the transactional outbox (ADR-0008).
 type OutboxStore interface {
 	// PublishPending claims up to limit unpublished rows in id order,
-	// calls publish for each, and marks them published in the same
-	// transaction. A publish error aborts the batch so the rows are
-	// retried on the next poll: delivery is at-least-once. Returns the
-	// number of rows published.
+	// numbers them in publish order, and calls publish for each before
+	// marking them published in the same transaction. Batches commit one
+	// at a time, so publish order is also commit order. A publish error
+	// aborts the batch so the rows are retried on the next poll: delivery
+	// is at-least-once. Returns the number of rows published.
 	PublishPending(ctx context.Context, limit int, publish func(events.OutboxMessage) error) (int, error)
-	// DeletePublishedBefore removes rows published before cutoff and
-	// returns how many were deleted.
+	// DeletePublishedBefore removes rows published before cutoff that
+	// every sink has passed, and returns how many were deleted.
 	DeletePublishedBefore(ctx context.Context, cutoff time.Time) (int64, error)
 	// UnpublishedStats reports the backlog: row count and the creation
 	// time of the oldest unpublished row (now when the backlog is empty).
 	UnpublishedStats(ctx context.Context) (rows int64, oldest time.Time, err error)
 }
+
+// OutboxReader reads the outbox back for resumable event streams, by
+// publish order (events.OutboxMessage.PublishSeq), until cleanup removes
+// the rows.
+type OutboxReader interface {
+	// ListAfter returns up to limit rows with a PublishSeq above after
+	// whose subject starts with prefix, in publish order. Every row
+	// published to the bus before the call is listed, even when its
+	// batch was still committing.
+	ListAfter(ctx context.Context, after int64, prefix string, limit int) ([]events.OutboxMessage, error)
+	// HasRow reports whether the row published at seq is still held.
+	HasRow(ctx context.Context, seq int64) (bool, error)
+}
+
+// SinkStore keeps each sink's delivery cursor and the deliveries it gave
+// up on. Cursors count in publish order (events.OutboxMessage.PublishSeq),
+// not by outbox ID.
+type SinkStore interface {
+	// OpenCursor returns the sink's cursor. A sink seen for the first time
+	// starts at the newest published row.
+	OpenCursor(ctx context.Context, sink string) (int64, error)
+	// AdvanceCursor moves the sink's cursor forward to after; a cursor
+	// already past it is left alone.
+	AdvanceCursor(ctx context.Context, sink string, after int64) error
+	// PruneCursors deletes the cursors of sinks not in keep, so a sink
+	// removed from the configuration stops holding back outbox cleanup.
+	// Returns how many were deleted.
+	PruneCursors(ctx context.Context, keep []string) (int64, error)
+	// ListPublishedAfter returns up to limit published outbox rows with a
+	// PublishSeq above after, in publish order.
+	ListPublishedAfter(ctx context.Context, after int64, limit int) ([]events.OutboxMessage, error)
+	// DeadLetter stores a delivery the sink gave up on and advances the
+	// sink's cursor to seq in the same transaction.
+	DeadLetter(ctx context.Context, letter sink.DeadLetter, seq int64) error
+	// ListDeadLetters returns the dead letters matching query, oldest first.
+	ListDeadLetters(ctx context.Context, query sink.DeadLetterQuery) ([]sink.DeadLetter, error)
+	// DeleteDeadLetter removes a dead letter that has been delivered.
+	DeleteDeadLetter(ctx context.Context, id int64) error
+	// FailDeadLetter records another failed attempt at a dead letter.
+	FailDeadLetter(ctx context.Context, id int64, lastErr string, at time.Time) error
+}
+
+// TokenStore keeps the control-plane tokens managed over the API. Only
+// hashes are stored; a secret is shown once, when its token is created.
+type TokenStore interface {
+	// InsertToken stores a new token. Returns false, storing nothing, when
+	// a valid token already holds its name.
+	InsertToken(ctx context.Context, t auth.Token) (bool, error)
+	// TokenByHash returns the valid token with the hash, or ErrNotFound.
+	TokenByHash(ctx context.Context, hash auth.Hash) (auth.Token, error)
+	// ListTokens returns the valid tokens, and the revoked ones too when
+	// asked, oldest first.
+	ListTokens(ctx context.Context, includeRevoked bool) ([]auth.Token, error)
+	// RevokeToken revokes the valid token with the id, or returns
+	// ErrNotFound when there is none.
+	RevokeToken(ctx context.Context, id string, at time.Time) error
+}
@@ -3,17 +3,37 @@ package ports
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	"github.com/romanornr/delta-works/internal/domain/instrument"
 	"github.com/romanornr/delta-works/internal/domain/order"
 )
 
-// ErrNoVenueOrderID reports that a venue-ID lookup was requested without the
-// venue identity it requires.
-var ErrNoVenueOrderID = errors.New("venue order ID is required")
+var (
+	// ErrNoVenueOrderID reports that a venue-ID lookup was requested
+	// without the venue identity it requires.
+	ErrNoVenueOrderID = errors.New("venue order ID is required")
+	// ErrAmendUnsupported reports a venue without native amend; callers
+	// fall back to cancel-then-place.
+	ErrAmendUnsupported = errors.New("venue does not support amending orders")
+)
+
+// UnsupportedError reports an order flag (a time in force, post-only) or
+// order type (a stop) the venue adapter cannot honor. PlaceOrder returns it before anything is
+// submitted: an order must never trade on terms it was not given.
+type UnsupportedError struct {
+	Venue instrument.VenueID
+	Flag  string
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("venue %s does not support %s orders", e.Venue, e.Flag)
+}
 
 // OrderPlacer submits and manages orders at a venue. Request.ClientOrderID
 // is generated by us and is the idempotency key: retrying PlaceOrder with
-// the same ID must not create a second order.
+// the same ID must not create a second order. Execution flags the venue
+// cannot honor fail with *UnsupportedError.
 type OrderPlacer interface {
 	PlaceOrder(ctx context.Context, req order.Request) (order.Ack, error)
 	CancelOrder(ctx context.Context, ref order.Ref) error
@@ -25,6 +45,33 @@ type OrderPlacer interface {
 	GetOrder(ctx context.Context, ref order.Ref) (order.Snapshot, error)
 }
 
+// OrderAmender changes a resting order's price and quantity in place. The
+// venue keeps both order IDs and the fills so far, so req carries the same
+// ClientOrderID and its Qty is the new total, filled part included.
+// Adapters without native amend leave it unimplemented; the exchange
+// decorators then return ErrAmendUnsupported.
+type OrderAmender interface {
+	AmendOrder(ctx context.Context, ref order.Ref, req order.Request) (order.Ack, error)
+}
+
+// StopPlacer marks an adapter whose venue holds stop orders itself: its
+// PlaceOrder accepts order.StopMarket and order.StopLimit, acks an armed
+// stop as untriggered, and reports a fired one by the status it then
+// works in. Stops on other venues wait in the local trigger engine.
+// NativeStops lets the exchange decorators forward the capability.
+type StopPlacer interface {
+	NativeStops() bool
+}
+
+// PostOnlyPlacer marks an adapter whose venue honors order.Request.PostOnly:
+// its PlaceOrder sends the venue's maker-only flag, so the venue rejects
+// an order that would take liquidity rather than fill it. An adapter
+// reporting false refuses post-only orders with an UnsupportedError.
+// HonorsPostOnly lets the exchange decorators forward the capability.
+type PostOnlyPlacer interface {
+	HonorsPostOnly() bool
+}
+
 // PrivateStreamer streams private order events. The adapter owns
 // reconnection; the channel closes only when ctx is canceled. Missed events
 // during reconnects are recovered by the reconciliation loop polling
@@ -0,0 +1,380 @@
+// Package arb runs cross-venue arbitrage bots. Every interval each bot
+// settles its open trades from their legs' stored orders and, when none
+// is left open or waiting for a hedge, compares its venues' tickers: an
+// edge past the bot's minimum is sized to the free balances and traded as
+// two IOC legs placed at once through the order service. A trade whose
+// legs filled unequally is kept as needing a hedge and blocks the bot
+// until an operator resolves it. Order outcomes on the bus settle trades
+// without waiting for the interval.
+package arb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/arb"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/events"
+	"github.com/romanornr/delta-works/internal/exchange"
+	"github.com/romanornr/delta-works/internal/id"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+	orderservice "github.com/romanornr/delta-works/internal/service/order"
+	"github.com/romanornr/delta-works/internal/service/runloop"
+)
+
+// nudgeBuffer sizes the queue of bots to settle early. A full queue drops
+// the nudge: the next interval settles the bot anyway.
+const nudgeBuffer = 16
+
+// Placer is the order surface the legs trade through; *order.Service
+// satisfies it.
+type Placer interface {
+	Place(ctx context.Context, req order.Request) (orderservice.PlaceResult, error)
+}
+
+// Service runs the configured bots from one loop.
+type Service struct {
+	specs    map[string]arb.Spec // immutable after New
+	all      []arb.Spec
+	placer   Placer
+	store    ports.ArbTradeStore
+	registry exchange.Registry
+	catalog  ports.InstrumentCatalog
+	accounts map[instrument.VenueID]account.Type
+	bus      bus.Bus
+	clk      clockwork.Clock
+	log      log.Logger
+	interval time.Duration
+	metrics  *Metrics
+	nudge    chan string
+
+	mu sync.Mutex // serializes steps with Resolve
+}
+
+// New builds the service. Specs must be valid and have unique bot IDs.
+// accounts names the account each venue's balances are read from, spot
+// when absent, as for the funds service. Metrics must not be nil; catalog
+// may be, in which case legs are not fitted to the venues' rules.
+func New(
+	specs []arb.Spec,
+	placer Placer,
+	store ports.ArbTradeStore,
+	registry exchange.Registry,
+	catalog ports.InstrumentCatalog,
+	accounts map[instrument.VenueID]account.Type,
+	eventBus bus.Bus,
+	clk clockwork.Clock,
+	logger log.Logger,
+	interval time.Duration,
+	metrics *Metrics,
+) *Service {
+	s := &Service{
+		specs: make(map[string]arb.Spec, len(specs)), all: specs,
+		placer: placer, store: store, registry: registry, catalog: catalog, accounts: accounts,
+		bus: eventBus, clk: clk, log: log.Component(logger, "arb"),
+		interval: interval, metrics: metrics, nudge: make(chan string, nudgeBuffer),
+	}
+	for _, spec := range specs {
+		s.specs[spec.BotID] = spec
+	}
+	return s
+}
+
+// Run steps every bot each interval, and a bot early when one of its legs
+// ends, until ctx is canceled. Store failures stop the service so the
+// process can fail fast.
+func (s *Service) Run(ctx context.Context) error {
+	if len(s.all) == 0 {
+		<-ctx.Done()
+		return nil
+	}
+	unsubscribe, err := s.bus.Subscribe("order.", s.route)
+	if err != nil {
+		return fmt.Errorf("arb: subscribe to order events: %w", err)
+	}
+	defer unsubscribe()
+
+	ticker := s.clk.NewTicker(s.interval)
+	defer ticker.Stop()
+	if err := s.stepAll(ctx); err != nil {
+		return runloop.Err(ctx, err)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case botID := <-s.nudge:
+			if err := s.step(ctx, s.specs[botID]); err != nil {
+				return runloop.Err(ctx, err)
+			}
+		case <-ticker.Chan():
+			if err := s.stepAll(ctx); err != nil {
+				return runloop.Err(ctx, err)
+			}
+		}
+	}
+}
+
+func (s *Service) stepAll(ctx context.Context) error {
+	for _, spec := range s.all {
+		if err := s.step(ctx, spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// route nudges the bot whose leg reached a terminal state.
+func (s *Service) route(_ context.Context, event bus.Event) {
+	raw, ok := event.Payload.(json.RawMessage)
+	if !ok {
+		return
+	}
+	var (
+		botID  string
+		status order.Status
+	)
+	switch event.Subject {
+	case events.SubjectOrderFilled:
+		var p events.OrderFilledPayload
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return
+		}
+		botID, status = p.BotID, p.Status
+	case events.SubjectOrderUpdated:
+		var p events.OrderUpdatedPayload
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return
+		}
+		botID, status = p.BotID, p.Status
+	default:
+		return
+	}
+	if _, ok := s.specs[botID]; !ok || !status.Terminal() {
+		return
+	}
+	select {
+	case s.nudge <- botID:
+	default:
+	}
+}
+
+// List returns at most limit trades newest first, see
+// ports.ArbTradeStore.ListArbTrades.
+func (s *Service) List(ctx context.Context, botID string, unsettledOnly bool, limit int32) ([]arb.Trade, error) {
+	return s.store.ListArbTrades(ctx, botID, unsettledOnly, limit)
+}
+
+// Resolve marks a hedge-needed trade hedged once the operator has evened
+// out its legs, which lets its bot trade again.
+func (s *Service) Resolve(ctx context.Context, tradeID arb.TradeID, note string) (arb.Trade, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.store.GetArbTrade(ctx, tradeID)
+	if err != nil {
+		return arb.Trade{}, err
+	}
+	t, err = t.Resolve(note, s.clk.Now())
+	if err != nil {
+		return arb.Trade{}, err
+	}
+	if err := s.store.UpdateArbTrade(ctx, t); err != nil {
+		return arb.Trade{}, err
+	}
+	s.metrics.observeTrade(t.BotID, string(t.Status))
+	s.log.Info().Str("bot", t.BotID).Str("trade_id", string(t.ID)).Str("reason", t.Reason).Msg("arbitrage hedge resolved")
+	select {
+	case s.nudge <- t.BotID:
+	default:
+	}
+	return t, nil
+}
+
+// step settles the bot's open trades and looks for a new one when nothing
+// is left unsettled.
+func (s *Service) step(ctx context.Context, spec arb.Spec) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	trades, err := s.store.ListUnsettledArbTrades(ctx, spec.BotID)
+	if err != nil {
+		return fmt.Errorf("arb store: list trades of bot %s: %w", spec.BotID, err)
+	}
+	var unsettled, hedges int
+	for _, t := range trades {
+		if t, err = s.settle(ctx, t); err != nil {
+			return err
+		}
+		if !t.Status.Final() {
+			unsettled++
+		}
+		if t.Status == arb.StatusHedgeNeeded {
+			hedges++
+		}
+	}
+	if unsettled == 0 {
+		t, err := s.seek(ctx, spec)
+		if err != nil {
+			return err
+		}
+		if t.Status == arb.StatusHedgeNeeded {
+			hedges++
+		}
+	}
+	s.metrics.setHedgesNeeded(spec.BotID, hedges)
+	return nil
+}
+
+// settle ends an open trade whose legs have both ended. A leg that was
+// never stored, because its placement was refused, counts as unfilled.
+func (s *Service) settle(ctx context.Context, t arb.Trade) (arb.Trade, error) {
+	if t.Status != arb.StatusOpen {
+		return t, nil
+	}
+	var legs [2]order.Record
+	for i, legID := range []order.ClientOrderID{t.BuyOrderID, t.SellOrderID} {
+		rec, err := s.store.GetOrder(ctx, legID)
+		switch {
+		case errors.Is(err, ports.ErrNotFound):
+			rec = order.Record{ClientOrderID: legID, Status: order.StatusRejected}
+		case err != nil:
+			return t, fmt.Errorf("arb store: get leg %s: %w", legID, err)
+		}
+		legs[i] = rec
+	}
+	settled, ok := t.Settle(legs[0], legs[1], s.clk.Now())
+	if !ok {
+		return t, nil
+	}
+	if err := s.store.UpdateArbTrade(ctx, settled); err != nil {
+		return t, fmt.Errorf("arb store: update trade %s: %w", t.ID, err)
+	}
+	s.metrics.observeTrade(settled.BotID, string(settled.Status))
+	l := s.log.With().Str("bot", settled.BotID).Str("trade_id", string(settled.ID)).Str("reason", settled.Reason).Logger()
+	if settled.Status == arb.StatusHedgeNeeded {
+		l.Warn().Str("hedge_side", string(settled.HedgeSide)).Str("hedge_qty", settled.HedgeQty.String()).
+			Msg("arbitrage legs filled unequally; hedge needed")
+	} else {
+		l.Info().Msg("arbitrage trade settled")
+	}
+	return settled, nil
+}
+
+// seek trades the bot's spread when it clears the minimum edge, sized to
+// the free balances and fitted to both venues' rules. Venue failures skip
+// this look; only store failures are returned.
+func (s *Service) seek(ctx context.Context, spec arb.Spec) (arb.Trade, error) {
+	var tickers [2]marketdata.Ticker
+	for i, leg := range spec.Legs {
+		ticker, err := s.ticker(ctx, leg)
+		if err != nil {
+			s.log.Warn().Err(err).Str("bot", spec.BotID).Str("venue", string(leg.Venue)).Msg("ticker failed; skipping this look")
+			s.metrics.observeSkip(spec.BotID, "no_quote")
+			return arb.Trade{}, nil
+		}
+		tickers[i] = ticker
+	}
+	o, ok := spec.Evaluate(tickers[0], tickers[1])
+	if !ok {
+		s.metrics.observeSkip(spec.BotID, "no_quote")
+		return arb.Trade{}, nil
+	}
+	s.metrics.observeEdge(spec.BotID, o.EdgeBps)
+	if !spec.Tradable(o) {
+		return arb.Trade{}, nil
+	}
+	freeQuote, err := s.free(ctx, o.Buy.Venue, o.Buy.Quote)
+	if err == nil {
+		var freeBase decimal.Decimal
+		freeBase, err = s.free(ctx, o.Sell.Venue, o.Sell.Base)
+		o.Qty = spec.Affordable(o, freeQuote, freeBase)
+	}
+	if err != nil || !o.Qty.IsPositive() {
+		s.log.Warn().Err(err).Str("bot", spec.BotID).Str("edge_bps", o.EdgeBps.String()).Msg("no free balance for the edge")
+		s.metrics.observeSkip(spec.BotID, "insufficient_balance")
+		return arb.Trade{}, nil
+	}
+	var rules [2]instrument.Rules
+	for i, leg := range []instrument.Instrument{o.Buy, o.Sell} {
+		if rules[i], err = orderservice.CatalogRules(ctx, s.catalog, leg); err != nil {
+			return arb.Trade{}, fmt.Errorf("arb store: rules of %s: %w", leg.Key(), err)
+		}
+	}
+	qty := o.Round(o.Qty, rules[0], rules[1])
+	if !qty.IsPositive() {
+		s.metrics.observeSkip(spec.BotID, "below_minimum")
+		return arb.Trade{}, nil
+	}
+	return s.open(ctx, spec, o, qty)
+}
+
+// open stores the trade, places both legs at once and settles it if they
+// have already ended. The trade is stored first so a crash between the
+// legs still leaves it to settle.
+func (s *Service) open(ctx context.Context, spec arb.Spec, o arb.Opportunity, qty decimal.Decimal) (arb.Trade, error) {
+	t := arb.NewTrade(arb.TradeID(id.New()), spec.BotID, o, qty,
+		order.ClientOrderID(id.New()), order.ClientOrderID(id.New()), s.clk.Now())
+	if err := s.store.CreateArbTrade(ctx, t); err != nil {
+		return arb.Trade{}, fmt.Errorf("arb store: create trade: %w", err)
+	}
+	l := s.log.With().Str("bot", spec.BotID).Str("trade_id", string(t.ID)).Logger()
+	l.Info().Str("buy_venue", string(o.Buy.Venue)).Str("sell_venue", string(o.Sell.Venue)).
+		Str("qty", qty.String()).Str("edge_bps", o.EdgeBps.String()).Msg("arbitrage legs placing")
+
+	buy, sell := t.Legs()
+	var wg sync.WaitGroup
+	for _, req := range []order.Request{buy, sell} {
+		wg.Go(func() {
+			// A failed leg settles as unfilled, or from its stored state
+			// once reconcile learns what the venue did with it.
+			if _, err := s.placer.Place(context.WithoutCancel(ctx), req); err != nil {
+				l.Warn().Err(err).Str("client_order_id", string(req.ClientOrderID)).Str("side", string(req.Side)).Msg("arbitrage leg failed")
+			}
+		})
+	}
+	wg.Wait()
+	return s.settle(ctx, t)
+}
+
+func (s *Service) ticker(ctx context.Context, inst instrument.Instrument) (marketdata.Ticker, error) {
+	ex, err := s.registry.Get(inst.Venue)
+	if err != nil {
+		return marketdata.Ticker{}, err
+	}
+	return ex.Ticker(ctx, inst)
+}
+
+// free returns the venue's free balance of the currency in the account it
+// trades from, zero when it holds none.
+func (s *Service) free(ctx context.Context, venue instrument.VenueID, currency money.Currency) (decimal.Decimal, error) {
+	ex, err := s.registry.Get(venue)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	acct, ok := s.accounts[venue]
+	if !ok {
+		acct = account.TypeSpot
+	}
+	balances, err := ex.Balances(ctx, acct)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	for _, b := range balances {
+		if b.Currency == currency {
+			return b.Free, nil
+		}
+	}
+	return decimal.Zero, nil
+}
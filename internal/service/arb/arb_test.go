@@ -0,0 +1,451 @@
+package arb
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/arb"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/events"
+	"github.com/romanornr/delta-works/internal/exchange"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+	orderservice "github.com/romanornr/delta-works/internal/service/order"
+)
+
+const botID = "arb-1"
+
+func leg(venue instrument.VenueID) instrument.Instrument {
+	return instrument.Instrument{Venue: venue, Type: instrument.TypeSpot, Base: "BTC", Quote: "USDT"}
+}
+
+func testSpec() arb.Spec {
+	return arb.Spec{
+		BotID: botID, Legs: [2]instrument.Instrument{leg("alpha"), leg("beta")},
+		Qty: decimal.NewFromInt(2), MinEdgeBps: decimal.NewFromInt(10),
+		Fees: map[instrument.VenueID]decimal.Decimal{"alpha": decimal.RequireFromString("0.001"), "beta": decimal.RequireFromString("0.001")},
+	}
+}
+
+type fakeExchange struct {
+	venue    instrument.VenueID
+	mu       sync.Mutex
+	ticker   marketdata.Ticker
+	balances []account.Balance
+}
+
+func (f *fakeExchange) ID() instrument.VenueID { return f.venue }
+
+func (f *fakeExchange) Ticker(_ context.Context, inst instrument.Instrument) (marketdata.Ticker, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := f.ticker
+	t.Instrument = inst
+	return t, nil
+}
+
+func (f *fakeExchange) Instruments(context.Context, instrument.Type) ([]instrument.Instrument, error) {
+	return nil, nil
+}
+
+func (f *fakeExchange) Balances(context.Context, account.Type) ([]account.Balance, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.balances, nil
+}
+
+func (f *fakeExchange) quote(bid, ask string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.ticker = marketdata.Ticker{Bid: decimal.RequireFromString(bid), Ask: decimal.RequireFromString(ask)}
+}
+
+func free(currency money.Currency, qty string) []account.Balance {
+	return []account.Balance{{Currency: currency, Free: decimal.RequireFromString(qty), Total: decimal.RequireFromString(qty)}}
+}
+
+// fakeStore keeps trades and the legs' orders in memory.
+type fakeStore struct {
+	mu     sync.Mutex
+	trades []arb.Trade
+	orders map[order.ClientOrderID]order.Record
+	steps  map[string]int // unsettled reads per bot, one per step
+}
+
+func (f *fakeStore) CreateArbTrade(_ context.Context, t arb.Trade) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.trades = append(f.trades, t)
+	return nil
+}
+
+func (f *fakeStore) GetArbTrade(_ context.Context, tradeID arb.TradeID) (arb.Trade, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, t := range f.trades {
+		if t.ID == tradeID {
+			return t, nil
+		}
+	}
+	return arb.Trade{}, ports.ErrNotFound
+}
+
+func (f *fakeStore) ListArbTrades(_ context.Context, _ string, unsettledOnly bool, _ int32) ([]arb.Trade, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []arb.Trade
+	for _, t := range f.trades {
+		if !unsettledOnly || !t.Status.Final() {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStore) ListUnsettledArbTrades(ctx context.Context, botID string) ([]arb.Trade, error) {
+	f.mu.Lock()
+	f.steps[botID]++
+	f.mu.Unlock()
+	return f.ListArbTrades(ctx, "", true, 0)
+}
+
+func (f *fakeStore) stepsOf(botID string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.steps[botID]
+}
+
+func (f *fakeStore) UpdateArbTrade(_ context.Context, t arb.Trade) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.trades {
+		if f.trades[i].ID == t.ID {
+			f.trades[i] = t
+			return nil
+		}
+	}
+	return ports.ErrNotFound
+}
+
+func (f *fakeStore) GetOrder(_ context.Context, orderID order.ClientOrderID) (order.Record, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	rec, ok := f.orders[orderID]
+	if !ok {
+		return order.Record{}, ports.ErrNotFound
+	}
+	return rec, nil
+}
+
+func (f *fakeStore) setOrder(rec order.Record) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.orders[rec.ClientOrderID] = rec
+}
+
+func (f *fakeStore) trade(i int) arb.Trade {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.trades[i]
+}
+
+func (f *fakeStore) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.trades)
+}
+
+// fakePlacer stores each leg with the outcome its side is given: the
+// fraction of it that fills at once, or a refusal.
+type fakePlacer struct {
+	store  *fakeStore
+	mu     sync.Mutex
+	placed []order.Request
+	fill   map[order.Side]string // fraction filled; absent leaves the leg open
+	refuse map[order.Side]bool
+}
+
+func (f *fakePlacer) Place(_ context.Context, req order.Request) (orderservice.PlaceResult, error) {
+	f.mu.Lock()
+	f.placed = append(f.placed, req)
+	fraction, filled := f.fill[req.Side]
+	refused := f.refuse[req.Side]
+	f.mu.Unlock()
+	if refused {
+		return orderservice.PlaceResult{}, errors.New("risk: max notional")
+	}
+	rec := order.Record{ClientOrderID: req.ClientOrderID, BotID: req.BotID, Side: req.Side, Qty: req.Qty, Status: order.StatusOpen}
+	if filled {
+		rec.FilledQty = req.Qty.Mul(decimal.RequireFromString(fraction))
+		rec.Status = order.StatusFilled
+		if !rec.FilledQty.Equal(req.Qty) {
+			rec.Status = order.StatusCanceled // IOC remainder
+		}
+	}
+	f.store.setOrder(rec)
+	return orderservice.PlaceResult{ClientOrderID: req.ClientOrderID, Status: rec.Status}, nil
+}
+
+func (f *fakePlacer) requests() []order.Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.placed)
+}
+
+type harness struct {
+	svc         *Service
+	store       *fakeStore
+	placer      *fakePlacer
+	alpha, beta *fakeExchange
+	metrics     *Metrics
+	clk         *clockwork.FakeClock
+	bus         *bus.InProc
+	instruments []instrument.Instrument
+}
+
+// newHarness quotes BTC at 100 on alpha and 101.5 on beta, a 129.72 bps
+// edge after fees buying on alpha, with 1000 USDT free on alpha and 0.5
+// BTC free on beta.
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+	metrics, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &fakeStore{orders: map[order.ClientOrderID]order.Record{}, steps: map[string]int{}}
+	h := &harness{
+		store:   store,
+		placer:  &fakePlacer{store: store, fill: map[order.Side]string{order.Buy: "1", order.Sell: "1"}},
+		alpha:   &fakeExchange{venue: "alpha", balances: free("USDT", "1000")},
+		beta:    &fakeExchange{venue: "beta", balances: free("BTC", "0.5")},
+		metrics: metrics, clk: clockwork.NewFakeClock(), bus: bus.NewInProc(),
+	}
+	t.Cleanup(h.bus.Close)
+	h.alpha.quote("99.9", "100")
+	h.beta.quote("101.5", "101.6")
+	registry := exchange.NewRegistry([]ports.Exchange{h.alpha, h.beta})
+	h.svc = New([]arb.Spec{testSpec()}, h.placer, store, registry, h, nil, h.bus, h.clk, log.Nop(), time.Second, metrics)
+	return h
+}
+
+// ListInstruments serves the harness's catalog entries.
+func (h *harness) ListInstruments(_ context.Context, venue instrument.VenueID) ([]instrument.Instrument, error) {
+	var out []instrument.Instrument
+	for _, inst := range h.instruments {
+		if inst.Venue == venue {
+			out = append(out, inst)
+		}
+	}
+	return out, nil
+}
+
+func (h *harness) step(t *testing.T) {
+	t.Helper()
+	if err := h.svc.step(t.Context(), testSpec()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSpreadTradesBothLegsSizedToFreeBalances(t *testing.T) {
+	t.Parallel()
+	h := newHarness(t)
+	h.step(t)
+
+	reqs := h.placer.requests()
+	if len(reqs) != 2 {
+		t.Fatalf("placed %d legs, want 2", len(reqs))
+	}
+	slices.SortFunc(reqs, func(a, b order.Request) int { return cmp.Compare(a.Side, b.Side) }) // buy before sell
+	buy, sell := reqs[0], reqs[1]
+	half := decimal.RequireFromString("0.5")
+	if buy.Instrument.Venue != "alpha" || buy.Side != order.Buy || !buy.Price.Equal(decimal.NewFromInt(100)) ||
+		buy.TimeInForce != order.IOC || buy.BotID != botID || !buy.Qty.Equal(half) {
+		t.Fatalf("buy leg = %+v", buy)
+	}
+	if sell.Instrument.Venue != "beta" || sell.Side != order.Sell || !sell.Price.Equal(decimal.RequireFromString("101.5")) || !sell.Qty.Equal(half) {
+		t.Fatalf("sell leg = %+v", sell)
+	}
+	tr := h.store.trade(0)
+	if tr.Status != arb.StatusBalanced || tr.Reason != "both legs filled 0.5" || !tr.EdgeBps.Equal(decimal.RequireFromString("129.72")) {
+		t.Fatalf("trade = %+v", tr)
+	}
+	if got := testutil.ToFloat64(h.metrics.edge.WithLabelValues(botID)); got != 129.72 {
+		t.Fatalf("arb_edge_bps = %v", got)
+	}
+
+	h.step(t) // settled trades do not block the next one
+	if n := h.store.count(); n != 2 {
+		t.Fatalf("trades = %d, want 2", n)
+	}
+}
+
+func TestOneSidedFillNeedsAHedgeUntilResolved(t *testing.T) {
+	t.Parallel()
+	h := newHarness(t)
+	h.placer.refuse = map[order.Side]bool{order.Sell: true}
+	h.step(t)
+
+	tr := h.store.trade(0)
+	if tr.Status != arb.StatusHedgeNeeded || tr.HedgeSide != order.Sell || !tr.HedgeQty.Equal(decimal.RequireFromString("0.5")) ||
+		tr.Reason != "bought 0.5 on alpha, sold 0 on beta" {
+		t.Fatalf("trade = %+v", tr)
+	}
+	if got := testutil.ToFloat64(h.metrics.hedges.WithLabelValues(botID)); got != 1 {
+		t.Fatalf("arb_hedges_needed = %v", got)
+	}
+
+	h.step(t) // the hedge blocks the bot
+	if n := len(h.placer.requests()); n != 2 {
+		t.Fatalf("placed %d legs while a hedge is needed, want 2", n)
+	}
+
+	if _, err := h.svc.Resolve(t.Context(), "nope", ""); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("Resolve(unknown) err = %v", err)
+	}
+	resolved, err := h.svc.Resolve(t.Context(), tr.ID, "sold 0.5 on alpha")
+	if err != nil || resolved.Status != arb.StatusHedged || resolved.Reason != "hedged: sold 0.5 on alpha" {
+		t.Fatalf("Resolve = %+v, %v", resolved, err)
+	}
+	if _, err := h.svc.Resolve(t.Context(), tr.ID, ""); !errors.Is(err, arb.ErrNoHedgeNeeded) {
+		t.Fatalf("second Resolve err = %v", err)
+	}
+
+	h.placer.refuse = nil
+	h.step(t)
+	if n := h.store.count(); n != 2 || h.store.trade(1).Status != arb.StatusBalanced {
+		t.Fatalf("trades = %d, want a second, balanced one", n)
+	}
+	if got := testutil.ToFloat64(h.metrics.hedges.WithLabelValues(botID)); got != 0 {
+		t.Fatalf("arb_hedges_needed = %v after resolve", got)
+	}
+}
+
+func TestNoTrade(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		setup  func(*harness)
+		reason string // arb_skipped_total reason, empty for none
+	}{
+		{name: "edge below the minimum", setup: func(h *harness) { h.beta.quote("100.2", "100.3") }},
+		{name: "no quote", setup: func(h *harness) { h.beta.quote("0", "0") }, reason: "no_quote"},
+		{name: "no base to sell", setup: func(h *harness) { h.beta.balances = free("USDT", "5000") }, reason: "insufficient_balance"},
+		{name: "below the venue minimum", setup: func(h *harness) {
+			h.instruments = []instrument.Instrument{{
+				Venue: "beta", Type: instrument.TypeSpot, Base: "BTC", Quote: "USDT",
+				Rules: instrument.Rules{MinQty: decimal.NewFromInt(1)},
+			}}
+		}, reason: "below_minimum"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			h := newHarness(t)
+			tt.setup(h)
+			h.step(t)
+			if n := len(h.placer.requests()); n != 0 {
+				t.Fatalf("placed %d legs, want none", n)
+			}
+			if tt.reason != "" {
+				if got := testutil.ToFloat64(h.metrics.skipped.WithLabelValues(botID, tt.reason)); got != 1 {
+					t.Fatalf("arb_skipped_total{reason=%q} = %v", tt.reason, got)
+				}
+			}
+		})
+	}
+}
+
+func TestLegEventSettlesWithoutWaitingForTheInterval(t *testing.T) {
+	t.Parallel()
+	h := newHarness(t)
+	h.placer.fill = map[order.Side]string{order.Buy: "1"} // the sell leg rests for now
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() { done <- h.svc.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Run: %v", err)
+		}
+	})
+	waitFor(t, func() bool { return h.store.count() == 1 })
+	tr := h.store.trade(0)
+	if tr.Status != arb.StatusOpen {
+		t.Fatalf("trade = %+v, want open", tr)
+	}
+
+	sell, _ := h.store.GetOrder(t.Context(), tr.SellOrderID)
+	sell.FilledQty, sell.Status = decimal.RequireFromString("0.2"), order.StatusCanceled
+	h.store.setOrder(sell)
+	body, err := json.Marshal(events.OrderUpdatedPayload{ClientOrderID: tr.SellOrderID, BotID: botID, Status: order.StatusCanceled})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h.bus.Publish(t.Context(), bus.Event{Subject: events.SubjectOrderUpdated, Payload: json.RawMessage(body)}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return h.store.trade(0).Status == arb.StatusHedgeNeeded })
+	if tr := h.store.trade(0); tr.HedgeSide != order.Sell || !tr.HedgeQty.Equal(decimal.RequireFromString("0.3")) {
+		t.Fatalf("trade = %+v", tr)
+	}
+}
+
+func TestLegEventStepsOnlyItsBot(t *testing.T) {
+	t.Parallel()
+	h := newHarness(t)
+	other := testSpec()
+	other.BotID = "arb-2"
+	h.svc = New([]arb.Spec{testSpec(), other}, h.placer, h.store, exchange.NewRegistry([]ports.Exchange{h.alpha, h.beta}),
+		h, nil, h.bus, h.clk, log.Nop(), time.Second, h.metrics)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() { done <- h.svc.Run(ctx) }()
+	waitFor(t, func() bool { return h.store.stepsOf(other.BotID) == 1 })
+
+	body, err := json.Marshal(events.OrderUpdatedPayload{ClientOrderID: "leg", BotID: botID, Status: order.StatusCanceled})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for want := 2; want <= 3; want++ {
+		if err := h.bus.Publish(t.Context(), bus.Event{Subject: events.SubjectOrderUpdated, Payload: json.RawMessage(body)}); err != nil {
+			t.Fatal(err)
+		}
+		waitFor(t, func() bool { return h.store.stepsOf(botID) == want })
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got := h.store.stepsOf(other.BotID); got != 1 {
+		t.Fatalf("%s stepped %d times, want only at startup", other.BotID, got)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not reached")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,58 @@
+package arb
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shopspring/decimal"
+)
+
+// Metrics holds the service's Prometheus instruments.
+type Metrics struct {
+	edge    *prometheus.GaugeVec
+	trades  *prometheus.CounterVec
+	skipped *prometheus.CounterVec
+	hedges  *prometheus.GaugeVec
+}
+
+// NewMetrics registers the service metrics on the given registry.
+func NewMetrics(reg *prometheus.Registry) (*Metrics, error) {
+	m := &Metrics{
+		edge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "arb_edge_bps",
+			Help: "Best edge net of fees across a bot's venues at its last look, in basis points.",
+		}, []string{"bot"}),
+		trades: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "arb_trades_total",
+			Help: "Arbitrage trades by how they ended: balanced, hedge_needed or hedged.",
+		}, []string{"bot", "outcome"}),
+		skipped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "arb_skipped_total",
+			Help: "Looks that placed no trade for want of a quote (no_quote), of funds (insufficient_balance) or of size (below_minimum).",
+		}, []string{"bot", "reason"}),
+		hedges: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "arb_hedges_needed",
+			Help: "A bot's trades waiting for an operator to hedge them.",
+		}, []string{"bot"}),
+	}
+	for _, collector := range []prometheus.Collector{m.edge, m.trades, m.skipped, m.hedges} {
+		if err := reg.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func (m *Metrics) observeEdge(bot string, bps decimal.Decimal) {
+	m.edge.With(prometheus.Labels{"bot": bot}).Set(bps.InexactFloat64())
+}
+
+func (m *Metrics) observeTrade(bot, outcome string) {
+	m.trades.With(prometheus.Labels{"bot": bot, "outcome": outcome}).Inc()
+}
+
+func (m *Metrics) observeSkip(bot, reason string) {
+	m.skipped.With(prometheus.Labels{"bot": bot, "reason": reason}).Inc()
+}
+
+func (m *Metrics) setHedgesNeeded(bot string, n int) {
+	m.hedges.With(prometheus.Labels{"bot": bot}).Set(float64(n))
+}
@@ -0,0 +1,103 @@
+// Package auth admits control-plane clients by bearer token and manages
+// the tokens kept in Postgres (ADR-0012). Tokens from the tokens file sit
+// beside them: they are how the first admin token exists before the API
+// can create one, and they change only with the file.
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/romanornr/delta-works/internal/domain/auth"
+	"github.com/romanornr/delta-works/internal/id"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// Service authenticates tokens and manages the stored ones.
+type Service struct {
+	store ports.TokenStore
+	file  []auth.Token
+	clk   clockwork.Clock
+	log   log.Logger
+}
+
+// New builds the service. file holds the tokens read from the tokens
+// file, none when it is not configured.
+func New(store ports.TokenStore, file []auth.Token, clk clockwork.Clock, logger log.Logger) *Service {
+	return &Service{store: store, file: file, clk: clk, log: log.Component(logger, "auth")}
+}
+
+// Authenticate returns the valid token whose secret was presented, or
+// auth.ErrUnauthenticated. File tokens are checked first, so a bootstrap
+// token works while Postgres is unreachable.
+func (s *Service) Authenticate(ctx context.Context, secret string) (auth.Token, error) {
+	if secret == "" {
+		return auth.Token{}, auth.ErrUnauthenticated
+	}
+	hash := auth.HashSecret(secret)
+	for _, t := range s.file {
+		if subtle.ConstantTimeCompare(hash[:], t.Hash[:]) == 1 {
+			return t, nil
+		}
+	}
+	t, err := s.store.TokenByHash(ctx, hash)
+	if errors.Is(err, ports.ErrNotFound) {
+		return auth.Token{}, auth.ErrUnauthenticated
+	}
+	return t, err
+}
+
+// Create stores a new token and returns it with its secret, which is not
+// kept and cannot be shown again. A name held by a file token or a valid
+// stored token is auth.ErrNameTaken.
+func (s *Service) Create(ctx context.Context, name string, scopes []auth.Scope) (auth.Token, string, error) {
+	if err := auth.ValidateName(name); err != nil {
+		return auth.Token{}, "", err
+	}
+	if len(scopes) == 0 {
+		return auth.Token{}, "", fmt.Errorf("%w: at least one scope is required", auth.ErrInvalidToken)
+	}
+	if slices.ContainsFunc(s.file, func(t auth.Token) bool { return t.Name == name }) {
+		return auth.Token{}, "", auth.ErrNameTaken
+	}
+	secret := auth.NewSecret()
+	t := auth.Token{
+		ID: id.New(), Name: name, Scopes: slices.Clone(scopes), Hash: auth.HashSecret(secret),
+		Source: auth.SourceStore, CreatedAt: s.clk.Now().UTC(),
+	}
+	inserted, err := s.store.InsertToken(ctx, t)
+	if err != nil {
+		return auth.Token{}, "", err
+	}
+	if !inserted {
+		return auth.Token{}, "", auth.ErrNameTaken
+	}
+	s.log.Info().Str("token_id", t.ID).Str("name", name).Str("scopes", auth.JoinScopes(scopes)).Msg("api token created")
+	return t, secret, nil
+}
+
+// List returns the file tokens, then the stored ones oldest first,
+// including revoked stored tokens when asked.
+func (s *Service) List(ctx context.Context, includeRevoked bool) ([]auth.Token, error) {
+	stored, err := s.store.ListTokens(ctx, includeRevoked)
+	if err != nil {
+		return nil, err
+	}
+	return append(slices.Clone(s.file), stored...), nil
+}
+
+// Revoke revokes a stored token by ID; it stops authenticating at once.
+// An unknown or already revoked ID is ports.ErrNotFound.
+func (s *Service) Revoke(ctx context.Context, tokenID string) error {
+	if err := s.store.RevokeToken(ctx, tokenID, s.clk.Now().UTC()); err != nil {
+		return err
+	}
+	s.log.Info().Str("token_id", tokenID).Msg("api token revoked")
+	return nil
+}
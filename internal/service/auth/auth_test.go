@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/romanornr/delta-works/internal/domain/auth"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+type fakeStore struct {
+	mu     sync.Mutex
+	tokens []auth.Token
+	err    error
+}
+
+func (f *fakeStore) InsertToken(_ context.Context, t auth.Token) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if slices.ContainsFunc(f.tokens, func(held auth.Token) bool { return held.Name == t.Name && !held.Revoked() }) {
+		return false, nil
+	}
+	f.tokens = append(f.tokens, t)
+	return true, nil
+}
+
+func (f *fakeStore) TokenByHash(_ context.Context, hash auth.Hash) (auth.Token, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return auth.Token{}, f.err
+	}
+	for _, t := range f.tokens {
+		if t.Hash == hash && !t.Revoked() {
+			return t, nil
+		}
+	}
+	return auth.Token{}, ports.ErrNotFound
+}
+
+func (f *fakeStore) ListTokens(_ context.Context, includeRevoked bool) ([]auth.Token, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []auth.Token
+	for _, t := range f.tokens {
+		if includeRevoked || !t.Revoked() {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStore) RevokeToken(_ context.Context, id string, at time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.tokens {
+		if f.tokens[i].ID == id && !f.tokens[i].Revoked() {
+			f.tokens[i].RevokedAt = at
+			return nil
+		}
+	}
+	return ports.ErrNotFound
+}
+
+func TestService(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	bootstrap := auth.Token{Name: "ops", Scopes: []auth.Scope{auth.ScopeAdmin}, Hash: auth.HashSecret("ops-secret"), Source: auth.SourceFile}
+	store := &fakeStore{}
+	clk := clockwork.NewFakeClockAt(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC))
+	s := New(store, []auth.Token{bootstrap}, clk, log.Nop())
+
+	if got, err := s.Authenticate(ctx, "ops-secret"); err != nil || got.Name != "ops" || got.Source != auth.SourceFile {
+		t.Fatalf("Authenticate(file token) = %+v, %v", got, err)
+	}
+	for _, secret := range []string{"", "wrong"} {
+		if _, err := s.Authenticate(ctx, secret); !errors.Is(err, auth.ErrUnauthenticated) {
+			t.Fatalf("Authenticate(%q) err = %v, want ErrUnauthenticated", secret, err)
+		}
+	}
+
+	created, secret, err := s.Create(ctx, "dashboard", []auth.Scope{auth.ScopeRead})
+	if err != nil || len(created.ID) != 26 || created.Hash != auth.HashSecret(secret) || !created.CreatedAt.Equal(clk.Now()) {
+		t.Fatalf("Create = %+v, %q, %v", created, secret, err)
+	}
+	if got, err := s.Authenticate(ctx, secret); err != nil || got.ID != created.ID || !got.Allows(auth.ScopeRead) || got.Allows(auth.ScopeTrade) {
+		t.Fatalf("Authenticate(stored token) = %+v, %v", got, err)
+	}
+	for _, name := range []string{"dashboard", "ops"} {
+		if _, _, err := s.Create(ctx, name, []auth.Scope{auth.ScopeRead}); !errors.Is(err, auth.ErrNameTaken) {
+			t.Fatalf("Create(%s) again err = %v, want ErrNameTaken", name, err)
+		}
+	}
+	if _, _, err := s.Create(ctx, "Bad Name", []auth.Scope{auth.ScopeRead}); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Fatalf("Create with a bad name err = %v, want ErrInvalidToken", err)
+	}
+	if _, _, err := s.Create(ctx, "empty", nil); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Fatalf("Create without scopes err = %v, want ErrInvalidToken", err)
+	}
+
+	if tokens, err := s.List(ctx, false); err != nil || len(tokens) != 2 || tokens[0].Name != "ops" || tokens[1].ID != created.ID {
+		t.Fatalf("List = %+v, %v", tokens, err)
+	}
+	if err := s.Revoke(ctx, created.ID); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if _, err := s.Authenticate(ctx, secret); !errors.Is(err, auth.ErrUnauthenticated) {
+		t.Fatalf("Authenticate after revoking err = %v, want ErrUnauthenticated", err)
+	}
+	if err := s.Revoke(ctx, created.ID); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("Revoke twice err = %v, want ErrNotFound", err)
+	}
+	if tokens, err := s.List(ctx, true); err != nil || len(tokens) != 2 || !tokens[1].Revoked() {
+		t.Fatalf("List(include revoked) = %+v, %v", tokens, err)
+	}
+}
+
+func TestAuthenticateStoreFailure(t *testing.T) {
+	t.Parallel()
+	outage := errors.New("connection refused")
+	bootstrap := auth.Token{Name: "ops", Scopes: []auth.Scope{auth.ScopeAdmin}, Hash: auth.HashSecret("ops-secret"), Source: auth.SourceFile}
+	s := New(&fakeStore{err: outage}, []auth.Token{bootstrap}, clockwork.NewFakeClock(), log.Nop())
+	if _, err := s.Authenticate(t.Context(), "ops-secret"); err != nil {
+		t.Fatalf("file token during a store outage: %v", err)
+	}
+	if _, err := s.Authenticate(t.Context(), "other"); !errors.Is(err, outage) {
+		t.Fatalf("stored token during a store outage err = %v, want the outage, not a denial", err)
+	}
+}
@@ -0,0 +1,127 @@
+// Package catalog keeps the local instrument catalog in step with the
+// venues. Each sync diffs a venue's listing against the stored entries and
+// applies the changes with their history rows and instrument.changed
+// events; placement then resolves instruments locally.
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/exchange"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// fetchTimeout bounds one venue's listing fetch so a stalled venue cannot
+// hold up the others or shutdown.
+const fetchTimeout = time.Minute
+
+// Service syncs every registered venue's spot listing into the store.
+type Service struct {
+	registry exchange.Registry
+	store    ports.InstrumentStore
+	clk      clockwork.Clock
+	log      log.Logger
+	interval time.Duration
+	metrics  *Metrics
+}
+
+// New builds the service. Metrics must not be nil.
+func New(
+	registry exchange.Registry,
+	store ports.InstrumentStore,
+	clk clockwork.Clock,
+	logger log.Logger,
+	interval time.Duration,
+	metrics *Metrics,
+) *Service {
+	return &Service{
+		registry: registry,
+		store:    store,
+		clk:      clk,
+		log:      log.Component(logger, "catalog"),
+		interval: interval,
+		metrics:  metrics,
+	}
+}
+
+// Run syncs every interval until ctx is canceled. The startup sync is the
+// caller's, so Run waits one interval before its first.
+func (s *Service) Run(ctx context.Context) error {
+	ticker := s.clk.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.Chan():
+			if err := s.Sync(ctx); err != nil && ctx.Err() == nil {
+				s.log.Error().Err(err).Msg("instrument catalog sync failed")
+			}
+		}
+	}
+}
+
+// Sync brings every venue's catalog entries up to date. A venue that
+// cannot be listed keeps its entries as they are: an outage must not read
+// as a delisting. Only store failures are returned.
+func (s *Service) Sync(ctx context.Context) error {
+	var errs []error
+	for _, ex := range s.registry.All() {
+		if err := s.syncVenue(ctx, ex); err != nil {
+			s.metrics.observeError(ex.ID(), errStore)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Service) syncVenue(ctx context.Context, ex ports.Exchange) error {
+	venue := ex.ID()
+	fetchCtx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	listing, err := ex.Instruments(fetchCtx, instrument.TypeSpot)
+	cancel()
+	if err != nil {
+		if ctx.Err() == nil {
+			s.metrics.observeError(venue, errFetch)
+			s.log.Warn().Str("venue", string(venue)).Err(err).Msg("instrument listing failed; keeping the catalog")
+		}
+		return nil
+	}
+	stored, err := s.store.ListInstruments(ctx, venue)
+	if err != nil {
+		return fmt.Errorf("catalog %s: %w", venue, err)
+	}
+	var catalog []instrument.Instrument
+	for _, inst := range stored {
+		if inst.Type == instrument.TypeSpot {
+			catalog = append(catalog, inst)
+		}
+	}
+	if len(listing) == 0 && len(catalog) > 0 {
+		// An empty listing is far likelier a venue or config fault than a
+		// venue delisting everything at once.
+		s.metrics.observeError(venue, errEmpty)
+		s.log.Warn().Str("venue", string(venue)).Int("catalog", len(catalog)).
+			Msg("venue listed no instruments; keeping the catalog")
+		return nil
+	}
+
+	changes := instrument.Diff(catalog, listing)
+	if err := s.store.ApplyInstrumentChanges(ctx, changes, s.clk.Now()); err != nil {
+		return fmt.Errorf("catalog %s: %w", venue, err)
+	}
+	for _, c := range changes {
+		s.metrics.observeChange(venue, c.Kind)
+		s.log.Info().Str("venue", string(venue)).Str("pair", c.Instrument.Pair()).
+			Str("change", string(c.Kind)).Str("status", string(c.Instrument.Status)).Msg("instrument changed")
+	}
+	s.metrics.observeSync(venue, s.clk.Now())
+	return nil
+}
@@ -0,0 +1,173 @@
+package catalog
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/exchange"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+type fakeVenue struct {
+	mu      sync.Mutex
+	listing []instrument.Instrument
+	err     error
+}
+
+func (f *fakeVenue) ID() instrument.VenueID { return "bybit" }
+
+func (f *fakeVenue) Instruments(context.Context, instrument.Type) ([]instrument.Instrument, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]instrument.Instrument(nil), f.listing...), f.err
+}
+
+func (f *fakeVenue) Ticker(context.Context, instrument.Instrument) (marketdata.Ticker, error) {
+	return marketdata.Ticker{}, nil
+}
+
+func (f *fakeVenue) Balances(context.Context, account.Type) ([]account.Balance, error) {
+	return nil, nil
+}
+
+func (f *fakeVenue) set(listing []instrument.Instrument, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listing, f.err = listing, err
+}
+
+type fakeStore struct {
+	mu      sync.Mutex
+	byKey   map[string]instrument.Instrument
+	applied []instrument.Change
+}
+
+func (f *fakeStore) ListInstruments(_ context.Context, venue instrument.VenueID) ([]instrument.Instrument, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []instrument.Instrument
+	for _, inst := range f.byKey {
+		if inst.Venue == venue {
+			out = append(out, inst)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStore) ApplyInstrumentChanges(_ context.Context, changes []instrument.Change, _ time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.byKey == nil {
+		f.byKey = map[string]instrument.Instrument{}
+	}
+	for _, c := range changes {
+		f.byKey[c.Instrument.Key()] = c.Instrument
+	}
+	f.applied = append(f.applied, changes...)
+	return nil
+}
+
+func (f *fakeStore) changes() []instrument.Change {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]instrument.Change(nil), f.applied...)
+}
+
+func spot(base, tick string) instrument.Instrument {
+	return instrument.Instrument{
+		Venue: "bybit", Type: instrument.TypeSpot, Base: money.Currency(base), Quote: "USDT",
+		VenueSymbol: base + "USDT", Rules: instrument.Rules{PriceIncrement: decimal.RequireFromString(tick)},
+	}
+}
+
+func newTestService(t *testing.T, venue *fakeVenue) (*Service, *fakeStore, *clockwork.FakeClock, *Metrics) {
+	t.Helper()
+	m, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &fakeStore{}
+	clk := clockwork.NewFakeClock()
+	return New(exchange.NewRegistry([]ports.Exchange{venue}), store, clk, log.Nop(), time.Hour, m), store, clk, m
+}
+
+func TestSync(t *testing.T) {
+	t.Parallel()
+	venue := &fakeVenue{listing: []instrument.Instrument{spot("BTC", "0.1"), spot("ETH", "0.01")}}
+	svc, store, _, m := newTestService(t, venue)
+
+	if err := svc.Sync(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	if got := store.changes(); len(got) != 2 || got[0].Kind != instrument.ChangeListed || got[0].Instrument.Status != instrument.StatusTrading {
+		t.Fatalf("first sync = %+v", got)
+	}
+	if err := svc.Sync(t.Context()); err != nil || len(store.changes()) != 2 {
+		t.Fatalf("unchanged listing applied %d changes, err=%v", len(store.changes())-2, err)
+	}
+
+	// Outages never delist: a failed or empty listing keeps the catalog.
+	venue.set(nil, ports.ErrVenueUnavailable)
+	if err := svc.Sync(t.Context()); err != nil {
+		t.Fatalf("venue outage = %v, want nil", err)
+	}
+	venue.set(nil, nil)
+	if err := svc.Sync(t.Context()); err != nil || len(store.changes()) != 2 {
+		t.Fatalf("empty listing applied changes, err=%v", err)
+	}
+	if fetch, empty := testutil.ToFloat64(m.errors.WithLabelValues("bybit", errFetch)), testutil.ToFloat64(m.errors.WithLabelValues("bybit", errEmpty)); fetch != 1 || empty != 1 {
+		t.Fatalf("errors fetch=%v empty=%v, want 1 and 1", fetch, empty)
+	}
+
+	venue.set([]instrument.Instrument{spot("BTC", "0.01")}, nil)
+	if err := svc.Sync(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	got := store.changes()[2:]
+	if len(got) != 2 || got[0].Kind != instrument.ChangeUpdated || got[1].Kind != instrument.ChangeDelisted || got[1].Instrument.Base != "ETH" {
+		t.Fatalf("third sync = %+v", got)
+	}
+	if n := testutil.ToFloat64(m.changes.WithLabelValues("bybit", string(instrument.ChangeDelisted))); n != 1 {
+		t.Fatalf("delisted = %v, want 1", n)
+	}
+}
+
+func TestRunSyncsEveryInterval(t *testing.T) {
+	t.Parallel()
+	venue := &fakeVenue{listing: []instrument.Instrument{spot("BTC", "0.1")}}
+	svc, store, clk, _ := newTestService(t, venue)
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() { done <- svc.Run(ctx) }()
+
+	if err := clk.BlockUntilContext(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.changes()) != 0 {
+		t.Fatal("Run synced before its first interval; the startup sync is the caller's")
+	}
+	clk.Advance(time.Hour)
+	deadline := time.Now().Add(5 * time.Second)
+	for len(store.changes()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("no sync after one interval")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Run = %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package catalog
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+)
+
+// Error kinds for instrument_sync_errors_total.
+const (
+	errFetch = "fetch"
+	errEmpty = "empty"
+	errStore = "store"
+)
+
+// Metrics holds the service's Prometheus instruments. The last-sync gauge
+// exists so a catalog that stopped following its venue can be alerted on.
+type Metrics struct {
+	errors   *prometheus.CounterVec
+	changes  *prometheus.CounterVec
+	lastSync *prometheus.GaugeVec
+}
+
+// NewMetrics registers the service metrics on the given registry.
+func NewMetrics(reg *prometheus.Registry) (*Metrics, error) {
+	m := &Metrics{
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "instrument_sync_errors_total",
+			Help: "Failed or skipped instrument catalog syncs.",
+		}, []string{"venue", "kind"}),
+		changes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "instrument_changes_total",
+			Help: "Instrument catalog changes applied, by kind.",
+		}, []string{"venue", "change"}),
+		lastSync: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "instrument_last_sync_timestamp_seconds",
+			Help: "Unix time of the last applied instrument catalog sync.",
+		}, []string{"venue"}),
+	}
+	for _, c := range []prometheus.Collector{m.errors, m.changes, m.lastSync} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func (m *Metrics) observeError(venue instrument.VenueID, kind string) {
+	m.errors.WithLabelValues(string(venue), kind).Inc()
+}
+
+func (m *Metrics) observeChange(venue instrument.VenueID, kind instrument.ChangeKind) {
+	m.changes.WithLabelValues(string(venue), string(kind)).Inc()
+}
+
+func (m *Metrics) observeSync(venue instrument.VenueID, at time.Time) {
+	m.lastSync.WithLabelValues(string(venue)).Set(float64(at.Unix()))
+}
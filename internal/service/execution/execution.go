@@ -0,0 +1,587 @@
+// Package execution works parent orders through execution algorithms. A
+// parent is stored with its schedule; each slice is an ordinary order
+// placed through the order service under the parent's ID. Progress is
+// read from those children, and the outbox order subjects on the bus tell
+// the service when a child's fill may have completed its parent. A tick
+// places the slices that fall due, so a restart resumes each schedule
+// where the store left it.
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/execution"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/events"
+	"github.com/romanornr/delta-works/internal/id"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+	orderservice "github.com/romanornr/delta-works/internal/service/order"
+	"github.com/romanornr/delta-works/internal/service/runloop"
+)
+
+// inboxBuffer sizes the queue of parents whose children moved. A full
+// inbox blocks the bus subscriber, and once the bus's own subscriber
+// buffer fills it drops events; a dropped event only delays its parent
+// to the next tick's sweep.
+const inboxBuffer = 64
+
+// benchmarkTimeout bounds the read of a VWAP's benchmark, which happens
+// while its parent is claimed.
+const benchmarkTimeout = 5 * time.Second
+
+// ErrParentFinished reports a pause, resume or cancel of a parent that
+// already completed or was canceled.
+var ErrParentFinished = errors.New("parent order already finished")
+
+// Placer is the order surface children trade through; *order.Service
+// satisfies it.
+type Placer interface {
+	Place(ctx context.Context, req order.Request) (orderservice.PlaceResult, error)
+	Cancel(ctx context.Context, orderID order.ClientOrderID) (order.Status, error)
+}
+
+// View is a parent with its children and the progress they add up to.
+// A VWAP's Benchmark is the market's VWAP over the parent's life, so far
+// for a live parent, and SlippageBps how far the fills sit from it; both
+// are zero when the market's trades are unknown.
+type View struct {
+	Parent      execution.Parent
+	Progress    execution.Progress
+	Children    []order.Record
+	Benchmark   decimal.Decimal
+	SlippageBps decimal.Decimal
+}
+
+// Service runs the execution algorithms.
+type Service struct {
+	store    ports.ParentOrderStore
+	placer   Placer
+	catalog  ports.InstrumentCatalog
+	trades   ports.TradeSeriesReader
+	bus      bus.Bus
+	clk      clockwork.Clock
+	log      log.Logger
+	tick     time.Duration
+	lookback time.Duration
+	metrics  *Metrics
+	inbox    chan order.ParentID
+	// jitter draws the u in [-1, 1] that varies a slice's size and wait.
+	jitter func() float64
+
+	// A decision on a parent claims it in busy for its whole length, so a
+	// tick, an event and an operator never act on the same parent at once.
+	// mu guards busy only and is not held across a decision, so a slice
+	// retrying against a slow venue holds up its own parent and no other.
+	mu   sync.Mutex
+	idle *sync.Cond
+	busy map[order.ParentID]bool
+}
+
+// New builds the service. Metrics must not be nil; catalog may be, in
+// which case slices are not floored to the instrument's quantity
+// increment and the order service's rule policy applies alone. trades may
+// be nil too, in which case a VWAP must be given its volume profile and
+// goes without a benchmark. tick spaces the passes that place due slices;
+// lookback is how much trade history a volume profile is read from.
+func New(store ports.ParentOrderStore, placer Placer, catalog ports.InstrumentCatalog, trades ports.TradeSeriesReader, eventBus bus.Bus, clk clockwork.Clock, logger log.Logger, tick, lookback time.Duration, metrics *Metrics) *Service {
+	s := &Service{
+		store:    store,
+		placer:   placer,
+		catalog:  catalog,
+		trades:   trades,
+		bus:      eventBus,
+		clk:      clk,
+		log:      log.Component(logger, "execution"),
+		tick:     tick,
+		lookback: lookback,
+		metrics:  metrics,
+		inbox:    make(chan order.ParentID, inboxBuffer),
+		jitter:   func() float64 { return rand.Float64()*2 - 1 }, //nolint:gosec // schedule noise, not a secret
+	}
+	s.idle = sync.NewCond(&s.mu)
+	s.busy = map[order.ParentID]bool{}
+	return s
+}
+
+// PlaceTWAP stores the parent and places its first slice. An empty parent
+// ID is generated; a retry under a stored ID with the same terms returns
+// that parent without placing anything more. A refused slice does not fail
+// the parent: its quantity rolls into the slices after it.
+func (s *Service) PlaceTWAP(ctx context.Context, p execution.Parent) (View, error) {
+	p.Algo = execution.AlgoTWAP
+	return s.place(ctx, p)
+}
+
+// PlaceIceberg stores the parent and places its first visible child.
+// Retries behave as PlaceTWAP's. A refused child cancels the parent.
+func (s *Service) PlaceIceberg(ctx context.Context, p execution.Parent) (View, error) {
+	p.Algo = execution.AlgoIceberg
+	p.Slices, p.Interval, p.Jitter = 0, 0, 0
+	return s.place(ctx, p)
+}
+
+// PlaceVWAP stores the parent and places its first slice, sizing each
+// slice by the share of profile's volume its window holds. An empty
+// profile is read from the market's trades over the lookback. Retries
+// behave as PlaceTWAP's and keep the stored curve.
+func (s *Service) PlaceVWAP(ctx context.Context, p execution.Parent, profile execution.VolumeProfile) (View, error) {
+	p.Algo, p.Jitter = execution.AlgoVWAP, 0
+	now := s.clk.Now()
+	if len(profile) == 0 {
+		if s.trades == nil {
+			return View{}, fmt.Errorf("%w: no trade history is configured; supply a volume profile", execution.ErrInvalidParent)
+		}
+		var err error
+		if profile, err = s.trades.VolumeProfile(ctx, p.Instrument, now.Add(-s.lookback)); err != nil {
+			return View{}, fmt.Errorf("read volume profile: %w", err)
+		}
+	}
+	if err := profile.Check(); err != nil {
+		return View{}, err
+	}
+	if p.Slices > 0 && p.Slices <= execution.MaxSlices && p.Interval > 0 {
+		p.Curve = profile.Curve(now, p.Interval, p.Slices)
+	}
+	return s.place(ctx, p)
+}
+
+func (s *Service) place(ctx context.Context, p execution.Parent) (View, error) {
+	if p.ID == "" {
+		p.ID = order.ParentID(id.New())
+	}
+	if err := execution.Check(p); err != nil {
+		return View{}, err
+	}
+	now := s.clk.Now()
+	p.Status, p.SlicesSent, p.Reason = execution.StatusRunning, 0, ""
+	p.NextChildID, p.NextAt = order.ClientOrderID(id.New()), now
+	p.CreatedAt, p.UpdatedAt = now, now
+
+	defer s.claim(p.ID)()
+	created, err := s.store.CreateParent(ctx, p)
+	if err != nil {
+		return View{}, err
+	}
+	if !created {
+		stored, err := s.store.GetParent(ctx, p.ID)
+		if err != nil {
+			return View{}, err
+		}
+		if !sameParent(stored, p) {
+			return View{}, fmt.Errorf("%w: parent %s", orderservice.ErrIdentityMismatch, p.ID)
+		}
+		return s.view(ctx, stored)
+	}
+	s.log.Info().Str("parent", string(p.ID)).Str("algo", string(p.Algo)).Str("venue", string(p.Instrument.Venue)).
+		Str("qty", p.Qty.String()).Int("slices", p.Slices).Dur("interval", p.Interval).Msg("parent order placed")
+	if p, err = s.step(ctx, p); err != nil {
+		return View{}, err
+	}
+	return s.view(ctx, p)
+}
+
+// Pause stops the parent placing slices. Children already working carry
+// on. Pausing a paused parent changes nothing.
+func (s *Service) Pause(ctx context.Context, parentID order.ParentID) (View, error) {
+	return s.transition(ctx, parentID, execution.StatusPaused, "paused by request")
+}
+
+// Resume sets a paused parent running again, its next slice due now.
+// Resuming a running parent changes nothing.
+func (s *Service) Resume(ctx context.Context, parentID order.ParentID) (View, error) {
+	return s.transition(ctx, parentID, execution.StatusRunning, "")
+}
+
+// Cancel cancels the parent's working children and the parent with them.
+// A child whose cancel fails leaves the parent as it was, and the error is
+// returned for the caller to retry.
+func (s *Service) Cancel(ctx context.Context, parentID order.ParentID) (View, error) {
+	defer s.claim(parentID)()
+	p, err := s.store.GetParent(ctx, parentID)
+	if err != nil {
+		return View{}, err
+	}
+	if p.Status.Final() {
+		return View{}, fmt.Errorf("%w: %s is %s", ErrParentFinished, parentID, p.Status)
+	}
+	children, err := s.store.ListChildren(ctx, parentID)
+	if err != nil {
+		return View{}, err
+	}
+	for _, child := range children {
+		if child.Status.Terminal() {
+			continue
+		}
+		if _, err := s.placer.Cancel(ctx, child.ClientOrderID); err != nil && !errors.Is(err, orderservice.ErrTerminal) {
+			return View{}, fmt.Errorf("cancel child %s: %w", child.ClientOrderID, err)
+		}
+	}
+	if p, err = s.settle(ctx, p, execution.StatusCanceled, "canceled by request"); err != nil {
+		return View{}, err
+	}
+	return s.view(ctx, p)
+}
+
+// Get returns the parent with its children, or ports.ErrNotFound.
+func (s *Service) Get(ctx context.Context, parentID order.ParentID) (View, error) {
+	p, err := s.store.GetParent(ctx, parentID)
+	if err != nil {
+		return View{}, err
+	}
+	return s.view(ctx, p)
+}
+
+// List returns at most limit parents newest first, the live ones only
+// when liveOnly is set.
+func (s *Service) List(ctx context.Context, venue instrument.VenueID, liveOnly bool, limit int32) ([]execution.Parent, error) {
+	return s.store.ListParents(ctx, venue, liveOnly, limit)
+}
+
+// Run passes over the live parents once, then every tick, placing the
+// slices that fall due, and checks a parent for completion whenever one
+// of its children fills or ends, until ctx is canceled. A refused slice is
+// logged and counted; a store failure stops the service so the process
+// fails fast.
+func (s *Service) Run(ctx context.Context) error {
+	unsubscribe, err := s.bus.Subscribe("order.", s.route)
+	if err != nil {
+		return fmt.Errorf("execution: subscribe to order events: %w", err)
+	}
+	defer unsubscribe()
+
+	ticker := s.clk.NewTicker(s.tick)
+	defer ticker.Stop()
+	if err := s.sweepLive(ctx); err != nil {
+		return runloop.Err(ctx, fmt.Errorf("parent order store: %w", err))
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case parentID := <-s.inbox:
+			if err := s.stepOne(ctx, parentID); err != nil {
+				return runloop.Err(ctx, fmt.Errorf("parent order store: %w", err))
+			}
+		case <-ticker.Chan():
+			if err := s.sweepLive(ctx); err != nil {
+				return runloop.Err(ctx, fmt.Errorf("parent order store: %w", err))
+			}
+		}
+	}
+}
+
+// route forwards the parent of a child that filled or ended. It blocks
+// while the inbox is full, which makes the bus drop this subscriber's
+// events past its buffer; the next sweep catches up on their parents.
+func (s *Service) route(ctx context.Context, event bus.Event) {
+	raw, ok := event.Payload.(json.RawMessage)
+	if !ok {
+		return
+	}
+	var parentID order.ParentID
+	switch event.Subject {
+	case events.SubjectOrderFilled:
+		var p events.OrderFilledPayload
+		if err := json.Unmarshal(raw, &p); err != nil || !p.FilledQty.IsPositive() {
+			return
+		}
+		parentID = p.ParentID
+	case events.SubjectOrderUpdated:
+		var p events.OrderUpdatedPayload
+		if err := json.Unmarshal(raw, &p); err != nil || !p.Status.Terminal() {
+			return
+		}
+		parentID = p.ParentID
+	default:
+		return
+	}
+	if parentID == "" {
+		return
+	}
+	select {
+	case s.inbox <- parentID:
+	case <-ctx.Done():
+	}
+}
+
+func (s *Service) sweepLive(ctx context.Context) error {
+	parents, err := s.store.ListLiveParents(ctx)
+	if err != nil {
+		return err
+	}
+	for _, live := range parents {
+		if err := s.stepOne(ctx, live.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) stepOne(ctx context.Context, parentID order.ParentID) error {
+	defer s.claim(parentID)()
+	p, err := s.store.GetParent(ctx, parentID)
+	switch {
+	case errors.Is(err, ports.ErrNotFound):
+		return nil
+	case err != nil:
+		return err
+	}
+	_, err = s.step(ctx, p)
+	return err
+}
+
+// step completes the parent if its children are done, or places its next
+// slice if one is due. A slice whose child is already stored was placed
+// before a restart cut the step short, so only the schedule moves on.
+// Only store errors are returned. Callers hold the parent's claim.
+func (s *Service) step(ctx context.Context, p execution.Parent) (execution.Parent, error) {
+	if p.Status.Final() {
+		return p, nil
+	}
+	children, err := s.store.ListChildren(ctx, p.ID)
+	if err != nil {
+		return p, err
+	}
+	progress := execution.Summarize(children)
+	if done, reason := p.Done(progress); done {
+		return s.settle(ctx, p, execution.StatusCompleted, reason)
+	}
+	if p.Status != execution.StatusRunning {
+		return p, nil
+	}
+	if p.Algo == execution.AlgoIceberg {
+		return s.refill(ctx, p, children, progress)
+	}
+	now := s.clk.Now()
+	if p.SlicesSent >= p.Slices || now.Before(p.NextAt) {
+		return p, nil
+	}
+
+	_, err = s.store.GetOrder(ctx, p.NextChildID)
+	switch {
+	case err == nil:
+	case !errors.Is(err, ports.ErrNotFound):
+		return p, err
+	default:
+		s.placeSlice(ctx, p, progress)
+	}
+	p.NextAt = now.Add(p.Wait(s.jitter()))
+	if p, err = s.advance(ctx, p); err != nil {
+		return p, err
+	}
+	if p.SlicesSent < p.Slices {
+		return p, nil
+	}
+	// The last slice may have ended at once; a slice refused by the order
+	// service never produces the event that would complete the parent.
+	if children, err = s.store.ListChildren(ctx, p.ID); err != nil {
+		return p, err
+	}
+	if done, reason := p.Done(execution.Summarize(children)); done {
+		return s.settle(ctx, p, execution.StatusCompleted, reason)
+	}
+	return p, nil
+}
+
+// placeSlice places the parent's next child for the slice's share of what
+// remains. A refusal is logged and counted; what the child would have
+// taken rolls into the slices after it.
+func (s *Service) placeSlice(ctx context.Context, p execution.Parent, progress execution.Progress) {
+	rules, err := orderservice.CatalogRules(ctx, s.catalog, p.Instrument)
+	if err != nil {
+		s.log.Warn().Str("parent", string(p.ID)).Err(err).Msg("instrument rules unavailable; slicing unrounded")
+	}
+	qty := p.Slice(progress, rules, s.jitter())
+	if !qty.IsPositive() {
+		s.metrics.observeSlice(p.Algo, "skipped")
+		return
+	}
+	_, err = s.placer.Place(ctx, p.Child(p.NextChildID, qty, p.LimitPrice))
+	switch {
+	case err == nil:
+		s.metrics.observeSlice(p.Algo, "placed")
+	case errors.Is(err, orderservice.ErrSubmitUnsettled):
+		s.metrics.observeSlice(p.Algo, "unsettled")
+	default:
+		s.metrics.observeSlice(p.Algo, "refused")
+		s.log.Warn().Str("parent", string(p.ID)).Str("client_order_id", string(p.NextChildID)).
+			Str("qty", qty.String()).Err(err).Msg("slice refused; its quantity rolls forward")
+	}
+}
+
+// refill keeps one child of the iceberg's display size working, placing
+// the next once the last has filled. A child that ended short of its
+// quantity was canceled or expired at the venue, and a refill the order
+// service refuses would be refused again; either cancels the parent
+// rather than showing more size into whatever stopped it. Callers hold the
+// parent's claim.
+func (s *Service) refill(ctx context.Context, p execution.Parent, children []order.Record, progress execution.Progress) (execution.Parent, error) {
+	_, err := s.store.GetOrder(ctx, p.NextChildID)
+	switch {
+	case err == nil:
+		// Placed before a restart cut the step short.
+		return s.advance(ctx, p)
+	case !errors.Is(err, ports.ErrNotFound):
+		return p, err
+	}
+	if progress.Working.IsPositive() {
+		return p, nil
+	}
+	if n := len(children); n > 0 {
+		if last := children[n-1]; last.FilledQty.LessThan(last.Qty) {
+			return s.settle(ctx, p, execution.StatusCanceled,
+				fmt.Sprintf("refill %s ended %s with %s unfilled", last.ClientOrderID, last.Status, last.Qty.Sub(last.FilledQty)))
+		}
+	}
+	rules, err := orderservice.CatalogRules(ctx, s.catalog, p.Instrument)
+	if err != nil {
+		s.log.Warn().Str("parent", string(p.ID)).Err(err).Msg("instrument rules unavailable; refilling unrounded")
+	}
+	qty := p.Refill(progress, rules)
+	if !qty.IsPositive() {
+		s.metrics.observeSlice(p.Algo, "skipped")
+		return s.settle(ctx, p, execution.StatusCompleted,
+			p.Qty.Sub(progress.FilledQty).String()+" unfilled, below the quantity increment")
+	}
+	price := p.RefillPrice(rules, s.jitter())
+	_, err = s.placer.Place(ctx, p.Child(p.NextChildID, qty, price))
+	switch {
+	case err == nil:
+		s.metrics.observeSlice(p.Algo, "placed")
+	case errors.Is(err, orderservice.ErrSubmitUnsettled):
+		s.metrics.observeSlice(p.Algo, "unsettled")
+	default:
+		s.metrics.observeSlice(p.Algo, "refused")
+		return s.settle(ctx, p, execution.StatusCanceled, "refill refused: "+err.Error())
+	}
+	return s.advance(ctx, p)
+}
+
+// advance moves the parent past the child placed under NextChildID.
+func (s *Service) advance(ctx context.Context, p execution.Parent) (execution.Parent, error) {
+	now := s.clk.Now()
+	p.SlicesSent++
+	p.NextChildID = order.ClientOrderID(id.New())
+	p.UpdatedAt = now
+	return p, s.store.UpdateParent(context.WithoutCancel(ctx), p)
+}
+
+// claim waits until no one else is deciding on the parent, claims it, and
+// returns the release.
+func (s *Service) claim(parentID order.ParentID) func() {
+	s.mu.Lock()
+	for s.busy[parentID] {
+		s.idle.Wait()
+	}
+	s.busy[parentID] = true
+	s.mu.Unlock()
+	return func() {
+		s.mu.Lock()
+		delete(s.busy, parentID)
+		s.mu.Unlock()
+		s.idle.Broadcast()
+	}
+}
+
+func (s *Service) transition(ctx context.Context, parentID order.ParentID, status execution.Status, reason string) (View, error) {
+	defer s.claim(parentID)()
+	p, err := s.store.GetParent(ctx, parentID)
+	if err != nil {
+		return View{}, err
+	}
+	if p.Status.Final() {
+		return View{}, fmt.Errorf("%w: %s is %s", ErrParentFinished, parentID, p.Status)
+	}
+	if p.Status != status {
+		now := s.clk.Now()
+		p.Status, p.Reason, p.UpdatedAt = status, reason, now
+		if status == execution.StatusRunning {
+			p.NextAt = now
+		}
+		if err := s.store.UpdateParent(context.WithoutCancel(ctx), p); err != nil {
+			return View{}, err
+		}
+		s.log.Info().Str("parent", string(p.ID)).Str("status", string(status)).Msg("parent order moved")
+		if p, err = s.step(ctx, p); err != nil {
+			return View{}, err
+		}
+	}
+	return s.view(ctx, p)
+}
+
+// settle stores the parent's final status, with a VWAP's benchmark when
+// the market's trades give one.
+func (s *Service) settle(ctx context.Context, p execution.Parent, status execution.Status, reason string) (execution.Parent, error) {
+	p.Status, p.Reason, p.UpdatedAt = status, reason, s.clk.Now()
+	if p.Algo == execution.AlgoVWAP {
+		p.Benchmark = s.benchmark(ctx, p)
+	}
+	if err := s.store.UpdateParent(context.WithoutCancel(ctx), p); err != nil {
+		return p, err
+	}
+	s.metrics.observeFinished(p.Algo, status)
+	if p.Benchmark.IsPositive() {
+		if children, err := s.store.ListChildren(ctx, p.ID); err == nil {
+			progress := execution.Summarize(children)
+			if progress.FilledQty.IsPositive() {
+				s.metrics.observeSlippage(p.Algo, execution.SlippageBps(p.Side, progress.AvgFillPrice, p.Benchmark))
+			}
+		}
+	}
+	s.log.Info().Str("parent", string(p.ID)).Str("algo", string(p.Algo)).Str("status", string(status)).
+		Str("reason", reason).Msg("parent order finished")
+	return p, nil
+}
+
+func (s *Service) view(ctx context.Context, p execution.Parent) (View, error) {
+	children, err := s.store.ListChildren(ctx, p.ID)
+	if err != nil {
+		return View{}, err
+	}
+	v := View{Parent: p, Progress: execution.Summarize(children), Children: children}
+	if p.Algo == execution.AlgoVWAP {
+		v.Benchmark = p.Benchmark
+		if !p.Status.Final() {
+			v.Benchmark = s.benchmark(ctx, p)
+		}
+		v.SlippageBps = execution.SlippageBps(p.Side, v.Progress.AvgFillPrice, v.Benchmark)
+	}
+	return v, nil
+}
+
+// benchmark is the market's VWAP from the parent's creation until now, or
+// zero when it cannot be read. Best effort: a parent never waits on it.
+func (s *Service) benchmark(ctx context.Context, p execution.Parent) decimal.Decimal {
+	if s.trades == nil {
+		return decimal.Zero
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), benchmarkTimeout)
+	defer cancel()
+	price, err := s.trades.VWAP(ctx, p.Instrument, p.CreatedAt, s.clk.Now())
+	if err != nil {
+		s.log.Warn().Str("parent", string(p.ID)).Err(err).Msg("vwap benchmark unavailable")
+		return decimal.Zero
+	}
+	return price
+}
+
+// sameParent reports whether a retried placement asks for the stored
+// parent.
+func sameParent(stored, req execution.Parent) bool {
+	return stored.Algo == req.Algo && stored.BotID == req.BotID && stored.Instrument.Key() == req.Instrument.Key() &&
+		stored.Side == req.Side && stored.Qty.Equal(req.Qty) && stored.LimitPrice.Equal(req.LimitPrice) &&
+		stored.Slices == req.Slices && stored.Interval == req.Interval && stored.Jitter == req.Jitter &&
+		stored.DisplayQty.Equal(req.DisplayQty) && stored.PriceJitter.Equal(req.PriceJitter)
+}
@@ -0,0 +1,611 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/execution"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/events"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+	orderservice "github.com/romanornr/delta-works/internal/service/order"
+)
+
+func d(s string) decimal.Decimal { return decimal.RequireFromString(s) }
+
+var btc = instrument.Instrument{Venue: "bybit", Type: instrument.TypeSpot, Base: "BTC", Quote: "USDT"}
+
+// twap buys 10 in 4 slices a minute apart.
+func twap() execution.Parent {
+	return execution.Parent{
+		ID: "P", BotID: "manual", Instrument: btc, Side: order.Buy, Qty: d("10"), Slices: 4, Interval: time.Minute,
+	}
+}
+
+// iceberg buys 5 at 100 or better, 2 at a time.
+func iceberg() execution.Parent {
+	return execution.Parent{
+		ID: "I", BotID: "manual", Instrument: btc, Side: order.Buy, Qty: d("5"), LimitPrice: d("100"), DisplayQty: d("2"),
+		PriceJitter: d("1"),
+	}
+}
+
+// fakeStore keeps parents and orders in memory.
+type fakeStore struct {
+	mu      sync.Mutex
+	parents map[order.ParentID]execution.Parent
+	orders  []order.Record
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{parents: map[order.ParentID]execution.Parent{}}
+}
+
+func (f *fakeStore) CreateParent(_ context.Context, p execution.Parent) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.parents[p.ID]; ok {
+		return false, nil
+	}
+	f.parents[p.ID] = p
+	return true, nil
+}
+
+func (f *fakeStore) GetParent(_ context.Context, id order.ParentID) (execution.Parent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.parents[id]
+	if !ok {
+		return execution.Parent{}, ports.ErrNotFound
+	}
+	return p, nil
+}
+
+func (f *fakeStore) ListParents(_ context.Context, _ instrument.VenueID, liveOnly bool, _ int32) ([]execution.Parent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []execution.Parent
+	for _, p := range f.parents {
+		if !liveOnly || !p.Status.Final() {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStore) ListLiveParents(ctx context.Context) ([]execution.Parent, error) {
+	return f.ListParents(ctx, "", true, 0)
+}
+
+func (f *fakeStore) UpdateParent(_ context.Context, p execution.Parent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.parents[p.ID]; !ok {
+		return ports.ErrNotFound
+	}
+	f.parents[p.ID] = p
+	return nil
+}
+
+func (f *fakeStore) ListChildren(_ context.Context, id order.ParentID) ([]order.Record, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []order.Record
+	for _, rec := range f.orders {
+		if rec.ParentID == id {
+			out = append(out, rec)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStore) GetOrder(_ context.Context, id order.ClientOrderID) (order.Record, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, rec := range f.orders {
+		if rec.ClientOrderID == id {
+			return rec, nil
+		}
+	}
+	return order.Record{}, ports.ErrNotFound
+}
+
+func (f *fakeStore) parent(id order.ParentID) execution.Parent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.parents[id]
+}
+
+// fill ends the i-th child with filled at price, as the order service
+// would on a venue event.
+func (f *fakeStore) fill(i int, filled, price string) order.ClientOrderID {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	rec := &f.orders[i]
+	rec.FilledQty, rec.AvgFillPrice, rec.Status = d(filled), d(price), order.StatusFilled
+	if !rec.FilledQty.Equal(rec.Qty) {
+		rec.Status = order.StatusExpired
+	}
+	return rec.ClientOrderID
+}
+
+// fakePlacer stores what it places as an open order, unless refuse is set,
+// in which case the order service turned it down before storing it. A
+// child of the stalled parent waits for release, as a submit retrying
+// against a slow venue would, after signaling stalling.
+type fakePlacer struct {
+	store    *fakeStore
+	refuse   bool
+	placed   []order.Request
+	canceled []order.ClientOrderID
+	stalled  order.ParentID
+	stalling chan struct{}
+	release  chan struct{}
+}
+
+func (p *fakePlacer) Place(_ context.Context, req order.Request) (orderservice.PlaceResult, error) {
+	if p.refuse {
+		return orderservice.PlaceResult{}, errors.New("insufficient balance")
+	}
+	if p.stalled != "" && req.ParentID == p.stalled {
+		p.stalling <- struct{}{}
+		<-p.release
+	}
+	p.store.mu.Lock()
+	p.placed = append(p.placed, req)
+	p.store.orders = append(p.store.orders, order.Record{
+		ClientOrderID: req.ClientOrderID, ParentID: req.ParentID, Qty: req.Qty, Status: order.StatusOpen,
+	})
+	p.store.mu.Unlock()
+	return orderservice.PlaceResult{ClientOrderID: req.ClientOrderID, Status: order.StatusOpen}, nil
+}
+
+func (p *fakePlacer) Cancel(_ context.Context, id order.ClientOrderID) (order.Status, error) {
+	p.canceled = append(p.canceled, id)
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+	for i := range p.store.orders {
+		if p.store.orders[i].ClientOrderID == id {
+			p.store.orders[i].Status = order.StatusCanceled
+		}
+	}
+	return order.StatusCanceled, nil
+}
+
+type fakeCatalog []instrument.Instrument
+
+func (c fakeCatalog) ListInstruments(context.Context, instrument.VenueID) ([]instrument.Instrument, error) {
+	return c, nil
+}
+
+// fakeTrades serves a fixed volume profile and market VWAP, recording
+// the windows asked for.
+type fakeTrades struct {
+	profile execution.VolumeProfile
+	vwap    decimal.Decimal
+	since   time.Time
+	to      time.Time
+}
+
+func (f *fakeTrades) VolumeProfile(_ context.Context, _ instrument.Instrument, since time.Time) (execution.VolumeProfile, error) {
+	f.since = since
+	return f.profile, nil
+}
+
+func (f *fakeTrades) VWAP(_ context.Context, _ instrument.Instrument, _, to time.Time) (decimal.Decimal, error) {
+	f.to = to
+	return f.vwap, nil
+}
+
+func newService(t *testing.T, store *fakeStore, placer *fakePlacer, b bus.Bus) (*Service, *clockwork.FakeClock, *Metrics) {
+	t.Helper()
+	m, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	listed := btc
+	listed.Rules.QtyIncrement = d("0.01")
+	clk := clockwork.NewFakeClock()
+	svc := New(store, placer, fakeCatalog{listed}, nil, b, clk, log.Nop(), time.Second, 7*24*time.Hour, m)
+	svc.jitter = func() float64 { return 0 }
+	return svc, clk, m
+}
+
+func quantities(reqs []order.Request) []string {
+	var out []string
+	for _, req := range reqs {
+		out = append(out, req.Qty.String())
+	}
+	return out
+}
+
+func TestTWAPSlicesOnScheduleThenCompletes(t *testing.T) {
+	t.Parallel()
+	store := newFakeStore()
+	placer := &fakePlacer{store: store}
+	svc, clk, m := newService(t, store, placer, nil)
+
+	view, err := svc.PlaceTWAP(t.Context(), twap())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Parent.Status != execution.StatusRunning || view.Parent.SlicesSent != 1 || len(placer.placed) != 1 {
+		t.Fatalf("parent %+v, placed %v; want the first slice out", view.Parent, quantities(placer.placed))
+	}
+	if child := placer.placed[0]; child.ParentID != "P" || child.Type != order.Market || child.TimeInForce != order.IOC {
+		t.Fatalf("child = %+v", child)
+	}
+
+	// Nothing is due until the interval has passed.
+	if err := svc.sweepLive(t.Context()); err != nil || len(placer.placed) != 1 {
+		t.Fatalf("early sweep placed %v, %v", quantities(placer.placed), err)
+	}
+	store.fill(0, "2", "100")
+	for i := 1; i < 4; i++ {
+		clk.Advance(time.Minute)
+		if err := svc.sweepLive(t.Context()); err != nil {
+			t.Fatal(err)
+		}
+		store.fill(i, placer.placed[i].Qty.String(), "102")
+	}
+	// The first child's shortfall is spread over the later slices.
+	if got := quantities(placer.placed); !slices.Equal(got, []string{"2.5", "2.66", "2.67", "2.67"}) {
+		t.Fatalf("slices = %v", got)
+	}
+	if store.parent("P").Status != execution.StatusRunning {
+		t.Fatal("completed before the last child ended")
+	}
+	if err := svc.stepOne(t.Context(), "P"); err != nil {
+		t.Fatal(err)
+	}
+	view, err = svc.Get(t.Context(), "P")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Parent.Status != execution.StatusCompleted || view.Parent.Reason != "filled" || !view.Progress.FilledQty.Equal(d("10")) ||
+		!view.Progress.AvgFillPrice.Equal(d("101.6")) {
+		t.Fatalf("parent %+v, progress %+v", view.Parent, view.Progress)
+	}
+	if got := testutil.ToFloat64(m.slices.WithLabelValues("twap", "placed")); got != 4 {
+		t.Fatalf("placed slices = %v, want 4", got)
+	}
+	if got := testutil.ToFloat64(m.finished.WithLabelValues("twap", "completed")); got != 1 {
+		t.Fatalf("finished = %v, want 1", got)
+	}
+}
+
+func TestRefusedSliceRollsForward(t *testing.T) {
+	t.Parallel()
+	store := newFakeStore()
+	placer := &fakePlacer{store: store, refuse: true}
+	svc, clk, m := newService(t, store, placer, nil)
+
+	p := twap()
+	p.Slices = 2
+	view, err := svc.PlaceTWAP(t.Context(), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Parent.SlicesSent != 1 || len(view.Children) != 0 {
+		t.Fatalf("parent %+v, children %v; want the slice counted and nothing stored", view.Parent, view.Children)
+	}
+	if got := testutil.ToFloat64(m.slices.WithLabelValues("twap", "refused")); got != 1 {
+		t.Fatalf("refused slices = %v, want 1", got)
+	}
+
+	placer.refuse = false
+	clk.Advance(time.Minute)
+	if err := svc.sweepLive(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	if got := quantities(placer.placed); !slices.Equal(got, []string{"10"}) {
+		t.Fatalf("slices = %v, want the whole quantity in the last", got)
+	}
+	store.fill(0, "4", "100")
+	if err := svc.stepOne(t.Context(), "P"); err != nil {
+		t.Fatal(err)
+	}
+	if got := store.parent("P"); got.Status != execution.StatusCompleted || got.Reason != "schedule ended with 6 unfilled" {
+		t.Fatalf("parent = %+v", got)
+	}
+}
+
+func TestSliceStoredBeforeARestartIsNotPlacedAgain(t *testing.T) {
+	t.Parallel()
+	store := newFakeStore()
+	placer := &fakePlacer{store: store}
+	svc, clk, _ := newService(t, store, placer, nil)
+
+	if _, err := svc.PlaceTWAP(t.Context(), twap()); err != nil {
+		t.Fatal(err)
+	}
+	// The second child is stored, but the step was cut short before the
+	// schedule moved on.
+	next := store.parent("P").NextChildID
+	store.mu.Lock()
+	store.orders = append(store.orders, order.Record{ClientOrderID: next, ParentID: "P", Qty: d("2.5"), Status: order.StatusOpen})
+	store.mu.Unlock()
+
+	clk.Advance(time.Minute)
+	if err := svc.sweepLive(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	if got := store.parent("P"); len(placer.placed) != 1 || got.SlicesSent != 2 || got.NextChildID == next {
+		t.Fatalf("placed %v, parent %+v; want the schedule advanced without placing", quantities(placer.placed), got)
+	}
+}
+
+func TestIcebergRefillsOnEachFullFill(t *testing.T) {
+	t.Parallel()
+	store := newFakeStore()
+	placer := &fakePlacer{store: store}
+	svc, _, m := newService(t, store, placer, nil)
+
+	view, err := svc.PlaceIceberg(t.Context(), iceberg())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Parent.Algo != execution.AlgoIceberg || !view.Progress.Working.Equal(d("2")) {
+		t.Fatalf("parent %+v, progress %+v; want one child of the display size working", view.Parent, view.Progress)
+	}
+	if child := placer.placed[0]; child.Type != order.Limit || child.TimeInForce != order.GTC || !child.Price.Equal(d("99.5")) {
+		t.Fatalf("child = %+v, want a resting limit halfway into the price jitter", child)
+	}
+	if err := svc.sweepLive(t.Context()); err != nil || len(placer.placed) != 1 {
+		t.Fatalf("sweep with a child working placed %v, %v", quantities(placer.placed), err)
+	}
+	for i := range 3 {
+		store.fill(i, placer.placed[i].Qty.String(), "99.5")
+		if err := svc.stepOne(t.Context(), "I"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := quantities(placer.placed); !slices.Equal(got, []string{"2", "2", "1"}) {
+		t.Fatalf("refills = %v", got)
+	}
+	if placer.placed[0].ClientOrderID == placer.placed[1].ClientOrderID {
+		t.Fatal("a refill reused its predecessor's ID")
+	}
+	if got := store.parent("I"); got.Status != execution.StatusCompleted || got.Reason != "filled" || got.SlicesSent != 3 {
+		t.Fatalf("parent = %+v", got)
+	}
+	if got := testutil.ToFloat64(m.slices.WithLabelValues("iceberg", "placed")); got != 3 {
+		t.Fatalf("placed refills = %v, want 3", got)
+	}
+}
+
+func TestIcebergCanceledWhenARefillCannotShow(t *testing.T) {
+	t.Parallel()
+	store := newFakeStore()
+	placer := &fakePlacer{store: store}
+	svc, _, _ := newService(t, store, placer, nil)
+
+	if _, err := svc.PlaceIceberg(t.Context(), iceberg()); err != nil {
+		t.Fatal(err)
+	}
+	childID := store.fill(0, "0.5", "100")
+	if err := svc.stepOne(t.Context(), "I"); err != nil {
+		t.Fatal(err)
+	}
+	if got := store.parent("I"); got.Status != execution.StatusCanceled || got.Reason != "refill "+string(childID)+" ended expired with 1.5 unfilled" {
+		t.Fatalf("parent = %+v, want canceled by the short child", got)
+	}
+
+	refused := iceberg()
+	refused.ID = "J"
+	placer.refuse = true
+	view, err := svc.PlaceIceberg(t.Context(), refused)
+	if err != nil || view.Parent.Status != execution.StatusCanceled || view.Parent.Reason != "refill refused: insufficient balance" {
+		t.Fatalf("PlaceIceberg = %+v, %v; want canceled by the refusal", view.Parent, err)
+	}
+}
+
+func TestVWAPFollowsItsCurveThenRecordsTheBenchmark(t *testing.T) {
+	t.Parallel()
+	store := newFakeStore()
+	placer := &fakePlacer{store: store}
+	svc, _, m := newService(t, store, placer, nil)
+	midnight := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := clockwork.NewFakeClockAt(midnight)
+	svc.clk = clk
+
+	p := twap()
+	p.Qty, p.Interval, p.Slices = d("8"), time.Hour, 2
+	if _, err := svc.PlaceVWAP(t.Context(), p, nil); !errors.Is(err, execution.ErrInvalidParent) {
+		t.Fatalf("PlaceVWAP without trades or a profile = %v, want ErrInvalidParent", err)
+	}
+
+	// The first hour of the day usually trades a third of the second.
+	profile := make(execution.VolumeProfile, 24)
+	for i := range profile {
+		profile[i] = d("0")
+	}
+	profile[0], profile[1] = d("1"), d("3")
+	trades := &fakeTrades{profile: profile, vwap: d("102")}
+	svc.trades = trades
+	view, err := svc.PlaceVWAP(t.Context(), p, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !trades.since.Equal(midnight.Add(-7*24*time.Hour)) || view.Parent.Algo != execution.AlgoVWAP ||
+		!view.Parent.Curve[0].Equal(d("0.25")) || !view.Benchmark.Equal(d("102")) {
+		t.Fatalf("parent %+v, benchmark %s, profile since %s", view.Parent, view.Benchmark, trades.since)
+	}
+	store.fill(0, placer.placed[0].Qty.String(), "100")
+	clk.Advance(time.Hour)
+	if err := svc.sweepLive(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	store.fill(1, placer.placed[1].Qty.String(), "103")
+	if got := quantities(placer.placed); !slices.Equal(got, []string{"2", "6"}) {
+		t.Fatalf("slices = %v, want the curve's 2 then 6", got)
+	}
+	if err := svc.stepOne(t.Context(), "P"); err != nil {
+		t.Fatal(err)
+	}
+	view, err = svc.Get(t.Context(), "P")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Bought at 102.25 against a market VWAP of 102.
+	if view.Parent.Status != execution.StatusCompleted || !view.Parent.Benchmark.Equal(d("102")) ||
+		!view.SlippageBps.Equal(d("24.51")) || !trades.to.Equal(midnight.Add(time.Hour)) {
+		t.Fatalf("parent %+v, slippage %s", view.Parent, view.SlippageBps)
+	}
+	if got := testutil.CollectAndCount(m.slippage); got != 1 {
+		t.Fatalf("slippage series = %d, want 1", got)
+	}
+}
+
+func TestPauseResumeCancel(t *testing.T) {
+	t.Parallel()
+	store := newFakeStore()
+	placer := &fakePlacer{store: store}
+	svc, clk, _ := newService(t, store, placer, nil)
+
+	if _, err := svc.PlaceTWAP(t.Context(), twap()); err != nil {
+		t.Fatal(err)
+	}
+	if view, err := svc.Pause(t.Context(), "P"); err != nil || view.Parent.Status != execution.StatusPaused {
+		t.Fatalf("Pause = %+v, %v", view.Parent, err)
+	}
+	clk.Advance(10 * time.Minute)
+	if err := svc.sweepLive(t.Context()); err != nil || len(placer.placed) != 1 {
+		t.Fatalf("paused sweep placed %v, %v", quantities(placer.placed), err)
+	}
+	view, err := svc.Resume(t.Context(), "P")
+	if err != nil || view.Parent.Status != execution.StatusRunning || len(placer.placed) != 2 {
+		t.Fatalf("Resume = %+v, %v; placed %v; want the due slice out at once", view.Parent, err, quantities(placer.placed))
+	}
+
+	store.fill(0, "2.5", "100")
+	view, err = svc.Cancel(t.Context(), "P")
+	if err != nil || view.Parent.Status != execution.StatusCanceled {
+		t.Fatalf("Cancel = %+v, %v", view.Parent, err)
+	}
+	if !slices.Equal(placer.canceled, []order.ClientOrderID{placer.placed[1].ClientOrderID}) {
+		t.Fatalf("canceled %v, want the working child alone", placer.canceled)
+	}
+	for _, op := range []func(context.Context, order.ParentID) (View, error){svc.Pause, svc.Resume, svc.Cancel} {
+		if _, err := op(t.Context(), "P"); !errors.Is(err, ErrParentFinished) {
+			t.Fatalf("err = %v, want ErrParentFinished", err)
+		}
+	}
+}
+
+func TestSlowVenueHoldsUpOnlyItsParent(t *testing.T) {
+	t.Parallel()
+	store := newFakeStore()
+	placer := &fakePlacer{store: store, stalled: "P", stalling: make(chan struct{}), release: make(chan struct{})}
+	svc, _, _ := newService(t, store, placer, nil)
+
+	placed := make(chan error, 1)
+	go func() {
+		_, err := svc.PlaceTWAP(t.Context(), twap())
+		placed <- err
+	}()
+	<-placer.stalling
+	other := twap()
+	other.ID = "Q"
+	if _, err := svc.PlaceTWAP(t.Context(), other); err != nil {
+		t.Fatalf("other parent while P stalls: %v", err)
+	}
+	paused := make(chan error, 1)
+	go func() {
+		_, err := svc.Pause(t.Context(), "P")
+		paused <- err
+	}()
+	select {
+	case err := <-paused:
+		t.Fatalf("Pause decided on P mid-placement: %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(placer.release)
+	if err := <-placed; err != nil {
+		t.Fatal(err)
+	}
+	if err := <-paused; err != nil {
+		t.Fatal(err)
+	}
+	if p := store.parent("P"); p.Status != execution.StatusPaused || p.SlicesSent != 1 {
+		t.Fatalf("P = %s with %d slices sent, want paused after its first slice", p.Status, p.SlicesSent)
+	}
+}
+
+func TestRetryReturnsTheParentOrRefusesOtherTerms(t *testing.T) {
+	t.Parallel()
+	store := newFakeStore()
+	placer := &fakePlacer{store: store}
+	svc, _, _ := newService(t, store, placer, nil)
+
+	if _, err := svc.PlaceTWAP(t.Context(), twap()); err != nil {
+		t.Fatal(err)
+	}
+	view, err := svc.PlaceTWAP(t.Context(), twap())
+	if err != nil || len(placer.placed) != 1 || len(view.Children) != 1 {
+		t.Fatalf("retry = %+v, %v; placed %v", view, err, quantities(placer.placed))
+	}
+	other := twap()
+	other.Slices = 5
+	if _, err := svc.PlaceTWAP(t.Context(), other); !errors.Is(err, orderservice.ErrIdentityMismatch) {
+		t.Fatalf("err = %v, want ErrIdentityMismatch", err)
+	}
+	invalid := twap()
+	invalid.ID, invalid.Interval = "Q", time.Millisecond
+	if _, err := svc.PlaceTWAP(t.Context(), invalid); !errors.Is(err, execution.ErrInvalidParent) {
+		t.Fatalf("err = %v, want ErrInvalidParent", err)
+	}
+}
+
+func TestRunCompletesOnChildEvents(t *testing.T) {
+	t.Parallel()
+	store := newFakeStore()
+	placer := &fakePlacer{store: store}
+	b := bus.NewInProc()
+	t.Cleanup(b.Close)
+	svc, _, _ := newService(t, store, placer, b)
+	p := twap()
+	p.Slices = 1
+	if _, err := svc.PlaceTWAP(t.Context(), p); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() { done <- svc.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	childID := store.fill(0, "10", "100")
+	payload, err := json.Marshal(events.OrderFilledPayload{ClientOrderID: childID, ParentID: "P", Status: order.StatusFilled, FilledQty: d("10")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.After(5 * time.Second)
+	for store.parent("P").Status != execution.StatusCompleted {
+		// Publish until the service has subscribed and acted.
+		if err := b.Publish(t.Context(), bus.Event{Subject: events.SubjectOrderFilled, Payload: json.RawMessage(payload)}); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("status %s, want completed after the child filled", store.parent("P").Status)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
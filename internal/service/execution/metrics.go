@@ -0,0 +1,52 @@
+package execution
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/execution"
+)
+
+// Metrics holds the execution service's Prometheus instruments.
+type Metrics struct {
+	slices   *prometheus.CounterVec
+	finished *prometheus.CounterVec
+	slippage *prometheus.HistogramVec
+}
+
+// NewMetrics registers the execution metrics on the given registry.
+func NewMetrics(reg *prometheus.Registry) (*Metrics, error) {
+	m := &Metrics{
+		slices: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "execution_slices_total",
+			Help: "Parent-order slices and iceberg refills taken by algorithm and outcome (placed, unsettled, refused, skipped).",
+		}, []string{"algo", "outcome"}),
+		finished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "execution_parents_finished_total",
+			Help: "Parent orders finished by algorithm and final status (completed, canceled).",
+		}, []string{"algo", "status"}),
+		slippage: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "execution_slippage_bps",
+			Help:    "Finished parents' average fill price against the market VWAP over their life, in basis points; positive is worse.",
+			Buckets: []float64{-50, -20, -10, -5, -2, 0, 2, 5, 10, 20, 50},
+		}, []string{"algo"}),
+	}
+	for _, c := range []prometheus.Collector{m.slices, m.finished, m.slippage} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func (m *Metrics) observeSlice(algo execution.Algo, outcome string) {
+	m.slices.WithLabelValues(string(algo), outcome).Inc()
+}
+
+func (m *Metrics) observeFinished(algo execution.Algo, status execution.Status) {
+	m.finished.WithLabelValues(string(algo), string(status)).Inc()
+}
+
+func (m *Metrics) observeSlippage(algo execution.Algo, bps decimal.Decimal) {
+	m.slippage.WithLabelValues(string(algo)).Observe(bps.InexactFloat64())
+}
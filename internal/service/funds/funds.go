@@ -0,0 +1,144 @@
+// Package funds reserves what a new order will spend as it is stored, so
+// bots placing at once cannot both count on the same balance. It prices
+// the reservation and hands it to the store, which draws it against the
+// account's latest balance snapshot under a lock and refuses the order
+// when the balance falls short (see the domain package for the rules).
+package funds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/funds"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/exchange"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// priceTimeout bounds the ticker fetch that prices a market buy, so a
+// stalled venue refuses the order instead of holding the caller.
+const priceTimeout = 3 * time.Second
+
+// Service is the order service's reservation hook.
+type Service struct {
+	store     ports.ReservationStore
+	registry  exchange.Registry
+	accounts  map[instrument.VenueID]account.Type
+	feeBuffer decimal.Decimal
+	clk       clockwork.Clock
+	log       log.Logger
+	metrics   *Metrics
+}
+
+// New builds the service. accounts names the account each venue trades
+// from, spot when absent; feeBuffer is the fee rate a buy reserves on top
+// of its notional, 0.002 for 0.2%. Metrics must not be nil.
+func New(store ports.ReservationStore, registry exchange.Registry, accounts map[instrument.VenueID]account.Type, feeBuffer decimal.Decimal, clk clockwork.Clock, logger log.Logger, metrics *Metrics) *Service {
+	return &Service{
+		store:     store,
+		registry:  registry,
+		accounts:  accounts,
+		feeBuffer: feeBuffer,
+		clk:       clk,
+		log:       log.Component(logger, "funds"),
+		metrics:   metrics,
+	}
+}
+
+// Reserve prices what req will spend, refusing a market buy with no quote
+// with funds.ErrNoPrice. It may call the venue, so the order service runs
+// it before taking its admit lock.
+func (s *Service) Reserve(ctx context.Context, req order.Request) (funds.Reservation, error) {
+	acct, ok := s.accounts[req.Instrument.Venue]
+	if !ok {
+		acct = account.TypeSpot
+	}
+	price, err := s.price(ctx, req)
+	if err != nil {
+		s.refused(req, err)
+		return funds.Reservation{}, err
+	}
+	return funds.New(req, acct, price, s.feeBuffer, s.clk.Now()), nil
+}
+
+// CreatePending stores the order in status pending together with r, or
+// refuses it with funds.ErrInsufficient or funds.ErrNoBalance.
+func (s *Service) CreatePending(ctx context.Context, req order.Request, r funds.Reservation) (bool, error) {
+	inserted, err := s.store.CreateReserved(ctx, req, r)
+	if err != nil {
+		s.refused(req, err)
+	}
+	return inserted, err
+}
+
+// CheckAmend refuses an amend that would hold more than the account has
+// available with funds.ErrInsufficient. The order service calls it before
+// asking the venue for the amend.
+func (s *Service) CheckAmend(ctx context.Context, req order.Request, amend order.Replacement) error {
+	err := s.store.CheckAmend(ctx, amend)
+	if err != nil {
+		s.refused(req, err)
+	}
+	return err
+}
+
+// refused counts and logs err when it refused the order for funds.
+func (s *Service) refused(req order.Request, err error) {
+	if reason, refused := refusal(err); refused {
+		s.metrics.observeRefusal(req.Instrument.Venue, reason)
+		s.log.Warn().Str("venue", string(req.Instrument.Venue)).Str("bot", req.BotID).
+			Str("pair", req.Instrument.Pair()).Str("side", string(req.Side)).Str("reason", reason).
+			Err(err).Msg("order refused for funds")
+	}
+}
+
+// price is what a buy reserves its quote at: its limit, a stop-market's
+// trigger, or the ask a market buy will lift. A sell reserves base and
+// needs no price.
+func (s *Service) price(ctx context.Context, req order.Request) (decimal.Decimal, error) {
+	switch {
+	case req.Side != order.Buy:
+		return decimal.Zero, nil
+	case req.Type == order.StopMarket:
+		return req.TriggerPrice, nil
+	case req.Type != order.Market:
+		return req.Price, nil
+	}
+	ex, err := s.registry.Get(req.Instrument.Venue)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("%w: %w", funds.ErrNoPrice, err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, priceTimeout)
+	defer cancel()
+	t, err := ex.Ticker(ctx, req.Instrument)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("%w: ticker %s: %w", funds.ErrNoPrice, req.Instrument.Pair(), err)
+	}
+	for _, price := range []decimal.Decimal{t.Ask, t.Last} {
+		if price.IsPositive() {
+			return price, nil
+		}
+	}
+	return decimal.Zero, fmt.Errorf("%w: ticker %s has no ask", funds.ErrNoPrice, req.Instrument.Pair())
+}
+
+// refusal classifies an error that refused the order for funds.
+func refusal(err error) (string, bool) {
+	switch {
+	case errors.Is(err, funds.ErrInsufficient):
+		return "insufficient", true
+	case errors.Is(err, funds.ErrNoBalance):
+		return "no_balance", true
+	case errors.Is(err, funds.ErrNoPrice):
+		return "no_price", true
+	}
+	return "", false
+}
@@ -0,0 +1,127 @@
+package funds
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/funds"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/exchange"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+type fakeExchange struct {
+	ask decimal.Decimal
+	err error
+}
+
+func (*fakeExchange) ID() instrument.VenueID { return "bybit" }
+
+func (f *fakeExchange) Ticker(_ context.Context, inst instrument.Instrument) (marketdata.Ticker, error) {
+	return marketdata.Ticker{Instrument: inst, Ask: f.ask}, f.err
+}
+
+func (*fakeExchange) Instruments(context.Context, instrument.Type) ([]instrument.Instrument, error) {
+	return nil, nil
+}
+
+func (*fakeExchange) Balances(context.Context, account.Type) ([]account.Balance, error) {
+	return nil, nil
+}
+
+// fakeStore draws every reservation from free, as if nothing else were
+// reserved.
+type fakeStore struct {
+	free     decimal.Decimal
+	reserved []funds.Reservation
+}
+
+func (f *fakeStore) CreateReserved(_ context.Context, _ order.Request, r funds.Reservation) (bool, error) {
+	r, err := r.Draw(funds.Pool{Free: f.free})
+	if err != nil {
+		return false, err
+	}
+	f.reserved = append(f.reserved, r)
+	return true, nil
+}
+
+func (*fakeStore) CheckAmend(context.Context, order.Replacement) error { return nil }
+
+func d(s string) decimal.Decimal { return decimal.RequireFromString(s) }
+
+func request(side order.Side, typ order.Type, price, qty string) order.Request {
+	req := order.Request{
+		ClientOrderID: "01J00000000000000000000001", BotID: "manual",
+		Instrument: instrument.Instrument{Venue: "bybit", Type: instrument.TypeSpot, Base: "BTC", Quote: "USDT"},
+		Side:       side, Type: typ, Qty: d(qty),
+	}
+	if price != "" {
+		req.Price = d(price)
+	}
+	return req
+}
+
+func TestCreatePending(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		req        order.Request
+		venue      *fakeExchange
+		free       string
+		wantAmount string
+		wantErr    error
+		wantReason string
+	}{
+		{name: "limit buy at its price plus the fee buffer", req: request(order.Buy, order.Limit, "100", "2"), free: "1000", wantAmount: "201"},
+		{name: "market buy at the ask", req: request(order.Buy, order.Market, "", "2"), venue: &fakeExchange{ask: d("110")}, free: "1000", wantAmount: "221.1"},
+		{name: "market buy with no quote", req: request(order.Buy, order.Market, "", "2"), venue: &fakeExchange{err: errors.New("down")}, free: "1000", wantErr: funds.ErrNoPrice, wantReason: "no_price"},
+		{name: "sell of base", req: request(order.Sell, order.Market, "", "2"), free: "2", wantAmount: "2"},
+		{name: "buy beyond the balance", req: request(order.Buy, order.Limit, "100", "20"), free: "1000", wantErr: funds.ErrInsufficient, wantReason: "insufficient"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var exchanges []ports.Exchange
+			if tt.venue != nil {
+				exchanges = append(exchanges, tt.venue)
+			}
+			metrics, err := NewMetrics(prometheus.NewRegistry())
+			if err != nil {
+				t.Fatal(err)
+			}
+			store := &fakeStore{free: d(tt.free)}
+			svc := New(store, exchange.NewRegistry(exchanges), nil, d("0.005"), clockwork.NewFakeClockAt(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)), log.Nop(), metrics)
+			r, err := svc.Reserve(t.Context(), tt.req)
+			inserted := false
+			if err == nil {
+				inserted, err = svc.CreatePending(t.Context(), tt.req, r)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || inserted {
+					t.Fatalf("inserted=%v err=%v, want %v", inserted, err, tt.wantErr)
+				}
+				if got := testutil.ToFloat64(metrics.refusals.WithLabelValues("bybit", tt.wantReason)); got != 1 {
+					t.Fatalf("refusals{%s} = %v, want 1", tt.wantReason, got)
+				}
+				return
+			}
+			if err != nil || !inserted || len(store.reserved) != 1 {
+				t.Fatalf("inserted=%v err=%v reserved=%d", inserted, err, len(store.reserved))
+			}
+			if r := store.reserved[0]; !r.Amount.Equal(d(tt.wantAmount)) || r.Account.Type != account.TypeSpot {
+				t.Fatalf("reservation = %+v, want %s", r, tt.wantAmount)
+			}
+		})
+	}
+}
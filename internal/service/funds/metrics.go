@@ -0,0 +1,30 @@
+package funds
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+)
+
+// Metrics holds the service's Prometheus instruments.
+type Metrics struct {
+	refusals *prometheus.CounterVec
+}
+
+// NewMetrics registers the service metrics on the given registry.
+func NewMetrics(reg *prometheus.Registry) (*Metrics, error) {
+	m := &Metrics{
+		refusals: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "funds_refusals_total",
+			Help: "Orders refused at placement for want of funds (insufficient), of a balance snapshot (no_balance) or of a price to reserve a market buy at (no_price).",
+		}, []string{"venue", "reason"}),
+	}
+	if err := reg.Register(m.refusals); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Metrics) observeRefusal(venue instrument.VenueID, reason string) {
+	m.refusals.WithLabelValues(string(venue), reason).Inc()
+}
@@ -0,0 +1,407 @@
+package grid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/grid"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/id"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+	orderservice "github.com/romanornr/delta-works/internal/service/order"
+)
+
+// listPage bounds one page of the scans for the bot's own orders.
+const listPage = 200
+
+// stopTimeout bounds the cancels a Stop issues, which run on a context
+// detached from shutdown so a stop is not abandoned halfway.
+const stopTimeout = 30 * time.Second
+
+// actor is one bot's state. Only the actor goroutine touches it, so it
+// needs no locks.
+type actor struct {
+	*Service
+	b       *bot
+	spec    grid.Spec
+	log     log.Logger
+	state   State
+	started bool
+	refusal error
+	want    map[int]order.Side                 // levels that should rest, by index
+	live    map[order.ClientOrderID]grid.Level // orders placed and not yet ended
+	byLevel map[int]order.ClientOrderID
+}
+
+func (s *Service) runBot(ctx context.Context, b *bot) error {
+	a := &actor{
+		Service: s, b: b, spec: b.spec,
+		log:   s.log.With().Str("bot", b.spec.BotID).Logger(),
+		state: StateRunning,
+		want:  map[int]order.Side{}, live: map[order.ClientOrderID]grid.Level{},
+		byLevel: map[int]order.ClientOrderID{},
+	}
+	defer func() {
+		b.mu.Lock()
+		b.final, b.refusal = a.status(), a.refusal
+		b.mu.Unlock()
+		close(b.done)
+	}()
+
+	ticker := s.clk.NewTicker(s.retry)
+	defer ticker.Stop()
+	if err := a.tick(ctx); err != nil {
+		return a.exit(ctx, err)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case cmd := <-b.mailbox:
+			if stop := a.handle(ctx, cmd); stop {
+				return nil
+			}
+		case ev := <-b.inbox:
+			a.onOrder(ctx, ev)
+		case <-ticker.Chan():
+			if err := a.tick(ctx); err != nil {
+				return a.exit(ctx, err)
+			}
+		}
+	}
+}
+
+// exit ends the actor on a tick error. A bot that refused to start stops
+// alone and keeps its reason for the API; any other error stops the
+// service.
+func (a *actor) exit(ctx context.Context, err error) error {
+	if !errors.Is(err, ErrBotRefused) {
+		return passError(ctx, err)
+	}
+	a.state, a.refusal = StateStopped, err
+	a.log.Error().Err(err).Msg("grid bot refused to start; nothing placed")
+	return nil
+}
+
+func passError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+func (a *actor) status() Status {
+	return Status{BotID: a.spec.BotID, State: a.state, Open: len(a.live)}
+}
+
+// tick finishes startup if it has not succeeded yet, or else sweeps the
+// store for outcomes the bus dropped, then re-places any level whose
+// order failed.
+func (a *actor) tick(ctx context.Context) error {
+	if !a.started {
+		if err := a.start(ctx); err != nil {
+			return err
+		}
+	} else if err := a.sweep(ctx); err != nil {
+		return err
+	}
+	a.placeMissing(ctx)
+	return nil
+}
+
+// start checks the spec against the instrument's rules, adopts the bot's
+// active orders from the store, so a restart resumes the existing ladder
+// instead of doubling it, then fills the remaining levels from the current
+// price. Adopted orders take precedence over the computed ladder. A spec
+// that breaks the rules, or a venue that cannot place post-only, refuses
+// the bot; an instrument the catalog does not
+// list as trading yet, or a venue failure, leaves it unstarted for the next
+// tick; a store failure is returned.
+func (a *actor) start(ctx context.Context) error {
+	listed, ok, err := orderservice.CatalogListing(ctx, a.catalog, a.spec.Instrument)
+	if err != nil {
+		return fmt.Errorf("grid %s: instrument catalog: %w", a.spec.BotID, err)
+	}
+	if !ok || listed.Status != instrument.StatusTrading {
+		a.log.Warn().Msg("instrument not trading in the catalog; grid start retries next interval")
+		return nil
+	}
+	if err := a.spec.CheckRules(listed.Rules); err != nil {
+		return fmt.Errorf("%w: %w", ErrBotRefused, err)
+	}
+	ex, err := a.registry.Get(a.spec.Instrument.Venue)
+	if err != nil {
+		return fmt.Errorf("grid %s: %w", a.spec.BotID, err)
+	}
+	if po, ok := ex.(ports.PostOnlyPlacer); !ok || !po.HonorsPostOnly() {
+		return fmt.Errorf("%w: venue %s cannot place grid orders post-only", ErrBotRefused, a.spec.Instrument.Venue)
+	}
+	if err := a.adopt(ctx); err != nil {
+		return err
+	}
+	ticker, err := ex.Ticker(ctx, a.spec.Instrument)
+	if err != nil {
+		a.log.Warn().Err(err).Msg("ticker failed; grid start retries next interval")
+		return nil
+	}
+	price := ticker.Last
+	if !price.IsPositive() {
+		price = ticker.Bid.Add(ticker.Ask).Div(decimal.NewFromInt(2))
+	}
+	if !price.IsPositive() {
+		a.log.Warn().Msg("ticker has no price; grid start retries next interval")
+		return nil
+	}
+	for _, lvl := range a.spec.Ladder(price) {
+		if _, adopted := a.byLevel[lvl.Index]; !adopted {
+			a.want[lvl.Index] = lvl.Side
+		}
+	}
+	a.started = true
+	a.log.Info().Str("price", price.String()).Int("adopted", len(a.live)).Msg("grid started")
+	return nil
+}
+
+func (a *actor) adopt(ctx context.Context) error {
+	active, err := a.activeOrders(ctx)
+	if err != nil {
+		return err
+	}
+	clear(a.want)
+	clear(a.live)
+	clear(a.byLevel)
+	for _, rec := range active {
+		a.adoptOrder(rec)
+	}
+	return nil
+}
+
+// sweep settles every live order the store holds as ended, the way its
+// event would have. Events are delivered at most once, so without it a
+// dropped fill would leave its level occupied and its counter unplaced.
+func (a *actor) sweep(ctx context.Context) error {
+	active, err := a.activeOrders(ctx)
+	if err != nil {
+		return err
+	}
+	resting := make(map[order.ClientOrderID]bool, len(active))
+	for _, rec := range active {
+		resting[rec.ClientOrderID] = true
+	}
+	for clientID := range a.live {
+		if resting[clientID] {
+			continue
+		}
+		rec, err := a.orders.GetOrder(ctx, clientID)
+		switch {
+		case errors.Is(err, ports.ErrNotFound):
+			continue
+		case err != nil:
+			return fmt.Errorf("grid store: get order %s: %w", clientID, err)
+		}
+		if rec.Status.Terminal() {
+			a.settle(orderEvent{id: clientID, status: rec.Status})
+		}
+	}
+	return nil
+}
+
+// activeOrders pages through the bot's pending, open and partially filled
+// orders on its venue.
+func (a *actor) activeOrders(ctx context.Context) ([]order.Record, error) {
+	botID, venue := a.spec.BotID, string(a.spec.Instrument.Venue)
+	query := order.Query{
+		BotID: &botID, Venue: &venue, Limit: listPage,
+		Statuses: []string{string(order.StatusPending), string(order.StatusOpen), string(order.StatusPartiallyFilled)},
+	}
+	var active []order.Record
+	for {
+		rows, err := a.orders.ListOrders(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("grid store: list orders of bot %s: %w", botID, err)
+		}
+		hasMore := len(rows) > listPage
+		if hasMore {
+			rows = rows[:listPage]
+		}
+		active = append(active, rows...)
+		if !hasMore {
+			return active, nil
+		}
+		last := rows[len(rows)-1]
+		cursorID := string(last.ClientOrderID)
+		query.CursorCreatedAt, query.CursorID = &last.CreatedAt, &cursorID
+	}
+}
+
+func (a *actor) adoptOrder(rec order.Record) {
+	idx, onGrid := a.spec.IndexOf(rec.Price)
+	_, taken := a.byLevel[idx]
+	if rec.Instrument.Pair() != a.spec.Instrument.Pair() || !onGrid || taken {
+		a.log.Warn().Str("client_order_id", string(rec.ClientOrderID)).Str("price", rec.Price.String()).
+			Msg("active order does not fit the grid; leaving it alone")
+		return
+	}
+	a.register(rec.ClientOrderID, grid.Level{Index: idx, Side: rec.Side, Price: rec.Price})
+	a.want[idx] = rec.Side
+}
+
+func (a *actor) register(id order.ClientOrderID, lvl grid.Level) {
+	a.live[id] = lvl
+	a.byLevel[lvl.Index] = id
+}
+
+func (a *actor) unregister(id order.ClientOrderID) (grid.Level, bool) {
+	lvl, ok := a.live[id]
+	if !ok {
+		return grid.Level{}, false
+	}
+	delete(a.live, id)
+	if a.byLevel[lvl.Index] == id {
+		delete(a.byLevel, lvl.Index)
+	}
+	return lvl, true
+}
+
+// placeMissing places every wanted level without a live order, lowest
+// level first.
+func (a *actor) placeMissing(ctx context.Context) {
+	if !a.started || a.state != StateRunning {
+		return
+	}
+	for i := range a.spec.Levels {
+		side, wanted := a.want[i]
+		if _, resting := a.byLevel[i]; !wanted || resting {
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		a.place(ctx, grid.Level{Index: i, Side: side, Price: a.spec.Price(i)})
+	}
+}
+
+// place submits one level. The order is registered before the submit so
+// an outcome that races the reply still finds its level. An unsettled
+// submit counts as placed: the order exists locally and its outcome
+// arrives like any other. Levels are post-only, so a grid never takes
+// liquidity: a level the price has already run through is rejected by the
+// venue instead of trading. A bot on a venue that cannot promise post-only
+// is refused at start; should the adapter refuse the flag anyway, the
+// level is left empty rather than retried.
+func (a *actor) place(ctx context.Context, lvl grid.Level) {
+	clientID := order.ClientOrderID(id.New())
+	a.register(clientID, lvl)
+	res, err := a.placer.Place(ctx, order.Request{
+		ClientOrderID: clientID,
+		BotID:         a.spec.BotID,
+		Instrument:    a.spec.Instrument,
+		Side:          lvl.Side,
+		Type:          order.Limit,
+		Price:         lvl.Price,
+		Qty:           a.spec.Qty,
+		PostOnly:      true,
+	})
+	logEvent := a.log.Debug()
+	var unsupported *ports.UnsupportedError
+	switch {
+	case errors.Is(err, orderservice.ErrSubmitUnsettled):
+		logEvent = a.log.Warn().Err(err)
+	case errors.As(err, &unsupported):
+		a.unregister(clientID)
+		delete(a.want, lvl.Index)
+		a.metrics.observeFailure(a.spec.BotID)
+		a.log.Error().Int("level", lvl.Index).Str("side", string(lvl.Side)).Err(err).
+			Msg("venue cannot place grid order post-only; level left empty")
+		return
+	case err != nil:
+		a.unregister(clientID)
+		a.metrics.observeFailure(a.spec.BotID)
+		a.log.Warn().Int("level", lvl.Index).Str("side", string(lvl.Side)).Err(err).
+			Msg("grid order failed; retrying next interval")
+		return
+	case res.Status == order.StatusRejected:
+		a.unregister(clientID)
+		delete(a.want, lvl.Index)
+		a.log.Warn().Int("level", lvl.Index).Str("side", string(lvl.Side)).
+			Msg("venue rejected grid order; level left empty")
+		return
+	}
+	a.metrics.observePlaced(a.spec.BotID)
+	logEvent.Int("level", lvl.Index).Str("side", string(lvl.Side)).
+		Str("client_order_id", string(clientID)).Msg("grid order placed")
+}
+
+// onOrder applies an order outcome. A fill moves the level to its
+// counter order; a venue rejection empties it; a cancel or expiry keeps it
+// wanted, so the grid re-places it (pause or stop the bot to take levels
+// out).
+func (a *actor) onOrder(ctx context.Context, ev orderEvent) {
+	a.settle(ev)
+	a.placeMissing(ctx)
+}
+
+// settle applies an outcome to the levels without placing anything. An
+// order the bot no longer tracks, such as one a sweep already settled, is
+// ignored.
+func (a *actor) settle(ev orderEvent) {
+	lvl, ok := a.unregister(ev.id)
+	if !ok {
+		return
+	}
+	switch ev.status {
+	case order.StatusFilled:
+		a.metrics.observeFill(a.spec.BotID)
+		delete(a.want, lvl.Index)
+		next, ok := a.spec.Counter(lvl)
+		if !ok {
+			a.log.Info().Int("level", lvl.Index).Msg("grid edge filled; no counter level")
+			break
+		}
+		if side, wanted := a.want[next.Index]; wanted && side != next.Side {
+			a.log.Warn().Int("level", next.Index).Msg("counter level already holds the opposite side; skipped")
+			break
+		}
+		a.want[next.Index] = next.Side
+	case order.StatusRejected:
+		delete(a.want, lvl.Index)
+	}
+}
+
+// handle runs one mailbox command and reports whether the actor exits.
+func (a *actor) handle(ctx context.Context, cmd command) bool {
+	switch cmd.op {
+	case opPause:
+		a.state = StatePaused
+	case opResume:
+		a.state = StateRunning
+		a.placeMissing(ctx)
+	case opStop:
+		a.stop(ctx)
+	}
+	cmd.reply <- a.status()
+	return cmd.op == opStop
+}
+
+// stop cancels every live order. Orders that already ended are fine; other
+// failures are logged and left for the operator, since the bot will not
+// touch them again.
+func (a *actor) stop(ctx context.Context) {
+	a.state = StateStopped
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), stopTimeout)
+	defer cancel()
+	for clientID := range a.live {
+		if _, err := a.placer.Cancel(ctx, clientID); err != nil && !errors.Is(err, orderservice.ErrTerminal) {
+			a.log.Error().Str("client_order_id", string(clientID)).Err(err).Msg("grid stop: cancel failed")
+		}
+		a.unregister(clientID)
+	}
+	clear(a.want)
+	a.log.Info().Msg("grid stopped")
+}
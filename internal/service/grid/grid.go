@@ -0,0 +1,277 @@
+// Package grid runs grid bots. Each bot is one actor goroutine owning its
+// ladder: it places a buy on every level below the price and a sell on
+// every level above, through the order service under its own bot ID, and
+// answers every fill with the opposite side one level away. Operators
+// steer a bot through its mailbox (pause, resume, stop); order outcomes
+// reach it from the outbox subjects on the bus.
+package grid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/grid"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/events"
+	"github.com/romanornr/delta-works/internal/exchange"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+	orderservice "github.com/romanornr/delta-works/internal/service/order"
+)
+
+// inboxBuffer sizes each bot's order-event queue. The bus delivers at
+// most once and drops events its subscriber falls behind on, so a fill
+// can still be lost; each tick's sweep of the store recovers it.
+const inboxBuffer = 64
+
+var (
+	// ErrUnknownBot reports a command for a bot that is not configured.
+	ErrUnknownBot = errors.New("unknown grid bot")
+	// ErrBotStopped reports a command for a bot whose actor has exited.
+	ErrBotStopped = errors.New("grid bot stopped")
+	// ErrBotRefused reports a bot that refused to start because its spec
+	// does not fit the instrument. Every command to it returns the reason.
+	ErrBotRefused = errors.New("grid bot refused")
+)
+
+// State is a bot's lifecycle state.
+type State string
+
+// Bot states. A paused bot keeps its resting orders but places nothing;
+// a stopped bot has canceled them and exited.
+const (
+	StateRunning State = "running"
+	StatePaused  State = "paused"
+	StateStopped State = "stopped"
+)
+
+// Status is a bot's state as reported by its actor.
+type Status struct {
+	BotID string
+	State State
+	Open  int // grid orders the bot believes are resting
+}
+
+// Placer is the order surface the bots trade through; *order.Service
+// satisfies it.
+type Placer interface {
+	Place(ctx context.Context, req order.Request) (orderservice.PlaceResult, error)
+	Cancel(ctx context.Context, orderID order.ClientOrderID) (order.Status, error)
+}
+
+type op int
+
+const (
+	opStatus op = iota
+	opPause
+	opResume
+	opStop
+)
+
+type command struct {
+	op    op
+	reply chan Status
+}
+
+// orderEvent is an order outcome the actor acts on.
+type orderEvent struct {
+	id     order.ClientOrderID
+	status order.Status
+}
+
+type bot struct {
+	spec    grid.Spec
+	mailbox chan command
+	inbox   chan orderEvent
+	done    chan struct{}
+
+	mu      sync.Mutex
+	final   Status // set before done is closed
+	refusal error  // set before done is closed when the bot refused to start
+}
+
+// Service runs one actor per configured bot.
+type Service struct {
+	bots     map[string]*bot // immutable after New
+	all      []*bot
+	placer   Placer
+	orders   ports.GridOrderStore
+	catalog  ports.InstrumentCatalog
+	registry exchange.Registry
+	bus      bus.Bus
+	clk      clockwork.Clock
+	log      log.Logger
+	retry    time.Duration
+	metrics  *Metrics
+}
+
+// New builds the service. Specs must be valid and have unique bot IDs.
+// Metrics must not be nil. retry spaces re-placement of failed levels and
+// startup retries while the venue is unreachable. Each bot checks its spec
+// against the catalog's rules for its instrument before it places anything.
+func New(
+	specs []grid.Spec,
+	placer Placer,
+	orders ports.GridOrderStore,
+	catalog ports.InstrumentCatalog,
+	registry exchange.Registry,
+	eventBus bus.Bus,
+	clk clockwork.Clock,
+	logger log.Logger,
+	retry time.Duration,
+	metrics *Metrics,
+) *Service {
+	s := &Service{
+		bots: make(map[string]*bot, len(specs)), placer: placer, orders: orders,
+		catalog: catalog, registry: registry, bus: eventBus, clk: clk,
+		log: log.Component(logger, "grid"), retry: retry, metrics: metrics,
+	}
+	for _, spec := range specs {
+		b := &bot{
+			spec:    spec,
+			mailbox: make(chan command),
+			inbox:   make(chan orderEvent, inboxBuffer),
+			done:    make(chan struct{}),
+		}
+		s.bots[spec.BotID] = b
+		s.all = append(s.all, b)
+	}
+	return s
+}
+
+// Run routes order events to the bots and runs every actor until ctx is
+// canceled. Canceling ctx leaves resting orders in place; only Stop
+// cancels them. Store failures stop the service so the process can fail
+// fast.
+func (s *Service) Run(ctx context.Context) error {
+	unsubscribe, err := s.bus.Subscribe("order.", s.route)
+	if err != nil {
+		return fmt.Errorf("grid: subscribe to order events: %w", err)
+	}
+	defer unsubscribe()
+
+	g, ctx := errgroup.WithContext(ctx)
+	for _, b := range s.all {
+		g.Go(func() error { return s.runBot(ctx, b) })
+	}
+	return g.Wait()
+}
+
+// route forwards the order outcomes a grid reacts to: full fills, and
+// terminal states that end a level without one. Partial fills are left to
+// run their course. Events are nudges: one the bus drops is settled by the
+// bot's next sweep.
+func (s *Service) route(ctx context.Context, event bus.Event) {
+	raw, ok := event.Payload.(json.RawMessage)
+	if !ok {
+		return
+	}
+	var (
+		botID string
+		ev    orderEvent
+	)
+	switch event.Subject {
+	case events.SubjectOrderFilled:
+		var p events.OrderFilledPayload
+		if err := json.Unmarshal(raw, &p); err != nil || p.Status != order.StatusFilled {
+			return
+		}
+		botID, ev = p.BotID, orderEvent{id: p.ClientOrderID, status: p.Status}
+	case events.SubjectOrderUpdated:
+		var p events.OrderUpdatedPayload
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return
+		}
+		switch p.Status {
+		case order.StatusCanceled, order.StatusRejected, order.StatusExpired:
+		default:
+			return
+		}
+		botID, ev = p.BotID, orderEvent{id: p.ClientOrderID, status: p.Status}
+	default:
+		return
+	}
+	b, ok := s.bots[botID]
+	if !ok {
+		return
+	}
+	select {
+	case b.inbox <- ev:
+	case <-b.done:
+	case <-ctx.Done():
+	}
+}
+
+// Status reports a bot's state. A stopped bot reports its final state; one
+// that refused to start also returns why.
+func (s *Service) Status(ctx context.Context, botID string) (Status, error) {
+	return s.send(ctx, botID, opStatus)
+}
+
+// List reports every bot's state in configuration order, a refused bot as
+// stopped.
+func (s *Service) List(ctx context.Context) ([]Status, error) {
+	statuses := make([]Status, 0, len(s.all))
+	for _, b := range s.all {
+		st, err := s.Status(ctx, b.spec.BotID)
+		if err != nil && !errors.Is(err, ErrBotRefused) {
+			return nil, err
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses, nil
+}
+
+// Pause stops a bot from placing orders; its resting orders stay.
+func (s *Service) Pause(ctx context.Context, botID string) (Status, error) {
+	return s.send(ctx, botID, opPause)
+}
+
+// Resume lets a paused bot place its missing levels again.
+func (s *Service) Resume(ctx context.Context, botID string) (Status, error) {
+	return s.send(ctx, botID, opResume)
+}
+
+// Stop cancels a bot's resting orders and ends its actor. A stopped bot
+// stays stopped until the daemon restarts.
+func (s *Service) Stop(ctx context.Context, botID string) (Status, error) {
+	return s.send(ctx, botID, opStop)
+}
+
+func (s *Service) send(ctx context.Context, botID string, o op) (Status, error) {
+	b, ok := s.bots[botID]
+	if !ok {
+		return Status{}, fmt.Errorf("%w: %q", ErrUnknownBot, botID)
+	}
+	reply := make(chan Status, 1)
+	select {
+	case b.mailbox <- command{op: o, reply: reply}:
+	case <-b.done:
+		b.mu.Lock()
+		final, refusal := b.final, b.refusal
+		b.mu.Unlock()
+		if refusal != nil {
+			return final, refusal
+		}
+		if o == opStatus {
+			return final, nil
+		}
+		return final, fmt.Errorf("%w: %q", ErrBotStopped, botID)
+	case <-ctx.Done():
+		return Status{}, ctx.Err()
+	}
+	select {
+	case st := <-reply:
+		return st, nil
+	case <-ctx.Done():
+		return Status{}, ctx.Err()
+	}
+}
@@ -0,0 +1,439 @@
+package grid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/grid"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/events"
+	"github.com/romanornr/delta-works/internal/exchange"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+	orderservice "github.com/romanornr/delta-works/internal/service/order"
+)
+
+const botID = "grid-1"
+
+func testSpec() grid.Spec {
+	return grid.Spec{
+		BotID:      botID,
+		Instrument: instrument.Instrument{Venue: "paper", Type: instrument.TypeSpot, Base: "BTC", Quote: "USDT"},
+		Lower:      decimal.NewFromInt(100), Upper: decimal.NewFromInt(110),
+		Levels: 6, Qty: decimal.NewFromInt(1),
+	}
+}
+
+// fakeExchange quotes last; plainLimits makes it a venue whose adapter
+// cannot place post-only.
+type fakeExchange struct {
+	last        decimal.Decimal
+	plainLimits bool
+}
+
+func (f *fakeExchange) ID() instrument.VenueID { return "paper" }
+
+func (f *fakeExchange) HonorsPostOnly() bool { return !f.plainLimits }
+
+func (f *fakeExchange) Ticker(_ context.Context, inst instrument.Instrument) (marketdata.Ticker, error) {
+	return marketdata.Ticker{Instrument: inst, Last: f.last}, nil
+}
+
+func (f *fakeExchange) Instruments(context.Context, instrument.Type) ([]instrument.Instrument, error) {
+	return nil, nil
+}
+
+func (f *fakeExchange) Balances(context.Context, account.Type) ([]account.Balance, error) {
+	return nil, nil
+}
+
+type fakePlacer struct {
+	mu      sync.Mutex
+	placed  []order.Request
+	cancels []order.ClientOrderID
+	failing int // submits to fail before succeeding
+	reject  bool
+	// unsupported refuses every submit as the adapter would a post-only
+	// order it cannot honor.
+	unsupported bool
+}
+
+func (f *fakePlacer) Place(_ context.Context, req order.Request) (orderservice.PlaceResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failing > 0 {
+		f.failing--
+		return orderservice.PlaceResult{}, ports.ErrVenueUnavailable
+	}
+	if f.unsupported {
+		return orderservice.PlaceResult{}, &ports.UnsupportedError{Venue: req.Instrument.Venue, Flag: "post-only"}
+	}
+	f.placed = append(f.placed, req)
+	status := order.StatusOpen
+	if f.reject {
+		status = order.StatusRejected
+	}
+	return orderservice.PlaceResult{ClientOrderID: req.ClientOrderID, Status: status}, nil
+}
+
+func (f *fakePlacer) Cancel(_ context.Context, id order.ClientOrderID) (order.Status, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cancels = append(f.cancels, id)
+	return order.StatusCanceled, nil
+}
+
+// orders renders the placed requests from index from on as side@price.
+func (f *fakePlacer) orders(from int) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []string
+	for _, req := range f.placed[min(from, len(f.placed)):] {
+		out = append(out, fmt.Sprintf("%s@%s", req.Side, req.Price))
+	}
+	return strings.Join(out, " ")
+}
+
+func (f *fakePlacer) request(i int) order.Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.placed[i]
+}
+
+type fakeOrders struct {
+	mu     sync.Mutex
+	active []order.Record
+	ended  map[order.ClientOrderID]order.Status
+}
+
+func (f *fakeOrders) ListOrders(_ context.Context, query order.Query) ([]order.Record, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if *query.BotID != botID || query.CursorID != nil {
+		return nil, nil
+	}
+	return f.active, nil
+}
+
+// GetOrder reports the orders marked ended; the rest are not stored, which
+// a sweep skips.
+func (f *fakeOrders) GetOrder(_ context.Context, id order.ClientOrderID) (order.Record, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	status, ok := f.ended[id]
+	if !ok {
+		return order.Record{}, ports.ErrNotFound
+	}
+	return order.Record{ClientOrderID: id, BotID: botID, Status: status}, nil
+}
+
+func (f *fakeOrders) end(id order.ClientOrderID, status order.Status) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.ended[id] = status
+}
+
+// fakeCatalog lists the test instrument, trading under rules.
+type fakeCatalog struct{ rules instrument.Rules }
+
+func (f fakeCatalog) ListInstruments(context.Context, instrument.VenueID) ([]instrument.Instrument, error) {
+	inst := testSpec().Instrument
+	inst.Status, inst.Rules = instrument.StatusTrading, f.rules
+	return []instrument.Instrument{inst}, nil
+}
+
+type harness struct {
+	svc     *Service
+	placer  *fakePlacer
+	orders  *fakeOrders
+	bus     *bus.InProc
+	clk     *clockwork.FakeClock
+	metrics *Metrics
+}
+
+func start(t *testing.T, placer *fakePlacer, active ...order.Record) *harness {
+	t.Helper()
+	return startWith(t, instrument.Rules{}, placer, active...)
+}
+
+func startWith(t *testing.T, rules instrument.Rules, placer *fakePlacer, active ...order.Record) *harness {
+	t.Helper()
+	return startOn(t, &fakeExchange{last: decimal.RequireFromString("105.1")}, rules, placer, active...)
+}
+
+func startOn(t *testing.T, ex *fakeExchange, rules instrument.Rules, placer *fakePlacer, active ...order.Record) *harness {
+	t.Helper()
+	metrics, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &harness{
+		placer: placer, orders: &fakeOrders{active: active, ended: map[order.ClientOrderID]order.Status{}},
+		bus: bus.NewInProc(), clk: clockwork.NewFakeClock(), metrics: metrics,
+	}
+	t.Cleanup(h.bus.Close)
+	registry := exchange.NewRegistry([]ports.Exchange{ex})
+	h.svc = New([]grid.Spec{testSpec()}, placer, h.orders, fakeCatalog{rules: rules}, registry, h.bus, h.clk, log.Nop(), time.Minute, metrics)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() { done <- h.svc.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Run: %v", err)
+		}
+	})
+	// Commands are served after the first tick, so this waits for startup.
+	if _, err := h.svc.Status(t.Context(), botID); err != nil && !errors.Is(err, ErrBotRefused) {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func (h *harness) publish(t *testing.T, subject string, payload any) {
+	t.Helper()
+	body, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h.bus.Publish(t.Context(), bus.Event{Subject: subject, Payload: json.RawMessage(body)}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (h *harness) fill(t *testing.T, i int) {
+	t.Helper()
+	h.publish(t, events.SubjectOrderFilled, events.OrderFilledPayload{
+		ClientOrderID: h.placer.request(i).ClientOrderID, BotID: botID, Status: order.StatusFilled,
+	})
+}
+
+func (h *harness) end(t *testing.T, i int, status order.Status) {
+	t.Helper()
+	h.publish(t, events.SubjectOrderUpdated, events.OrderUpdatedPayload{
+		ClientOrderID: h.placer.request(i).ClientOrderID, BotID: botID, Status: status,
+	})
+}
+
+// waitOrders waits until the requests placed from index from on render as
+// want.
+func waitOrders(t *testing.T, placer *fakePlacer, from int, want string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for placer.orders(from) != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("placed %q, want %q", placer.orders(from), want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestFillsPlaceCounterOrders(t *testing.T) {
+	t.Parallel()
+	h := start(t, &fakePlacer{})
+	waitOrders(t, h.placer, 0, "buy@100 buy@102 buy@104 sell@108 sell@110")
+	if req := h.placer.request(0); req.BotID != botID || req.Type != order.Limit || !req.PostOnly || !req.Qty.Equal(decimal.NewFromInt(1)) {
+		t.Fatalf("request = %+v", req)
+	}
+
+	h.fill(t, 2) // buy@104 fills: sell one level up
+	waitOrders(t, h.placer, 5, "sell@106")
+	h.fill(t, 5) // that sell fills: buy one level down again
+	waitOrders(t, h.placer, 5, "sell@106 buy@104")
+	h.publish(t, events.SubjectOrderFilled, events.OrderFilledPayload{
+		ClientOrderID: "someone-else", BotID: "manual", Status: order.StatusFilled,
+	})
+
+	st, err := h.svc.Status(t.Context(), botID)
+	if err != nil || st.State != StateRunning || st.Open != 5 {
+		t.Fatalf("Status = %+v, %v", st, err)
+	}
+	if got := testutil.ToFloat64(h.metrics.fills.WithLabelValues(botID)); got != 2 {
+		t.Fatalf("fills = %v, want 2", got)
+	}
+}
+
+func TestRestartAdoptsActiveOrders(t *testing.T) {
+	t.Parallel()
+	h := start(t, &fakePlacer{}, order.Record{
+		ClientOrderID: "resting", BotID: botID, Instrument: testSpec().Instrument,
+		Side: order.Buy, Price: decimal.NewFromInt(104), Status: order.StatusOpen,
+	})
+	waitOrders(t, h.placer, 0, "buy@100 buy@102 sell@108 sell@110")
+
+	h.publish(t, events.SubjectOrderFilled, events.OrderFilledPayload{ClientOrderID: "resting", BotID: botID, Status: order.StatusFilled})
+	waitOrders(t, h.placer, 4, "sell@106")
+}
+
+func TestSweepSettlesDroppedFill(t *testing.T) {
+	t.Parallel()
+	h := start(t, &fakePlacer{})
+	waitOrders(t, h.placer, 0, "buy@100 buy@102 buy@104 sell@108 sell@110")
+
+	// The fill event never arrives; the store has the order filled.
+	h.orders.end(h.placer.request(2).ClientOrderID, order.StatusFilled)
+	h.clk.Advance(time.Minute)
+	waitOrders(t, h.placer, 5, "sell@106")
+	if got := testutil.ToFloat64(h.metrics.fills.WithLabelValues(botID)); got != 1 {
+		t.Fatalf("fills = %v, want 1", got)
+	}
+
+	// A late event for the settled order changes nothing.
+	h.fill(t, 2)
+	h.clk.Advance(time.Minute)
+	st, err := h.svc.Status(t.Context(), botID)
+	if err != nil || st.Open != 5 || h.placer.orders(6) != "" {
+		t.Fatalf("Status = %+v, %v; placed %q", st, err, h.placer.orders(6))
+	}
+}
+
+func TestOffTickSpecRefusesBot(t *testing.T) {
+	t.Parallel()
+	h := startWith(t, instrument.Rules{PriceIncrement: decimal.NewFromInt(4)}, &fakePlacer{})
+	st, err := h.svc.Status(t.Context(), botID)
+	if !errors.Is(err, ErrBotRefused) || st.State != StateStopped {
+		t.Fatalf("Status = %+v, %v; want stopped and refused", st, err)
+	}
+	if _, err := h.svc.Resume(t.Context(), botID); !errors.Is(err, ErrBotRefused) {
+		t.Fatalf("Resume = %v, want refused", err)
+	}
+	if placed := h.placer.orders(0); placed != "" {
+		t.Fatalf("refused bot placed %q", placed)
+	}
+	if list, err := h.svc.List(t.Context()); err != nil || len(list) != 1 || list[0].State != StateStopped {
+		t.Fatalf("List = %+v, %v", list, err)
+	}
+}
+
+func TestFailedPlacementsRetryOnInterval(t *testing.T) {
+	t.Parallel()
+	h := start(t, &fakePlacer{failing: 4})
+	waitOrders(t, h.placer, 0, "sell@110")
+	if got := testutil.ToFloat64(h.metrics.failures.WithLabelValues(botID)); got != 4 {
+		t.Fatalf("failures = %v, want 4", got)
+	}
+	h.clk.Advance(time.Minute)
+	waitOrders(t, h.placer, 1, "buy@100 buy@102 buy@104 sell@108")
+}
+
+func TestRejectedLevelIsLeftEmpty(t *testing.T) {
+	t.Parallel()
+	h := start(t, &fakePlacer{reject: true})
+	waitOrders(t, h.placer, 0, "buy@100 buy@102 buy@104 sell@108 sell@110")
+	h.clk.Advance(time.Minute)
+	st, err := h.svc.Status(t.Context(), botID)
+	if err != nil || st.Open != 0 || h.placer.orders(5) != "" {
+		t.Fatalf("Status = %+v, %v; re-placed %q", st, err, h.placer.orders(5))
+	}
+}
+
+func TestVenueWithoutPostOnlyRefusesBot(t *testing.T) {
+	t.Parallel()
+	h := startOn(t, &fakeExchange{last: decimal.RequireFromString("105.1"), plainLimits: true}, instrument.Rules{}, &fakePlacer{})
+	st, err := h.svc.Status(t.Context(), botID)
+	if !errors.Is(err, ErrBotRefused) || !strings.Contains(err.Error(), "post-only") || st.State != StateStopped {
+		t.Fatalf("Status = %+v, %v; want stopped and refused for post-only", st, err)
+	}
+	h.clk.Advance(time.Minute)
+	if placed := h.placer.orders(0); placed != "" {
+		t.Fatalf("refused bot placed %q", placed)
+	}
+}
+
+func TestUnsupportedPostOnlyLeavesLevelsEmpty(t *testing.T) {
+	t.Parallel()
+	h := start(t, &fakePlacer{unsupported: true})
+	waitFailures(t, h.metrics, 5)
+	h.clk.Advance(time.Minute)
+	st, err := h.svc.Status(t.Context(), botID)
+	if err != nil || st.Open != 0 {
+		t.Fatalf("Status = %+v, %v", st, err)
+	}
+	if got := testutil.ToFloat64(h.metrics.failures.WithLabelValues(botID)); got != 5 {
+		t.Fatalf("failures = %v, want 5 with no retries", got)
+	}
+}
+
+func waitFailures(t *testing.T, metrics *Metrics, want float64) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for testutil.ToFloat64(metrics.failures.WithLabelValues(botID)) != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("failures = %v, want %v", testutil.ToFloat64(metrics.failures.WithLabelValues(botID)), want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPauseResumeStop(t *testing.T) {
+	t.Parallel()
+	h := start(t, &fakePlacer{})
+	waitOrders(t, h.placer, 0, "buy@100 buy@102 buy@104 sell@108 sell@110")
+
+	if st, err := h.svc.Pause(t.Context(), botID); err != nil || st.State != StatePaused {
+		t.Fatalf("Pause = %+v, %v", st, err)
+	}
+	h.end(t, 0, order.StatusCanceled)
+	waitOpen(t, h.svc, 4)
+	if got := h.placer.orders(5); got != "" {
+		t.Fatalf("paused bot placed %q", got)
+	}
+	if _, err := h.svc.Resume(t.Context(), botID); err != nil {
+		t.Fatal(err)
+	}
+	waitOrders(t, h.placer, 5, "buy@100")
+
+	st, err := h.svc.Stop(t.Context(), botID)
+	if err != nil || st.State != StateStopped || st.Open != 0 {
+		t.Fatalf("Stop = %+v, %v", st, err)
+	}
+	h.placer.mu.Lock()
+	canceled := len(h.placer.cancels)
+	h.placer.mu.Unlock()
+	if canceled != 5 {
+		t.Fatalf("canceled %d orders, want 5", canceled)
+	}
+	if st, err := h.svc.Status(t.Context(), botID); err != nil || st.State != StateStopped {
+		t.Fatalf("Status after stop = %+v, %v", st, err)
+	}
+	if _, err := h.svc.Resume(t.Context(), botID); !errors.Is(err, ErrBotStopped) {
+		t.Fatalf("Resume after stop = %v, want ErrBotStopped", err)
+	}
+	if _, err := h.svc.Pause(t.Context(), "missing"); !errors.Is(err, ErrUnknownBot) {
+		t.Fatalf("Pause unknown = %v, want ErrUnknownBot", err)
+	}
+}
+
+func waitOpen(t *testing.T, svc *Service, want int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		st, err := svc.Status(t.Context(), botID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st.Open == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("open = %d, want %d", st.Open, want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
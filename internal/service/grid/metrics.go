@@ -0,0 +1,48 @@
+package grid
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics holds the service's Prometheus instruments.
+type Metrics struct {
+	placed   *prometheus.CounterVec
+	failures *prometheus.CounterVec
+	fills    *prometheus.CounterVec
+}
+
+// NewMetrics registers the service metrics on the given registry.
+func NewMetrics(reg *prometheus.Registry) (*Metrics, error) {
+	m := &Metrics{
+		placed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grid_orders_placed_total",
+			Help: "Grid level orders submitted through the order service.",
+		}, []string{"bot"}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grid_place_failures_total",
+			Help: "Grid level placements that failed and wait for the retry interval.",
+		}, []string{"bot"}),
+		fills: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grid_fills_total",
+			Help: "Grid level orders fully filled.",
+		}, []string{"bot"}),
+	}
+	for _, collector := range []prometheus.Collector{m.placed, m.failures, m.fills} {
+		if err := reg.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func (m *Metrics) observePlaced(bot string) {
+	m.placed.With(prometheus.Labels{"bot": bot}).Inc()
+}
+
+func (m *Metrics) observeFailure(bot string) {
+	m.failures.With(prometheus.Labels{"bot": bot}).Inc()
+}
+
+func (m *Metrics) observeFill(bot string) {
+	m.fills.With(prometheus.Labels{"bot": bot}).Inc()
+}
@@ -0,0 +1,437 @@
+// Package group coordinates linked order groups: OCO pairs and brackets.
+// It places a group's first orders through the order service, then follows
+// the outbox order subjects on the bus and, whenever a leg moves, places a
+// bracket's exits or cancels the other exit. Every decision is read from
+// the stored orders, so a periodic sweep repairs whatever a lost event or a
+// restart left undone.
+package group
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/events"
+	"github.com/romanornr/delta-works/internal/id"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+	orderservice "github.com/romanornr/delta-works/internal/service/order"
+)
+
+// inboxBuffer sizes the queue of leg orders to look at. A full inbox
+// applies backpressure to the bus subscriber rather than dropping a fill.
+const inboxBuffer = 64
+
+// ErrGroupFinished reports a cancel of a group that already completed or
+// was canceled.
+var ErrGroupFinished = errors.New("order group already finished")
+
+// Placer is the order surface groups trade through; *order.Service
+// satisfies it.
+type Placer interface {
+	Place(ctx context.Context, req order.Request) (orderservice.PlaceResult, error)
+	Cancel(ctx context.Context, orderID order.ClientOrderID) (order.Status, error)
+}
+
+// View is a group with the stored orders of the legs placed so far.
+type View struct {
+	Group  order.Group
+	Orders map[order.ClientOrderID]order.Record
+}
+
+// Service is the group coordinator.
+type Service struct {
+	store   ports.OrderGroupStore
+	placer  Placer
+	bus     bus.Bus
+	clk     clockwork.Clock
+	log     log.Logger
+	sweep   time.Duration
+	metrics *Metrics
+	inbox   chan order.ClientOrderID
+
+	// mu serializes every decision on every group, so an event, a sweep
+	// and an operator never act on the same group at once.
+	mu sync.Mutex
+}
+
+// New builds the coordinator. Metrics must not be nil. sweep spaces the
+// passes over every open group.
+func New(store ports.OrderGroupStore, placer Placer, eventBus bus.Bus, clk clockwork.Clock, logger log.Logger, sweep time.Duration, metrics *Metrics) *Service {
+	return &Service{
+		store:   store,
+		placer:  placer,
+		bus:     eventBus,
+		clk:     clk,
+		log:     log.Component(logger, "group"),
+		sweep:   sweep,
+		metrics: metrics,
+		inbox:   make(chan order.ClientOrderID, inboxBuffer),
+	}
+}
+
+// Place stores the group, then places its first legs: a bracket's entry,
+// or both legs of an OCO pair. Empty group and client order IDs are
+// generated; a retry under a stored group ID with the same terms resumes
+// that group. A leg refused before it was stored cancels the group and
+// the legs already placed; the refusal is returned with the group.
+// ErrSubmitUnsettled means every leg is stored but a submit did not
+// settle.
+func (s *Service) Place(ctx context.Context, g order.Group) (View, error) {
+	if g.ID == "" {
+		g.ID = order.GroupID(id.New())
+	}
+	for i := range g.Legs {
+		if g.Legs[i].ClientOrderID == "" {
+			g.Legs[i].ClientOrderID = order.ClientOrderID(id.New())
+		}
+	}
+	if err := order.CheckGroup(g); err != nil {
+		return View{}, err
+	}
+	g.Status = order.GroupActive
+	if g.Kind == order.GroupBracket {
+		g.Status = order.GroupPending
+	}
+	g.CreatedAt = s.clk.Now()
+	g.UpdatedAt = g.CreatedAt
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	created, err := s.store.CreateGroup(ctx, g)
+	if err != nil {
+		return View{}, err
+	}
+	if !created {
+		stored, err := s.store.GetGroup(ctx, g.ID)
+		if err != nil {
+			return View{}, err
+		}
+		if !sameGroup(stored, g) {
+			return View{}, fmt.Errorf("%w: group %s", orderservice.ErrIdentityMismatch, g.ID)
+		}
+		g = stored
+	}
+	if g.Status.Final() {
+		return s.view(ctx, g)
+	}
+
+	refused := map[order.ClientOrderID]order.Record{}
+	var placeErr error
+	for _, leg := range g.Initial() {
+		rec, err := s.placeLeg(ctx, g.Request(leg))
+		if rec != nil {
+			refused[leg.ClientOrderID] = *rec
+		}
+		if err != nil && (placeErr == nil || errors.Is(placeErr, orderservice.ErrSubmitUnsettled)) {
+			placeErr = err
+		}
+		if rec != nil {
+			break
+		}
+	}
+	if created {
+		s.log.Info().Str("group", string(g.ID)).Str("kind", string(g.Kind)).
+			Str("venue", string(g.Instrument.Venue)).Msg("order group placed")
+	}
+	g, err = s.advance(ctx, g, refused)
+	if err != nil {
+		return View{}, err
+	}
+	view, err := s.view(ctx, g)
+	if err != nil {
+		return View{}, err
+	}
+	return view, placeErr
+}
+
+// Cancel cancels the group's working legs and the group with them; a
+// pending bracket never places its exits. A group whose legs already
+// finished it reports ErrGroupFinished. A leg whose cancel fails leaves
+// the group open, and the error is returned for the caller to retry.
+func (s *Service) Cancel(ctx context.Context, groupID order.GroupID) (View, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, err := s.store.GetGroup(ctx, groupID)
+	if err != nil {
+		return View{}, err
+	}
+	if g, err = s.advance(ctx, g, nil); err != nil {
+		return View{}, err
+	}
+	if g.Status.Final() {
+		return View{}, fmt.Errorf("%w: %s is %s", ErrGroupFinished, groupID, g.Status)
+	}
+	orders, err := s.legOrders(ctx, g, nil)
+	if err != nil {
+		return View{}, err
+	}
+	for _, leg := range g.Legs {
+		rec, ok := orders[leg.ClientOrderID]
+		if !ok || rec.Status.Terminal() {
+			continue
+		}
+		if _, err := s.placer.Cancel(ctx, leg.ClientOrderID); err != nil && !errors.Is(err, orderservice.ErrTerminal) {
+			return View{}, fmt.Errorf("cancel %s %s: %w", leg.Role, leg.ClientOrderID, err)
+		}
+	}
+	if g, err = s.settle(ctx, g, order.GroupCanceled, "canceled by request"); err != nil {
+		return View{}, err
+	}
+	return s.view(ctx, g)
+}
+
+// Get returns the group with its legs' orders, or ports.ErrNotFound.
+func (s *Service) Get(ctx context.Context, groupID order.GroupID) (View, error) {
+	g, err := s.store.GetGroup(ctx, groupID)
+	if err != nil {
+		return View{}, err
+	}
+	return s.view(ctx, g)
+}
+
+// List returns at most limit groups newest first, the open ones only when
+// openOnly is set.
+func (s *Service) List(ctx context.Context, venue instrument.VenueID, openOnly bool, limit int32) ([]order.Group, error) {
+	return s.store.ListGroups(ctx, venue, openOnly, limit)
+}
+
+// Run sweeps the open groups once, then acts on every leg event and sweeps
+// again every interval until ctx is canceled. A failed cancel is logged
+// and counted, and the next event or sweep retries it; a store failure
+// stops the service so the process fails fast.
+func (s *Service) Run(ctx context.Context) error {
+	unsubscribe, err := s.bus.Subscribe("order.", s.route)
+	if err != nil {
+		return fmt.Errorf("group: subscribe to order events: %w", err)
+	}
+	defer unsubscribe()
+
+	ticker := s.clk.NewTicker(s.sweep)
+	defer ticker.Stop()
+	if err := s.sweepOpen(ctx); err != nil {
+		return storeError(ctx, err)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case orderID := <-s.inbox:
+			if err := s.onOrder(ctx, orderID); err != nil {
+				return storeError(ctx, err)
+			}
+		case <-ticker.Chan():
+			if err := s.sweepOpen(ctx); err != nil {
+				return storeError(ctx, err)
+			}
+		}
+	}
+}
+
+// route forwards the order events that can move a group: any fill, and
+// any status a leg can end or fire on.
+func (s *Service) route(ctx context.Context, event bus.Event) {
+	raw, ok := event.Payload.(json.RawMessage)
+	if !ok {
+		return
+	}
+	var orderID order.ClientOrderID
+	switch event.Subject {
+	case events.SubjectOrderFilled:
+		var p events.OrderFilledPayload
+		if err := json.Unmarshal(raw, &p); err != nil || !p.FilledQty.IsPositive() {
+			return
+		}
+		orderID = p.ClientOrderID
+	case events.SubjectOrderUpdated:
+		var p events.OrderUpdatedPayload
+		if err := json.Unmarshal(raw, &p); err != nil || !(p.Status.Terminal() || p.Status == order.StatusTriggered) {
+			return
+		}
+		orderID = p.ClientOrderID
+	default:
+		return
+	}
+	select {
+	case s.inbox <- orderID:
+	case <-ctx.Done():
+	}
+}
+
+func (s *Service) onOrder(ctx context.Context, orderID order.ClientOrderID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, err := s.store.GroupOf(ctx, orderID)
+	switch {
+	case errors.Is(err, ports.ErrNotFound):
+		return nil
+	case err != nil:
+		return err
+	}
+	_, err = s.advance(ctx, g, nil)
+	return err
+}
+
+func (s *Service) sweepOpen(ctx context.Context) error {
+	groups, err := s.store.ListOpenGroups(ctx)
+	if err != nil {
+		return err
+	}
+	for _, open := range groups {
+		if err := s.sweepOne(ctx, open.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) sweepOne(ctx context.Context, groupID order.GroupID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, err := s.store.GetGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	_, err = s.advance(ctx, g, nil)
+	return err
+}
+
+// advance takes the group's next step and stores the status it leads to.
+// refused stands in for legs refused before they were stored. A bracket
+// whose exits are placed steps again as the active group, so an exit
+// refused at once cancels the other in the same pass. A cancel that fails
+// is logged and counted and leaves the group as stored for the next pass;
+// only store errors are returned. Callers hold mu.
+func (s *Service) advance(ctx context.Context, g order.Group, refused map[order.ClientOrderID]order.Record) (order.Group, error) {
+	if g.Status.Final() {
+		return g, nil
+	}
+	orders, err := s.legOrders(ctx, g, refused)
+	if err != nil {
+		return g, err
+	}
+	step := g.Step(orders)
+	if len(step.Place) > 0 {
+		if refused == nil {
+			refused = map[order.ClientOrderID]order.Record{}
+		}
+		for _, req := range step.Place {
+			rec, err := s.placeLeg(ctx, req)
+			if rec != nil {
+				refused[req.ClientOrderID] = *rec
+			}
+			if err != nil {
+				s.log.Warn().Str("group", string(g.ID)).Str("client_order_id", string(req.ClientOrderID)).
+					Err(err).Msg("exit placement failed")
+			}
+		}
+		placed := g
+		placed.Status, placed.Reason = step.Status, step.Reason
+		if orders, err = s.legOrders(ctx, placed, refused); err != nil {
+			return g, err
+		}
+		step = placed.Step(orders)
+	}
+	for _, orderID := range step.Cancel {
+		if _, err := s.placer.Cancel(ctx, orderID); err != nil && !errors.Is(err, orderservice.ErrTerminal) {
+			if ctx.Err() == nil {
+				s.metrics.observeFailure(g.Kind)
+				s.log.Warn().Str("group", string(g.ID)).Str("client_order_id", string(orderID)).
+					Err(err).Msg("leg cancel failed; retrying on the next pass")
+			}
+			return g, nil
+		}
+	}
+	if step.Status == g.Status && step.Reason == g.Reason {
+		return g, nil
+	}
+	return s.settle(ctx, g, step.Status, step.Reason)
+}
+
+// settle stores the group's new status.
+func (s *Service) settle(ctx context.Context, g order.Group, status order.GroupStatus, reason string) (order.Group, error) {
+	now := s.clk.Now()
+	if err := s.store.SetGroupStatus(context.WithoutCancel(ctx), g.ID, status, reason, now); err != nil {
+		return g, err
+	}
+	g.Status, g.Reason, g.UpdatedAt = status, reason, now
+	if status.Final() {
+		s.metrics.observeFinished(g.Kind, status)
+	}
+	s.log.Info().Str("group", string(g.ID)).Str("kind", string(g.Kind)).Str("status", string(status)).
+		Str("reason", reason).Msg("order group moved")
+	return g, nil
+}
+
+// placeLeg places req. A leg refused before anything was stored comes
+// back as a rejected record carrying the refusal, which stands in for the
+// order it never became. The error is the placement's.
+func (s *Service) placeLeg(ctx context.Context, req order.Request) (*order.Record, error) {
+	_, err := s.placer.Place(ctx, req)
+	if err == nil || errors.Is(err, orderservice.ErrSubmitUnsettled) {
+		return nil, err
+	}
+	if _, getErr := s.store.GetOrder(ctx, req.ClientOrderID); !errors.Is(getErr, ports.ErrNotFound) {
+		// Stored, or unknown: the stored order speaks for the leg.
+		return nil, err
+	}
+	return &order.Record{ClientOrderID: req.ClientOrderID, Status: order.StatusRejected, Reason: err.Error()}, err
+}
+
+// legOrders reads the stored order of every leg placed so far.
+func (s *Service) legOrders(ctx context.Context, g order.Group, refused map[order.ClientOrderID]order.Record) (map[order.ClientOrderID]order.Record, error) {
+	orders := make(map[order.ClientOrderID]order.Record, len(g.Legs))
+	for _, leg := range g.Legs {
+		if rec, ok := refused[leg.ClientOrderID]; ok {
+			orders[leg.ClientOrderID] = rec
+			continue
+		}
+		rec, err := s.store.GetOrder(ctx, leg.ClientOrderID)
+		switch {
+		case err == nil:
+			orders[leg.ClientOrderID] = rec
+		case !errors.Is(err, ports.ErrNotFound):
+			return nil, err
+		}
+	}
+	return orders, nil
+}
+
+func (s *Service) view(ctx context.Context, g order.Group) (View, error) {
+	orders, err := s.legOrders(ctx, g, nil)
+	if err != nil {
+		return View{}, err
+	}
+	return View{Group: g, Orders: orders}, nil
+}
+
+// sameGroup reports whether a retried placement asks for the stored group.
+func sameGroup(stored, req order.Group) bool {
+	if stored.Kind != req.Kind || stored.BotID != req.BotID || stored.Instrument.Key() != req.Instrument.Key() ||
+		len(stored.Legs) != len(req.Legs) {
+		return false
+	}
+	for _, want := range req.Legs {
+		got, ok := stored.Leg(want.Role)
+		if !ok || got.ClientOrderID != want.ClientOrderID || got.Side != want.Side || got.Type != want.Type ||
+			!got.Price.Equal(want.Price) || !got.Qty.Equal(want.Qty) || !got.TriggerPrice.Equal(want.TriggerPrice) {
+			return false
+		}
+	}
+	return true
+}
+
+func storeError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+	return fmt.Errorf("group store: %w", err)
+}
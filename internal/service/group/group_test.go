@@ -0,0 +1,351 @@
+package group
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/events"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+	orderservice "github.com/romanornr/delta-works/internal/service/order"
+)
+
+func d(s string) decimal.Decimal { return decimal.RequireFromString(s) }
+
+var btc = instrument.Instrument{Venue: "bybit", Type: instrument.TypeSpot, Base: "BTC", Quote: "USDT"}
+
+// bracket buys 2 at 100 with a take-profit at 110 and a stop at 95.
+func bracket() order.Group {
+	return order.Group{
+		ID: "G", Kind: order.GroupBracket, BotID: "manual", Instrument: btc,
+		Legs: []order.Leg{
+			{Role: order.RoleEntry, ClientOrderID: "E", Side: order.Buy, Type: order.Limit, Price: d("100"), Qty: d("2")},
+			{Role: order.RoleTakeProfit, ClientOrderID: "TP", Side: order.Sell, Type: order.Limit, Price: d("110"), Qty: d("2")},
+			{Role: order.RoleStopLoss, ClientOrderID: "SL", Side: order.Sell, Type: order.StopMarket, TriggerPrice: d("95"), Qty: d("2")},
+		},
+	}
+}
+
+func oco() order.Group {
+	g := bracket()
+	g.Kind, g.Legs = order.GroupOCO, g.Legs[1:]
+	return g
+}
+
+// fakeStore keeps groups and orders in memory.
+type fakeStore struct {
+	mu     sync.Mutex
+	groups map[order.GroupID]order.Group
+	orders map[order.ClientOrderID]order.Record
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{groups: map[order.GroupID]order.Group{}, orders: map[order.ClientOrderID]order.Record{}}
+}
+
+func (f *fakeStore) CreateGroup(_ context.Context, g order.Group) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.groups[g.ID]; ok {
+		return false, nil
+	}
+	f.groups[g.ID] = g
+	return true, nil
+}
+
+func (f *fakeStore) GetGroup(_ context.Context, id order.GroupID) (order.Group, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	g, ok := f.groups[id]
+	if !ok {
+		return order.Group{}, ports.ErrNotFound
+	}
+	return g, nil
+}
+
+func (f *fakeStore) GroupOf(_ context.Context, id order.ClientOrderID) (order.Group, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, g := range f.groups {
+		for _, leg := range g.Legs {
+			if leg.ClientOrderID == id {
+				return g, nil
+			}
+		}
+	}
+	return order.Group{}, ports.ErrNotFound
+}
+
+func (f *fakeStore) ListGroups(_ context.Context, _ instrument.VenueID, openOnly bool, _ int32) ([]order.Group, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []order.Group
+	for _, g := range f.groups {
+		if !openOnly || !g.Status.Final() {
+			out = append(out, g)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStore) ListOpenGroups(ctx context.Context) ([]order.Group, error) {
+	return f.ListGroups(ctx, "", true, 0)
+}
+
+func (f *fakeStore) SetGroupStatus(_ context.Context, id order.GroupID, status order.GroupStatus, reason string, at time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	g, ok := f.groups[id]
+	if !ok {
+		return ports.ErrNotFound
+	}
+	g.Status, g.Reason, g.UpdatedAt = status, reason, at
+	f.groups[id] = g
+	return nil
+}
+
+func (f *fakeStore) GetOrder(_ context.Context, id order.ClientOrderID) (order.Record, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	rec, ok := f.orders[id]
+	if !ok {
+		return order.Record{}, ports.ErrNotFound
+	}
+	return rec, nil
+}
+
+func (f *fakeStore) status(id order.GroupID) order.GroupStatus {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.groups[id].Status
+}
+
+// set stores the order's status and fill, as the order service would on a
+// venue event.
+func (f *fakeStore) set(id order.ClientOrderID, status order.Status, filled string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	rec := f.orders[id]
+	rec.Status, rec.FilledQty = status, d(filled)
+	f.orders[id] = rec
+}
+
+// fakePlacer stores what it places, unless refuse says the order service
+// would have turned it down before storing it. Cancels fail with
+// cancelErr when set.
+type fakePlacer struct {
+	store     *fakeStore
+	refuse    map[order.ClientOrderID]bool
+	cancelErr error
+	placed    []order.Request
+	canceled  []order.ClientOrderID
+}
+
+func (p *fakePlacer) Place(_ context.Context, req order.Request) (orderservice.PlaceResult, error) {
+	if p.refuse[req.ClientOrderID] {
+		return orderservice.PlaceResult{}, errors.New("insufficient balance")
+	}
+	p.placed = append(p.placed, req)
+	status := order.StatusOpen
+	if req.Type.Stop() {
+		status = order.StatusUntriggered
+	}
+	p.store.mu.Lock()
+	p.store.orders[req.ClientOrderID] = order.Record{ClientOrderID: req.ClientOrderID, Qty: req.Qty, Status: status}
+	p.store.mu.Unlock()
+	return orderservice.PlaceResult{ClientOrderID: req.ClientOrderID, Status: status}, nil
+}
+
+func (p *fakePlacer) Cancel(_ context.Context, id order.ClientOrderID) (order.Status, error) {
+	if p.cancelErr != nil {
+		return "", p.cancelErr
+	}
+	p.canceled = append(p.canceled, id)
+	rec, _ := p.store.GetOrder(context.Background(), id)
+	p.store.set(id, order.StatusCanceled, rec.FilledQty.String())
+	return order.StatusCanceled, nil
+}
+
+func newService(t *testing.T, store *fakeStore, placer *fakePlacer, b bus.Bus) (*Service, *Metrics) {
+	t.Helper()
+	m, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return New(store, placer, b, clockwork.NewFakeClock(), log.Nop(), time.Minute, m), m
+}
+
+func TestBracketPlacesExitsForTheEntryFillThenCompletes(t *testing.T) {
+	t.Parallel()
+	store := newFakeStore()
+	placer := &fakePlacer{store: store}
+	svc, m := newService(t, store, placer, nil)
+
+	view, err := svc.Place(t.Context(), bracket())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Group.Status != order.GroupPending || len(placer.placed) != 1 || placer.placed[0].ClientOrderID != "E" {
+		t.Fatalf("status %s, placed %+v; want the entry alone", view.Group.Status, placer.placed)
+	}
+
+	store.set("E", order.StatusCanceled, "1.5")
+	if err := svc.onOrder(t.Context(), "E"); err != nil {
+		t.Fatal(err)
+	}
+	if store.status("G") != order.GroupActive || len(placer.placed) != 3 {
+		t.Fatalf("status %s, placed %+v; want both exits", store.status("G"), placer.placed)
+	}
+	for _, req := range placer.placed[1:] {
+		if !req.Qty.Equal(d("1.5")) {
+			t.Fatalf("exit %s for %s, want the entry fill 1.5", req.ClientOrderID, req.Qty)
+		}
+	}
+
+	store.set("TP", order.StatusPartiallyFilled, "0.5")
+	if err := svc.onOrder(t.Context(), "TP"); err != nil {
+		t.Fatal(err)
+	}
+	if store.status("G") != order.GroupCompleted || len(placer.canceled) != 1 || placer.canceled[0] != "SL" {
+		t.Fatalf("status %s, canceled %v; want completed with the stop-loss canceled", store.status("G"), placer.canceled)
+	}
+	if got := testutil.ToFloat64(m.finished.WithLabelValues("bracket", "completed")); got != 1 {
+		t.Fatalf("finished = %v, want 1", got)
+	}
+}
+
+func TestRefusedLegCancelsTheGroup(t *testing.T) {
+	t.Parallel()
+	store := newFakeStore()
+	placer := &fakePlacer{store: store, refuse: map[order.ClientOrderID]bool{"SL": true}}
+	svc, _ := newService(t, store, placer, nil)
+
+	view, err := svc.Place(t.Context(), oco())
+	if err == nil {
+		t.Fatal("Place = nil, want the refusal")
+	}
+	if view.Group.Status != order.GroupCanceled || len(placer.canceled) != 1 || placer.canceled[0] != "TP" {
+		t.Fatalf("status %s, canceled %v; want canceled with the take-profit canceled", view.Group.Status, placer.canceled)
+	}
+}
+
+func TestRetryResumesTheGroupOrRefusesOtherTerms(t *testing.T) {
+	t.Parallel()
+	store := newFakeStore()
+	placer := &fakePlacer{store: store}
+	svc, _ := newService(t, store, placer, nil)
+
+	if _, err := svc.Place(t.Context(), oco()); err != nil {
+		t.Fatal(err)
+	}
+	view, err := svc.Place(t.Context(), oco())
+	if err != nil || view.Group.Status != order.GroupActive || len(view.Orders) != 2 {
+		t.Fatalf("retry = %+v, %v", view, err)
+	}
+	other := oco()
+	other.Legs[0].Price = d("120")
+	if _, err := svc.Place(t.Context(), other); !errors.Is(err, orderservice.ErrIdentityMismatch) {
+		t.Fatalf("err = %v, want ErrIdentityMismatch", err)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	t.Parallel()
+	store := newFakeStore()
+	placer := &fakePlacer{store: store}
+	svc, _ := newService(t, store, placer, nil)
+
+	if _, err := svc.Place(t.Context(), oco()); err != nil {
+		t.Fatal(err)
+	}
+	view, err := svc.Cancel(t.Context(), "G")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Group.Status != order.GroupCanceled || len(placer.canceled) != 2 {
+		t.Fatalf("status %s, canceled %v", view.Group.Status, placer.canceled)
+	}
+	if _, err := svc.Cancel(t.Context(), "G"); !errors.Is(err, ErrGroupFinished) {
+		t.Fatalf("err = %v, want ErrGroupFinished", err)
+	}
+}
+
+func TestFailedCancelLeavesTheGroupOpen(t *testing.T) {
+	t.Parallel()
+	store := newFakeStore()
+	placer := &fakePlacer{store: store}
+	svc, m := newService(t, store, placer, nil)
+
+	if _, err := svc.Place(t.Context(), oco()); err != nil {
+		t.Fatal(err)
+	}
+	placer.cancelErr = errors.New("venue down")
+	store.set("TP", order.StatusFilled, "2")
+	if err := svc.onOrder(t.Context(), "TP"); err != nil {
+		t.Fatal(err)
+	}
+	if store.status("G") != order.GroupActive {
+		t.Fatalf("status %s, want active until the stop-loss is canceled", store.status("G"))
+	}
+	if got := testutil.ToFloat64(m.failures.WithLabelValues("oco")); got != 1 {
+		t.Fatalf("failures = %v, want 1", got)
+	}
+
+	placer.cancelErr = nil
+	if err := svc.sweepOpen(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	if store.status("G") != order.GroupCompleted {
+		t.Fatalf("status %s after the sweep, want completed", store.status("G"))
+	}
+}
+
+func TestRunFollowsLegEvents(t *testing.T) {
+	t.Parallel()
+	store := newFakeStore()
+	placer := &fakePlacer{store: store}
+	b := bus.NewInProc()
+	t.Cleanup(b.Close)
+	svc, _ := newService(t, store, placer, b)
+	if _, err := svc.Place(t.Context(), oco()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() { done <- svc.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	store.set("SL", order.StatusTriggered, "0")
+	payload, err := json.Marshal(events.OrderUpdatedPayload{ClientOrderID: "SL", Status: order.StatusTriggered})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.After(5 * time.Second)
+	for store.status("G") != order.GroupCompleted {
+		// Publish until the coordinator has subscribed and acted.
+		if err := b.Publish(t.Context(), bus.Event{Subject: events.SubjectOrderUpdated, Payload: json.RawMessage(payload)}); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("status %s, want completed after the stop-loss fired", store.status("G"))
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package group
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/romanornr/delta-works/internal/domain/order"
+)
+
+// Metrics holds the coordinator's Prometheus instruments.
+type Metrics struct {
+	finished *prometheus.CounterVec
+	failures *prometheus.CounterVec
+}
+
+// NewMetrics registers the coordinator metrics on the given registry.
+func NewMetrics(reg *prometheus.Registry) (*Metrics, error) {
+	m := &Metrics{
+		finished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "order_groups_finished_total",
+			Help: "Order groups finished by kind and final status (completed, canceled).",
+		}, []string{"kind", "status"}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "order_group_failures_total",
+			Help: "Leg cancels the coordinator failed and left for the next pass, by group kind.",
+		}, []string{"kind"}),
+	}
+	for _, c := range []prometheus.Collector{m.finished, m.failures} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func (m *Metrics) observeFinished(kind order.GroupKind, status order.GroupStatus) {
+	m.finished.With(prometheus.Labels{"kind": string(kind), "status": string(status)}).Inc()
+}
+
+func (m *Metrics) observeFailure(kind order.GroupKind) {
+	m.failures.WithLabelValues(string(kind)).Inc()
+}
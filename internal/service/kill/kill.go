@@ -0,0 +1,187 @@
+// Package kill is the emergency stop: it engages a kill switch on the order
+// service, cancels every active order the switch covers and reports each
+// cancel as it settles.
+package kill
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	domain "github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+	orderservice "github.com/romanornr/delta-works/internal/service/order"
+)
+
+// Orders is the slice of the order service the kill switch drives.
+type Orders interface {
+	Halt(ctx context.Context, venue instrument.VenueID, reason string) (domain.Halt, error)
+	Release(ctx context.Context, venue instrument.VenueID) error
+	Halts() []domain.Halt
+	Venues() []instrument.VenueID
+	Cancel(ctx context.Context, id domain.ClientOrderID) (domain.Status, error)
+}
+
+// ProgressKind names one step of a kill.
+type ProgressKind string
+
+// Progress kinds, in the order a kill reports them.
+const (
+	// ProgressEngaged: the switch is persisted and no placement is in
+	// flight on the covered venues. Active is the number of orders to cancel.
+	ProgressEngaged ProgressKind = "engaged"
+	// ProgressCancelRequested: the venue accepted the cancel request.
+	ProgressCancelRequested ProgressKind = "cancel_requested"
+	// ProgressCancelFailed: the cancel request failed; Err says why. The
+	// order is still watched in case the venue acted on it anyway.
+	ProgressCancelFailed ProgressKind = "cancel_failed"
+	// ProgressSettled: the order reached a terminal status.
+	ProgressSettled ProgressKind = "settled"
+	// ProgressUnsettled: the order was still active when the settle
+	// timeout ran out. Reconciliation keeps converging it.
+	ProgressUnsettled ProgressKind = "unsettled"
+)
+
+// Progress is one kill step. Order fields are empty for ProgressEngaged.
+type Progress struct {
+	Kind          ProgressKind
+	Halt          domain.Halt
+	Active        int
+	ClientOrderID domain.ClientOrderID
+	Venue         instrument.VenueID
+	Status        domain.Status
+	Err           error
+}
+
+// pollInterval is how often unsettled orders are re-read from the store.
+const pollInterval = time.Second
+
+// Service runs kills.
+type Service struct {
+	orders        Orders
+	store         ports.OrderReconcileStore
+	clk           clockwork.Clock
+	log           log.Logger
+	settleTimeout time.Duration
+}
+
+// New builds the service. settleTimeout bounds how long a kill waits for
+// its cancels to reach a terminal status.
+func New(orders Orders, store ports.OrderReconcileStore, clk clockwork.Clock, logger log.Logger, settleTimeout time.Duration) *Service {
+	return &Service{
+		orders:        orders,
+		store:         store,
+		clk:           clk,
+		log:           log.Component(logger, "kill"),
+		settleTimeout: settleTimeout,
+	}
+}
+
+// Kill engages the switch for venue (every trading venue when empty), then
+// cancels each covered active order and reports progress until all of them
+// settle or the settle timeout runs out. The switch stays engaged either
+// way; only Release lifts it.
+//
+// Once the switch is engaged the cancels run to completion even if ctx is
+// canceled: a caller hanging up mid-kill must not leave orders working.
+// An error from report stops the reporting, not the cancels.
+func (s *Service) Kill(ctx context.Context, venue instrument.VenueID, reason string, report func(Progress) error) error {
+	h, err := s.orders.Halt(ctx, venue, reason)
+	if err != nil {
+		return err
+	}
+	ctx = context.WithoutCancel(ctx)
+
+	venues := []instrument.VenueID{venue}
+	if venue == "" {
+		venues = s.orders.Venues()
+	}
+	var active []domain.Record
+	for _, v := range venues {
+		records, err := s.store.ListActiveOrders(ctx, v)
+		if err != nil {
+			return err
+		}
+		active = append(active, records...)
+	}
+	s.log.Warn().Str("venue", string(venue)).Int("active", len(active)).Msg("kill switch engaged; canceling active orders")
+
+	r := reporter{fn: report}
+	r.send(Progress{Kind: ProgressEngaged, Halt: h, Active: len(active)})
+
+	for _, rec := range active {
+		p := Progress{ClientOrderID: rec.ClientOrderID, Venue: rec.Instrument.Venue, Status: rec.Status}
+		_, err := s.orders.Cancel(ctx, rec.ClientOrderID)
+		switch {
+		case errors.Is(err, orderservice.ErrTerminal):
+			// Settled between the list and the cancel; report its final status below.
+		case err != nil:
+			s.log.Error().Str("client_order_id", string(rec.ClientOrderID)).Err(err).Msg("kill cancel failed")
+			p.Kind, p.Err = ProgressCancelFailed, err
+			r.send(p)
+		default:
+			p.Kind = ProgressCancelRequested
+			r.send(p)
+		}
+	}
+	return s.settle(ctx, active, &r)
+}
+
+// settle polls the store until every pending order is terminal or the
+// settle timeout runs out, reporting each order once, in list order.
+func (s *Service) settle(ctx context.Context, pending []domain.Record, r *reporter) error {
+	deadline := s.clk.Now().Add(s.settleTimeout)
+	for {
+		still := pending[:0]
+		for _, rec := range pending {
+			stored, err := s.store.GetOrder(ctx, rec.ClientOrderID)
+			if err != nil {
+				return err
+			}
+			if !stored.Status.Terminal() {
+				still = append(still, stored)
+				continue
+			}
+			r.send(Progress{Kind: ProgressSettled, ClientOrderID: stored.ClientOrderID, Venue: stored.Instrument.Venue, Status: stored.Status})
+		}
+		pending = still
+		if len(pending) == 0 {
+			return nil
+		}
+		if !s.clk.Now().Before(deadline) {
+			for _, rec := range pending {
+				s.log.Warn().Str("client_order_id", string(rec.ClientOrderID)).Str("status", string(rec.Status)).
+					Msg("kill cancel unsettled at timeout")
+				r.send(Progress{Kind: ProgressUnsettled, ClientOrderID: rec.ClientOrderID, Venue: rec.Instrument.Venue, Status: rec.Status})
+			}
+			return nil
+		}
+		<-s.clk.After(pollInterval)
+	}
+}
+
+// Release disengages one kill switch; see order.Service.Release.
+func (s *Service) Release(ctx context.Context, venue instrument.VenueID) error {
+	return s.orders.Release(ctx, venue)
+}
+
+// Halts returns the engaged kill switches.
+func (s *Service) Halts() []domain.Halt { return s.orders.Halts() }
+
+// reporter forwards progress until the first report error, which usually
+// means the caller went away.
+type reporter struct {
+	fn  func(Progress) error
+	err error
+}
+
+func (r *reporter) send(p Progress) {
+	if r.err != nil {
+		return
+	}
+	r.err = r.fn(p)
+}
@@ -0,0 +1,161 @@
+package kill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	domain "github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+	orderservice "github.com/romanornr/delta-works/internal/service/order"
+)
+
+type fakeOrders struct {
+	mu        sync.Mutex
+	halted    []instrument.VenueID
+	haltErr   error
+	cancels   []domain.ClientOrderID
+	cancelErr map[domain.ClientOrderID]error
+}
+
+func (f *fakeOrders) Halt(_ context.Context, venue instrument.VenueID, reason string) (domain.Halt, error) {
+	if f.haltErr != nil {
+		return domain.Halt{}, f.haltErr
+	}
+	f.halted = append(f.halted, venue)
+	return domain.Halt{Venue: venue, Reason: reason}, nil
+}
+
+func (f *fakeOrders) Release(context.Context, instrument.VenueID) error { return nil }
+func (f *fakeOrders) Halts() []domain.Halt                              { return nil }
+
+func (f *fakeOrders) Venues() []instrument.VenueID {
+	return []instrument.VenueID{"binance", "bybit"}
+}
+
+func (f *fakeOrders) Cancel(ctx context.Context, id domain.ClientOrderID) (domain.Status, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cancels = append(f.cancels, id)
+	return domain.StatusOpen, f.cancelErr[id]
+}
+
+type fakeStore struct {
+	mu     sync.Mutex
+	active map[instrument.VenueID][]domain.Record
+	status map[domain.ClientOrderID]domain.Status
+}
+
+func (f *fakeStore) ListActiveOrders(_ context.Context, venue instrument.VenueID) ([]domain.Record, error) {
+	return f.active[venue], nil
+}
+
+func (f *fakeStore) GetOrder(_ context.Context, id domain.ClientOrderID) (domain.Record, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	status, ok := f.status[id]
+	if !ok {
+		return domain.Record{}, ports.ErrNotFound
+	}
+	return domain.Record{ClientOrderID: id, Status: status}, nil
+}
+
+func (f *fakeStore) set(id domain.ClientOrderID, status domain.Status) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status[id] = status
+}
+
+func record(venue instrument.VenueID, id domain.ClientOrderID) domain.Record {
+	return domain.Record{
+		ClientOrderID: id,
+		Instrument:    instrument.Instrument{Venue: venue, Type: instrument.TypeSpot, Base: "BTC", Quote: "USDT"},
+		Status:        domain.StatusOpen,
+	}
+}
+
+func TestKill(t *testing.T) {
+	t.Parallel()
+	orders := &fakeOrders{cancelErr: map[domain.ClientOrderID]error{
+		"b": orderservice.ErrTerminal,
+		"c": errors.New("venue timeout"),
+	}}
+	store := &fakeStore{
+		active: map[instrument.VenueID][]domain.Record{
+			"bybit":   {record("bybit", "a"), record("bybit", "b")},
+			"binance": {record("binance", "c")},
+		},
+		status: map[domain.ClientOrderID]domain.Status{"a": domain.StatusOpen, "b": domain.StatusFilled, "c": domain.StatusOpen},
+	}
+	clk := clockwork.NewFakeClock()
+	svc := New(orders, store, clk, log.Nop(), 5*time.Second)
+
+	// The caller hangs up right after the switch engages; the kill goes on.
+	ctx, cancel := context.WithCancel(t.Context())
+	var got []string
+	report := func(p Progress) error {
+		got = append(got, fmt.Sprintf("%s %s %s", p.Kind, p.ClientOrderID, p.Status))
+		cancel()
+		return nil
+	}
+	done := make(chan error, 1)
+	go func() { done <- svc.Kill(ctx, "", "drill", report) }()
+
+	waitCtx, stop := context.WithTimeout(t.Context(), 5*time.Second)
+	defer stop()
+	if err := clk.BlockUntilContext(waitCtx, 1); err != nil {
+		t.Fatal(err)
+	}
+	store.set("a", domain.StatusCanceled)
+	clk.Advance(pollInterval)
+	for range 4 {
+		if err := clk.BlockUntilContext(waitCtx, 1); err != nil {
+			t.Fatal(err)
+		}
+		clk.Advance(pollInterval)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Kill: %v", err)
+	}
+
+	want := []string{
+		"engaged  ",
+		"cancel_failed c open",
+		"cancel_requested a open",
+		"settled b filled",
+		"settled a canceled",
+		"unsettled c open",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("progress =\n%q\nwant\n%q", got, want)
+	}
+	if len(orders.halted) != 1 || orders.halted[0] != "" {
+		t.Fatalf("halted = %v, want one global halt", orders.halted)
+	}
+	if len(orders.cancels) != 3 {
+		t.Fatalf("cancels = %v, want all three after the caller hung up", orders.cancels)
+	}
+}
+
+func TestKillHaltFailure(t *testing.T) {
+	t.Parallel()
+	orders := &fakeOrders{haltErr: orderservice.ErrVenueNotConfigured}
+	svc := New(orders, &fakeStore{}, clockwork.NewFakeClock(), log.Nop(), time.Second)
+	err := svc.Kill(t.Context(), "kraken", "", func(Progress) error {
+		t.Fatal("progress reported for a switch that never engaged")
+		return nil
+	})
+	if !errors.Is(err, orderservice.ErrVenueNotConfigured) || len(orders.cancels) != 0 {
+		t.Fatalf("Kill = %v, cancels = %v", err, orders.cancels)
+	}
+}
@@ -0,0 +1,195 @@
+// Package mark values open inventory against live tickers on an interval:
+// every position's unrealized profit goes to the series writer and the
+// latest figures stay in memory for the control plane. It reads the ledger
+// and never writes it.
+package mark
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/ledger"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+	"github.com/romanornr/delta-works/internal/exchange"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// Service marks open lots to market.
+type Service struct {
+	lots     ports.OpenLotStore
+	registry exchange.Registry
+	series   ports.ValuationSeriesWriter
+	clk      clockwork.Clock
+	log      log.Logger
+	interval time.Duration
+	source   marketdata.PriceSource
+	metrics  *Metrics
+
+	mu      sync.RWMutex
+	current []ledger.Valuation
+}
+
+// New builds the service. Metrics must not be nil.
+func New(
+	lots ports.OpenLotStore,
+	registry exchange.Registry,
+	series ports.ValuationSeriesWriter,
+	clk clockwork.Clock,
+	logger log.Logger,
+	interval time.Duration,
+	source marketdata.PriceSource,
+	metrics *Metrics,
+) *Service {
+	return &Service{
+		lots:     lots,
+		registry: registry,
+		series:   series,
+		clk:      clk,
+		log:      log.Component(logger, "mark"),
+		interval: interval,
+		source:   source,
+		metrics:  metrics,
+	}
+}
+
+// Run blocks until ctx is canceled. Nothing here is accounting truth, so no
+// failure stops the service: store, venue and series errors are logged,
+// counted and retried on the next tick.
+func (s *Service) Run(ctx context.Context) error {
+	ticker := s.clk.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.mark(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.Chan():
+			s.mark(ctx)
+		}
+	}
+}
+
+// Current returns the latest valuation of every open position, ordered by
+// bot, venue, base and quote. A position whose ticker failed keeps its
+// last mark; its At says how old that mark is. Positions never marked are
+// absent.
+func (s *Service) Current() []ledger.Valuation {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]ledger.Valuation(nil), s.current...)
+}
+
+type positionKey struct {
+	bot  string
+	inst string
+}
+
+func (s *Service) mark(ctx context.Context) {
+	// The pass gets its own deadline so a stalled venue cannot eat the next
+	// tick or hold up shutdown.
+	ctx, cancel := context.WithTimeout(ctx, s.interval/2)
+	defer cancel()
+
+	lots, err := s.lots.ListOpenLots(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.metrics.observeError("", errStore)
+			s.log.Error().Err(err).Msg("list open lots failed")
+		}
+		return
+	}
+
+	previous := map[positionKey]ledger.Valuation{}
+	for _, v := range s.Current() {
+		previous[positionKey{bot: v.BotID, inst: v.Instrument().Key()}] = v
+	}
+	// Bots holding the same pair share one ticker fetch per pass.
+	quotes := map[string]quote{}
+	var next, fresh []ledger.Valuation
+	for _, p := range ledger.Positions(lots) {
+		inst := p.Instrument()
+		key := positionKey{bot: p.BotID, inst: inst.Key()}
+		q, seen := quotes[inst.Key()]
+		if !seen {
+			q = s.quote(ctx, inst)
+			quotes[inst.Key()] = q
+		}
+		if q.ok {
+			v := p.MarkAt(q.price, q.at)
+			next, fresh = append(next, v), append(fresh, v)
+		} else if old, marked := previous[key]; marked {
+			// Today's quantity at the last known price, dated by that price.
+			next = append(next, p.MarkAt(old.Mark, old.At))
+		}
+	}
+
+	s.mu.Lock()
+	s.current = next
+	s.mu.Unlock()
+
+	if len(fresh) == 0 {
+		return
+	}
+	if err := s.writeSeries(ctx, fresh); err != nil {
+		s.metrics.observeError("", errSeries)
+		s.log.Error().Err(err).Msg("valuation series write failed")
+		return
+	}
+	s.metrics.observeSuccess(s.clk.Now())
+}
+
+// quote is one instrument's mark for a pass; ok is false when it has none.
+type quote struct {
+	price decimal.Decimal
+	at    time.Time
+	ok    bool
+}
+
+// quote fetches one instrument's mark price. A missing venue, a venue error
+// or a ticker without a usable price is reported and leaves the instrument
+// unmarked for this pass.
+func (s *Service) quote(ctx context.Context, inst instrument.Instrument) quote {
+	ex, err := s.registry.Get(inst.Venue)
+	if err != nil {
+		s.metrics.observeError(inst.Venue, errTicker)
+		s.log.Error().Str("venue", string(inst.Venue)).Err(err).Msg("open lots on an unconfigured venue")
+		return quote{}
+	}
+	t, err := ex.Ticker(ctx, inst)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.metrics.observeError(inst.Venue, errTicker)
+			s.log.Warn().Str("venue", string(inst.Venue)).Str("pair", inst.Pair()).Err(err).
+				Msg("ticker failed; positions keep their last mark")
+		}
+		return quote{}
+	}
+	price, ok := t.Price(s.source)
+	if !ok {
+		s.metrics.observeError(inst.Venue, errNoPrice)
+		s.log.Warn().Str("venue", string(inst.Venue)).Str("pair", inst.Pair()).
+			Msg("ticker has no price; positions keep their last mark")
+		return quote{}
+	}
+	at := t.At
+	if at.IsZero() {
+		at = s.clk.Now()
+	}
+	return quote{price: price, at: at, ok: true}
+}
+
+func (s *Service) writeSeries(ctx context.Context, valuations []ledger.Valuation) error {
+	for _, v := range valuations {
+		if err := s.series.WriteValuation(ctx, v); err != nil {
+			return err
+		}
+	}
+	return s.series.Flush(ctx)
+}
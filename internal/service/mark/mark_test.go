@@ -0,0 +1,156 @@
+package mark
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/ledger"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+	"github.com/romanornr/delta-works/internal/exchange"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+type fakeExchange struct {
+	mu    sync.Mutex
+	calls int
+	bid   decimal.Decimal
+	err   error
+}
+
+func (f *fakeExchange) ID() instrument.VenueID { return "bybit" }
+
+func (f *fakeExchange) Ticker(_ context.Context, inst instrument.Instrument) (marketdata.Ticker, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return marketdata.Ticker{}, f.err
+	}
+	return marketdata.Ticker{Instrument: inst, Bid: f.bid, Ask: f.bid.Add(decimal.NewFromInt(2)), Last: decimal.NewFromInt(1)}, nil
+}
+
+func (f *fakeExchange) Instruments(context.Context, instrument.Type) ([]instrument.Instrument, error) {
+	return nil, nil
+}
+
+func (f *fakeExchange) Balances(context.Context, account.Type) ([]account.Balance, error) {
+	return nil, nil
+}
+
+func (f *fakeExchange) set(bid int64, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.bid, f.err = decimal.NewFromInt(bid), err
+}
+
+type fakeLots struct {
+	lots   []ledger.Lot
+	passes chan struct{}
+}
+
+func (f *fakeLots) ListOpenLots(context.Context) ([]ledger.Lot, error) {
+	f.passes <- struct{}{}
+	return f.lots, nil
+}
+
+type fakeSeries struct {
+	mu      sync.Mutex
+	written []ledger.Valuation
+	flushes chan struct{}
+}
+
+func (f *fakeSeries) WriteValuation(_ context.Context, v ledger.Valuation) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.written = append(f.written, v)
+	return nil
+}
+
+func (f *fakeSeries) Flush(context.Context) error {
+	f.flushes <- struct{}{}
+	return nil
+}
+
+func openLot(bot, qty, cost string) ledger.Lot {
+	q, c := decimal.RequireFromString(qty), decimal.RequireFromString(cost)
+	return ledger.Lot{
+		BotID: bot, Venue: "bybit", Base: "BTC", Quote: "USDT",
+		Qty: q, RemainingQty: q, CostPrice: c.Div(q), Cost: c, RemainingCost: c,
+	}
+}
+
+func wait(t *testing.T, ch chan struct{}, what string) {
+	t.Helper()
+	select {
+	case <-ch:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for %s", what)
+	}
+}
+
+func TestMarkToMarket(t *testing.T) {
+	start := time.Date(2026, 7, 12, 12, 0, 0, 0, time.UTC)
+	clk := clockwork.NewFakeClockAt(start)
+	venue := &fakeExchange{}
+	venue.set(99, nil)
+	lots := &fakeLots{
+		lots:   []ledger.Lot{openLot("grid", "1", "100"), openLot("manual", "0.5", "40")},
+		passes: make(chan struct{}, 1),
+	}
+	series := &fakeSeries{flushes: make(chan struct{}, 1)}
+	m, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := New(lots, exchange.NewRegistry([]ports.Exchange{venue}), series, clk, log.Nop(), time.Minute, marketdata.PriceMid, m)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- svc.Run(ctx) }()
+
+	wait(t, lots.passes, "first pass")
+	wait(t, series.flushes, "first flush")
+	got := svc.Current()
+	if len(got) != 2 || got[0].BotID != "grid" || !got[0].Mark.Equal(decimal.NewFromInt(100)) ||
+		!got[0].Unrealized.IsZero() || !got[1].Unrealized.Equal(decimal.NewFromInt(10)) || !got[1].At.Equal(start) {
+		t.Fatalf("valuations = %+v", got)
+	}
+	if venue.calls != 1 || len(series.written) != 2 {
+		t.Fatalf("ticker calls = %d, rows = %d; want one fetch for the shared pair and two rows", venue.calls, len(series.written))
+	}
+
+	// A failed ticker keeps the last mark and writes nothing new.
+	venue.set(0, errors.New("venue down"))
+	lots.lots = []ledger.Lot{openLot("grid", "2", "200")}
+	clk.Advance(time.Minute)
+	wait(t, lots.passes, "second pass")
+	deadline := time.Now().Add(5 * time.Second)
+	for got = svc.Current(); len(got) != 1 && time.Now().Before(deadline); got = svc.Current() {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(got) != 1 || !got[0].Qty.Equal(decimal.NewFromInt(2)) || !got[0].Value.Equal(decimal.NewFromInt(200)) || !got[0].At.Equal(start) {
+		t.Fatalf("stale valuations = %+v", got)
+	}
+	if n := testutil.ToFloat64(m.errors.WithLabelValues("bybit", errTicker)); n != 1 {
+		t.Fatalf("ticker errors = %v, want 1", n)
+	}
+	if len(series.written) != 2 {
+		t.Fatalf("rows = %d, stale marks must not be written again", len(series.written))
+	}
+}
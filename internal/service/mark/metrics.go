@@ -0,0 +1,52 @@
+package mark
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+)
+
+// Error kinds for mark_errors_total.
+const (
+	errStore   = "store"
+	errTicker  = "ticker"
+	errNoPrice = "no_price"
+	errSeries  = "series"
+)
+
+// Metrics holds the service's Prometheus instruments. The last-success
+// timestamp gauge exists so stale valuations can be alerted on directly.
+type Metrics struct {
+	errors      *prometheus.CounterVec
+	lastSuccess prometheus.Gauge
+}
+
+// NewMetrics registers the service metrics on the given registry.
+func NewMetrics(reg *prometheus.Registry) (*Metrics, error) {
+	m := &Metrics{
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mark_errors_total",
+			Help: "Mark-to-market failures by venue and kind (store, ticker, no_price, series).",
+		}, []string{"venue", "kind"}),
+		lastSuccess: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "mark_last_success_timestamp_seconds",
+			Help: "Unix time valuations last reached the series store.",
+		}),
+	}
+	for _, c := range []prometheus.Collector{m.errors, m.lastSuccess} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func (m *Metrics) observeError(venue instrument.VenueID, kind string) {
+	m.errors.With(prometheus.Labels{"venue": string(venue), "kind": kind}).Inc()
+}
+
+func (m *Metrics) observeSuccess(at time.Time) {
+	m.lastSuccess.Set(float64(at.Unix()))
+}
@@ -4,12 +4,16 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/romanornr/delta-works/internal/domain/instrument"
+	domain "github.com/romanornr/delta-works/internal/domain/order"
 )
 
 // Metrics holds the service's Prometheus instruments.
 type Metrics struct {
-	dropped   *prometheus.CounterVec
-	unmatched *prometheus.CounterVec
+	dropped        *prometheus.CounterVec
+	unmatched      *prometheus.CounterVec
+	ruleViolations *prometheus.CounterVec
+	rounded        *prometheus.CounterVec
+	replacements   *prometheus.CounterVec
 }
 
 // NewMetrics registers the service metrics on the given registry.
@@ -23,8 +27,20 @@ func NewMetrics(reg *prometheus.Registry) (*Metrics, error) {
 			Name: "ledger_unmatched_sells_total",
 			Help: "Sell fills with quantity unmatched to open lots.",
 		}, []string{"venue"}),
+		ruleViolations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "order_rule_violations_total",
+			Help: "New orders refused before submission for breaking their instrument's tick, step, minimum quantity or minimum notional rule.",
+		}, []string{"venue", "rule"}),
+		rounded: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "order_rule_rounded_total",
+			Help: "New orders whose price or quantity was rounded to the instrument's increments.",
+		}, []string{"venue"}),
+		replacements: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "order_replacements_total",
+			Help: "Orders given new terms, by mode: amended at the venue or canceled and replaced.",
+		}, []string{"venue", "mode"}),
 	}
-	for _, collector := range []prometheus.Collector{m.dropped, m.unmatched} {
+	for _, collector := range []prometheus.Collector{m.dropped, m.unmatched, m.ruleViolations, m.rounded, m.replacements} {
 		if err := reg.Register(collector); err != nil {
 			return nil, err
 		}
@@ -39,3 +55,15 @@ func (m *Metrics) observeDropped(venue instrument.VenueID, reason string) {
 func (m *Metrics) observeUnmatched(venue instrument.VenueID) {
 	m.unmatched.With(prometheus.Labels{"venue": string(venue)}).Inc()
 }
+
+func (m *Metrics) observeRuleViolation(venue instrument.VenueID, rule domain.Rule) {
+	m.ruleViolations.With(prometheus.Labels{"venue": string(venue), "rule": string(rule)}).Inc()
+}
+
+func (m *Metrics) observeRounded(venue instrument.VenueID) {
+	m.rounded.With(prometheus.Labels{"venue": string(venue)}).Inc()
+}
+
+func (m *Metrics) observeReplace(venue instrument.VenueID, mode domain.ReplaceMode) {
+	m.replacements.With(prometheus.Labels{"venue": string(venue), "mode": string(mode)}).Inc()
+}
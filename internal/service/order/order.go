@@ -8,12 +8,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v5"
 	"github.com/jonboulle/clockwork"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/romanornr/delta-works/internal/domain/funds"
 	"github.com/romanornr/delta-works/internal/domain/instrument"
 	domain "github.com/romanornr/delta-works/internal/domain/order"
 	"github.com/romanornr/delta-works/internal/id"
@@ -42,6 +46,8 @@ var (
 	ErrTerminal = errors.New("order is terminal")
 	// ErrVenueNotConfigured reports a request for a venue without trading enabled.
 	ErrVenueNotConfigured = errors.New("venue not configured for trading")
+	// ErrHalted reports a placement refused by an engaged kill switch.
+	ErrHalted = errors.New("trading halted by kill switch")
 )
 
 // PlaceResult is the locally persisted state after a placement attempt.
@@ -50,12 +56,36 @@ type PlaceResult struct {
 	Status        domain.Status
 }
 
-// Venue is one tradable venue: the resilience-wrapped order surface and
-// its private event stream.
+// Venue is one tradable venue: the resilience-wrapped order surface, its
+// private event stream, and the instruments whose tickers the daemon
+// polls, the only ones a stop held locally can trigger on.
 type Venue struct {
 	ID       instrument.VenueID
 	Placer   ports.OrderPlacer
 	Streamer ports.PrivateStreamer
+	Tickers  []instrument.Instrument
+}
+
+// PreTradeCheck vets a new order before it is persisted. Check runs first,
+// outside the admit lock, and may call the venue; CheckAdmit runs under
+// the lock right before the insert and must only count stored orders,
+// since it holds up every placement on the venue and for the bot. A
+// non-nil error refuses the order and is returned to the caller unchanged.
+type PreTradeCheck interface {
+	Check(ctx context.Context, req domain.Request) error
+	CheckAdmit(ctx context.Context, req domain.Request) error
+}
+
+// Reserver stores a new order together with the funds it will spend.
+// Reserve prices them and may call the venue, so it runs outside the admit
+// lock; CreatePending stores the order with that reservation, refusing it
+// with an error when the balances cannot cover it. CreatePending replaces
+// the command store's, with the same idempotency. CheckAmend refuses an
+// amend of req's order that would hold more than the balances cover.
+type Reserver interface {
+	Reserve(ctx context.Context, req domain.Request) (funds.Reservation, error)
+	CreatePending(ctx context.Context, req domain.Request, r funds.Reservation) (bool, error)
+	CheckAmend(ctx context.Context, req domain.Request, amend domain.Replacement) error
 }
 
 // Service is the only path through which orders are placed or canceled
@@ -64,36 +94,71 @@ type Service struct {
 	venues       map[instrument.VenueID]Venue
 	commands     ports.OrderCommandStore
 	events       ports.OrderEventStore
+	killSwitches ports.KillSwitchStore
+	rules        Conformer
+	preTrade     PreTradeCheck
+	funds        Reserver
 	clk          clockwork.Clock
 	log          log.Logger
 	submitBudget time.Duration
-	metrics      *Metrics
+	// replaceSettle bounds a cancel-replace's wait for the old order.
+	replaceSettle time.Duration
+	metrics       *Metrics
+
+	// haltMu is held shared while a placement is checked and stored, and
+	// exclusively while a kill switch flips, so no order is admitted past
+	// a halt it raced. Submits run outside it and check the halt again
+	// before every attempt, so engaging never waits on a venue.
+	haltMu sync.RWMutex
+	halts  map[instrument.VenueID]domain.Halt
+
+	// stopMu serializes firing and canceling the stops held locally, so a
+	// stop is never canceled while its child is being placed.
+	stopMu sync.Mutex
+
+	// venueAdmits and botAdmits serialize the count-based pre-trade
+	// verdict with the insert it admits, so two placements on one venue or
+	// for one bot cannot both pass a limit before either is stored. Nothing
+	// that calls a venue runs under them.
+	venueAdmits, botAdmits keyedMutex
 }
 
-// New builds the service. Metrics must not be nil. submitBudget caps the
-// total time spent retrying one venue submit with the same ULID.
-func New(venues []Venue, commands ports.OrderCommandStore, events ports.OrderEventStore, clk clockwork.Clock, logger log.Logger, submitBudget time.Duration, metrics *Metrics) *Service {
+// New builds the service. Metrics must not be nil; rules and preTrade may
+// be nil to place orders unchecked, and funds nil to place them without
+// reserving what they spend. submitBudget caps the total time spent
+// retrying one venue submit with the same ULID; replaceSettle caps how
+// long a cancel-replace waits for the old order to settle. Call LoadHalts
+// before serving.
+func New(venues []Venue, commands ports.OrderCommandStore, events ports.OrderEventStore, killSwitches ports.KillSwitchStore, rules Conformer, preTrade PreTradeCheck, funds Reserver, clk clockwork.Clock, logger log.Logger, submitBudget, replaceSettle time.Duration, metrics *Metrics) *Service {
 	byID := make(map[instrument.VenueID]Venue, len(venues))
 	for _, v := range venues {
 		byID[v.ID] = v
 	}
 	return &Service{
-		venues:       byID,
-		commands:     commands,
-		events:       events,
-		clk:          clk,
-		log:          log.Component(logger, "order"),
-		submitBudget: submitBudget,
-		metrics:      metrics,
+		venues:        byID,
+		commands:      commands,
+		events:        events,
+		killSwitches:  killSwitches,
+		rules:         rules,
+		preTrade:      preTrade,
+		funds:         funds,
+		clk:           clk,
+		log:           log.Component(logger, "order"),
+		submitBudget:  submitBudget,
+		replaceSettle: replaceSettle,
+		metrics:       metrics,
+		halts:         map[instrument.VenueID]domain.Halt{},
 	}
 }
 
 // Place persists the order as pending, submits it to the venue retrying
 // ambiguous failures with the SAME client order ID, and applies the ack.
 // The returned ID is valid even when the error is ErrSubmitUnsettled: the
-// order exists locally and reconciliation settles its fate.
+// order exists locally and reconciliation settles its fate. While a kill
+// switch covers the venue every placement fails with ErrHalted, and a
+// halt engaged mid-submit stops its retries.
 func (s *Service) Place(ctx context.Context, req domain.Request) (PlaceResult, error) {
-	req, venue, existing, err := s.preparePlace(ctx, req)
+	req, venue, existing, err := s.prepareUnhalted(ctx, req)
 	if err != nil {
 		return PlaceResult{}, err
 	}
@@ -101,13 +166,22 @@ func (s *Service) Place(ctx context.Context, req domain.Request) (PlaceResult, e
 		return *existing, nil
 	}
 
+	sent := false
 	ack, err := backoff.Retry(ctx, func() (domain.Ack, error) {
+		if h, halted := s.halted(req.Instrument.Venue); halted {
+			return domain.Ack{}, backoff.Permanent(fmt.Errorf("%w: %s", ErrHalted, haltScope(h)))
+		}
+		sent = true
 		a, err := venue.Placer.PlaceOrder(ctx, req)
-		if errors.Is(err, ports.ErrAuth) || errors.Is(err, ports.ErrTradingUnsupported) {
+		if permanentSubmitError(err) {
 			return domain.Ack{}, backoff.Permanent(err)
 		}
 		return a, err
 	}, backoff.WithMaxElapsedTime(s.submitBudget))
+	if err != nil && !sent {
+		// Halted before the first attempt: the venue never saw the order.
+		return s.reject(ctx, req, err)
+	}
 	if err != nil {
 		return s.submitFailure(ctx, req, err)
 	}
@@ -141,23 +215,45 @@ func (s *Service) acceptedUnsettled(req domain.Request, err error) (PlaceResult,
 		fmt.Errorf("%w: %w", ErrSubmitUnsettled, err)
 }
 
+// prepareUnhalted refuses the placement with ErrHalted, or prepares it,
+// under the shared halt lock.
+func (s *Service) prepareUnhalted(ctx context.Context, req domain.Request) (domain.Request, Venue, *PlaceResult, error) {
+	s.haltMu.RLock()
+	defer s.haltMu.RUnlock()
+	if h, halted := s.haltedLocked(req.Instrument.Venue); halted {
+		return req, Venue{}, nil, fmt.Errorf("%w: %s", ErrHalted, haltScope(h))
+	}
+	return s.preparePlace(ctx, req)
+}
+
 func (s *Service) preparePlace(ctx context.Context, req domain.Request) (domain.Request, Venue, *PlaceResult, error) {
-	if req.ClientOrderID == "" {
+	supplied := req.ClientOrderID != ""
+	if !supplied {
 		req.ClientOrderID = domain.ClientOrderID(id.New())
 	}
 	if req.BotID == "" {
 		req.BotID = "manual"
 	}
+	if req.TimeInForce == "" {
+		req.TimeInForce = domain.GTC
+	}
 	// A retry for an order that already advanced needs no venue, so the
 	// venue is only required when inserting or resubmitting. That keeps
 	// supplied-ID retries idempotent even after a venue is deconfigured.
 	venue, configured := s.venues[req.Instrument.Venue]
 	if configured {
-		inserted, err := s.commands.CreatePending(ctx, req)
-		if err != nil {
+		vetted, inserted, err := s.admit(ctx, req, supplied)
+		req = vetted
+		local := s.holdsLocally(req)
+		switch {
+		case err != nil:
 			return req, Venue{}, nil, err
-		}
-		if inserted {
+		case inserted && local:
+			s.log.Info().Str("venue", string(req.Instrument.Venue)).Str("client_order_id", string(req.ClientOrderID)).
+				Str("pair", req.Instrument.Pair()).Str("trigger_price", req.TriggerPrice.String()).
+				Msg("stop held for the trigger engine")
+			return req, Venue{}, &PlaceResult{ClientOrderID: req.ClientOrderID, Status: domain.StatusUntriggered}, nil
+		case inserted:
 			return req, venue, nil, nil
 		}
 	}
@@ -181,23 +277,133 @@ func (s *Service) preparePlace(ctx context.Context, req domain.Request) (domain.
 	return req, Venue{}, &result, nil
 }
 
+// admit vets a new order and stores it: held locally when it is a stop the
+// trigger engine fires, pending otherwise. The rules, the pre-trade checks
+// that read the market and the price its funds are reserved at are settled
+// first, since they may wait on the venue; only the checks that count
+// stored orders and the insert run under the admit locks of the order's
+// venue and bot, taken in that order.
+func (s *Service) admit(ctx context.Context, req domain.Request, supplied bool) (domain.Request, bool, error) {
+	vetted, err := s.vet(ctx, req, supplied)
+	if err != nil {
+		return req, false, err
+	}
+	local := s.holdsLocally(vetted)
+	var reservation funds.Reservation
+	if s.funds != nil && !local {
+		if reservation, err = s.funds.Reserve(ctx, vetted); err != nil {
+			return vetted, false, s.passStored(ctx, vetted, supplied, err)
+		}
+	}
+
+	unlockVenue := s.venueAdmits.lock(string(vetted.Instrument.Venue))
+	defer unlockVenue()
+	unlockBot := s.botAdmits.lock(vetted.BotID)
+	defer unlockBot()
+	if s.preTrade != nil {
+		if err := s.preTrade.CheckAdmit(ctx, vetted); err != nil {
+			return vetted, false, s.passStored(ctx, vetted, supplied, err)
+		}
+	}
+	if local {
+		inserted, err := s.commands.CreateUntriggered(ctx, vetted, domain.ClientOrderID(id.New()))
+		return vetted, inserted, err
+	}
+	inserted, err := s.createPending(ctx, vetted, reservation, supplied)
+	return vetted, inserted, err
+}
+
+// createPending stores the order with its funds reserved when a Reserver
+// is set. Like vet, a retry under a supplied ID that is already stored
+// passes a refusal: its funds were reserved when it was first placed.
+func (s *Service) createPending(ctx context.Context, req domain.Request, r funds.Reservation, supplied bool) (bool, error) {
+	if s.funds == nil {
+		return s.commands.CreatePending(ctx, req)
+	}
+	inserted, err := s.funds.CreatePending(ctx, req, r)
+	if err != nil {
+		return false, s.passStored(ctx, req, supplied, err)
+	}
+	return inserted, nil
+}
+
+// passStored returns err, or nil when it refused a retry under a supplied
+// ID that is already stored: that order passed when it was first placed,
+// and refusing it now would break idempotency, since rules may have
+// changed and the order counts against today's limits. The caller reads
+// the stored order instead.
+func (s *Service) passStored(ctx context.Context, req domain.Request, supplied bool, err error) error {
+	if !supplied {
+		return err
+	}
+	_, getErr := s.commands.GetOrder(ctx, req.ClientOrderID)
+	switch {
+	case getErr == nil:
+		return nil
+	case errors.Is(getErr, ports.ErrNotFound):
+		return err
+	default:
+		return getErr
+	}
+}
+
+// vet checks the terms of an order not yet stored, fits it to its
+// instrument rules and runs the pre-trade checks that do not count stored
+// orders over the result. A retry under a supplied ID that is already
+// stored skips the verdict, as passStored explains.
+func (s *Service) vet(ctx context.Context, req domain.Request, supplied bool) (domain.Request, error) {
+	conformed, err := req, s.checkTerms(req)
+	if err == nil {
+		conformed, err = s.conform(ctx, req)
+	}
+	if err == nil {
+		req = conformed
+		if s.preTrade != nil {
+			err = s.preTrade.Check(ctx, req)
+		}
+	}
+	if err != nil {
+		return req, s.passStored(ctx, req, supplied, err)
+	}
+	return req, nil
+}
+
+// conform applies the instrument rules, returning req unchanged on error.
+func (s *Service) conform(ctx context.Context, req domain.Request) (domain.Request, error) {
+	if s.rules == nil {
+		return req, nil
+	}
+	conformed, err := s.rules.Conform(ctx, req)
+	var violation *domain.RuleViolation
+	switch {
+	case errors.As(err, &violation):
+		s.metrics.observeRuleViolation(req.Instrument.Venue, violation.Rule)
+		s.log.Warn().Str("venue", string(req.Instrument.Venue)).Str("bot", req.BotID).
+			Str("pair", req.Instrument.Pair()).Str("rule", string(violation.Rule)).
+			Msg(violation.Detail)
+		return req, err
+	case err != nil:
+		return req, err
+	}
+	if !conformed.Price.Equal(req.Price) || !conformed.Qty.Equal(req.Qty) {
+		s.metrics.observeRounded(req.Instrument.Venue)
+		s.log.Info().Str("venue", string(req.Instrument.Venue)).Str("bot", req.BotID).
+			Str("pair", req.Instrument.Pair()).
+			Str("qty", req.Qty.String()).Str("rounded_qty", conformed.Qty.String()).
+			Str("price", req.Price.String()).Str("rounded_price", conformed.Price.String()).
+			Msg("order rounded to the instrument rules")
+	}
+	return conformed, nil
+}
+
 func (s *Service) submitFailure(ctx context.Context, req domain.Request, err error) (PlaceResult, error) {
 	// Recovery reads and writes must survive the caller's context: a
 	// canceled deadline is often exactly why we are here.
 	ctx = context.WithoutCancel(ctx)
-	if errors.Is(err, ports.ErrAuth) || errors.Is(err, ports.ErrTradingUnsupported) {
+	if permanentSubmitError(err) {
 		// No venue order can exist, and the same failure blocks the venue
 		// lookups reconciliation would need, so settle the row now.
-		if applyErr := s.apply(ctx, domain.SourceLocal, domain.Event{
-			Ref:    domain.Ref{Instrument: req.Instrument, ClientOrderID: req.ClientOrderID},
-			Status: domain.StatusRejected,
-			Reason: "submit failed: " + err.Error(),
-			At:     s.clk.Now(),
-		}); applyErr != nil {
-			s.log.Error().Str("client_order_id", string(req.ClientOrderID)).
-				Err(applyErr).Msg("could not reject order after permanent submit failure")
-		}
-		return PlaceResult{ClientOrderID: req.ClientOrderID, Status: domain.StatusRejected}, err
+		return s.reject(ctx, req, err)
 	}
 	// Everything else is ambiguous: the venue may hold the order. That
 	// covers duplicate-ID venue errors (GCT gives no way to classify
@@ -215,6 +421,28 @@ func (s *Service) submitFailure(ctx context.Context, req domain.Request, err err
 	return placeResult(stored), fmt.Errorf("%w: %w", ErrSubmitUnsettled, err)
 }
 
+// reject settles a pending order no venue can hold as rejected for err.
+func (s *Service) reject(ctx context.Context, req domain.Request, err error) (PlaceResult, error) {
+	if applyErr := s.apply(context.WithoutCancel(ctx), domain.SourceLocal, domain.Event{
+		Ref:    domain.Ref{Instrument: req.Instrument, ClientOrderID: req.ClientOrderID},
+		Status: domain.StatusRejected,
+		Reason: "submit failed: " + err.Error(),
+		At:     s.clk.Now(),
+	}); applyErr != nil {
+		s.log.Error().Str("client_order_id", string(req.ClientOrderID)).
+			Err(applyErr).Msg("could not reject order after permanent submit failure")
+	}
+	return PlaceResult{ClientOrderID: req.ClientOrderID, Status: domain.StatusRejected}, err
+}
+
+// permanentSubmitError reports a submit failure that guarantees the venue
+// holds no order: retrying cannot help, and the row can be rejected now.
+func permanentSubmitError(err error) bool {
+	var unsupported *ports.UnsupportedError
+	return errors.Is(err, ports.ErrAuth) || errors.Is(err, ports.ErrTradingUnsupported) ||
+		errors.As(err, &unsupported)
+}
+
 func sameIdentity(stored domain.Record, req domain.Request) bool {
 	return stored.ClientOrderID == req.ClientOrderID &&
 		stored.BotID == req.BotID &&
@@ -222,15 +450,115 @@ func sameIdentity(stored domain.Record, req domain.Request) bool {
 		stored.Instrument.Base == req.Instrument.Base &&
 		stored.Instrument.Quote == req.Instrument.Quote &&
 		stored.Side == req.Side && stored.Type == req.Type &&
-		stored.Price.Equal(req.Price) && stored.Qty.Equal(req.Qty)
+		stored.Price.Equal(req.Price) && stored.Qty.Equal(req.Qty) &&
+		stored.TriggerPrice.Equal(req.TriggerPrice) &&
+		stored.TimeInForce == req.TimeInForce && stored.ExpiresAt.Equal(req.ExpiresAt) &&
+		stored.PostOnly == req.PostOnly && stored.ParentID == req.ParentID
 }
 
 func placeResult(stored domain.Record) PlaceResult {
 	return PlaceResult{ClientOrderID: stored.ClientOrderID, Status: stored.Status}
 }
 
+// LoadHalts restores the kill switches engaged before a restart.
+func (s *Service) LoadHalts(ctx context.Context) error {
+	halts, err := s.killSwitches.ListKillSwitches(ctx)
+	if err != nil {
+		return err
+	}
+	s.haltMu.Lock()
+	defer s.haltMu.Unlock()
+	for _, h := range halts {
+		s.halts[h.Venue] = h
+		s.log.Warn().Str("scope", haltScope(h)).Str("reason", h.Reason).Time("engaged_at", h.EngagedAt).
+			Msg("kill switch engaged; new orders are refused")
+	}
+	return nil
+}
+
+// Halt engages the kill switch for one trading venue, or for every venue
+// when venue is empty. It persists the halt first, so a failed write
+// leaves trading as it was, and returns once no placement is being
+// admitted on the covered venues. It does not wait on the venue: a submit
+// attempt already sent may still land and is reconciled, but none starts
+// after Halt returns. Cancels are not affected.
+func (s *Service) Halt(ctx context.Context, venue instrument.VenueID, reason string) (domain.Halt, error) {
+	if _, ok := s.venues[venue]; venue != "" && !ok {
+		return domain.Halt{}, fmt.Errorf("%w: %q", ErrVenueNotConfigured, venue)
+	}
+	s.haltMu.Lock()
+	defer s.haltMu.Unlock()
+	h, err := s.killSwitches.EngageKillSwitch(ctx, domain.Halt{Venue: venue, Reason: reason, EngagedAt: s.clk.Now()})
+	if err != nil {
+		return domain.Halt{}, err
+	}
+	s.halts[h.Venue] = h
+	s.log.Warn().Str("scope", haltScope(h)).Str("reason", h.Reason).Msg("kill switch engaged")
+	return h, nil
+}
+
+// Release disengages one kill switch. Releasing a venue's switch does not
+// lift a global halt. Returns ports.ErrNotFound when it was not engaged.
+func (s *Service) Release(ctx context.Context, venue instrument.VenueID) error {
+	s.haltMu.Lock()
+	defer s.haltMu.Unlock()
+	if err := s.killSwitches.ReleaseKillSwitch(ctx, venue); err != nil {
+		return err
+	}
+	h := s.halts[venue]
+	delete(s.halts, venue)
+	s.log.Warn().Str("scope", haltScope(h)).Msg("kill switch released")
+	return nil
+}
+
+// Halts returns the engaged kill switches, ordered by venue with the
+// global switch first.
+func (s *Service) Halts() []domain.Halt {
+	s.haltMu.RLock()
+	defer s.haltMu.RUnlock()
+	halts := make([]domain.Halt, 0, len(s.halts))
+	for _, h := range s.halts {
+		halts = append(halts, h)
+	}
+	slices.SortFunc(halts, func(a, b domain.Halt) int { return strings.Compare(string(a.Venue), string(b.Venue)) })
+	return halts
+}
+
+// Venues returns the trading venues, sorted.
+func (s *Service) Venues() []instrument.VenueID {
+	ids := make([]instrument.VenueID, 0, len(s.venues))
+	for id := range s.venues {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// halted reports the kill switch covering venue, if one is engaged.
+func (s *Service) halted(venue instrument.VenueID) (domain.Halt, bool) {
+	s.haltMu.RLock()
+	defer s.haltMu.RUnlock()
+	return s.haltedLocked(venue)
+}
+
+func (s *Service) haltedLocked(venue instrument.VenueID) (domain.Halt, bool) {
+	if h, ok := s.halts[""]; ok {
+		return h, true
+	}
+	h, ok := s.halts[venue]
+	return h, ok
+}
+
+func haltScope(h domain.Halt) string {
+	if h.Venue == "" {
+		return "all venues"
+	}
+	return string(h.Venue)
+}
+
 // Cancel records the cancel intent and asks the venue. The canceled state
-// itself arrives later like any other venue event.
+// itself arrives later like any other venue event. A stop held locally
+// involves no venue: it is canceled at once and Cancel reports canceled.
 func (s *Service) Cancel(ctx context.Context, orderID domain.ClientOrderID) (domain.Status, error) {
 	stored, err := s.commands.GetOrder(ctx, orderID)
 	if err != nil {
@@ -239,6 +567,9 @@ func (s *Service) Cancel(ctx context.Context, orderID domain.ClientOrderID) (dom
 	if stored.Status.Terminal() {
 		return "", fmt.Errorf("%w: %s is already %s", ErrTerminal, orderID, stored.Status)
 	}
+	if stored.HeldLocally() {
+		return s.cancelStop(ctx, orderID)
+	}
 	venue, ok := s.venues[stored.Instrument.Venue]
 	if !ok {
 		return "", fmt.Errorf("%w: %q", ErrVenueNotConfigured, stored.Instrument.Venue)
@@ -344,3 +675,40 @@ func (s *Service) apply(ctx context.Context, source domain.Source, ev domain.Eve
 	}
 	return nil
 }
+
+// keyedMutex is a mutex per key, dropped once nobody holds or waits on
+// it, so keys taken from requests do not accumulate.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+type keyedLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lock takes key's mutex and returns the func that releases it.
+func (k *keyedMutex) lock(key string) func() {
+	k.mu.Lock()
+	if k.locks == nil {
+		k.locks = map[string]*keyedLock{}
+	}
+	l, ok := k.locks[key]
+	if !ok {
+		l = &keyedLock{}
+		k.locks[key] = l
+	}
+	l.refs++
+	k.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		k.mu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
@@ -3,6 +3,8 @@ package order
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"sync"
 	"testing"
 	"time"
@@ -12,6 +14,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/shopspring/decimal"
 
+	"github.com/romanornr/delta-works/internal/domain/funds"
 	"github.com/romanornr/delta-works/internal/domain/instrument"
 	domain "github.com/romanornr/delta-works/internal/domain/order"
 	"github.com/romanornr/delta-works/internal/log"
@@ -106,6 +109,9 @@ type fakeStore struct {
 	applyErr    error
 	result      domain.ApplyResult
 	appliedCh   chan struct{}
+	halts       []domain.Halt
+	killErr     error
+	intents     map[domain.ClientOrderID]domain.Replacement
 }
 
 func (f *fakeStore) CreatePending(_ context.Context, req domain.Request) (bool, error) {
@@ -123,11 +129,24 @@ func (f *fakeStore) CreatePending(_ context.Context, req domain.Request) (bool,
 		Type:          req.Type,
 		Price:         req.Price,
 		Qty:           req.Qty,
+		TimeInForce:   req.TimeInForce,
+		ExpiresAt:     req.ExpiresAt,
+		PostOnly:      req.PostOnly,
 		Status:        domain.StatusPending,
 	}
 	return true, nil
 }
 
+func (f *fakeStore) CreateUntriggered(ctx context.Context, req domain.Request, child domain.ClientOrderID) (bool, error) {
+	inserted, err := f.CreatePending(ctx, req)
+	if inserted {
+		f.mu.Lock()
+		f.stored.Status, f.stored.Child, f.stored.TriggerPrice = domain.StatusUntriggered, child, req.TriggerPrice
+		f.mu.Unlock()
+	}
+	return inserted, err
+}
+
 func (f *fakeStore) ApplyEvent(_ context.Context, source domain.Source, ev domain.Event) (domain.ApplyResult, error) {
 	f.mu.Lock()
 	f.applied = append(f.applied, appliedEvent{source: source, ev: ev})
@@ -148,6 +167,39 @@ func (f *fakeStore) GetOrder(context.Context, domain.ClientOrderID) (domain.Reco
 	return f.stored, f.getErr
 }
 
+func (f *fakeStore) EngageKillSwitch(_ context.Context, h domain.Halt) (domain.Halt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.killErr != nil {
+		return domain.Halt{}, f.killErr
+	}
+	for _, engaged := range f.halts {
+		if engaged.Venue == h.Venue {
+			return engaged, nil
+		}
+	}
+	f.halts = append(f.halts, h)
+	return h, nil
+}
+
+func (f *fakeStore) ReleaseKillSwitch(_ context.Context, venue instrument.VenueID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, h := range f.halts {
+		if h.Venue == venue {
+			f.halts = slices.Delete(f.halts, i, i+1)
+			return nil
+		}
+	}
+	return ports.ErrNotFound
+}
+
+func (f *fakeStore) ListKillSwitches(context.Context) ([]domain.Halt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.halts), nil
+}
+
 func (f *fakeStore) MarkCancelRequested(_ context.Context, orderID domain.ClientOrderID, _ time.Time) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -155,6 +207,35 @@ func (f *fakeStore) MarkCancelRequested(_ context.Context, orderID domain.Client
 	return nil
 }
 
+func (*fakeStore) RecordAmend(context.Context, domain.Replacement) error { return nil }
+
+func (f *fakeStore) RecordReplaceIntent(_ context.Context, r domain.Replacement) (domain.Replacement, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if stored, ok := f.intents[r.Old]; ok {
+		return stored, nil
+	}
+	if f.intents == nil {
+		f.intents = map[domain.ClientOrderID]domain.Replacement{}
+	}
+	f.intents[r.Old] = r
+	return r, nil
+}
+
+func (f *fakeStore) GetReplaceIntent(_ context.Context, old domain.ClientOrderID) (domain.Replacement, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stored, ok := f.intents[old]
+	if !ok {
+		return domain.Replacement{}, ports.ErrNotFound
+	}
+	return stored, nil
+}
+
+func (*fakeStore) ListReplacements(context.Context, domain.ClientOrderID) ([]domain.Replacement, error) {
+	return nil, nil
+}
+
 func newService(t *testing.T, placer ports.OrderPlacer, store *fakeStore, streamer ports.PrivateStreamer) (*Service, *clockwork.FakeClock, *Metrics) {
 	t.Helper()
 	clk := clockwork.NewFakeClockAt(time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC))
@@ -163,7 +244,7 @@ func newService(t *testing.T, placer ports.OrderPlacer, store *fakeStore, stream
 		t.Fatal(err)
 	}
 	venues := []Venue{{ID: "bybit", Placer: placer, Streamer: streamer}}
-	return New(venues, store, store, clk, log.Nop(), 2*time.Second, m), clk, m
+	return New(venues, store, store, store, nil, nil, nil, clk, log.Nop(), 2*time.Second, 5*time.Second, m), clk, m
 }
 
 func placeRequest() domain.Request {
@@ -251,19 +332,50 @@ func TestPlaceUnsettledKeepsPending(t *testing.T) {
 
 func TestPlacePermanentErrorRejectsOrder(t *testing.T) {
 	t.Parallel()
-	placer := &fakePlacer{failures: 1, err: ports.ErrAuth}
-	store := &fakeStore{}
+	for _, permanent := range []error{
+		ports.ErrAuth,
+		fmt.Errorf("gct: %w", &ports.UnsupportedError{Venue: "bybit", Flag: "gtd"}),
+	} {
+		placer := &fakePlacer{failures: 1, err: permanent}
+		store := &fakeStore{}
+		svc, _, _ := newService(t, placer, store, nil)
+		result, err := svc.Place(t.Context(), placeRequest())
+		if !errors.Is(err, permanent) {
+			t.Fatalf("err = %v, want %v", err, permanent)
+		}
+		if result.ClientOrderID == "" || result.Status != domain.StatusRejected {
+			t.Fatalf("%v: result = %+v, want rejected with ID", permanent, result)
+		}
+		if len(placer.submits) != 1 {
+			t.Fatalf("%v: %d submits, want 1: a permanent failure is not retried", permanent, len(placer.submits))
+		}
+		if len(store.applied) != 1 || store.applied[0].source != domain.SourceLocal ||
+			store.applied[0].ev.Status != domain.StatusRejected {
+			t.Fatalf("%v: applied = %+v, want one local rejected event", permanent, store.applied)
+		}
+	}
+}
+
+func TestPlaceRefusesInvalidExecution(t *testing.T) {
+	t.Parallel()
+	placer, store := &fakePlacer{}, &fakeStore{}
 	svc, _, _ := newService(t, placer, store, nil)
-	result, err := svc.Place(t.Context(), placeRequest())
-	if !errors.Is(err, ports.ErrAuth) {
-		t.Fatalf("err = %v, want ErrAuth", err)
+	req := placeRequest()
+	req.TimeInForce, req.PostOnly = domain.IOC, true
+	if _, err := svc.Place(t.Context(), req); !errors.Is(err, domain.ErrInvalidPostOnly) {
+		t.Fatalf("err = %v, want ErrInvalidPostOnly", err)
 	}
-	if result.ClientOrderID == "" || result.Status != domain.StatusRejected {
-		t.Fatalf("result = %+v, want rejected with ID", result)
+	if len(store.pending) != 0 || len(placer.submits) != 0 {
+		t.Fatalf("pending=%d submits=%d, want nothing stored or submitted", len(store.pending), len(placer.submits))
 	}
-	if len(store.applied) != 1 || store.applied[0].source != domain.SourceLocal ||
-		store.applied[0].ev.Status != domain.StatusRejected {
-		t.Fatalf("applied = %+v, want one local rejected event", store.applied)
+
+	req = placeRequest()
+	req.PostOnly = true
+	if _, err := svc.Place(t.Context(), req); err != nil {
+		t.Fatalf("post-only Place: %v", err)
+	}
+	if got := placer.submits[0]; got.TimeInForce != domain.GTC || !got.PostOnly {
+		t.Fatalf("submitted %+v, want gtc post-only", got)
 	}
 }
 
@@ -303,7 +415,8 @@ func TestPlaceSuppliedIDContract(t *testing.T) {
 	request.BotID = "manual"
 	stored := domain.Record{
 		ClientOrderID: request.ClientOrderID, BotID: request.BotID, Instrument: request.Instrument,
-		Side: request.Side, Type: request.Type, Price: request.Price, Qty: request.Qty, Status: domain.StatusOpen, VenueOrderID: "v-1",
+		Side: request.Side, Type: request.Type, Price: request.Price, Qty: request.Qty, TimeInForce: domain.GTC,
+		Status: domain.StatusOpen, VenueOrderID: "v-1",
 	}
 	t.Run("matching advanced order is not resubmitted", func(t *testing.T) {
 		placer, store := &fakePlacer{}, &fakeStore{stored: stored}
@@ -353,6 +466,314 @@ func TestPlaceSuppliedIDContract(t *testing.T) {
 	})
 }
 
+type rejectAll struct{ calls int }
+
+var errRefused = errors.New("refused")
+
+func (r *rejectAll) Check(context.Context, domain.Request) error {
+	r.calls++
+	return errRefused
+}
+
+func (*rejectAll) CheckAdmit(context.Context, domain.Request) error { return nil }
+
+func TestPlacePreTradeCheck(t *testing.T) {
+	t.Parallel()
+	t.Run("rejection stops a new order before it is stored", func(t *testing.T) {
+		placer, store, check := &fakePlacer{}, &fakeStore{}, &rejectAll{}
+		svc, _, _ := newService(t, placer, store, nil)
+		svc.preTrade = check
+		_, err := svc.Place(t.Context(), placeRequest())
+		if !errors.Is(err, errRefused) || check.calls != 1 || len(store.pending) != 0 || len(placer.submits) != 0 {
+			t.Fatalf("err=%v calls=%d pending=%d submits=%d", err, check.calls, len(store.pending), len(placer.submits))
+		}
+	})
+	t.Run("supplied ID not yet stored is rejected", func(t *testing.T) {
+		placer, store := &fakePlacer{}, &fakeStore{getErr: ports.ErrNotFound}
+		svc, _, _ := newService(t, placer, store, nil)
+		svc.preTrade = &rejectAll{}
+		request := placeRequest()
+		request.ClientOrderID = "01J00000000000000000000002"
+		if _, err := svc.Place(t.Context(), request); !errors.Is(err, errRefused) || len(store.pending) != 0 {
+			t.Fatalf("err=%v pending=%d", err, len(store.pending))
+		}
+	})
+	t.Run("retry of a stored order keeps its answer", func(t *testing.T) {
+		request := placeRequest()
+		request.ClientOrderID, request.BotID = "01J00000000000000000000003", "manual"
+		placer, store := &fakePlacer{}, &fakeStore{stored: domain.Record{
+			ClientOrderID: request.ClientOrderID, BotID: request.BotID, Instrument: request.Instrument,
+			Side: request.Side, Type: request.Type, Price: request.Price, Qty: request.Qty, TimeInForce: domain.GTC,
+			Status: domain.StatusOpen, VenueOrderID: "v-1",
+		}}
+		svc, _, _ := newService(t, placer, store, nil)
+		svc.preTrade = &rejectAll{}
+		result, err := svc.Place(t.Context(), request)
+		if err != nil || result.Status != domain.StatusOpen || len(placer.submits) != 0 {
+			t.Fatalf("result=%+v err=%v submits=%d", result, err, len(placer.submits))
+		}
+	})
+}
+
+// admitOne passes only while nothing is stored, like an open-order limit
+// of one, and dawdles between its count and its verdict.
+type admitOne struct{ store *fakeStore }
+
+func (admitOne) Check(context.Context, domain.Request) error { return nil }
+
+func (c admitOne) CheckAdmit(context.Context, domain.Request) error {
+	c.store.mu.Lock()
+	n := len(c.store.pending)
+	c.store.mu.Unlock()
+	time.Sleep(10 * time.Millisecond)
+	if n > 0 {
+		return errRefused
+	}
+	return nil
+}
+
+func TestPlaceAdmitsUnderTheVenueLock(t *testing.T) {
+	t.Parallel()
+	store := &fakeStore{result: domain.ApplyResult{Decision: domain.Decision{Transition: true, To: domain.StatusOpen}}}
+	svc, _, _ := newService(t, &fakePlacer{}, store, nil)
+	svc.preTrade = admitOne{store: store}
+
+	errs := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := svc.Place(t.Context(), placeRequest())
+			errs <- err
+		}()
+	}
+	refused := 0
+	for range 2 {
+		if err := <-errs; errors.Is(err, errRefused) {
+			refused++
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if refused != 1 || len(store.pending) != 1 {
+		t.Fatalf("refused %d, stored %d; want one of each", refused, len(store.pending))
+	}
+}
+
+// stallVenue holds the market checks of orders on venue until their
+// context ends, like a reference price fetch from a venue that hangs.
+type stallVenue struct {
+	venue   instrument.VenueID
+	stalled chan struct{}
+}
+
+func (c stallVenue) Check(ctx context.Context, req domain.Request) error {
+	if req.Instrument.Venue != c.venue {
+		return nil
+	}
+	close(c.stalled)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (stallVenue) CheckAdmit(context.Context, domain.Request) error { return nil }
+
+func TestSlowVenueDoesNotHoldOthers(t *testing.T) {
+	t.Parallel()
+	store := &fakeStore{result: domain.ApplyResult{Decision: domain.Decision{Transition: true, To: domain.StatusOpen}}}
+	svc, _, _ := newService(t, &fakePlacer{}, store, nil)
+	svc.venues["kraken"] = Venue{ID: "kraken", Placer: &fakePlacer{}}
+	check := stallVenue{venue: "bybit", stalled: make(chan struct{})}
+	svc.preTrade = check
+
+	ctx, cancel := context.WithCancel(t.Context())
+	stalled := make(chan error, 1)
+	go func() {
+		_, err := svc.Place(ctx, placeRequest())
+		stalled <- err
+	}()
+	<-check.stalled
+	elsewhere := placeRequest()
+	elsewhere.Instrument.Venue = "kraken"
+	if _, err := svc.Place(t.Context(), elsewhere); err != nil {
+		t.Fatalf("Place on another venue: %v", err)
+	}
+	cancel()
+	if err := <-stalled; !errors.Is(err, context.Canceled) {
+		t.Fatalf("stalled Place = %v, want canceled", err)
+	}
+}
+
+// refuseFunds refuses every order, or stores it through the command store
+// when pass is set.
+type refuseFunds struct {
+	store *fakeStore
+	pass  bool
+	calls int
+}
+
+var errNoFunds = errors.New("insufficient funds")
+
+func (*refuseFunds) Reserve(context.Context, domain.Request) (funds.Reservation, error) {
+	return funds.Reservation{}, nil
+}
+
+func (r *refuseFunds) CheckAmend(context.Context, domain.Request, domain.Replacement) error {
+	r.calls++
+	if r.pass {
+		return nil
+	}
+	return funds.ErrInsufficient
+}
+
+func (r *refuseFunds) CreatePending(ctx context.Context, req domain.Request, _ funds.Reservation) (bool, error) {
+	r.calls++
+	if r.pass {
+		return r.store.CreatePending(ctx, req)
+	}
+	return false, errNoFunds
+}
+
+func TestPlaceReservesFunds(t *testing.T) {
+	t.Parallel()
+	t.Run("the reserver stores the order", func(t *testing.T) {
+		placer, store := &fakePlacer{}, &fakeStore{}
+		svc, _, _ := newService(t, placer, store, nil)
+		reserver := &refuseFunds{store: store, pass: true}
+		svc.funds = reserver
+		if _, err := svc.Place(t.Context(), placeRequest()); err != nil || reserver.calls != 1 || len(store.pending) != 1 || len(placer.submits) != 1 {
+			t.Fatalf("err=%v calls=%d pending=%d submits=%d", err, reserver.calls, len(store.pending), len(placer.submits))
+		}
+	})
+	t.Run("a refusal stops the order before the venue", func(t *testing.T) {
+		placer, store := &fakePlacer{}, &fakeStore{}
+		svc, _, _ := newService(t, placer, store, nil)
+		svc.funds = &refuseFunds{store: store}
+		if _, err := svc.Place(t.Context(), placeRequest()); !errors.Is(err, errNoFunds) || len(store.pending) != 0 || len(placer.submits) != 0 {
+			t.Fatalf("err=%v pending=%d submits=%d", err, len(store.pending), len(placer.submits))
+		}
+	})
+	t.Run("retry of a stored order keeps its answer", func(t *testing.T) {
+		request := placeRequest()
+		request.ClientOrderID, request.BotID = "01J00000000000000000000004", "manual"
+		placer, store := &fakePlacer{}, &fakeStore{stored: domain.Record{
+			ClientOrderID: request.ClientOrderID, BotID: request.BotID, Instrument: request.Instrument,
+			Side: request.Side, Type: request.Type, Price: request.Price, Qty: request.Qty, TimeInForce: domain.GTC,
+			Status: domain.StatusOpen, VenueOrderID: "v-1",
+		}}
+		svc, _, _ := newService(t, placer, store, nil)
+		svc.funds = &refuseFunds{store: store}
+		result, err := svc.Place(t.Context(), request)
+		if err != nil || result.Status != domain.StatusOpen || len(placer.submits) != 0 {
+			t.Fatalf("result=%+v err=%v submits=%d", result, err, len(placer.submits))
+		}
+	})
+}
+
+type blockingPlacer struct {
+	*fakePlacer
+	entered, release chan struct{}
+}
+
+func (b blockingPlacer) PlaceOrder(ctx context.Context, req domain.Request) (domain.Ack, error) {
+	close(b.entered)
+	<-b.release
+	return b.fakePlacer.PlaceOrder(ctx, req)
+}
+
+func TestKillSwitch(t *testing.T) {
+	t.Parallel()
+	t.Run("halt refuses placement and survives a restart", func(t *testing.T) {
+		placer, store := &fakePlacer{}, &fakeStore{getErr: ports.ErrNotFound}
+		svc, _, _ := newService(t, placer, store, nil)
+		h, err := svc.Halt(t.Context(), "bybit", "drill")
+		if err != nil || h.Venue != "bybit" || h.Reason != "drill" {
+			t.Fatalf("Halt = %+v, %v", h, err)
+		}
+		if _, err := svc.Place(t.Context(), placeRequest()); !errors.Is(err, ErrHalted) {
+			t.Fatalf("Place while halted = %v, want ErrHalted", err)
+		}
+		restarted, _, _ := newService(t, placer, store, nil)
+		if err := restarted.LoadHalts(t.Context()); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := restarted.Place(t.Context(), placeRequest()); !errors.Is(err, ErrHalted) {
+			t.Fatalf("Place after restart = %v, want ErrHalted", err)
+		}
+		if len(store.pending) != 0 || len(placer.submits) != 0 {
+			t.Fatalf("pending=%d submits=%d while halted", len(store.pending), len(placer.submits))
+		}
+		if err := restarted.Release(t.Context(), "bybit"); err != nil {
+			t.Fatal(err)
+		}
+		store.getErr = nil
+		if _, err := restarted.Place(t.Context(), placeRequest()); err != nil {
+			t.Fatalf("Place after release = %v", err)
+		}
+	})
+	t.Run("global halt covers every venue and a venue release does not lift it", func(t *testing.T) {
+		svc, _, _ := newService(t, &fakePlacer{}, &fakeStore{}, nil)
+		if _, err := svc.Halt(t.Context(), "", ""); err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.Release(t.Context(), "bybit"); !errors.Is(err, ports.ErrNotFound) {
+			t.Fatalf("Release of an unengaged venue = %v", err)
+		}
+		if _, err := svc.Place(t.Context(), placeRequest()); !errors.Is(err, ErrHalted) {
+			t.Fatalf("Place under global halt = %v", err)
+		}
+		if halts := svc.Halts(); len(halts) != 1 || halts[0].Venue != "" {
+			t.Fatalf("Halts = %+v", halts)
+		}
+	})
+	t.Run("unknown venue and failed persistence leave trading alone", func(t *testing.T) {
+		store := &fakeStore{killErr: errors.New("postgres down")}
+		svc, _, _ := newService(t, &fakePlacer{}, store, nil)
+		if _, err := svc.Halt(t.Context(), "kraken", ""); !errors.Is(err, ErrVenueNotConfigured) {
+			t.Fatalf("Halt(kraken) = %v", err)
+		}
+		if _, err := svc.Halt(t.Context(), "bybit", ""); err == nil {
+			t.Fatal("Halt succeeded without persisting")
+		}
+		if len(svc.Halts()) != 0 {
+			t.Fatalf("Halts = %+v after failed engage", svc.Halts())
+		}
+	})
+	t.Run("engaging waits for admission, not for the venue", func(t *testing.T) {
+		placer := blockingPlacer{
+			fakePlacer: &fakePlacer{failures: 1, err: errors.New("connection reset")},
+			entered:    make(chan struct{}), release: make(chan struct{}),
+		}
+		svc, _, _ := newService(t, placer, &fakeStore{}, nil)
+		placed := make(chan error, 1)
+		go func() {
+			_, err := svc.Place(t.Context(), placeRequest())
+			placed <- err
+		}()
+		<-placer.entered
+		halted := make(chan error, 1)
+		go func() {
+			_, err := svc.Halt(t.Context(), "bybit", "")
+			halted <- err
+		}()
+		select {
+		case err := <-halted:
+			if err != nil {
+				t.Fatalf("Halt = %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Halt waited on a submit in flight")
+		}
+		close(placer.release)
+		// The failed attempt may have reached the venue, so the order is
+		// left to reconciliation, but the halt stops its retries.
+		if err := <-placed; !errors.Is(err, ErrSubmitUnsettled) || !errors.Is(err, ErrHalted) {
+			t.Fatalf("in-flight Place = %v, want unsettled by the halt", err)
+		}
+		if len(placer.submits) != 1 {
+			t.Fatalf("submits = %d after the halt, want 1", len(placer.submits))
+		}
+	})
+}
+
 func TestConcurrentSameIDPlace(t *testing.T) {
 	t.Parallel()
 	request := placeRequest()
@@ -541,7 +962,7 @@ func TestRunStartsOneConsumerPerVenue(t *testing.T) {
 	svc := New([]Venue{
 		{ID: "bybit", Placer: &fakePlacer{}, Streamer: first},
 		{ID: "kraken", Placer: &fakePlacer{}, Streamer: second},
-	}, store, store, clk, log.Nop(), time.Second, metrics)
+	}, store, store, store, nil, nil, nil, clk, log.Nop(), time.Second, time.Second, metrics)
 	ctx, cancel := context.WithCancel(t.Context())
 	done := make(chan error, 1)
 	go func() { done <- svc.Run(ctx) }()
@@ -0,0 +1,288 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	domain "github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/id"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// replacePollInterval is how often a cancel-replace re-reads the old
+// order while waiting for its cancel to settle.
+const replacePollInterval = 250 * time.Millisecond
+
+var (
+	// ErrNotReplaceable reports a replace of an order that cannot take new
+	// terms: a market order, one not yet acknowledged, or terms that are
+	// not positive.
+	ErrNotReplaceable = errors.New("order cannot be replaced")
+	// ErrReplaceUnsettled reports a cancel-replace whose old order was not
+	// terminal within the settle timeout. Nothing was placed; retrying
+	// with the same new client order ID, returned with the error, resumes
+	// the replace.
+	ErrReplaceUnsettled = errors.New("replaced order did not settle; replacement not placed")
+	// ErrReplaceFilled reports an order already filled up to the new
+	// quantity, leaving nothing to replace.
+	ErrReplaceFilled = errors.New("order filled past the replacement quantity")
+)
+
+// ReplaceRequest gives an active limit order new terms. Qty is the new
+// total, fills included, as in a FIX cancel/replace: a cancel-replace
+// places only what the old order left unfilled. NewClientOrderID is
+// optional and is the idempotency key of a cancel-replace.
+type ReplaceRequest struct {
+	ClientOrderID    domain.ClientOrderID
+	NewClientOrderID domain.ClientOrderID
+	Price, Qty       decimal.Decimal
+}
+
+// ReplaceResult is the working order after a replace. ClientOrderID equals
+// Replaced after an amend, which keeps the order's IDs.
+type ReplaceResult struct {
+	Replaced      domain.ClientOrderID
+	ClientOrderID domain.ClientOrderID
+	Mode          domain.ReplaceMode
+	Status        domain.Status
+}
+
+// Replace gives an active limit order new terms. A venue that amends
+// natively changes the order in place. Otherwise the old order is canceled
+// and, only once it is terminal, a new one is placed for the quantity it
+// left unfilled: the two are never working together, and an old order the
+// venue has not confirmed canceled within the settle timeout fails the
+// replace with ErrReplaceUnsettled. Both paths record the replacement link.
+// A replacement refused by the rules or the pre-trade checks leaves the
+// old order canceled; a kill switch covering the venue refuses the replace
+// before anything is canceled.
+//
+// A cancel-replace records its intent, the new client order ID and terms,
+// before it cancels. An old order already terminal is only replaced under
+// that intent, which makes a retry after ErrReplaceUnsettled or
+// ErrSubmitUnsettled pick up where the first attempt stopped and keeps any
+// other request from placing a new order for a finished one.
+func (s *Service) Replace(ctx context.Context, rr ReplaceRequest) (ReplaceResult, error) {
+	old, err := s.commands.GetOrder(ctx, rr.ClientOrderID)
+	if err != nil {
+		return ReplaceResult{}, err
+	}
+	venue, ok := s.venues[old.Instrument.Venue]
+	if !ok {
+		return ReplaceResult{}, fmt.Errorf("%w: %q", ErrVenueNotConfigured, old.Instrument.Venue)
+	}
+	switch {
+	case old.Type != domain.Limit:
+		return ReplaceResult{}, fmt.Errorf("%w: %s is a %s order", ErrNotReplaceable, old.ClientOrderID, old.Type)
+	case !rr.Price.IsPositive() || !rr.Qty.IsPositive():
+		return ReplaceResult{}, fmt.Errorf("%w: price and qty must be positive", ErrNotReplaceable)
+	case !rr.Qty.GreaterThan(old.FilledQty):
+		return ReplaceResult{}, fmt.Errorf("%w: %s filled %s of the new qty %s", ErrReplaceFilled, old.ClientOrderID, old.FilledQty, rr.Qty)
+	case old.Status.Terminal():
+		return s.resumeReplacement(ctx, old, rr)
+	case old.VenueOrderID == "":
+		return ReplaceResult{}, fmt.Errorf("%w: %s is not acknowledged by the venue yet", ErrNotReplaceable, old.ClientOrderID)
+	}
+	if h, halted := s.halted(old.Instrument.Venue); halted {
+		return ReplaceResult{}, fmt.Errorf("%w: %s", ErrHalted, haltScope(h))
+	}
+
+	if amender, ok := venue.Placer.(ports.OrderAmender); ok {
+		result, err := s.amend(ctx, amender, old, rr)
+		if !errors.Is(err, ports.ErrAmendUnsupported) {
+			return result, err
+		}
+	}
+
+	if rr.NewClientOrderID == "" {
+		rr.NewClientOrderID = domain.ClientOrderID(id.New())
+	}
+	if err := s.recordIntent(ctx, old, rr); err != nil {
+		return ReplaceResult{}, err
+	}
+	if _, err := s.Cancel(ctx, old.ClientOrderID); err != nil && !errors.Is(err, ErrTerminal) {
+		return ReplaceResult{}, err
+	}
+	settled, err := s.awaitTerminal(ctx, old.ClientOrderID)
+	if errors.Is(err, ErrReplaceUnsettled) {
+		// The caller may not have chosen the ID; it needs it to resume.
+		return ReplaceResult{
+			Replaced: old.ClientOrderID, ClientOrderID: rr.NewClientOrderID,
+			Mode: domain.ReplaceCancelPlace, Status: old.Status,
+		}, err
+	}
+	if err != nil {
+		return ReplaceResult{}, err
+	}
+	return s.placeReplacement(ctx, settled, rr)
+}
+
+// recordIntent stores the cancel-replace intent before the cancel. An
+// intent already stored for the old order must be this one: a second
+// replace with other terms or another ID would place a second order.
+func (s *Service) recordIntent(ctx context.Context, old domain.Record, rr ReplaceRequest) error {
+	intent, err := s.commands.RecordReplaceIntent(ctx, domain.Replacement{
+		Old: old.ClientOrderID, New: rr.NewClientOrderID, Mode: domain.ReplaceCancelPlace,
+		Price: rr.Price, Qty: rr.Qty, RequestedAt: s.clk.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	return matchIntent(intent, rr)
+}
+
+// resumeReplacement places the replacement of an old order already
+// terminal, only under the intent its cancel-replace recorded.
+func (s *Service) resumeReplacement(ctx context.Context, old domain.Record, rr ReplaceRequest) (ReplaceResult, error) {
+	if rr.NewClientOrderID == "" {
+		return ReplaceResult{}, fmt.Errorf("%w: %s is already %s", ErrTerminal, old.ClientOrderID, old.Status)
+	}
+	intent, err := s.commands.GetReplaceIntent(ctx, old.ClientOrderID)
+	if errors.Is(err, ports.ErrNotFound) {
+		return ReplaceResult{}, fmt.Errorf("%w: %s is already %s with no replace pending", ErrTerminal, old.ClientOrderID, old.Status)
+	}
+	if err != nil {
+		return ReplaceResult{}, err
+	}
+	if err := matchIntent(intent, rr); err != nil {
+		return ReplaceResult{}, err
+	}
+	return s.placeReplacement(ctx, old, rr)
+}
+
+func matchIntent(intent domain.Replacement, rr ReplaceRequest) error {
+	if intent.New != rr.NewClientOrderID || !intent.Price.Equal(rr.Price) || !intent.Qty.Equal(rr.Qty) {
+		return fmt.Errorf("%w: %s is being replaced by %s at %s for %s", ErrIdentityMismatch, intent.Old, intent.New, intent.Price, intent.Qty)
+	}
+	return nil
+}
+
+// amend changes the order in place. The amended terms are vetted like a
+// new order; Replaces tells the pre-trade checks the order already counts
+// against the open-order limits. An amend that would hold more funds is
+// checked against the balances before the venue is asked, and refused with
+// funds.ErrInsufficient when they cannot cover the increase.
+func (s *Service) amend(ctx context.Context, amender ports.OrderAmender, old domain.Record, rr ReplaceRequest) (ReplaceResult, error) {
+	s.haltMu.RLock()
+	defer s.haltMu.RUnlock()
+	if h, halted := s.haltedLocked(old.Instrument.Venue); halted {
+		return ReplaceResult{}, fmt.Errorf("%w: %s", ErrHalted, haltScope(h))
+	}
+	req := domain.Request{
+		ClientOrderID: old.ClientOrderID, Replaces: old.ClientOrderID, BotID: old.BotID,
+		Instrument: old.Instrument, Side: old.Side, Type: old.Type,
+		Price: rr.Price, Qty: rr.Qty,
+		TimeInForce: old.TimeInForce, ExpiresAt: old.ExpiresAt, PostOnly: old.PostOnly, ParentID: old.ParentID,
+	}
+	req, err := s.vet(ctx, req, false)
+	if err == nil && s.preTrade != nil {
+		err = s.preTrade.CheckAdmit(ctx, req)
+	}
+	if err != nil {
+		return ReplaceResult{}, err
+	}
+	if !req.Qty.GreaterThan(old.FilledQty) {
+		// Rounded down to the fills.
+		return ReplaceResult{}, fmt.Errorf("%w: %s filled %s of the new qty %s", ErrReplaceFilled, old.ClientOrderID, old.FilledQty, req.Qty)
+	}
+	amended := domain.Replacement{
+		Old: old.ClientOrderID, New: old.ClientOrderID, Mode: domain.ReplaceAmend,
+		Price: req.Price, Qty: req.Qty, RequestedAt: s.clk.Now(),
+	}
+	if s.funds != nil {
+		if err := s.funds.CheckAmend(ctx, req, amended); err != nil {
+			return ReplaceResult{}, err
+		}
+	}
+	ack, err := amender.AmendOrder(ctx, domain.Ref{
+		Instrument:    old.Instrument,
+		ClientOrderID: old.ClientOrderID,
+		VenueOrderID:  old.VenueOrderID,
+	}, req)
+	if err != nil {
+		return ReplaceResult{}, err
+	}
+	if err := s.commands.RecordAmend(context.WithoutCancel(ctx), amended); err != nil {
+		// The venue holds the new terms; only the local copy is stale.
+		s.log.Error().Str("client_order_id", string(old.ClientOrderID)).Err(err).
+			Msg("venue amended the order but the local update failed")
+		return ReplaceResult{}, fmt.Errorf("%w: %w", ErrSubmitUnsettled, err)
+	}
+	s.metrics.observeReplace(old.Instrument.Venue, domain.ReplaceAmend)
+	s.log.Info().Str("client_order_id", string(old.ClientOrderID)).
+		Str("price", req.Price.String()).Str("qty", req.Qty.String()).Msg("order amended")
+	return ReplaceResult{
+		Replaced: old.ClientOrderID, ClientOrderID: old.ClientOrderID,
+		Mode: domain.ReplaceAmend, Status: ack.Status,
+	}, nil
+}
+
+// awaitTerminal polls the store until the order is terminal. The venue
+// stream or reconciliation settles it; this only watches.
+func (s *Service) awaitTerminal(ctx context.Context, orderID domain.ClientOrderID) (domain.Record, error) {
+	deadline := s.clk.Now().Add(s.replaceSettle)
+	for {
+		stored, err := s.commands.GetOrder(ctx, orderID)
+		if err != nil {
+			return domain.Record{}, err
+		}
+		if stored.Status.Terminal() {
+			return stored, nil
+		}
+		if !s.clk.Now().Before(deadline) {
+			return domain.Record{}, fmt.Errorf("%w: %s is still %s", ErrReplaceUnsettled, orderID, stored.Status)
+		}
+		select {
+		case <-ctx.Done():
+			return domain.Record{}, ctx.Err()
+		case <-s.clk.After(replacePollInterval):
+		}
+	}
+}
+
+// placeReplacement places the quantity the terminal old order left
+// unfilled under rr.NewClientOrderID, linked to the old order. A
+// replacement already stored under that ID is returned as it is.
+func (s *Service) placeReplacement(ctx context.Context, old domain.Record, rr ReplaceRequest) (ReplaceResult, error) {
+	result := ReplaceResult{Replaced: old.ClientOrderID, ClientOrderID: rr.NewClientOrderID, Mode: domain.ReplaceCancelPlace}
+	stored, err := s.commands.GetOrder(ctx, rr.NewClientOrderID)
+	switch {
+	case err == nil:
+		links, err := s.commands.ListReplacements(ctx, rr.NewClientOrderID)
+		if err != nil {
+			return ReplaceResult{}, err
+		}
+		for _, l := range links {
+			if l.Old == old.ClientOrderID && l.New == rr.NewClientOrderID {
+				result.Status = stored.Status
+				return result, nil
+			}
+		}
+		return ReplaceResult{}, fmt.Errorf("%w: %s does not replace %s", ErrIdentityMismatch, rr.NewClientOrderID, old.ClientOrderID)
+	case !errors.Is(err, ports.ErrNotFound):
+		return ReplaceResult{}, err
+	}
+
+	remaining := rr.Qty.Sub(old.FilledQty)
+	if !remaining.IsPositive() {
+		return ReplaceResult{}, fmt.Errorf("%w: %s filled %s of the new qty %s", ErrReplaceFilled, old.ClientOrderID, old.FilledQty, rr.Qty)
+	}
+	placed, err := s.Place(ctx, domain.Request{
+		ClientOrderID: rr.NewClientOrderID, Replaces: old.ClientOrderID, BotID: old.BotID,
+		Instrument: old.Instrument, Side: old.Side, Type: old.Type,
+		Price: rr.Price, Qty: remaining,
+		TimeInForce: old.TimeInForce, ExpiresAt: old.ExpiresAt, PostOnly: old.PostOnly, ParentID: old.ParentID,
+	})
+	result.Status = placed.Status
+	if err == nil || errors.Is(err, ErrSubmitUnsettled) {
+		s.metrics.observeReplace(old.Instrument.Venue, domain.ReplaceCancelPlace)
+		s.log.Info().Str("client_order_id", string(old.ClientOrderID)).
+			Str("replacement", string(rr.NewClientOrderID)).Str("status", string(placed.Status)).
+			Msg("order canceled and replaced")
+	}
+	return result, err
+}
@@ -0,0 +1,350 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/funds"
+	domain "github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// replaceStore keeps every order, so a replace can read the old order
+// while placing the new one. Kill switches come from the embedded store.
+type replaceStore struct {
+	*fakeStore
+	rmu    sync.Mutex
+	orders map[domain.ClientOrderID]domain.Record
+	links  []domain.Replacement
+}
+
+func newReplaceStore(old domain.Record) *replaceStore {
+	return &replaceStore{fakeStore: &fakeStore{}, orders: map[domain.ClientOrderID]domain.Record{old.ClientOrderID: old}}
+}
+
+func (s *replaceStore) CreatePending(_ context.Context, req domain.Request) (bool, error) {
+	s.rmu.Lock()
+	defer s.rmu.Unlock()
+	if _, ok := s.orders[req.ClientOrderID]; ok {
+		return false, nil
+	}
+	s.orders[req.ClientOrderID] = domain.Record{
+		ClientOrderID: req.ClientOrderID, BotID: req.BotID, Instrument: req.Instrument,
+		Side: req.Side, Type: req.Type, Price: req.Price, Qty: req.Qty, TriggerPrice: req.TriggerPrice,
+		TimeInForce: req.TimeInForce, ExpiresAt: req.ExpiresAt, PostOnly: req.PostOnly, Status: domain.StatusPending,
+	}
+	if req.Replaces != "" {
+		s.links = append(s.links, domain.Replacement{Old: req.Replaces, New: req.ClientOrderID, Mode: domain.ReplaceCancelPlace, Price: req.Price, Qty: req.Qty})
+	}
+	return true, nil
+}
+
+func (s *replaceStore) GetOrder(_ context.Context, id domain.ClientOrderID) (domain.Record, error) {
+	s.rmu.Lock()
+	defer s.rmu.Unlock()
+	stored, ok := s.orders[id]
+	if !ok {
+		return domain.Record{}, ports.ErrNotFound
+	}
+	return stored, nil
+}
+
+func (s *replaceStore) ApplyEvent(_ context.Context, _ domain.Source, ev domain.Event) (domain.ApplyResult, error) {
+	s.rmu.Lock()
+	defer s.rmu.Unlock()
+	stored, ok := s.orders[ev.Ref.ClientOrderID]
+	if !ok {
+		return domain.ApplyResult{}, ports.ErrNotFound
+	}
+	stored.Status, stored.VenueOrderID = ev.Status, ev.Ref.VenueOrderID
+	if ev.FilledQty.GreaterThan(stored.FilledQty) {
+		stored.FilledQty = ev.FilledQty
+	}
+	s.orders[ev.Ref.ClientOrderID] = stored
+	return domain.ApplyResult{}, nil
+}
+
+func (s *replaceStore) RecordAmend(_ context.Context, r domain.Replacement) error {
+	s.rmu.Lock()
+	defer s.rmu.Unlock()
+	stored := s.orders[r.Old]
+	stored.Price, stored.Qty = r.Price, r.Qty
+	s.orders[r.Old] = stored
+	s.links = append(s.links, r)
+	return nil
+}
+
+func (s *replaceStore) ListReplacements(_ context.Context, id domain.ClientOrderID) ([]domain.Replacement, error) {
+	s.rmu.Lock()
+	defer s.rmu.Unlock()
+	var out []domain.Replacement
+	for _, l := range s.links {
+		if l.Old == id || l.New == id {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+// settle stands in for the venue stream reporting the old order terminal.
+func (s *replaceStore) settle(id domain.ClientOrderID, status domain.Status, filled string) {
+	s.rmu.Lock()
+	defer s.rmu.Unlock()
+	stored := s.orders[id]
+	stored.Status, stored.FilledQty = status, decimal.RequireFromString(filled)
+	s.orders[id] = stored
+}
+
+// replacePlacer is a venue with or without native amend. With
+// settleCancels set, a cancel reaches the store as the stream would
+// deliver it, leaving the order's fills as they were.
+type replacePlacer struct {
+	fakePlacer
+	amendErr      error
+	amends        []domain.Request
+	store         *replaceStore
+	settleCancels bool
+	// filledOnCancel, when set, is what the order filled before the
+	// cancel took effect.
+	filledOnCancel string
+}
+
+func (p *replacePlacer) AmendOrder(_ context.Context, ref domain.Ref, req domain.Request) (domain.Ack, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.amendErr != nil {
+		return domain.Ack{}, p.amendErr
+	}
+	p.amends = append(p.amends, req)
+	return domain.Ack{Ref: ref, Status: domain.StatusPartiallyFilled}, nil
+}
+
+func (p *replacePlacer) CancelOrder(ctx context.Context, ref domain.Ref) error {
+	if err := p.fakePlacer.CancelOrder(ctx, ref); err != nil {
+		return err
+	}
+	if p.settleCancels {
+		filled := p.filledOnCancel
+		if filled == "" {
+			stored, _ := p.store.GetOrder(ctx, ref.ClientOrderID)
+			filled = stored.FilledQty.String()
+		}
+		p.store.settle(ref.ClientOrderID, domain.StatusCanceled, filled)
+	}
+	return nil
+}
+
+func restingOrder() domain.Record {
+	return domain.Record{
+		ClientOrderID: "old-1", BotID: "grid-1", VenueOrderID: "v-old", Instrument: testInstrument(),
+		Side: domain.Buy, Type: domain.Limit, Status: domain.StatusPartiallyFilled,
+		Price: decimal.RequireFromString("50000"), Qty: decimal.RequireFromString("1"),
+		FilledQty: decimal.RequireFromString("0.25"),
+	}
+}
+
+func newReplaceService(t *testing.T, placer *replacePlacer) (*Service, *replaceStore, *Metrics) {
+	t.Helper()
+	store := newReplaceStore(restingOrder())
+	placer.store = store
+	svc, _, m := newService(t, placer, nil, nil)
+	svc.commands, svc.events, svc.killSwitches = store, store, store
+	return svc, store, m
+}
+
+func TestReplaceAmendsNatively(t *testing.T) {
+	t.Parallel()
+	placer := &replacePlacer{}
+	svc, store, m := newReplaceService(t, placer)
+
+	result, err := svc.Replace(t.Context(), ReplaceRequest{
+		ClientOrderID: "old-1", Price: decimal.RequireFromString("49900"), Qty: decimal.RequireFromString("2"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ReplaceResult{Replaced: "old-1", ClientOrderID: "old-1", Mode: domain.ReplaceAmend, Status: domain.StatusPartiallyFilled}
+	if result != want {
+		t.Fatalf("result = %+v, want %+v", result, want)
+	}
+	if len(placer.amends) != 1 || placer.amends[0].Replaces != "old-1" || len(placer.cancels) != 0 || len(placer.submits) != 0 {
+		t.Fatalf("venue calls: amends %+v, cancels %d, submits %d", placer.amends, len(placer.cancels), len(placer.submits))
+	}
+	stored, _ := store.GetOrder(t.Context(), "old-1")
+	if !stored.Price.Equal(decimal.RequireFromString("49900")) || !stored.Qty.Equal(decimal.RequireFromString("2")) {
+		t.Fatalf("stored terms = %s @ %s", stored.Qty, stored.Price)
+	}
+	if len(store.links) != 1 || store.links[0].Old != "old-1" || store.links[0].New != "old-1" || store.links[0].Mode != domain.ReplaceAmend {
+		t.Fatalf("links = %+v", store.links)
+	}
+	if got := testutil.ToFloat64(m.replacements.WithLabelValues("bybit", "amend")); got != 1 {
+		t.Fatalf("amend replacements = %v", got)
+	}
+}
+
+func TestReplaceAmendUpRefusedForFunds(t *testing.T) {
+	t.Parallel()
+	placer := &replacePlacer{}
+	svc, store, _ := newReplaceService(t, placer)
+	reserver := &refuseFunds{}
+	svc.funds = reserver
+
+	_, err := svc.Replace(t.Context(), ReplaceRequest{
+		ClientOrderID: "old-1", Price: decimal.RequireFromString("50000"), Qty: decimal.RequireFromString("3"),
+	})
+	if !errors.Is(err, funds.ErrInsufficient) || reserver.calls != 1 {
+		t.Fatalf("err = %v after %d checks, want ErrInsufficient", err, reserver.calls)
+	}
+	if len(placer.amends) != 0 || len(placer.cancels) != 0 || len(store.links) != 0 {
+		t.Fatalf("venue calls: amends %d, cancels %d; links %+v", len(placer.amends), len(placer.cancels), store.links)
+	}
+	if stored, _ := store.GetOrder(t.Context(), "old-1"); !stored.Qty.Equal(decimal.RequireFromString("1")) {
+		t.Fatalf("stored qty = %s, want the old terms", stored.Qty)
+	}
+}
+
+func TestReplaceFallsBackToCancelPlace(t *testing.T) {
+	t.Parallel()
+	placer := &replacePlacer{amendErr: ports.ErrAmendUnsupported, settleCancels: true}
+	svc, store, m := newReplaceService(t, placer)
+	rr := ReplaceRequest{
+		ClientOrderID: "old-1", NewClientOrderID: "new-1",
+		Price: decimal.RequireFromString("49900"), Qty: decimal.RequireFromString("1"),
+	}
+
+	result, err := svc.Replace(t.Context(), rr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ReplaceResult{Replaced: "old-1", ClientOrderID: "new-1", Mode: domain.ReplaceCancelPlace, Status: domain.StatusOpen}
+	if result != want {
+		t.Fatalf("result = %+v, want %+v", result, want)
+	}
+	if len(placer.cancels) != 1 || placer.cancels[0].VenueOrderID != "v-old" || len(placer.submits) != 1 {
+		t.Fatalf("venue calls: cancels %+v, submits %d", placer.cancels, len(placer.submits))
+	}
+	// The old order's 0.25 fill counts against the new total of 1.
+	if sub := placer.submits[0]; sub.Replaces != "old-1" || sub.BotID != "grid-1" || !sub.Qty.Equal(decimal.RequireFromString("0.75")) {
+		t.Fatalf("replacement submit = %+v", sub)
+	}
+	if l := store.links; len(l) != 1 || l[0].Old != "old-1" || l[0].New != "new-1" || l[0].Mode != domain.ReplaceCancelPlace || !l[0].Qty.Equal(decimal.RequireFromString("0.75")) {
+		t.Fatalf("links = %+v", store.links)
+	}
+
+	again, err := svc.Replace(t.Context(), rr)
+	if err != nil || again != want || len(placer.submits) != 1 {
+		t.Fatalf("retry = %+v, %v, submits %d", again, err, len(placer.submits))
+	}
+	if got := testutil.ToFloat64(m.replacements.WithLabelValues("bybit", "cancel_replace")); got != 1 {
+		t.Fatalf("cancel-replace replacements = %v", got)
+	}
+}
+
+func TestReplaceUnsettledPlacesNothing(t *testing.T) {
+	t.Parallel()
+	placer := &replacePlacer{amendErr: ports.ErrAmendUnsupported}
+	svc, store, _ := newReplaceService(t, placer)
+	clk := svc.clk.(interface {
+		BlockUntilContext(context.Context, int) error
+		Advance(time.Duration)
+	})
+	rr := ReplaceRequest{
+		ClientOrderID: "old-1",
+		Price:         decimal.RequireFromString("49900"), Qty: decimal.RequireFromString("1"),
+	}
+
+	type outcome struct {
+		result ReplaceResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := svc.Replace(t.Context(), rr)
+		done <- outcome{result, err}
+	}()
+	if err := clk.BlockUntilContext(t.Context(), 1); err != nil {
+		t.Fatal(err)
+	}
+	clk.Advance(svc.replaceSettle)
+	out := <-done
+	if !errors.Is(out.err, ErrReplaceUnsettled) {
+		t.Fatalf("err = %v, want ErrReplaceUnsettled", out.err)
+	}
+	// The generated ID comes back with the error, so the caller can resume.
+	if out.result.ClientOrderID == "" || out.result.Replaced != "old-1" {
+		t.Fatalf("unsettled result = %+v", out.result)
+	}
+	if len(placer.cancels) != 1 || len(placer.submits) != 0 {
+		t.Fatalf("cancels %d, submits %d", len(placer.cancels), len(placer.submits))
+	}
+
+	// The cancel settles later; the request under the returned ID resumes
+	// the replace, and no other ID can.
+	store.settle("old-1", domain.StatusCanceled, "0.25")
+	other := rr
+	other.NewClientOrderID = "new-2"
+	if _, err := svc.Replace(t.Context(), other); !errors.Is(err, ErrIdentityMismatch) {
+		t.Fatalf("other ID = %v, want ErrIdentityMismatch", err)
+	}
+	rr.NewClientOrderID = out.result.ClientOrderID
+	result, err := svc.Replace(t.Context(), rr)
+	if err != nil || result.ClientOrderID != rr.NewClientOrderID || len(placer.submits) != 1 {
+		t.Fatalf("resumed = %+v, %v, submits %d", result, err, len(placer.submits))
+	}
+}
+
+func TestReplaceRefusals(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		mutate         func(*domain.Record)
+		newID          domain.ClientOrderID
+		halted         bool
+		qty            string
+		filledOnCancel string
+		want           error
+		cancels        int
+	}{
+		{name: "market order", mutate: func(r *domain.Record) { r.Type = domain.Market }, qty: "1", want: ErrNotReplaceable},
+		{name: "not acknowledged", mutate: func(r *domain.Record) { r.VenueOrderID, r.Status = "", domain.StatusPending }, qty: "1", want: ErrNotReplaceable},
+		{name: "zero qty", qty: "0", want: ErrNotReplaceable},
+		{name: "terminal without new ID", mutate: func(r *domain.Record) { r.Status = domain.StatusFilled }, qty: "1", want: ErrTerminal},
+		{name: "terminal with no replace pending", mutate: func(r *domain.Record) { r.Status = domain.StatusCanceled }, newID: "new-1", qty: "1", want: ErrTerminal},
+		{name: "halted", halted: true, qty: "1", want: ErrHalted},
+		{name: "already filled to the new qty", qty: "0.25", want: ErrReplaceFilled},
+		{name: "filled to the new qty while canceling", qty: "0.5", filledOnCancel: "0.5", want: ErrReplaceFilled, cancels: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			placer := &replacePlacer{amendErr: ports.ErrAmendUnsupported, settleCancels: true, filledOnCancel: tt.filledOnCancel}
+			svc, store, _ := newReplaceService(t, placer)
+			if tt.mutate != nil {
+				old := store.orders["old-1"]
+				tt.mutate(&old)
+				store.orders["old-1"] = old
+			}
+			if tt.halted {
+				if _, err := svc.Halt(t.Context(), "", "test"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			_, err := svc.Replace(t.Context(), ReplaceRequest{
+				ClientOrderID: "old-1", NewClientOrderID: tt.newID,
+				Price: decimal.RequireFromString("49900"), Qty: decimal.RequireFromString(tt.qty),
+			})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if len(placer.cancels) != tt.cancels || len(placer.submits) != 0 || len(placer.amends) != 0 {
+				t.Fatalf("venue calls: cancels %d, submits %d, amends %d", len(placer.cancels), len(placer.submits), len(placer.amends))
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	domain "github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// Conformer fits a new order to its venue's instrument rules before it is
+// persisted. It returns the request to submit, possibly rounded, or a
+// *domain.RuleViolation.
+type Conformer interface {
+	Conform(ctx context.Context, req domain.Request) (domain.Request, error)
+}
+
+// relistAfter spaces reloads outside the TTL: an instrument missing from
+// the cache triggers one early, so a fresh listing is picked up without
+// reloading on every order for a pair the venue lacks, and a failed reload
+// is not retried on every order either.
+const relistAfter = 10 * time.Second
+
+// RuleBook is a Conformer over the local instrument catalog, cached per
+// venue for a TTL.
+type RuleBook struct {
+	catalog ports.InstrumentCatalog
+	clk     clockwork.Clock
+	log     log.Logger
+	ttl     time.Duration
+	policy  domain.RulePolicy
+
+	mu       sync.Mutex
+	listings map[instrument.VenueID]*listing
+}
+
+// listing is one venue's cached catalog entries. Its mutex is held across
+// the load, so concurrent orders on a cold venue share one query.
+type listing struct {
+	mu          sync.Mutex
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	byKey       map[string]instrument.Instrument
+}
+
+// NewRuleBook builds the rule cache. ttl bounds how long a catalog sync
+// takes to reach placement; policy decides between rounding and rejecting.
+func NewRuleBook(catalog ports.InstrumentCatalog, clk clockwork.Clock, logger log.Logger, ttl time.Duration, policy domain.RulePolicy) *RuleBook {
+	return &RuleBook{
+		catalog:  catalog,
+		clk:      clk,
+		log:      log.Component(logger, "rules"),
+		ttl:      ttl,
+		policy:   policy,
+		listings: map[instrument.VenueID]*listing{},
+	}
+}
+
+// Conform resolves the instrument in the catalog, attaches its rules and
+// venue symbol, and applies the policy. An instrument the catalog lacks,
+// or that is not trading, is refused before anything is stored.
+func (b *RuleBook) Conform(ctx context.Context, req domain.Request) (domain.Request, error) {
+	listed, ok, err := b.lookup(ctx, req.Instrument)
+	if err != nil {
+		return req, err
+	}
+	if !ok {
+		return req, &domain.RuleViolation{
+			Rule:   domain.RuleUnknownInstrument,
+			Detail: fmt.Sprintf("%s is not in the %s catalog", req.Instrument.Pair(), req.Instrument.Venue),
+		}
+	}
+	if listed.Status != instrument.StatusTrading {
+		return req, &domain.RuleViolation{
+			Rule:   domain.RuleInstrumentStatus,
+			Detail: fmt.Sprintf("%s on %s is %s", req.Instrument.Pair(), req.Instrument.Venue, listed.Status),
+		}
+	}
+	req.Instrument.Rules = listed.Rules
+	if req.Instrument.VenueSymbol == "" {
+		req.Instrument.VenueSymbol = listed.VenueSymbol
+	}
+	return domain.Conform(req, b.policy)
+}
+
+func (b *RuleBook) lookup(ctx context.Context, inst instrument.Instrument) (instrument.Instrument, bool, error) {
+	b.mu.Lock()
+	l, ok := b.listings[inst.Venue]
+	if !ok {
+		l = &listing{}
+		b.listings[inst.Venue] = l
+	}
+	b.mu.Unlock()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	expired := b.clk.Since(l.fetchedAt) >= b.ttl && b.clk.Since(l.attemptedAt) >= relistAfter
+	if l.byKey == nil || expired {
+		if err := b.refresh(ctx, l, inst); err != nil {
+			return instrument.Instrument{}, false, err
+		}
+	}
+	listed, ok := l.byKey[inst.Key()]
+	if !ok && b.clk.Since(l.attemptedAt) >= relistAfter {
+		if err := b.refresh(ctx, l, inst); err != nil {
+			return instrument.Instrument{}, false, err
+		}
+		listed, ok = l.byKey[inst.Key()]
+	}
+	return listed, ok, nil
+}
+
+// CatalogRules returns the catalog's trading rules for inst, or none when
+// catalog is nil or does not list it. It reads the catalog on every call,
+// for callers that look up rules far less often than orders are placed.
+func CatalogRules(ctx context.Context, catalog ports.InstrumentCatalog, inst instrument.Instrument) (instrument.Rules, error) {
+	if catalog == nil {
+		return instrument.Rules{}, nil
+	}
+	listed, _, err := CatalogListing(ctx, catalog, inst)
+	return listed.Rules, err
+}
+
+// CatalogListing returns the catalog's entry for inst and whether it lists
+// it, reading the catalog like CatalogRules.
+func CatalogListing(ctx context.Context, catalog ports.InstrumentCatalog, inst instrument.Instrument) (instrument.Instrument, bool, error) {
+	listed, err := catalog.ListInstruments(ctx, inst.Venue)
+	if err != nil {
+		return instrument.Instrument{}, false, err
+	}
+	for _, candidate := range listed {
+		if candidate.Key() == inst.Key() {
+			return candidate, true, nil
+		}
+	}
+	return instrument.Instrument{}, false, nil
+}
+
+// refresh reloads the venue's catalog entries. A failure keeps the cached
+// entries in use, since rules change rarely and a stale tick beats no
+// check; only a venue never loaded fails the order.
+func (b *RuleBook) refresh(ctx context.Context, l *listing, inst instrument.Instrument) error {
+	l.attemptedAt = b.clk.Now()
+	listed, err := b.catalog.ListInstruments(ctx, inst.Venue)
+	if err == nil {
+		l.byKey = make(map[string]instrument.Instrument, len(listed))
+		for _, i := range listed {
+			l.byKey[i.Key()] = i
+		}
+		l.fetchedAt = b.clk.Now()
+		return nil
+	}
+	if l.byKey == nil {
+		return fmt.Errorf("instrument rules for %s: %w", inst.Venue, err)
+	}
+	b.log.Warn().Str("venue", string(inst.Venue)).Err(err).Time("fetched_at", l.fetchedAt).
+		Msg("instrument catalog read failed; using the cached rules")
+	return nil
+}
@@ -0,0 +1,148 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	domain "github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+type fakeCatalog struct {
+	mu     sync.Mutex
+	calls  int
+	err    error
+	status instrument.Status
+}
+
+func (f *fakeCatalog) ListInstruments(_ context.Context, venue instrument.VenueID) ([]instrument.Instrument, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	inst := testInstrument()
+	inst.Status = f.status
+	if inst.Status == "" {
+		inst.Status = instrument.StatusTrading
+	}
+	inst.Rules = instrument.Rules{
+		PriceIncrement: decimal.RequireFromString("0.1"),
+		QtyIncrement:   decimal.RequireFromString("0.001"),
+		MinQty:         decimal.RequireFromString("0.001"),
+		MinNotional:    decimal.NewFromInt(10),
+	}
+	return []instrument.Instrument{inst}, nil
+}
+
+func (f *fakeCatalog) fail(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+func TestPlaceConformsToRules(t *testing.T) {
+	t.Parallel()
+	offIncrement := func() domain.Request {
+		req := placeRequest()
+		req.Instrument.VenueSymbol = ""
+		req.Price, req.Qty = decimal.RequireFromString("50000.07"), decimal.RequireFromString("0.0105")
+		return req
+	}
+	t.Run("round policy submits the rounded order with the venue symbol", func(t *testing.T) {
+		placer, store := &fakePlacer{}, &fakeStore{}
+		svc, clk, m := newService(t, placer, store, nil)
+		svc.rules = NewRuleBook(&fakeCatalog{}, clk, log.Nop(), time.Hour, domain.RuleRound)
+		if _, err := svc.Place(t.Context(), offIncrement()); err != nil {
+			t.Fatal(err)
+		}
+		got := placer.submits[0]
+		if !got.Price.Equal(decimal.RequireFromString("50000")) || !got.Qty.Equal(decimal.RequireFromString("0.01")) ||
+			got.Instrument.VenueSymbol != "BTCUSDT" || !store.pending[0].Qty.Equal(got.Qty) {
+			t.Fatalf("submitted %s @ %s (%s), stored qty %s", got.Qty, got.Price, got.Instrument.VenueSymbol, store.pending[0].Qty)
+		}
+		if n := testutil.ToFloat64(m.rounded.WithLabelValues("bybit")); n != 1 {
+			t.Fatalf("rounded = %v, want 1", n)
+		}
+	})
+	t.Run("reject policy refuses before anything is stored", func(t *testing.T) {
+		placer, store := &fakePlacer{}, &fakeStore{}
+		svc, clk, m := newService(t, placer, store, nil)
+		svc.rules = NewRuleBook(&fakeCatalog{}, clk, log.Nop(), time.Hour, domain.RuleReject)
+		_, err := svc.Place(t.Context(), offIncrement())
+		var violation *domain.RuleViolation
+		if !errors.As(err, &violation) || violation.Rule != domain.RuleStepSize || len(store.pending) != 0 || len(placer.submits) != 0 {
+			t.Fatalf("err=%v pending=%d submits=%d", err, len(store.pending), len(placer.submits))
+		}
+		if n := testutil.ToFloat64(m.ruleViolations.WithLabelValues("bybit", string(domain.RuleStepSize))); n != 1 {
+			t.Fatalf("violations = %v, want 1", n)
+		}
+	})
+}
+
+func TestRuleBookCache(t *testing.T) {
+	t.Parallel()
+	venue := &fakeCatalog{}
+	clk := clockwork.NewFakeClock()
+	book := NewRuleBook(venue, clk, log.Nop(), time.Hour, domain.RuleReject)
+	req := placeRequest()
+	req.Qty = decimal.RequireFromString("0.01")
+
+	for range 2 {
+		if _, err := book.Conform(t.Context(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if venue.calls != 1 {
+		t.Fatalf("catalog reads = %d, want one within the TTL", venue.calls)
+	}
+
+	// A failed refresh keeps the stale rules in force.
+	venue.fail(ports.ErrVenueUnavailable)
+	clk.Advance(time.Hour)
+	req.Qty = decimal.RequireFromString("0.0001")
+	if _, err := book.Conform(t.Context(), req); !errors.As(err, new(*domain.RuleViolation)) {
+		t.Fatalf("Conform with stale rules = %v, want a violation", err)
+	}
+
+	if _, err := book.Conform(t.Context(), req); venue.calls != 2 {
+		t.Fatalf("reads = %d after a failed refresh, want no retry before relistAfter (err=%v)", venue.calls, err)
+	}
+
+	// A pair missing from the catalog is rechecked, then refused.
+	venue.fail(nil)
+	clk.Advance(relistAfter)
+	req.Instrument.Base = "DOGE"
+	var violation *domain.RuleViolation
+	if _, err := book.Conform(t.Context(), req); !errors.As(err, &violation) || violation.Rule != domain.RuleUnknownInstrument || venue.calls != 3 {
+		t.Fatalf("unknown pair: err=%v reads=%d", err, venue.calls)
+	}
+
+	// A venue never listed fails closed.
+	venue.fail(ports.ErrVenueUnavailable)
+	cold := NewRuleBook(venue, clk, log.Nop(), time.Hour, domain.RuleReject)
+	if _, err := cold.Conform(t.Context(), placeRequest()); !errors.Is(err, ports.ErrVenueUnavailable) {
+		t.Fatalf("cold venue = %v, want ErrVenueUnavailable", err)
+	}
+}
+
+func TestRuleBookRefusesHaltedInstrument(t *testing.T) {
+	t.Parallel()
+	book := NewRuleBook(&fakeCatalog{status: instrument.StatusHalted}, clockwork.NewFakeClock(), log.Nop(), time.Minute, domain.RuleReject)
+	req := placeRequest()
+	req.Instrument.VenueSymbol = ""
+	var violation *domain.RuleViolation
+	if _, err := book.Conform(t.Context(), req); !errors.As(err, &violation) || violation.Rule != domain.RuleInstrumentStatus {
+		t.Fatalf("Conform = %v, want an instrument_status violation", err)
+	}
+}
@@ -0,0 +1,139 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	domain "github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// ErrNoTriggerFeed reports a stop for a venue without native stops on an
+// instrument whose ticker the daemon does not poll: nothing would ever
+// trigger it.
+var ErrNoTriggerFeed = errors.New("no ticker feed to trigger the stop")
+
+// checkTerms runs the pure checks on req's terms, then refuses a stop
+// that would be held locally without a ticker to trigger it.
+func (s *Service) checkTerms(req domain.Request) error {
+	if err := domain.CheckExecution(req, s.clk.Now()); err != nil {
+		return err
+	}
+	if err := domain.CheckStop(req); err != nil {
+		return err
+	}
+	if s.holdsLocally(req) && !s.venues[req.Instrument.Venue].polls(req.Instrument) {
+		return fmt.Errorf("%w: %s on %s", ErrNoTriggerFeed, req.Instrument.Pair(), req.Instrument.Venue)
+	}
+	return nil
+}
+
+// holdsLocally reports whether req is a stop its venue cannot hold, which
+// the trigger engine holds instead.
+func (s *Service) holdsLocally(req domain.Request) bool {
+	if !req.Type.Stop() {
+		return false
+	}
+	stops, ok := s.venues[req.Instrument.Venue].Placer.(ports.StopPlacer)
+	return !ok || !stops.NativeStops()
+}
+
+func (v Venue) polls(inst instrument.Instrument) bool {
+	return slices.ContainsFunc(v.Tickers, func(t instrument.Instrument) bool {
+		return t.Base == inst.Base && t.Quote == inst.Quote
+	})
+}
+
+// FireStop places the child of a stop held locally and marks the stop
+// triggered once the child is stored. The child's ID was fixed when the
+// stop was stored, so a crash between the two steps is repaired by firing
+// again: Place finds the child and returns it unchanged. A stop that is no
+// longer armed reports its status and places nothing.
+//
+// A child refused for good, by the instrument rules or its own terms,
+// rejects the stop. Any other failure to store the child leaves the stop
+// armed for the next crossing tick; a child stored but not settled at the
+// venue still triggers the stop, and reconciliation settles the child.
+func (s *Service) FireStop(ctx context.Context, stopID domain.ClientOrderID, reason string) (domain.Status, error) {
+	s.stopMu.Lock()
+	defer s.stopMu.Unlock()
+	stop, err := s.commands.GetOrder(ctx, stopID)
+	if err != nil {
+		return "", err
+	}
+	if stop.Status != domain.StatusUntriggered || !stop.HeldLocally() {
+		return stop.Status, nil
+	}
+
+	child := domain.ChildOf(stop)
+	_, placeErr := s.Place(ctx, child)
+	ctx = context.WithoutCancel(ctx)
+	_, err = s.commands.GetOrder(ctx, child.ClientOrderID)
+	var violation *domain.RuleViolation
+	switch {
+	case err == nil:
+	case !errors.Is(err, ports.ErrNotFound):
+		return "", err
+	case errors.As(placeErr, &violation), errors.Is(placeErr, domain.ErrInvalidExecution),
+		errors.Is(placeErr, domain.ErrInvalidPostOnly):
+		if err := s.settleStop(ctx, stop, domain.StatusRejected, "child refused: "+placeErr.Error()); err != nil {
+			return "", err
+		}
+		return domain.StatusRejected, placeErr
+	default:
+		return domain.StatusUntriggered, placeErr
+	}
+	if err := s.settleStop(ctx, stop, domain.StatusTriggered, reason); err != nil {
+		return "", err
+	}
+	s.log.Info().Str("venue", string(stop.Instrument.Venue)).Str("client_order_id", string(stopID)).
+		Str("child", string(child.ClientOrderID)).Str("reason", reason).Msg("stop triggered")
+	return domain.StatusTriggered, placeErr
+}
+
+// cancelStop cancels a stop held locally before it fires. A stop whose
+// child is already stored has fired, whatever its row says: it settles as
+// triggered and the cancel reports ErrTerminal, leaving the child to be
+// canceled on its own.
+func (s *Service) cancelStop(ctx context.Context, stopID domain.ClientOrderID) (domain.Status, error) {
+	s.stopMu.Lock()
+	defer s.stopMu.Unlock()
+	stop, err := s.commands.GetOrder(ctx, stopID)
+	if err != nil {
+		return "", err
+	}
+	if stop.Status.Terminal() {
+		return "", fmt.Errorf("%w: %s is already %s", ErrTerminal, stopID, stop.Status)
+	}
+	_, err = s.commands.GetOrder(ctx, stop.Child)
+	switch {
+	case err == nil:
+		if err := s.settleStop(ctx, stop, domain.StatusTriggered, "child already placed"); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("%w: %s already triggered %s", ErrTerminal, stopID, stop.Child)
+	case !errors.Is(err, ports.ErrNotFound):
+		return "", err
+	}
+	if err := s.commands.MarkCancelRequested(ctx, stopID, s.clk.Now()); err != nil {
+		return "", err
+	}
+	if err := s.settleStop(ctx, stop, domain.StatusCanceled, "canceled before it triggered"); err != nil {
+		return "", err
+	}
+	return domain.StatusCanceled, nil
+}
+
+// settleStop moves a stop held locally to a final status. No venue knows
+// the stop, so the event is local.
+func (s *Service) settleStop(ctx context.Context, stop domain.Record, status domain.Status, reason string) error {
+	return s.apply(ctx, domain.SourceLocal, domain.Event{
+		Ref:    domain.Ref{Instrument: stop.Instrument, ClientOrderID: stop.ClientOrderID},
+		Status: status,
+		Reason: reason,
+		At:     s.clk.Now(),
+	})
+}
@@ -0,0 +1,202 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	domain "github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+func (s *replaceStore) CreateUntriggered(_ context.Context, req domain.Request, child domain.ClientOrderID) (bool, error) {
+	s.rmu.Lock()
+	defer s.rmu.Unlock()
+	if _, ok := s.orders[req.ClientOrderID]; ok {
+		return false, nil
+	}
+	s.orders[req.ClientOrderID] = domain.Record{
+		ClientOrderID: req.ClientOrderID, Child: child, BotID: req.BotID, Instrument: req.Instrument,
+		Side: req.Side, Type: req.Type, Price: req.Price, Qty: req.Qty, TriggerPrice: req.TriggerPrice,
+		TimeInForce: req.TimeInForce, Status: domain.StatusUntriggered,
+	}
+	return true, nil
+}
+
+// nativeStopPlacer is a venue that holds stops itself.
+type nativeStopPlacer struct{ fakePlacer }
+
+func (*nativeStopPlacer) NativeStops() bool { return true }
+
+func newStopService(t *testing.T, placer ports.OrderPlacer) (*Service, *replaceStore) {
+	t.Helper()
+	store := &replaceStore{fakeStore: &fakeStore{}, orders: map[domain.ClientOrderID]domain.Record{}}
+	svc, _, _ := newService(t, placer, nil, nil)
+	svc.commands, svc.events, svc.killSwitches = store, store, store
+	v := svc.venues["bybit"]
+	v.Tickers = []instrument.Instrument{testInstrument()}
+	svc.venues["bybit"] = v
+	return svc, store
+}
+
+func stopRequest() domain.Request {
+	return domain.Request{
+		ClientOrderID: "stop-1",
+		Instrument:    testInstrument(),
+		Side:          domain.Sell,
+		Type:          domain.StopMarket,
+		Qty:           decimal.RequireFromString("1"),
+		TriggerPrice:  decimal.RequireFromString("49000"),
+	}
+}
+
+func TestPlaceStop(t *testing.T) {
+	t.Parallel()
+	t.Run("held locally without native stops", func(t *testing.T) {
+		placer := &fakePlacer{}
+		svc, store := newStopService(t, placer)
+		for range 2 {
+			result, err := svc.Place(t.Context(), stopRequest())
+			if err != nil || result.Status != domain.StatusUntriggered || result.ClientOrderID != "stop-1" {
+				t.Fatalf("Place = %+v, %v", result, err)
+			}
+		}
+		stored, _ := store.GetOrder(t.Context(), "stop-1")
+		if !stored.HeldLocally() || !stored.TriggerPrice.Equal(decimal.RequireFromString("49000")) || len(placer.submits) != 0 {
+			t.Fatalf("stored = %+v, submits %d", stored, len(placer.submits))
+		}
+	})
+	t.Run("native stops go to the venue", func(t *testing.T) {
+		placer := &nativeStopPlacer{}
+		svc, store := newStopService(t, placer)
+		if _, err := svc.Place(t.Context(), stopRequest()); err != nil {
+			t.Fatal(err)
+		}
+		stored, _ := store.GetOrder(t.Context(), "stop-1")
+		if stored.HeldLocally() || len(placer.submits) != 1 || placer.submits[0].Type != domain.StopMarket {
+			t.Fatalf("stored = %+v, submits %+v", stored, placer.submits)
+		}
+	})
+	t.Run("refused without a ticker feed", func(t *testing.T) {
+		svc, _ := newStopService(t, &fakePlacer{})
+		req := stopRequest()
+		req.Instrument.Base, req.Instrument.VenueSymbol = "ETH", "ETHUSDT"
+		if _, err := svc.Place(t.Context(), req); !errors.Is(err, ErrNoTriggerFeed) {
+			t.Fatalf("err = %v, want ErrNoTriggerFeed", err)
+		}
+	})
+	t.Run("refused with bad terms", func(t *testing.T) {
+		svc, _ := newStopService(t, &fakePlacer{})
+		req := stopRequest()
+		req.TriggerPrice = decimal.Zero
+		if _, err := svc.Place(t.Context(), req); !errors.Is(err, domain.ErrInvalidStop) {
+			t.Fatalf("err = %v, want ErrInvalidStop", err)
+		}
+	})
+}
+
+func TestFireStop(t *testing.T) {
+	t.Parallel()
+	placer := &fakePlacer{}
+	svc, store := newStopService(t, placer)
+	if _, err := svc.Place(t.Context(), stopRequest()); err != nil {
+		t.Fatal(err)
+	}
+	stop, _ := store.GetOrder(t.Context(), "stop-1")
+
+	status, err := svc.FireStop(t.Context(), "stop-1", "crossed")
+	if err != nil || status != domain.StatusTriggered {
+		t.Fatalf("FireStop = %s, %v", status, err)
+	}
+	if len(placer.submits) != 1 || placer.submits[0].ClientOrderID != stop.Child || placer.submits[0].Type != domain.Market {
+		t.Fatalf("submits = %+v", placer.submits)
+	}
+	if child, _ := store.GetOrder(t.Context(), stop.Child); child.Status != domain.StatusOpen {
+		t.Fatalf("child = %+v", child)
+	}
+
+	status, err = svc.FireStop(t.Context(), "stop-1", "crossed again")
+	if err != nil || status != domain.StatusTriggered || len(placer.submits) != 1 {
+		t.Fatalf("second FireStop = %s, %v, submits %d", status, err, len(placer.submits))
+	}
+}
+
+func TestFireStopResumesAfterCrash(t *testing.T) {
+	t.Parallel()
+	placer := &fakePlacer{}
+	svc, store := newStopService(t, placer)
+	if _, err := svc.Place(t.Context(), stopRequest()); err != nil {
+		t.Fatal(err)
+	}
+	stop, _ := store.GetOrder(t.Context(), "stop-1")
+	// The child was placed, then the process died before the stop was
+	// marked triggered.
+	if _, err := svc.Place(t.Context(), domain.ChildOf(stop)); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := svc.FireStop(t.Context(), "stop-1", "resumed")
+	if err != nil || status != domain.StatusTriggered || len(placer.submits) != 1 {
+		t.Fatalf("FireStop = %s, %v, submits %d", status, err, len(placer.submits))
+	}
+}
+
+func TestFireStopWhileHaltedStaysArmed(t *testing.T) {
+	t.Parallel()
+	placer := &fakePlacer{}
+	svc, store := newStopService(t, placer)
+	if _, err := svc.Place(t.Context(), stopRequest()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Halt(t.Context(), "bybit", "drill"); err != nil {
+		t.Fatal(err)
+	}
+	status, err := svc.FireStop(t.Context(), "stop-1", "crossed")
+	if !errors.Is(err, ErrHalted) || status != domain.StatusUntriggered || len(placer.submits) != 0 {
+		t.Fatalf("FireStop = %s, %v, submits %d", status, err, len(placer.submits))
+	}
+	if stored, _ := store.GetOrder(t.Context(), "stop-1"); stored.Status != domain.StatusUntriggered {
+		t.Fatalf("stop = %s", stored.Status)
+	}
+}
+
+func TestCancelStop(t *testing.T) {
+	t.Parallel()
+	t.Run("before it triggers", func(t *testing.T) {
+		placer := &fakePlacer{}
+		svc, store := newStopService(t, placer)
+		if _, err := svc.Place(t.Context(), stopRequest()); err != nil {
+			t.Fatal(err)
+		}
+		status, err := svc.Cancel(t.Context(), "stop-1")
+		if err != nil || status != domain.StatusCanceled || len(placer.cancels) != 0 {
+			t.Fatalf("Cancel = %s, %v, venue cancels %d", status, err, len(placer.cancels))
+		}
+		if stored, _ := store.GetOrder(t.Context(), "stop-1"); stored.Status != domain.StatusCanceled {
+			t.Fatalf("stop = %s", stored.Status)
+		}
+		if status, _ := svc.FireStop(t.Context(), "stop-1", "crossed"); status != domain.StatusCanceled || len(placer.submits) != 0 {
+			t.Fatalf("FireStop after cancel = %s, submits %d", status, len(placer.submits))
+		}
+	})
+	t.Run("after its child was placed", func(t *testing.T) {
+		placer := &fakePlacer{}
+		svc, store := newStopService(t, placer)
+		if _, err := svc.Place(t.Context(), stopRequest()); err != nil {
+			t.Fatal(err)
+		}
+		stop, _ := store.GetOrder(t.Context(), "stop-1")
+		if _, err := svc.Place(t.Context(), domain.ChildOf(stop)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := svc.Cancel(t.Context(), "stop-1"); !errors.Is(err, ErrTerminal) {
+			t.Fatalf("Cancel = %v, want ErrTerminal", err)
+		}
+		if stored, _ := store.GetOrder(t.Context(), "stop-1"); stored.Status != domain.StatusTriggered {
+			t.Fatalf("stop = %s", stored.Status)
+		}
+	})
+}
@@ -7,6 +7,7 @@ package outbox
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -18,14 +19,18 @@ import (
 	"github.com/romanornr/delta-works/internal/ports"
 )
 
-// retention is how long published rows are kept for debugging before the
-// periodic cleanup deletes them.
+// retention is how long published rows are kept, for debugging and for
+// event streams resuming from a cursor, before the periodic cleanup
+// deletes them.
 const retention = 7 * 24 * time.Hour
 
 // cleanupInterval spaces the retention deletes; retention is days, so
 // hourly is more than fine-grained enough.
 const cleanupInterval = time.Hour
 
+// errPublish marks a bus that refused a row, as opposed to a store failure.
+var errPublish = errors.New("publish")
+
 // Service is the single relay goroutine: poll, publish, mark, repeat.
 type Service struct {
 	store    ports.OutboxStore
@@ -90,19 +95,31 @@ func (s *Service) Run(ctx context.Context) error {
 
 // drain publishes batches until the backlog is smaller than one batch, so
 // a burst clears at publish speed instead of one batch per poll interval.
+// A bus that refuses a row, a NATS server that is down, leaves the batch
+// unpublished for the next tick rather than stopping the relay: the rows
+// are safe in Postgres and the backlog gauges show the stall.
 func (s *Service) drain(ctx context.Context) error {
 	for {
 		n, err := s.store.PublishPending(ctx, s.batch, func(m events.OutboxMessage) error {
-			return s.bus.Publish(ctx, bus.Event{
-				Subject: m.Subject,
-				At:      m.CreatedAt,
-				Payload: json.RawMessage(m.Payload),
-			})
+			if err := s.bus.Publish(ctx, bus.Event{
+				Subject:    m.Subject,
+				At:         m.CreatedAt,
+				Payload:    json.RawMessage(m.Payload),
+				OutboxID:   m.ID,
+				PublishSeq: m.PublishSeq,
+			}); err != nil {
+				return fmt.Errorf("%w: %w", errPublish, err)
+			}
+			return nil
 		})
 		if err != nil {
 			if ctx.Err() != nil {
 				return nil
 			}
+			if errors.Is(err, errPublish) {
+				s.log.Warn().Err(err).Msg("outbox rows left pending for the next tick")
+				break
+			}
 			return fmt.Errorf("outbox store: %w", err)
 		}
 		s.metrics.observePublished(n)
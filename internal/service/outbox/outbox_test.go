@@ -278,3 +278,70 @@ func TestStoreFailureStopsService(t *testing.T) {
 		t.Fatal("Run did not stop on store failure")
 	}
 }
+
+// refusingBus fails every publish until told otherwise, like a NATS server
+// that is down.
+type refusingBus struct {
+	bus.Bus
+	mu     sync.Mutex
+	refuse bool
+}
+
+func (b *refusingBus) Publish(ctx context.Context, e bus.Event) error {
+	b.mu.Lock()
+	refuse := b.refuse
+	b.mu.Unlock()
+	if refuse {
+		return errors.New("nats: no responders available for request")
+	}
+	return b.Bus.Publish(ctx, e)
+}
+
+func TestPublishFailureKeepsRowsPending(t *testing.T) {
+	t.Parallel()
+
+	inproc := bus.NewInProc()
+	t.Cleanup(inproc.Close)
+	delivered := make(chan bus.Event, 10)
+	if _, err := inproc.Subscribe("order.", func(_ context.Context, e bus.Event) { delivered <- e }); err != nil {
+		t.Fatal(err)
+	}
+	b := &refusingBus{Bus: inproc, refuse: true}
+	store := newFakeStore()
+	row := msg(1)
+	row.ID = 7
+	store.add(row)
+	m, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	clk := clockwork.NewFakeClockAt(time.Date(2026, 7, 5, 12, 0, 0, 0, time.UTC))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- New(store, b, clk, log.Nop(), testInterval, 100, m).Run(ctx) }()
+
+	waitCall(t, store.calls, "stats")
+	store.mu.Lock()
+	pending := len(store.pending)
+	store.mu.Unlock()
+	if pending != 1 {
+		t.Fatalf("pending rows = %d after a refused publish, want 1", pending)
+	}
+
+	b.mu.Lock()
+	b.refuse = false
+	b.mu.Unlock()
+	clk.Advance(testInterval)
+	select {
+	case e := <-delivered:
+		if e.OutboxID != 7 {
+			t.Fatalf("OutboxID = %d, want 7", e.OutboxID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("row not published after the bus recovered")
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Run returned %v, want nil", err)
+	}
+}
@@ -155,6 +155,10 @@ func (s *Service) pass(ctx context.Context, v *venueLoop) error {
 	}
 	localActive := make(map[domain.ClientOrderID]struct{}, len(local))
 	for _, lo := range local {
+		if lo.HeldLocally() {
+			// The trigger engine holds it; the venue never will.
+			continue
+		}
 		localActive[lo.ClientOrderID] = struct{}{}
 		if err := s.reconcileLocal(ctx, v.Venue, lo, venueByClient); err != nil {
 			return err
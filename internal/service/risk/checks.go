@@ -0,0 +1,195 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/exchange"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+const (
+	// referenceMaxAge lets a burst of orders, such as a grid laying its
+	// ladder, share one ticker fetch per pair.
+	referenceMaxAge = 5 * time.Second
+	// referenceTimeout bounds the ticker fetch so a stalled venue rejects
+	// the order instead of holding the caller.
+	referenceTimeout = 3 * time.Second
+)
+
+var bpsDenominator = decimal.NewFromInt(10_000)
+
+// maxNotional caps qty*price per quote currency. Market orders are priced
+// at the reference, stop-market orders at their trigger.
+type maxNotional struct {
+	max  map[money.Currency]decimal.Decimal
+	refs *references
+}
+
+func (c maxNotional) Check(ctx context.Context, req order.Request) error {
+	limit, ok := c.max[req.Instrument.Quote]
+	if !ok {
+		return nil
+	}
+	price := req.Price
+	switch req.Type {
+	case order.Market:
+		var err error
+		if price, err = c.refs.price(ctx, req.Instrument); err != nil {
+			return err
+		}
+	case order.StopMarket:
+		price = req.TriggerPrice
+	}
+	if notional := req.Qty.Mul(price); notional.GreaterThan(limit) {
+		return reject(ReasonMaxNotional, "notional %s %s exceeds the limit of %s", notional, req.Instrument.Quote, limit)
+	}
+	return nil
+}
+
+// priceBand refuses limit orders that cross the reference by more than
+// the band: buys above it, sells below it. Orders resting away from the
+// market are not a fat-finger risk, so a wide grid is unaffected.
+type priceBand struct {
+	bps  decimal.Decimal
+	refs *references
+}
+
+func (c priceBand) Check(ctx context.Context, req order.Request) error {
+	if req.Type != order.Limit {
+		return nil
+	}
+	ref, err := c.refs.price(ctx, req.Instrument)
+	if err != nil {
+		return err
+	}
+	band := ref.Mul(c.bps).Div(bpsDenominator)
+	switch {
+	case req.Side == order.Buy && req.Price.GreaterThan(ref.Add(band)):
+		return reject(ReasonPriceBand, "buy at %s is more than %s bps above the reference %s", req.Price, c.bps, ref)
+	case req.Side == order.Sell && req.Price.LessThan(ref.Sub(band)):
+		return reject(ReasonPriceBand, "sell at %s is more than %s bps below the reference %s", req.Price, c.bps, ref)
+	}
+	return nil
+}
+
+// maxOpenOrders caps non-terminal orders per bot across venues and per
+// venue across bots. A replacement takes the place of an order already
+// counted, so it never adds one. The count is only sound because the order
+// service runs it under the admit locks of the order's venue and bot,
+// together with the insert it admits.
+type maxOpenOrders struct {
+	orders           ports.ActiveOrderCounter
+	perBot, perVenue int
+}
+
+func (maxOpenOrders) admit() {}
+
+func (c maxOpenOrders) Check(ctx context.Context, req order.Request) error {
+	if req.Replaces != "" {
+		return nil
+	}
+	if c.perBot > 0 {
+		n, err := c.orders.CountActiveOrders(ctx, "", req.BotID)
+		if err != nil {
+			return fmt.Errorf("risk: %w", err)
+		}
+		if n >= c.perBot {
+			return reject(ReasonMaxOpenOrders, "bot %s has %d open orders, the limit is %d", req.BotID, n, c.perBot)
+		}
+	}
+	if c.perVenue > 0 {
+		n, err := c.orders.CountActiveOrders(ctx, req.Instrument.Venue, "")
+		if err != nil {
+			return fmt.Errorf("risk: %w", err)
+		}
+		if n >= c.perVenue {
+			return reject(ReasonMaxOpenOrders, "venue %s has %d open orders, the limit is %d", req.Instrument.Venue, n, c.perVenue)
+		}
+	}
+	return nil
+}
+
+// positionLimit caps a bot's held base quantity, priced or not, plus the
+// buy being placed. Sells only shrink inventory and always pass. Resting
+// buys are not counted; the open-order limit bounds them.
+type positionLimit struct {
+	held ports.HeldQtyReader
+	max  map[PositionKey]decimal.Decimal
+}
+
+func (c positionLimit) Check(ctx context.Context, req order.Request) error {
+	if req.Side != order.Buy {
+		return nil
+	}
+	key := PositionKey{BotID: req.BotID, Venue: req.Instrument.Venue, Base: req.Instrument.Base, Quote: req.Instrument.Quote}
+	limit, ok := c.max[key]
+	if !ok {
+		return nil
+	}
+	held, err := c.held.HeldQty(ctx, req.BotID, req.Instrument)
+	if err != nil {
+		return fmt.Errorf("risk: %w", err)
+	}
+	if after := held.Add(req.Qty); after.GreaterThan(limit) {
+		return reject(ReasonPositionLimit, "bot %s would hold %s %s, the limit is %s", req.BotID, after, req.Instrument.Base, limit)
+	}
+	return nil
+}
+
+// references quotes the market price orders are checked against: the
+// ticker midpoint, or the last trade on a one-sided book.
+type references struct {
+	registry exchange.Registry
+	clk      clockwork.Clock
+
+	mu    sync.Mutex
+	cache map[string]reference
+}
+
+type reference struct {
+	price     decimal.Decimal
+	fetchedAt time.Time
+}
+
+func newReferences(registry exchange.Registry, clk clockwork.Clock) *references {
+	return &references{registry: registry, clk: clk, cache: map[string]reference{}}
+}
+
+func (r *references) price(ctx context.Context, inst instrument.Instrument) (decimal.Decimal, error) {
+	key := inst.Key()
+	r.mu.Lock()
+	cached, ok := r.cache[key]
+	r.mu.Unlock()
+	if ok && r.clk.Since(cached.fetchedAt) < referenceMaxAge {
+		return cached.price, nil
+	}
+
+	ex, err := r.registry.Get(inst.Venue)
+	if err != nil {
+		return decimal.Decimal{}, reject(ReasonNoReference, "%v", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, referenceTimeout)
+	defer cancel()
+	t, err := ex.Ticker(ctx, inst)
+	if err != nil {
+		return decimal.Decimal{}, reject(ReasonNoReference, "ticker %s: %v", inst.Pair(), err)
+	}
+	price, ok := t.Price(marketdata.PriceMid)
+	if !ok {
+		return decimal.Decimal{}, reject(ReasonNoReference, "ticker %s has no price", inst.Pair())
+	}
+	r.mu.Lock()
+	r.cache[key] = reference{price: price, fetchedAt: r.clk.Now()}
+	r.mu.Unlock()
+	return price, nil
+}
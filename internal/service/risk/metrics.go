@@ -0,0 +1,30 @@
+package risk
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+)
+
+// Metrics holds the chain's Prometheus instruments.
+type Metrics struct {
+	rejections *prometheus.CounterVec
+}
+
+// NewMetrics registers the chain metrics on the given registry.
+func NewMetrics(reg *prometheus.Registry) (*Metrics, error) {
+	m := &Metrics{
+		rejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "risk_rejections_total",
+			Help: "Orders refused by a pre-trade check.",
+		}, []string{"venue", "reason"}),
+	}
+	if err := reg.Register(m.rejections); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Metrics) observeRejection(venue instrument.VenueID, reason Reason) {
+	m.rejections.WithLabelValues(string(venue), string(reason)).Inc()
+}
@@ -0,0 +1,158 @@
+// Package risk vets new orders before they are persisted or sent: a chain
+// of pre-trade checks where the first rejection wins. Checks read the
+// order book, the ledger and live tickers; they never write.
+package risk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/exchange"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// Reason is the machine-readable cause of a rejection.
+type Reason string
+
+// Rejection reasons.
+const (
+	ReasonMaxNotional   Reason = "max_notional"
+	ReasonMaxOpenOrders Reason = "max_open_orders"
+	ReasonPriceBand     Reason = "price_band"
+	ReasonPositionLimit Reason = "position_limit"
+	// ReasonNoReference fails closed: a check that needs the market price
+	// rejects when the venue cannot quote one.
+	ReasonNoReference Reason = "no_reference_price"
+)
+
+// Rejection is an order refused by a pre-trade check. Detail is safe to
+// show to the operator.
+type Rejection struct {
+	Reason Reason
+	Detail string
+}
+
+func (r *Rejection) Error() string {
+	return fmt.Sprintf("pre-trade check %s: %s", r.Reason, r.Detail)
+}
+
+func reject(reason Reason, format string, args ...any) error {
+	return &Rejection{Reason: reason, Detail: fmt.Sprintf(format, args...)}
+}
+
+// Check is one pre-trade rule. It returns a *Rejection when the order must
+// not be placed, and any other error when it could not decide.
+type Check interface {
+	Check(ctx context.Context, req order.Request) error
+}
+
+// admitCheck marks a Check that counts stored orders, whose verdict the
+// insert it admits changes.
+type admitCheck interface {
+	Check
+	admit()
+}
+
+// Chain runs checks in order and stops at the first error. Checks that
+// count stored orders run from CheckAdmit, every other one from Check.
+type Chain struct {
+	checks  []Check
+	admits  []Check
+	log     log.Logger
+	metrics *Metrics
+}
+
+// New builds a chain. Metrics must not be nil.
+func New(logger log.Logger, metrics *Metrics, checks ...Check) *Chain {
+	c := &Chain{log: log.Component(logger, "risk"), metrics: metrics}
+	for _, check := range checks {
+		if _, ok := check.(admitCheck); ok {
+			c.admits = append(c.admits, check)
+		} else {
+			c.checks = append(c.checks, check)
+		}
+	}
+	return c
+}
+
+// Check implements the order service's pre-trade hook: the checks that
+// read the order, the market and the ledger. It may call the venue, so the
+// order service runs it before taking its admit lock.
+func (c *Chain) Check(ctx context.Context, req order.Request) error {
+	return c.run(ctx, req, c.checks)
+}
+
+// CheckAdmit runs the checks that count stored orders. The order service
+// runs it under its admit lock, right before the insert, so two orders
+// cannot both pass a count before either is stored.
+func (c *Chain) CheckAdmit(ctx context.Context, req order.Request) error {
+	return c.run(ctx, req, c.admits)
+}
+
+func (c *Chain) run(ctx context.Context, req order.Request, checks []Check) error {
+	for _, check := range checks {
+		err := check.Check(ctx, req)
+		if err == nil {
+			continue
+		}
+		var rejection *Rejection
+		if errors.As(err, &rejection) {
+			c.metrics.observeRejection(req.Instrument.Venue, rejection.Reason)
+			c.log.Warn().Str("venue", string(req.Instrument.Venue)).Str("bot", req.BotID).
+				Str("pair", req.Instrument.Pair()).Str("reason", string(rejection.Reason)).
+				Str("detail", rejection.Detail).Msg("order rejected before placement")
+		}
+		return err
+	}
+	return nil
+}
+
+// PositionKey names one bot's inventory of a spot pair on a venue.
+type PositionKey struct {
+	BotID       string
+	Venue       instrument.VenueID
+	Base, Quote money.Currency
+}
+
+// Limits are the configured pre-trade limits. A zero or absent limit is
+// not enforced.
+type Limits struct {
+	// MaxNotional caps one order's qty*price, per quote currency.
+	MaxNotional map[money.Currency]decimal.Decimal
+	// MaxOpenOrdersPerBot and MaxOpenOrdersPerVenue cap non-terminal orders.
+	MaxOpenOrdersPerBot   int
+	MaxOpenOrdersPerVenue int
+	// PriceBandBps caps how far a limit order may cross the reference
+	// price, in basis points.
+	PriceBandBps int64
+	// Positions caps the base quantity a bot may hold after a buy fills.
+	Positions map[PositionKey]decimal.Decimal
+}
+
+// Standard builds the built-in checks for the configured limits: the
+// order's own numbers first, store reads last.
+func Standard(limits Limits, orders ports.ActiveOrderCounter, held ports.HeldQtyReader, registry exchange.Registry, clk clockwork.Clock) []Check {
+	refs := newReferences(registry, clk)
+	var checks []Check
+	if len(limits.MaxNotional) > 0 {
+		checks = append(checks, maxNotional{max: limits.MaxNotional, refs: refs})
+	}
+	if limits.PriceBandBps > 0 {
+		checks = append(checks, priceBand{bps: decimal.NewFromInt(limits.PriceBandBps), refs: refs})
+	}
+	if limits.MaxOpenOrdersPerBot > 0 || limits.MaxOpenOrdersPerVenue > 0 {
+		checks = append(checks, maxOpenOrders{orders: orders, perBot: limits.MaxOpenOrdersPerBot, perVenue: limits.MaxOpenOrdersPerVenue})
+	}
+	if len(limits.Positions) > 0 {
+		checks = append(checks, positionLimit{held: held, max: limits.Positions})
+	}
+	return checks
+}
@@ -0,0 +1,219 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/ledger"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/exchange"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+type fakeExchange struct {
+	calls int
+	last  decimal.Decimal
+	err   error
+}
+
+func (f *fakeExchange) ID() instrument.VenueID { return "bybit" }
+
+func (f *fakeExchange) Ticker(_ context.Context, inst instrument.Instrument) (marketdata.Ticker, error) {
+	f.calls++
+	return marketdata.Ticker{Instrument: inst, Last: f.last}, f.err
+}
+
+func (f *fakeExchange) Instruments(context.Context, instrument.Type) ([]instrument.Instrument, error) {
+	return nil, nil
+}
+
+func (f *fakeExchange) Balances(context.Context, account.Type) ([]account.Balance, error) {
+	return nil, nil
+}
+
+type fakeOrders struct{ byBot, byVenue int }
+
+func (f fakeOrders) CountActiveOrders(_ context.Context, venue instrument.VenueID, botID string) (int, error) {
+	if venue != "" {
+		return f.byVenue, nil
+	}
+	return f.byBot, nil
+}
+
+type fakeLots []ledger.Lot
+
+// HeldQty sums like the store: every open lot of the inventory, priced or
+// not.
+func (f fakeLots) HeldQty(_ context.Context, botID string, inst instrument.Instrument) (decimal.Decimal, error) {
+	held := decimal.Zero
+	for _, lot := range f {
+		if lot.BotID == botID && lot.Venue == inst.Venue && lot.Base == inst.Base && lot.Quote == inst.Quote {
+			held = held.Add(lot.RemainingQty)
+		}
+	}
+	return held, nil
+}
+
+func d(s string) decimal.Decimal { return decimal.RequireFromString(s) }
+
+func request(side order.Side, kind order.Type, qty, price string) order.Request {
+	req := order.Request{
+		BotID:      "grid",
+		Instrument: instrument.Instrument{Venue: "bybit", Type: instrument.TypeSpot, Base: "BTC", Quote: "USDT"},
+		Side:       side, Type: kind, Qty: d(qty),
+	}
+	if price != "" {
+		req.Price = d(price)
+	}
+	return req
+}
+
+func replacement(req order.Request) order.Request {
+	req.Replaces = "old-1"
+	return req
+}
+
+func stopMarket(req order.Request, trigger string) order.Request {
+	req.TriggerPrice = d(trigger)
+	return req
+}
+
+func TestStandardChecks(t *testing.T) {
+	limits := Limits{
+		MaxNotional:           map[money.Currency]decimal.Decimal{"USDT": d("10000")},
+		MaxOpenOrdersPerBot:   3,
+		MaxOpenOrdersPerVenue: 5,
+		PriceBandBps:          500,
+		Positions: map[PositionKey]decimal.Decimal{
+			{BotID: "grid", Venue: "bybit", Base: "BTC", Quote: "USDT"}: d("0.3"),
+		},
+	}
+	held := fakeLots{
+		{BotID: "grid", Venue: "bybit", Base: "BTC", Quote: "USDT", RemainingQty: d("0.1"), CostPrice: d("50000")},
+		{BotID: "grid", Venue: "bybit", Base: "BTC", Quote: "USDT", RemainingQty: d("0.05")},
+		{BotID: "other", Venue: "bybit", Base: "BTC", Quote: "USDT", RemainingQty: d("5"), CostPrice: d("50000")},
+	}
+	tests := []struct {
+		name   string
+		req    order.Request
+		orders fakeOrders
+		venue  *fakeExchange
+		want   Reason
+	}{
+		{"within every limit", request(order.Buy, order.Limit, "0.1", "50000"), fakeOrders{}, nil, ""},
+		{"limit notional", request(order.Buy, order.Limit, "0.3", "50000"), fakeOrders{}, nil, ReasonMaxNotional},
+		{"market notional at the reference", request(order.Sell, order.Market, "0.25", ""), fakeOrders{}, nil, ReasonMaxNotional},
+		{"stop-market notional at the trigger", stopMarket(request(order.Sell, order.StopMarket, "0.25", ""), "41000"), fakeOrders{}, nil, ReasonMaxNotional},
+		{"stop-market under the limit at the trigger", stopMarket(request(order.Sell, order.StopMarket, "0.24", ""), "41000"), fakeOrders{}, nil, ""},
+		{"buy through the band", request(order.Buy, order.Limit, "0.01", "52501"), fakeOrders{}, nil, ReasonPriceBand},
+		{"sell through the band", request(order.Sell, order.Limit, "0.01", "47499"), fakeOrders{}, nil, ReasonPriceBand},
+		{"resting buy far below", request(order.Buy, order.Limit, "0.01", "30000"), fakeOrders{}, nil, ""},
+		{"bot open orders", request(order.Buy, order.Limit, "0.01", "50000"), fakeOrders{byBot: 3}, nil, ReasonMaxOpenOrders},
+		{"venue open orders", request(order.Buy, order.Limit, "0.01", "50000"), fakeOrders{byVenue: 5}, nil, ReasonMaxOpenOrders},
+		{"replacement at the open-order limit", replacement(request(order.Buy, order.Limit, "0.01", "50000")), fakeOrders{byBot: 3, byVenue: 5}, nil, ""},
+		{"position counts unpriced lots", request(order.Buy, order.Limit, "0.16", "50000"), fakeOrders{}, nil, ReasonPositionLimit},
+		{"sells pass the position limit", request(order.Sell, order.Limit, "0.16", "50000"), fakeOrders{}, nil, ""},
+		{"no reference fails closed", request(order.Buy, order.Limit, "0.01", "50000"), fakeOrders{}, &fakeExchange{err: errors.New("down")}, ReasonNoReference},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			venue := tt.venue
+			if venue == nil {
+				venue = &fakeExchange{last: d("50000")}
+			}
+			m, err := NewMetrics(prometheus.NewRegistry())
+			if err != nil {
+				t.Fatal(err)
+			}
+			registry := exchange.NewRegistry([]ports.Exchange{venue})
+			chain := New(log.Nop(), m, Standard(limits, tt.orders, held, registry, clockwork.NewFakeClock())...)
+			err = chain.Check(t.Context(), tt.req)
+			if err == nil {
+				err = chain.CheckAdmit(t.Context(), tt.req)
+			}
+			var rejection *Rejection
+			switch {
+			case tt.want == "" && err != nil:
+				t.Fatalf("Check = %v, want pass", err)
+			case tt.want != "" && (!errors.As(err, &rejection) || rejection.Reason != tt.want):
+				t.Fatalf("Check = %v, want %s", err, tt.want)
+			case tt.want != "":
+				if n := testutil.ToFloat64(m.rejections.WithLabelValues("bybit", string(tt.want))); n != 1 {
+					t.Fatalf("rejections = %v, want 1", n)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenOrderLimitRunsOnAdmit(t *testing.T) {
+	m, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	venue := &fakeExchange{last: d("50000")}
+	limits := Limits{MaxOpenOrdersPerBot: 1, PriceBandBps: 500}
+	chain := New(log.Nop(), m, Standard(limits, fakeOrders{byBot: 1}, fakeLots{}, exchange.NewRegistry([]ports.Exchange{venue}), clockwork.NewFakeClock())...)
+	req := request(order.Buy, order.Limit, "0.01", "50000")
+	if err := chain.Check(t.Context(), req); err != nil {
+		t.Fatalf("Check = %v, want the count left to CheckAdmit", err)
+	}
+	calls := venue.calls
+	var rejection *Rejection
+	if err := chain.CheckAdmit(t.Context(), req); !errors.As(err, &rejection) || rejection.Reason != ReasonMaxOpenOrders {
+		t.Fatalf("CheckAdmit = %v, want %s", err, ReasonMaxOpenOrders)
+	}
+	if venue.calls != calls {
+		t.Fatal("CheckAdmit called the venue")
+	}
+}
+
+func TestReferenceCache(t *testing.T) {
+	clk := clockwork.NewFakeClock()
+	venue := &fakeExchange{last: d("100")}
+	refs := newReferences(exchange.NewRegistry([]ports.Exchange{venue}), clk)
+	inst := request(order.Buy, order.Limit, "1", "100").Instrument
+	for range 3 {
+		if _, err := refs.price(t.Context(), inst); err != nil {
+			t.Fatal(err)
+		}
+	}
+	clk.Advance(referenceMaxAge + time.Second)
+	if _, err := refs.price(t.Context(), inst); err != nil {
+		t.Fatal(err)
+	}
+	if venue.calls != 2 {
+		t.Fatalf("ticker calls = %d, want one per freshness window", venue.calls)
+	}
+}
+
+func TestStoreErrorIsNotARejection(t *testing.T) {
+	m, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := New(log.Nop(), m, maxOpenOrders{orders: failingOrders{}, perBot: 1})
+	err = chain.CheckAdmit(t.Context(), request(order.Buy, order.Limit, "1", "1"))
+	var rejection *Rejection
+	if err == nil || errors.As(err, &rejection) {
+		t.Fatalf("Check = %v, want a plain store error", err)
+	}
+}
+
+type failingOrders struct{}
+
+func (failingOrders) CountActiveOrders(context.Context, instrument.VenueID, string) (int, error) {
+	return 0, errors.New("postgres down")
+}
@@ -0,0 +1,14 @@
+// Package runloop holds what the services' Run loops share.
+package runloop
+
+import "context"
+
+// Err is the error a Run loop stops with after a step failed: nil once
+// ctx is done, since a step cut short by shutdown is not a fault, and err
+// otherwise.
+func Err(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,26 @@
+package sink
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Metrics holds the sink service's Prometheus instruments.
+type Metrics struct {
+	deliveries *prometheus.CounterVec
+}
+
+// NewMetrics registers the sink metrics on the given registry.
+func NewMetrics(reg *prometheus.Registry) (*Metrics, error) {
+	m := &Metrics{
+		deliveries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "sink_deliveries_total",
+			Help: "Sink delivery attempts by outcome: delivered, failed (retried or dead-lettered after), dead_lettered, or redelivered from the dead letters.",
+		}, []string{"sink", "outcome"}),
+	}
+	if err := reg.Register(m.deliveries); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Metrics) observe(sink, outcome string) {
+	m.deliveries.WithLabelValues(sink, outcome).Inc()
+}
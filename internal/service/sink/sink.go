@@ -0,0 +1,317 @@
+// Package sink delivers outbox events to external systems. Each sink
+// follows the outbox on its own cursor, kept in Postgres and counted in
+// publish order, so it receives every event it subscribes to at least
+// once, in the order the relay published them, across restarts. A
+// delivery that fails is retried with backoff; one that still fails after
+// the last attempt, or that the receiver rejects outright, becomes a dead
+// letter and the sink moves on. Dead letters wait for an operator to
+// retry them.
+//
+// The bus only wakes a sink early: events are read from Postgres, never
+// taken from the bus, so a dropped bus event costs a poll interval, not a
+// delivery.
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/jonboulle/clockwork"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/sink"
+	"github.com/romanornr/delta-works/internal/events"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// batch is how many outbox rows a sink reads at a time.
+const batch = 100
+
+// ErrUnknownSink refuses a dead-letter retry for a sink that is not
+// configured.
+var ErrUnknownSink = errors.New("unknown sink")
+
+// Target is one configured sink: the subject prefixes it takes, every
+// outbox subject when there are none, and where it delivers them.
+type Target struct {
+	Name     string
+	Subjects []string
+	Sink     ports.Sink
+}
+
+func (t Target) takes(subject string) bool {
+	return len(t.Subjects) == 0 || slices.ContainsFunc(t.Subjects, func(prefix string) bool {
+		return strings.HasPrefix(subject, prefix)
+	})
+}
+
+// Retry is the policy for one delivery: up to MaxAttempts attempts, the
+// wait between them starting at Backoff and doubling up to MaxBackoff.
+type Retry struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Encoder renders an outbox event as the JSON a sink receives.
+type Encoder func(bus.Event) ([]byte, error)
+
+// RetryResult is the outcome of one dead-letter retry: Err is nil when the
+// letter was delivered and removed.
+type RetryResult struct {
+	Letter sink.DeadLetter
+	Err    error
+}
+
+// Service runs every configured sink.
+type Service struct {
+	targets  []Target
+	byName   map[string]Target
+	store    ports.SinkStore
+	encode   Encoder
+	bus      bus.Bus
+	clk      clockwork.Clock
+	log      log.Logger
+	interval time.Duration
+	retry    Retry
+	metrics  *Metrics
+	wake     map[string]chan struct{}
+}
+
+// New builds the sink service. Metrics must not be nil.
+func New(
+	targets []Target,
+	store ports.SinkStore,
+	encode Encoder,
+	eventBus bus.Bus,
+	clk clockwork.Clock,
+	logger log.Logger,
+	interval time.Duration,
+	retry Retry,
+	metrics *Metrics,
+) *Service {
+	s := &Service{
+		targets: targets, byName: make(map[string]Target, len(targets)),
+		store: store, encode: encode, bus: eventBus, clk: clk, log: log.Component(logger, "sink"),
+		interval: interval, retry: retry, metrics: metrics,
+		wake: make(map[string]chan struct{}, len(targets)),
+	}
+	for _, t := range targets {
+		s.byName[t.Name] = t
+		s.wake[t.Name] = make(chan struct{}, 1)
+	}
+	return s
+}
+
+// Run delivers until ctx is canceled. It first drops the cursors of sinks
+// no longer configured, which would otherwise hold outbox rows forever.
+// Store failures stop the service so the process can fail fast; every
+// cursor resumes where it stopped.
+func (s *Service) Run(ctx context.Context) error {
+	keep := make([]string, 0, len(s.targets))
+	for _, t := range s.targets {
+		keep = append(keep, t.Name)
+	}
+	pruned, err := s.store.PruneCursors(ctx, keep)
+	if err != nil {
+		return passError(ctx, err)
+	}
+	if pruned > 0 {
+		s.log.Info().Int64("cursors", pruned).Msg("dropped the cursors of sinks no longer configured")
+	}
+	if len(s.targets) == 0 {
+		<-ctx.Done()
+		return nil
+	}
+
+	unsubscribe, err := s.bus.Subscribe("", s.nudge)
+	if err != nil {
+		return fmt.Errorf("sink: subscribe: %w", err)
+	}
+	defer unsubscribe()
+
+	g, ctx := errgroup.WithContext(ctx)
+	for _, t := range s.targets {
+		g.Go(func() error { return passError(ctx, s.follow(ctx, t)) })
+	}
+	return g.Wait()
+}
+
+func passError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// nudge wakes the sinks that take a freshly published outbox event.
+func (s *Service) nudge(_ context.Context, e bus.Event) {
+	if e.OutboxID == 0 {
+		return
+	}
+	for _, t := range s.targets {
+		if t.takes(e.Subject) {
+			select {
+			case s.wake[t.Name] <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// follow delivers one sink's events from its cursor, then waits for the
+// bus or the next poll.
+func (s *Service) follow(ctx context.Context, t Target) error {
+	after, err := s.store.OpenCursor(ctx, t.Name)
+	if err != nil {
+		return err
+	}
+	ticker := s.clk.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		if after, err = s.drain(ctx, t, after); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.wake[t.Name]:
+		case <-ticker.Chan():
+		}
+	}
+}
+
+// drain delivers every published row after the cursor and returns the new
+// cursor. The cursor moves past each row once it is delivered or
+// dead-lettered, and past rows the sink does not take a batch at a time.
+func (s *Service) drain(ctx context.Context, t Target, after int64) (int64, error) {
+	for {
+		rows, err := s.store.ListPublishedAfter(ctx, after, batch)
+		if err != nil {
+			return after, err
+		}
+		for _, m := range rows {
+			if t.takes(m.Subject) {
+				if err := s.deliver(ctx, t, m); err != nil {
+					return after, err
+				}
+			}
+			after = m.PublishSeq
+		}
+		if len(rows) > 0 {
+			if err := s.store.AdvanceCursor(ctx, t.Name, after); err != nil {
+				return after, err
+			}
+		}
+		if len(rows) < batch {
+			return after, nil
+		}
+	}
+}
+
+// deliver sends one row with retries and moves the cursor past it. Only a
+// store failure or cancellation is returned: a delivery that cannot be
+// made is dead-lettered.
+func (s *Service) deliver(ctx context.Context, t Target, m events.OutboxMessage) error {
+	logger := s.log.With().Str("sink", t.Name).Int64("outbox_id", m.ID).Str("subject", m.Subject).Logger()
+	d := sink.Delivery{OutboxID: m.ID, Subject: m.Subject, At: m.CreatedAt}
+	body, err := s.encode(bus.Event{Subject: m.Subject, At: m.CreatedAt, Payload: json.RawMessage(m.Payload), OutboxID: m.ID, PublishSeq: m.PublishSeq})
+	if err != nil {
+		// A payload that does not encode never will: keep what the row
+		// held so the letter shows what went wrong.
+		d.Body = m.Payload
+		return s.deadLetter(ctx, t, d, m.PublishSeq, 1, err, logger)
+	}
+	d.Body = body
+
+	attempts := 0
+	_, err = backoff.Retry(ctx, func() (struct{}, error) {
+		attempts++
+		err := t.Sink.Deliver(ctx, d)
+		if errors.Is(err, sink.ErrRejected) {
+			return struct{}{}, backoff.Permanent(err)
+		}
+		if err != nil {
+			s.metrics.observe(t.Name, "failed")
+			logger.Warn().Err(err).Int("attempt", attempts).Msg("sink delivery failed")
+		}
+		return struct{}{}, err
+	}, backoff.WithBackOff(s.backOff()), backoff.WithMaxTries(uint(max(s.retry.MaxAttempts, 1))), backoff.WithMaxElapsedTime(0)) //nolint:gosec // positive
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil {
+		return s.deadLetter(ctx, t, d, m.PublishSeq, attempts, err, logger)
+	}
+	s.metrics.observe(t.Name, "delivered")
+	return s.store.AdvanceCursor(ctx, t.Name, m.PublishSeq)
+}
+
+func (s *Service) backOff() backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = s.retry.Backoff
+	b.MaxInterval = s.retry.MaxBackoff
+	return b
+}
+
+func (s *Service) deadLetter(ctx context.Context, t Target, d sink.Delivery, seq int64, attempts int, cause error, logger log.Logger) error {
+	letter := sink.DeadLetter{Sink: t.Name, Delivery: d, Attempts: attempts, LastError: cause.Error(), FailedAt: s.clk.Now()}
+	if err := s.store.DeadLetter(ctx, letter, seq); err != nil {
+		return err
+	}
+	s.metrics.observe(t.Name, "dead_lettered")
+	logger.Error().Err(cause).Int("attempts", attempts).Msg("sink delivery dead-lettered")
+	return nil
+}
+
+// ListDeadLetters returns the dead letters matching query, oldest first.
+func (s *Service) ListDeadLetters(ctx context.Context, query sink.DeadLetterQuery) ([]sink.DeadLetter, error) {
+	return s.store.ListDeadLetters(ctx, query)
+}
+
+// RetryDeadLetters makes one more attempt at each dead letter matching
+// query, removing those delivered and recording the failure on the rest.
+// Only store failures are returned as the error; a letter whose sink is no
+// longer configured fails with ErrUnknownSink in its result.
+func (s *Service) RetryDeadLetters(ctx context.Context, query sink.DeadLetterQuery) ([]RetryResult, error) {
+	if _, ok := s.byName[query.Sink]; query.Sink != "" && !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownSink, query.Sink)
+	}
+	letters, err := s.store.ListDeadLetters(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]RetryResult, 0, len(letters))
+	for _, letter := range letters {
+		t, ok := s.byName[letter.Sink]
+		if !ok {
+			results = append(results, RetryResult{Letter: letter, Err: fmt.Errorf("%w %q", ErrUnknownSink, letter.Sink)})
+			continue
+		}
+		deliverErr := t.Sink.Deliver(ctx, letter.Delivery)
+		if deliverErr == nil {
+			if err := s.store.DeleteDeadLetter(ctx, letter.ID); err != nil {
+				return nil, err
+			}
+			s.metrics.observe(t.Name, "redelivered")
+			s.log.Info().Str("sink", t.Name).Int64("outbox_id", letter.Delivery.OutboxID).Msg("dead letter redelivered")
+		} else {
+			letter.Attempts++
+			letter.LastError, letter.FailedAt = deliverErr.Error(), s.clk.Now()
+			if err := s.store.FailDeadLetter(ctx, letter.ID, letter.LastError, letter.FailedAt); err != nil {
+				return nil, err
+			}
+			s.metrics.observe(t.Name, "failed")
+		}
+		results = append(results, RetryResult{Letter: letter, Err: deliverErr})
+	}
+	return results, nil
+}
@@ -0,0 +1,288 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/sink"
+	"github.com/romanornr/delta-works/internal/events"
+	"github.com/romanornr/delta-works/internal/log"
+)
+
+type fakeStore struct {
+	mu      sync.Mutex
+	rows    []events.OutboxMessage
+	cursors map[string]int64
+	letters []sink.DeadLetter
+	nextID  int64
+}
+
+func (f *fakeStore) publish(id int64, subject string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rows = append(f.rows, events.OutboxMessage{
+		ID: id, Subject: subject, Payload: []byte(fmt.Sprintf(`{"id":%d}`, id)),
+		CreatedAt: time.Unix(id, 0), PublishSeq: int64(len(f.rows) + 1),
+	})
+}
+
+func (f *fakeStore) cursor(name string) int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.cursors[name]
+}
+
+func (f *fakeStore) deadLetters() []sink.DeadLetter {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.letters)
+}
+
+func (f *fakeStore) OpenCursor(_ context.Context, name string) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.cursors[name], nil
+}
+
+func (f *fakeStore) AdvanceCursor(_ context.Context, name string, after int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cursors[name] = max(f.cursors[name], after)
+	return nil
+}
+
+func (f *fakeStore) PruneCursors(_ context.Context, keep []string) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var pruned int64
+	for name := range f.cursors {
+		if !slices.Contains(keep, name) {
+			delete(f.cursors, name)
+			pruned++
+		}
+	}
+	return pruned, nil
+}
+
+func (f *fakeStore) ListPublishedAfter(_ context.Context, after int64, limit int) ([]events.OutboxMessage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []events.OutboxMessage
+	for _, m := range f.rows {
+		if m.PublishSeq > after && len(out) < limit {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStore) DeadLetter(_ context.Context, letter sink.DeadLetter, seq int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	letter.ID = f.nextID
+	f.letters = append(f.letters, letter)
+	f.cursors[letter.Sink] = max(f.cursors[letter.Sink], seq)
+	return nil
+}
+
+func (f *fakeStore) ListDeadLetters(_ context.Context, query sink.DeadLetterQuery) ([]sink.DeadLetter, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []sink.DeadLetter
+	for _, letter := range f.letters {
+		if (query.Sink == "" || letter.Sink == query.Sink) && (len(query.IDs) == 0 || slices.Contains(query.IDs, letter.ID)) {
+			out = append(out, letter)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStore) DeleteDeadLetter(_ context.Context, id int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.letters = slices.DeleteFunc(f.letters, func(letter sink.DeadLetter) bool { return letter.ID == id })
+	return nil
+}
+
+func (f *fakeStore) FailDeadLetter(_ context.Context, id int64, lastErr string, at time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.letters {
+		if f.letters[i].ID == id {
+			f.letters[i].Attempts++
+			f.letters[i].LastError, f.letters[i].FailedAt = lastErr, at
+		}
+	}
+	return nil
+}
+
+// fakeSink fails each outbox ID with the errors queued for it, then
+// accepts it.
+type fakeSink struct {
+	mu        sync.Mutex
+	failures  map[int64][]error
+	delivered []int64
+}
+
+func (f *fakeSink) Deliver(_ context.Context, d sink.Delivery) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if queued := f.failures[d.OutboxID]; len(queued) > 0 {
+		f.failures[d.OutboxID] = queued[1:]
+		return queued[0]
+	}
+	f.delivered = append(f.delivered, d.OutboxID)
+	return nil
+}
+
+func (f *fakeSink) deliveries() []int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.delivered)
+}
+
+func encode(e bus.Event) ([]byte, error) {
+	return json.Marshal(map[string]any{"subject": e.Subject, "cursor": e.OutboxID, "payload": e.Payload})
+}
+
+type harness struct {
+	svc     *Service
+	store   *fakeStore
+	sink    *fakeSink
+	metrics *Metrics
+	bus     *bus.InProc
+}
+
+func newHarness(t *testing.T, subjects ...string) *harness {
+	t.Helper()
+	metrics, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &harness{
+		store:   &fakeStore{cursors: map[string]int64{"removed": 0}},
+		sink:    &fakeSink{failures: map[int64][]error{}},
+		metrics: metrics, bus: bus.NewInProc(),
+	}
+	t.Cleanup(h.bus.Close)
+	targets := []Target{{Name: "hooks", Subjects: subjects, Sink: h.sink}}
+	retry := Retry{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	h.svc = New(targets, h.store, encode, h.bus, clockwork.NewFakeClock(), log.Nop(), time.Minute, retry, metrics)
+	return h
+}
+
+func (h *harness) run(t *testing.T) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() { done <- h.svc.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Run: %v", err)
+		}
+	})
+}
+
+func TestDeliversRetriesAndDeadLetters(t *testing.T) {
+	t.Parallel()
+	h := newHarness(t, "order.")
+	unavailable := errors.New("503 Service Unavailable")
+	h.sink.failures[11] = []error{unavailable}
+	h.sink.failures[12] = []error{fmt.Errorf("400 Bad Request: %w", sink.ErrRejected)}
+	h.sink.failures[13] = []error{unavailable, unavailable, unavailable}
+	h.store.publish(10, events.SubjectOrderUpdated)
+	h.store.publish(11, events.SubjectOrderFilled)
+	h.store.publish(12, events.SubjectOrderUpdated)
+	h.store.publish(13, events.SubjectOrderUpdated)
+	h.store.publish(14, events.SubjectInstrumentChanged)
+
+	h.run(t)
+	waitFor(t, func() bool { return h.store.cursor("hooks") == 5 })
+	if got := h.sink.deliveries(); !slices.Equal(got, []int64{10, 11}) {
+		t.Fatalf("delivered %v, want 10 and 11 after its retry", got)
+	}
+	letters := h.store.deadLetters()
+	if len(letters) != 2 || letters[0].Delivery.OutboxID != 12 || letters[0].Attempts != 1 ||
+		letters[1].Delivery.OutboxID != 13 || letters[1].Attempts != 3 || letters[1].LastError != unavailable.Error() {
+		t.Fatalf("dead letters = %+v, want 12 rejected at once and 13 after every attempt", letters)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(letters[1].Delivery.Body, &body); err != nil || body["cursor"] != float64(13) {
+		t.Fatalf("dead letter body = %s, %v", letters[1].Delivery.Body, err)
+	}
+	if pruned, _ := h.store.PruneCursors(t.Context(), []string{"hooks"}); pruned != 0 {
+		t.Fatal("the cursor of a sink no longer configured was kept")
+	}
+
+	// A published event wakes the sink without waiting for the poll.
+	h.store.publish(15, events.SubjectOrderFilled)
+	if err := h.bus.Publish(t.Context(), bus.Event{Subject: events.SubjectOrderFilled, OutboxID: 15}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return h.store.cursor("hooks") == 6 })
+	if got := h.sink.deliveries(); !slices.Equal(got, []int64{10, 11, 15}) {
+		t.Fatalf("delivered %v after the wake", got)
+	}
+	for outcome, want := range map[string]float64{"delivered": 3, "failed": 4, "dead_lettered": 2} {
+		if got := testutil.ToFloat64(h.metrics.deliveries.WithLabelValues("hooks", outcome)); got != want {
+			t.Errorf("%s = %v, want %v", outcome, got, want)
+		}
+	}
+}
+
+func TestRetryDeadLetters(t *testing.T) {
+	t.Parallel()
+	h := newHarness(t)
+	for _, letter := range []sink.DeadLetter{
+		{Sink: "hooks", Delivery: sink.Delivery{OutboxID: 1}, Attempts: 3},
+		{Sink: "hooks", Delivery: sink.Delivery{OutboxID: 2}, Attempts: 3},
+		{Sink: "removed", Delivery: sink.Delivery{OutboxID: 3}, Attempts: 3},
+	} {
+		if err := h.store.DeadLetter(t.Context(), letter, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h.sink.failures[2] = []error{errors.New("503 Service Unavailable")}
+
+	if _, err := h.svc.RetryDeadLetters(t.Context(), sink.DeadLetterQuery{Sink: "removed"}); !errors.Is(err, ErrUnknownSink) {
+		t.Fatalf("retry an unconfigured sink: err = %v, want ErrUnknownSink", err)
+	}
+	results, err := h.svc.RetryDeadLetters(t.Context(), sink.DeadLetterQuery{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 || results[0].Err != nil || results[1].Err == nil || !errors.Is(results[2].Err, ErrUnknownSink) {
+		t.Fatalf("results = %+v", results)
+	}
+	if results[1].Letter.Attempts != 4 {
+		t.Fatalf("failed retry = %+v, want the attempt counted", results[1].Letter)
+	}
+	letters := h.store.deadLetters()
+	if len(letters) != 2 || letters[0].Delivery.OutboxID != 2 || letters[0].Attempts != 4 || letters[1].Delivery.OutboxID != 3 {
+		t.Fatalf("dead letters left = %+v, want the redelivered one removed", letters)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not reached")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
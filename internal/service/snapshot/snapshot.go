@@ -166,7 +166,8 @@ func (s *Service) snapshot(ctx context.Context, t Target) error {
 	recordCtx, recordCancel := context.WithTimeout(context.WithoutCancel(ctx), recordTimeout)
 	defer recordCancel()
 	checkpoint.Status = snapshotmodel.StatusOK
-	checkpoint.BalanceCount = len(snap.NonZero())
+	checkpoint.Balances = snap.NonZero()
+	checkpoint.BalanceCount = len(checkpoint.Balances)
 	if err := s.record(recordCtx, checkpoint); err != nil {
 		return err
 	}
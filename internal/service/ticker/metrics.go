@@ -0,0 +1,67 @@
+package ticker
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+)
+
+// Error kinds for ticker_errors_total.
+const (
+	errTicker = "ticker"
+	errTrades = "trades"
+	errSeries = "series"
+)
+
+// Metrics holds the service's Prometheus instruments. The last-success
+// timestamp gauge exists so a stale market-data feed can be alerted on.
+type Metrics struct {
+	duration    *prometheus.HistogramVec
+	errors      *prometheus.CounterVec
+	lastSuccess *prometheus.GaugeVec
+	trades      *prometheus.CounterVec
+}
+
+// NewMetrics registers the service metrics on the given registry.
+func NewMetrics(reg *prometheus.Registry) (*Metrics, error) {
+	m := &Metrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "ticker_poll_duration_seconds",
+			Help:    "Time to fetch and persist one venue's tickers.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"venue"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ticker_errors_total",
+			Help: "Failed ticker and trade fetches and series writes.",
+		}, []string{"venue", "kind"}),
+		lastSuccess: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "ticker_last_success_timestamp_seconds",
+			Help: "Unix time of the last persisted ticker poll.",
+		}, []string{"venue"}),
+		trades: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ticker_trades_recorded_total",
+			Help: "Public trades appended to the trade series.",
+		}, []string{"venue"}),
+	}
+	for _, c := range []prometheus.Collector{m.duration, m.errors, m.lastSuccess, m.trades} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func (m *Metrics) observeSuccess(venue instrument.VenueID, d time.Duration, at time.Time) {
+	m.duration.WithLabelValues(string(venue)).Observe(d.Seconds())
+	m.lastSuccess.WithLabelValues(string(venue)).Set(float64(at.Unix()))
+}
+
+func (m *Metrics) observeTrades(venue instrument.VenueID, n int) {
+	m.trades.WithLabelValues(string(venue)).Add(float64(n))
+}
+
+func (m *Metrics) observeError(venue instrument.VenueID, kind string) {
+	m.errors.WithLabelValues(string(venue), kind).Inc()
+}
@@ -0,0 +1,200 @@
+// Package ticker polls venue tickers on an interval and appends them to the
+// time-series store. Each venue keeps its own interval and instrument list;
+// an observation is published on the bus only after its flush succeeded.
+// The same poll records the instruments' public trades, the series the
+// execution algorithms read volume profiles and benchmarks from.
+package ticker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+	"github.com/romanornr/delta-works/internal/events"
+	"github.com/romanornr/delta-works/internal/exchange"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+// Target is one venue's poll: every Interval, one ticker per instrument.
+type Target struct {
+	Venue       instrument.VenueID
+	Interval    time.Duration
+	Instruments []instrument.Instrument
+}
+
+// Service polls all targets concurrently, one goroutine per venue.
+type Service struct {
+	registry exchange.Registry
+	series   ports.TickerSeriesWriter
+	trades   ports.TradeSeriesWriter
+	bus      bus.Bus
+	clk      clockwork.Clock
+	log      log.Logger
+	targets  []Target
+	metrics  *Metrics
+	writeMu  sync.Mutex
+}
+
+// New builds the service. Metrics must not be nil; trades may be, in which
+// case no public trades are recorded.
+func New(
+	registry exchange.Registry,
+	series ports.TickerSeriesWriter,
+	trades ports.TradeSeriesWriter,
+	eventBus bus.Bus,
+	clk clockwork.Clock,
+	logger log.Logger,
+	targets []Target,
+	metrics *Metrics,
+) *Service {
+	return &Service{
+		registry: registry,
+		series:   series,
+		trades:   trades,
+		bus:      eventBus,
+		clk:      clk,
+		log:      log.Component(logger, "ticker"),
+		targets:  targets,
+		metrics:  metrics,
+	}
+}
+
+// Run blocks until ctx is canceled or a wiring failure occurs. Market data
+// is analytics, not accounting truth: venue and series failures are logged,
+// counted and leave a gap that the next tick does not backfill.
+func (s *Service) Run(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+	for _, t := range s.targets {
+		g.Go(func() error { return s.pollLoop(ctx, t) })
+	}
+	return g.Wait()
+}
+
+func (s *Service) pollLoop(ctx context.Context, t Target) error {
+	ex, err := s.registry.Get(t.Venue)
+	if err != nil {
+		return err // a missing venue is a wiring bug, not a venue outage
+	}
+	ticker := s.clk.NewTicker(t.Interval)
+	defer ticker.Stop()
+
+	tape := newTape(s.trades != nil)
+	if err := s.poll(ctx, ex, t, tape); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.Chan():
+			if err := s.poll(ctx, ex, t, tape); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// poll returns a non-nil error only when publishing fails.
+func (s *Service) poll(ctx context.Context, ex ports.Exchange, t Target, tape *tape) error {
+	start := s.clk.Now()
+	// The fetches share one deadline so a stalled venue cannot eat the next
+	// tick or hold up shutdown.
+	fetchCtx, cancel := context.WithTimeout(ctx, t.Interval/2)
+	var observed []marketdata.Ticker
+	for _, inst := range t.Instruments {
+		tk, err := ex.Ticker(fetchCtx, inst)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.metrics.observeError(t.Venue, errTicker)
+				s.log.Warn().Str("venue", string(t.Venue)).Str("pair", inst.Pair()).Err(err).Msg("ticker fetch failed")
+			}
+			continue
+		}
+		if tk.At.IsZero() {
+			tk.At = s.clk.Now()
+		}
+		observed = append(observed, tk)
+	}
+	traded := s.fetchTrades(fetchCtx, ex, t, tape)
+	cancel()
+
+	if ctx.Err() != nil || (len(observed) == 0 && len(traded) == 0) {
+		return nil
+	}
+	if err := s.writeSeries(ctx, observed, traded); err != nil {
+		s.metrics.observeError(t.Venue, errSeries)
+		s.log.Error().Str("venue", string(t.Venue)).Err(err).Msg("ticker series write failed")
+		return nil
+	}
+	tape.commit()
+	s.metrics.observeTrades(t.Venue, len(traded))
+	s.metrics.observeSuccess(t.Venue, s.clk.Now().Sub(start), s.clk.Now())
+	for _, tk := range observed {
+		if err := s.bus.Publish(ctx, bus.Event{Subject: events.SubjectTickerUpdated, At: tk.At, Payload: tk}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeSeries holds one lock across write and flush so a venue's flush
+// result describes its own rows rather than another venue's. The lock
+// covers this service's targets only: the series writers must not be
+// shared with other services, or their rows would ride along in a flush.
+func (s *Service) writeSeries(ctx context.Context, tickers []marketdata.Ticker, trades []marketdata.Trade) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	for _, tk := range tickers {
+		if err := s.series.WriteTicker(ctx, tk); err != nil {
+			return err
+		}
+	}
+	if len(trades) > 0 {
+		for _, tr := range trades {
+			if err := s.trades.WriteTrade(ctx, tr); err != nil {
+				return err
+			}
+		}
+		if err := s.trades.Flush(ctx); err != nil {
+			return err
+		}
+	}
+	return s.series.Flush(ctx)
+}
+
+// fetchTrades reads each instrument's recent public trades and returns
+// those the tape has not recorded. A venue whose adapter reads no trades
+// is asked once.
+func (s *Service) fetchTrades(ctx context.Context, ex ports.Exchange, t Target, tape *tape) []marketdata.Trade {
+	reader, ok := ex.(ports.TradeReader)
+	if !ok || !tape.enabled {
+		return nil
+	}
+	clear(tape.pending)
+	var fresh []marketdata.Trade
+	for _, inst := range t.Instruments {
+		trades, err := reader.RecentTrades(ctx, inst)
+		if errors.Is(err, ports.ErrTradesUnsupported) {
+			tape.enabled = false
+			s.log.Info().Str("venue", string(t.Venue)).Msg("venue adapter reads no public trades; recording tickers only")
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				s.metrics.observeError(t.Venue, errTrades)
+				s.log.Warn().Str("venue", string(t.Venue)).Str("pair", inst.Pair()).Err(err).Msg("trade fetch failed")
+			}
+			continue
+		}
+		fresh = append(fresh, tape.fresh(inst, trades)...)
+	}
+	return fresh
+}
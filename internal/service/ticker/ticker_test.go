@@ -0,0 +1,242 @@
+package ticker
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/account"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/events"
+	"github.com/romanornr/delta-works/internal/exchange"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+type fakeExchange struct {
+	mu    sync.Mutex
+	last  decimal.Decimal
+	fails map[string]bool
+}
+
+func (f *fakeExchange) ID() instrument.VenueID { return "bybit" }
+
+func (f *fakeExchange) Ticker(_ context.Context, inst instrument.Instrument) (marketdata.Ticker, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fails[inst.Pair()] {
+		return marketdata.Ticker{}, errors.New("venue down")
+	}
+	return marketdata.Ticker{Instrument: inst, Bid: f.last.Sub(decimal.NewFromInt(1)), Ask: f.last.Add(decimal.NewFromInt(1)), Last: f.last}, nil
+}
+
+func (f *fakeExchange) Instruments(context.Context, instrument.Type) ([]instrument.Instrument, error) {
+	return nil, nil
+}
+
+func (f *fakeExchange) Balances(context.Context, account.Type) ([]account.Balance, error) {
+	return nil, nil
+}
+
+func (f *fakeExchange) setLast(last int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.last = decimal.NewFromInt(last)
+}
+
+type fakeSeries struct {
+	mu      sync.Mutex
+	written []marketdata.Ticker
+}
+
+func (f *fakeSeries) WriteTicker(_ context.Context, t marketdata.Ticker) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.written = append(f.written, t)
+	return nil
+}
+
+func (f *fakeSeries) Flush(context.Context) error { return nil }
+
+func (f *fakeSeries) rows() []marketdata.Ticker {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]marketdata.Ticker(nil), f.written...)
+}
+
+func spot(base, quote string) instrument.Instrument {
+	return instrument.Instrument{Venue: "bybit", Type: instrument.TypeSpot, Base: money.Currency(base), Quote: money.Currency(quote)}
+}
+
+func receive(t *testing.T, ch <-chan bus.Event) bus.Event {
+	t.Helper()
+	select {
+	case e := <-ch:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for ticker event")
+		return bus.Event{}
+	}
+}
+
+func TestPollWritesAndPublishes(t *testing.T) {
+	start := time.Date(2026, 7, 12, 12, 0, 0, 0, time.UTC)
+	clk := clockwork.NewFakeClockAt(start)
+	venue := &fakeExchange{fails: map[string]bool{"ETH/USDT": true}}
+	venue.setLast(100)
+	series := &fakeSeries{}
+	eventBus := bus.NewInProc()
+	t.Cleanup(eventBus.Close)
+	published := make(chan bus.Event, 4)
+	unsubscribe, err := eventBus.Subscribe(events.SubjectTickerUpdated, func(_ context.Context, e bus.Event) { published <- e })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unsubscribe()
+	m, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := Target{Venue: "bybit", Interval: 10 * time.Second, Instruments: []instrument.Instrument{spot("BTC", "USDT"), spot("ETH", "USDT")}}
+	svc := New(exchange.NewRegistry([]ports.Exchange{venue}), series, nil, eventBus, clk, log.Nop(), []Target{target}, m)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- svc.Run(ctx) }()
+
+	first := receive(t, published)
+	got, ok := first.Payload.(marketdata.Ticker)
+	if !ok || got.Instrument.Pair() != "BTC/USDT" || !got.Last.Equal(decimal.NewFromInt(100)) || !got.At.Equal(start) || !first.At.Equal(start) {
+		t.Fatalf("first event = %+v", first)
+	}
+	if rows := series.rows(); len(rows) != 1 {
+		t.Fatalf("rows = %+v, want only the healthy pair", rows)
+	}
+	if n := testutil.ToFloat64(m.errors.WithLabelValues("bybit", errTicker)); n != 1 {
+		t.Fatalf("ticker errors = %v, want 1", n)
+	}
+
+	venue.setLast(101)
+	if err := clk.BlockUntilContext(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	clk.Advance(target.Interval)
+	second := receive(t, published)
+	if got := second.Payload.(marketdata.Ticker); !got.Last.Equal(decimal.NewFromInt(101)) || !got.At.Equal(start.Add(target.Interval)) {
+		t.Fatalf("second event = %+v", second)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if n := testutil.ToFloat64(m.lastSuccess.WithLabelValues("bybit")); n != float64(start.Add(target.Interval).Unix()) {
+		t.Fatalf("last success = %v", n)
+	}
+}
+
+func TestUnknownVenueStopsRun(t *testing.T) {
+	m, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventBus := bus.NewInProc()
+	t.Cleanup(eventBus.Close)
+	svc := New(exchange.NewRegistry(nil), &fakeSeries{}, nil, eventBus,
+		clockwork.NewFakeClock(), log.Nop(), []Target{{Venue: "kraken", Interval: time.Second}}, m)
+	if err := svc.Run(context.Background()); err == nil {
+		t.Fatal("Run with an unconfigured venue returned nil")
+	}
+}
+
+// tradingExchange also reads public trades, returning the window set last.
+type tradingExchange struct {
+	*fakeExchange
+	window []marketdata.Trade
+}
+
+func (f *tradingExchange) RecentTrades(context.Context, instrument.Instrument) ([]marketdata.Trade, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.window, nil
+}
+
+type fakeTradeSeries struct {
+	mu       sync.Mutex
+	written  []marketdata.Trade
+	flushErr error
+}
+
+func (f *fakeTradeSeries) WriteTrade(_ context.Context, t marketdata.Trade) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.written = append(f.written, t)
+	return nil
+}
+
+func (f *fakeTradeSeries) Flush(context.Context) error { return f.flushErr }
+
+func (f *fakeTradeSeries) ids() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []string
+	for _, t := range f.written {
+		out = append(out, t.ID)
+	}
+	return out
+}
+
+func TestPollRecordsEachTradeOnce(t *testing.T) {
+	at := time.Date(2026, 7, 12, 12, 0, 0, 0, time.UTC)
+	btc := spot("BTC", "USDT")
+	trade := func(id string, offset time.Duration) marketdata.Trade {
+		return marketdata.Trade{Instrument: btc, ID: id, Price: decimal.NewFromInt(100), Qty: decimal.NewFromInt(1), At: at.Add(offset)}
+	}
+	venue := &tradingExchange{fakeExchange: &fakeExchange{last: decimal.NewFromInt(100)}}
+	trades := &fakeTradeSeries{}
+	eventBus := bus.NewInProc()
+	t.Cleanup(eventBus.Close)
+	m, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := Target{Venue: "bybit", Interval: 10 * time.Second, Instruments: []instrument.Instrument{btc}}
+	svc := New(exchange.NewRegistry([]ports.Exchange{venue}), &fakeSeries{}, trades, eventBus,
+		clockwork.NewFakeClockAt(at), log.Nop(), []Target{target}, m)
+	tape := newTape(true)
+
+	venue.window = []marketdata.Trade{trade("1", 0), trade("2", time.Second)}
+	if err := svc.poll(t.Context(), venue, target, tape); err != nil {
+		t.Fatal(err)
+	}
+	// The next window overlaps the last: 2 again, a second trade in the
+	// same instant as 2, and a later one.
+	venue.window = []marketdata.Trade{trade("2", time.Second), trade("2b", time.Second), trade("3", 2*time.Second)}
+	trades.flushErr = errors.New("questdb down")
+	if err := svc.poll(t.Context(), venue, target, tape); err != nil {
+		t.Fatal(err)
+	}
+	trades.flushErr = nil
+	if err := svc.poll(t.Context(), venue, target, tape); err != nil {
+		t.Fatal(err)
+	}
+	// The failed flush's rows were resent, since its marks never moved.
+	want := []string{"1", "2", "2b", "3", "2b", "3"}
+	if got := trades.ids(); !slices.Equal(got, want) {
+		t.Fatalf("written = %v, want %v", got, want)
+	}
+	if n := testutil.ToFloat64(m.trades.WithLabelValues("bybit")); n != 4 {
+		t.Fatalf("trades recorded = %v, want 4", n)
+	}
+}
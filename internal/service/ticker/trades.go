@@ -0,0 +1,65 @@
+package ticker
+
+import (
+	"maps"
+	"time"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+)
+
+// tape remembers, per instrument, the newest trade time recorded and the
+// venue trade IDs recorded at that time, so the overlapping windows that
+// successive polls return are written once. Only its venue's poll loop
+// touches it.
+type tape struct {
+	enabled bool
+	seen    map[string]tapeMark
+	pending map[string]tapeMark
+}
+
+type tapeMark struct {
+	at  time.Time
+	ids map[string]bool
+}
+
+func newTape(enabled bool) *tape {
+	return &tape{enabled: enabled, seen: map[string]tapeMark{}, pending: map[string]tapeMark{}}
+}
+
+// fresh returns the trades, oldest first, newer than the instrument's mark,
+// and stages the mark they move it to. A trade at the mark's own time is
+// fresh only under an ID not yet recorded; without an ID it cannot be told
+// from one already written, so it is dropped.
+func (t *tape) fresh(inst instrument.Instrument, trades []marketdata.Trade) []marketdata.Trade {
+	key := inst.Key()
+	mark := t.seen[key]
+	next := tapeMark{at: mark.at, ids: maps.Clone(mark.ids)}
+	var out []marketdata.Trade
+	for _, tr := range trades {
+		if tr.At.Before(mark.at) || (tr.At.Equal(mark.at) && (tr.ID == "" || mark.ids[tr.ID])) {
+			continue
+		}
+		out = append(out, tr)
+		if tr.At.After(next.at) {
+			next = tapeMark{at: tr.At}
+		}
+		if tr.ID != "" && tr.At.Equal(next.at) {
+			if next.ids == nil {
+				next.ids = map[string]bool{}
+			}
+			next.ids[tr.ID] = true
+		}
+	}
+	if len(out) > 0 {
+		t.pending[key] = next
+	}
+	return out
+}
+
+// commit adopts the staged marks once their trades are flushed; a failed
+// write leaves the marks where they were, so the next poll retries them.
+func (t *tape) commit() {
+	maps.Copy(t.seen, t.pending)
+	clear(t.pending)
+}
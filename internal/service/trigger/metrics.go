@@ -0,0 +1,48 @@
+package trigger
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+)
+
+// Outcomes for stop_triggers_total.
+const (
+	outcomeTriggered = "triggered"
+	outcomeRejected  = "rejected"
+	outcomeFailed    = "failed"
+)
+
+// Metrics holds the engine's Prometheus instruments.
+type Metrics struct {
+	triggers *prometheus.CounterVec
+	dropped  prometheus.Counter
+}
+
+// NewMetrics registers the engine metrics on the given registry.
+func NewMetrics(reg *prometheus.Registry) (*Metrics, error) {
+	m := &Metrics{
+		triggers: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "stop_triggers_total",
+			Help: "Local stops fired by venue and outcome (triggered, rejected, failed).",
+		}, []string{"venue", "outcome"}),
+		dropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "stop_tickers_dropped_total",
+			Help: "Tickers the trigger engine skipped because it was still busy with earlier ones.",
+		}),
+	}
+	for _, c := range []prometheus.Collector{m.triggers, m.dropped} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func (m *Metrics) observeTrigger(venue instrument.VenueID, outcome string) {
+	m.triggers.With(prometheus.Labels{"venue": string(venue), "outcome": outcome}).Inc()
+}
+
+func (m *Metrics) observeDropped() {
+	m.dropped.Inc()
+}
@@ -0,0 +1,166 @@
+// Package trigger is the local trigger engine: it holds the stop orders
+// of venues without native stops. Every polled ticker is checked against
+// the untriggered stops on its instrument, and a stop the last trade
+// price crosses is fired through the order service, which places its
+// child order. Stops live in Postgres, so a restart resumes them where it
+// left off.
+package trigger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/events"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+	orderservice "github.com/romanornr/delta-works/internal/service/order"
+)
+
+// inboxBuffer sizes the ticker queue. A ticker arriving while it is full
+// is dropped: the next poll brings a fresher one.
+const inboxBuffer = 64
+
+// Firer fires a stop the engine holds; *order.Service satisfies it.
+type Firer interface {
+	FireStop(ctx context.Context, stopID order.ClientOrderID, reason string) (order.Status, error)
+}
+
+// Service is the trigger engine.
+type Service struct {
+	stops   ports.StopStore
+	firer   Firer
+	bus     bus.Bus
+	log     log.Logger
+	metrics *Metrics
+	inbox   chan marketdata.Ticker
+}
+
+// New builds the engine. Metrics must not be nil.
+func New(stops ports.StopStore, firer Firer, eventBus bus.Bus, logger log.Logger, metrics *Metrics) *Service {
+	return &Service{
+		stops:   stops,
+		firer:   firer,
+		bus:     eventBus,
+		log:     log.Component(logger, "trigger"),
+		metrics: metrics,
+		inbox:   make(chan marketdata.Ticker, inboxBuffer),
+	}
+}
+
+// Run first settles the stops a previous run fired but did not mark
+// triggered, then checks every ticker until ctx is canceled. A failure to
+// fire is logged and counted, and the stop stays armed for the next
+// crossing ticker; a store failure stops the service so the process
+// fails fast.
+func (s *Service) Run(ctx context.Context) error {
+	unsubscribe, err := s.bus.Subscribe(events.SubjectTickerUpdated, s.route)
+	if err != nil {
+		return fmt.Errorf("trigger: subscribe to tickers: %w", err)
+	}
+	defer unsubscribe()
+
+	if err := s.resume(ctx); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case tk := <-s.inbox:
+			if err := s.check(ctx, tk); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Service) route(_ context.Context, event bus.Event) {
+	tk, ok := event.Payload.(marketdata.Ticker)
+	if !ok {
+		return
+	}
+	select {
+	case s.inbox <- tk:
+	default:
+		s.metrics.observeDropped()
+	}
+}
+
+// resume finishes the fires a crash interrupted: a stop whose child is
+// stored was fired, and firing it again only marks it triggered. Stops
+// whose child never got stored wait for their next crossing ticker.
+func (s *Service) resume(ctx context.Context) error {
+	stops, err := s.stops.ListUntriggeredStops(ctx, "")
+	if err != nil {
+		return storeError(ctx, err)
+	}
+	for _, stop := range stops {
+		_, err := s.stops.GetOrder(ctx, stop.Child)
+		switch {
+		case errors.Is(err, ports.ErrNotFound):
+			continue
+		case err != nil:
+			return storeError(ctx, err)
+		}
+		s.fire(ctx, stop, "resumed after restart")
+	}
+	if len(stops) > 0 {
+		s.log.Info().Int("stops", len(stops)).Msg("holding untriggered stops")
+	}
+	return nil
+}
+
+// check fires every stop on tk's instrument that the last trade crossed.
+func (s *Service) check(ctx context.Context, tk marketdata.Ticker) error {
+	last, ok := tk.Price(marketdata.PriceLast)
+	if !ok {
+		return nil
+	}
+	stops, err := s.stops.ListUntriggeredStops(ctx, tk.Instrument.Venue)
+	if err != nil {
+		return storeError(ctx, err)
+	}
+	for _, stop := range stops {
+		if stop.Instrument.Base != tk.Instrument.Base || stop.Instrument.Quote != tk.Instrument.Quote ||
+			!order.Crossed(stop.Side, stop.TriggerPrice, last) {
+			continue
+		}
+		s.fire(ctx, stop, fmt.Sprintf("last %s crossed the trigger %s", last, stop.TriggerPrice))
+	}
+	return nil
+}
+
+func (s *Service) fire(ctx context.Context, stop order.Record, reason string) {
+	venue := stop.Instrument.Venue
+	status, err := s.firer.FireStop(ctx, stop.ClientOrderID, reason)
+	logEvent := s.log.Info()
+	switch {
+	case status == order.StatusTriggered && (err == nil || errors.Is(err, orderservice.ErrSubmitUnsettled)):
+		s.metrics.observeTrigger(venue, outcomeTriggered)
+	case status == order.StatusRejected:
+		s.metrics.observeTrigger(venue, outcomeRejected)
+		logEvent = s.log.Warn()
+	case err != nil:
+		if ctx.Err() != nil {
+			return
+		}
+		s.metrics.observeTrigger(venue, outcomeFailed)
+		logEvent = s.log.Error()
+	default:
+		// Canceled or fired by a concurrent call.
+		return
+	}
+	logEvent.Str("venue", string(venue)).Str("client_order_id", string(stop.ClientOrderID)).
+		Str("child", string(stop.Child)).Str("status", string(status)).Err(err).Msg(reason)
+}
+
+func storeError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+	return fmt.Errorf("trigger store: %w", err)
+}
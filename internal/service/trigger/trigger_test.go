@@ -0,0 +1,245 @@
+package trigger
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+
+	"github.com/romanornr/delta-works/internal/bus"
+	"github.com/romanornr/delta-works/internal/domain/instrument"
+	"github.com/romanornr/delta-works/internal/domain/marketdata"
+	"github.com/romanornr/delta-works/internal/domain/money"
+	"github.com/romanornr/delta-works/internal/domain/order"
+	"github.com/romanornr/delta-works/internal/events"
+	"github.com/romanornr/delta-works/internal/log"
+	"github.com/romanornr/delta-works/internal/ports"
+)
+
+func spot(base, quote string) instrument.Instrument {
+	return instrument.Instrument{Venue: "bybit", Type: instrument.TypeSpot, Base: money.Currency(base), Quote: money.Currency(quote)}
+}
+
+func stop(id string, inst instrument.Instrument, side order.Side, trigger int64) order.Record {
+	return order.Record{
+		ClientOrderID: order.ClientOrderID(id), Child: order.ClientOrderID(id + "-child"),
+		Instrument: inst, Side: side, Type: order.StopMarket, Qty: decimal.NewFromInt(1),
+		TriggerPrice: decimal.NewFromInt(trigger), Status: order.StatusUntriggered,
+	}
+}
+
+// fakeStore holds stops and the child IDs already stored. listed is
+// signaled after every listing so a test knows the engine is running.
+type fakeStore struct {
+	mu       sync.Mutex
+	stops    []order.Record
+	children map[order.ClientOrderID]bool
+	listErr  error
+	listed   chan struct{}
+}
+
+func (f *fakeStore) GetOrder(_ context.Context, id order.ClientOrderID) (order.Record, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.children[id] {
+		return order.Record{ClientOrderID: id}, nil
+	}
+	return order.Record{}, ports.ErrNotFound
+}
+
+func (f *fakeStore) ListUntriggeredStops(_ context.Context, venue instrument.VenueID) ([]order.Record, error) {
+	f.mu.Lock()
+	var out []order.Record
+	for _, s := range f.stops {
+		if s.Status == order.StatusUntriggered && (venue == "" || s.Instrument.Venue == venue) {
+			out = append(out, s)
+		}
+	}
+	err := f.listErr
+	f.mu.Unlock()
+	f.listed <- struct{}{}
+	return out, err
+}
+
+// fakeFirer marks the stop triggered in the store, as the order service
+// would, and reports every fire on fired.
+type fakeFirer struct {
+	store *fakeStore
+	err   error
+	fired chan order.ClientOrderID
+}
+
+func (f *fakeFirer) FireStop(_ context.Context, id order.ClientOrderID, _ string) (order.Status, error) {
+	if f.err != nil {
+		f.fired <- id
+		return order.StatusUntriggered, f.err
+	}
+	f.store.mu.Lock()
+	for i, s := range f.store.stops {
+		if s.ClientOrderID == id {
+			f.store.stops[i].Status = order.StatusTriggered
+		}
+	}
+	f.store.mu.Unlock()
+	f.fired <- id
+	return order.StatusTriggered, nil
+}
+
+type harness struct {
+	store   *fakeStore
+	firer   *fakeFirer
+	bus     *bus.InProc
+	metrics *Metrics
+	done    chan error
+}
+
+func start(t *testing.T, store *fakeStore, firerErr error) *harness {
+	t.Helper()
+	m, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.listed = make(chan struct{}, 16)
+	h := &harness{
+		store: store, firer: &fakeFirer{store: store, err: firerErr, fired: make(chan order.ClientOrderID, 16)},
+		bus: bus.NewInProc(), metrics: m, done: make(chan error, 1),
+	}
+	t.Cleanup(h.bus.Close)
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(func() {
+		cancel()
+		<-h.done
+	})
+	svc := New(store, h.firer, h.bus, log.Nop(), m)
+	go func() { h.done <- svc.Run(ctx) }()
+	h.waitListed(t)
+	return h
+}
+
+func (h *harness) waitListed(t *testing.T) {
+	t.Helper()
+	select {
+	case <-h.store.listed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("engine did not list stops")
+	}
+}
+
+// publish sends a ticker and returns once the engine has acted on it: a
+// ticker from a venue without stops follows it, and tickers are handled in
+// order, so that one being listed means the first was fully checked.
+func (h *harness) publish(t *testing.T, inst instrument.Instrument, last int64) {
+	t.Helper()
+	idle := spot("BTC", "USDT")
+	idle.Venue = "idle"
+	for _, tk := range []marketdata.Ticker{
+		{Instrument: inst, Last: decimal.NewFromInt(last)},
+		{Instrument: idle, Last: decimal.NewFromInt(1)},
+	} {
+		if err := h.bus.Publish(t.Context(), bus.Event{Subject: events.SubjectTickerUpdated, Payload: tk}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h.waitListed(t)
+	h.waitListed(t)
+}
+
+func (h *harness) fired() []order.ClientOrderID {
+	var ids []order.ClientOrderID
+	for {
+		select {
+		case id := <-h.firer.fired:
+			ids = append(ids, id)
+		default:
+			return ids
+		}
+	}
+}
+
+func TestFiresCrossedStops(t *testing.T) {
+	t.Parallel()
+	btc, eth := spot("BTC", "USDT"), spot("ETH", "USDT")
+	store := &fakeStore{stops: []order.Record{
+		stop("sell-btc", btc, order.Sell, 49000),
+		stop("buy-btc", btc, order.Buy, 51000),
+		stop("sell-eth", eth, order.Sell, 2500),
+	}}
+	h := start(t, store, nil)
+
+	h.publish(t, btc, 50000)
+	if ids := h.fired(); len(ids) != 0 {
+		t.Fatalf("fired between the triggers: %v", ids)
+	}
+	h.publish(t, btc, 48900)
+	if ids := h.fired(); len(ids) != 1 || ids[0] != "sell-btc" {
+		t.Fatalf("fired = %v, want sell-btc", ids)
+	}
+	h.publish(t, btc, 48000)
+	if ids := h.fired(); len(ids) != 0 {
+		t.Fatalf("a triggered stop fired again: %v", ids)
+	}
+	h.publish(t, btc, 51000)
+	if ids := h.fired(); len(ids) != 1 || ids[0] != "buy-btc" {
+		t.Fatalf("fired = %v, want buy-btc", ids)
+	}
+	if got := testutil.ToFloat64(h.metrics.triggers.WithLabelValues("bybit", outcomeTriggered)); got != 2 {
+		t.Fatalf("triggered = %v, want 2", got)
+	}
+}
+
+func TestResumeSettlesStopsFiredBeforeARestart(t *testing.T) {
+	t.Parallel()
+	btc := spot("BTC", "USDT")
+	store := &fakeStore{
+		stops:    []order.Record{stop("placed", btc, order.Sell, 49000), stop("armed", btc, order.Sell, 48000)},
+		children: map[order.ClientOrderID]bool{"placed-child": true},
+	}
+	h := start(t, store, nil)
+	select {
+	case id := <-h.firer.fired:
+		if id != "placed" {
+			t.Fatalf("resumed %s, want placed", id)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("stop with a stored child was not resumed")
+	}
+	if ids := h.fired(); len(ids) != 0 {
+		t.Fatalf("resume fired an armed stop: %v", ids)
+	}
+}
+
+func TestFireFailureKeepsRunning(t *testing.T) {
+	t.Parallel()
+	btc := spot("BTC", "USDT")
+	store := &fakeStore{stops: []order.Record{stop("sell-btc", btc, order.Sell, 49000)}}
+	h := start(t, store, errors.New("venue down"))
+
+	h.publish(t, btc, 48000)
+	h.publish(t, btc, 47000)
+	if ids := h.fired(); len(ids) != 2 {
+		t.Fatalf("fired = %v, want one attempt per crossing ticker", ids)
+	}
+	if got := testutil.ToFloat64(h.metrics.triggers.WithLabelValues("bybit", outcomeFailed)); got != 2 {
+		t.Fatalf("failed = %v, want 2", got)
+	}
+}
+
+func TestStoreFailureStopsTheEngine(t *testing.T) {
+	t.Parallel()
+	m, err := NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := bus.NewInProc()
+	t.Cleanup(b.Close)
+	store := &fakeStore{listErr: errors.New("connection refused"), listed: make(chan struct{}, 1)}
+	svc := New(store, &fakeFirer{store: store}, b, log.Nop(), m)
+	if err := svc.Run(t.Context()); err == nil {
+		t.Fatal("Run = nil, want the store error")
+	}
+}
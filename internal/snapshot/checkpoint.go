@@ -18,6 +18,10 @@ type Checkpoint struct {
 	BalanceCount int
 	Status       Status
 	Error        string
+	// Balances are the snapshot's balances. Recording a successful
+	// checkpoint keeps them as the account's latest, the ones placement
+	// reserves funds against; reading one back leaves them empty.
+	Balances []account.Balance
 }
 
 // Status classifies a snapshot attempt.